@@ -0,0 +1,706 @@
+// Package app wires the whole service together so that it can be embedded in other
+// Go programs and started and stopped in integration tests
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"leeta/internal/adapter/analytics"
+	"leeta/internal/adapter/config"
+	"leeta/internal/adapter/geocoding"
+	grpcHandler "leeta/internal/adapter/handler/grpc"
+	httpHandler "leeta/internal/adapter/handler/http"
+	"leeta/internal/adapter/locationsource"
+	"leeta/internal/adapter/logger"
+	"leeta/internal/adapter/notifier"
+	"leeta/internal/adapter/objectstorage"
+	"leeta/internal/adapter/publisher"
+	"leeta/internal/adapter/routing"
+	"leeta/internal/adapter/scheduler"
+	"leeta/internal/adapter/search"
+	"leeta/internal/adapter/storage/breaker"
+	breakerRepository "leeta/internal/adapter/storage/breaker/repository"
+	"leeta/internal/adapter/storage/instrument"
+	instrumentRepository "leeta/internal/adapter/storage/instrument/repository"
+	"leeta/internal/adapter/storage/postgres"
+	"leeta/internal/adapter/storage/postgres/repository"
+	"leeta/internal/adapter/storage/redis"
+	cacheRepository "leeta/internal/adapter/storage/redis/repository"
+	"leeta/internal/adapter/subscriber"
+	"leeta/internal/adapter/validation"
+	"leeta/internal/core/auth"
+	"leeta/internal/core/domain"
+	"leeta/internal/core/port"
+	"leeta/internal/core/service"
+
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+)
+
+// defaultShutdownTimeout is how long Run waits for the in-flight work when it stops
+const defaultShutdownTimeout = 30 * time.Second
+
+// defaultCheckTimeout bounds each reachability check of the startup self-check when the health checks set none
+const defaultCheckTimeout = 2 * time.Second
+
+// defaultExportRetain is the number of snapshots of each format kept when the exports set none
+const defaultExportRetain = 7
+
+// defaultSyncMaxRemoved is the share of the locations of a source a sync may remove when the source sets none
+const defaultSyncMaxRemoved = 0.5
+
+// defaultShareTTL and defaultShareMaxTTL are the lifetime of the share links and its bound when the shares set none
+const (
+	defaultShareTTL    = 24 * time.Hour
+	defaultShareMaxTTL = 30 * 24 * time.Hour
+)
+
+// Config is the configuration of the service, see config-sample.yml
+type Config = config.Configuration
+
+// worker is a background task that runs until its context is done
+type worker struct {
+	name string
+	run  func(ctx context.Context)
+}
+
+/**
+ * App is the whole service: the connections, the background
+ * workers and the HTTP server. It is created with New and
+ * serves until the context given to Run is done
+ */
+type App struct {
+	config *Config
+	logger *zap.Logger
+
+	db            *postgres.DB
+	cache         *redis.Cache
+	router        *httpHandler.Router
+	server        *httpHandler.Server
+	grpcServer    *grpcHandler.Server
+	kafka         *publisher.Kafka
+	nats          *publisher.NATS
+	importService *service.ImportService
+	queue         *postgres.Queue
+	listeners     []listener
+	workers       []worker
+}
+
+// New checks the configuration and the dependencies, connects to the database and redis, migrates the
+// database and wires the service. A failed self-check is reported as a *SelfCheckError listing every
+// failure. Nothing is served until Run is called
+func New(cfg *Config) (*App, error) {
+	// the logger reads the app settings of the global configuration
+	if config.GetConfig() == nil {
+		config.Config = cfg
+	}
+	l := logger.Get()
+
+	l.Info("Starting the application",
+		zap.String("app", cfg.App.Name),
+		zap.String("env", cfg.App.Env))
+
+	ctx := context.Background()
+	app := &App{
+		config: cfg,
+		logger: l,
+	}
+
+	// Self-check, every check runs so that the failures are reported together
+	check := &selfCheck{logger: l, strict: cfg.Health.StrictStartup}
+	check.add("config", true, checkConfig(cfg))
+
+	db, err := postgres.New(ctx, &cfg.Database)
+	if err == nil {
+		app.db = db
+		check.add("postgres", true, nil)
+		check.add("migrations", true, checkMigrations(db))
+	} else {
+		check.add("postgres", true, err)
+	}
+
+	if cfg.Redis.Enabled {
+		cache, err := redis.New(ctx, &cfg.Redis)
+		if err == nil {
+			app.cache = cache
+		}
+		check.add("redis", true, err)
+	}
+
+	checkTimeout := cfg.Health.Timeout
+	if checkTimeout <= 0 {
+		checkTimeout = defaultCheckTimeout
+	}
+	checkAdapters(ctx, cfg, checkTimeout, check)
+
+	if err := check.err(); err != nil {
+		app.Close()
+		return nil, err
+	}
+
+	l.Info("Successfully connected to the database",
+		zap.String("db", cfg.Database.Protocol),
+		zap.Int("replicas", len(cfg.Database.Replicas)))
+
+	poolConfig := db.Config()
+	l.Info("Database pool configuration",
+		zap.Int32("max_conns", poolConfig.MaxConns),
+		zap.Int32("min_conns", poolConfig.MinConns),
+		zap.Duration("max_conn_lifetime", poolConfig.MaxConnLifetime),
+		zap.Duration("max_conn_idle_time", poolConfig.MaxConnIdleTime),
+		zap.Duration("health_check_period", poolConfig.HealthCheckPeriod),
+		zap.Duration("connect_timeout", poolConfig.ConnConfig.ConnectTimeout))
+
+	// Migrate postgres database
+	if err := db.Migrate(); err != nil {
+		app.Close()
+		return nil, fmt.Errorf("migrating database: %w", err)
+	}
+
+	l.Info("Successfully migrated the database")
+
+	// row level security is enabled or disabled after the migrations, which add its policies
+	if err := db.ApplyTenancy(ctx); err != nil {
+		app.Close()
+		return nil, fmt.Errorf("applying database tenancy: %w", err)
+	}
+
+	if app.cache != nil {
+		l.Info("Successfully connected to redis", zap.String("addr", cfg.Redis.Addr))
+	}
+
+	if err := app.wire(); err != nil {
+		app.Close()
+		return nil, err
+	}
+
+	return app, nil
+}
+
+// wire creates the repositories, services, handlers, workers and the HTTP server
+func (a *App) wire() error {
+	cfg, db, cache := a.config, a.db, a.cache
+
+	// Health, the dependencies are registered as they are set up
+	healthService := service.NewHealthService(cfg.Health.Timeout)
+	healthService.Register("postgres", true, db.Ping)
+	if cache != nil {
+		healthService.Register("redis", false, cache.Ping)
+	}
+
+	// Leader election, the singleton workers only run on the leader
+	elector := postgres.NewElector(db, &cfg.LeaderElection)
+	healthService.Register("leader_election", false, elector.Check)
+	healthService.ReportLeadership(elector.IsLeader)
+
+	// Notifications, delivered in the background
+	notifications, err := notifier.New(&cfg.Notifications)
+	if err != nil {
+		return fmt.Errorf("initializing notifications: %w", err)
+	}
+	a.workers = append(a.workers, worker{"notifier", notifications.Run})
+
+	// the requests, and the locations read by the sync sources, are checked by the same validator
+	vld := validation.New(&cfg.Names)
+
+	// Ping
+	pingRepo := repository.NewPingRepository(db)
+	pingService := service.NewPingService(pingRepo)
+	pingHandler := httpHandler.NewPingHandler(pingService, healthService, vld)
+
+	// Device
+	deviceRepo := repository.NewDeviceRepository(db)
+	deviceService := service.NewDeviceService(deviceRepo)
+	deviceHandler := httpHandler.NewDeviceHandler(deviceService, vld)
+
+	// Quota, the tenants without a quota of their own have the configured one
+	quotaRepo := repository.NewQuotaRepository(db)
+	quotaService := service.NewQuotaService(quotaRepo, cfg.Quotas.MaxLocations, cfg.Quotas.MaxWebhooks)
+	quotaHandler := httpHandler.NewQuotaHandler(quotaService, vld)
+
+	// Subscription, matches are posted to the webhooks and sent to the WebSocket clients of every instance
+	matchFeed := postgres.NewMatchFeed(db)
+	subscriptionRepo := repository.NewSubscriptionRepository(db)
+	subscriptionService := service.NewSubscriptionService(subscriptionRepo, quotaService, analytics.NewWebhookDelivery(publisher.NewSubscriptionWebhook(&cfg.Subscriptions)), matchFeed)
+	subscriptionHandler := httpHandler.NewSubscriptionHandler(subscriptionService, vld)
+	matchFeed.Subscribe(subscriptionHandler.OnMatch)
+	a.workers = append(a.workers, worker{"subscriptions", subscriptionService.Run}, worker{"match_feed", matchFeed.Run})
+
+	// Entity, the live positions of couriers and vehicles
+	entityRepo := repository.NewEntityRepository(db)
+	entityService := service.NewEntityService(entityRepo, cfg.Entities.StaleAfter, subscriptionService)
+	entityHandler := httpHandler.NewEntityHandler(entityService, vld)
+
+	// Device positions published to MQTT are recorded as heartbeats
+	if cfg.MQTT.Broker != "" {
+		mqttSubscriber, err := subscriber.NewMQTT(&cfg.MQTT, deviceService, vld)
+		if err != nil {
+			return fmt.Errorf("initializing MQTT subscriber: %w", err)
+		}
+		a.workers = append(a.workers, worker{"mqtt_subscriber", mqttSubscriber.Run})
+		healthService.Register("mqtt", false, mqttSubscriber.Ping)
+	}
+
+	// Durable jobs, such as imports, run on the job queue
+	jobRepo := repository.NewJobRepository(db)
+	jobService := service.NewJobService(jobRepo)
+	jobHandler := httpHandler.NewJobHandler(jobService, vld)
+	a.queue = postgres.NewQueue(db, &cfg.Jobs)
+	a.workers = append(a.workers, worker{"job_queue", a.queue.Run})
+
+	// Exports run on the worker pool
+	workerPool := service.NewWorkerPool(cfg.Workers.Size, cfg.Workers.QueueSize)
+
+	// Location
+	var locationRepo port.LocationRepository = repository.NewLocationRepository(db)
+	locationRepo = instrumentRepository.NewLocationRepository(locationRepo, instrument.New("location", cfg.Database.SlowQueryThreshold))
+	locationRepo = breakerRepository.NewLocationRepository(locationRepo, breaker.New("database", &cfg.Database.Breaker, notifications))
+	var cacheRepo *cacheRepository.LocationRepository
+	if cache != nil {
+		cacheRepo = cacheRepository.NewLocationRepository(locationRepo, cache)
+		locationRepo = cacheRepo
+	}
+	var formula domain.DistanceFormula
+	if cfg.Nearest.Formula != "" {
+		var ok bool
+		if formula, ok = domain.ParseDistanceFormula(cfg.Nearest.Formula); !ok {
+			return fmt.Errorf("invalid nearest distance formula %q", cfg.Nearest.Formula)
+		}
+	}
+	var duplicates domain.DuplicatePolicy
+	if cfg.Duplicates.Policy != "" {
+		var ok bool
+		if duplicates, ok = domain.ParseDuplicatePolicy(cfg.Duplicates.Policy); !ok {
+			return fmt.Errorf("invalid duplicates policy %q", cfg.Duplicates.Policy)
+		}
+	}
+	var travelTimes port.Routing
+	if cfg.Routing.URL != "" {
+		osrm, err := routing.New(&cfg.Routing)
+		if err != nil {
+			return fmt.Errorf("initializing routing: %w", err)
+		}
+		travelTimes = osrm
+	}
+	// Geocode, address suggestions are only available with a geocoding service
+	var geocoder port.Geocoder
+	if cfg.Geocoding.URL != "" {
+		geocoder = geocoding.New(&cfg.Geocoding)
+	}
+	geocodeService := service.NewGeocodeService(geocoder, cfg.Geocoding.CacheSize, cfg.Geocoding.CacheTTL)
+	geocodeHandler := httpHandler.NewGeocodeHandler(geocodeService, vld)
+
+	// Popularity, the hits of every instance are counted in memory and written in batches
+	popularityRepo := repository.NewPopularityRepository(db)
+	popularityService := service.NewPopularityService(popularityRepo, cfg.Popularity.FlushInterval, cfg.Popularity.Retention)
+	popularityHandler := httpHandler.NewPopularityHandler(popularityService, vld)
+	a.workers = append(a.workers, worker{"popularity", popularityService.Run})
+
+	locationService := service.NewLocationService(locationRepo, cfg.LocalCache.Size, cfg.LocalCache.TTL, workerPool, notifications, formula, travelTimes, popularityService, quotaService, duplicates)
+	// the cost guard only bounds the requests of the clients, the snapshots and reindexing export every location
+	costLimits := make(map[string]domain.CostLimits, len(cfg.CostGuard.Tenants))
+	for tenant, name := range cfg.CostGuard.Tenants {
+		plan, ok := cfg.CostGuard.Plans[strings.ToLower(name)]
+		if !ok {
+			return fmt.Errorf("tenant %s is on cost guard plan %q, which is not configured", tenant, name)
+		}
+		costLimits[tenant] = domain.CostLimits{MaxRadius: plan.MaxRadius, MaxExportLocations: plan.MaxExportLocations}
+	}
+	costGuard := service.NewCostGuard(locationService, quotaRepo, domain.CostLimits{
+		MaxRadius:          cfg.CostGuard.MaxRadius,
+		MaxExportLocations: cfg.CostGuard.MaxExportLocations,
+	}, costLimits)
+	locationHandler := httpHandler.NewLocationHandler(analytics.NewLocationService(costGuard), vld)
+
+	// Geofence
+	geofenceRepo := repository.NewGeofenceRepository(db)
+	geofenceService := service.NewGeofenceService(geofenceRepo)
+	geofenceHandler := httpHandler.NewGeofenceHandler(geofenceService, vld)
+
+	// Collection
+	collectionRepo := repository.NewCollectionRepository(db)
+	collectionService := service.NewCollectionService(collectionRepo)
+	collectionHandler := httpHandler.NewCollectionHandler(collectionService, vld)
+
+	// Location change feed
+	changeListener := postgres.NewChangeListener(db)
+	changeListener.Subscribe(locationService.OnLocationChange)
+	if cacheRepo != nil {
+		changeListener.Subscribe(cacheRepo.OnLocationChange)
+	}
+	a.workers = append(a.workers, worker{"change_listener", changeListener.Run})
+
+	// Import
+	txManager := postgres.NewTxManager(db)
+	a.importService = service.NewImportService(locationRepo, txManager, jobRepo, notifications, quotaService)
+	importHandler := httpHandler.NewImportHandler(analytics.NewImportService(a.importService), vld)
+	a.queue.Register(domain.JobKindImportLocations, a.importService.RunImportJob)
+
+	// Sync, every source is synced by its own recurring job
+	syncService := service.NewSyncService(repository.NewSyncRepository(db), locationRepo, notifications)
+	syncHandler := httpHandler.NewSyncHandler(syncService, vld)
+	var syncJobs []scheduler.Job
+	for i := range cfg.Sync.Sources {
+		sourceConfig := &cfg.Sync.Sources[i]
+		if sourceConfig.Name == "" || slices.ContainsFunc(cfg.Sync.Sources[:i], func(s config.SyncSourceConfiguration) bool {
+			return s.Name == sourceConfig.Name
+		}) {
+			return fmt.Errorf("sync source %d needs a name of its own", i+1)
+		}
+		source, err := locationsource.New(sourceConfig, vld)
+		if err != nil {
+			return fmt.Errorf("initializing sync source: %w", err)
+		}
+
+		maxRemoved := sourceConfig.MaxRemoved
+		if maxRemoved <= 0 {
+			maxRemoved = defaultSyncMaxRemoved
+		}
+		syncService.Register(sourceConfig.Name, source, sourceConfig.DryRun, maxRemoved)
+
+		schedule := sourceConfig.Schedule
+		if schedule == "" {
+			schedule = "@daily"
+		}
+		name := sourceConfig.Name
+		syncJobs = append(syncJobs, scheduler.Job{Name: "sync_" + name, Schedule: schedule, Run: func(ctx context.Context) error {
+			report, cerr := syncService.Sync(ctx, name)
+			if cerr != nil {
+				return cerr
+			}
+			if report.Status == domain.SyncFailed {
+				return errors.New(report.Error)
+			}
+			return nil
+		}})
+	}
+
+	// Exports, snapshots of every location are written to the object storage by a recurring job
+	var storage port.ObjectStorage
+	if cfg.Exports.Storage.Bucket != "" {
+		s3, err := objectstorage.New(&cfg.Exports.Storage)
+		if err != nil {
+			return fmt.Errorf("initializing object storage: %w", err)
+		}
+		storage = s3
+	}
+	exportFormats := []domain.ExportFormat{domain.ExportGeoJSON, domain.ExportCSV}
+	if len(cfg.Exports.Formats) > 0 {
+		exportFormats = exportFormats[:0]
+		for _, name := range cfg.Exports.Formats {
+			format, ok := domain.ParseExportFormat(name)
+			if !ok {
+				return fmt.Errorf("invalid export format %q, expected geojson or csv", name)
+			}
+			exportFormats = append(exportFormats, format)
+		}
+	}
+	exportRetain := cfg.Exports.Retain
+	if exportRetain <= 0 {
+		exportRetain = defaultExportRetain
+	}
+	exportService := service.NewExportService(locationService, repository.NewRestoreRepository(db), storage, cfg.Exports.Prefix, exportFormats, exportRetain)
+	exportHandler := httpHandler.NewExportHandler(exportService, vld)
+
+	// Search, the index is fed by the outbox relay and searches are only available with a search engine
+	var searchIndex port.SearchIndex
+	if cfg.Search.URL != "" {
+		meilisearch := search.New(&cfg.Search)
+		searchIndex = meilisearch
+		healthService.Register("search", false, meilisearch.Ping)
+	}
+	searchService := service.NewSearchService(searchIndex, locationService)
+	searchHandler := httpHandler.NewSearchHandler(searchService, vld)
+
+	// Share, the links are only available with a signing secret
+	shareTTL, shareMaxTTL := cfg.Shares.TTL, cfg.Shares.MaxTTL
+	if shareTTL <= 0 {
+		shareTTL = defaultShareTTL
+	}
+	if shareMaxTTL <= 0 {
+		shareMaxTTL = defaultShareMaxTTL
+	}
+	shareService := service.NewShareService(repository.NewShareRepository(db), locationRepo, cfg.Shares.Secret, cfg.Shares.BaseURL, shareTTL, shareMaxTTL)
+	shareHandler := httpHandler.NewShareHandler(shareService, vld)
+
+	// Tile
+	tileService := service.NewTileService(repository.NewTileRepository(db))
+	tileHandler := httpHandler.NewTileHandler(tileService, vld)
+
+	// Admin
+	adminHandler := httpHandler.NewAdminHandler(vld)
+
+	// Outbox relay, created locations are matched against the proximity subscriptions and the search index is
+	// kept up to date as they are published
+	publishers := []port.EventPublisher{publisher.NewWebhook(&cfg.Outbox), subscriptionService}
+	if len(cfg.Outbox.Kafka.Brokers) > 0 {
+		a.kafka = publisher.NewKafka(&cfg.Outbox.Kafka)
+		publishers = append(publishers, a.kafka)
+		healthService.Register("kafka", false, a.kafka.Ping)
+	}
+	if cfg.Outbox.NATS.URL != "" {
+		nats, err := publisher.NewNATS(context.Background(), &cfg.Outbox.NATS)
+		if err != nil {
+			return fmt.Errorf("initializing NATS connection: %w", err)
+		}
+		a.nats = nats
+		publishers = append(publishers, nats)
+		healthService.Register("nats", false, nats.Ping)
+	}
+	if searchIndex != nil {
+		publishers = append(publishers, searchService)
+	}
+	relay := postgres.NewRelay(db, publisher.NewFanout(publishers...), &cfg.Outbox)
+	elector.Add("outbox_relay", func(ctx context.Context) {
+		relay.Run(auth.WithAllTenants(ctx))
+	})
+	healthService.Register("outbox", false, relay.Check)
+
+	// Recurring jobs
+	partitioner := postgres.NewPartitioner(db, &cfg.Partitions)
+	partitionSchedule := "@hourly"
+	if cfg.Partitions.Interval > 0 {
+		partitionSchedule = "@every " + cfg.Partitions.Interval.String()
+	}
+	jobs := []scheduler.Job{
+		// the partitions of the current month must exist before rows are written to them
+		{Name: "partitions", Schedule: partitionSchedule, RunOnStart: true, Run: partitioner.MaintainAll},
+		{Name: "location_hits", Schedule: "@daily", Run: popularityService.PurgeHits},
+		{Name: "entity_positions", Schedule: "@every 1m", Run: entityService.ExpirePositions},
+		{Name: "location_expiry", Schedule: "@every 1m", Run: forAllTenants(locationService.ExpireLocations)},
+	}
+	jobs = append(jobs, syncJobs...)
+	if storage != nil {
+		exportSchedule := cfg.Exports.Schedule
+		if exportSchedule == "" {
+			exportSchedule = "@daily"
+		}
+		jobs = append(jobs, scheduler.Job{Name: "exports", Schedule: exportSchedule, Run: forAllTenants(exportService.Export)})
+	}
+	if searchIndex != nil {
+		reindexSchedule := cfg.Search.Schedule
+		if reindexSchedule == "" {
+			reindexSchedule = "@daily"
+		}
+		// the first run fills a new index with the locations written before it was configured
+		jobs = append(jobs, scheduler.Job{Name: "search_reindex", Schedule: reindexSchedule, RunOnStart: true, Run: forAllTenants(searchService.Reindex)})
+	}
+	if cache != nil {
+		jobs = append(jobs, scheduler.Job{Name: "cache_warmer", Schedule: "@every 5m", Run: func(ctx context.Context) error {
+			// the unfiltered list is the most requested read, it is cached again after every write
+			if _, cerr := locationService.ListLocations(ctx, &domain.ListLocationsRequest{}); cerr != nil {
+				return cerr
+			}
+			return nil
+		}})
+	}
+	jobScheduler, err := scheduler.New(&cfg.Scheduler, jobs...)
+	if err != nil {
+		return fmt.Errorf("initializing scheduler: %w", err)
+	}
+	elector.Add("scheduler", jobScheduler.Run)
+	a.workers = append(a.workers, worker{"leader_election", elector.Run})
+
+	// Init router
+	groups, err := httpHandler.NewRouteGroups(&cfg.Server)
+	if err != nil {
+		return fmt.Errorf("initializing router: %w", err)
+	}
+
+	router, err := httpHandler.NewRouter(&cfg.Server, a.logger, notifications, groups, *pingHandler, *locationHandler, *importHandler, *adminHandler, *deviceHandler, *jobHandler, *geofenceHandler, *popularityHandler, *collectionHandler, *entityHandler, *subscriptionHandler, *geocodeHandler, *syncHandler, *exportHandler, *searchHandler, *quotaHandler, *shareHandler, *tileHandler)
+	if err != nil {
+		return fmt.Errorf("initializing router: %w", err)
+	}
+	a.router = router
+
+	// Init server
+	server, err := httpHandler.NewServer(&cfg.Server, router, router.Admin())
+	if err != nil {
+		return fmt.Errorf("initializing the HTTP server: %w", err)
+	}
+	a.server = server
+	a.listeners = append(a.listeners, listener{"http", server.ListenAndServe, server.Shutdown})
+
+	// gRPC server, it shares the lifecycle of the HTTP server
+	if cfg.Server.GrpcPort != "" {
+		grpcServer, err := grpcHandler.NewServer(&cfg.Server, healthService)
+		if err != nil {
+			return fmt.Errorf("initializing the gRPC server: %w", err)
+		}
+		a.grpcServer = grpcServer
+		a.listeners = append(a.listeners, listener{"grpc", grpcServer.ListenAndServe, grpcServer.Shutdown})
+	}
+
+	return nil
+}
+
+// forAllTenants returns job acting for every tenant, for the recurring jobs maintaining the locations of all of
+// them. The syncs are not, they manage the shared locations only, which the callers without a tenant see
+func forAllTenants(job func(ctx context.Context) error) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		return job(auth.WithAllTenants(ctx))
+	}
+}
+
+// Handler returns the HTTP handler of the service, e.g. to serve it with httptest
+func (a *App) Handler() http.Handler {
+	return a.router
+}
+
+// Run starts the background workers and serves HTTP until ctx is done or a listener or worker fails,
+// then drains the in-flight requests and the running jobs, stops the workers and closes the connections.
+// It returns the error of the first failure, an App cannot be run again
+func (a *App) Run(ctx context.Context) error {
+	defer a.Close()
+
+	// Every listener and worker runs in the group, the first one failing shuts the others down
+	group, groupCtx := errgroup.WithContext(ctx)
+
+	// Background workers run until shutdown, which happens after groupCtx is done
+	workerCtx, stopWorkers := context.WithCancel(context.WithoutCancel(ctx))
+	for _, w := range a.workers {
+		group.Go(func() error {
+			return a.runWorker(workerCtx, w)
+		})
+	}
+
+	a.logger.Info("Starting the HTTP server",
+		zap.String("listen_address", a.server.Addr),
+		zap.String("admin_listen_address", a.server.AdminAddr()),
+		zap.Bool("tls", a.server.TLSConfig != nil),
+		zap.String("redirect_port", a.config.Server.TLS.RedirectPort))
+
+	if a.grpcServer != nil {
+		a.logger.Info("Starting the gRPC server", zap.String("listen_address", a.grpcServer.Addr))
+	}
+
+	for _, l := range a.listeners {
+		group.Go(func() error {
+			return a.runListener(l)
+		})
+	}
+
+	// Graceful shutdown: stop accepting requests and drain the in-flight ones and
+	// the running jobs, then stop the workers before the connections are closed
+	group.Go(func() error {
+		<-groupCtx.Done()
+		a.logger.Info("Shutting down")
+
+		shutdownTimeout := a.config.Server.ShutdownTimeout
+		if shutdownTimeout <= 0 {
+			shutdownTimeout = defaultShutdownTimeout
+		}
+		shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), shutdownTimeout)
+		defer cancel()
+
+		for _, l := range a.listeners {
+			if err := l.shutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				a.logger.Error("Error draining the listener", zap.String("listener", l.name), zap.Error(err))
+			}
+		}
+
+		if err := a.queue.Shutdown(shutdownCtx); err != nil {
+			a.logger.Error("Error waiting for running jobs", zap.Error(err))
+		}
+
+		stopWorkers()
+		return nil
+	})
+
+	err := group.Wait()
+	a.logger.Info("Shutdown complete")
+
+	return err
+}
+
+// Import imports the locations and waits for the import to finish, the returned job tells
+// how many locations were imported and whether the import failed. The import runs in the
+// calling goroutine, unless a running instance of the service picks it up first
+func (a *App) Import(ctx context.Context, locations []domain.RegisterLocationRequest) (*domain.ImportJob, error) {
+	job, cerr := a.importService.ImportLocations(ctx, locations)
+	if cerr != nil {
+		return nil, cerr
+	}
+
+	id, err := strconv.ParseInt(job.ID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("parsing the import job id: %w", err)
+	}
+
+	for job.FinishedAt == nil {
+		ran, err := a.queue.RunJob(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("running the import: %w", err)
+		}
+
+		// the job runs elsewhere or waits for its retry
+		if !ran {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(time.Second):
+			}
+		}
+
+		job, cerr = a.importService.GetImportJob(ctx, job.ID)
+		if cerr != nil {
+			return nil, cerr
+		}
+	}
+
+	return job, nil
+}
+
+// WatchConfig reloads the configuration when the config file of store changes and applies the
+// settings that can change while the server runs: the log level, the CORS allowed origins, the
+// rate limits and the cache TTL.
+// The other settings still require a restart
+func (a *App) WatchConfig(store *config.Store) {
+	store.Subscribe(func(config *config.Configuration) {
+		if config.App.LogLevel != "" {
+			if err := logger.SetLevel(config.App.LogLevel); err != nil {
+				a.logger.Error("Error applying the log level", zap.String("level", config.App.LogLevel), zap.Error(err))
+			}
+		}
+
+		a.router.SetAllowedOrigins(config.Server.HttpAllowedOrigins)
+		a.router.SetRateLimits(&config.Server.RateLimits)
+
+		if a.cache != nil {
+			a.cache.SetTTL(config.Redis.TTL)
+		}
+
+		a.logger.Info("Applied the reloaded configuration",
+			zap.String("log_level", config.App.LogLevel),
+			zap.String("allowed_origins", config.Server.HttpAllowedOrigins),
+			zap.Duration("cache_ttl", config.Redis.TTL))
+	})
+
+	store.Watch()
+}
+
+// Close closes the connections, Run closes them when it returns so it is only needed
+// when the App is not run, e.g. after an import
+func (a *App) Close() {
+	if a.kafka != nil {
+		if err := a.kafka.Close(); err != nil {
+			a.logger.Error("Error closing the Kafka publisher", zap.Error(err))
+		}
+	}
+	if a.nats != nil {
+		a.nats.Close()
+	}
+	if a.cache != nil {
+		a.cache.Close()
+	}
+	if a.db != nil {
+		a.db.Close()
+	}
+}
@@ -0,0 +1,61 @@
+package app
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"leeta/internal/adapter/config"
+	"leeta/internal/adapter/storage/postgres/postgrestest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(postgrestest.Run(m))
+}
+
+// testConfig points to the test database and serves on a random port
+func testConfig(t *testing.T) *Config {
+	return &Config{
+		Database: *postgrestest.Config(t),
+		Server: config.ServerConfiguration{
+			HttpUrl:         "127.0.0.1",
+			HttpPort:        "0",
+			ShutdownTimeout: 5 * time.Second,
+		},
+		App: config.AppConfiguration{
+			Name: "leeta",
+			Env:  "test",
+		},
+	}
+}
+
+func TestApp_RunAndStop(t *testing.T) {
+	app, err := New(testConfig(t))
+	require.NoError(t, err, "Failed to create the app")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan error, 1)
+	go func() {
+		stopped <- app.Run(ctx)
+	}()
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/health/", nil)
+	w := httptest.NewRecorder()
+	app.Handler().ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	cancel()
+
+	select {
+	case err := <-stopped:
+		assert.NoError(t, err)
+	case <-time.After(10 * time.Second):
+		t.Fatal("Run did not return after the context was canceled")
+	}
+}
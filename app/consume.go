@@ -0,0 +1,53 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"leeta/internal/adapter/config"
+	"leeta/internal/adapter/logger"
+	"leeta/internal/adapter/storage/redis"
+	cacheRepository "leeta/internal/adapter/storage/redis/repository"
+	"leeta/internal/adapter/subscriber"
+
+	"go.uber.org/zap"
+)
+
+// Consume reads the location events of the NATS stream and drops the cached location reads of
+// Redis as the locations change, until ctx is done. It needs neither the database nor the HTTP
+// server, so it can keep the cache of a deployment in sync from another process. With replay,
+// the stream is read from its first event instead of after the last one consumed
+func Consume(ctx context.Context, cfg *Config, replay bool) error {
+	if config.GetConfig() == nil {
+		config.Config = cfg
+	}
+	l := logger.Get()
+
+	if cfg.Outbox.NATS.URL == "" {
+		return errors.New("no NATS url is configured")
+	}
+	if !cfg.Redis.Enabled {
+		return errors.New("redis is disabled, there is no cache to keep in sync")
+	}
+
+	cache, err := redis.New(ctx, &cfg.Redis)
+	if err != nil {
+		return fmt.Errorf("initializing redis connection: %w", err)
+	}
+	defer cache.Close()
+
+	feed, err := subscriber.NewNATS(ctx, &cfg.Outbox.NATS, replay)
+	if err != nil {
+		return fmt.Errorf("initializing NATS consumer: %w", err)
+	}
+	defer feed.Close()
+
+	// only the change handler of the cache is used, it never reads through
+	cacheRepo := cacheRepository.NewLocationRepository(nil, cache)
+	feed.Subscribe(cacheRepo.OnLocationChange)
+
+	l.Info("Consuming location events", zap.String("url", cfg.Outbox.NATS.URL), zap.Bool("replay", replay))
+
+	return feed.Run(logger.WithCtx(ctx, l.With(zap.String("worker", "nats_consumer"))))
+}
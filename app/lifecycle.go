@@ -0,0 +1,48 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+
+	"leeta/internal/adapter/logger"
+
+	"go.uber.org/zap"
+)
+
+// listener is a server of the service, the HTTP or the gRPC server, serving until it is shut down
+type listener struct {
+	name     string
+	serve    func() error
+	shutdown func(ctx context.Context) error
+}
+
+// runListener serves l until it is shut down, a listener stopping for any other reason fails the group
+func (a *App) runListener(l listener) error {
+	if err := l.serve(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("serving %s: %w", l.name, err)
+	}
+	return nil
+}
+
+// runWorker runs w until ctx is done. A worker panicking or stopping before fails the group,
+// so that the service shuts down rather than running without it
+func (a *App) runWorker(ctx context.Context, w worker) (err error) {
+	log := a.logger.With(zap.String("worker", w.name))
+
+	defer func() {
+		if rvr := recover(); rvr != nil {
+			log.Error("Worker panicked", zap.Any("panic", rvr), zap.ByteString("stack", debug.Stack()))
+			err = fmt.Errorf("worker %s panicked: %v", w.name, rvr)
+		}
+	}()
+
+	w.run(logger.WithCtx(ctx, log))
+
+	if ctx.Err() == nil {
+		return fmt.Errorf("worker %s stopped before the shutdown", w.name)
+	}
+	return nil
+}
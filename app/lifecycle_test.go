@@ -0,0 +1,34 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestApp_RunWorker(t *testing.T) {
+	a := &App{logger: zap.NewNop()}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.NoError(t, a.runWorker(ctx, worker{"relay", func(ctx context.Context) { <-ctx.Done() }}))
+
+	err := a.runWorker(context.Background(), worker{"relay", func(ctx context.Context) {}})
+	assert.EqualError(t, err, "worker relay stopped before the shutdown")
+
+	err = a.runWorker(context.Background(), worker{"relay", func(ctx context.Context) { panic("nil map") }})
+	assert.EqualError(t, err, "worker relay panicked: nil map")
+}
+
+func TestApp_RunListener(t *testing.T) {
+	a := &App{logger: zap.NewNop()}
+
+	assert.NoError(t, a.runListener(listener{name: "http", serve: func() error { return http.ErrServerClosed }}))
+
+	err := a.runListener(listener{name: "http", serve: func() error { return errors.New("address already in use") }})
+	assert.EqualError(t, err, "serving http: address already in use")
+}
@@ -0,0 +1,27 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"fmt"
+
+	"leeta/internal/adapter/locationfile"
+	"leeta/internal/core/domain"
+)
+
+// seedLocations are well known places to try the API with
+//
+//go:embed seed.csv
+var seedLocations []byte
+
+// Seed imports the sample locations. Locations that already exist are skipped,
+// so seeding twice is harmless
+func (a *App) Seed(ctx context.Context) (*domain.ImportJob, error) {
+	locations, err := locationfile.ReadCSV(bytes.NewReader(seedLocations))
+	if err != nil {
+		return nil, fmt.Errorf("reading the seed locations: %w", err)
+	}
+
+	return a.Import(ctx, locations)
+}
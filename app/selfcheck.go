@@ -0,0 +1,234 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"leeta/internal/adapter/storage/postgres"
+	"leeta/internal/adapter/validation"
+	"leeta/internal/core/domain"
+	"leeta/internal/core/slugger"
+
+	"go.uber.org/zap"
+)
+
+// CheckResult is the outcome of a check of the startup self-check
+type CheckResult struct {
+	Name string
+	// Critical checks stop the startup when they fail, the others only when the self-check is strict
+	Critical bool
+	Err      error
+}
+
+/**
+ * SelfCheckError is the consolidated report of a failed startup
+ * self-check. It lists every check that failed rather than the
+ * first one, so a misconfigured deployment is fixed in one go
+ */
+type SelfCheckError struct {
+	Failed []CheckResult
+}
+
+func (e *SelfCheckError) Error() string {
+	failures := make([]string, len(e.Failed))
+	for i, result := range e.Failed {
+		failures[i] = fmt.Sprintf("%s: %v", result.Name, result.Err)
+	}
+	return "startup self-check failed: " + strings.Join(failures, "; ")
+}
+
+// selfCheck collects the results of the startup checks
+type selfCheck struct {
+	logger  *zap.Logger
+	strict  bool
+	results []CheckResult
+}
+
+// add records the result of the check name, err is nil when it passed
+func (s *selfCheck) add(name string, critical bool, err error) {
+	s.results = append(s.results, CheckResult{name, critical, err})
+
+	switch {
+	case err == nil:
+		s.logger.Info("Self-check passed", zap.String("check", name))
+	case critical || s.strict:
+		s.logger.Error("Self-check failed", zap.String("check", name), zap.Error(err))
+	default:
+		s.logger.Warn("Self-check failed, starting without it", zap.String("check", name), zap.Error(err))
+	}
+}
+
+// err returns the report of the failed checks that stop the startup, nil if there is none
+func (s *selfCheck) err() error {
+	var failed []CheckResult
+	for _, result := range s.results {
+		if result.Err != nil && (result.Critical || s.strict) {
+			failed = append(failed, result)
+		}
+	}
+
+	if len(failed) == 0 {
+		return nil
+	}
+	return &SelfCheckError{failed}
+}
+
+// checkConfig reports every required setting left unset or invalid
+func checkConfig(cfg *Config) error {
+	var problems []string
+	required := func(value, name string) {
+		if value == "" {
+			problems = append(problems, name+" is required")
+		}
+	}
+
+	required(cfg.Database.Host, "database.host")
+	required(cfg.Database.Port, "database.port")
+	required(cfg.Database.Name, "database.name")
+	required(cfg.Database.User, "database.user")
+	required(cfg.Server.HttpPort, "server.httpPort")
+	if cfg.Redis.Enabled {
+		required(cfg.Redis.Addr, "redis.addr")
+	}
+	if cfg.Server.Debug.Enabled {
+		required(cfg.Server.Debug.AdminToken, "server.debug.adminToken")
+	}
+	if cfg.Server.Moderation && cfg.Server.AdminToken == "" && cfg.Server.Auth.Secret == "" {
+		problems = append(problems, "server.moderation requires server.adminToken or server.auth.secret")
+	}
+	if cfg.Server.AdminUI && cfg.Server.AdminToken == "" && cfg.Server.Auth.Secret == "" {
+		problems = append(problems, "server.adminUI requires server.adminToken or server.auth.secret")
+	}
+	if cfg.Database.Tenancy != "" && cfg.Database.Tenancy != postgres.TenancyRLS {
+		problems = append(problems, fmt.Sprintf("invalid database.tenancy %q, expected rls", cfg.Database.Tenancy))
+	}
+	if cfg.Database.Tenancy == postgres.TenancyRLS && cfg.Server.Auth.Secret == "" {
+		problems = append(problems, "database.tenancy requires server.auth.secret")
+	}
+	if _, ok := slugger.ParseCollisions(cfg.Database.Slugs.Collisions); !ok {
+		problems = append(problems, fmt.Sprintf("invalid database.slugs.collisions %q, expected none, counter or hash", cfg.Database.Slugs.Collisions))
+	}
+	if cfg.Database.Slugs.MaxLength < 0 || cfg.Database.Slugs.MaxLength > slugger.MaxLength {
+		problems = append(problems, fmt.Sprintf("database.slugs.maxLength must be between 0 and %d", slugger.MaxLength))
+	} else if cfg.Database.Slugs.MaxLength > 0 && cfg.Database.Slugs.MaxLength < slugger.MinLength {
+		problems = append(problems, fmt.Sprintf("database.slugs.maxLength must be at least %d to leave room for the collision suffixes", slugger.MinLength))
+	}
+	if cfg.Names.MinLength < 0 || cfg.Names.MinLength > validation.MaxNameLength ||
+		cfg.Names.MaxLength < 0 || cfg.Names.MaxLength > validation.MaxNameLength {
+		problems = append(problems, fmt.Sprintf("names.minLength and names.maxLength must be between 0 and %d", validation.MaxNameLength))
+	} else if cfg.Names.MaxLength > 0 && cfg.Names.MinLength > cfg.Names.MaxLength {
+		problems = append(problems, "names.minLength must not exceed names.maxLength")
+	}
+	if cfg.Nearest.Formula != "" {
+		if _, ok := domain.ParseDistanceFormula(cfg.Nearest.Formula); !ok {
+			problems = append(problems, fmt.Sprintf("invalid nearest.formula %q", cfg.Nearest.Formula))
+		}
+	}
+	if cfg.CostGuard.MaxRadius < 0 || cfg.CostGuard.MaxExportLocations < 0 {
+		problems = append(problems, "costGuard limits must not be negative")
+	}
+	for tenant, name := range cfg.CostGuard.Tenants {
+		if plan, ok := cfg.CostGuard.Plans[strings.ToLower(name)]; !ok {
+			problems = append(problems, fmt.Sprintf("costGuard.tenants.%s is on plan %q, which costGuard.plans does not list", tenant, name))
+		} else if plan.MaxRadius < 0 || plan.MaxExportLocations < 0 {
+			problems = append(problems, fmt.Sprintf("costGuard.plans.%s limits must not be negative", name))
+		}
+	}
+	if cfg.Duplicates.Policy != "" {
+		if _, ok := domain.ParseDuplicatePolicy(cfg.Duplicates.Policy); !ok {
+			problems = append(problems, fmt.Sprintf("invalid duplicates.policy %q, expected allow, warn or reject", cfg.Duplicates.Policy))
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+	return errors.New(strings.Join(problems, ", "))
+}
+
+// checkMigrations reports a schema the migrations cannot bring up to date
+func checkMigrations(db *postgres.DB) error {
+	status, err := db.MigrationStatus()
+	if err != nil {
+		return err
+	}
+
+	if status.Dirty {
+		return fmt.Errorf("version %d is dirty, a migration failed halfway and the schema must be fixed by hand", status.Version)
+	}
+	if status.Version > status.Latest {
+		return fmt.Errorf("version %d is newer than the latest migration %d, the database was migrated by a newer release",
+			status.Version, status.Latest)
+	}
+	return nil
+}
+
+// checkAdapters checks that the configured external adapters answer, each within timeout.
+// They are optional, the service starts without them unless the self-check is strict
+func checkAdapters(ctx context.Context, cfg *Config, timeout time.Duration, check *selfCheck) {
+	reachable := func(name string, addresses ...string) {
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		// one answering address is enough, like the brokers of a cluster
+		var err error
+		for _, address := range addresses {
+			if err = dial(ctx, address); err == nil {
+				break
+			}
+		}
+		check.add(name, false, err)
+	}
+
+	if cfg.Routing.URL != "" {
+		reachable("routing", urlAddress(cfg.Routing.URL))
+	}
+	if cfg.Geocoding.URL != "" {
+		reachable("geocoding", urlAddress(cfg.Geocoding.URL))
+	}
+	if cfg.MQTT.Broker != "" {
+		reachable("mqtt", urlAddress(cfg.MQTT.Broker))
+	}
+	if len(cfg.Outbox.Kafka.Brokers) > 0 {
+		reachable("kafka", cfg.Outbox.Kafka.Brokers...)
+	}
+}
+
+// urlAddress returns the host and port of rawURL, defaulting the port to the one of its scheme
+func urlAddress(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		// dialing it reports the invalid address
+		return rawURL
+	}
+
+	port := u.Port()
+	if port == "" {
+		switch u.Scheme {
+		case "https":
+			port = "443"
+		case "tcp", "mqtt":
+			port = "1883"
+		case "ssl", "tls", "mqtts":
+			port = "8883"
+		default:
+			port = "80"
+		}
+	}
+	return net.JoinHostPort(u.Hostname(), port)
+}
+
+// dial opens and closes a TCP connection to address
+func dial(ctx context.Context, address string) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
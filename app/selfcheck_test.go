@@ -0,0 +1,147 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"leeta/internal/adapter/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestCheckConfig(t *testing.T) {
+	assert.NoError(t, checkConfig(&Config{
+		Database: config.DatabaseConfiguration{Host: "127.0.0.1", Port: "5432", Name: "leeta", User: "postgres"},
+		Server:   config.ServerConfiguration{HttpPort: "8080"},
+	}))
+
+	err := checkConfig(&Config{
+		Database: config.DatabaseConfiguration{Host: "127.0.0.1", Port: "5432", User: "postgres"},
+		Server:   config.ServerConfiguration{Moderation: true, AdminUI: true},
+		Nearest:  config.NearestConfiguration{Formula: "flat-earth"},
+	})
+	require.Error(t, err)
+	assert.Equal(t, `database.name is required, server.httpPort is required, `+
+		`server.moderation requires server.adminToken or server.auth.secret, `+
+		`server.adminUI requires server.adminToken or server.auth.secret, invalid nearest.formula "flat-earth"`, err.Error())
+
+	err = checkConfig(&Config{
+		Database: config.DatabaseConfiguration{Host: "127.0.0.1", Port: "5432", Name: "leeta", User: "postgres", Tenancy: "rls"},
+		Server:   config.ServerConfiguration{HttpPort: "8080"},
+	})
+	assert.EqualError(t, err, "database.tenancy requires server.auth.secret")
+
+	err = checkConfig(&Config{
+		Database: config.DatabaseConfiguration{Host: "127.0.0.1", Port: "5432", Name: "leeta", User: "postgres", Tenancy: "schema"},
+		Server:   config.ServerConfiguration{HttpPort: "8080"},
+	})
+	assert.EqualError(t, err, `invalid database.tenancy "schema", expected rls`)
+
+	err = checkConfig(&Config{
+		Database: config.DatabaseConfiguration{
+			Host: "127.0.0.1", Port: "5432", Name: "leeta", User: "postgres",
+			Slugs: config.SlugsConfiguration{MaxLength: 8, Collisions: "random"},
+		},
+		Server: config.ServerConfiguration{HttpPort: "8080"},
+	})
+	assert.EqualError(t, err, `invalid database.slugs.collisions "random", expected none, counter or hash, `+
+		`database.slugs.maxLength must be at least 16 to leave room for the collision suffixes`)
+
+	err = checkConfig(&Config{
+		Database: config.DatabaseConfiguration{
+			Host: "127.0.0.1", Port: "5432", Name: "leeta", User: "postgres",
+			Slugs: config.SlugsConfiguration{MaxLength: 300, Collisions: "hash"},
+		},
+		Server: config.ServerConfiguration{HttpPort: "8080"},
+	})
+	assert.EqualError(t, err, "database.slugs.maxLength must be between 0 and 255")
+
+	err = checkConfig(&Config{
+		Database: config.DatabaseConfiguration{Host: "127.0.0.1", Port: "5432", Name: "leeta", User: "postgres"},
+		Server:   config.ServerConfiguration{HttpPort: "8080"},
+		Names:    config.NamesConfiguration{MinLength: 20, MaxLength: 10},
+	})
+	assert.EqualError(t, err, "names.minLength must not exceed names.maxLength")
+
+	err = checkConfig(&Config{
+		Database:   config.DatabaseConfiguration{Host: "127.0.0.1", Port: "5432", Name: "leeta", User: "postgres"},
+		Server:     config.ServerConfiguration{HttpPort: "8080"},
+		Duplicates: config.DuplicatesConfiguration{Policy: "merge"},
+	})
+	assert.EqualError(t, err, `invalid duplicates.policy "merge", expected allow, warn or reject`)
+
+	err = checkConfig(&Config{
+		Database: config.DatabaseConfiguration{Host: "127.0.0.1", Port: "5432", Name: "leeta", User: "postgres"},
+		Server:   config.ServerConfiguration{HttpPort: "8080"},
+		CostGuard: config.CostGuardConfiguration{
+			MaxRadius: 50000,
+			Plans:     map[string]config.CostLimitsConfiguration{"enterprise": {}},
+			Tenants:   map[string]string{"acme": "enterprise", "globex": "premium"},
+		},
+	})
+	assert.EqualError(t, err, `costGuard.tenants.globex is on plan "premium", which costGuard.plans does not list`)
+}
+
+func TestSelfCheck(t *testing.T) {
+	t.Run("Every critical failure is reported", func(t *testing.T) {
+		check := &selfCheck{logger: zap.NewNop()}
+		check.add("config", true, errors.New("database.name is required"))
+		check.add("postgres", true, errors.New("connection refused"))
+		check.add("migrations", true, nil)
+		check.add("routing", false, errors.New("no such host"))
+
+		var selfCheckErr *SelfCheckError
+		require.ErrorAs(t, check.err(), &selfCheckErr)
+		assert.Len(t, selfCheckErr.Failed, 2)
+		assert.Equal(t, "startup self-check failed: config: database.name is required; postgres: connection refused", selfCheckErr.Error())
+	})
+
+	t.Run("Optional failures only fail strict checks", func(t *testing.T) {
+		check := &selfCheck{logger: zap.NewNop()}
+		check.add("routing", false, errors.New("no such host"))
+		assert.NoError(t, check.err())
+
+		check.strict = true
+		assert.EqualError(t, check.err(), "startup self-check failed: routing: no such host")
+	})
+}
+
+func TestCheckAdapters(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	// a closed port refuses the connections
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	closedAddr := closed.Addr().String()
+	closed.Close()
+
+	cfg := &Config{
+		Routing:   config.RoutingConfiguration{URL: "http://" + listener.Addr().String()},
+		Geocoding: config.GeocodingConfiguration{URL: "http://" + closedAddr},
+		Outbox: config.OutboxConfiguration{Kafka: config.KafkaConfiguration{
+			Brokers: []string{closedAddr, listener.Addr().String()},
+		}},
+	}
+
+	check := &selfCheck{logger: zap.NewNop()}
+	checkAdapters(context.Background(), cfg, time.Second, check)
+
+	require.Len(t, check.results, 3)
+	assert.NoError(t, check.results[0].Err, "routing")
+	assert.Error(t, check.results[1].Err, "geocoding")
+	assert.NoError(t, check.results[2].Err, "kafka")
+	assert.False(t, check.results[1].Critical)
+}
+
+func TestURLAddress(t *testing.T) {
+	assert.Equal(t, "router.project-osrm.org:443", urlAddress("https://router.project-osrm.org"))
+	assert.Equal(t, "127.0.0.1:2322", urlAddress("http://127.0.0.1:2322/api"))
+	assert.Equal(t, "broker:8883", urlAddress("ssl://broker"))
+}
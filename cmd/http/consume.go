@@ -0,0 +1,33 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"leeta/app"
+
+	"github.com/spf13/cobra"
+)
+
+// consumeCommand keeps the Redis cache in sync with the NATS stream until the process is interrupted
+func consumeCommand() *cobra.Command {
+	var replay bool
+
+	cmd := &cobra.Command{
+		Use:   "consume",
+		Short: "Keep the Redis cache in sync with the location events of the NATS stream",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			return app.Consume(ctx, loadConfig().Get(), replay)
+		},
+	}
+
+	cmd.Flags().BoolVar(&replay, "replay", false, "read the stream from its first event, e.g. to rebuild the cache")
+
+	return cmd
+}
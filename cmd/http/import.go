@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"leeta/internal/adapter/locationfile"
+	"leeta/internal/adapter/validation"
+	"leeta/internal/core/domain"
+
+	"github.com/spf13/cobra"
+)
+
+// maxImportErrors is the number of invalid records reported when a file is rejected
+const maxImportErrors = 10
+
+// importCommand imports the locations of a JSON, CSV, GeoJSON or GPX file
+func importCommand() *cobra.Command {
+	var file string
+
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Import locations from a JSON array, a CSV file with a name,latitude,longitude[,category] header, a GeoJSON file of points or the waypoints of a GPX file",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			locations, err := readLocations(file)
+			if err != nil {
+				return err
+			}
+
+			application, _, err := newApp()
+			if err != nil {
+				return err
+			}
+			defer application.Close()
+
+			job, err := application.Import(context.Background(), locations)
+			if err != nil {
+				return err
+			}
+
+			return printImportJob(cmd, job)
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", "", "path of the .json, .csv, .geojson or .gpx file to import")
+	cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+// readLocations reads and validates the locations of file, its extension tells the format
+func readLocations(file string) ([]domain.RegisterLocationRequest, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var locations []domain.RegisterLocationRequest
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".csv":
+		locations, err = locationfile.ReadCSV(f)
+	case ".json":
+		locations, err = locationfile.ReadJSON(f)
+	case ".geojson":
+		locations, err = locationfile.ReadGeoJSON(f)
+	case ".gpx":
+		locations, err = locationfile.ReadGPX(f)
+	default:
+		return nil, errors.New("unsupported file, expected a .json, .csv, .geojson or .gpx file")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", file, err)
+	}
+
+	validate := validation.New(nil)
+	var errs []error
+	for i := range locations {
+		if err := validate.Struct(&locations[i]); err != nil {
+			errs = append(errs, fmt.Errorf("record %d: %w", i+1, err))
+		} else if err := checkLocation(&locations[i]); err != nil {
+			errs = append(errs, fmt.Errorf("record %d: %w", i+1, err))
+		}
+
+		if len(errs) >= maxImportErrors {
+			break
+		}
+	}
+
+	return locations, errors.Join(errs...)
+}
+
+// checkLocation checks what the validator cannot, the rings of the footprint and the order of the validity
+func checkLocation(location *domain.RegisterLocationRequest) error {
+	if location.Footprint != nil {
+		if err := location.Footprint.CheckRings(); err != nil {
+			return err
+		}
+	}
+	return domain.CheckValidity(location.ValidFrom, location.ValidUntil)
+}
+
+// printImportJob prints the outcome of an import and fails if the import did
+func printImportJob(cmd *cobra.Command, job *domain.ImportJob) error {
+	cmd.Printf("Imported %d of %d locations, skipped %d that already exist\n", job.Imported, job.Total, job.Skipped)
+
+	if job.Status == domain.ImportFailed {
+		return errors.New(job.Error)
+	}
+
+	return nil
+}
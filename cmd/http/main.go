@@ -1,20 +1,14 @@
 package main
 
 import (
-	"context"
-	"fmt"
-	"net/http"
 	"os"
 
+	"leeta/app"
 	_ "leeta/docs"
 	"leeta/internal/adapter/config"
-	httpHandler "leeta/internal/adapter/handler/http"
 	"leeta/internal/adapter/logger"
-	"leeta/internal/adapter/storage/postgres"
-	"leeta/internal/adapter/storage/postgres/repository"
-	"leeta/internal/core/service"
 
-	"github.com/go-playground/validator/v10"
+	"github.com/spf13/cobra"
 	"go.uber.org/zap"
 )
 
@@ -30,63 +24,40 @@ import (
 // @BasePath		/v1
 // @schemes		http https
 func main() {
-	// Load environment variables
-	config := config.Setup()
-
-	// Set logger
-	l := logger.Get()
-
-	l.Info("Starting the application",
-		zap.String("app", config.App.Name),
-		zap.String("env", config.App.Env))
-
-	ctx := context.Background()
-
-	// Init database
-	db, err := postgres.New(ctx, &config.Database)
-	if err != nil {
-		l.Error("Error initializing database connection", zap.Error(err))
-		os.Exit(1)
+	root := &cobra.Command{
+		Use:           "leeta",
+		Short:         "Find nearest places to a given location",
+		SilenceUsage:  true,
+		SilenceErrors: true,
 	}
-	defer db.Close()
 
-	l.Info("Successfully connected to the database",
-		zap.String("db", config.Database.Protocol))
+	root.AddCommand(
+		serveCommand(),
+		migrateCommand(),
+		seedCommand(),
+		importCommand(),
+		consumeCommand(),
+	)
 
-	// Migrate postgres database
-	err = db.Migrate()
-	if err != nil {
-		l.Error("Error migrating database", zap.Error(err))
+	if err := root.Execute(); err != nil {
+		logger.Get().Error("Error running the command", zap.Error(err))
 		os.Exit(1)
 	}
+}
 
-	l.Info("Successfully migrated the database")
-
-	// Dependency injection
-	// Ping
-	pingRepo := repository.NewPingRepository(db)
-	pingService := service.NewPingService(pingRepo)
-	pingHandler := httpHandler.NewPingHandler(pingService, validator.New())
+// loadConfig loads the configuration file shared by every command
+func loadConfig() *config.Store {
+	return config.NewStore(config.Setup())
+}
 
-	// Location
-	locationRepo := repository.NewLocationRepository(db)
-	locationService := service.NewLocationService(locationRepo)
-	locationHandler := httpHandler.NewLocationHandler(locationService, validator.New())
+// newApp loads the configuration and wires the service, the caller closes it
+func newApp() (*app.App, *config.Store, error) {
+	store := loadConfig()
 
-	// Init router
-	router, err := httpHandler.NewRouter(&config.Server, l, *pingHandler, *locationHandler)
+	application, err := app.New(store.Get())
 	if err != nil {
-		l.Error("Error initializing router ", zap.Error(err))
-		os.Exit(1)
+		return nil, nil, err
 	}
 
-	// Start server
-	listenAddr := fmt.Sprintf("%s:%s", config.Server.HttpUrl, config.Server.HttpPort)
-	l.Info("Starting the HTTP server", zap.String("listen_address", listenAddr))
-
-	err = http.ListenAndServe(listenAddr, router)
-	if err != nil {
-		l.Error("Error starting the HTTP server", zap.Error(err))
-		os.Exit(1)
-	}
+	return application, store, nil
 }
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"leeta/internal/adapter/logger"
+	"leeta/internal/adapter/storage/postgres"
+
+	"github.com/spf13/cobra"
+)
+
+// migrateCommand applies the pending migrations without starting the service
+func migrateCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "migrate",
+		Short: "Apply the pending database migrations",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config := loadConfig().Get()
+
+			db, err := postgres.New(context.Background(), &config.Database)
+			if err != nil {
+				return fmt.Errorf("initializing database connection: %w", err)
+			}
+			defer db.Close()
+
+			if err := db.Migrate(); err != nil {
+				return fmt.Errorf("migrating database: %w", err)
+			}
+
+			logger.Get().Info("Successfully migrated the database")
+			return nil
+		},
+	}
+}
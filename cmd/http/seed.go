@@ -0,0 +1,30 @@
+package main
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+)
+
+// seedCommand loads the sample locations
+func seedCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "seed",
+		Short: "Load sample locations, the ones that already exist are skipped",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			application, _, err := newApp()
+			if err != nil {
+				return err
+			}
+			defer application.Close()
+
+			job, err := application.Seed(context.Background())
+			if err != nil {
+				return err
+			}
+
+			return printImportJob(cmd, job)
+		},
+	}
+}
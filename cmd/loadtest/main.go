@@ -0,0 +1,260 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"math/rand/v2"
+	"net/http"
+	"os"
+	"os/signal"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/rs/xid"
+	"golang.org/x/time/rate"
+)
+
+// operation is a kind of request fired by the load test
+type operation struct {
+	name   string
+	weight int
+	send   func(client *http.Client, target string) (*http.Response, error)
+}
+
+// operations are the requests the traffic mix can be made of
+var operations = map[string]func(client *http.Client, target string) (*http.Response, error){
+	"register": func(client *http.Client, target string) (*http.Response, error) {
+		body, _ := json.Marshal(map[string]any{
+			"name":      "loadtest " + xid.New().String(),
+			"latitude":  randomLatitude(),
+			"longitude": randomLongitude(),
+			"category":  "loadtest",
+		})
+		return client.Post(target+"/v1/locations/", "application/json", bytes.NewReader(body))
+	},
+	"nearest": func(client *http.Client, target string) (*http.Response, error) {
+		return client.Get(fmt.Sprintf("%s/v1/locations/nearest?lat=%f&lng=%f", target, randomLatitude(), randomLongitude()))
+	},
+	"list": func(client *http.Client, target string) (*http.Response, error) {
+		return client.Get(target + "/v1/locations/?q=category:loadtest")
+	},
+}
+
+// result is the outcome of a request
+type result struct {
+	operation string
+	duration  time.Duration
+	failed    bool
+}
+
+func main() {
+	target := flag.String("target", "http://localhost:8081", "base URL of the server under test")
+	duration := flag.Duration("duration", 30*time.Second, "how long to fire requests")
+	concurrency := flag.Int("concurrency", 20, "number of concurrent clients")
+	rps := flag.Float64("rate", 0, "total requests per second, 0 fires as fast as the clients can")
+	mix := flag.String("mix", "register=1,nearest=8,list=1", "weights of the operations in the traffic")
+	timeout := flag.Duration("timeout", 10*time.Second, "timeout of a request")
+	maxErrorRate := flag.Float64("max-error-rate", 0, "exit with an error above this error rate, e.g. 0.01, 0 disables the check")
+	maxNearestP99 := flag.Duration("max-nearest-p99", 0, "exit with an error if the nearest p99 latency is above this, 0 disables the check")
+	flag.Parse()
+
+	ops, err := parseMix(*mix)
+	if err != nil {
+		log.Fatalf("Invalid mix: %v", err)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+	ctx, cancelDuration := context.WithTimeout(ctx, *duration)
+	defer cancelDuration()
+
+	limiter := rate.NewLimiter(rate.Inf, 0)
+	if *rps > 0 {
+		limiter = rate.NewLimiter(rate.Limit(*rps), 1)
+	}
+
+	client := &http.Client{
+		Timeout: *timeout,
+		Transport: &http.Transport{
+			MaxIdleConns:        *concurrency,
+			MaxIdleConnsPerHost: *concurrency,
+		},
+	}
+
+	log.Printf("Firing %s of traffic at %s with %d clients, mix %s", *duration, *target, *concurrency, *mix)
+
+	results := make(chan result, *concurrency)
+	var clients sync.WaitGroup
+	start := time.Now()
+
+	for i := 0; i < *concurrency; i++ {
+		clients.Add(1)
+		go func() {
+			defer clients.Done()
+
+			for limiter.Wait(ctx) == nil {
+				op := pick(ops)
+
+				sent := time.Now()
+				resp, err := op.send(client, *target)
+				failed := err != nil
+				if err == nil {
+					io.Copy(io.Discard, resp.Body)
+					resp.Body.Close()
+					failed = resp.StatusCode >= 400
+				}
+
+				// requests cut off by the end of the test are not counted
+				if ctx.Err() != nil {
+					return
+				}
+
+				results <- result{op.name, time.Since(sent), failed}
+			}
+		}()
+	}
+
+	go func() {
+		clients.Wait()
+		close(results)
+	}()
+
+	durations := make(map[string][]time.Duration)
+	failures := make(map[string]int)
+	for r := range results {
+		durations[r.operation] = append(durations[r.operation], r.duration)
+		if r.failed {
+			failures[r.operation]++
+		}
+	}
+
+	elapsed := time.Since(start)
+	ok := report(durations, failures, elapsed, *maxErrorRate, *maxNearestP99)
+	if !ok {
+		os.Exit(1)
+	}
+}
+
+// parseMix parses weights such as "register=1,nearest=8,list=1"
+func parseMix(mix string) ([]operation, error) {
+	var ops []operation
+
+	for _, part := range strings.Split(mix, ",") {
+		name, value, found := strings.Cut(strings.TrimSpace(part), "=")
+		if !found {
+			return nil, fmt.Errorf("expected name=weight, got %q", part)
+		}
+
+		send, ok := operations[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown operation %q, expected register, nearest or list", name)
+		}
+
+		weight, err := strconv.Atoi(value)
+		if err != nil || weight < 0 {
+			return nil, fmt.Errorf("invalid weight %q for %s", value, name)
+		}
+
+		if weight > 0 {
+			ops = append(ops, operation{name, weight, send})
+		}
+	}
+
+	if len(ops) == 0 {
+		return nil, fmt.Errorf("every weight is 0")
+	}
+
+	return ops, nil
+}
+
+// pick picks an operation at random according to the weights
+func pick(ops []operation) operation {
+	total := 0
+	for _, op := range ops {
+		total += op.weight
+	}
+
+	n := rand.IntN(total)
+	for _, op := range ops {
+		if n < op.weight {
+			return op
+		}
+		n -= op.weight
+	}
+
+	return ops[len(ops)-1]
+}
+
+// report prints the latency percentiles and error rates of every operation and reports
+// whether they are within the thresholds
+func report(durations map[string][]time.Duration, failures map[string]int, elapsed time.Duration, maxErrorRate float64, maxNearestP99 time.Duration) bool {
+	names := make([]string, 0, len(durations))
+	total, totalFailures := 0, 0
+	for name, d := range durations {
+		names = append(names, name)
+		total += len(d)
+		totalFailures += failures[name]
+	}
+	slices.Sort(names)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "operation\trequests\terrors\terror rate\tp50\tp90\tp99\tmax\t")
+
+	ok := true
+	for _, name := range names {
+		d := durations[name]
+		slices.Sort(d)
+
+		errorRate := float64(failures[name]) / float64(len(d))
+		p99 := percentile(d, 99)
+
+		fmt.Fprintf(w, "%s\t%d\t%d\t%.2f%%\t%s\t%s\t%s\t%s\t\n",
+			name, len(d), failures[name], errorRate*100,
+			percentile(d, 50), percentile(d, 90), p99, d[len(d)-1])
+
+		if name == "nearest" && maxNearestP99 > 0 && p99 > maxNearestP99 {
+			log.Printf("Nearest p99 latency %s is above %s", p99, maxNearestP99)
+			ok = false
+		}
+	}
+	w.Flush()
+
+	if total == 0 {
+		log.Println("No request completed")
+		return false
+	}
+
+	errorRate := float64(totalFailures) / float64(total)
+	fmt.Printf("\n%d requests in %s, %.1f requests/s, %.2f%% errors\n",
+		total, elapsed.Round(time.Millisecond), float64(total)/elapsed.Seconds(), errorRate*100)
+
+	if maxErrorRate > 0 && errorRate > maxErrorRate {
+		log.Printf("Error rate %.2f%% is above %.2f%%", errorRate*100, maxErrorRate*100)
+		ok = false
+	}
+
+	return ok
+}
+
+// percentile returns the p-th percentile of the sorted durations
+func percentile(sorted []time.Duration, p int) time.Duration {
+	i := (len(sorted)*p+99)/100 - 1
+	return sorted[max(i, 0)].Round(10 * time.Microsecond)
+}
+
+func randomLatitude() float64 {
+	return rand.Float64()*170 - 85
+}
+
+func randomLongitude() float64 {
+	return rand.Float64()*360 - 180
+}
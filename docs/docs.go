@@ -19,9 +19,14 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/health": {
+        "/admin/exports": {
             "get": {
-                "description": "check server status",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "list the snapshots of every location written to the object storage by the scheduled exports, most recent first",
                 "consumes": [
                     "application/json"
                 ],
@@ -29,20 +34,45 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Ping"
+                    "Admin"
                 ],
-                "summary": "Check server status",
+                "summary": "List snapshots",
                 "responses": {
                     "200": {
-                        "description": "Ping created",
+                        "description": "Success",
                         "schema": {
-                            "$ref": "#/definitions/http.response"
+                            "$ref": "#/definitions/http.snapshotListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "501": {
+                        "description": "No object storage bucket configured",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "create a new ping object with name",
+            }
+        },
+        "/admin/jobs": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "list the jobs of the job queue, most recent first",
                 "consumes": [
                     "application/json"
                 ],
@@ -50,25 +80,41 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Ping"
+                    "Admin"
                 ],
-                "summary": "Create a new ping object",
+                "summary": "List jobs",
                 "parameters": [
                     {
-                        "description": "Create ping request",
-                        "name": "domain.Ping",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domain.Ping"
-                        }
+                        "type": "string",
+                        "description": "Job kind, e.g. import_locations",
+                        "name": "kind",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "available",
+                            "running",
+                            "completed",
+                            "failed",
+                            "cancelled"
+                        ],
+                        "type": "string",
+                        "description": "Job state",
+                        "name": "state",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of jobs, 20 by default, at most 100",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Ping created",
+                    "200": {
+                        "description": "Success",
                         "schema": {
-                            "$ref": "#/definitions/http.response"
+                            "$ref": "#/definitions/http.jobListResponse"
                         }
                     },
                     "400": {
@@ -77,6 +123,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/http.errorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -86,14 +138,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/locations": {
+        "/admin/jobs/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "list all registered active locations",
+                "description": "get a job of the job queue by its id",
                 "consumes": [
                     "application/json"
                 ],
@@ -101,14 +153,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Location"
+                    "Admin"
+                ],
+                "summary": "Get a job",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Job id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "List all locations",
                 "responses": {
                     "200": {
                         "description": "Success",
                         "schema": {
-                            "$ref": "#/definitions/http.response"
+                            "$ref": "#/definitions/http.jobResponse"
                         }
                     },
                     "400": {
@@ -117,6 +178,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/http.errorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Job not found",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -124,9 +197,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/admin/jobs/{id}/cancel": {
             "post": {
-                "description": "register a new location with all required details",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "cancel a job that is waiting for a worker, running and finished jobs cannot be cancelled",
                 "consumes": [
                     "application/json"
                 ],
@@ -134,25 +214,90 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Location"
+                    "Admin"
                 ],
-                "summary": "Register a new location",
+                "summary": "Cancel a job",
                 "parameters": [
                     {
-                        "description": "Location",
-                        "name": "domain.RegisterLocationRequest",
-                        "in": "body",
-                        "required": true,
+                        "type": "integer",
+                        "description": "Job id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Job cancelled",
                         "schema": {
-                            "$ref": "#/definitions/domain.RegisterLocationRequest"
+                            "$ref": "#/definitions/http.jobResponse"
                         }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Job not found",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Job is not waiting for a worker",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/jobs/{id}/retry": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "run a failed or cancelled job again, with a new set of attempts",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Retry a job",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Job id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Location created successfully",
+                    "200": {
+                        "description": "Job retried",
                         "schema": {
-                            "$ref": "#/definitions/http.response"
+                            "$ref": "#/definitions/http.jobResponse"
                         }
                     },
                     "400": {
@@ -161,8 +306,20 @@ const docTemplate = `{
                             "$ref": "#/definitions/http.errorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Job not found",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
                     "409": {
-                        "description": "Conflict error",
+                        "description": "Job has not failed nor been cancelled",
                         "schema": {
                             "$ref": "#/definitions/http.errorResponse"
                         }
@@ -176,14 +333,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/locations/nearest": {
+        "/admin/locations/pending": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "get the nearest location to the longitude and latitude",
+                "description": "list a page of the submitted locations waiting for review, oldest submission first",
                 "consumes": [
                     "application/json"
                 ],
@@ -191,30 +348,28 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Location"
+                    "Admin"
                 ],
-                "summary": "Get the nearest location to the longitude and latitude",
+                "summary": "List the locations waiting for review",
                 "parameters": [
                     {
-                        "type": "number",
-                        "description": "Latitude",
-                        "name": "lat",
-                        "in": "query",
-                        "required": true
+                        "type": "integer",
+                        "description": "Maximum number of locations, 20 by default, at most 100",
+                        "name": "limit",
+                        "in": "query"
                     },
                     {
-                        "type": "number",
-                        "description": "Longitude",
-                        "name": "lng",
-                        "in": "query",
-                        "required": true
+                        "type": "integer",
+                        "description": "Number of locations to skip",
+                        "name": "offset",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "Success",
                         "schema": {
-                            "$ref": "#/definitions/http.response"
+                            "$ref": "#/definitions/http.locationListResponse"
                         }
                     },
                     "400": {
@@ -223,8 +378,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/http.errorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not found error",
+                    "401": {
+                        "description": "Unauthorized error",
                         "schema": {
                             "$ref": "#/definitions/http.errorResponse"
                         }
@@ -238,14 +393,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/locations/{name}": {
-            "get": {
+        "/admin/locations/{name}/approve": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "fetch a location through name",
+                "description": "approve a location waiting for review, it then appears in the public queries",
                 "consumes": [
                     "application/json"
                 ],
@@ -253,9 +408,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Location"
+                    "Admin"
                 ],
-                "summary": "Get a location by name",
+                "summary": "Approve a location",
                 "parameters": [
                     {
                         "type": "string",
@@ -267,13 +422,25 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Success",
+                        "description": "Location approved",
                         "schema": {
-                            "$ref": "#/definitions/http.response"
+                            "$ref": "#/definitions/http.locationResponse"
                         }
                     },
-                    "400": {
-                        "description": "Validation error",
+                    "401": {
+                        "description": "Unauthorized error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Location is not pending review",
                         "schema": {
                             "$ref": "#/definitions/http.errorResponse"
                         }
@@ -285,14 +452,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/admin/locations/{name}/reject": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "delete a location through name",
+                "description": "reject a location waiting for review with a reason, it stays out of the public queries",
                 "consumes": [
                     "application/json"
                 ],
@@ -300,9 +469,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Location"
+                    "Admin"
                 ],
-                "summary": "Delete a location by name",
+                "summary": "Reject a location",
                 "parameters": [
                     {
                         "type": "string",
@@ -310,13 +479,22 @@ const docTemplate = `{
                         "name": "name",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Rejection",
+                        "name": "domain.RejectLocationRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.RejectLocationRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Success",
+                        "description": "Location rejected",
                         "schema": {
-                            "$ref": "#/definitions/http.response"
+                            "$ref": "#/definitions/http.locationResponse"
                         }
                     },
                     "400": {
@@ -325,12 +503,30 @@ const docTemplate = `{
                             "$ref": "#/definitions/http.errorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not found error",
                         "schema": {
                             "$ref": "#/definitions/http.errorResponse"
                         }
                     },
+                    "409": {
+                        "description": "Location is not pending review",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request body too large",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -339,46 +535,5886 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "domain.Ping": {
-            "type": "object",
-            "properties": {
-                "name": {
-                    "type": "string"
-                }
-            }
         },
-        "domain.RegisterLocationRequest": {
-            "type": "object",
-            "required": [
-                "latitude",
-                "longitude",
-                "name"
-            ],
-            "properties": {
-                "latitude": {
-                    "type": "number",
-                    "maximum": 90,
-                    "minimum": -90
-                },
-                "longitude": {
-                    "type": "number",
-                    "maximum": 180,
-                    "minimum": -180
-                },
-                "name": {
-                    "type": "string"
-                }
-            }
+        "/admin/log-level": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "switch the log level of the running server, it lasts until the server restarts or the config file is reloaded",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Change the log level",
+                "parameters": [
+                    {
+                        "description": "Log level",
+                        "name": "logLevelRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.logLevelRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Log level changed",
+                        "schema": {
+                            "$ref": "#/definitions/http.logLevelResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request body too large",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/quotas/{tenant}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get the number of locations and webhooks a tenant may have and how many it has",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Get the quota of a tenant",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant",
+                        "name": "tenant",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/http.quotaResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "set the number of locations and webhooks a tenant may have, a limit left out or null is the default one of the configuration and 0 lifts it. The resources a tenant already has are kept when it is lowered",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Set the quota of a tenant",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant",
+                        "name": "tenant",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Quota",
+                        "name": "domain.SetQuotaRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.SetQuotaRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Quota set",
+                        "schema": {
+                            "$ref": "#/definitions/http.quotaResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request body too large",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "reload a snapshot of the scheduled exports, making the approved locations those of the snapshot or loading it into the locations_shadow table to compare it with them. The report lists the changes between the snapshot and the locations, a dry run only reports them",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Restore a snapshot",
+                "parameters": [
+                    {
+                        "description": "Snapshot to restore",
+                        "name": "restoreRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.RestoreRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/http.restoreReportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Snapshot not found",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request body too large",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "501": {
+                        "description": "No object storage bucket configured",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/syncs": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "list the reports of the syncs from the external sources, most recent first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "List sync reports",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Name of the source",
+                        "name": "source",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of reports, 20 by default, at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/http.syncReportListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/syncs/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get the report of a sync from an external source by its id, with the changes it made",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Get a sync report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sync report id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/http.syncReportResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Sync report not found",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "list all the collections, by name",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Collection"
+                ],
+                "summary": "List the collections",
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/http.collectionListResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "create an empty named collection of locations, lists and nearest searches can be restricted to it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Collection"
+                ],
+                "summary": "Create a collection",
+                "parameters": [
+                    {
+                        "description": "Collection",
+                        "name": "domain.CreateCollectionRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.CreateCollectionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Collection created",
+                        "schema": {
+                            "$ref": "#/definitions/http.collectionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request body too large",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{name}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "delete a collection by name, its locations are kept",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Collection"
+                ],
+                "summary": "Delete a collection",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Collection name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/http.response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{name}/locations/{location}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "add a location to a collection, both by name. Adding a location already in the collection succeeds",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Collection"
+                ],
+                "summary": "Add a location to a collection",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Collection name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Location name",
+                        "name": "location",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/http.response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "remove a location from a collection, both by name. The location itself is kept",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Collection"
+                ],
+                "summary": "Remove a location from a collection",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Collection name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Location name",
+                        "name": "location",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/http.response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/devices": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "register an edge device so that it can send heartbeats, registering it again renames it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Device"
+                ],
+                "summary": "Register a device",
+                "parameters": [
+                    {
+                        "description": "Device",
+                        "name": "domain.RegisterDeviceRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.RegisterDeviceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Device registered",
+                        "schema": {
+                            "$ref": "#/definitions/http.deviceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/devices/stale": {
+            "get": {
+                "description": "list the devices that have not sent a heartbeat in the given number of minutes, least recently seen first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Device"
+                ],
+                "summary": "List stale devices",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Minutes without a heartbeat, 5 by default, at most 10080",
+                        "name": "minutes",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/http.deviceListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/entities/nearest": {
+            "get": {
+                "description": "find the moving entities nearest to a point by their current position, entities whose position is stale are left out",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Entity"
+                ],
+                "summary": "Find the nearest entities",
+                "parameters": [
+                    {
+                        "type": "number",
+                        "description": "Latitude",
+                        "name": "lat",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "Longitude",
+                        "name": "lng",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of entities, defaults to 10",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Only return the entities within this many meters",
+                        "name": "radius",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return the entities of this kind",
+                        "name": "kind",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/http.nearestEntityListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/entities/{id}/positions": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "record the current position of a moving entity such as a courier or a vehicle, it replaces the position reported before",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Entity"
+                ],
+                "summary": "Report the position of an entity",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Entity id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Position",
+                        "name": "domain.ReportPositionRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.ReportPositionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Position reported",
+                        "schema": {
+                            "$ref": "#/definitions/http.entityPositionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request body too large",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/geocode/autocomplete": {
+            "get": {
+                "description": "suggest the addresses completing a partly typed one, e.g. to fill the coordinates of a location being registered. Suggestions are cached by query, so the requests of a client typing can be sent as often as every keystroke is debounced",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Geocode"
+                ],
+                "summary": "Suggest addresses",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Address typed so far, at least 3 characters",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of suggestions, defaults to 5",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Latitude of the point the addresses near are suggested first",
+                        "name": "lat",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Longitude of the point the addresses near are suggested first",
+                        "name": "lng",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language of the suggestions, defaults to the one preferred by Accept-Language",
+                        "name": "lang",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Preferred language of the suggestions",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/http.addressSuggestionListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "501": {
+                        "description": "No geocoding service is configured",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Geocoding service unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/geofences": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "create a named area bounded by a polygon, the polygon is closed without repeating its first point",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Geofence"
+                ],
+                "summary": "Create a geofence",
+                "parameters": [
+                    {
+                        "description": "Geofence",
+                        "name": "domain.CreateGeofenceRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.CreateGeofenceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Geofence created",
+                        "schema": {
+                            "$ref": "#/definitions/http.geofenceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request body too large",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/geofences/{name}/locations": {
+            "get": {
+                "description": "list a page of the locations inside a geofence, by name",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Geofence"
+                ],
+                "summary": "List the locations inside a geofence",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Geofence name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of locations, 20 by default, at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of locations to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Preferred locales of the location names, the canonical name is kept when none has a translation",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/http.locationListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/health": {
+            "get": {
+                "description": "check server status",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Ping"
+                ],
+                "summary": "Check server status",
+                "responses": {
+                    "200": {
+                        "description": "Server OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.logLevelResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "store a heartbeat, optionally named and carrying the latency the client measured for its previous ping",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Ping"
+                ],
+                "summary": "Send a ping",
+                "parameters": [
+                    {
+                        "description": "Ping",
+                        "name": "domain.PingRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.PingRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Ping created",
+                        "schema": {
+                            "$ref": "#/definitions/http.pingResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/health/dependencies": {
+            "get": {
+                "description": "check every dependency of the server concurrently and report their status, latency and last success. It answers 503 when a critical dependency is down",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Ping"
+                ],
+                "summary": "Check the dependencies",
+                "responses": {
+                    "200": {
+                        "description": "Dependencies checked",
+                        "schema": {
+                            "$ref": "#/definitions/http.healthReportResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Critical dependencies are down",
+                        "schema": {
+                            "$ref": "#/definitions/http.healthReportResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/health/stats": {
+            "get": {
+                "description": "get the uptime of the server, the recent ping counts and the availability over the last day",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Ping"
+                ],
+                "summary": "Get uptime statistics",
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/http.pingStatsResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/heartbeats": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "mark a registered device as seen now, optionally with its last known coordinates",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Device"
+                ],
+                "summary": "Send a heartbeat",
+                "parameters": [
+                    {
+                        "description": "Heartbeat",
+                        "name": "domain.HeartbeatRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.HeartbeatRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Heartbeat recorded",
+                        "schema": {
+                            "$ref": "#/definitions/http.deviceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Device is not registered",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/locations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "list all registered active locations, optionally narrowed by a filter such as ` + "`" + `category:park AND created_at\u003e2024-01-01` + "`" + `",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Location"
+                ],
+                "summary": "List all locations",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only list the locations of this collection",
+                        "name": "collection",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Also list the locations outside their validity",
+                        "name": "include_expired",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "created_at",
+                            "updated_at"
+                        ],
+                        "type": "string",
+                        "description": "Order of the list, most recent first",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Preferred locales of the location names, the canonical name is kept when none has a translation",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/http.locationListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Collection not found",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "register a new location with all required details. With moderation enabled, the locations submitted without the admin token wait for review",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Location"
+                ],
+                "summary": "Register a new location",
+                "parameters": [
+                    {
+                        "description": "Location",
+                        "name": "domain.RegisterLocationRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.RegisterLocationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Location created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/http.locationResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "Location submitted for review",
+                        "schema": {
+                            "$ref": "#/definitions/http.locationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Quota of the tenant exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request body too large",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/locations/along-route": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get the locations within a buffer distance of a route given as an encoded polyline or waypoints, in the order the route passes them",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Location"
+                ],
+                "summary": "Search the locations along a route",
+                "parameters": [
+                    {
+                        "description": "Route search",
+                        "name": "domain.AlongRouteRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.AlongRouteRequest"
+                        }
+                    },
+                    {
+                        "enum": [
+                            "m",
+                            "km",
+                            "mi"
+                        ],
+                        "type": "string",
+                        "description": "Unit of the formatted distances when the body has none",
+                        "name": "unit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Preferred locales of the location names, the canonical name is kept when none has a translation",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/http.routeLocationListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request body too large",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/locations/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "stream all locations matching an optional filter, one JSON object per line",
+                "produces": [
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "Location"
+                ],
+                "summary": "Export locations",
+                "parameters": [
+                    {
+                        "enum": [
+                            "ndjson"
+                        ],
+                        "type": "string",
+                        "description": "Export format",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only export the locations of this collection",
+                        "name": "collection",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Also export the locations outside their validity",
+                        "name": "include_expired",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "created_at",
+                            "updated_at"
+                        ],
+                        "type": "string",
+                        "description": "Order of the export, most recent first",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "One location per line",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Location"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Collection not found",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Export without a filter too large for the plan of the tenant",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/locations/geometry": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get the centroid, bounding box and convex hull as GeoJSON of the locations matching a filter or listed by slug, e.g. to fit a map viewport to them. Without a filter or slugs every location is included",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Location"
+                ],
+                "summary": "Compute the geometry of a set of locations",
+                "parameters": [
+                    {
+                        "description": "Locations",
+                        "name": "domain.LocationGeometryRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.LocationGeometryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/http.locationGeometryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Collection not found",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request body too large",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/locations/id/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "fetch a location through its id, which unlike its name never changes, so integrators can keep it as a reference",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Location"
+                ],
+                "summary": "Get a location by id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Location id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Preferred locales of the location names, the canonical name is kept when none has a translation",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/http.locationResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "replace the details of a location through its id, like an update by name",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Location"
+                ],
+                "summary": "Update a location by id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Location id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Expected version",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Time the location must not have changed after, ignored along with If-Match",
+                        "name": "If-Unmodified-Since",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Location",
+                        "name": "domain.UpdateLocationRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.UpdateLocationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Location updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/http.locationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition failed, with the current location",
+                        "schema": {
+                            "$ref": "#/definitions/http.preconditionFailedResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request body too large",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "delete a location through its id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Location"
+                ],
+                "summary": "Delete a location by id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Location id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Expected version",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Time the location must not have changed after, ignored along with If-Match",
+                        "name": "If-Unmodified-Since",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/http.response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition failed, with the current location",
+                        "schema": {
+                            "$ref": "#/definitions/http.preconditionFailedResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/locations/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "start an asynchronous import of locations sent as a JSON array, as CSV with a name,latitude,longitude[,category] header, as a GeoJSON FeatureCollection of points or as the waypoints of a GPX file. The format is told by the content type, or detected from the body when it is missing or generic. With dry_run the file is only read and checked, and a preview of the import is returned",
+                "consumes": [
+                    "application/json",
+                    "text/csv",
+                    "application/geo+json",
+                    "application/gpx+xml"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Import"
+                ],
+                "summary": "Import locations in bulk",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Only preview the import",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Locations",
+                        "name": "domain.RegisterLocationRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.RegisterLocationRequest"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Preview of the import",
+                        "schema": {
+                            "$ref": "#/definitions/http.importPreviewResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "Import started",
+                        "schema": {
+                            "$ref": "#/definitions/http.importJobResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Quota of the tenant exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request body too large",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/locations/import/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get the status and progress of an import job",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Import"
+                ],
+                "summary": "Get an import job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Import job id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/http.importJobResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/locations/lookup": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "fetch up to 1000 locations by id or slug at once, along with the ids and slugs that match no location, e.g. for a client to reconcile its cache",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Location"
+                ],
+                "summary": "Look up locations by id or slug",
+                "parameters": [
+                    {
+                        "description": "Ids and slugs",
+                        "name": "domain.LookupLocationsRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.LookupLocationsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/http.locationLookupResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request body too large",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/locations/nearest": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get the nearest location to the longitude and latitude",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Location"
+                ],
+                "summary": "Get the nearest location to the longitude and latitude",
+                "parameters": [
+                    {
+                        "maximum": 90,
+                        "minimum": -90,
+                        "type": "number",
+                        "description": "Latitude, required without plus_code",
+                        "name": "lat",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 180,
+                        "minimum": -180,
+                        "type": "number",
+                        "description": "Longitude, required without plus_code",
+                        "name": "lng",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Full plus code of the point, instead of lat and lng",
+                        "name": "plus_code",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 20000,
+                        "minimum": -1000,
+                        "type": "number",
+                        "description": "Altitude of the point in meters above sea level, distances to locations with an altitude are then in 3D",
+                        "name": "alt",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "m",
+                            "km",
+                            "mi"
+                        ],
+                        "type": "string",
+                        "description": "Unit of the formatted distance, defaults to the region of Accept-Language",
+                        "name": "unit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "spheroid",
+                            "haversine",
+                            "vincenty"
+                        ],
+                        "type": "string",
+                        "description": "Distance formula, defaults to the configured formula",
+                        "name": "formula",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 7200,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Only return a location reachable within this many seconds",
+                        "name": "max_travel_time",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "driving",
+                            "walking",
+                            "cycling"
+                        ],
+                        "type": "string",
+                        "description": "Mode of travel of max_travel_time, defaults to driving",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return a location of this collection",
+                        "name": "collection",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Also consider the locations outside their validity",
+                        "name": "include_expired",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Also return the next two nearest locations with their distances, as fallbacks",
+                        "name": "alternatives",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Preferred locales of the location names, the canonical name is kept when none has a translation",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/http.nearestLocationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "501": {
+                        "description": "No routing service is configured",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get the nearest locations to a point, optionally limited by distance, travel time, categories and exclusions",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Location"
+                ],
+                "summary": "Search the nearest locations with filters",
+                "parameters": [
+                    {
+                        "description": "Nearest search",
+                        "name": "domain.NearestLocationsRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.NearestLocationsRequest"
+                        }
+                    },
+                    {
+                        "enum": [
+                            "m",
+                            "km",
+                            "mi"
+                        ],
+                        "type": "string",
+                        "description": "Unit of the formatted distances when the body has none",
+                        "name": "unit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Preferred locales of the location names, the canonical name is kept when none has a translation",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/http.nearestLocationListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Collection not found",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request body too large",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Search too wide without categories for the plan of the tenant",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "501": {
+                        "description": "No routing service is configured",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/locations/search": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "search the locations by name, translated name or category, tolerating typos, and count the matches per category. The search engine is fed with the location events, so a change shows up shortly after it is made",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Location"
+                ],
+                "summary": "Search locations",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Text searched",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Only search the locations of these categories, repeated for several",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of locations, defaults to 20",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of matching locations skipped",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/http.locationSearchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "501": {
+                        "description": "No search engine is configured",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Search engine unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/locations/trending": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "list the locations fetched or returned by nearest searches the most within a window, optionally near a point",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Location"
+                ],
+                "summary": "List the trending locations",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Window up to now, such as 24h or 7d, defaults to 7d",
+                        "name": "window",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of locations, defaults to 10",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Latitude of the point the locations are near, requires lng",
+                        "name": "lat",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Longitude of the point the locations are near, requires lat",
+                        "name": "lng",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Radius in meters around the point, defaults to 5000",
+                        "name": "radius",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only list the locations of this category",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Preferred locales of the location names, the canonical name is kept when none has a translation",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/http.trendingLocationListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/locations/{name}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "fetch a location through its name, slug or one of its aliases, the response holds its canonical name",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Location"
+                ],
+                "summary": "Get a location by name",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Location name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Preferred locales of the location names, the canonical name is kept when none has a translation",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/http.locationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "replace the details of a location. The version the update is based on must be sent in the body or as an If-Match header, a stale version in the body is rejected with a conflict, and one in If-Match with a failed precondition holding the current location",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Location"
+                ],
+                "summary": "Update a location",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Location name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Expected version",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Time the location must not have changed after, ignored along with If-Match",
+                        "name": "If-Unmodified-Since",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Location",
+                        "name": "domain.UpdateLocationRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.UpdateLocationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Location updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/http.locationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition failed, with the current location",
+                        "schema": {
+                            "$ref": "#/definitions/http.preconditionFailedResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request body too large",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "delete a location through name",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Location"
+                ],
+                "summary": "Delete a location by name",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Location name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Expected version",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Time the location must not have changed after, ignored along with If-Match",
+                        "name": "If-Unmodified-Since",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/http.response"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition failed, with the current location",
+                        "schema": {
+                            "$ref": "#/definitions/http.preconditionFailedResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/locations/{name}/aliases": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "list the alternate names of a location, by name",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Location"
+                ],
+                "summary": "List the aliases of a location",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Location name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/http.aliasListResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "add an alternate name a location can be fetched by, it must not be the name or an alias of another location",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Location"
+                ],
+                "summary": "Add an alias to a location",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Location name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Alias",
+                        "name": "domain.CreateLocationAliasRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.CreateLocationAliasRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Alias created",
+                        "schema": {
+                            "$ref": "#/definitions/http.aliasResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request body too large",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/locations/{name}/aliases/{alias}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "remove an alternate name from a location, both by name",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Location"
+                ],
+                "summary": "Remove an alias from a location",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Location name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Alias",
+                        "name": "alias",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/http.response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/locations/{name}/share": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "list the links sharing the location that are neither revoked nor expired, the latest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Location"
+                ],
+                "summary": "List the links to a location",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Location name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/http.shareListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "create a signed link granting read access to the location without authentication until it expires, 24 hours after by default, or is revoked. The body may be left out",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Location"
+                ],
+                "summary": "Share a location",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Location name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Lifetime of the link",
+                        "name": "domain.ShareLocationRequest",
+                        "in": "body",
+                        "required": false,
+                        "schema": {
+                            "$ref": "#/definitions/domain.ShareLocationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Location shared",
+                        "schema": {
+                            "$ref": "#/definitions/http.shareResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request body too large",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "501": {
+                        "description": "No signing secret is configured",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/locations/{name}/share/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "revoke a link sharing the location, it is refused from then on",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Location"
+                ],
+                "summary": "Revoke a link to a location",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Location name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Share ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/http.response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/shared/{token}": {
+            "get": {
+                "description": "get the location a share link points to, without authentication, whatever its review status or validity. The response is not to be cached, as the link may be revoked",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Location"
+                ],
+                "summary": "Get a shared location",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token of the link",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/http.locationResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Unknown, expired or revoked link",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "501": {
+                        "description": "No signing secret is configured",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/subscriptions": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "register a standing query for the locations or entities that appear within a radius of a point. Matches are posted to the webhook and sent to the WebSocket clients of the subscription",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Subscription"
+                ],
+                "summary": "Create a proximity subscription",
+                "parameters": [
+                    {
+                        "description": "Subscription",
+                        "name": "domain.CreateSubscriptionRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.CreateSubscriptionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Subscription created",
+                        "schema": {
+                            "$ref": "#/definitions/http.subscriptionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Quota of the tenant exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request body too large",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/subscriptions/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get a proximity subscription by id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Subscription"
+                ],
+                "summary": "Get a proximity subscription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/http.subscriptionResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "delete a proximity subscription by id, its WebSocket clients are disconnected",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Subscription"
+                ],
+                "summary": "Delete a proximity subscription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/http.response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/subscriptions/{id}/ws": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "upgrade to a WebSocket that receives every match of the subscription as a JSON text message",
+                "tags": [
+                    "Subscription"
+                ],
+                "summary": "Watch a proximity subscription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "Switching protocols, one match per message",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ProximityMatch"
+                        }
+                    },
+                    "400": {
+                        "description": "Not a WebSocket handshake",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tiles/{z}/{x}/{y}.mvt": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get the approved locations within a tile of the Web Mercator grid as a Mapbox Vector Tile, for map frontends to draw them at any zoom level. The tile has a single locations layer of points with the id, name, slug and category of their location. A tile without locations is answered with no content",
+                "produces": [
+                    "application/vnd.mapbox-vector-tile"
+                ],
+                "tags": [
+                    "Location"
+                ],
+                "summary": "Get a vector tile of the locations",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Zoom level, up to 22",
+                        "name": "z",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Column of the tile, from the west",
+                        "name": "x",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Row of the tile, from the north",
+                        "name": "y",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Also draw the locations outside their validity",
+                        "name": "include_expired",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Tile",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "204": {
+                        "description": "Tile without locations"
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "domain.AddressSuggestion": {
+            "type": "object",
+            "properties": {
+                "city": {
+                    "type": "string"
+                },
+                "country": {
+                    "type": "string"
+                },
+                "country_code": {
+                    "type": "string"
+                },
+                "house_number": {
+                    "type": "string"
+                },
+                "label": {
+                    "type": "string",
+                    "description": "Label is the whole address, as shown to the user"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "postcode": {
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string"
+                },
+                "street": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.AlongRouteRequest": {
+            "type": "object",
+            "required": [
+                "buffer",
+                "categories"
+            ],
+            "properties": {
+                "buffer": {
+                    "description": "Buffer is the greatest distance in meters from the route to a location",
+                    "type": "number",
+                    "maximum": 50000
+                },
+                "categories": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "limit": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 1
+                },
+                "polyline": {
+                    "description": "Polyline is the route in the encoded polyline algorithm format, with 5 decimal places",
+                    "type": "string",
+                    "maxLength": 100000
+                },
+                "unit": {
+                    "description": "Unit is the unit of the formatted distances, m, km or mi",
+                    "enum": [
+                        "m",
+                        "km",
+                        "mi"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.DistanceUnit"
+                        }
+                    ]
+                },
+                "waypoints": {
+                    "type": "array",
+                    "maxItems": 1000,
+                    "minItems": 2,
+                    "items": {
+                        "$ref": "#/definitions/domain.Point"
+                    }
+                }
+            }
+        },
+        "domain.Collection": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "slug": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.CreateCollectionRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.CreateGeofenceRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "points"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "points": {
+                    "type": "array",
+                    "maxItems": 1000,
+                    "minItems": 3,
+                    "items": {
+                        "$ref": "#/definitions/domain.Point"
+                    }
+                }
+            }
+        },
+        "domain.CreateLocationAliasRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.CreateSubscriptionRequest": {
+            "type": "object",
+            "required": [
+                "target"
+            ],
+            "properties": {
+                "kind": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "latitude": {
+                    "type": "number",
+                    "maximum": 90,
+                    "minimum": -90
+                },
+                "longitude": {
+                    "type": "number",
+                    "maximum": 180,
+                    "minimum": -180
+                },
+                "radius_meters": {
+                    "type": "number",
+                    "maximum": 50000
+                },
+                "target": {
+                    "enum": [
+                        "locations",
+                        "entities",
+                        "all"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.SubscriptionTarget"
+                        }
+                    ]
+                },
+                "webhook_url": {
+                    "type": "string",
+                    "maxLength": 2048
+                }
+            }
+        },
+        "domain.DependencyHealth": {
+            "type": "object",
+            "properties": {
+                "critical": {
+                    "description": "Critical dependencies take the server down with them, the others only degrade it",
+                    "type": "boolean"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "last_success_at": {
+                    "type": "string"
+                },
+                "latency_ms": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/domain.HealthStatus"
+                }
+            }
+        },
+        "domain.Device": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "last_seen_at": {
+                    "type": "string"
+                },
+                "latitude": {
+                    "description": "Latitude and Longitude are the last coordinates the device reported, if any",
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "registered_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.DistanceFormula": {
+            "type": "string",
+            "enum": [
+                "spheroid",
+                "haversine",
+                "vincenty"
+            ],
+            "x-enum-varnames": [
+                "DistanceSpheroid",
+                "DistanceHaversine",
+                "DistanceVincenty"
+            ]
+        },
+        "domain.DistanceUnit": {
+            "type": "string",
+            "enum": [
+                "m",
+                "km",
+                "mi"
+            ],
+            "x-enum-varnames": [
+                "DistanceUnitMeters",
+                "DistanceUnitKilometers",
+                "DistanceUnitMiles"
+            ]
+        },
+        "domain.EntityPosition": {
+            "type": "object",
+            "properties": {
+                "entity_id": {
+                    "type": "string"
+                },
+                "kind": {
+                    "description": "Kind tells the entities apart, e.g. courier or vehicle",
+                    "type": "string"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "reported_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.Entrance": {
+            "type": "object",
+            "properties": {
+                "latitude": {
+                    "type": "number",
+                    "maximum": 90,
+                    "minimum": -90
+                },
+                "longitude": {
+                    "type": "number",
+                    "maximum": 180,
+                    "minimum": -180
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "domain.ExportFormat": {
+            "type": "string",
+            "enum": [
+                "geojson",
+                "csv"
+            ],
+            "x-enum-varnames": [
+                "ExportGeoJSON",
+                "ExportCSV"
+            ]
+        },
+        "domain.Geofence": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "points": {
+                    "description": "Points are the vertices of the polygon bounding the area, in order",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Point"
+                    }
+                },
+                "slug": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.HealthReport": {
+            "type": "object",
+            "properties": {
+                "checked_at": {
+                    "type": "string"
+                },
+                "dependencies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.DependencyHealth"
+                    }
+                },
+                "leader": {
+                    "description": "Leader tells whether this instance runs the singleton workers, when a leader is elected",
+                    "type": "boolean"
+                },
+                "status": {
+                    "$ref": "#/definitions/domain.HealthStatus"
+                }
+            }
+        },
+        "domain.HealthStatus": {
+            "type": "string",
+            "enum": [
+                "up",
+                "degraded",
+                "down"
+            ],
+            "x-enum-varnames": [
+                "HealthUp",
+                "HealthDegraded",
+                "HealthDown"
+            ]
+        },
+        "domain.HeartbeatRequest": {
+            "type": "object",
+            "required": [
+                "device_id"
+            ],
+            "properties": {
+                "device_id": {
+                    "type": "string",
+                    "maxLength": 64
+                },
+                "latitude": {
+                    "type": "number",
+                    "maximum": 90,
+                    "minimum": -90
+                },
+                "longitude": {
+                    "type": "number",
+                    "maximum": 180,
+                    "minimum": -180
+                }
+            }
+        },
+        "domain.ImportJob": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "imported": {
+                    "type": "integer"
+                },
+                "skipped": {
+                    "type": "integer"
+                },
+                "status": {
+                    "$ref": "#/definitions/domain.ImportStatus"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.ImportPreview": {
+            "type": "object",
+            "properties": {
+                "duplicates": {
+                    "description": "Duplicates are the valid records named like an earlier one, ignoring case, which the import skips",
+                    "type": "integer",
+                    "example": 1
+                },
+                "errors": {
+                    "description": "Errors holds the problems of the first invalid records",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ImportRecordError"
+                    }
+                },
+                "format": {
+                    "description": "Format is the format the file was read in, json, csv, geojson or gpx",
+                    "type": "string",
+                    "example": "csv"
+                },
+                "invalid": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "sample": {
+                    "description": "Sample holds the first valid records as they would be imported",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.RegisterLocationRequest"
+                    }
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "valid": {
+                    "type": "integer",
+                    "example": 118
+                }
+            }
+        },
+        "domain.ImportRecordError": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Invalid plus code"
+                },
+                "record": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "domain.ImportStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "running",
+                "completed",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "ImportPending",
+                "ImportRunning",
+                "ImportCompleted",
+                "ImportFailed"
+            ]
+        },
+        "domain.Job": {
+            "type": "object",
+            "properties": {
+                "attempt": {
+                    "type": "integer"
+                },
+                "attempted_at": {
+                    "type": "string"
+                },
+                "attempted_by": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "finalized_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "max_attempts": {
+                    "type": "integer"
+                },
+                "progress": {
+                    "description": "Progress is written by the job as it runs, and kept across its attempts",
+                    "type": "object"
+                },
+                "scheduled_at": {
+                    "type": "string"
+                },
+                "state": {
+                    "$ref": "#/definitions/domain.JobState"
+                }
+            }
+        },
+        "domain.JobState": {
+            "type": "string",
+            "enum": [
+                "available",
+                "running",
+                "completed",
+                "failed",
+                "cancelled"
+            ],
+            "x-enum-varnames": [
+                "JobAvailable",
+                "JobRunning",
+                "JobCompleted",
+                "JobFailed",
+                "JobCancelled"
+            ]
+        },
+        "domain.Location": {
+            "type": "object",
+            "properties": {
+                "altitude": {
+                    "description": "Altitude is in meters above sea level, for locations off the ground such as drone pads or floors of a building",
+                    "type": "number"
+                },
+                "category": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "entrances": {
+                    "description": "Entrances are the points the location is reached from, nearest searches measure to the closest one",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Entrance"
+                    }
+                },
+                "expired": {
+                    "description": "Expired is set once ValidUntil has passed",
+                    "type": "boolean"
+                },
+                "footprint": {
+                    "description": "Footprint is the boundary of a location spreading over an area, nearest searches without entrances\nmeasure to its edge",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.Polygon"
+                        }
+                    ]
+                },
+                "id": {
+                    "type": "string"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "names": {
+                    "description": "Names are the names of the location translated per locale, Name stays the canonical name",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "nearby_duplicates": {
+                    "description": "NearbyDuplicates are the locations at the coordinates of a location just registered, listed with the\nwarn duplicate policy",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Location"
+                    }
+                },
+                "plus_code": {
+                    "description": "PlusCode is the Open Location Code of the coordinates, it is derived from them rather than stored",
+                    "type": "string"
+                },
+                "review_reason": {
+                    "type": "string"
+                },
+                "slug": {
+                    "type": "string"
+                },
+                "source": {
+                    "description": "Source is the name of the external source the location is synced from, empty for the others",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Status is pending while the location waits for review, only approved locations are public",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.LocationStatus"
+                        }
+                    ]
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "valid_from": {
+                    "description": "ValidFrom and ValidUntil bound the time a location is listed and found by nearest searches, such as\na pop-up store or an event. A location without them is always valid",
+                    "type": "string"
+                },
+                "valid_until": {
+                    "type": "string"
+                },
+                "version": {
+                    "description": "Version is incremented by every update, updates must send the version they were based on",
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.LocationAlias": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "location_id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "slug": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.LocationGeometry": {
+            "type": "object",
+            "properties": {
+                "bbox": {
+                    "description": "BoundingBox is the GeoJSON bbox of the locations, their least and greatest longitude and latitude",
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "centroid": {
+                    "description": "Centroid is the GeoJSON point at the center of the locations",
+                    "type": "object"
+                },
+                "convex_hull": {
+                    "description": "ConvexHull is the smallest convex GeoJSON polygon holding the locations. It is a point or a line\nstring when the locations are fewer than 3 or in a line",
+                    "type": "object"
+                },
+                "count": {
+                    "description": "Count is the number of locations, the geometries are left out when there is none",
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.LocationGeometryRequest": {
+            "type": "object",
+            "properties": {
+                "collection": {
+                    "description": "Collection restricts the locations to those of the collection with this name or slug",
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "query": {
+                    "description": "Query is a filter such as ` + "`" + `category:park AND created_at\u003e2024-01-01` + "`" + `",
+                    "type": "string"
+                },
+                "slugs": {
+                    "description": "Slugs picks the locations by slug instead of a filter",
+                    "type": "array",
+                    "maxItems": 1000,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "domain.LocationLookup": {
+            "type": "object",
+            "properties": {
+                "found": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Location"
+                    }
+                },
+                "missing": {
+                    "$ref": "#/definitions/domain.MissingLookups"
+                }
+            }
+        },
+        "domain.LocationSearchResult": {
+            "type": "object",
+            "properties": {
+                "categories": {
+                    "description": "Categories is the number of matching locations of each category, across every page",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "locations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Location"
+                    }
+                },
+                "total": {
+                    "description": "Total is the estimated number of matching locations, across every page",
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "domain.LocationShare": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string",
+                    "example": "ada"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "0b8a3f5e-6f4c-4d2a-9c1e-2f7b5d8e4a10"
+                },
+                "location_id": {
+                    "type": "string",
+                    "example": "5f1d7c2e-8b3a-4e6f-a9d0-1c2b3a4d5e6f"
+                },
+                "url": {
+                    "description": "URL is the link, its token is signed with the expiry of the share",
+                    "type": "string",
+                    "example": "https://api.example.com/v1/shared/0b8a3f5e-6f4c-4d2a-9c1e-2f7b5d8e4a10.1767225600.c2lnbmF0dXJl"
+                }
+            }
+        },
+        "domain.LocationStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "approved",
+                "rejected",
+                "removed"
+            ],
+            "x-enum-varnames": [
+                "LocationPending",
+                "LocationApproved",
+                "LocationRejected",
+                "LocationRemoved"
+            ]
+        },
+        "domain.LookupLocationsRequest": {
+            "type": "object",
+            "properties": {
+                "ids": {
+                    "type": "array",
+                    "maxItems": 1000,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "slugs": {
+                    "type": "array",
+                    "maxItems": 1000,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "domain.MissingLookups": {
+            "type": "object",
+            "properties": {
+                "ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "slugs": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "domain.NearestEntity": {
+            "type": "object",
+            "properties": {
+                "distance_meters": {
+                    "type": "number"
+                },
+                "entity_id": {
+                    "type": "string"
+                },
+                "kind": {
+                    "description": "Kind tells the entities apart, e.g. courier or vehicle",
+                    "type": "string"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "reported_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.NearestLocation": {
+            "type": "object",
+            "properties": {
+                "alternatives": {
+                    "description": "Alternatives are the next nearest locations, for clients offering a fallback when the nearest is closed or\nfull. They are only set on request",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.NearestLocation"
+                    }
+                },
+                "altitude": {
+                    "description": "Altitude is in meters above sea level, for locations off the ground such as drone pads or floors of a building",
+                    "type": "number"
+                },
+                "bearing": {
+                    "description": "Bearing is in degrees clockwise from north, from the query point to the location",
+                    "type": "number"
+                },
+                "category": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "direction": {
+                    "description": "Direction is the compass point of the bearing, e.g. NE",
+                    "type": "string"
+                },
+                "distance_meters": {
+                    "description": "Distance is in meters",
+                    "type": "number"
+                },
+                "entrance": {
+                    "description": "Entrance is the entrance closest to the query point of a location with entrances, the distance,\nbearing and travel time are to it",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.Entrance"
+                        }
+                    ]
+                },
+                "entrances": {
+                    "description": "Entrances are the points the location is reached from, nearest searches measure to the closest one",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Entrance"
+                    }
+                },
+                "expired": {
+                    "description": "Expired is set once ValidUntil has passed",
+                    "type": "boolean"
+                },
+                "footprint": {
+                    "description": "Footprint is the boundary of a location spreading over an area, nearest searches without entrances\nmeasure to its edge",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.Polygon"
+                        }
+                    ]
+                },
+                "id": {
+                    "type": "string"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "names": {
+                    "description": "Names are the names of the location translated per locale, Name stays the canonical name",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "nearby_duplicates": {
+                    "description": "NearbyDuplicates are the locations at the coordinates of a location just registered, listed with the\nwarn duplicate policy",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Location"
+                    }
+                },
+                "plus_code": {
+                    "description": "PlusCode is the Open Location Code of the coordinates, it is derived from them rather than stored",
+                    "type": "string"
+                },
+                "review_reason": {
+                    "type": "string"
+                },
+                "slug": {
+                    "type": "string"
+                },
+                "source": {
+                    "description": "Source is the name of the external source the location is synced from, empty for the others",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Status is pending while the location waits for review, only approved locations are public",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.LocationStatus"
+                        }
+                    ]
+                },
+                "travel_time_seconds": {
+                    "description": "TravelTime is in seconds from the query point, only set when searching by travel time",
+                    "type": "number"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "valid_from": {
+                    "description": "ValidFrom and ValidUntil bound the time a location is listed and found by nearest searches, such as\na pop-up store or an event. A location without them is always valid",
+                    "type": "string"
+                },
+                "valid_until": {
+                    "type": "string"
+                },
+                "version": {
+                    "description": "Version is incremented by every update, updates must send the version they were based on",
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.NearestLocationsRequest": {
+            "type": "object",
+            "required": [
+                "categories",
+                "exclude"
+            ],
+            "properties": {
+                "altitude": {
+                    "description": "Altitude of the query point in meters above sea level. Distances to the locations with an altitude are\nthen measured in 3D, and on the ground to the others",
+                    "type": "number",
+                    "maximum": 20000,
+                    "minimum": -1000
+                },
+                "categories": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "collection": {
+                    "description": "Collection restricts the search to the locations of the collection with this name or slug",
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "exclude": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "formula": {
+                    "description": "Formula is how distances are computed, spheroid, haversine or vincenty",
+                    "enum": [
+                        "spheroid",
+                        "haversine",
+                        "vincenty"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.DistanceFormula"
+                        }
+                    ]
+                },
+                "include_expired": {
+                    "description": "IncludeExpired searches the locations outside their validity too",
+                    "type": "boolean"
+                },
+                "latitude": {
+                    "type": "number",
+                    "maximum": 90,
+                    "minimum": -90
+                },
+                "limit": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 1
+                },
+                "longitude": {
+                    "type": "number",
+                    "maximum": 180,
+                    "minimum": -180
+                },
+                "max_distance": {
+                    "type": "number"
+                },
+                "max_travel_time": {
+                    "description": "MaxTravelTime keeps the locations reachable within this many seconds with the mode of travel,\nthey are then ordered by travel time",
+                    "type": "integer",
+                    "maximum": 7200,
+                    "minimum": 1
+                },
+                "mode": {
+                    "description": "Mode is the mode of travel of MaxTravelTime, driving, walking or cycling. Defaults to driving",
+                    "enum": [
+                        "driving",
+                        "walking",
+                        "cycling"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.TravelMode"
+                        }
+                    ]
+                },
+                "plus_code": {
+                    "description": "PlusCode is a full Open Location Code such as 6FR5G9JC+XH, sent instead of the coordinates",
+                    "type": "string",
+                    "maxLength": 20
+                },
+                "unit": {
+                    "description": "Unit is the unit of the formatted distances, m, km or mi",
+                    "enum": [
+                        "m",
+                        "km",
+                        "mi"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.DistanceUnit"
+                        }
+                    ]
+                }
+            }
+        },
+        "domain.Ping": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "latency_ms": {
+                    "description": "LatencyMs is the round trip time the client measured for its previous ping, 0 if unknown",
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "source": {
+                    "description": "Source is the address the ping came from",
+                    "type": "string"
+                }
+            }
+        },
+        "domain.PingRequest": {
+            "type": "object",
+            "properties": {
+                "latency_ms": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                }
+            }
+        },
+        "domain.PingStats": {
+            "type": "object",
+            "properties": {
+                "availability": {
+                    "description": "Availability is the share of the minutes of the last day, counted from the first ping\nof the day, in which at least one ping was received",
+                    "type": "number"
+                },
+                "average_latency_ms": {
+                    "type": "number"
+                },
+                "last_ping_at": {
+                    "type": "string"
+                },
+                "pings_last_day": {
+                    "type": "integer"
+                },
+                "pings_last_hour": {
+                    "type": "integer"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "uptime_seconds": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.Point": {
+            "type": "object",
+            "properties": {
+                "latitude": {
+                    "type": "number",
+                    "maximum": 90,
+                    "minimum": -90
+                },
+                "longitude": {
+                    "type": "number",
+                    "maximum": 180,
+                    "minimum": -180
+                }
+            }
+        },
+        "domain.Polygon": {
+            "type": "object",
+            "properties": {
+                "coordinates": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "minItems": 1,
+                    "items": {
+                        "type": "array",
+                        "items": {
+                            "type": "array",
+                            "items": {
+                                "type": "number"
+                            }
+                        }
+                    }
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ProximityMatch": {
+            "type": "object",
+            "properties": {
+                "distance_meters": {
+                    "type": "number"
+                },
+                "entity": {
+                    "$ref": "#/definitions/domain.EntityPosition"
+                },
+                "location": {
+                    "$ref": "#/definitions/domain.Location"
+                },
+                "matched_at": {
+                    "type": "string"
+                },
+                "subscription_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.Quota": {
+            "type": "object",
+            "properties": {
+                "locations": {
+                    "$ref": "#/definitions/domain.QuotaUsage"
+                },
+                "tenant": {
+                    "type": "string",
+                    "example": "acme"
+                },
+                "updated_at": {
+                    "description": "UpdatedAt is the time the quota was last set by the admins, left out for a tenant with the defaults",
+                    "type": "string"
+                },
+                "webhooks": {
+                    "$ref": "#/definitions/domain.QuotaUsage"
+                }
+            }
+        },
+        "domain.QuotaUsage": {
+            "type": "object",
+            "properties": {
+                "default": {
+                    "description": "Default is set when the limit is the default one of the configuration",
+                    "type": "boolean",
+                    "example": true
+                },
+                "limit": {
+                    "description": "Limit is the number of resources the tenant may have, 0 for no limit",
+                    "type": "integer",
+                    "example": 1000
+                },
+                "used": {
+                    "type": "integer",
+                    "example": 120
+                }
+            }
+        },
+        "domain.RegisterDeviceRequest": {
+            "type": "object",
+            "required": [
+                "id"
+            ],
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "maxLength": 64
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "domain.RegisterLocationRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "altitude": {
+                    "description": "Altitude is in meters above sea level, distances to a location without one are measured on the ground",
+                    "type": "number",
+                    "maximum": 20000,
+                    "minimum": -1000
+                },
+                "category": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "entrances": {
+                    "description": "Entrances are the points the location is reached from, up to 50, such as the doors of a mall",
+                    "type": "array",
+                    "maxItems": 50,
+                    "items": {
+                        "$ref": "#/definitions/domain.Entrance"
+                    }
+                },
+                "footprint": {
+                    "description": "Footprint is the boundary of a location spreading over an area, such as a campus or a park",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.Polygon"
+                        }
+                    ]
+                },
+                "latitude": {
+                    "type": "number",
+                    "maximum": 90,
+                    "minimum": -90
+                },
+                "longitude": {
+                    "type": "number",
+                    "maximum": 180,
+                    "minimum": -180
+                },
+                "name": {
+                    "type": "string"
+                },
+                "names": {
+                    "description": "Names are translated names keyed by a BCP 47 locale such as en or fr-CA",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "plus_code": {
+                    "description": "PlusCode is a full Open Location Code such as 6FR5G9JC+XH, sent instead of the coordinates",
+                    "type": "string",
+                    "maxLength": 20
+                },
+                "valid_from": {
+                    "description": "ValidFrom and ValidUntil bound the time the location is valid, ValidUntil must come after ValidFrom",
+                    "type": "string"
+                },
+                "valid_until": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.RejectLocationRequest": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "maxLength": 500
+                }
+            }
+        },
+        "domain.ReportPositionRequest": {
+            "type": "object",
+            "properties": {
+                "kind": {
+                    "type": "string",
+                    "maxLength": 32
+                },
+                "latitude": {
+                    "type": "number",
+                    "maximum": 90,
+                    "minimum": -90
+                },
+                "longitude": {
+                    "type": "number",
+                    "maximum": 180,
+                    "minimum": -180
+                }
+            }
+        },
+        "domain.RestoreAction": {
+            "type": "string",
+            "enum": [
+                "create",
+                "update",
+                "delete"
+            ],
+            "x-enum-varnames": [
+                "RestoreCreate",
+                "RestoreUpdate",
+                "RestoreDelete"
+            ]
+        },
+        "domain.RestoreChange": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "$ref": "#/definitions/domain.RestoreAction"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.RestoreReport": {
+            "type": "object",
+            "properties": {
+                "changes": {
+                    "description": "Changes are the changes of the restore, only the first ones are kept",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.RestoreChange"
+                    }
+                },
+                "created": {
+                    "type": "integer"
+                },
+                "deleted": {
+                    "type": "integer"
+                },
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "target": {
+                    "$ref": "#/definitions/domain.RestoreTarget"
+                },
+                "total": {
+                    "description": "Total is the number of locations in the snapshot",
+                    "type": "integer"
+                },
+                "unchanged": {
+                    "type": "integer"
+                },
+                "updated": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.RestoreRequest": {
+            "type": "object",
+            "required": [
+                "key"
+            ],
+            "properties": {
+                "dry_run": {
+                    "description": "DryRun reports the changes the restore would make to the locations without making them",
+                    "type": "boolean",
+                    "example": true
+                },
+                "key": {
+                    "description": "Key is the key of the snapshot, as listed by the admin API",
+                    "type": "string",
+                    "maxLength": 1024,
+                    "example": "exports/locations-20261016T020000Z.geojson"
+                },
+                "target": {
+                    "description": "Target is live by default",
+                    "enum": [
+                        "live",
+                        "shadow"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.RestoreTarget"
+                        }
+                    ],
+                    "example": "live"
+                }
+            }
+        },
+        "domain.RestoreTarget": {
+            "type": "string",
+            "enum": [
+                "live",
+                "shadow"
+            ],
+            "x-enum-varnames": [
+                "RestoreLive",
+                "RestoreShadow"
+            ]
+        },
+        "domain.RouteLocation": {
+            "type": "object",
+            "properties": {
+                "altitude": {
+                    "description": "Altitude is in meters above sea level, for locations off the ground such as drone pads or floors of a building",
+                    "type": "number"
+                },
+                "category": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "distance_meters": {
+                    "description": "Distance is in meters, from the location to the closest point of the route",
+                    "type": "number"
+                },
+                "entrances": {
+                    "description": "Entrances are the points the location is reached from, nearest searches measure to the closest one",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Entrance"
+                    }
+                },
+                "expired": {
+                    "description": "Expired is set once ValidUntil has passed",
+                    "type": "boolean"
+                },
+                "footprint": {
+                    "description": "Footprint is the boundary of a location spreading over an area, nearest searches without entrances\nmeasure to its edge",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.Polygon"
+                        }
+                    ]
+                },
+                "id": {
+                    "type": "string"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "names": {
+                    "description": "Names are the names of the location translated per locale, Name stays the canonical name",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "plus_code": {
+                    "description": "PlusCode is the Open Location Code of the coordinates, it is derived from them rather than stored",
+                    "type": "string"
+                },
+                "review_reason": {
+                    "type": "string"
+                },
+                "route_fraction": {
+                    "description": "Fraction is how far along the route its closest point to the location is, from 0 at the start to 1 at the end",
+                    "type": "number"
+                },
+                "slug": {
+                    "type": "string"
+                },
+                "source": {
+                    "description": "Source is the name of the external source the location is synced from, empty for the others",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Status is pending while the location waits for review, only approved locations are public",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.LocationStatus"
+                        }
+                    ]
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "valid_from": {
+                    "description": "ValidFrom and ValidUntil bound the time a location is listed and found by nearest searches, such as\na pop-up store or an event. A location without them is always valid",
+                    "type": "string"
+                },
+                "valid_until": {
+                    "type": "string"
+                },
+                "version": {
+                    "description": "Version is incremented by every update, updates must send the version they were based on",
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.SetQuotaRequest": {
+            "type": "object",
+            "properties": {
+                "max_locations": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 1000
+                },
+                "max_webhooks": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 10
+                }
+            }
+        },
+        "domain.ShareLocationRequest": {
+            "type": "object",
+            "properties": {
+                "expires_in": {
+                    "description": "ExpiresIn is the number of seconds the link is valid for, the configured default when left out",
+                    "type": "integer",
+                    "minimum": 60,
+                    "example": 86400
+                }
+            }
+        },
+        "domain.Snapshot": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2026-10-16T02:00:00Z"
+                },
+                "format": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.ExportFormat"
+                        }
+                    ],
+                    "example": "geojson"
+                },
+                "key": {
+                    "type": "string",
+                    "example": "exports/locations-20261016T020000Z.geojson"
+                },
+                "size": {
+                    "description": "Size is in bytes",
+                    "type": "integer",
+                    "example": 48213
+                }
+            }
+        },
+        "domain.Subscription": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "kind": {
+                    "description": "Kind is the category of the locations or the kind of the entities matched, any when empty",
+                    "type": "string"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "radius_meters": {
+                    "type": "number"
+                },
+                "target": {
+                    "$ref": "#/definitions/domain.SubscriptionTarget"
+                },
+                "webhook_url": {
+                    "description": "WebhookURL receives the matches, they are also sent to the WebSocket clients of the subscription",
+                    "type": "string"
+                }
+            }
+        },
+        "domain.SubscriptionTarget": {
+            "type": "string",
+            "enum": [
+                "locations",
+                "entities",
+                "all"
+            ],
+            "x-enum-varnames": [
+                "SubscriptionTargetLocations",
+                "SubscriptionTargetEntities",
+                "SubscriptionTargetAll"
+            ]
+        },
+        "domain.SyncAction": {
+            "type": "string",
+            "enum": [
+                "create",
+                "update",
+                "remove",
+                "restore"
+            ],
+            "x-enum-varnames": [
+                "SyncCreate",
+                "SyncUpdate",
+                "SyncRemove",
+                "SyncRestore"
+            ]
+        },
+        "domain.SyncChange": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "$ref": "#/definitions/domain.SyncAction"
+                },
+                "error": {
+                    "description": "Error is why the change failed, the location is left as it was",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.SyncReport": {
+            "type": "object",
+            "properties": {
+                "changes": {
+                    "description": "Changes are the changes made, or that would be made in a dry run, only the first ones are kept",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.SyncChange"
+                    }
+                },
+                "created": {
+                    "type": "integer"
+                },
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "failed": {
+                    "description": "Failed is the number of changes that failed, a failed sync fails before any change is made",
+                    "type": "integer"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "removed": {
+                    "type": "integer"
+                },
+                "restored": {
+                    "type": "integer"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/domain.SyncStatus"
+                },
+                "total": {
+                    "description": "Total is the number of locations listed by the source",
+                    "type": "integer"
+                },
+                "unchanged": {
+                    "type": "integer"
+                },
+                "updated": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.SyncStatus": {
+            "type": "string",
+            "enum": [
+                "running",
+                "succeeded",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "SyncRunning",
+                "SyncSucceeded",
+                "SyncFailed"
+            ]
+        },
+        "domain.TravelMode": {
+            "type": "string",
+            "enum": [
+                "driving",
+                "walking",
+                "cycling"
+            ],
+            "x-enum-varnames": [
+                "TravelDriving",
+                "TravelWalking",
+                "TravelCycling"
+            ]
+        },
+        "domain.TrendingLocation": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "hits": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "names": {
+                    "description": "Names are the names of the location translated per locale, Name stays the canonical name",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "review_reason": {
+                    "type": "string"
+                },
+                "slug": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Status is pending while the location waits for review, only approved locations are public",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.LocationStatus"
+                        }
+                    ]
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "version": {
+                    "description": "Version is incremented by every update, updates must send the version they were based on",
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.UpdateLocationRequest": {
+            "type": "object",
+            "required": [
+                "latitude",
+                "longitude",
+                "name",
+                "version"
+            ],
+            "properties": {
+                "altitude": {
+                    "description": "Altitude is in meters above sea level, it is cleared when left out",
+                    "type": "number",
+                    "maximum": 20000,
+                    "minimum": -1000
+                },
+                "category": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "entrances": {
+                    "description": "Entrances replace the entrances of the location, they are kept when left out and cleared when empty",
+                    "type": "array",
+                    "maxItems": 50,
+                    "items": {
+                        "$ref": "#/definitions/domain.Entrance"
+                    }
+                },
+                "footprint": {
+                    "description": "Footprint is the boundary of the location, it is cleared when left out",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.Polygon"
+                        }
+                    ]
+                },
+                "latitude": {
+                    "type": "number",
+                    "maximum": 90,
+                    "minimum": -90
+                },
+                "longitude": {
+                    "type": "number",
+                    "maximum": 180,
+                    "minimum": -180
+                },
+                "name": {
+                    "type": "string"
+                },
+                "names": {
+                    "description": "Names replace the translated names of the location, they are kept when left out and cleared when empty",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "valid_from": {
+                    "description": "ValidFrom and ValidUntil bound the time the location is valid, they are cleared when left out",
+                    "type": "string"
+                },
+                "valid_until": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "http.addressSuggestionListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.AddressSuggestion"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Success"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "http.aliasListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.LocationAlias"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Success"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "http.aliasResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/domain.LocationAlias"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Success"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "http.collectionListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Collection"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Success"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "http.collectionResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/domain.Collection"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Success"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "http.deviceListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Device"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Success"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "http.deviceResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/domain.Device"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Success"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "http.entityPositionResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/domain.EntityPosition"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Success"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "http.errorResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Code is the machine-readable code of the error, clients branch on it rather than on the message",
+                    "type": "string",
+                    "example": "LOCATION_NOT_FOUND"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Error message 1 - Error message 2"
+                },
+                "request_id": {
+                    "description": "RequestID identifies the request in the server logs, it is worth quoting in bug reports",
+                    "type": "string",
+                    "example": "cq3v1bb2ne1s73a0m2f0"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "http.geofenceResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/domain.Geofence"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Success"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "http.healthReportResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/domain.HealthReport"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Success"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "http.healthResponse": {
+            "type": "object",
+            "properties": {
+                "log_level": {
+                    "type": "string",
+                    "example": "info"
+                }
+            }
+        },
+        "http.importJobResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/domain.ImportJob"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Success"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "http.importPreviewResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/domain.ImportPreview"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Success"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "http.jobListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Job"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Success"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "http.jobResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/domain.Job"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Success"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "http.locationGeometryResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/domain.LocationGeometry"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Success"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "http.locationListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Location"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Success"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "http.locationLookupResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/domain.LocationLookup"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Success"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
         },
-        "http.errorResponse": {
+        "http.locationResponse": {
             "type": "object",
             "properties": {
+                "data": {
+                    "$ref": "#/definitions/domain.Location"
+                },
                 "message": {
                     "type": "string",
-                    "example": "Error message 1 - Error message 2"
+                    "example": "Success"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "http.locationSearchResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/domain.LocationSearchResult"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Success"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "http.logLevelRequest": {
+            "type": "object",
+            "required": [
+                "level"
+            ],
+            "properties": {
+                "level": {
+                    "type": "string",
+                    "enum": [
+                        "debug",
+                        "info",
+                        "warn"
+                    ],
+                    "example": "debug"
+                }
+            }
+        },
+        "http.logLevelResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/http.healthResponse"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Success"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "http.nearestEntityListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.NearestEntity"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Success"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "http.nearestLocationListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.NearestLocation"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Success"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "http.nearestLocationResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/domain.NearestLocation"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Success"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "http.pingResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/domain.Ping"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Success"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "http.pingStatsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/domain.PingStats"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Success"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "http.preconditionFailedResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "PRECONDITION_FAILED"
+                },
+                "data": {
+                    "$ref": "#/definitions/domain.Location"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "the location changed since the version or date the request is based on"
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "cq3v1bb2ne1s73a0m2f0"
                 },
                 "success": {
                     "type": "boolean",
@@ -386,10 +6422,184 @@ const docTemplate = `{
                 }
             }
         },
+        "http.quotaResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/domain.Quota"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Success"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
         "http.response": {
             "type": "object",
             "properties": {
-                "data": {},
+                "message": {
+                    "type": "string",
+                    "example": "Success"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "http.restoreReportResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/domain.RestoreReport"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Success"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "http.routeLocationListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.RouteLocation"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Success"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "http.shareListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.LocationShare"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Success"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "http.shareResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/domain.LocationShare"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Success"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "http.snapshotListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Snapshot"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Success"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "http.subscriptionResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/domain.Subscription"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Success"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "http.syncReportListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.SyncReport"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Success"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "http.syncReportResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/domain.SyncReport"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Success"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "http.trendingLocationListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.TrendingLocation"
+                    }
+                },
                 "message": {
                     "type": "string",
                     "example": "Success"
@@ -0,0 +1,63 @@
+// Package analytics counts the business operations, such as the locations registered or the nearest queries, by
+// tenant and outcome. The counters are Prometheus metrics served with the others, for product analytics rather than
+// for the health of the service
+package analytics
+
+import (
+	"context"
+	"net/http"
+
+	"leeta/internal/core/auth"
+	"leeta/internal/core/domain"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	locationsRegistered = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "leeta",
+		Subsystem: "business",
+		Name:      "locations_registered_total",
+		Help:      "Location registrations by tenant and outcome: success, client_error or error.",
+	}, []string{"tenant", "outcome"})
+
+	nearestQueries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "leeta",
+		Subsystem: "business",
+		Name:      "nearest_queries_total",
+		Help:      "Nearest location lookups and searches by tenant and outcome: success, client_error or error.",
+	}, []string{"tenant", "outcome"})
+
+	imports = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "leeta",
+		Subsystem: "business",
+		Name:      "imports_total",
+		Help:      "Location imports started by tenant and outcome: success, client_error or error.",
+	}, []string{"tenant", "outcome"})
+
+	webhookDeliveries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "leeta",
+		Subsystem: "business",
+		Name:      "webhook_deliveries_total",
+		Help:      "Proximity matches posted to the webhooks of subscriptions by tenant and outcome: success or error.",
+	}, []string{"tenant", "outcome"})
+)
+
+// count counts an operation made for the tenant of ctx that failed with cerr if not nil. The tenant is empty
+// for the callers without one
+func count(ctx context.Context, counter *prometheus.CounterVec, cerr domain.CError) {
+	counter.WithLabelValues(auth.Tenant(ctx), outcome(cerr)).Inc()
+}
+
+// outcome is success without an error, client_error for the errors caused by the request and error otherwise
+func outcome(cerr domain.CError) string {
+	switch {
+	case cerr == nil:
+		return "success"
+	case cerr.Code() < http.StatusInternalServerError:
+		return "client_error"
+	default:
+		return "error"
+	}
+}
@@ -0,0 +1,65 @@
+package analytics
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"leeta/internal/core/auth"
+	"leeta/internal/core/domain"
+	"leeta/internal/core/port/mock"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocationService(t *testing.T) {
+	ls := NewLocationService(&mock.LocationServiceMock{
+		RegisterLocationFunc: func(ctx context.Context, location *domain.RegisterLocationRequest) (*domain.Location, domain.CError) {
+			if location.Name == "" {
+				return nil, domain.NewBadRequestCError("name is required")
+			}
+			return &domain.Location{Name: location.Name}, nil
+		},
+		GetNearestLocationFunc: func(ctx context.Context, latitude, longitude float64, formula domain.DistanceFormula) (*domain.NearestLocation, domain.CError) {
+			return nil, domain.ErrInternal
+		},
+	})
+	acme := auth.WithPrincipal(context.Background(), &auth.Principal{User: "ada", Tenant: "acme"})
+
+	registered := testutil.ToFloat64(locationsRegistered.WithLabelValues("acme", "success"))
+	refused := testutil.ToFloat64(locationsRegistered.WithLabelValues("acme", "client_error"))
+	failed := testutil.ToFloat64(nearestQueries.WithLabelValues("", "error"))
+
+	ls.RegisterLocation(acme, &domain.RegisterLocationRequest{Name: "Lekki Mall"})
+	ls.RegisterLocation(acme, &domain.RegisterLocationRequest{})
+	ls.GetNearestLocation(context.Background(), 6.45, 3.47, "")
+
+	assert.Equal(t, registered+1, testutil.ToFloat64(locationsRegistered.WithLabelValues("acme", "success")))
+	assert.Equal(t, refused+1, testutil.ToFloat64(locationsRegistered.WithLabelValues("acme", "client_error")))
+	assert.Equal(t, failed+1, testutil.ToFloat64(nearestQueries.WithLabelValues("", "error")))
+}
+
+func TestWebhookDelivery(t *testing.T) {
+	wd := NewWebhookDelivery(&mock.MatchDeliveryMock{
+		DeliverFunc: func(ctx context.Context, subscription *domain.Subscription, match *domain.ProximityMatch) error {
+			if subscription.WebhookURL == "https://example.com/down" {
+				return errors.New("webhook https://example.com/down answered with status 503")
+			}
+			return nil
+		},
+	})
+	// the deliveries are counted for the tenant of the subscription, they are made in the background without a caller
+	ctx := context.Background()
+
+	delivered := testutil.ToFloat64(webhookDeliveries.WithLabelValues("acme", "success"))
+	failed := testutil.ToFloat64(webhookDeliveries.WithLabelValues("acme", "error"))
+
+	wd.Deliver(ctx, &domain.Subscription{Tenant: "acme", WebhookURL: "https://example.com/hook"}, &domain.ProximityMatch{})
+	wd.Deliver(ctx, &domain.Subscription{Tenant: "acme", WebhookURL: "https://example.com/down"}, &domain.ProximityMatch{})
+	// the subscriptions without a webhook are only delivered over WebSocket
+	wd.Deliver(ctx, &domain.Subscription{Tenant: "acme"}, &domain.ProximityMatch{})
+
+	assert.Equal(t, delivered+1, testutil.ToFloat64(webhookDeliveries.WithLabelValues("acme", "success")))
+	assert.Equal(t, failed+1, testutil.ToFloat64(webhookDeliveries.WithLabelValues("acme", "error")))
+}
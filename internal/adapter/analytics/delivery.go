@@ -0,0 +1,40 @@
+package analytics
+
+import (
+	"context"
+
+	"leeta/internal/core/domain"
+	"leeta/internal/core/port"
+)
+
+/**
+ * WebhookDelivery implements port.MatchDelivery interface
+ * and counts the matches another delivery posts to the
+ * webhooks of subscriptions
+ */
+type WebhookDelivery struct {
+	next port.MatchDelivery
+}
+
+// NewWebhookDelivery creates a new webhook delivery instance counting the deliveries of next
+func NewWebhookDelivery(next port.MatchDelivery) *WebhookDelivery {
+	return &WebhookDelivery{
+		next,
+	}
+}
+
+// Deliver delivers match with the next delivery, counted for the tenant of subscription. The subscriptions
+// without a webhook are not counted
+func (wd *WebhookDelivery) Deliver(ctx context.Context, subscription *domain.Subscription, match *domain.ProximityMatch) error {
+	err := wd.next.Deliver(ctx, subscription, match)
+	if subscription.WebhookURL == "" {
+		return err
+	}
+
+	result := "success"
+	if err != nil {
+		result = "error"
+	}
+	webhookDeliveries.WithLabelValues(subscription.Tenant, result).Inc()
+	return err
+}
@@ -0,0 +1,29 @@
+package analytics
+
+import (
+	"context"
+
+	"leeta/internal/core/domain"
+	"leeta/internal/core/port"
+)
+
+/**
+ * ImportService implements port.ImportService interface
+ * and counts the imports started with another import service
+ */
+type ImportService struct {
+	port.ImportService
+}
+
+// NewImportService creates a new import service instance counting the imports of next
+func NewImportService(next port.ImportService) *ImportService {
+	return &ImportService{
+		next,
+	}
+}
+
+func (is *ImportService) ImportLocations(ctx context.Context, locations []domain.RegisterLocationRequest) (*domain.ImportJob, domain.CError) {
+	job, cerr := is.ImportService.ImportLocations(ctx, locations)
+	count(ctx, imports, cerr)
+	return job, cerr
+}
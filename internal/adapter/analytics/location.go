@@ -0,0 +1,42 @@
+package analytics
+
+import (
+	"context"
+
+	"leeta/internal/core/domain"
+	"leeta/internal/core/port"
+)
+
+/**
+ * LocationService implements port.LocationService interface
+ * and counts the registrations and nearest queries made with
+ * another location service, the other calls are passed through
+ */
+type LocationService struct {
+	port.LocationService
+}
+
+// NewLocationService creates a new location service instance counting the operations of next
+func NewLocationService(next port.LocationService) *LocationService {
+	return &LocationService{
+		next,
+	}
+}
+
+func (ls *LocationService) RegisterLocation(ctx context.Context, location *domain.RegisterLocationRequest) (*domain.Location, domain.CError) {
+	registered, cerr := ls.LocationService.RegisterLocation(ctx, location)
+	count(ctx, locationsRegistered, cerr)
+	return registered, cerr
+}
+
+func (ls *LocationService) GetNearestLocation(ctx context.Context, latitude, longitude float64, formula domain.DistanceFormula) (*domain.NearestLocation, domain.CError) {
+	location, cerr := ls.LocationService.GetNearestLocation(ctx, latitude, longitude, formula)
+	count(ctx, nearestQueries, cerr)
+	return location, cerr
+}
+
+func (ls *LocationService) FindNearestLocations(ctx context.Context, req *domain.NearestLocationsRequest) ([]domain.NearestLocation, domain.CError) {
+	locations, cerr := ls.LocationService.FindNearestLocations(ctx, req)
+	count(ctx, nearestQueries, cerr)
+	return locations, cerr
+}
@@ -0,0 +1,80 @@
+package config
+
+import (
+	"log"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/viper"
+)
+
+/**
+ * Store holds the current configuration and notifies its
+ * subscribers when the config file changes. Only the tunable
+ * settings are applied by the subscribers, changing the other
+ * ones still requires a restart
+ */
+type Store struct {
+	mu      sync.RWMutex
+	current *Configuration
+	subs    []func(config *Configuration)
+}
+
+// NewStore creates a new configuration store holding config
+func NewStore(config *Configuration) *Store {
+	return &Store{
+		current: config,
+	}
+}
+
+// Get returns the current configuration, it must not be modified
+func (s *Store) Get() *Configuration {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.current
+}
+
+// Subscribe registers fn to be called with the new configuration after every reload
+func (s *Store) Subscribe(fn func(config *Configuration)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.subs = append(s.subs, fn)
+}
+
+// Reload reads the config file again and notifies the subscribers.
+// The current configuration is kept if the file cannot be read
+func (s *Store) Reload() error {
+	if err := viper.ReadInConfig(); err != nil {
+		return err
+	}
+
+	var config *Configuration
+	if err := viper.Unmarshal(&config); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.current = config
+	subs := append([]func(*Configuration){}, s.subs...)
+	s.mu.Unlock()
+
+	for _, fn := range subs {
+		fn(config)
+	}
+
+	return nil
+}
+
+// Watch reloads the configuration whenever the config file changes
+func (s *Store) Watch() {
+	viper.OnConfigChange(func(event fsnotify.Event) {
+		if err := s.Reload(); err != nil {
+			log.Printf("Error reloading config file, keeping the current configuration: %v", err)
+			return
+		}
+		log.Println("Configurations reloaded successfully")
+	})
+	viper.WatchConfig()
+}
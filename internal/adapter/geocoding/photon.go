@@ -0,0 +1,138 @@
+// Package geocoding suggests addresses with a Photon server
+package geocoding
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"leeta/internal/adapter/config"
+	"leeta/internal/core/domain"
+)
+
+const defaultTimeout = 3 * time.Second
+
+// languages are the languages Photon translates its answers to, it rejects the others
+var languages = map[string]bool{"en": true, "de": true, "fr": true}
+
+/**
+ * Photon implements port.Geocoder interface with the search
+ * API of a Photon server, built for search as you type
+ */
+type Photon struct {
+	client *http.Client
+	url    string
+}
+
+// New creates a new Photon client instance from the geocoding configuration
+func New(config *config.GeocodingConfiguration) *Photon {
+	timeout := config.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	return &Photon{
+		&http.Client{Timeout: timeout},
+		strings.TrimSuffix(config.URL, "/"),
+	}
+}
+
+// searchResponse is the answer of the search API, a GeoJSON feature collection of points
+type searchResponse struct {
+	Message  string `json:"message"`
+	Features []struct {
+		Geometry struct {
+			// Coordinates are longitude first
+			Coordinates []float64 `json:"coordinates"`
+		} `json:"geometry"`
+		Properties struct {
+			Name        string `json:"name"`
+			HouseNumber string `json:"housenumber"`
+			Street      string `json:"street"`
+			Postcode    string `json:"postcode"`
+			City        string `json:"city"`
+			State       string `json:"state"`
+			Country     string `json:"country"`
+			CountryCode string `json:"countrycode"`
+		} `json:"properties"`
+	} `json:"features"`
+}
+
+func (p *Photon) Autocomplete(ctx context.Context, req *domain.AutocompleteRequest) ([]domain.AddressSuggestion, error) {
+	query := url.Values{}
+	query.Set("q", req.Query)
+	query.Set("limit", strconv.Itoa(req.Limit))
+	if req.Near != nil {
+		query.Set("lat", strconv.FormatFloat(req.Near.Latitude, 'f', -1, 64))
+		query.Set("lon", strconv.FormatFloat(req.Near.Longitude, 'f', -1, 64))
+	}
+	if languages[req.Language] {
+		query.Set("lang", req.Language)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, p.url+"/api?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := p.client.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var search searchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&search); err != nil {
+		return nil, fmt.Errorf("decoding Photon answer with status %d: %w", resp.StatusCode, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Photon answered with status %d: %s", resp.StatusCode, search.Message)
+	}
+
+	suggestions := make([]domain.AddressSuggestion, 0, len(search.Features))
+	for _, feature := range search.Features {
+		if len(feature.Geometry.Coordinates) < 2 {
+			continue
+		}
+
+		properties := feature.Properties
+		suggestion := domain.AddressSuggestion{
+			Name:        properties.Name,
+			HouseNumber: properties.HouseNumber,
+			Street:      properties.Street,
+			Postcode:    properties.Postcode,
+			City:        properties.City,
+			State:       properties.State,
+			Country:     properties.Country,
+			CountryCode: strings.ToUpper(properties.CountryCode),
+			Latitude:    feature.Geometry.Coordinates[1],
+			Longitude:   feature.Geometry.Coordinates[0],
+		}
+		suggestion.Label = label(&suggestion)
+		suggestions = append(suggestions, suggestion)
+	}
+
+	return suggestions, nil
+}
+
+// label joins the parts of an address from the most to the least precise, leaving out the parts
+// repeating the previous one, e.g. the name of a city
+func label(s *domain.AddressSuggestion) string {
+	street := strings.TrimSpace(s.HouseNumber + " " + s.Street)
+	city := strings.TrimSpace(s.Postcode + " " + s.City)
+
+	parts := make([]string, 0, 5)
+	for _, part := range []string{s.Name, street, city, s.State, s.Country} {
+		if part == "" || (len(parts) > 0 && strings.Contains(parts[len(parts)-1], part)) {
+			continue
+		}
+		parts = append(parts, part)
+	}
+
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,71 @@
+package geocoding
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"leeta/internal/adapter/config"
+	"leeta/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPhoton_Autocomplete(t *testing.T) {
+	var path, query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path, query = r.URL.Path, r.URL.RawQuery
+		w.Write([]byte(`{"type":"FeatureCollection","features":[
+			{"type":"Feature","geometry":{"type":"Point","coordinates":[3.4723,6.4474]},
+			 "properties":{"name":"Lekki Phase 1","housenumber":"12","street":"Admiralty Way","city":"Lagos","state":"Lagos","country":"Nigeria","countrycode":"ng"}},
+			{"type":"Feature","geometry":{"type":"Point","coordinates":[3.3792,6.5244]},
+			 "properties":{"name":"Lagos","state":"Lagos","country":"Nigeria","countrycode":"NG"}}
+		]}`))
+	}))
+	defer server.Close()
+
+	photon := New(&config.GeocodingConfiguration{URL: server.URL + "/"})
+
+	suggestions, err := photon.Autocomplete(context.Background(), &domain.AutocompleteRequest{
+		Query:    "admiralty w",
+		Limit:    5,
+		Near:     &domain.Point{Latitude: 6.45, Longitude: 3.47},
+		Language: "en",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "/api", path)
+	assert.Equal(t, "lang=en&lat=6.45&limit=5&lon=3.47&q=admiralty+w", query)
+	assert.Equal(t, []domain.AddressSuggestion{
+		{
+			Label: "Lekki Phase 1, 12 Admiralty Way, Lagos, Nigeria", Name: "Lekki Phase 1", HouseNumber: "12",
+			Street: "Admiralty Way", City: "Lagos", State: "Lagos", Country: "Nigeria", CountryCode: "NG",
+			Latitude: 6.4474, Longitude: 3.4723,
+		},
+		{
+			Label: "Lagos, Nigeria", Name: "Lagos", State: "Lagos", Country: "Nigeria", CountryCode: "NG",
+			Latitude: 6.5244, Longitude: 3.3792,
+		},
+	}, suggestions)
+
+	t.Run("Unsupported languages are left out", func(t *testing.T) {
+		_, err := photon.Autocomplete(context.Background(), &domain.AutocompleteRequest{Query: "lagos", Limit: 5, Language: "yo"})
+		require.NoError(t, err)
+		assert.Equal(t, "limit=5&q=lagos", query)
+	})
+}
+
+func TestPhoton_AutocompleteError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"message":"missing search term 'q': /api?q=berlin"}`))
+	}))
+	defer server.Close()
+
+	photon := New(&config.GeocodingConfiguration{URL: server.URL})
+
+	_, err := photon.Autocomplete(context.Background(), &domain.AutocompleteRequest{Query: "lagos", Limit: 5})
+	assert.ErrorContains(t, err, "missing search term")
+}
@@ -0,0 +1,47 @@
+package grpc
+
+import (
+	"context"
+
+	"leeta/internal/core/domain"
+	"leeta/internal/core/port"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// serviceName is the name the health of the service is checked by, besides the empty one of the server
+const serviceName = "leeta"
+
+/**
+ * HealthHandler implements grpc_health_v1.HealthServer
+ * interface, it checks the dependencies of the service on
+ * every call as the HTTP health check does
+ */
+type HealthHandler struct {
+	grpc_health_v1.UnimplementedHealthServer
+	svc port.HealthService
+}
+
+// NewHealthHandler creates a new health handler instance
+func NewHealthHandler(svc port.HealthService) *HealthHandler {
+	return &HealthHandler{
+		svc: svc,
+	}
+}
+
+// Check reports the service as serving unless a critical dependency is down, a degraded service still serves.
+// Watch is not implemented, the clients poll Check
+func (hh *HealthHandler) Check(ctx context.Context, req *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+	if req.GetService() != "" && req.GetService() != serviceName {
+		return nil, status.Errorf(codes.NotFound, "unknown service %q", req.GetService())
+	}
+
+	serving := grpc_health_v1.HealthCheckResponse_SERVING
+	if hh.svc.Report(ctx).Status == domain.HealthDown {
+		serving = grpc_health_v1.HealthCheckResponse_NOT_SERVING
+	}
+
+	return &grpc_health_v1.HealthCheckResponse{Status: serving}, nil
+}
@@ -0,0 +1,82 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+
+	"leeta/internal/adapter/config"
+	"leeta/internal/core/port"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+/**
+ * Server is a wrapper for the gRPC server of the service, it
+ * serves the health checking protocol, grpc.health.v1, with the
+ * health of the dependencies, and the reflection of its services
+ */
+type Server struct {
+	*grpc.Server
+	Addr string
+}
+
+// NewServer creates the gRPC server listening on the gRPC port, bound to the HTTP address, reporting the health of
+// health. It serves TLS with the certificate files of the HTTP server, the certificates obtained with autocert are
+// only served over HTTP
+func NewServer(config *config.ServerConfiguration, health port.HealthService) (*Server, error) {
+	if config.GrpcPort == config.HttpPort || config.GrpcPort == config.AdminPort {
+		return nil, errors.New("the gRPC port must differ from the HTTP and admin ports")
+	}
+
+	var options []grpc.ServerOption
+	if tlsConfig := &config.TLS; tlsConfig.CertFile != "" {
+		creds, err := credentials.NewServerTLSFromFile(tlsConfig.CertFile, tlsConfig.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("loading the gRPC certificate: %w", err)
+		}
+		options = append(options, grpc.Creds(creds))
+	} else if tlsConfig.Autocert {
+		return nil, errors.New("tls: the gRPC server needs a certificate file, autocert only serves HTTPS")
+	}
+
+	server := grpc.NewServer(options...)
+	grpc_health_v1.RegisterHealthServer(server, NewHealthHandler(health))
+	reflection.Register(server)
+
+	return &Server{
+		server,
+		fmt.Sprintf("%s:%s", config.HttpUrl, config.GrpcPort),
+	}, nil
+}
+
+// ListenAndServe listens on the address of the server and serves until it is shut down
+func (s *Server) ListenAndServe() error {
+	lis, err := net.Listen("tcp", s.Addr)
+	if err != nil {
+		return err
+	}
+	return s.Serve(lis)
+}
+
+// Shutdown stops accepting connections and waits for the pending calls to finish, the calls still running once
+// ctx is done are cancelled
+func (s *Server) Shutdown(ctx context.Context) error {
+	stopped := make(chan struct{})
+	go func() {
+		s.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		s.Stop()
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,85 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"leeta/internal/adapter/config"
+	"leeta/internal/core/domain"
+	"leeta/internal/core/port/mock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestServer_Health(t *testing.T) {
+	report := &domain.HealthReport{Status: domain.HealthUp}
+	server, err := NewServer(&config.ServerConfiguration{HttpUrl: "127.0.0.1", HttpPort: "8080", GrpcPort: "9090"}, &mock.HealthServiceMock{
+		ReportFunc: func(ctx context.Context) *domain.HealthReport {
+			return report
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "127.0.0.1:9090", server.Addr)
+
+	lis := bufconn.Listen(1 << 20)
+	served := make(chan error, 1)
+	go func() {
+		served <- server.Serve(lis)
+	}()
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+	client := grpc_health_v1.NewHealthClient(conn)
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		service string
+		status  domain.HealthStatus
+		serving grpc_health_v1.HealthCheckResponse_ServingStatus
+	}{
+		{"Up", "", domain.HealthUp, grpc_health_v1.HealthCheckResponse_SERVING},
+		{"Degraded still serves", "leeta", domain.HealthDegraded, grpc_health_v1.HealthCheckResponse_SERVING},
+		{"Down", "leeta", domain.HealthDown, grpc_health_v1.HealthCheckResponse_NOT_SERVING},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report.Status = tt.status
+			resp, err := client.Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: tt.service})
+			require.NoError(t, err)
+			assert.Equal(t, tt.serving, resp.GetStatus())
+		})
+	}
+
+	t.Run("Unknown service", func(t *testing.T) {
+		_, err := client.Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: "payments"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	// the server stops serving once shut down
+	shutdownCtx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	require.NoError(t, server.Shutdown(shutdownCtx))
+	assert.NoError(t, <-served)
+}
+
+func TestNewServer(t *testing.T) {
+	_, err := NewServer(&config.ServerConfiguration{HttpPort: "8080", GrpcPort: "8080"}, &mock.HealthServiceMock{})
+	assert.EqualError(t, err, "the gRPC port must differ from the HTTP and admin ports")
+
+	_, err = NewServer(&config.ServerConfiguration{HttpPort: "8080", GrpcPort: "9090", TLS: config.TLSConfiguration{Autocert: true}}, &mock.HealthServiceMock{})
+	assert.EqualError(t, err, "tls: the gRPC server needs a certificate file, autocert only serves HTTPS")
+}
@@ -0,0 +1,153 @@
+package http
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+
+	"leeta/internal/adapter/config"
+	"leeta/internal/adapter/logger"
+	"leeta/internal/core/domain"
+
+	"go.uber.org/zap"
+)
+
+/**
+ * ipAccess restricts routes to clients in the allowed ranges and
+ * outside the denied ones. The client address is read from
+ * X-Forwarded-For only when the request comes through a trusted proxy
+ */
+type ipAccess struct {
+	allow   []netip.Prefix
+	deny    []netip.Prefix
+	proxies []netip.Prefix
+}
+
+// newIPAccess creates the access rules of config, an empty allowlist allows every address
+func newIPAccess(config *config.AccessConfiguration) (*ipAccess, error) {
+	allow, err := parsePrefixes(config.Allow)
+	if err != nil {
+		return nil, fmt.Errorf("invalid allowed range: %w", err)
+	}
+
+	deny, err := parsePrefixes(config.Deny)
+	if err != nil {
+		return nil, fmt.Errorf("invalid denied range: %w", err)
+	}
+
+	proxies, err := parsePrefixes(config.TrustedProxies)
+	if err != nil {
+		return nil, fmt.Errorf("invalid trusted proxy: %w", err)
+	}
+
+	return &ipAccess{
+		allow,
+		deny,
+		proxies,
+	}, nil
+}
+
+// parsePrefixes parses CIDR ranges, a bare address is a range of its own. IPv4-mapped IPv6 ranges are
+// turned into IPv4 ones, since the client addresses they are matched against are unmapped
+func parsePrefixes(ranges []string) ([]netip.Prefix, error) {
+	var prefixes []netip.Prefix
+	for _, r := range ranges {
+		r = strings.TrimSpace(r)
+		if !strings.Contains(r, "/") {
+			addr, err := netip.ParseAddr(r)
+			if err != nil {
+				return nil, err
+			}
+			prefixes = append(prefixes, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+			continue
+		}
+
+		prefix, err := netip.ParsePrefix(r)
+		if err != nil {
+			return nil, err
+		}
+		if prefix.Addr().Is4In6() && prefix.Bits() >= 96 {
+			prefix = netip.PrefixFrom(prefix.Addr().Unmap(), prefix.Bits()-96)
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+
+	return prefixes, nil
+}
+
+// middleware rejects the requests of clients that are not allowed with 403 Forbidden
+func (ia *ipAccess) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		addr, ok := ia.clientAddr(r)
+		if !ok || !ia.allowed(addr) {
+			logger.FromCtx(r.Context()).Warn("Request from a disallowed address",
+				zap.String("remote_addr", r.RemoteAddr), zap.String("client_addr", addr.String()))
+			handleError(w, domain.ErrForbidden)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// allowed reports whether addr is outside the denied ranges and inside the allowed ones
+func (ia *ipAccess) allowed(addr netip.Addr) bool {
+	if contains(ia.deny, addr) {
+		return false
+	}
+
+	return len(ia.allow) == 0 || contains(ia.allow, addr)
+}
+
+// clientAddr returns the address of the client. Behind trusted proxies it is the last
+// X-Forwarded-For entry not added by one of them, since clients can forge the earlier ones
+func (ia *ipAccess) clientAddr(r *http.Request) (netip.Addr, bool) {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return netip.Addr{}, false
+	}
+	addr = addr.Unmap()
+
+	if !contains(ia.proxies, addr) {
+		return addr, true
+	}
+
+	forwarded := strings.Split(strings.Join(r.Header.Values("X-Forwarded-For"), ","), ",")
+	for i := len(forwarded) - 1; i >= 0; i-- {
+		entry := strings.TrimSpace(forwarded[i])
+		if entry == "" {
+			continue
+		}
+
+		hop, err := netip.ParseAddr(entry)
+		if err != nil {
+			return netip.Addr{}, false
+		}
+
+		addr = hop.Unmap()
+		if !contains(ia.proxies, addr) {
+			return addr, true
+		}
+	}
+
+	// every hop is a trusted proxy, so the request comes from the proxies themselves
+	return addr, true
+}
+
+// contains reports whether addr is in one of prefixes
+func contains(prefixes []netip.Prefix, addr netip.Addr) bool {
+	for _, prefix := range prefixes {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,148 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"leeta/internal/adapter/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIPAccess_ClientAddr(t *testing.T) {
+	access, err := newIPAccess(&config.AccessConfiguration{TrustedProxies: []string{"10.1.0.0/16", "172.16.0.1"}})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		forwarded  []string
+		// client is the address read, empty when the request is rejected
+		client string
+	}{
+		{"Direct peer", "203.0.113.9:51234", nil, "203.0.113.9"},
+		{"Peer without a port", "203.0.113.9", nil, "203.0.113.9"},
+		{"Spoofed header from an untrusted peer", "203.0.113.9:51234", []string{"10.0.0.5"}, "203.0.113.9"},
+		{"One trusted proxy", "10.1.1.1:443", []string{"198.51.100.4"}, "198.51.100.4"},
+		{"Trusted proxy without the header", "10.1.1.1:443", nil, "10.1.1.1"},
+		{"Chain of trusted proxies", "10.1.1.1:443", []string{"198.51.100.4, 172.16.0.1, 10.1.2.2"}, "198.51.100.4"},
+		{"Right-most untrusted hop wins over forged ones", "10.1.1.1:443", []string{"192.0.2.66, 198.51.100.4, 10.1.2.2"}, "198.51.100.4"},
+		{"Headers joined in order", "10.1.1.1:443", []string{"192.0.2.66", "198.51.100.4, 10.1.2.2"}, "198.51.100.4"},
+		{"Every hop trusted", "10.1.1.1:443", []string{"172.16.0.1, 10.1.2.2"}, "172.16.0.1"},
+		{"Empty entries skipped", "10.1.1.1:443", []string{"198.51.100.4, , "}, "198.51.100.4"},
+		{"IPv4-mapped IPv6 peer is a trusted proxy", "[::ffff:10.1.1.1]:443", []string{"198.51.100.4"}, "198.51.100.4"},
+		{"IPv4-mapped IPv6 peer without proxy", "[::ffff:203.0.113.9]:443", nil, "203.0.113.9"},
+		{"IPv4-mapped IPv6 hop", "10.1.1.1:443", []string{"::ffff:198.51.100.4"}, "198.51.100.4"},
+		{"IPv6 hop", "10.1.1.1:443", []string{"2001:db8::7"}, "2001:db8::7"},
+		{"Malformed hop", "10.1.1.1:443", []string{"198.51.100.4, not-an-ip"}, ""},
+		{"Hop with a port", "10.1.1.1:443", []string{"198.51.100.4:8080"}, ""},
+		{"Malformed hop before the client is ignored", "10.1.1.1:443", []string{"not-an-ip, 198.51.100.4"}, "198.51.100.4"},
+		{"Malformed peer", "unknown", nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/v1/admin/quotas/acme", nil)
+			req.RemoteAddr = tt.remoteAddr
+			for _, value := range tt.forwarded {
+				req.Header.Add("X-Forwarded-For", value)
+			}
+
+			addr, ok := access.clientAddr(req)
+			if tt.client == "" {
+				assert.False(t, ok)
+				return
+			}
+
+			require.True(t, ok)
+			assert.Equal(t, tt.client, addr.String())
+		})
+	}
+}
+
+func TestIPAccess_Allowed(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  config.AccessConfiguration
+		addr    string
+		allowed bool
+	}{
+		{"No rules", config.AccessConfiguration{}, "203.0.113.9", true},
+		{"Inside an allowed range", config.AccessConfiguration{Allow: []string{"10.0.0.0/8"}}, "10.2.3.4", true},
+		{"Outside the allowed ranges", config.AccessConfiguration{Allow: []string{"10.0.0.0/8"}}, "11.0.0.1", false},
+		{"Allowed bare address", config.AccessConfiguration{Allow: []string{"192.0.2.1"}}, "192.0.2.1", true},
+		{"Bare address is a range of one", config.AccessConfiguration{Allow: []string{"192.0.2.1"}}, "192.0.2.2", false},
+		{"Range with host bits is masked", config.AccessConfiguration{Allow: []string{"192.0.2.77/24"}}, "192.0.2.1", true},
+		{"Ranges with spaces", config.AccessConfiguration{Allow: []string{" 192.0.2.0/24 "}}, "192.0.2.1", true},
+		{"Deny wins over allow", config.AccessConfiguration{Allow: []string{"10.0.0.0/8"}, Deny: []string{"10.0.0.5"}}, "10.0.0.5", false},
+		{"Deny range wins over an allowed address", config.AccessConfiguration{Allow: []string{"10.0.0.5"}, Deny: []string{"10.0.0.0/24"}}, "10.0.0.5", false},
+		{"Allowed next to a denied address", config.AccessConfiguration{Allow: []string{"10.0.0.0/8"}, Deny: []string{"10.0.0.5"}}, "10.0.0.6", true},
+		{"Denied without an allowlist", config.AccessConfiguration{Deny: []string{"203.0.113.0/24"}}, "203.0.113.9", false},
+		{"IPv6 range", config.AccessConfiguration{Allow: []string{"2001:db8::/32"}}, "2001:db8::7", true},
+		{"IPv4-mapped allowed address", config.AccessConfiguration{Allow: []string{"::ffff:192.0.2.1"}}, "192.0.2.1", true},
+		{"IPv4-mapped denied range", config.AccessConfiguration{Deny: []string{"::ffff:10.0.0.0/104"}}, "10.2.3.4", false},
+		{"IPv4 range does not cover IPv6", config.AccessConfiguration{Allow: []string{"0.0.0.0/0"}}, "2001:db8::7", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			access, err := newIPAccess(&tt.config)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.allowed, access.allowed(netip.MustParseAddr(tt.addr)))
+		})
+	}
+}
+
+func TestIPAccess_Middleware(t *testing.T) {
+	access, err := newIPAccess(&config.AccessConfiguration{
+		Allow:          []string{"198.51.100.0/24"},
+		Deny:           []string{"198.51.100.66"},
+		TrustedProxies: []string{"10.1.0.0/16"},
+	})
+	require.NoError(t, err)
+
+	handler := access.middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		forwarded  string
+		status     int
+	}{
+		{"Allowed client through the proxy", "10.1.1.1:443", "198.51.100.4", http.StatusOK},
+		{"Denied client through the proxy", "10.1.1.1:443", "198.51.100.66", http.StatusForbidden},
+		{"Allowed address forged by an untrusted peer", "203.0.113.9:51234", "198.51.100.4", http.StatusForbidden},
+		{"Allowed address forged before the proxy", "10.1.1.1:443", "198.51.100.4, 203.0.113.9", http.StatusForbidden},
+		{"Malformed header", "10.1.1.1:443", "198.51.100.4, ???", http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/v1/admin/quotas/acme", nil)
+			req.RemoteAddr = tt.remoteAddr
+			req.Header.Set("X-Forwarded-For", tt.forwarded)
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.status, w.Code)
+		})
+	}
+}
+
+func TestNewIPAccess_Invalid(t *testing.T) {
+	for _, c := range []config.AccessConfiguration{
+		{Allow: []string{"10.0.0.0/33"}},
+		{Deny: []string{"not-an-ip"}},
+		{TrustedProxies: []string{"10.0.0"}},
+	} {
+		_, err := newIPAccess(&c)
+		assert.Error(t, err, c)
+	}
+}
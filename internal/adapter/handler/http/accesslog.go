@@ -0,0 +1,204 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strings"
+
+	"leeta/internal/adapter/config"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// accessRecordCtxKey is the key for the access record of the request
+	accessRecordCtxKey contextKey = "access_record"
+
+	// redacted replaces the logged values of sensitive headers and body fields
+	redacted = "[REDACTED]"
+
+	defaultAccessLogMaxBodySize = 4 << 10
+)
+
+// alwaysRedactedHeaders carry credentials, so they are never logged in clear
+var alwaysRedactedHeaders = []string{"Authorization", "Cookie", "Proxy-Authorization"}
+
+// accessLog decides which requests are logged and what their access log lines carry
+type accessLog struct {
+	// sampling is the share of the successful requests logged, keyed by "METHOD pattern"
+	sampling      map[string]float64
+	headers       []string
+	redactHeaders map[string]bool
+	body          bool
+	maxBodySize   int
+	redactFields  map[string]bool
+}
+
+// newAccessLog builds the access log rules from config
+func newAccessLog(config *config.AccessLogConfiguration) (*accessLog, error) {
+	al := &accessLog{
+		sampling:      make(map[string]float64, len(config.Sampling)),
+		redactHeaders: make(map[string]bool),
+		body:          config.Body,
+		maxBodySize:   config.MaxBodySize,
+		redactFields:  make(map[string]bool, len(config.RedactFields)),
+	}
+
+	if al.maxBodySize <= 0 {
+		al.maxBodySize = defaultAccessLogMaxBodySize
+	}
+
+	for _, s := range config.Sampling {
+		if s.Pattern == "" {
+			return nil, fmt.Errorf("sampled route is missing a pattern")
+		}
+		if s.Rate < 0 || s.Rate > 1 {
+			return nil, fmt.Errorf("invalid sampling rate %v for %s, expected a rate between 0 and 1", s.Rate, s.Pattern)
+		}
+
+		method := strings.ToUpper(s.Method)
+		if method == "" {
+			method = "*"
+		}
+
+		al.sampling[method+" "+s.Pattern] = s.Rate
+	}
+
+	for _, h := range config.Headers {
+		al.headers = append(al.headers, http.CanonicalHeaderKey(h))
+	}
+
+	for _, h := range append(alwaysRedactedHeaders, config.RedactHeaders...) {
+		al.redactHeaders[http.CanonicalHeaderKey(h)] = true
+	}
+
+	for _, f := range config.RedactFields {
+		al.redactFields[strings.ToLower(f)] = true
+	}
+
+	return al, nil
+}
+
+// sampled reports whether a request to the route pattern answered with status is logged.
+// Failed requests are always logged
+func (al *accessLog) sampled(method, pattern string, status int) bool {
+	if status >= http.StatusBadRequest {
+		return true
+	}
+
+	rate, ok := al.sampling[method+" "+pattern]
+	if !ok {
+		rate, ok = al.sampling["* "+pattern]
+	}
+	if !ok {
+		return true
+	}
+
+	return rand.Float64() < rate
+}
+
+// headerFields returns the configured request headers, redacting the sensitive ones
+func (al *accessLog) headerFields(h http.Header) map[string]string {
+	if len(al.headers) == 0 {
+		return nil
+	}
+
+	headers := make(map[string]string, len(al.headers))
+	for _, name := range al.headers {
+		value := h.Get(name)
+		if value == "" {
+			continue
+		}
+
+		if al.redactHeaders[name] {
+			value = redacted
+		}
+		headers[name] = value
+	}
+
+	return headers
+}
+
+// bodyField returns the recorded JSON body with the sensitive fields redacted
+func (al *accessLog) bodyField(body *bodyRecorder) zap.Field {
+	if body.truncated {
+		return zap.String("body", "[TRUNCATED]")
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body.buf.Bytes()))
+	decoder.UseNumber()
+
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return zap.String("body", "[NOT JSON]")
+	}
+
+	redactedBody, err := json.Marshal(al.redact(value))
+	if err != nil {
+		return zap.Skip()
+	}
+
+	return zap.String("body", string(redactedBody))
+}
+
+// redact replaces the values of the redacted fields of a decoded JSON value, at any depth
+func (al *accessLog) redact(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, field := range v {
+			if al.redactFields[strings.ToLower(key)] {
+				v[key] = redacted
+				continue
+			}
+			v[key] = al.redact(field)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = al.redact(item)
+		}
+	}
+
+	return value
+}
+
+// accessRecord collects what the middlewares learn about the request for its access log line
+type accessRecord struct {
+	user string
+}
+
+// setAccessUser records who made the request, once a middleware authenticated it
+func setAccessUser(ctx context.Context, user string) {
+	if record, ok := ctx.Value(accessRecordCtxKey).(*accessRecord); ok {
+		record.user = user
+	}
+}
+
+// bodyRecorder keeps a copy of the first bytes of the request body read by the handler
+type bodyRecorder struct {
+	io.ReadCloser
+	buf       bytes.Buffer
+	limit     int
+	truncated bool
+}
+
+func newBodyRecorder(body io.ReadCloser, limit int) *bodyRecorder {
+	return &bodyRecorder{ReadCloser: body, limit: limit}
+}
+
+func (br *bodyRecorder) Read(p []byte) (int, error) {
+	n, err := br.ReadCloser.Read(p)
+
+	if room := br.limit - br.buf.Len(); n > room {
+		br.buf.Write(p[:max(room, 0)])
+		br.truncated = true
+	} else {
+		br.buf.Write(p[:n])
+	}
+
+	return n, err
+}
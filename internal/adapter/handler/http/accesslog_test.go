@@ -0,0 +1,84 @@
+package http
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"leeta/internal/adapter/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccessLog_Sampled(t *testing.T) {
+	al, err := newAccessLog(&config.AccessLogConfiguration{
+		Sampling: []config.AccessLogSamplingConfiguration{
+			{Method: "GET", Pattern: "/v1/health/", Rate: 0},
+			{Pattern: "/v1/locations/nearest", Rate: 1},
+		},
+	})
+	require.NoError(t, err)
+
+	assert.False(t, al.sampled(http.MethodGet, "/v1/health/", http.StatusOK), "successes of a route sampled at 0 are dropped")
+	assert.True(t, al.sampled(http.MethodGet, "/v1/health/", http.StatusServiceUnavailable), "failures are always logged")
+	assert.True(t, al.sampled(http.MethodPost, "/v1/health/", http.StatusCreated), "other methods are not sampled")
+	assert.True(t, al.sampled(http.MethodPost, "/v1/locations/nearest", http.StatusOK), "rules without a method match every method")
+	assert.True(t, al.sampled(http.MethodGet, "/v1/locations/", http.StatusOK), "routes without a rule are always logged")
+
+	_, err = newAccessLog(&config.AccessLogConfiguration{
+		Sampling: []config.AccessLogSamplingConfiguration{{Pattern: "/v1/health/", Rate: 1.5}},
+	})
+	assert.Error(t, err)
+}
+
+func TestAccessLog_HeaderFields(t *testing.T) {
+	al, err := newAccessLog(&config.AccessLogConfiguration{
+		Headers:       []string{"user-agent", "Authorization", "X-Api-Key", "X-Missing"},
+		RedactHeaders: []string{"x-api-key"},
+	})
+	require.NoError(t, err)
+
+	h := http.Header{}
+	h.Set("User-Agent", "curl/8.0")
+	h.Set("Authorization", "Bearer secret")
+	h.Set("X-Api-Key", "secret")
+
+	assert.Equal(t, map[string]string{
+		"User-Agent":    "curl/8.0",
+		"Authorization": redacted,
+		"X-Api-Key":     redacted,
+	}, al.headerFields(h))
+}
+
+func TestAccessLog_BodyField(t *testing.T) {
+	al, err := newAccessLog(&config.AccessLogConfiguration{
+		Body:         true,
+		MaxBodySize:  64,
+		RedactFields: []string{"Token"},
+	})
+	require.NoError(t, err)
+
+	record := func(body string) *bodyRecorder {
+		br := newBodyRecorder(io.NopCloser(strings.NewReader(body)), al.maxBodySize)
+		_, err := io.ReadAll(br)
+		require.NoError(t, err)
+		return br
+	}
+
+	t.Run("Redacts nested fields", func(t *testing.T) {
+		field := al.bodyField(record(`{"name":"Hub","token":"a","items":[{"TOKEN":"b","n":1.5}]}`))
+		assert.Equal(t, `{"items":[{"TOKEN":"[REDACTED]","n":1.5}],"name":"Hub","token":"[REDACTED]"}`, field.String)
+	})
+
+	t.Run("Truncated", func(t *testing.T) {
+		field := al.bodyField(record(`{"name":"` + strings.Repeat("a", 100) + `"}`))
+		assert.Equal(t, "[TRUNCATED]", field.String)
+	})
+
+	t.Run("Not JSON", func(t *testing.T) {
+		field := al.bodyField(record("name,latitude,longitude\n"))
+		assert.Equal(t, "[NOT JSON]", field.String)
+	})
+}
@@ -0,0 +1,66 @@
+package http
+
+import (
+	"net/http"
+
+	"leeta/internal/adapter/logger"
+
+	"github.com/go-playground/validator/v10"
+	"go.uber.org/zap"
+)
+
+// AdminHandler represents the HTTP handler for administration requests
+type AdminHandler struct {
+	validate *validator.Validate
+}
+
+// NewAdminHandler creates a new AdminHandler instance
+func NewAdminHandler(vld *validator.Validate) *AdminHandler {
+	return &AdminHandler{
+		vld,
+	}
+}
+
+// logLevelRequest represents the request body to change the log level
+type logLevelRequest struct {
+	Level string `json:"level" validate:"required,oneof=debug info warn" example:"debug"`
+}
+
+// SetLogLevel godoc
+//
+//	@Summary		Change the log level
+//	@Description	switch the log level of the running server, it lasts until the server restarts or the config file is reloaded
+//	@Tags			Admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			logLevelRequest	body		logLevelRequest		true	"Log level"
+//	@Success		200				{object}	logLevelResponse	"Log level changed"
+//	@Failure		400				{object}	errorResponse		"Validation error"
+//	@Failure		413				{object}	errorResponse		"Request body too large"
+//	@Failure		401				{object}	errorResponse		"Unauthorized error"
+//	@Failure		500				{object}	errorResponse		"Internal server error"
+//	@Router			/admin/log-level [put]
+//	@Security		BearerAuth
+func (ah *AdminHandler) SetLogLevel(w http.ResponseWriter, r *http.Request) {
+	var req logLevelRequest
+	if cerr := decodeJSON(r, &req); cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+
+	if err := ah.validate.Struct(&req); err != nil {
+		validationError(w, err)
+		return
+	}
+
+	previous := logger.Level()
+	if err := logger.SetLevel(req.Level); err != nil {
+		validationError(w, err)
+		return
+	}
+
+	logger.FromCtx(r.Context()).Warn("Changed the log level",
+		zap.String("previous", previous), zap.String("level", req.Level))
+
+	handleSuccessWithMessage(w, http.StatusOK, healthResponse{logger.Level()}, "Log level changed")
+}
@@ -0,0 +1,32 @@
+package http
+
+import (
+	"embed"
+	"io/fs"
+	"net/http"
+)
+
+//go:embed adminui
+var adminUIFiles embed.FS
+
+// adminUIPolicy is the Content-Security-Policy of the admin UI. Leaflet and the map tiles come from their CDNs,
+// and the API may be served on another origin than the UI
+const adminUIPolicy = "default-src 'self'; script-src 'self' https://unpkg.com; style-src 'self' https://unpkg.com; " +
+	"img-src 'self' data: https://*.tile.openstreetmap.org https://unpkg.com; connect-src *; frame-ancestors 'none'"
+
+// adminUI serves the static files of the admin UI under /admin. They hold no data, the UI calls the JSON API
+// with the token the admin signs in with
+func adminUI() http.Handler {
+	// the directory is embedded, so it is always there
+	files, err := fs.Sub(adminUIFiles, "adminui")
+	if err != nil {
+		panic(err)
+	}
+	fileServer := http.StripPrefix("/admin", http.FileServer(http.FS(files)))
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Security-Policy", adminUIPolicy)
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		fileServer.ServeHTTP(w, r)
+	})
+}
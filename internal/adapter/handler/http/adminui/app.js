@@ -0,0 +1,264 @@
+// The admin UI calls the JSON API with the token entered on sign in. The admin routes are served by the listener
+// serving the UI, the other routes by the public API, the same one unless its URL is entered on sign in.
+(function () {
+  "use strict";
+
+  const session = window.sessionStorage;
+  const $ = (selector) => document.querySelector(selector);
+
+  let map;
+  let markers;
+  const imports = new Map();
+
+  function status(message, error) {
+    const el = $("#status");
+    el.textContent = message || "";
+    el.classList.toggle("error", Boolean(error));
+  }
+
+  // api calls path, on the public API unless admin is set, and returns the data of the response
+  async function api(path, options = {}, admin = false) {
+    const base = admin ? "" : session.getItem("api") || "";
+    const headers = Object.assign({ Authorization: "Bearer " + session.getItem("token") }, options.headers);
+    const response = await fetch(base + path, Object.assign({}, options, { headers }));
+    const body = await response.json().catch(() => ({}));
+    if (!response.ok || body.success === false) {
+      if (response.status === 401 || response.status === 403) {
+        signOut();
+      }
+      const error = new Error(body.message || response.statusText);
+      error.code = body.code;
+      throw error;
+    }
+    return body.data;
+  }
+
+  function cell(row, text) {
+    const td = document.createElement("td");
+    td.textContent = text === undefined || text === null ? "" : String(text);
+    row.appendChild(td);
+    return td;
+  }
+
+  function button(parent, label, onClick) {
+    const b = document.createElement("button");
+    b.type = "button";
+    b.textContent = label;
+    b.addEventListener("click", onClick);
+    parent.appendChild(b);
+    return b;
+  }
+
+  // Tabs
+
+  function show(tab) {
+    for (const b of document.querySelectorAll("#tabs [data-tab]")) {
+      b.classList.toggle("active", b.dataset.tab === tab);
+      $("#" + b.dataset.tab).hidden = b.dataset.tab !== tab;
+    }
+    status("");
+    if (tab === "map") {
+      loadMap();
+    } else if (tab === "review") {
+      loadReview();
+    }
+  }
+
+  for (const b of document.querySelectorAll("#tabs [data-tab]")) {
+    b.addEventListener("click", () => show(b.dataset.tab));
+  }
+
+  // Sign in
+
+  function signedIn() {
+    $("#login").hidden = true;
+    $("#tabs").hidden = false;
+    show("map");
+  }
+
+  function signOut() {
+    session.clear();
+    $("#login").hidden = false;
+    $("#tabs").hidden = true;
+    for (const section of document.querySelectorAll("section")) {
+      section.hidden = true;
+    }
+  }
+
+  $("#login").addEventListener("submit", async (event) => {
+    event.preventDefault();
+    const form = event.target;
+    session.setItem("token", form.token.value);
+    session.setItem("api", form.api.value.replace(/\/+$/, ""));
+    try {
+      // the pending locations are an admin route, so they tell whether the token is an admin one
+      await api("/v1/admin/locations/pending?limit=1", {}, true);
+      form.reset();
+      signedIn();
+    } catch (error) {
+      signOut();
+      status("Sign in failed: " + error.message, true);
+    }
+  });
+
+  $("#logout").addEventListener("click", () => {
+    signOut();
+    status("Signed out");
+  });
+
+  // Map
+
+  async function loadMap() {
+    if (!map) {
+      map = L.map("map-canvas").setView([6.5244, 3.3792], 11);
+      L.tileLayer("https://{s}.tile.openstreetmap.org/{z}/{x}/{y}.png", {
+        maxZoom: 19,
+        attribution: "&copy; OpenStreetMap contributors",
+      }).addTo(map);
+      markers = L.featureGroup().addTo(map);
+    }
+    // the map may have been laid out while hidden
+    map.invalidateSize();
+
+    const form = $("#map-filter");
+    const params = new URLSearchParams();
+    if (form.q.value) {
+      params.set("q", form.q.value);
+    }
+    if (form.include_expired.checked) {
+      params.set("include_expired", "true");
+    }
+
+    try {
+      const locations = await api("/v1/locations/?" + params);
+      markers.clearLayers();
+      for (const location of locations) {
+        const popup = document.createElement("div");
+        const name = document.createElement("strong");
+        name.textContent = location.name;
+        popup.appendChild(name);
+        for (const line of [location.category, location.latitude + ", " + location.longitude, location.plus_code]) {
+          if (line) {
+            popup.appendChild(document.createElement("br"));
+            popup.appendChild(document.createTextNode(line));
+          }
+        }
+        L.marker([location.latitude, location.longitude]).bindPopup(popup).addTo(markers);
+      }
+      if (locations.length > 0) {
+        map.fitBounds(markers.getBounds(), { maxZoom: 15 });
+      }
+      status(locations.length + " locations");
+    } catch (error) {
+      status("Loading the locations failed: " + error.message, true);
+    }
+  }
+
+  $("#map-filter").addEventListener("submit", (event) => {
+    event.preventDefault();
+    loadMap();
+  });
+
+  // Review
+
+  async function review(location, action) {
+    const options = { method: "POST" };
+    if (action === "reject") {
+      const reason = window.prompt("Reason for rejecting " + location.name);
+      if (!reason) {
+        return;
+      }
+      options.headers = { "Content-Type": "application/json" };
+      options.body = JSON.stringify({ reason });
+    }
+
+    try {
+      await api("/v1/admin/locations/" + encodeURIComponent(location.slug) + "/" + action, options, true);
+      await loadReview();
+      status(location.name + (action === "approve" ? " approved" : " rejected"));
+    } catch (error) {
+      status("Reviewing " + location.name + " failed: " + error.message, true);
+    }
+  }
+
+  async function loadReview() {
+    try {
+      const locations = await api("/v1/admin/locations/pending?limit=100", {}, true);
+      const rows = $("#review-rows");
+      rows.replaceChildren();
+      for (const location of locations) {
+        const row = document.createElement("tr");
+        cell(row, location.name);
+        cell(row, location.category);
+        cell(row, location.latitude + ", " + location.longitude);
+        cell(row, new Date(location.created_at).toLocaleString());
+        const actions = cell(row, "");
+        button(actions, "Approve", () => review(location, "approve"));
+        button(actions, "Reject", () => review(location, "reject"));
+        rows.appendChild(row);
+      }
+      status(locations.length === 0 ? "No location waits for review" : "");
+    } catch (error) {
+      status("Loading the pending locations failed: " + error.message, true);
+    }
+  }
+
+  $("#review-reload").addEventListener("click", loadReview);
+
+  // Import
+
+  function renderImports() {
+    const rows = $("#import-rows");
+    rows.replaceChildren();
+    for (const job of imports.values()) {
+      const row = document.createElement("tr");
+      for (const value of [job.id, job.status, job.total, job.imported, job.skipped, job.error]) {
+        cell(row, value);
+      }
+      rows.appendChild(row);
+    }
+  }
+
+  // poll follows an import job until it is finished
+  async function poll(id) {
+    try {
+      const job = await api("/v1/locations/import/" + encodeURIComponent(id));
+      imports.set(id, job);
+      renderImports();
+      if (job.status === "pending" || job.status === "running") {
+        window.setTimeout(() => poll(id), 2000);
+      }
+    } catch (error) {
+      status("Following import " + id + " failed: " + error.message, true);
+    }
+  }
+
+  $("#import-form").file.addEventListener("change", async (event) => {
+    const file = event.target.files[0];
+    if (file) {
+      $("#import-form").body.value = await file.text();
+    }
+  });
+
+  $("#import-form").addEventListener("submit", async (event) => {
+    event.preventDefault();
+    const form = event.target;
+    // the API detects the format of the body
+    const body = form.body.value.trim();
+
+    try {
+      const job = await api("/v1/locations/import", { method: "POST", body });
+      imports.set(job.id, job);
+      renderImports();
+      form.reset();
+      status("Import " + job.id + " started");
+      poll(job.id);
+    } catch (error) {
+      status("Import failed: " + error.message, true);
+    }
+  });
+
+  if (session.getItem("token")) {
+    signedIn();
+  }
+})();
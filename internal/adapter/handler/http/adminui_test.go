@@ -0,0 +1,42 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdminUI(t *testing.T) {
+	r := chi.NewRouter()
+	r.Get("/admin", http.RedirectHandler("/admin/", http.StatusMovedPermanently).ServeHTTP)
+	r.Handle("/admin/*", adminUI())
+
+	tests := []struct {
+		name        string
+		path        string
+		status      int
+		contentType string
+	}{
+		{"Index", "/admin/", http.StatusOK, "text/html; charset=utf-8"},
+		{"Script", "/admin/app.js", http.StatusOK, "text/javascript; charset=utf-8"},
+		{"Stylesheet", "/admin/app.css", http.StatusOK, "text/css; charset=utf-8"},
+		{"Redirect to the index", "/admin", http.StatusMovedPermanently, ""},
+		{"Unknown file", "/admin/config.yml", http.StatusNotFound, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			assert.Equal(t, tt.status, w.Code)
+			if tt.contentType != "" {
+				assert.Equal(t, tt.contentType, w.Header().Get("Content-Type"))
+				assert.Contains(t, w.Header().Get("Content-Security-Policy"), "frame-ancestors 'none'")
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package http
+
+import (
+	"net/http"
+
+	"leeta/internal/core/domain"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// CreateLocationAlias godoc
+//
+//	@Summary		Add an alias to a location
+//	@Description	add an alternate name a location can be fetched by, it must not be the name or an alias of another location
+//	@Tags			Location
+//	@Accept			json
+//	@Produce		json
+//	@Param			name								path		string								true	"Location name"
+//	@Param			domain.CreateLocationAliasRequest	body		domain.CreateLocationAliasRequest	true	"Alias"
+//	@Success		201									{object}	aliasResponse						"Alias created"
+//	@Failure		400									{object}	errorResponse						"Validation error"
+//	@Failure		401									{object}	errorResponse						"Unauthorized error"
+//	@Failure		404									{object}	errorResponse						"Not found error"
+//	@Failure		409									{object}	errorResponse						"Conflict error"
+//	@Failure		413									{object}	errorResponse						"Request body too large"
+//	@Failure		500									{object}	errorResponse						"Internal server error"
+//	@Router			/locations/{name}/aliases [post]
+//	@Security		BearerAuth
+func (ch *LocationHandler) CreateLocationAlias(w http.ResponseWriter, r *http.Request) {
+	name := chi.URLParam(r, "name")
+	if name == "" {
+		handleError(w, domain.NewBadRequestCError("Invalid location name"))
+		return
+	}
+
+	var req domain.CreateLocationAliasRequest
+	if cerr := decodeJSON(r, &req); cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+
+	if err := ch.validate.Struct(&req); err != nil {
+		validationError(w, err)
+		return
+	}
+
+	alias, cerr := ch.svc.CreateLocationAlias(r.Context(), name, &req)
+	if cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+
+	handleSuccess(w, http.StatusCreated, alias)
+}
+
+// ListLocationAliases godoc
+//
+//	@Summary		List the aliases of a location
+//	@Description	list the alternate names of a location, by name
+//	@Tags			Location
+//	@Accept			json
+//	@Produce		json
+//	@Param			name	path		string				true	"Location name"
+//	@Success		200		{object}	aliasListResponse	"Success"
+//	@Failure		404		{object}	errorResponse		"Not found error"
+//	@Failure		500		{object}	errorResponse		"Internal server error"
+//	@Router			/locations/{name}/aliases [get]
+//	@Security		BearerAuth
+func (ch *LocationHandler) ListLocationAliases(w http.ResponseWriter, r *http.Request) {
+	name := chi.URLParam(r, "name")
+	if name == "" {
+		handleError(w, domain.NewBadRequestCError("Invalid location name"))
+		return
+	}
+
+	aliases, cerr := ch.svc.ListLocationAliases(r.Context(), name)
+	if cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+
+	handleSuccess(w, http.StatusOK, aliases)
+}
+
+// DeleteLocationAlias godoc
+//
+//	@Summary		Remove an alias from a location
+//	@Description	remove an alternate name from a location, both by name
+//	@Tags			Location
+//	@Accept			json
+//	@Produce		json
+//	@Param			name	path		string			true	"Location name"
+//	@Param			alias	path		string			true	"Alias"
+//	@Success		200		{object}	response		"Success"
+//	@Failure		401		{object}	errorResponse	"Unauthorized error"
+//	@Failure		404		{object}	errorResponse	"Not found error"
+//	@Failure		500		{object}	errorResponse	"Internal server error"
+//	@Router			/locations/{name}/aliases/{alias} [delete]
+//	@Security		BearerAuth
+func (ch *LocationHandler) DeleteLocationAlias(w http.ResponseWriter, r *http.Request) {
+	name, alias := chi.URLParam(r, "name"), chi.URLParam(r, "alias")
+	if name == "" || alias == "" {
+		handleError(w, domain.NewBadRequestCError("Invalid location name or alias"))
+		return
+	}
+
+	if cerr := ch.svc.DeleteLocationAlias(r.Context(), name, alias); cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+
+	handleMessage(w, http.StatusOK, "Deleted location alias successfully")
+}
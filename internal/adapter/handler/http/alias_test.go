@@ -0,0 +1,86 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"leeta/internal/core/domain"
+	"leeta/internal/core/port/mock"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocationHandler_CreateLocationAlias(t *testing.T) {
+	svc := &mock.LocationServiceMock{
+		CreateLocationAliasFunc: func(ctx context.Context, name string, req *domain.CreateLocationAliasRequest) (*domain.LocationAlias, domain.CError) {
+			if name != "jfk-airport" {
+				return nil, domain.NewCError(http.StatusNotFound, "location not found")
+			}
+			return &domain.LocationAlias{ID: "id", LocationID: "location-id", Name: req.Name, Slug: "jfk"}, nil
+		},
+	}
+	handler := newTestLocationHandler(svc)
+
+	tests := []struct {
+		name     string
+		location string
+		body     string
+		status   int
+	}{
+		{"Success", "jfk-airport", `{"name": "JFK"}`, http.StatusCreated},
+		{"Error - Missing name", "jfk-airport", `{}`, http.StatusBadRequest},
+		{"Error - Unknown field", "jfk-airport", `{"alias": "JFK"}`, http.StatusBadRequest},
+		{"Error - Unknown location", "lga-airport", `{"name": "LGA"}`, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/locations/"+tt.location+"/aliases", strings.NewReader(tt.body))
+			req = withURLParam(req, "name", tt.location)
+			w := httptest.NewRecorder()
+
+			handler.CreateLocationAlias(w, req)
+
+			assert.Equal(t, tt.status, w.Code, w.Body.String())
+		})
+	}
+
+	calls := svc.CreateLocationAliasCalls()
+	require.Len(t, calls, 2)
+	assert.Equal(t, "JFK", calls[0].Req.Name)
+}
+
+func TestLocationHandler_DeleteLocationAlias(t *testing.T) {
+	svc := &mock.LocationServiceMock{
+		DeleteLocationAliasFunc: func(ctx context.Context, name, alias string) domain.CError {
+			if alias != "jfk" {
+				return domain.NewCError(http.StatusNotFound, "location or alias not found")
+			}
+			return nil
+		},
+	}
+	handler := newTestLocationHandler(svc)
+
+	for alias, status := range map[string]int{"jfk": http.StatusOK, "lga": http.StatusNotFound} {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("name", "jfk-airport")
+		rctx.URLParams.Add("alias", alias)
+		req := httptest.NewRequest(http.MethodDelete, "/locations/jfk-airport/aliases/"+alias, nil)
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+		w := httptest.NewRecorder()
+
+		handler.DeleteLocationAlias(w, req)
+
+		assert.Equal(t, status, w.Code, alias)
+
+		var res errorResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+		assert.Equal(t, status == http.StatusOK, res.Success)
+	}
+}
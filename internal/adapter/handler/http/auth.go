@@ -0,0 +1,126 @@
+package http
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"leeta/internal/adapter/config"
+	"leeta/internal/adapter/logger"
+	"leeta/internal/core/auth"
+	"leeta/internal/core/domain"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+/**
+ * RouteGroups are the middleware chains of the routes by
+ * who may call them. Routes are mounted in a group rather
+ * than checking access themselves, so a new module only
+ * picks its group. Middlewares appended to a group before
+ * the router is created apply to all of its routes
+ */
+type RouteGroups struct {
+	// Public routes are open to anyone, the caller is known when it sends a valid token
+	Public chi.Middlewares
+	// Authenticated routes require the admin token or a JWT once server.auth is configured
+	Authenticated chi.Middlewares
+	// Admin routes require the admin token or a JWT with the admin role, from an allowed address
+	Admin chi.Middlewares
+}
+
+// NewRouteGroups creates the route groups of config
+func NewRouteGroups(config *config.ServerConfiguration) (*RouteGroups, error) {
+	adminAccess, err := newIPAccess(&config.AdminAccess)
+	if err != nil {
+		return nil, err
+	}
+
+	authn := &authenticator{adminToken: config.AdminToken}
+	if config.Auth.Secret != "" {
+		authn.verifier = newJWTVerifier(&config.Auth)
+	}
+
+	return &RouteGroups{
+		Public:        chi.Middlewares{authn.identify},
+		Authenticated: chi.Middlewares{authn.identify, authn.requireUser},
+		Admin:         chi.Middlewares{adminAccess.middleware, authn.identify, requireRole(auth.RoleAdmin)},
+	}, nil
+}
+
+// authenticator identifies callers by their bearer token, either the admin token or a JWT
+type authenticator struct {
+	adminToken string
+	// verifier is nil when JWTs are not configured
+	verifier *jwtVerifier
+}
+
+// identify attaches the principal of the bearer token to the request context. Requests without a token,
+// or with one that cannot be verified, go on anonymous and the next middlewares decide whether to serve them
+func (a *authenticator) identify(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if p := a.principal(r); p != nil {
+			setAccessUser(r.Context(), p.User)
+			r = r.WithContext(auth.WithPrincipal(r.Context(), p))
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// principal returns the principal of the bearer token of r, nil if it has none or an invalid one
+func (a *authenticator) principal(r *http.Request) *auth.Principal {
+	if a.adminToken != "" && authorized(r, a.adminToken) {
+		return &auth.Principal{User: "admin", Roles: []string{auth.RoleAdmin}}
+	}
+
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || a.verifier == nil {
+		return nil
+	}
+
+	p, err := a.verifier.verify(token, time.Now())
+	if err != nil {
+		logger.FromCtx(r.Context()).Debug("Rejected token", zap.Error(err))
+		return nil
+	}
+	return p
+}
+
+// requireUser rejects the anonymous requests with 401 Unauthorized. Every request is served
+// when JWTs are not configured, so the authenticated routes stay open until they are
+func (a *authenticator) requireUser(next http.Handler) http.Handler {
+	if a.verifier == nil {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := auth.FromCtx(r.Context()); !ok {
+			handleError(w, domain.ErrUnauthorized)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// requireRole rejects the anonymous requests with 401 Unauthorized and those of callers without role
+// with 403 Forbidden
+func requireRole(role string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			p, ok := auth.FromCtx(r.Context())
+			if !ok {
+				handleError(w, domain.ErrUnauthorized)
+				return
+			}
+			if !p.HasRole(role) {
+				handleError(w, domain.ErrForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package http
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"leeta/internal/adapter/config"
+	"leeta/internal/core/auth"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// signToken signs claims as an HS256 JWT with secret
+func signToken(t *testing.T, secret string, claims map[string]any) string {
+	t.Helper()
+
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+
+	unsigned := header + "." + base64.RawURLEncoding.EncodeToString(payload)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(unsigned))
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestJWTVerifier_Verify(t *testing.T) {
+	now := time.Now()
+	verifier := newJWTVerifier(&config.AuthConfiguration{
+		Secret: "secret", Issuer: "leeta", Audience: "api", Leeway: time.Minute,
+	})
+
+	claims := func(overrides map[string]any) map[string]any {
+		c := map[string]any{
+			"sub": "ada", "tenant": "acme", "roles": []string{"editor"},
+			"iss": "leeta", "aud": "api", "exp": now.Add(time.Hour).Unix(),
+		}
+		for k, v := range overrides {
+			if v == nil {
+				delete(c, k)
+			} else {
+				c[k] = v
+			}
+		}
+		return c
+	}
+
+	t.Run("Valid token", func(t *testing.T) {
+		p, err := verifier.verify(signToken(t, "secret", claims(nil)), now)
+		require.NoError(t, err)
+		assert.Equal(t, &auth.Principal{User: "ada", Tenant: "acme", Roles: []string{"editor"}}, p)
+	})
+
+	t.Run("Audience list", func(t *testing.T) {
+		_, err := verifier.verify(signToken(t, "secret", claims(map[string]any{"aud": []string{"web", "api"}})), now)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Expired within the leeway", func(t *testing.T) {
+		_, err := verifier.verify(signToken(t, "secret", claims(map[string]any{"exp": now.Add(-30 * time.Second).Unix()})), now)
+		assert.NoError(t, err)
+	})
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"Wrong secret", signToken(t, "guess", claims(nil))},
+		{"Expired", signToken(t, "secret", claims(map[string]any{"exp": now.Add(-time.Hour).Unix()}))},
+		{"Not valid yet", signToken(t, "secret", claims(map[string]any{"nbf": now.Add(time.Hour).Unix()}))},
+		{"Without expiry", signToken(t, "secret", claims(map[string]any{"exp": nil}))},
+		{"Without subject", signToken(t, "secret", claims(map[string]any{"sub": nil}))},
+		{"Other issuer", signToken(t, "secret", claims(map[string]any{"iss": "someone"}))},
+		{"Other audience", signToken(t, "secret", claims(map[string]any{"aud": "web"}))},
+		{"Unsigned", base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none"}`)) + "." +
+			base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"ada"}`)) + "."},
+		{"Malformed", "not-a-token"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := verifier.verify(tt.token, now)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestRouteGroups(t *testing.T) {
+	newRouter := func(t *testing.T, config *config.ServerConfiguration) http.Handler {
+		groups, err := NewRouteGroups(config)
+		require.NoError(t, err)
+
+		whoami := func(w http.ResponseWriter, r *http.Request) {
+			handleSuccess(w, http.StatusOK, auth.User(r.Context())+"/"+auth.Tenant(r.Context()))
+		}
+
+		r := chi.NewRouter()
+		r.With(groups.Public...).Get("/public", whoami)
+		r.With(groups.Authenticated...).Get("/authenticated", whoami)
+		r.With(groups.Admin...).Get("/admin", whoami)
+		return r
+	}
+
+	user := signToken(t, "secret", map[string]any{"sub": "ada", "tenant": "acme", "exp": time.Now().Add(time.Hour).Unix()})
+	admin := signToken(t, "secret", map[string]any{"sub": "grace", "roles": []string{"admin"}, "exp": time.Now().Add(time.Hour).Unix()})
+
+	tests := []struct {
+		name   string
+		config *config.ServerConfiguration
+		path   string
+		token  string
+		status int
+	}{
+		{"Public without token", &config.ServerConfiguration{Auth: config.AuthConfiguration{Secret: "secret"}}, "/public", "", http.StatusOK},
+		{"Public with invalid token", &config.ServerConfiguration{Auth: config.AuthConfiguration{Secret: "secret"}}, "/public", "guess", http.StatusOK},
+		{"Authenticated without token", &config.ServerConfiguration{Auth: config.AuthConfiguration{Secret: "secret"}}, "/authenticated", "", http.StatusUnauthorized},
+		{"Authenticated with invalid token", &config.ServerConfiguration{Auth: config.AuthConfiguration{Secret: "secret"}}, "/authenticated", "guess", http.StatusUnauthorized},
+		{"Authenticated with token", &config.ServerConfiguration{Auth: config.AuthConfiguration{Secret: "secret"}}, "/authenticated", user, http.StatusOK},
+		{"Authenticated with admin token", &config.ServerConfiguration{AdminToken: "root", Auth: config.AuthConfiguration{Secret: "secret"}}, "/authenticated", "root", http.StatusOK},
+		{"Authenticated open without auth", &config.ServerConfiguration{}, "/authenticated", "", http.StatusOK},
+		{"Admin without token", &config.ServerConfiguration{AdminToken: "root"}, "/admin", "", http.StatusUnauthorized},
+		{"Admin with admin token", &config.ServerConfiguration{AdminToken: "root"}, "/admin", "root", http.StatusOK},
+		{"Admin with user token", &config.ServerConfiguration{Auth: config.AuthConfiguration{Secret: "secret"}}, "/admin", user, http.StatusForbidden},
+		{"Admin with admin role", &config.ServerConfiguration{Auth: config.AuthConfiguration{Secret: "secret"}}, "/admin", admin, http.StatusOK},
+		{"Admin from a denied address", &config.ServerConfiguration{AdminToken: "root", AdminAccess: config.AccessConfiguration{Deny: []string{"192.0.2.1"}}}, "/admin", "root", http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			w := httptest.NewRecorder()
+
+			newRouter(t, tt.config).ServeHTTP(w, req)
+
+			assert.Equal(t, tt.status, w.Code, w.Body.String())
+		})
+	}
+
+	t.Run("Principal reaches the handler", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/authenticated", nil)
+		req.Header.Set("Authorization", "Bearer "+user)
+		w := httptest.NewRecorder()
+
+		newRouter(t, &config.ServerConfiguration{Auth: config.AuthConfiguration{Secret: "secret"}}).ServeHTTP(w, req)
+
+		var res dataResponse[any]
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+		assert.Equal(t, "ada/acme", res.Data)
+	})
+}
@@ -0,0 +1,112 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"leeta/internal/adapter/config"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// cachePolicy holds the caching headers sent on the successful responses of a route
+type cachePolicy struct {
+	cacheControl string
+	vary         []string
+}
+
+// apply sets the Cache-Control and Vary headers on h
+func (p cachePolicy) apply(h http.Header) {
+	h.Set("Cache-Control", p.cacheControl)
+
+	for _, v := range p.vary {
+		h.Add("Vary", v)
+	}
+}
+
+// parseCachePolicies builds the cache policies table keyed by "METHOD pattern"
+func parseCachePolicies(configs []config.CachePolicyConfiguration) (map[string]cachePolicy, error) {
+	policies := make(map[string]cachePolicy, len(configs))
+
+	for _, c := range configs {
+		if c.Pattern == "" {
+			return nil, fmt.Errorf("cache policy is missing a pattern")
+		}
+
+		if c.CacheControl == "" {
+			return nil, fmt.Errorf("cache policy for %s is missing a Cache-Control value", c.Pattern)
+		}
+
+		method := strings.ToUpper(c.Method)
+		if method == "" {
+			method = "*"
+		}
+
+		policies[method+" "+c.Pattern] = cachePolicy{c.CacheControl, c.Vary}
+	}
+
+	return policies, nil
+}
+
+// setLastModified sets the Last-Modified header to t, unless it is zero
+func setLastModified(w http.ResponseWriter, t time.Time) {
+	if !t.IsZero() {
+		w.Header().Set("Last-Modified", t.UTC().Format(http.TimeFormat))
+	}
+}
+
+// cacheHeaders adds the caching headers of the policies to the successful responses of their routes.
+// Error responses are left without them, so they are never cached. Like the deprecation headers,
+// they are added when the handler starts writing its response, once the route pattern is known
+func cacheHeaders(policies map[string]cachePolicy) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if len(policies) == 0 {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			crw := &cacheResponseWriter{ResponseWriter: w, r: r, policies: policies}
+			next.ServeHTTP(crw, r)
+		})
+	}
+}
+
+type cacheResponseWriter struct {
+	http.ResponseWriter
+	r           *http.Request
+	policies    map[string]cachePolicy
+	wroteHeader bool
+}
+
+func (crw *cacheResponseWriter) WriteHeader(code int) {
+	if !crw.wroteHeader {
+		crw.wroteHeader = true
+
+		rctx := chi.RouteContext(crw.r.Context())
+		if rctx != nil && (code < 300 || code == http.StatusNotModified) {
+			pattern := rctx.RoutePattern()
+
+			if p, ok := crw.policies[crw.r.Method+" "+pattern]; ok {
+				p.apply(crw.Header())
+			} else if p, ok := crw.policies["* "+pattern]; ok {
+				p.apply(crw.Header())
+			}
+		}
+	}
+
+	crw.ResponseWriter.WriteHeader(code)
+}
+
+func (crw *cacheResponseWriter) Write(b []byte) (int, error) {
+	if !crw.wroteHeader {
+		crw.WriteHeader(http.StatusOK)
+	}
+	return crw.ResponseWriter.Write(b)
+}
+
+// Unwrap returns the original http.ResponseWriter
+func (crw *cacheResponseWriter) Unwrap() http.ResponseWriter {
+	return crw.ResponseWriter
+}
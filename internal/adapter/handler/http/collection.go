@@ -0,0 +1,172 @@
+package http
+
+import (
+	"net/http"
+
+	"leeta/internal/core/domain"
+	"leeta/internal/core/port"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+)
+
+// CollectionHandler represents the HTTP handler for collection-related requests
+type CollectionHandler struct {
+	svc      port.CollectionService
+	validate *validator.Validate
+}
+
+// NewCollectionHandler creates a new CollectionHandler instance
+func NewCollectionHandler(svc port.CollectionService, vld *validator.Validate) *CollectionHandler {
+	return &CollectionHandler{
+		svc,
+		vld,
+	}
+}
+
+// CreateCollection godoc
+//
+//	@Summary		Create a collection
+//	@Description	create an empty named collection of locations, lists and nearest searches can be restricted to it
+//	@Tags			Collection
+//	@Accept			json
+//	@Produce		json
+//	@Param			domain.CreateCollectionRequest	body		domain.CreateCollectionRequest	true	"Collection"
+//	@Success		201								{object}	collectionResponse				"Collection created"
+//	@Failure		400								{object}	errorResponse					"Validation error"
+//	@Failure		401								{object}	errorResponse					"Unauthorized error"
+//	@Failure		409								{object}	errorResponse					"Conflict error"
+//	@Failure		413								{object}	errorResponse					"Request body too large"
+//	@Failure		500								{object}	errorResponse					"Internal server error"
+//	@Router			/collections [post]
+//	@Security		BearerAuth
+func (ch *CollectionHandler) CreateCollection(w http.ResponseWriter, r *http.Request) {
+	var req domain.CreateCollectionRequest
+	if cerr := decodeJSON(r, &req); cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+
+	if err := ch.validate.Struct(&req); err != nil {
+		validationError(w, err)
+		return
+	}
+
+	collection, cerr := ch.svc.CreateCollection(r.Context(), &req)
+	if cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+
+	handleSuccess(w, http.StatusCreated, collection)
+}
+
+// ListCollections godoc
+//
+//	@Summary		List the collections
+//	@Description	list all the collections, by name
+//	@Tags			Collection
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	collectionListResponse	"Success"
+//	@Failure		500	{object}	errorResponse			"Internal server error"
+//	@Router			/collections [get]
+//	@Security		BearerAuth
+func (ch *CollectionHandler) ListCollections(w http.ResponseWriter, r *http.Request) {
+	collections, cerr := ch.svc.ListCollections(r.Context())
+	if cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+
+	handleSuccess(w, http.StatusOK, collections)
+}
+
+// DeleteCollection godoc
+//
+//	@Summary		Delete a collection
+//	@Description	delete a collection by name, its locations are kept
+//	@Tags			Collection
+//	@Accept			json
+//	@Produce		json
+//	@Param			name	path		string			true	"Collection name"
+//	@Success		200		{object}	response		"Success"
+//	@Failure		401		{object}	errorResponse	"Unauthorized error"
+//	@Failure		404		{object}	errorResponse	"Not found error"
+//	@Failure		500		{object}	errorResponse	"Internal server error"
+//	@Router			/collections/{name} [delete]
+//	@Security		BearerAuth
+func (ch *CollectionHandler) DeleteCollection(w http.ResponseWriter, r *http.Request) {
+	name := chi.URLParam(r, "name")
+	if name == "" {
+		handleError(w, domain.NewBadRequestCError("Invalid collection name"))
+		return
+	}
+
+	if cerr := ch.svc.DeleteCollection(r.Context(), name); cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+
+	handleMessage(w, http.StatusOK, "Deleted collection successfully")
+}
+
+// AddCollectionLocation godoc
+//
+//	@Summary		Add a location to a collection
+//	@Description	add a location to a collection, both by name. Adding a location already in the collection succeeds
+//	@Tags			Collection
+//	@Accept			json
+//	@Produce		json
+//	@Param			name		path		string			true	"Collection name"
+//	@Param			location	path		string			true	"Location name"
+//	@Success		200			{object}	response		"Success"
+//	@Failure		401			{object}	errorResponse	"Unauthorized error"
+//	@Failure		404			{object}	errorResponse	"Not found error"
+//	@Failure		500			{object}	errorResponse	"Internal server error"
+//	@Router			/collections/{name}/locations/{location} [put]
+//	@Security		BearerAuth
+func (ch *CollectionHandler) AddCollectionLocation(w http.ResponseWriter, r *http.Request) {
+	name, location := chi.URLParam(r, "name"), chi.URLParam(r, "location")
+	if name == "" || location == "" {
+		handleError(w, domain.NewBadRequestCError("Invalid collection or location name"))
+		return
+	}
+
+	if cerr := ch.svc.AddCollectionLocation(r.Context(), name, location); cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+
+	handleMessage(w, http.StatusOK, "Added location to collection successfully")
+}
+
+// RemoveCollectionLocation godoc
+//
+//	@Summary		Remove a location from a collection
+//	@Description	remove a location from a collection, both by name. The location itself is kept
+//	@Tags			Collection
+//	@Accept			json
+//	@Produce		json
+//	@Param			name		path		string			true	"Collection name"
+//	@Param			location	path		string			true	"Location name"
+//	@Success		200			{object}	response		"Success"
+//	@Failure		401			{object}	errorResponse	"Unauthorized error"
+//	@Failure		404			{object}	errorResponse	"Not found error"
+//	@Failure		500			{object}	errorResponse	"Internal server error"
+//	@Router			/collections/{name}/locations/{location} [delete]
+//	@Security		BearerAuth
+func (ch *CollectionHandler) RemoveCollectionLocation(w http.ResponseWriter, r *http.Request) {
+	name, location := chi.URLParam(r, "name"), chi.URLParam(r, "location")
+	if name == "" || location == "" {
+		handleError(w, domain.NewBadRequestCError("Invalid collection or location name"))
+		return
+	}
+
+	if cerr := ch.svc.RemoveCollectionLocation(r.Context(), name, location); cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+
+	handleMessage(w, http.StatusOK, "Removed location from collection successfully")
+}
@@ -0,0 +1,107 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"leeta/internal/adapter/validation"
+	"leeta/internal/core/domain"
+	"leeta/internal/core/port/mock"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollectionHandler_CreateCollection(t *testing.T) {
+	svc := &mock.CollectionServiceMock{
+		CreateCollectionFunc: func(ctx context.Context, req *domain.CreateCollectionRequest) (*domain.Collection, domain.CError) {
+			if req.Name == "Hubs" {
+				return nil, domain.NewCError(http.StatusConflict, "collection already exists")
+			}
+			return &domain.Collection{ID: "id", Name: req.Name, Slug: "my-delivery-hubs"}, nil
+		},
+	}
+	handler := NewCollectionHandler(svc, validation.New(nil))
+
+	tests := []struct {
+		name   string
+		body   string
+		status int
+	}{
+		{"Success", `{"name": "My delivery hubs"}`, http.StatusCreated},
+		{"Error - Missing name", `{}`, http.StatusBadRequest},
+		{"Error - Malformed body", `{"name": }`, http.StatusBadRequest},
+		{"Error - Existing collection", `{"name": "Hubs"}`, http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/collections", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			handler.CreateCollection(w, req)
+
+			assert.Equal(t, tt.status, w.Code, w.Body.String())
+		})
+	}
+
+	require.Len(t, svc.CreateCollectionCalls(), 2)
+}
+
+func TestCollectionHandler_CollectionLocations(t *testing.T) {
+	svc := &mock.CollectionServiceMock{
+		AddCollectionLocationFunc: func(ctx context.Context, name, location string) domain.CError {
+			if name != "hubs" {
+				return domain.NewCError(http.StatusNotFound, "collection or location not found")
+			}
+			return nil
+		},
+		RemoveCollectionLocationFunc: func(ctx context.Context, name, location string) domain.CError {
+			if location != "ikeja-mall" {
+				return domain.NewCError(http.StatusNotFound, "collection not found or location not in it")
+			}
+			return nil
+		},
+	}
+	handler := NewCollectionHandler(svc, validation.New(nil))
+
+	membership := func(method, name, location string) *http.Request {
+		req := httptest.NewRequest(method, "/collections/"+name+"/locations/"+location, nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("name", name)
+		rctx.URLParams.Add("location", location)
+		return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	}
+
+	tests := []struct {
+		name       string
+		method     string
+		handle     http.HandlerFunc
+		collection string
+		location   string
+		status     int
+	}{
+		{"Add", http.MethodPut, handler.AddCollectionLocation, "hubs", "ikeja-mall", http.StatusOK},
+		{"Add - Unknown collection", http.MethodPut, handler.AddCollectionLocation, "depots", "ikeja-mall", http.StatusNotFound},
+		{"Remove", http.MethodDelete, handler.RemoveCollectionLocation, "hubs", "ikeja-mall", http.StatusOK},
+		{"Remove - Not in the collection", http.MethodDelete, handler.RemoveCollectionLocation, "hubs", "lekki-beach", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+
+			tt.handle(w, membership(tt.method, tt.collection, tt.location))
+
+			assert.Equal(t, tt.status, w.Code, w.Body.String())
+		})
+	}
+
+	require.Len(t, svc.AddCollectionLocationCalls(), 2)
+	assert.Equal(t, "ikeja-mall", svc.AddCollectionLocationCalls()[0].Location)
+}
@@ -0,0 +1,59 @@
+package http
+
+import (
+	"net/http"
+	"strings"
+	"sync/atomic"
+)
+
+// defaultAllowedOrigins are allowed when no origins are configured
+var defaultAllowedOrigins = []string{"https://*", "http://*"}
+
+/**
+ * allowedOrigins holds the origins allowed by CORS. They can be
+ * replaced while the server runs, an origin may contain one "*"
+ * wildcard, e.g. https://*.example.com
+ */
+type allowedOrigins struct {
+	origins atomic.Pointer[[]string]
+}
+
+// newAllowedOrigins creates the allowed origins from a comma separated list
+func newAllowedOrigins(list string) *allowedOrigins {
+	ao := &allowedOrigins{}
+	ao.set(list)
+	return ao
+}
+
+// set replaces the allowed origins with the ones of the comma separated list,
+// or the default ones if it is empty
+func (ao *allowedOrigins) set(list string) {
+	origins := defaultAllowedOrigins
+	if list != "" {
+		origins = nil
+		for _, origin := range strings.Split(list, ",") {
+			origins = append(origins, strings.ToLower(strings.TrimSpace(origin)))
+		}
+	}
+
+	ao.origins.Store(&origins)
+}
+
+// allow reports whether origin is allowed, it is the cors.Options AllowOriginFunc
+func (ao *allowedOrigins) allow(r *http.Request, origin string) bool {
+	origin = strings.ToLower(origin)
+
+	for _, allowed := range *ao.origins.Load() {
+		if allowed == "*" || allowed == origin {
+			return true
+		}
+
+		prefix, suffix, ok := strings.Cut(allowed, "*")
+		if ok && len(origin) >= len(prefix)+len(suffix) &&
+			strings.HasPrefix(origin, prefix) && strings.HasSuffix(origin, suffix) {
+			return true
+		}
+	}
+
+	return false
+}
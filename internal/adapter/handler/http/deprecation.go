@@ -0,0 +1,141 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"leeta/internal/adapter/config"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// deprecation holds the headers sent on responses of a deprecated route
+type deprecation struct {
+	since     time.Time
+	sunset    time.Time
+	link      string
+	successor string
+}
+
+// apply sets the Deprecation, Sunset and Link headers on h
+func (d deprecation) apply(h http.Header) {
+	if d.since.IsZero() {
+		h.Set("Deprecation", "true")
+	} else {
+		h.Set("Deprecation", fmt.Sprintf("@%d", d.since.Unix()))
+	}
+
+	if !d.sunset.IsZero() {
+		h.Set("Sunset", d.sunset.UTC().Format(http.TimeFormat))
+	}
+
+	if d.link != "" {
+		h.Add("Link", fmt.Sprintf(`<%s>; rel="deprecation"`, d.link))
+	}
+
+	if d.successor != "" {
+		h.Add("Link", fmt.Sprintf(`<%s>; rel="successor-version"`, d.successor))
+	}
+}
+
+// parseDeprecations builds the deprecated routes table keyed by "METHOD pattern"
+func parseDeprecations(configs []config.DeprecationConfiguration) (map[string]deprecation, error) {
+	deprecations := make(map[string]deprecation, len(configs))
+
+	for _, c := range configs {
+		if c.Pattern == "" {
+			return nil, fmt.Errorf("deprecated route is missing a pattern")
+		}
+
+		var d deprecation
+		var err error
+
+		if d.since, err = parseDeprecationDate(c.Since); err != nil {
+			return nil, fmt.Errorf("invalid deprecation date for %s: %w", c.Pattern, err)
+		}
+
+		if d.sunset, err = parseDeprecationDate(c.Sunset); err != nil {
+			return nil, fmt.Errorf("invalid sunset date for %s: %w", c.Pattern, err)
+		}
+
+		d.link = c.Link
+		d.successor = c.Successor
+
+		method := strings.ToUpper(c.Method)
+		if method == "" {
+			method = "*"
+		}
+
+		deprecations[method+" "+c.Pattern] = d
+	}
+
+	return deprecations, nil
+}
+
+// parseDeprecationDate parses an optional RFC3339 or YYYY-MM-DD date
+func parseDeprecationDate(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	return time.Parse("2006-01-02", value)
+}
+
+// deprecationHeaders adds deprecation headers to the responses of the routes in deprecations.
+// The matched route pattern is only known once chi has routed the request, so the headers
+// are added when the handler starts writing its response.
+func deprecationHeaders(deprecations map[string]deprecation) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if len(deprecations) == 0 {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			drw := &deprecationResponseWriter{ResponseWriter: w, r: r, deprecations: deprecations}
+			next.ServeHTTP(drw, r)
+		})
+	}
+}
+
+type deprecationResponseWriter struct {
+	http.ResponseWriter
+	r            *http.Request
+	deprecations map[string]deprecation
+	wroteHeader  bool
+}
+
+func (drw *deprecationResponseWriter) WriteHeader(code int) {
+	if !drw.wroteHeader {
+		drw.wroteHeader = true
+
+		if rctx := chi.RouteContext(drw.r.Context()); rctx != nil {
+			pattern := rctx.RoutePattern()
+
+			if d, ok := drw.deprecations[drw.r.Method+" "+pattern]; ok {
+				d.apply(drw.Header())
+			} else if d, ok := drw.deprecations["* "+pattern]; ok {
+				d.apply(drw.Header())
+			}
+		}
+	}
+
+	drw.ResponseWriter.WriteHeader(code)
+}
+
+func (drw *deprecationResponseWriter) Write(b []byte) (int, error) {
+	if !drw.wroteHeader {
+		drw.WriteHeader(http.StatusOK)
+	}
+	return drw.ResponseWriter.Write(b)
+}
+
+// Unwrap returns the original http.ResponseWriter
+func (drw *deprecationResponseWriter) Unwrap() http.ResponseWriter {
+	return drw.ResponseWriter
+}
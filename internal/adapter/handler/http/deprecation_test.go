@@ -0,0 +1,90 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"leeta/internal/adapter/config"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDeprecations(t *testing.T) {
+	tests := []struct {
+		name    string
+		configs []config.DeprecationConfiguration
+		key     string
+		wantErr bool
+	}{
+		{"Method is upper cased", []config.DeprecationConfiguration{{Method: "get", Pattern: "/v1/locations/"}}, "GET /v1/locations/", false},
+		{"No method matches every method", []config.DeprecationConfiguration{{Pattern: "/v1/locations/"}}, "* /v1/locations/", false},
+		{"RFC3339 dates", []config.DeprecationConfiguration{{Pattern: "/v1/locations/", Since: "2024-01-01T00:00:00Z", Sunset: "2025-01-01T12:00:00+01:00"}}, "* /v1/locations/", false},
+		{"Missing pattern", []config.DeprecationConfiguration{{Method: "GET"}}, "", true},
+		{"Invalid deprecation date", []config.DeprecationConfiguration{{Pattern: "/v1/locations/", Since: "01/01/2024"}}, "", true},
+		{"Invalid sunset date", []config.DeprecationConfiguration{{Pattern: "/v1/locations/", Sunset: "soon"}}, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deprecations, err := parseDeprecations(tt.configs)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Contains(t, deprecations, tt.key)
+		})
+	}
+}
+
+func TestDeprecationHeaders(t *testing.T) {
+	deprecations, err := parseDeprecations([]config.DeprecationConfiguration{
+		{Method: "GET", Pattern: "/v1/locations/{name}", Since: "2024-01-01", Sunset: "2025-06-30", Link: "https://docs.example.com/v2", Successor: "/v2/locations/{name}"},
+		{Pattern: "/v1/locations/{name}", Since: "2024-03-01"},
+		{Method: "GET", Pattern: "/v1/legacy"},
+	})
+	require.NoError(t, err)
+
+	router := chi.NewRouter()
+	router.Use(deprecationHeaders(deprecations))
+	router.Route("/v1", func(r chi.Router) {
+		r.Get("/locations/{name}", func(w http.ResponseWriter, r *http.Request) { w.Write([]byte("{}")) })
+		r.Delete("/locations/{name}", func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusNoContent) })
+		r.Get("/locations/", func(w http.ResponseWriter, r *http.Request) { w.Write([]byte("[]")) })
+		r.Get("/legacy", func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusNotFound) })
+	})
+
+	tests := []struct {
+		name        string
+		method      string
+		path        string
+		deprecation string
+		sunset      string
+		links       []string
+	}{
+		{
+			"Method matched before the routes of every method", http.MethodGet, "/v1/locations/lagos",
+			"@1704067200", "Mon, 30 Jun 2025 00:00:00 GMT",
+			[]string{`<https://docs.example.com/v2>; rel="deprecation"`, `</v2/locations/{name}>; rel="successor-version"`},
+		},
+		{"Route of every method", http.MethodDelete, "/v1/locations/lagos", "@1709251200", "", nil},
+		{"Deprecated without a date", http.MethodGet, "/v1/legacy", "true", "", nil},
+		{"Route not deprecated", http.MethodGet, "/v1/locations/", "", "", nil},
+		{"Route not found", http.MethodGet, "/v1/unknown", "", "", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, nil))
+
+			assert.Equal(t, tt.deprecation, w.Header().Get("Deprecation"))
+			assert.Equal(t, tt.sunset, w.Header().Get("Sunset"))
+			assert.Equal(t, tt.links, w.Header().Values("Link"))
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"leeta/internal/core/domain"
+	"leeta/internal/core/port"
+
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	// defaultStaleMinutes is how long a device must have been silent to be listed as stale by default
+	defaultStaleMinutes = 5
+	// maxStaleMinutes caps the stale window to a week
+	maxStaleMinutes = 7 * 24 * 60
+)
+
+// DeviceHandler represents the HTTP handler for device-related requests
+type DeviceHandler struct {
+	svc      port.DeviceService
+	validate *validator.Validate
+}
+
+// NewDeviceHandler creates a new DeviceHandler instance
+func NewDeviceHandler(svc port.DeviceService, vld *validator.Validate) *DeviceHandler {
+	return &DeviceHandler{
+		svc,
+		vld,
+	}
+}
+
+// RegisterDevice godoc
+//
+//	@Summary		Register a device
+//	@Description	register an edge device so that it can send heartbeats, registering it again renames it
+//	@Tags			Device
+//	@Accept			json
+//	@Produce		json
+//	@Param			domain.RegisterDeviceRequest	body		domain.RegisterDeviceRequest	true	"Device"
+//	@Success		201								{object}	deviceResponse					"Device registered"
+//	@Failure		400								{object}	errorResponse					"Validation error"
+//	@Failure		401								{object}	errorResponse					"Unauthorized error"
+//	@Failure		500								{object}	errorResponse					"Internal server error"
+//	@Router			/devices [post]
+//	@Security		BearerAuth
+func (dh *DeviceHandler) RegisterDevice(w http.ResponseWriter, r *http.Request) {
+	var req domain.RegisterDeviceRequest
+	if cerr := decodeJSON(r, &req); cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+
+	if err := dh.validate.Struct(&req); err != nil {
+		validationError(w, err)
+		return
+	}
+
+	device, cerr := dh.svc.RegisterDevice(r.Context(), &req)
+	if cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+
+	handleSuccess(w, http.StatusCreated, device)
+}
+
+// Heartbeat godoc
+//
+//	@Summary		Send a heartbeat
+//	@Description	mark a registered device as seen now, optionally with its last known coordinates
+//	@Tags			Device
+//	@Accept			json
+//	@Produce		json
+//	@Param			domain.HeartbeatRequest	body		domain.HeartbeatRequest	true	"Heartbeat"
+//	@Success		200						{object}	deviceResponse			"Heartbeat recorded"
+//	@Failure		400						{object}	errorResponse			"Validation error"
+//	@Failure		401						{object}	errorResponse			"Unauthorized error"
+//	@Failure		404						{object}	errorResponse			"Device is not registered"
+//	@Failure		500						{object}	errorResponse			"Internal server error"
+//	@Router			/heartbeats [post]
+//	@Security		BearerAuth
+func (dh *DeviceHandler) Heartbeat(w http.ResponseWriter, r *http.Request) {
+	var req domain.HeartbeatRequest
+	if cerr := decodeJSON(r, &req); cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+
+	if err := dh.validate.Struct(&req); err != nil {
+		validationError(w, err)
+		return
+	}
+
+	device, cerr := dh.svc.Heartbeat(r.Context(), &req)
+	if cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+
+	handleSuccess(w, http.StatusOK, device)
+}
+
+// ListStaleDevices godoc
+//
+//	@Summary		List stale devices
+//	@Description	list the devices that have not sent a heartbeat in the given number of minutes, least recently seen first
+//	@Tags			Device
+//	@Accept			json
+//	@Produce		json
+//	@Param			minutes	query		int					false	"Minutes without a heartbeat, 5 by default, at most 10080"
+//	@Success		200		{object}	deviceListResponse	"Success"
+//	@Failure		400		{object}	errorResponse		"Validation error"
+//	@Failure		500		{object}	errorResponse		"Internal server error"
+//	@Router			/devices/stale [get]
+func (dh *DeviceHandler) ListStaleDevices(w http.ResponseWriter, r *http.Request) {
+	minutes := defaultStaleMinutes
+	if value := r.URL.Query().Get("minutes"); value != "" {
+		var err error
+		minutes, err = strconv.Atoi(value)
+		if err != nil || minutes < 1 || minutes > maxStaleMinutes {
+			handleError(w, domain.NewBadRequestCError("minutes must be a number between 1 and 10080"))
+			return
+		}
+	}
+
+	devices, cerr := dh.svc.ListStaleDevices(r.Context(), time.Duration(minutes)*time.Minute)
+	if cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+
+	handleSuccess(w, http.StatusOK, devices)
+}
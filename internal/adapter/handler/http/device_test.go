@@ -0,0 +1,137 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"leeta/internal/core/domain"
+	"leeta/internal/core/port/mock"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeviceHandler_RegisterDevice(t *testing.T) {
+	svc := &mock.DeviceServiceMock{
+		RegisterDeviceFunc: func(ctx context.Context, req *domain.RegisterDeviceRequest) (*domain.Device, domain.CError) {
+			if req.ID == "down" {
+				return nil, domain.ErrServiceUnavailable
+			}
+			return &domain.Device{ID: req.ID, Name: req.Name}, nil
+		},
+	}
+	handler := NewDeviceHandler(svc, validator.New())
+
+	tests := []struct {
+		name   string
+		body   string
+		status int
+	}{
+		{"Success - With name", `{"id": "truck-42", "name": "Truck 42"}`, http.StatusCreated},
+		{"Success - Registered again to rename it", `{"id": "truck-42", "name": "Truck 42b"}`, http.StatusCreated},
+		{"Success - Without name", `{"id": "truck-42"}`, http.StatusCreated},
+		{"Error - Missing id", `{"name": "Truck 42"}`, http.StatusBadRequest},
+		{"Error - Id too long", `{"id": "` + strings.Repeat("a", 65) + `"}`, http.StatusBadRequest},
+		{"Error - Name too long", `{"id": "truck-42", "name": "` + strings.Repeat("a", 256) + `"}`, http.StatusBadRequest},
+		{"Error - Invalid JSON", `{"id": `, http.StatusBadRequest},
+		{"Error - Database unavailable", `{"id": "down"}`, http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/devices", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			handler.RegisterDevice(w, req)
+
+			assert.Equal(t, tt.status, w.Code, w.Body.String())
+		})
+	}
+
+	// only the valid registrations reach the service
+	require.Len(t, svc.RegisterDeviceCalls(), 4)
+	assert.Equal(t, "Truck 42b", svc.RegisterDeviceCalls()[1].Req.Name)
+}
+
+func TestDeviceHandler_Heartbeat(t *testing.T) {
+	svc := &mock.DeviceServiceMock{
+		HeartbeatFunc: func(ctx context.Context, req *domain.HeartbeatRequest) (*domain.Device, domain.CError) {
+			if req.DeviceID != "truck-42" {
+				return nil, domain.NewCError(http.StatusNotFound, "device is not registered")
+			}
+			return &domain.Device{ID: req.DeviceID, Latitude: req.Latitude, Longitude: req.Longitude}, nil
+		},
+	}
+	handler := NewDeviceHandler(svc, validator.New())
+
+	tests := []struct {
+		name   string
+		body   string
+		status int
+	}{
+		{"Success - With coordinates", `{"device_id": "truck-42", "latitude": 40.7589, "longitude": -73.9851}`, http.StatusOK},
+		{"Success - Without coordinates", `{"device_id": "truck-42"}`, http.StatusOK},
+		{"Error - Latitude without longitude", `{"device_id": "truck-42", "latitude": 40.7589}`, http.StatusBadRequest},
+		{"Error - Invalid longitude", `{"device_id": "truck-42", "latitude": 40.7589, "longitude": 200}`, http.StatusBadRequest},
+		{"Error - Missing device id", `{"latitude": 40.7589, "longitude": -73.9851}`, http.StatusBadRequest},
+		{"Error - Unregistered device", `{"device_id": "bike-7"}`, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/heartbeats", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			handler.Heartbeat(w, req)
+
+			assert.Equal(t, tt.status, w.Code, w.Body.String())
+		})
+	}
+
+	// only the valid heartbeats reach the service
+	assert.Len(t, svc.HeartbeatCalls(), 3)
+}
+
+func TestDeviceHandler_ListStaleDevices(t *testing.T) {
+	svc := &mock.DeviceServiceMock{
+		ListStaleDevicesFunc: func(ctx context.Context, notSeenFor time.Duration) ([]domain.Device, domain.CError) {
+			return []domain.Device{}, nil
+		},
+	}
+	handler := NewDeviceHandler(svc, validator.New())
+
+	t.Run("Success - Default window", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		handler.ListStaleDevices(w, httptest.NewRequest(http.MethodGet, "/devices/stale", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		require.Len(t, svc.ListStaleDevicesCalls(), 1)
+		assert.Equal(t, 5*time.Minute, svc.ListStaleDevicesCalls()[0].NotSeenFor)
+	})
+
+	t.Run("Success - Given window", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		handler.ListStaleDevices(w, httptest.NewRequest(http.MethodGet, "/devices/stale?minutes=90", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		require.Len(t, svc.ListStaleDevicesCalls(), 2)
+		assert.Equal(t, 90*time.Minute, svc.ListStaleDevicesCalls()[1].NotSeenFor)
+	})
+
+	t.Run("Error - Invalid window", func(t *testing.T) {
+		for _, minutes := range []string{"0", "abc", "10081"} {
+			w := httptest.NewRecorder()
+			handler.ListStaleDevices(w, httptest.NewRequest(http.MethodGet, "/devices/stale?minutes="+minutes, nil))
+
+			assert.Equal(t, http.StatusBadRequest, w.Code, minutes)
+		}
+	})
+}
@@ -0,0 +1,141 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"leeta/internal/core/domain"
+	"leeta/internal/core/port"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+)
+
+// maxEntityIDLength is the longest entity id, as stored in the entity_id column
+const maxEntityIDLength = 64
+
+// EntityHandler represents the HTTP handler for the positions of the moving entities
+type EntityHandler struct {
+	svc      port.EntityService
+	validate *validator.Validate
+}
+
+// NewEntityHandler creates a new EntityHandler instance
+func NewEntityHandler(svc port.EntityService, vld *validator.Validate) *EntityHandler {
+	return &EntityHandler{
+		svc,
+		vld,
+	}
+}
+
+// ReportPosition godoc
+//
+//	@Summary		Report the position of an entity
+//	@Description	record the current position of a moving entity such as a courier or a vehicle, it replaces the position reported before
+//	@Tags			Entity
+//	@Accept			json
+//	@Produce		json
+//	@Param			id								path		string							true	"Entity id"
+//	@Param			domain.ReportPositionRequest	body		domain.ReportPositionRequest	true	"Position"
+//	@Success		200								{object}	entityPositionResponse			"Position reported"
+//	@Failure		400								{object}	errorResponse					"Validation error"
+//	@Failure		401								{object}	errorResponse					"Unauthorized error"
+//	@Failure		413								{object}	errorResponse					"Request body too large"
+//	@Failure		500								{object}	errorResponse					"Internal server error"
+//	@Router			/entities/{id}/positions [post]
+//	@Security		BearerAuth
+func (eh *EntityHandler) ReportPosition(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" || len(id) > maxEntityIDLength {
+		handleError(w, domain.NewBadRequestCError("Invalid entity id"))
+		return
+	}
+
+	var req domain.ReportPositionRequest
+	if cerr := decodeJSON(r, &req); cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+
+	if err := eh.validate.Struct(&req); err != nil {
+		validationError(w, err)
+		return
+	}
+
+	position, cerr := eh.svc.ReportPosition(r.Context(), id, &req)
+	if cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+
+	handleSuccess(w, http.StatusOK, position)
+}
+
+// FindNearestEntities godoc
+//
+//	@Summary		Find the nearest entities
+//	@Description	find the moving entities nearest to a point by their current position, entities whose position is stale are left out
+//	@Tags			Entity
+//	@Accept			json
+//	@Produce		json
+//	@Param			lat		query		float64						true	"Latitude"
+//	@Param			lng		query		float64						true	"Longitude"
+//	@Param			limit	query		int							false	"Number of entities, defaults to 10"
+//	@Param			radius	query		number						false	"Only return the entities within this many meters"
+//	@Param			kind	query		string						false	"Only return the entities of this kind"
+//	@Success		200		{object}	nearestEntityListResponse	"Success"
+//	@Failure		400		{object}	errorResponse				"Validation error"
+//	@Failure		500		{object}	errorResponse				"Internal server error"
+//	@Router			/entities/nearest [get]
+func (eh *EntityHandler) FindNearestEntities(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	latitude, err := strconv.ParseFloat(query.Get("lat"), 64)
+	if err != nil {
+		handleError(w, domain.NewBadRequestCError("Invalid latitude"))
+		return
+	}
+
+	longitude, err := strconv.ParseFloat(query.Get("lng"), 64)
+	if err != nil {
+		handleError(w, domain.NewBadRequestCError("Invalid longitude"))
+		return
+	}
+
+	req := domain.NearestEntitiesRequest{
+		Latitude:  latitude,
+		Longitude: longitude,
+		Kind:      query.Get("kind"),
+	}
+
+	if value := query.Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil {
+			handleError(w, domain.NewBadRequestCError("limit must be a number"))
+			return
+		}
+		req.Limit = limit
+	}
+
+	if value := query.Get("radius"); value != "" {
+		radius, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			handleError(w, domain.NewBadRequestCError("radius must be a number"))
+			return
+		}
+		req.MaxDistance = radius
+	}
+
+	if err := eh.validate.Struct(&req); err != nil {
+		validationError(w, err)
+		return
+	}
+
+	entities, cerr := eh.svc.FindNearestEntities(r.Context(), &req)
+	if cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+
+	handleSuccess(w, http.StatusOK, entities)
+}
@@ -0,0 +1,114 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"leeta/internal/core/domain"
+	"leeta/internal/core/port/mock"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEntityHandler_ReportPosition(t *testing.T) {
+	svc := &mock.EntityServiceMock{
+		ReportPositionFunc: func(ctx context.Context, id string, req *domain.ReportPositionRequest) (*domain.EntityPosition, domain.CError) {
+			return &domain.EntityPosition{EntityID: id, Kind: req.Kind, Latitude: req.Latitude, Longitude: req.Longitude, ReportedAt: time.Now()}, nil
+		},
+	}
+	handler := NewEntityHandler(svc, validator.New())
+
+	tests := []struct {
+		name   string
+		id     string
+		body   string
+		status int
+	}{
+		{"Success", "courier-42", `{"kind": "courier", "latitude": 6.5244, "longitude": 3.3792}`, http.StatusOK},
+		{"Success - Without kind", "van-7", `{"latitude": 6.6018, "longitude": 3.3515}`, http.StatusOK},
+		{"Error - Invalid latitude", "courier-42", `{"latitude": 91, "longitude": 3.3792}`, http.StatusBadRequest},
+		{"Error - Kind too long", "courier-42", `{"kind": "` + strings.Repeat("k", 33) + `", "latitude": 6.5, "longitude": 3.3}`, http.StatusBadRequest},
+		{"Error - Id too long", strings.Repeat("c", 65), `{"latitude": 6.5, "longitude": 3.3}`, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/entities/"+tt.id+"/positions", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			handler.ReportPosition(w, withURLParam(req, "id", tt.id))
+
+			assert.Equal(t, tt.status, w.Code, w.Body.String())
+		})
+	}
+
+	calls := svc.ReportPositionCalls()
+	require.Len(t, calls, 2)
+	assert.Equal(t, "courier-42", calls[0].ID)
+	assert.Equal(t, "courier", calls[0].Req.Kind)
+}
+
+func TestEntityHandler_FindNearestEntities(t *testing.T) {
+	svc := &mock.EntityServiceMock{
+		FindNearestEntitiesFunc: func(ctx context.Context, req *domain.NearestEntitiesRequest) ([]domain.NearestEntity, domain.CError) {
+			return []domain.NearestEntity{{
+				EntityPosition: domain.EntityPosition{EntityID: "courier-42", Kind: "courier", Latitude: 6.5244, Longitude: 3.3792},
+				Distance:       320.5,
+			}}, nil
+		},
+	}
+	handler := NewEntityHandler(svc, validator.New())
+
+	tests := []struct {
+		name   string
+		query  string
+		status int
+	}{
+		{"Success", "?lat=6.52&lng=3.37", http.StatusOK},
+		{"Success - Filtered", "?lat=6.52&lng=3.37&limit=3&radius=5000&kind=courier", http.StatusOK},
+		{"Error - Missing latitude", "?lng=3.37", http.StatusBadRequest},
+		{"Error - Latitude out of range", "?lat=120&lng=3.37", http.StatusBadRequest},
+		{"Error - Invalid radius", "?lat=6.52&lng=3.37&radius=-1", http.StatusBadRequest},
+		{"Error - Limit too large", "?lat=6.52&lng=3.37&limit=500", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/entities/nearest"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			handler.FindNearestEntities(w, req)
+
+			assert.Equal(t, tt.status, w.Code, w.Body.String())
+		})
+	}
+
+	calls := svc.FindNearestEntitiesCalls()
+	require.Len(t, calls, 2)
+	assert.Equal(t, 3, calls[1].Req.Limit)
+	assert.Equal(t, 5000.0, calls[1].Req.MaxDistance)
+	assert.Equal(t, "courier", calls[1].Req.Kind)
+
+	t.Run("Distances in the response", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/entities/nearest?lat=6.52&lng=3.37", nil)
+		w := httptest.NewRecorder()
+
+		handler.FindNearestEntities(w, req)
+
+		var res dataResponse[any]
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+		data := res.Data.([]any)
+		require.Len(t, data, 1)
+		assert.Equal(t, "courier-42", data[0].(map[string]any)["entity_id"])
+		assert.Equal(t, 320.5, data[0].(map[string]any)["distance_meters"])
+	})
+}
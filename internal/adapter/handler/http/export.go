@@ -0,0 +1,86 @@
+package http
+
+import (
+	"net/http"
+
+	"leeta/internal/core/domain"
+	"leeta/internal/core/port"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// ExportHandler represents the HTTP handler for the requests about the scheduled exports
+type ExportHandler struct {
+	svc      port.ExportService
+	validate *validator.Validate
+}
+
+// NewExportHandler creates a new ExportHandler instance
+func NewExportHandler(svc port.ExportService, vld *validator.Validate) *ExportHandler {
+	return &ExportHandler{
+		svc,
+		vld,
+	}
+}
+
+// ListSnapshots godoc
+//
+//	@Summary		List snapshots
+//	@Description	list the snapshots of every location written to the object storage by the scheduled exports, most recent first
+//	@Tags			Admin
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	snapshotListResponse	"Success"
+//	@Failure		401	{object}	errorResponse			"Unauthorized error"
+//	@Failure		500	{object}	errorResponse			"Internal server error"
+//	@Failure		501	{object}	errorResponse			"No object storage bucket configured"
+//	@Router			/admin/exports [get]
+//	@Security		BearerAuth
+func (eh *ExportHandler) ListSnapshots(w http.ResponseWriter, r *http.Request) {
+	snapshots, cerr := eh.svc.ListSnapshots(r.Context())
+	if cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+
+	handleSuccess(w, http.StatusOK, snapshots)
+}
+
+// RestoreSnapshot godoc
+//
+//	@Summary		Restore a snapshot
+//	@Description	reload a snapshot of the scheduled exports, making the approved locations those of the snapshot or loading it into the locations_shadow table to compare it with them. The report lists the changes between the snapshot and the locations, a dry run only reports them
+//	@Tags			Admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			restoreRequest	body		domain.RestoreRequest	true	"Snapshot to restore"
+//	@Success		200				{object}	restoreReportResponse	"Success"
+//	@Failure		400				{object}	errorResponse			"Validation error"
+//	@Failure		401				{object}	errorResponse			"Unauthorized error"
+//	@Failure		404				{object}	errorResponse			"Snapshot not found"
+//	@Failure		409				{object}	errorResponse			"Conflict error"
+//	@Failure		413				{object}	errorResponse			"Request body too large"
+//	@Failure		500				{object}	errorResponse			"Internal server error"
+//	@Failure		501				{object}	errorResponse			"No object storage bucket configured"
+//	@Router			/admin/restore [post]
+//	@Security		BearerAuth
+func (eh *ExportHandler) RestoreSnapshot(w http.ResponseWriter, r *http.Request) {
+	var req domain.RestoreRequest
+	if cerr := decodeJSON(r, &req); cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+
+	if err := eh.validate.Struct(&req); err != nil {
+		validationError(w, err)
+		return
+	}
+
+	report, cerr := eh.svc.Restore(r.Context(), &req)
+	if cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+
+	handleSuccess(w, http.StatusOK, report)
+}
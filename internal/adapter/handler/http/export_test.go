@@ -0,0 +1,88 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"leeta/internal/core/domain"
+	"leeta/internal/core/port/mock"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportHandler_ListSnapshots(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		svc := &mock.ExportServiceMock{
+			ListSnapshotsFunc: func(ctx context.Context) ([]domain.Snapshot, domain.CError) {
+				return []domain.Snapshot{{
+					Key:       "exports/locations-20261016T000000Z.csv",
+					Format:    domain.ExportCSV,
+					Size:      12,
+					CreatedAt: time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC),
+				}}, nil
+			},
+		}
+
+		w := httptest.NewRecorder()
+		NewExportHandler(svc, validator.New()).ListSnapshots(w, httptest.NewRequest(http.MethodGet, "/admin/exports", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"success":true,"message":"Success","data":[{"key":"exports/locations-20261016T000000Z.csv",
+			"format":"csv","size":12,"created_at":"2026-10-16T00:00:00Z"}]}`, w.Body.String())
+	})
+
+	t.Run("Error - No object storage", func(t *testing.T) {
+		svc := &mock.ExportServiceMock{
+			ListSnapshotsFunc: func(ctx context.Context) ([]domain.Snapshot, domain.CError) {
+				return nil, domain.ErrExportsDisabled
+			},
+		}
+
+		w := httptest.NewRecorder()
+		NewExportHandler(svc, validator.New()).ListSnapshots(w, httptest.NewRequest(http.MethodGet, "/admin/exports", nil))
+
+		assert.Equal(t, http.StatusNotImplemented, w.Code)
+		assert.Contains(t, w.Body.String(), `"code":"EXPORTS_DISABLED"`)
+	})
+}
+
+func TestExportHandler_RestoreSnapshot(t *testing.T) {
+	svc := &mock.ExportServiceMock{
+		RestoreFunc: func(ctx context.Context, req *domain.RestoreRequest) (*domain.RestoreReport, domain.CError) {
+			return &domain.RestoreReport{Key: req.Key, Target: domain.RestoreLive, DryRun: req.DryRun, Changes: []domain.RestoreChange{}}, nil
+		},
+	}
+	handler := NewExportHandler(svc, validator.New())
+
+	tests := []struct {
+		name   string
+		body   string
+		status int
+	}{
+		{"Success - Dry run", `{"key":"exports/locations-20261016T000000Z.csv","dry_run":true}`, http.StatusOK},
+		{"Success - Shadow", `{"key":"exports/locations-20261016T000000Z.csv","target":"shadow"}`, http.StatusOK},
+		{"Error - No key", `{"dry_run":true}`, http.StatusBadRequest},
+		{"Error - Unknown target", `{"key":"exports/locations-20261016T000000Z.csv","target":"staging"}`, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/admin/restore", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+
+			handler.RestoreSnapshot(w, req)
+
+			assert.Equal(t, tt.status, w.Code, w.Body.String())
+		})
+	}
+
+	calls := svc.RestoreCalls()
+	assert.Len(t, calls, 2)
+	assert.Equal(t, &domain.RestoreRequest{Key: "exports/locations-20261016T000000Z.csv", DryRun: true}, calls[0].Req)
+	assert.Equal(t, domain.RestoreShadow, calls[1].Req.Target)
+}
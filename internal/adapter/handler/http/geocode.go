@@ -0,0 +1,95 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"leeta/internal/core/domain"
+	"leeta/internal/core/port"
+
+	"github.com/go-playground/validator/v10"
+	"golang.org/x/text/language"
+)
+
+// GeocodeHandler represents the HTTP handler for the address suggestions
+type GeocodeHandler struct {
+	svc      port.GeocodeService
+	validate *validator.Validate
+}
+
+// NewGeocodeHandler creates a new GeocodeHandler instance
+func NewGeocodeHandler(svc port.GeocodeService, vld *validator.Validate) *GeocodeHandler {
+	return &GeocodeHandler{
+		svc,
+		vld,
+	}
+}
+
+// Autocomplete godoc
+//
+//	@Summary		Suggest addresses
+//	@Description	suggest the addresses completing a partly typed one, e.g. to fill the coordinates of a location being registered. Suggestions are cached by query, so the requests of a client typing can be sent as often as every keystroke is debounced
+//	@Tags			Geocode
+//	@Accept			json
+//	@Produce		json
+//	@Param			q				query		string							true	"Address typed so far, at least 3 characters"
+//	@Param			limit			query		int								false	"Number of suggestions, defaults to 5"
+//	@Param			lat				query		float64							false	"Latitude of the point the addresses near are suggested first"
+//	@Param			lng				query		float64							false	"Longitude of the point the addresses near are suggested first"
+//	@Param			lang			query		string							false	"Language of the suggestions, defaults to the one preferred by Accept-Language"
+//	@Param			Accept-Language	header		string							false	"Preferred language of the suggestions"
+//	@Success		200				{object}	addressSuggestionListResponse	"Success"
+//	@Failure		400				{object}	errorResponse					"Validation error"
+//	@Failure		429				{object}	errorResponse					"Too many requests"
+//	@Failure		500				{object}	errorResponse					"Internal server error"
+//	@Failure		501				{object}	errorResponse					"No geocoding service is configured"
+//	@Failure		503				{object}	errorResponse					"Geocoding service unavailable"
+//	@Router			/geocode/autocomplete [get]
+func (gh *GeocodeHandler) Autocomplete(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	w.Header().Add("Vary", "Accept-Language")
+
+	req := domain.AutocompleteRequest{
+		Query:    query.Get("q"),
+		Language: query.Get("lang"),
+	}
+
+	if value := query.Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil {
+			handleError(w, domain.NewBadRequestCError("limit must be a number"))
+			return
+		}
+		req.Limit = limit
+	}
+
+	if query.Has("lat") || query.Has("lng") {
+		latitude, longitude, cerr := queryPoint(r)
+		if cerr != nil {
+			handleError(w, cerr)
+			return
+		}
+		req.Near = &domain.Point{Latitude: latitude, Longitude: longitude}
+	}
+
+	if req.Language == "" {
+		// a malformed header is ignored like a missing one
+		if accepted, _, err := language.ParseAcceptLanguage(r.Header.Get("Accept-Language")); err == nil && len(accepted) > 0 {
+			base, _ := accepted[0].Base()
+			req.Language = base.String()
+		}
+	}
+
+	if err := gh.validate.Struct(&req); err != nil {
+		validationError(w, err)
+		return
+	}
+
+	suggestions, cerr := gh.svc.Autocomplete(r.Context(), &req)
+	if cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+
+	handleSuccess(w, http.StatusOK, suggestions)
+}
@@ -0,0 +1,73 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"leeta/internal/core/domain"
+	"leeta/internal/core/port/mock"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeocodeHandler_Autocomplete(t *testing.T) {
+	svc := &mock.GeocodeServiceMock{
+		AutocompleteFunc: func(ctx context.Context, req *domain.AutocompleteRequest) ([]domain.AddressSuggestion, domain.CError) {
+			if req.Query == "nowhere" {
+				return nil, domain.ErrGeocodingDisabled
+			}
+			return []domain.AddressSuggestion{{Label: "12 Admiralty Way, Lagos, Nigeria", Latitude: 6.4474, Longitude: 3.4723}}, nil
+		},
+	}
+	handler := NewGeocodeHandler(svc, validator.New())
+
+	tests := []struct {
+		name   string
+		query  string
+		status int
+	}{
+		{"Success", "?q=admiralty", http.StatusOK},
+		{"Success - Near a point", "?q=admiralty&lat=6.45&lng=3.47&limit=3&lang=fr", http.StatusOK},
+		{"Error - Missing query", "", http.StatusBadRequest},
+		{"Error - Query too short", "?q=ad", http.StatusBadRequest},
+		{"Error - Limit too large", "?q=admiralty&limit=50", http.StatusBadRequest},
+		{"Error - Longitude missing", "?q=admiralty&lat=6.45", http.StatusBadRequest},
+		{"Error - Latitude out of range", "?q=admiralty&lat=96&lng=3.47", http.StatusBadRequest},
+		{"Error - Invalid language", "?q=admiralty&lang=!!", http.StatusBadRequest},
+		{"Error - Geocoding disabled", "?q=nowhere", http.StatusNotImplemented},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/geocode/autocomplete"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			handler.Autocomplete(w, req)
+
+			assert.Equal(t, tt.status, w.Code, w.Body.String())
+		})
+	}
+
+	calls := svc.AutocompleteCalls()
+	require.Len(t, calls, 3)
+	assert.Nil(t, calls[0].Req.Near)
+	assert.Equal(t, &domain.Point{Latitude: 6.45, Longitude: 3.47}, calls[1].Req.Near)
+	assert.Equal(t, 3, calls[1].Req.Limit)
+	assert.Equal(t, "fr", calls[1].Req.Language)
+
+	t.Run("Language from Accept-Language", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/geocode/autocomplete?q=admiralty", nil)
+		req.Header.Set("Accept-Language", "de-CH, en;q=0.8")
+		w := httptest.NewRecorder()
+
+		handler.Autocomplete(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "de", svc.AutocompleteCalls()[3].Req.Language)
+		assert.Equal(t, "Accept-Language", w.Header().Get("Vary"))
+	})
+}
@@ -0,0 +1,120 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"leeta/internal/core/domain"
+	"leeta/internal/core/port"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+)
+
+// GeofenceHandler represents the HTTP handler for geofence-related requests
+type GeofenceHandler struct {
+	svc      port.GeofenceService
+	validate *validator.Validate
+}
+
+// NewGeofenceHandler creates a new GeofenceHandler instance
+func NewGeofenceHandler(svc port.GeofenceService, vld *validator.Validate) *GeofenceHandler {
+	return &GeofenceHandler{
+		svc,
+		vld,
+	}
+}
+
+// CreateGeofence godoc
+//
+//	@Summary		Create a geofence
+//	@Description	create a named area bounded by a polygon, the polygon is closed without repeating its first point
+//	@Tags			Geofence
+//	@Accept			json
+//	@Produce		json
+//	@Param			domain.CreateGeofenceRequest	body		domain.CreateGeofenceRequest	true	"Geofence"
+//	@Success		201								{object}	geofenceResponse				"Geofence created"
+//	@Failure		400								{object}	errorResponse					"Validation error"
+//	@Failure		401								{object}	errorResponse					"Unauthorized error"
+//	@Failure		409								{object}	errorResponse					"Conflict error"
+//	@Failure		413								{object}	errorResponse					"Request body too large"
+//	@Failure		500								{object}	errorResponse					"Internal server error"
+//	@Router			/geofences [post]
+//	@Security		BearerAuth
+func (gh *GeofenceHandler) CreateGeofence(w http.ResponseWriter, r *http.Request) {
+	var req domain.CreateGeofenceRequest
+	if cerr := decodeJSON(r, &req); cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+
+	if err := gh.validate.Struct(&req); err != nil {
+		validationError(w, err)
+		return
+	}
+
+	geofence, cerr := gh.svc.CreateGeofence(r.Context(), &req)
+	if cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+
+	handleSuccess(w, http.StatusCreated, geofence)
+}
+
+// ListGeofenceLocations godoc
+//
+//	@Summary		List the locations inside a geofence
+//	@Description	list a page of the locations inside a geofence, by name
+//	@Tags			Geofence
+//	@Accept			json
+//	@Produce		json
+//	@Param			name			path		string					true	"Geofence name"
+//	@Param			limit			query		int						false	"Maximum number of locations, 20 by default, at most 100"
+//	@Param			offset			query		int						false	"Number of locations to skip"
+//	@Param			Accept-Language	header		string					false	"Preferred locales of the location names, the canonical name is kept when none has a translation"
+//	@Success		200				{object}	locationListResponse	"Success"
+//	@Failure		400				{object}	errorResponse			"Validation error"
+//	@Failure		404				{object}	errorResponse			"Not found error"
+//	@Failure		500				{object}	errorResponse			"Internal server error"
+//	@Router			/geofences/{name}/locations [get]
+func (gh *GeofenceHandler) ListGeofenceLocations(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	var req domain.ListGeofenceLocationsRequest
+
+	if value := query.Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil {
+			handleError(w, domain.NewBadRequestCError("limit must be a number"))
+			return
+		}
+		req.Limit = limit
+	}
+
+	if value := query.Get("offset"); value != "" {
+		offset, err := strconv.Atoi(value)
+		if err != nil {
+			handleError(w, domain.NewBadRequestCError("offset must be a number"))
+			return
+		}
+		req.Offset = offset
+	}
+
+	if err := gh.validate.Struct(&req); err != nil {
+		validationError(w, err)
+		return
+	}
+
+	locations, cerr := gh.svc.ListGeofenceLocations(r.Context(), chi.URLParam(r, "name"), &req)
+	if cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+
+	localize := localizer(w, r)
+	for i := range locations {
+		localize(&locations[i])
+	}
+
+	handleSuccess(w, http.StatusOK, locations)
+}
@@ -0,0 +1,110 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"leeta/internal/adapter/validation"
+	"leeta/internal/core/domain"
+	"leeta/internal/core/port/mock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeofenceHandler_CreateGeofence(t *testing.T) {
+	svc := &mock.GeofenceServiceMock{
+		CreateGeofenceFunc: func(ctx context.Context, req *domain.CreateGeofenceRequest) (*domain.Geofence, domain.CError) {
+			return &domain.Geofence{ID: "id", Name: req.Name, Slug: "manhattan", Points: req.Points}, nil
+		},
+	}
+	handler := NewGeofenceHandler(svc, validation.New(nil))
+
+	square := `[{"latitude": 40.70, "longitude": -74.02}, {"latitude": 40.88, "longitude": -74.02}, {"latitude": 40.88, "longitude": -73.90}, {"latitude": 40.70, "longitude": -73.90}]`
+
+	tests := []struct {
+		name   string
+		body   string
+		status int
+	}{
+		{"Success", `{"name": "Manhattan", "points": ` + square + `}`, http.StatusCreated},
+		{"Error - Missing name", `{"points": ` + square + `}`, http.StatusBadRequest},
+		{"Error - Too few points", `{"name": "Manhattan", "points": [{"latitude": 40.70, "longitude": -74.02}, {"latitude": 40.88, "longitude": -74.02}]}`, http.StatusBadRequest},
+		{"Error - Invalid latitude", `{"name": "Manhattan", "points": [{"latitude": 91, "longitude": -74.02}, {"latitude": 40.88, "longitude": -74.02}, {"latitude": 40.88, "longitude": -73.90}]}`, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/geofences", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			handler.CreateGeofence(w, req)
+
+			assert.Equal(t, tt.status, w.Code, w.Body.String())
+		})
+	}
+
+	// only the valid geofences reach the service
+	require.Len(t, svc.CreateGeofenceCalls(), 1)
+	assert.Len(t, svc.CreateGeofenceCalls()[0].Req.Points, 4)
+}
+
+func TestGeofenceHandler_ListGeofenceLocations(t *testing.T) {
+	svc := &mock.GeofenceServiceMock{
+		ListGeofenceLocationsFunc: func(ctx context.Context, name string, req *domain.ListGeofenceLocationsRequest) ([]domain.Location, domain.CError) {
+			if name != "manhattan" {
+				return nil, domain.NewCError(http.StatusNotFound, "geofence not found")
+			}
+			return []domain.Location{*testLocation("Central Park", 40.7829, -73.9654)}, nil
+		},
+	}
+	handler := NewGeofenceHandler(svc, validation.New(nil))
+
+	tests := []struct {
+		name   string
+		fence  string
+		query  string
+		status int
+	}{
+		{"Success", "manhattan", "", http.StatusOK},
+		{"Success - Paginated", "manhattan", "?limit=10&offset=20", http.StatusOK},
+		{"Error - Invalid limit", "manhattan", "?limit=ten", http.StatusBadRequest},
+		{"Error - Limit too large", "manhattan", "?limit=1000", http.StatusBadRequest},
+		{"Error - Negative offset", "manhattan", "?offset=-1", http.StatusBadRequest},
+		{"Error - Unknown geofence", "brooklyn", "", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/geofences/"+tt.fence+"/locations"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			handler.ListGeofenceLocations(w, withURLParam(req, "name", tt.fence))
+
+			assert.Equal(t, tt.status, w.Code, w.Body.String())
+		})
+	}
+
+	calls := svc.ListGeofenceLocationsCalls()
+	require.Len(t, calls, 3)
+	assert.Equal(t, 10, calls[1].Req.Limit)
+	assert.Equal(t, 20, calls[1].Req.Offset)
+
+	t.Run("Locations in the response", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/geofences/manhattan/locations", nil)
+		w := httptest.NewRecorder()
+
+		handler.ListGeofenceLocations(w, withURLParam(req, "name", "manhattan"))
+
+		var res dataResponse[any]
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+		data := res.Data.([]any)
+		require.Len(t, data, 1)
+		assert.Equal(t, "Central Park", data[0].(map[string]any)["name"])
+	})
+}
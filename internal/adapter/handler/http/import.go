@@ -0,0 +1,199 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+
+	"leeta/internal/adapter/locationfile"
+	"leeta/internal/core/domain"
+	"leeta/internal/core/port"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	// maxImportErrors is the number of invalid records reported back when an import is rejected
+	maxImportErrors = 10
+	// maxPreviewErrors is the number of problems listed by the preview of an import
+	maxPreviewErrors = 100
+	// maxPreviewSample is the number of valid records shown by the preview of an import
+	maxPreviewSample = 5
+)
+
+// ImportHandler represents the HTTP handler for location import requests
+type ImportHandler struct {
+	svc      port.ImportService
+	validate *validator.Validate
+}
+
+// NewImportHandler creates a new ImportHandler instance
+func NewImportHandler(svc port.ImportService, vld *validator.Validate) *ImportHandler {
+	return &ImportHandler{
+		svc,
+		vld,
+	}
+}
+
+// ImportLocations godoc
+//
+//	@Summary		Import locations in bulk
+//	@Description	start an asynchronous import of locations sent as a JSON array, as CSV with a name,latitude,longitude[,category] header, as a GeoJSON FeatureCollection of points or as the waypoints of a GPX file. The format is told by the content type, or detected from the body when it is missing or generic. With dry_run the file is only read and checked, and a preview of the import is returned
+//	@Tags			Import
+//	@Accept			json,text/csv,application/geo+json,application/gpx+xml
+//	@Produce		json
+//	@Param			dry_run							query		bool								false	"Only preview the import"
+//	@Param			domain.RegisterLocationRequest	body		[]domain.RegisterLocationRequest	true	"Locations"
+//	@Success		200								{object}	importPreviewResponse				"Preview of the import"
+//	@Success		202								{object}	importJobResponse					"Import started"
+//	@Failure		400								{object}	errorResponse						"Validation error"
+//	@Failure		401								{object}	errorResponse						"Unauthorized error"
+//	@Failure		403								{object}	errorResponse						"Quota of the tenant exceeded"
+//	@Failure		413								{object}	errorResponse						"Request body too large"
+//	@Failure		500								{object}	errorResponse						"Internal server error"
+//	@Router			/locations/import [post]
+//	@Security		BearerAuth
+func (ih *ImportHandler) ImportLocations(w http.ResponseWriter, r *http.Request) {
+	dryRun, cerr := queryBool(r, "dry_run")
+	if cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	format, body, ok := locationfile.Detect(mediaType, r.Body)
+	if !ok {
+		handleError(w, domain.NewBadRequestCError("Unsupported content type, expected application/json, text/csv, application/geo+json or application/gpx+xml"))
+		return
+	}
+
+	var locations []domain.RegisterLocationRequest
+	if format == locationfile.FormatJSON {
+		if cerr := decodeJSONBody(body, &locations); cerr != nil {
+			handleError(w, cerr)
+			return
+		}
+	} else {
+		var err error
+		locations, err = locationfile.Read(format, body)
+		if errors.As(err, new(*http.MaxBytesError)) {
+			handleError(w, domain.ErrPayloadTooLarge)
+			return
+		}
+		if err != nil {
+			handleError(w, domain.NewBadRequestCError("Invalid import body: "+err.Error()))
+			return
+		}
+	}
+
+	if dryRun {
+		handleSuccess(w, http.StatusOK, ih.preview(format, locations))
+		return
+	}
+
+	var errMsgs []string
+	for i := range locations {
+		for _, msg := range ih.check(&locations[i]) {
+			errMsgs = append(errMsgs, fmt.Sprintf("record %d: %s", i+1, msg))
+		}
+
+		if len(errMsgs) >= maxImportErrors {
+			break
+		}
+	}
+
+	if len(errMsgs) > 0 {
+		handleError(w, domain.NewBadRequestCError(strings.Join(errMsgs, " - ")))
+		return
+	}
+
+	job, cerr := ih.svc.ImportLocations(r.Context(), locations)
+	if cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+
+	handleSuccessWithMessage(w, http.StatusAccepted, job, "Import started")
+}
+
+// check resolves the plus code of a record and returns the problems that keep it from being imported
+func (ih *ImportHandler) check(location *domain.RegisterLocationRequest) []string {
+	if cerr := resolvePlusCode(location.PlusCode, &location.Latitude, &location.Longitude); cerr != nil {
+		return []string{cerr.Message()}
+	}
+	if err := ih.validate.Struct(location); err != nil {
+		return parseError(err)
+	}
+	if cerr := checkFootprint(location.Footprint); cerr != nil {
+		return []string{cerr.Message()}
+	}
+	if cerr := checkValidity(location.ValidFrom, location.ValidUntil); cerr != nil {
+		return []string{cerr.Message()}
+	}
+	return nil
+}
+
+// preview checks every record of an import read in format and reports what importing them would do
+func (ih *ImportHandler) preview(format locationfile.Format, locations []domain.RegisterLocationRequest) *domain.ImportPreview {
+	preview := &domain.ImportPreview{
+		Format: string(format),
+		Total:  len(locations),
+		Sample: []domain.RegisterLocationRequest{},
+		Errors: []domain.ImportRecordError{},
+	}
+
+	names := make(map[string]bool, len(locations))
+	for i := range locations {
+		msgs := ih.check(&locations[i])
+		if len(msgs) > 0 {
+			preview.Invalid++
+			for _, msg := range msgs {
+				if len(preview.Errors) < maxPreviewErrors {
+					preview.Errors = append(preview.Errors, domain.ImportRecordError{Record: i + 1, Message: msg})
+				}
+			}
+			continue
+		}
+
+		preview.Valid++
+		if name := strings.ToLower(locations[i].Name); names[name] {
+			preview.Duplicates++
+		} else {
+			names[name] = true
+		}
+		if len(preview.Sample) < maxPreviewSample {
+			preview.Sample = append(preview.Sample, locations[i])
+		}
+	}
+
+	return preview
+}
+
+// GetImportJob godoc
+//
+//	@Summary		Get an import job
+//	@Description	get the status and progress of an import job
+//	@Tags			Import
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string				true	"Import job id"
+//	@Success		200	{object}	importJobResponse	"Success"
+//	@Failure		401	{object}	errorResponse		"Unauthorized error"
+//	@Failure		404	{object}	errorResponse		"Not found error"
+//	@Failure		500	{object}	errorResponse		"Internal server error"
+//	@Router			/locations/import/{id} [get]
+//	@Security		BearerAuth
+func (ih *ImportHandler) GetImportJob(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	job, cerr := ih.svc.GetImportJob(r.Context(), id)
+	if cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+
+	handleSuccess(w, http.StatusOK, job)
+}
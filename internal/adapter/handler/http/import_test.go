@@ -0,0 +1,111 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"leeta/internal/adapter/validation"
+	"leeta/internal/core/domain"
+	"leeta/internal/core/port/mock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportHandler_ImportLocations(t *testing.T) {
+	newHandler := func() (*ImportHandler, *mock.ImportServiceMock) {
+		svc := &mock.ImportServiceMock{
+			ImportLocationsFunc: func(ctx context.Context, locations []domain.RegisterLocationRequest) (*domain.ImportJob, domain.CError) {
+				return &domain.ImportJob{ID: "42", Status: domain.ImportPending, Total: len(locations)}, nil
+			},
+		}
+		return NewImportHandler(svc, validation.New(nil)), svc
+	}
+
+	gpx := `<gpx version="1.1"><wpt lat="6.45" lon="3.47"><name>Lekki Depot</name></wpt></gpx>`
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		status      int
+		imported    int
+	}{
+		{"Success - JSON", "application/json", `[{"name": "Lekki Depot", "latitude": 6.45, "longitude": 3.47}]`, http.StatusAccepted, 1},
+		{"Success - CSV", "text/csv", "name,latitude,longitude\nLekki Depot,6.45,3.47\n", http.StatusAccepted, 1},
+		{"Success - GeoJSON", "application/geo+json", `{"type": "FeatureCollection", "features": [{"type": "Feature", "geometry": {"type": "Point", "coordinates": [3.47, 6.45]}, "properties": {"name": "Lekki Depot"}}]}`, http.StatusAccepted, 1},
+		{"Success - Detected GPX", "", gpx, http.StatusAccepted, 1},
+		{"Error - Invalid record", "application/json", `[{"name": "", "latitude": 6.45, "longitude": 3.47}]`, http.StatusBadRequest, 0},
+		{"Error - Invalid GeoJSON", "application/geo+json", `{"type": "Point"}`, http.StatusBadRequest, 0},
+		{"Error - Unsupported content type", "application/pdf", "%PDF", http.StatusBadRequest, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, svc := newHandler()
+
+			req := httptest.NewRequest(http.MethodPost, "/locations/import", strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			w := httptest.NewRecorder()
+
+			handler.ImportLocations(w, req)
+
+			assert.Equal(t, tt.status, w.Code, w.Body.String())
+			if tt.imported > 0 {
+				require.Len(t, svc.ImportLocationsCalls(), 1)
+				assert.Len(t, svc.ImportLocationsCalls()[0].Locations, tt.imported)
+			} else {
+				assert.Empty(t, svc.ImportLocationsCalls())
+			}
+		})
+	}
+
+	t.Run("Dry run", func(t *testing.T) {
+		handler, svc := newHandler()
+
+		body := "name,latitude,longitude,category\n" +
+			"Lekki Depot,6.45,3.47,depot\n" +
+			"Yaba Depot,95,3.38,depot\n" +
+			"lekki depot,6.46,3.48,depot\n" +
+			",6.47,3.49,depot\n"
+		req := httptest.NewRequest(http.MethodPost, "/locations/import?dry_run=true", strings.NewReader(body))
+		w := httptest.NewRecorder()
+
+		handler.ImportLocations(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Empty(t, svc.ImportLocationsCalls(), "a dry run imports nothing")
+
+		var resp importPreviewResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		preview := resp.Data
+		assert.Equal(t, "csv", preview.Format)
+		assert.Equal(t, 4, preview.Total)
+		assert.Equal(t, 2, preview.Valid)
+		assert.Equal(t, 2, preview.Invalid)
+		assert.Equal(t, 1, preview.Duplicates)
+		require.Len(t, preview.Sample, 2)
+		assert.Equal(t, "Lekki Depot", preview.Sample[0].Name)
+		require.Len(t, preview.Errors, 2)
+		assert.Equal(t, 2, preview.Errors[0].Record)
+		assert.Equal(t, 4, preview.Errors[1].Record)
+	})
+
+	t.Run("Dry run of an unreadable file", func(t *testing.T) {
+		handler, _ := newHandler()
+
+		req := httptest.NewRequest(http.MethodPost, "/locations/import?dry_run=true", strings.NewReader("name,category\nLekki Depot,depot\n"))
+		req.Header.Set("Content-Type", "text/csv")
+		w := httptest.NewRecorder()
+
+		handler.ImportLocations(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+	})
+}
@@ -0,0 +1,175 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"leeta/internal/core/domain"
+	"leeta/internal/core/port"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+)
+
+// JobHandler represents the HTTP handler for the job queue inspection requests
+type JobHandler struct {
+	svc      port.JobService
+	validate *validator.Validate
+}
+
+// NewJobHandler creates a new JobHandler instance
+func NewJobHandler(svc port.JobService, vld *validator.Validate) *JobHandler {
+	return &JobHandler{
+		svc,
+		vld,
+	}
+}
+
+// ListJobs godoc
+//
+//	@Summary		List jobs
+//	@Description	list the jobs of the job queue, most recent first
+//	@Tags			Admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			kind	query		string			false	"Job kind, e.g. import_locations"
+//	@Param			state	query		string			false	"Job state"	Enums(available, running, completed, failed, cancelled)
+//	@Param			limit	query		int				false	"Maximum number of jobs, 20 by default, at most 100"
+//	@Success		200		{object}	jobListResponse	"Success"
+//	@Failure		400		{object}	errorResponse	"Validation error"
+//	@Failure		401		{object}	errorResponse	"Unauthorized error"
+//	@Failure		500		{object}	errorResponse	"Internal server error"
+//	@Router			/admin/jobs [get]
+//	@Security		BearerAuth
+func (jh *JobHandler) ListJobs(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	req := domain.ListJobsRequest{
+		Kind:  query.Get("kind"),
+		State: domain.JobState(query.Get("state")),
+	}
+
+	if value := query.Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil {
+			handleError(w, domain.NewBadRequestCError("limit must be a number"))
+			return
+		}
+		req.Limit = limit
+	}
+
+	if err := jh.validate.Struct(&req); err != nil {
+		validationError(w, err)
+		return
+	}
+
+	jobs, cerr := jh.svc.ListJobs(r.Context(), &req)
+	if cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+
+	handleSuccess(w, http.StatusOK, jobs)
+}
+
+// GetJob godoc
+//
+//	@Summary		Get a job
+//	@Description	get a job of the job queue by its id
+//	@Tags			Admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int				true	"Job id"
+//	@Success		200	{object}	jobResponse		"Success"
+//	@Failure		400	{object}	errorResponse	"Validation error"
+//	@Failure		401	{object}	errorResponse	"Unauthorized error"
+//	@Failure		404	{object}	errorResponse	"Job not found"
+//	@Failure		500	{object}	errorResponse	"Internal server error"
+//	@Router			/admin/jobs/{id} [get]
+//	@Security		BearerAuth
+func (jh *JobHandler) GetJob(w http.ResponseWriter, r *http.Request) {
+	id, ok := jobID(w, r)
+	if !ok {
+		return
+	}
+
+	job, cerr := jh.svc.GetJob(r.Context(), id)
+	if cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+
+	handleSuccess(w, http.StatusOK, job)
+}
+
+// CancelJob godoc
+//
+//	@Summary		Cancel a job
+//	@Description	cancel a job that is waiting for a worker, running and finished jobs cannot be cancelled
+//	@Tags			Admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int				true	"Job id"
+//	@Success		200	{object}	jobResponse		"Job cancelled"
+//	@Failure		400	{object}	errorResponse	"Validation error"
+//	@Failure		401	{object}	errorResponse	"Unauthorized error"
+//	@Failure		404	{object}	errorResponse	"Job not found"
+//	@Failure		409	{object}	errorResponse	"Job is not waiting for a worker"
+//	@Failure		500	{object}	errorResponse	"Internal server error"
+//	@Router			/admin/jobs/{id}/cancel [post]
+//	@Security		BearerAuth
+func (jh *JobHandler) CancelJob(w http.ResponseWriter, r *http.Request) {
+	id, ok := jobID(w, r)
+	if !ok {
+		return
+	}
+
+	job, cerr := jh.svc.CancelJob(r.Context(), id)
+	if cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+
+	handleSuccessWithMessage(w, http.StatusOK, job, "Job cancelled")
+}
+
+// RetryJob godoc
+//
+//	@Summary		Retry a job
+//	@Description	run a failed or cancelled job again, with a new set of attempts
+//	@Tags			Admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int				true	"Job id"
+//	@Success		200	{object}	jobResponse		"Job retried"
+//	@Failure		400	{object}	errorResponse	"Validation error"
+//	@Failure		401	{object}	errorResponse	"Unauthorized error"
+//	@Failure		404	{object}	errorResponse	"Job not found"
+//	@Failure		409	{object}	errorResponse	"Job has not failed nor been cancelled"
+//	@Failure		500	{object}	errorResponse	"Internal server error"
+//	@Router			/admin/jobs/{id}/retry [post]
+//	@Security		BearerAuth
+func (jh *JobHandler) RetryJob(w http.ResponseWriter, r *http.Request) {
+	id, ok := jobID(w, r)
+	if !ok {
+		return
+	}
+
+	job, cerr := jh.svc.RetryJob(r.Context(), id)
+	if cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+
+	handleSuccessWithMessage(w, http.StatusOK, job, "Job retried")
+}
+
+// jobID parses the job id of the path, answering with a validation error when it is not a number
+func jobID(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		handleError(w, domain.NewBadRequestCError("job id must be a number"))
+		return 0, false
+	}
+
+	return id, true
+}
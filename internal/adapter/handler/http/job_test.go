@@ -0,0 +1,81 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"leeta/internal/core/domain"
+	"leeta/internal/core/port/mock"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJobHandler_ListJobs(t *testing.T) {
+	svc := &mock.JobServiceMock{
+		ListJobsFunc: func(ctx context.Context, req *domain.ListJobsRequest) ([]domain.Job, domain.CError) {
+			return []domain.Job{}, nil
+		},
+	}
+	handler := NewJobHandler(svc, validator.New())
+
+	tests := []struct {
+		name   string
+		query  string
+		status int
+	}{
+		{"Success - No filter", "", http.StatusOK},
+		{"Success - Filtered", "?kind=import_locations&state=failed&limit=5", http.StatusOK},
+		{"Error - Unknown state", "?state=stuck", http.StatusBadRequest},
+		{"Error - Limit not a number", "?limit=ten", http.StatusBadRequest},
+		{"Error - Limit too large", "?limit=1000", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/admin/jobs/"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			handler.ListJobs(w, req)
+
+			assert.Equal(t, tt.status, w.Code, w.Body.String())
+		})
+	}
+
+	assert.Len(t, svc.ListJobsCalls(), 2)
+}
+
+func TestJobHandler_CancelJob(t *testing.T) {
+	svc := &mock.JobServiceMock{
+		CancelJobFunc: func(ctx context.Context, id int64) (*domain.Job, domain.CError) {
+			if id != 7 {
+				return nil, domain.NewCError(http.StatusConflict, "only jobs waiting for a worker can be cancelled")
+			}
+			return &domain.Job{ID: id, State: domain.JobCancelled}, nil
+		},
+	}
+	handler := NewJobHandler(svc, validator.New())
+
+	tests := []struct {
+		name   string
+		id     string
+		status int
+	}{
+		{"Success", "7", http.StatusOK},
+		{"Error - Running job", "8", http.StatusConflict},
+		{"Error - Invalid id", "seven", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := withURLParam(httptest.NewRequest(http.MethodPost, "/admin/jobs/"+tt.id+"/cancel", nil), "id", tt.id)
+			w := httptest.NewRecorder()
+
+			handler.CancelJob(w, req)
+
+			assert.Equal(t, tt.status, w.Code, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package http
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"leeta/internal/adapter/config"
+	"leeta/internal/core/auth"
+)
+
+var (
+	errMalformedToken = errors.New("malformed token")
+	errTokenSignature = errors.New("invalid token signature")
+	errTokenExpired   = errors.New("token expired")
+)
+
+/**
+ * jwtVerifier checks the JWTs signed with HS256 and
+ * reads the principal they authenticate. Tokens must
+ * carry a subject and an expiry
+ */
+type jwtVerifier struct {
+	secret   []byte
+	issuer   string
+	audience string
+	leeway   time.Duration
+}
+
+// jwtClaims are the registered claims checked and the claims read into the principal
+type jwtClaims struct {
+	Subject   string      `json:"sub"`
+	Issuer    string      `json:"iss"`
+	Audience  jwtAudience `json:"aud"`
+	ExpiresAt *float64    `json:"exp"`
+	NotBefore *float64    `json:"nbf"`
+	Tenant    string      `json:"tenant"`
+	Roles     []string    `json:"roles"`
+}
+
+// jwtAudience is the aud claim, either a string or an array of them
+type jwtAudience []string
+
+func (a *jwtAudience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = jwtAudience{single}
+		return nil
+	}
+
+	var many []string
+	if err := json.Unmarshal(data, &many); err != nil {
+		return err
+	}
+	*a = many
+	return nil
+}
+
+// newJWTVerifier creates the verifier of the tokens signed with the secret of config
+func newJWTVerifier(config *config.AuthConfiguration) *jwtVerifier {
+	return &jwtVerifier{
+		[]byte(config.Secret),
+		config.Issuer,
+		config.Audience,
+		config.Leeway,
+	}
+}
+
+// verify checks the signature and the claims of token at now and returns the principal it authenticates
+func (v *jwtVerifier) verify(token string, now time.Time) (*auth.Principal, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errMalformedToken
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, errMalformedToken
+	}
+	// the algorithm is fixed, so a token cannot pick a weaker one such as none
+	if header.Alg != "HS256" {
+		return nil, errors.New("unsupported token algorithm")
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, errMalformedToken
+	}
+	mac := hmac.New(sha256.New, v.secret)
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return nil, errTokenSignature
+	}
+
+	var claims jwtClaims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, errMalformedToken
+	}
+
+	if claims.ExpiresAt == nil {
+		return nil, errors.New("token without expiry")
+	}
+	if !now.Add(-v.leeway).Before(numericDate(*claims.ExpiresAt)) {
+		return nil, errTokenExpired
+	}
+	if claims.NotBefore != nil && now.Add(v.leeway).Before(numericDate(*claims.NotBefore)) {
+		return nil, errors.New("token not valid yet")
+	}
+	if v.issuer != "" && claims.Issuer != v.issuer {
+		return nil, errors.New("token of another issuer")
+	}
+	if v.audience != "" && !slices.Contains(claims.Audience, v.audience) {
+		return nil, errors.New("token for another audience")
+	}
+	if claims.Subject == "" {
+		return nil, errors.New("token without subject")
+	}
+
+	return &auth.Principal{
+		User:   claims.Subject,
+		Tenant: claims.Tenant,
+		Roles:  claims.Roles,
+	}, nil
+}
+
+// decodeSegment decodes a base64url encoded JSON segment of a token into v
+func decodeSegment(segment string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// numericDate converts a JWT NumericDate, seconds since the epoch, to a time
+func numericDate(seconds float64) time.Time {
+	return time.Unix(0, int64(seconds*float64(time.Second)))
+}
@@ -2,11 +2,15 @@ package http
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"leeta/internal/adapter/logger"
 	"leeta/internal/core/domain"
+	"leeta/internal/core/errcode"
 	"leeta/internal/core/port"
 
 	"github.com/go-chi/chi/v5"
@@ -14,6 +18,9 @@ import (
 	"go.uber.org/zap"
 )
 
+// exportFlushInterval is the number of exported rows written between flushes
+const exportFlushInterval = 500
+
 // LocationHandler represents the HTTP handler for location-related requests
 type LocationHandler struct {
 	svc      port.LocationService
@@ -31,21 +38,30 @@ func NewLocationHandler(svc port.LocationService, vld *validator.Validate) *Loca
 // RegisterUser godoc
 //
 //	@Summary		Register a new location
-//	@Description	register a new location with all required details
+//	@Description	register a new location with all required details. With moderation enabled, the locations submitted without the admin token wait for review
 //	@Tags			Location
 //	@Accept			json
 //	@Produce		json
 //	@Param			domain.RegisterLocationRequest	body		domain.RegisterLocationRequest	true	"Location"
-//	@Success		201								{object}	response						"Location created successfully"
+//	@Success		201								{object}	locationResponse				"Location created successfully"
+//	@Success		202								{object}	locationResponse				"Location submitted for review"
 //	@Failure		400								{object}	errorResponse					"Validation error"
+//	@Failure		401								{object}	errorResponse					"Unauthorized error"
+//	@Failure		403								{object}	errorResponse					"Quota of the tenant exceeded"
+//	@Failure		413								{object}	errorResponse					"Request body too large"
 //	@Failure		409								{object}	errorResponse					"Conflict error"
 //	@Failure		500								{object}	errorResponse					"Internal server error"
 //	@Router			/locations [post]
+//	@Security		BearerAuth
 func (ch *LocationHandler) RegisterLocation(w http.ResponseWriter, r *http.Request) {
 	var req domain.RegisterLocationRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		logger.FromCtx(r.Context()).Error("Error decoding json body", zap.Error(err))
-		handleError(w, domain.ErrInternal)
+	if cerr := decodeJSON(r, &req); cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+
+	if cerr := resolvePlusCode(req.PlusCode, &req.Latitude, &req.Longitude); cerr != nil {
+		handleError(w, cerr)
 		return
 	}
 
@@ -54,26 +70,45 @@ func (ch *LocationHandler) RegisterLocation(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	if cerr := checkFootprint(req.Footprint); cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+
+	if cerr := checkValidity(req.ValidFrom, req.ValidUntil); cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+
+	req.Review = needsReview(r.Context())
+
 	result, cerr := ch.svc.RegisterLocation(r.Context(), &req)
 	if cerr != nil {
 		handleError(w, cerr)
 		return
 	}
 
+	setETag(w, result)
+	setLastModified(w, result.UpdatedAt)
+	if result.Status == domain.LocationPending {
+		handleSuccessWithMessage(w, http.StatusAccepted, result, "Location submitted for review")
+		return
+	}
 	handleSuccessWithMessage(w, http.StatusCreated, result, "Location created successfully")
 }
 
 // GetLocation godoc
 //
 //	@Summary		Get a location by name
-//	@Description	fetch a location through name
+//	@Description	fetch a location through its name, slug or one of its aliases, the response holds its canonical name
 //	@Tags			Location
 //	@Accept			json
 //	@Produce		json
-//	@Param			name	path		string			true	"Location name"
-//	@Success		200		{object}	response		"Success"
-//	@Failure		400		{object}	errorResponse	"Validation error"
-//	@Failure		500		{object}	errorResponse	"Internal server error"
+//	@Param			name			path		string				true	"Location name"
+//	@Param			Accept-Language	header		string				false	"Preferred locales of the location names, the canonical name is kept when none has a translation"
+//	@Success		200				{object}	locationResponse	"Success"
+//	@Failure		400				{object}	errorResponse		"Validation error"
+//	@Failure		500				{object}	errorResponse		"Internal server error"
 //	@Router			/locations/{name} [get]
 //	@Security		BearerAuth
 func (ch *LocationHandler) GetLocation(w http.ResponseWriter, r *http.Request) {
@@ -89,31 +124,396 @@ func (ch *LocationHandler) GetLocation(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	setETag(w, result)
+	setLastModified(w, result.UpdatedAt)
+	localizer(w, r)(result)
 	handleSuccess(w, http.StatusOK, result)
 }
 
+// UpdateLocation godoc
+//
+//	@Summary		Update a location
+//	@Description	replace the details of a location. The version the update is based on must be sent in the body or as an If-Match header, a stale version in the body is rejected with a conflict, and one in If-Match with a failed precondition holding the current location
+//	@Tags			Location
+//	@Accept			json
+//	@Produce		json
+//	@Param			name							path		string							true	"Location name"
+//	@Param			If-Match						header		string							false	"Expected version"
+//	@Param			If-Unmodified-Since				header		string							false	"Time the location must not have changed after, ignored along with If-Match"
+//	@Param			domain.UpdateLocationRequest	body		domain.UpdateLocationRequest	true	"Location"
+//	@Success		200								{object}	locationResponse				"Location updated successfully"
+//	@Failure		400								{object}	errorResponse					"Validation error"
+//	@Failure		401								{object}	errorResponse					"Unauthorized error"
+//	@Failure		413								{object}	errorResponse					"Request body too large"
+//	@Failure		404								{object}	errorResponse					"Not found error"
+//	@Failure		409								{object}	errorResponse					"Conflict error"
+//	@Failure		412								{object}	preconditionFailedResponse		"Precondition failed, with the current location"
+//	@Failure		500								{object}	errorResponse					"Internal server error"
+//	@Router			/locations/{name} [put]
+//	@Security		BearerAuth
+func (ch *LocationHandler) UpdateLocation(w http.ResponseWriter, r *http.Request) {
+	name := chi.URLParam(r, "name")
+	if name == "" {
+		handleError(w, domain.NewBadRequestCError("Invalid location name"))
+		return
+	}
+
+	req, ok := ch.updateRequest(w, r)
+	if !ok {
+		return
+	}
+
+	result, cerr := ch.svc.UpdateLocation(r.Context(), name, req)
+	if cerr != nil {
+		handleChangeError(w, cerr)
+		return
+	}
+
+	setETag(w, result)
+	setLastModified(w, result.UpdatedAt)
+	handleSuccessWithMessage(w, http.StatusOK, result, "Location updated successfully")
+}
+
+// updateRequest decodes and validates the body of an update, with the precondition of its headers if any.
+// It writes the error response and returns false if the request is invalid
+func (ch *LocationHandler) updateRequest(w http.ResponseWriter, r *http.Request) (*domain.UpdateLocationRequest, bool) {
+	var body updateBody
+	if cerr := decodeJSON(r, &body); cerr != nil {
+		handleError(w, cerr)
+		return nil, false
+	}
+
+	precondition, cerr := readPrecondition(r)
+	if cerr != nil {
+		handleError(w, cerr)
+		return nil, false
+	}
+	if precondition.Version != 0 {
+		body.Version = precondition.Version
+	}
+	body.Precondition = precondition
+
+	if err := ch.validate.Struct(&body); err != nil {
+		validationError(w, err)
+		return nil, false
+	}
+
+	req := body.UpdateLocationRequest
+	req.Latitude, req.Longitude = *body.Latitude, *body.Longitude
+
+	if cerr := checkFootprint(req.Footprint); cerr != nil {
+		handleError(w, cerr)
+		return nil, false
+	}
+
+	if cerr := checkValidity(req.ValidFrom, req.ValidUntil); cerr != nil {
+		handleError(w, cerr)
+		return nil, false
+	}
+
+	return &req, true
+}
+
+// updateBody is the body of an update. Its coordinates are pointers, so that a location on the equator or the
+// prime meridian is told apart from an update without coordinates
+type updateBody struct {
+	domain.UpdateLocationRequest
+	Latitude  *float64 `json:"latitude" validate:"required,min=-90,max=90"`
+	Longitude *float64 `json:"longitude" validate:"required,min=-180,max=180"`
+}
+
+// setETag exposes the version of location as its entity tag
+func setETag(w http.ResponseWriter, location *domain.Location) {
+	w.Header().Set("ETag", strconv.Quote(strconv.FormatInt(location.Version, 10)))
+}
+
+// readPrecondition reads the precondition of a change from the If-Match and If-Unmodified-Since headers of r.
+// As HTTP asks, If-Unmodified-Since is ignored along with If-Match or when it is not a valid date
+func readPrecondition(r *http.Request) (domain.Precondition, domain.CError) {
+	var precondition domain.Precondition
+
+	if header := r.Header.Get("If-Match"); header != "" {
+		version, ok := parseIfMatch(header)
+		if !ok {
+			return precondition, domain.NewBadRequestCError("Invalid If-Match header, expected a location version such as \"3\"")
+		}
+		precondition.Version = version
+		return precondition, nil
+	}
+
+	if header := r.Header.Get("If-Unmodified-Since"); header != "" {
+		if t, err := http.ParseTime(header); err == nil {
+			precondition.UnmodifiedSince = t
+		}
+	}
+
+	return precondition, nil
+}
+
+// handleChangeError answers a change whose precondition failed with the current location, which the client
+// reapplies its change on top of, and other errors like handleError
+func handleChangeError(w http.ResponseWriter, cerr domain.CError) {
+	var failed *domain.PreconditionFailedError
+	if !errors.As(cerr, &failed) {
+		handleError(w, cerr)
+		return
+	}
+
+	setETag(w, failed.Current)
+	setLastModified(w, failed.Current.UpdatedAt)
+	w.WriteHeader(http.StatusPreconditionFailed)
+	json.NewEncoder(w).Encode(preconditionFailedResponse{
+		Message:   failed.Message(),
+		Code:      failed.ErrorCode(),
+		RequestID: w.Header().Get(requestIDHeader),
+		Data:      *failed.Current,
+	})
+}
+
+// parseIfMatch reads the location version from an If-Match header such as "3" or W/"3"
+func parseIfMatch(header string) (int64, bool) {
+	tag := strings.TrimPrefix(strings.TrimSpace(header), "W/")
+	tag = strings.Trim(tag, `"`)
+
+	version, err := strconv.ParseInt(tag, 10, 64)
+	if err != nil || version < 1 {
+		return 0, false
+	}
+
+	return version, true
+}
+
 // ListLocations godoc
 //
 //	@Summary		List all locations
-//	@Description	list all registered active locations
+//	@Description	list all registered active locations, optionally narrowed by a filter such as `category:park AND created_at>2024-01-01`
 //	@Tags			Location
 //	@Accept			json
 //	@Produce		json
-//	@Success		200	{object}	response		"Success"
-//	@Failure		400	{object}	errorResponse	"Validation error"
-//	@Failure		500	{object}	errorResponse	"Internal server error"
+//	@Param			q				query		string					false	"Filter"
+//	@Param			collection		query		string					false	"Only list the locations of this collection"
+//	@Param			include_expired	query		bool					false	"Also list the locations outside their validity"
+//	@Param			sort			query		string					false	"Order of the list, most recent first"	Enums(created_at, updated_at)
+//	@Param			Accept-Language	header		string					false	"Preferred locales of the location names, the canonical name is kept when none has a translation"
+//	@Success		200				{object}	locationListResponse	"Success"
+//	@Failure		400				{object}	errorResponse			"Validation error"
+//	@Failure		404				{object}	errorResponse			"Collection not found"
+//	@Failure		500				{object}	errorResponse			"Internal server error"
 //	@Router			/locations [get]
 //	@Security		BearerAuth
 func (ch *LocationHandler) ListLocations(w http.ResponseWriter, r *http.Request) {
-	results, cerr := ch.svc.ListLocations(r.Context())
+	includeExpired, cerr := queryBool(r, "include_expired")
+	if cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+
+	sort, cerr := locationSort(r)
 	if cerr != nil {
 		handleError(w, cerr)
 		return
 	}
 
+	req := domain.ListLocationsRequest{
+		Query:          r.URL.Query().Get("q"),
+		Collection:     r.URL.Query().Get("collection"),
+		IncludeExpired: includeExpired,
+		Sort:           sort,
+	}
+
+	results, cerr := ch.svc.ListLocations(r.Context(), &req)
+	if cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+
+	// the list was last modified when its most recently updated location was
+	var lastModified time.Time
+	for _, location := range results {
+		if location.UpdatedAt.After(lastModified) {
+			lastModified = location.UpdatedAt
+		}
+	}
+	setLastModified(w, lastModified)
+
+	localize := localizer(w, r)
+	for i := range results {
+		localize(&results[i])
+	}
+
 	handleSuccess(w, http.StatusOK, results)
 }
 
+// GetLocationGeometry godoc
+//
+//	@Summary		Compute the geometry of a set of locations
+//	@Description	get the centroid, bounding box and convex hull as GeoJSON of the locations matching a filter or listed by slug, e.g. to fit a map viewport to them. Without a filter or slugs every location is included
+//	@Tags			Location
+//	@Accept			json
+//	@Produce		json
+//	@Param			domain.LocationGeometryRequest	body		domain.LocationGeometryRequest	true	"Locations"
+//	@Success		200								{object}	locationGeometryResponse		"Success"
+//	@Failure		400								{object}	errorResponse					"Validation error"
+//	@Failure		404								{object}	errorResponse					"Collection not found"
+//	@Failure		413								{object}	errorResponse					"Request body too large"
+//	@Failure		500								{object}	errorResponse					"Internal server error"
+//	@Router			/locations/geometry [post]
+//	@Security		BearerAuth
+func (ch *LocationHandler) GetLocationGeometry(w http.ResponseWriter, r *http.Request) {
+	var req domain.LocationGeometryRequest
+	if cerr := decodeJSON(r, &req); cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+
+	if err := ch.validate.Struct(&req); err != nil {
+		validationError(w, err)
+		return
+	}
+
+	geometry, cerr := ch.svc.LocationGeometry(r.Context(), &req)
+	if cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+
+	handleSuccess(w, http.StatusOK, geometry)
+}
+
+// LookupLocations godoc
+//
+//	@Summary		Look up locations by id or slug
+//	@Description	fetch up to 1000 locations by id or slug at once, along with the ids and slugs that match no location, e.g. for a client to reconcile its cache
+//	@Tags			Location
+//	@Accept			json
+//	@Produce		json
+//	@Param			domain.LookupLocationsRequest	body		domain.LookupLocationsRequest	true	"Ids and slugs"
+//	@Success		200								{object}	locationLookupResponse			"Success"
+//	@Failure		400								{object}	errorResponse					"Validation error"
+//	@Failure		413								{object}	errorResponse					"Request body too large"
+//	@Failure		500								{object}	errorResponse					"Internal server error"
+//	@Router			/locations/lookup [post]
+//	@Security		BearerAuth
+func (ch *LocationHandler) LookupLocations(w http.ResponseWriter, r *http.Request) {
+	var req domain.LookupLocationsRequest
+	if cerr := decodeJSON(r, &req); cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+
+	if err := ch.validate.Struct(&req); err != nil {
+		validationError(w, err)
+		return
+	}
+
+	lookup, cerr := ch.svc.LookupLocations(r.Context(), &req)
+	if cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+
+	localize := localizer(w, r)
+	for i := range lookup.Found {
+		localize(&lookup.Found[i])
+	}
+
+	handleSuccess(w, http.StatusOK, lookup)
+}
+
+// ExportLocations godoc
+//
+//	@Summary		Export locations
+//	@Description	stream all locations matching an optional filter, one JSON object per line
+//	@Tags			Location
+//	@Produce		application/x-ndjson
+//	@Param			format			query		string			false	"Export format"	Enums(ndjson)
+//	@Param			q				query		string			false	"Filter"
+//	@Param			collection		query		string			false	"Only export the locations of this collection"
+//	@Param			include_expired	query		bool			false	"Also export the locations outside their validity"
+//	@Param			sort			query		string			false	"Order of the export, most recent first"	Enums(created_at, updated_at)
+//	@Success		200				{object}	domain.Location	"One location per line"
+//	@Failure		400				{object}	errorResponse	"Validation error"
+//	@Failure		404				{object}	errorResponse	"Collection not found"
+//	@Failure		422				{object}	errorResponse	"Export without a filter too large for the plan of the tenant"
+//	@Failure		500				{object}	errorResponse	"Internal server error"
+//	@Router			/locations/export [get]
+//	@Security		BearerAuth
+func (ch *LocationHandler) ExportLocations(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	if format != "" && format != "ndjson" {
+		handleError(w, domain.NewBadRequestCError("Unsupported export format"))
+		return
+	}
+
+	includeExpired, cerr := queryBool(r, "include_expired")
+	if cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+
+	sort, cerr := locationSort(r)
+	if cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+
+	req := domain.ListLocationsRequest{
+		Query:          r.URL.Query().Get("q"),
+		Collection:     r.URL.Query().Get("collection"),
+		IncludeExpired: includeExpired,
+		Sort:           sort,
+	}
+
+	rc := http.NewResponseController(w)
+	enc := json.NewEncoder(w)
+	written := 0
+
+	// an export can take longer than the server write timeout, the request context still bounds it
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		logger.FromCtx(r.Context()).Warn("Error lifting the export write deadline", zap.Error(err))
+	}
+
+	cerr = ch.svc.ExportLocations(r.Context(), &req, func(location *domain.Location) error {
+		if written == 0 {
+			w.Header().Set("Content-Type", "application/x-ndjson")
+			w.WriteHeader(http.StatusOK)
+		}
+
+		if err := enc.Encode(location); err != nil {
+			return err
+		}
+
+		written++
+		if written%exportFlushInterval == 0 {
+			return rc.Flush()
+		}
+		return nil
+	})
+
+	if cerr != nil {
+		if written == 0 {
+			handleError(w, cerr)
+			return
+		}
+
+		// the status has already been sent, so the error ends the stream as its last line, and the
+		// connection is aborted so the response is not mistaken for a complete one
+		logger.FromCtx(r.Context()).Error("Error streaming export", zap.Error(cerr), zap.Int("written", written))
+		errRsp := newErrorResponse([]string{cerr.Message()})
+		errRsp.Code = cerr.ErrorCode()
+		errRsp.RequestID = w.Header().Get(requestIDHeader)
+		if err := enc.Encode(errRsp); err == nil {
+			rc.Flush()
+		}
+		panic(http.ErrAbortHandler)
+	}
+
+	if written == 0 {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
 // Delete Location godoc
 //
 //	@Summary		Delete a location by name
@@ -121,11 +521,15 @@ func (ch *LocationHandler) ListLocations(w http.ResponseWriter, r *http.Request)
 //	@Tags			Location
 //	@Accept			json
 //	@Produce		json
-//	@Param			name	path		string			true	"Location name"
-//	@Success		200		{object}	response		"Success"
-//	@Failure		400		{object}	errorResponse	"Validation error"
-//	@Failure		404		{object}	errorResponse	"Not found error"
-//	@Failure		500		{object}	errorResponse	"Internal server error"
+//	@Param			name				path		string						true	"Location name"
+//	@Param			If-Match			header		string						false	"Expected version"
+//	@Param			If-Unmodified-Since	header		string						false	"Time the location must not have changed after, ignored along with If-Match"
+//	@Success		200					{object}	response					"Success"
+//	@Failure		400					{object}	errorResponse				"Validation error"
+//	@Failure		401					{object}	errorResponse				"Unauthorized error"
+//	@Failure		404					{object}	errorResponse				"Not found error"
+//	@Failure		412					{object}	preconditionFailedResponse	"Precondition failed, with the current location"
+//	@Failure		500					{object}	errorResponse				"Internal server error"
 //	@Router			/locations/{name} [delete]
 //	@Security		BearerAuth
 func (ch *LocationHandler) DeleteLocation(w http.ResponseWriter, r *http.Request) {
@@ -135,15 +539,141 @@ func (ch *LocationHandler) DeleteLocation(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	cerr := ch.svc.DeleteLocation(r.Context(), name)
+	precondition, cerr := readPrecondition(r)
+	if cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+
+	cerr = ch.svc.DeleteLocation(r.Context(), name, precondition)
+	if cerr != nil {
+		handleChangeError(w, cerr)
+		return
+	}
+
+	handleMessage(w, http.StatusOK, "Deleted location successfully")
+}
+
+// GetLocationByID godoc
+//
+//	@Summary		Get a location by id
+//	@Description	fetch a location through its id, which unlike its name never changes, so integrators can keep it as a reference
+//	@Tags			Location
+//	@Accept			json
+//	@Produce		json
+//	@Param			id				path		string				true	"Location id"
+//	@Param			Accept-Language	header		string				false	"Preferred locales of the location names, the canonical name is kept when none has a translation"
+//	@Success		200				{object}	locationResponse	"Success"
+//	@Failure		404				{object}	errorResponse		"Not found error"
+//	@Failure		500				{object}	errorResponse		"Internal server error"
+//	@Router			/locations/id/{id} [get]
+//	@Security		BearerAuth
+func (ch *LocationHandler) GetLocationByID(w http.ResponseWriter, r *http.Request) {
+	result, cerr := ch.svc.GetLocationByID(r.Context(), chi.URLParam(r, "id"))
+	if cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+
+	setETag(w, result)
+	setLastModified(w, result.UpdatedAt)
+	localizer(w, r)(result)
+	handleSuccess(w, http.StatusOK, result)
+}
+
+// UpdateLocationByID godoc
+//
+//	@Summary		Update a location by id
+//	@Description	replace the details of a location through its id, like an update by name
+//	@Tags			Location
+//	@Accept			json
+//	@Produce		json
+//	@Param			id								path		string							true	"Location id"
+//	@Param			If-Match						header		string							false	"Expected version"
+//	@Param			If-Unmodified-Since				header		string							false	"Time the location must not have changed after, ignored along with If-Match"
+//	@Param			domain.UpdateLocationRequest	body		domain.UpdateLocationRequest	true	"Location"
+//	@Success		200								{object}	locationResponse				"Location updated successfully"
+//	@Failure		400								{object}	errorResponse					"Validation error"
+//	@Failure		401								{object}	errorResponse					"Unauthorized error"
+//	@Failure		413								{object}	errorResponse					"Request body too large"
+//	@Failure		404								{object}	errorResponse					"Not found error"
+//	@Failure		409								{object}	errorResponse					"Conflict error"
+//	@Failure		412								{object}	preconditionFailedResponse		"Precondition failed, with the current location"
+//	@Failure		500								{object}	errorResponse					"Internal server error"
+//	@Router			/locations/id/{id} [put]
+//	@Security		BearerAuth
+func (ch *LocationHandler) UpdateLocationByID(w http.ResponseWriter, r *http.Request) {
+	req, ok := ch.updateRequest(w, r)
+	if !ok {
+		return
+	}
+
+	result, cerr := ch.svc.UpdateLocationByID(r.Context(), chi.URLParam(r, "id"), req)
+	if cerr != nil {
+		handleChangeError(w, cerr)
+		return
+	}
+
+	setETag(w, result)
+	setLastModified(w, result.UpdatedAt)
+	handleSuccessWithMessage(w, http.StatusOK, result, "Location updated successfully")
+}
+
+// DeleteLocationByID godoc
+//
+//	@Summary		Delete a location by id
+//	@Description	delete a location through its id
+//	@Tags			Location
+//	@Accept			json
+//	@Produce		json
+//	@Param			id					path		string						true	"Location id"
+//	@Param			If-Match			header		string						false	"Expected version"
+//	@Param			If-Unmodified-Since	header		string						false	"Time the location must not have changed after, ignored along with If-Match"
+//	@Success		200					{object}	response					"Success"
+//	@Failure		401					{object}	errorResponse				"Unauthorized error"
+//	@Failure		404					{object}	errorResponse				"Not found error"
+//	@Failure		412					{object}	preconditionFailedResponse	"Precondition failed, with the current location"
+//	@Failure		500					{object}	errorResponse				"Internal server error"
+//	@Router			/locations/id/{id} [delete]
+//	@Security		BearerAuth
+func (ch *LocationHandler) DeleteLocationByID(w http.ResponseWriter, r *http.Request) {
+	precondition, cerr := readPrecondition(r)
 	if cerr != nil {
 		handleError(w, cerr)
 		return
 	}
 
-	handleSuccessWithMessage(w, http.StatusOK, nil, "Deleted location successfully")
+	if cerr := ch.svc.DeleteLocationByID(r.Context(), chi.URLParam(r, "id"), precondition); cerr != nil {
+		handleChangeError(w, cerr)
+		return
+	}
+
+	handleMessage(w, http.StatusOK, "Deleted location successfully")
+}
+
+// nearestQuery is the query string of a nearest location lookup, the unit and formula are read on their own
+// since they are checked against the parsed values of the domain
+type nearestQuery struct {
+	pointQuery
+	Altitude       *float64          `query:"alt" validate:"omitempty,min=-1000,max=20000"`
+	MaxTravelTime  *int              `query:"max_travel_time" validate:"omitempty,min=1,max=7200"`
+	Mode           domain.TravelMode `query:"mode" validate:"omitempty,oneof=driving walking cycling"`
+	Collection     string            `query:"collection"`
+	IncludeExpired bool              `query:"include_expired"`
+	Alternatives   bool              `query:"alternatives"`
 }
 
+// nearestBody is the body of a nearest search. Its coordinates are pointers, so that a point on the equator
+// or the prime meridian is told apart from one without coordinates
+type nearestBody struct {
+	domain.NearestLocationsRequest
+	Latitude  *float64 `json:"latitude" validate:"required_without=PlusCode,omitempty,min=-90,max=90"`
+	Longitude *float64 `json:"longitude" validate:"required_without=PlusCode,omitempty,min=-180,max=180"`
+}
+
+// nearestAlternatives is the number of locations after the nearest one a lookup with alternatives returns
+const nearestAlternatives = 2
+
 // GetNearestLocation godoc
 //
 //	@Summary		Get the nearest location to the longitude and latitude
@@ -151,32 +681,221 @@ func (ch *LocationHandler) DeleteLocation(w http.ResponseWriter, r *http.Request
 //	@Tags			Location
 //	@Accept			json
 //	@Produce		json
-//	@Param			lat	query		float64			true	"Latitude"
-//	@Param			lng	query		float64			true	"Longitude"
-//	@Success		200	{object}	response		"Success"
-//	@Failure		400	{object}	errorResponse	"Validation error"
-//	@Failure		404	{object}	errorResponse	"Not found error"
-//	@Failure		500	{object}	errorResponse	"Internal server error"
+//	@Param			lat				query		float64					false	"Latitude, required without plus_code"	minimum(-90)	maximum(90)
+//	@Param			lng				query		float64					false	"Longitude, required without plus_code"	minimum(-180)	maximum(180)
+//	@Param			plus_code		query		string					false	"Full plus code of the point, instead of lat and lng"
+//	@Param			alt				query		float64					false	"Altitude of the point in meters above sea level, distances to locations with an altitude are then in 3D"	minimum(-1000)	maximum(20000)
+//	@Param			unit			query		string					false	"Unit of the formatted distance, defaults to the region of Accept-Language"									Enums(m, km, mi)
+//	@Param			formula			query		string					false	"Distance formula, defaults to the configured formula"														Enums(spheroid, haversine, vincenty)
+//	@Param			max_travel_time	query		int						false	"Only return a location reachable within this many seconds"													minimum(1)	maximum(7200)
+//	@Param			mode			query		string					false	"Mode of travel of max_travel_time, defaults to driving"													Enums(driving, walking, cycling)
+//	@Param			collection		query		string					false	"Only return a location of this collection"
+//	@Param			include_expired	query		bool					false	"Also consider the locations outside their validity"
+//	@Param			alternatives	query		bool					false	"Also return the next two nearest locations with their distances, as fallbacks"
+//	@Param			Accept-Language	header		string					false	"Preferred locales of the location names, the canonical name is kept when none has a translation"
+//	@Success		200				{object}	nearestLocationResponse	"Success"
+//	@Failure		400				{object}	errorResponse			"Validation error"
+//	@Failure		404				{object}	errorResponse			"Not found error"
+//	@Failure		500				{object}	errorResponse			"Internal server error"
+//	@Failure		501				{object}	errorResponse			"No routing service is configured"
 //	@Router			/locations/nearest [get]
 //	@Security		BearerAuth
 func (ch *LocationHandler) GetNearestLocation(w http.ResponseWriter, r *http.Request) {
-	latitude, err := strconv.ParseFloat(r.URL.Query().Get("lat"), 64)
-	if err != nil {
-		handleError(w, domain.NewBadRequestCError("Invalid latitude"))
+	var query nearestQuery
+	if cerr := bindQuery(r, &query, ch.validate); cerr != nil {
+		handleError(w, cerr)
 		return
 	}
 
-	longitude, err := strconv.ParseFloat(r.URL.Query().Get("lng"), 64)
-	if err != nil {
-		handleError(w, domain.NewBadRequestCError("Invalid longitude"))
+	latitude, longitude, cerr := query.point()
+	if cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+
+	unit, cerr := distanceUnit(r)
+	if cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+
+	formula, cerr := distanceFormula(r)
+	if cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+
+	var maxTravelTime int
+	if query.MaxTravelTime != nil {
+		maxTravelTime = *query.MaxTravelTime
+	}
+
+	// the nearest location of a collection, within a travel time, in 3D, among the expired locations too or
+	// with its alternatives, is the first of a search
+	if maxTravelTime > 0 || query.Collection != "" || query.Altitude != nil || query.IncludeExpired || query.Alternatives {
+		limit := 1
+		if query.Alternatives {
+			limit += nearestAlternatives
+		}
+
+		results, cerr := ch.svc.FindNearestLocations(r.Context(), &domain.NearestLocationsRequest{
+			Latitude:       latitude,
+			Longitude:      longitude,
+			Altitude:       query.Altitude,
+			Limit:          limit,
+			Formula:        formula,
+			MaxTravelTime:  maxTravelTime,
+			Mode:           query.Mode,
+			Collection:     query.Collection,
+			IncludeExpired: query.IncludeExpired,
+		})
+		if cerr != nil {
+			handleError(w, cerr)
+			return
+		}
+		if len(results) == 0 {
+			if maxTravelTime > 0 {
+				handleError(w, domain.NewCodedCError(errcode.NoLocationFound, "no location reachable within the travel time"))
+			} else {
+				handleError(w, domain.NewCodedCError(errcode.NoLocationFound, "no location found"))
+			}
+			return
+		}
+		localize := localizer(w, r)
+		for i := range results {
+			results[i].Unit = unit
+			localize(&results[i].Location)
+		}
+
+		nearest := &results[0]
+		nearest.Alternatives = results[1:]
+
+		handleSuccess(w, http.StatusOK, nearest)
 		return
 	}
 
-	result, cerr := ch.svc.GetNearestLocation(r.Context(), latitude, longitude)
+	result, cerr := ch.svc.GetNearestLocation(r.Context(), latitude, longitude, formula)
 	if cerr != nil {
 		handleError(w, cerr)
 		return
 	}
+	result.Unit = unit
+	localizer(w, r)(&result.Location)
 
 	handleSuccess(w, http.StatusOK, result)
 }
+
+// SearchNearestLocations godoc
+//
+//	@Summary		Search the nearest locations with filters
+//	@Description	get the nearest locations to a point, optionally limited by distance, travel time, categories and exclusions
+//	@Tags			Location
+//	@Accept			json
+//	@Produce		json
+//	@Param			domain.NearestLocationsRequest	body		domain.NearestLocationsRequest	true	"Nearest search"
+//	@Param			unit							query		string							false	"Unit of the formatted distances when the body has none"	Enums(m, km, mi)
+//	@Param			Accept-Language					header		string							false	"Preferred locales of the location names, the canonical name is kept when none has a translation"
+//	@Success		200								{object}	nearestLocationListResponse		"Success"
+//	@Failure		400								{object}	errorResponse					"Validation error"
+//	@Failure		404								{object}	errorResponse					"Collection not found"
+//	@Failure		413								{object}	errorResponse					"Request body too large"
+//	@Failure		422								{object}	errorResponse					"Search too wide without categories for the plan of the tenant"
+//	@Failure		500								{object}	errorResponse					"Internal server error"
+//	@Failure		501								{object}	errorResponse					"No routing service is configured"
+//	@Router			/locations/nearest [post]
+//	@Security		BearerAuth
+func (ch *LocationHandler) SearchNearestLocations(w http.ResponseWriter, r *http.Request) {
+	var body nearestBody
+	if cerr := decodeJSON(r, &body); cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+
+	if err := ch.validate.Struct(&body); err != nil {
+		validationError(w, err)
+		return
+	}
+
+	point := pointQuery{Latitude: body.Latitude, Longitude: body.Longitude, PlusCode: body.PlusCode}
+	latitude, longitude, cerr := point.point()
+	if cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+
+	req := body.NearestLocationsRequest
+	req.Latitude, req.Longitude = latitude, longitude
+
+	unit := req.Unit
+	if unit == "" {
+		var cerr domain.CError
+		if unit, cerr = distanceUnit(r); cerr != nil {
+			handleError(w, cerr)
+			return
+		}
+	}
+
+	results, cerr := ch.svc.FindNearestLocations(r.Context(), &req)
+	if cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+	localize := localizer(w, r)
+	for i := range results {
+		results[i].Unit = unit
+		localize(&results[i].Location)
+	}
+
+	handleSuccess(w, http.StatusOK, results)
+}
+
+// FindLocationsAlongRoute godoc
+//
+//	@Summary		Search the locations along a route
+//	@Description	get the locations within a buffer distance of a route given as an encoded polyline or waypoints, in the order the route passes them
+//	@Tags			Location
+//	@Accept			json
+//	@Produce		json
+//	@Param			domain.AlongRouteRequest	body		domain.AlongRouteRequest	true	"Route search"
+//	@Param			unit						query		string						false	"Unit of the formatted distances when the body has none"	Enums(m, km, mi)
+//	@Param			Accept-Language				header		string						false	"Preferred locales of the location names, the canonical name is kept when none has a translation"
+//	@Success		200							{object}	routeLocationListResponse	"Success"
+//	@Failure		400							{object}	errorResponse				"Validation error"
+//	@Failure		413							{object}	errorResponse				"Request body too large"
+//	@Failure		500							{object}	errorResponse				"Internal server error"
+//	@Router			/locations/along-route [post]
+//	@Security		BearerAuth
+func (ch *LocationHandler) FindLocationsAlongRoute(w http.ResponseWriter, r *http.Request) {
+	var req domain.AlongRouteRequest
+	if cerr := decodeJSON(r, &req); cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+
+	if err := ch.validate.Struct(&req); err != nil {
+		validationError(w, err)
+		return
+	}
+
+	unit := req.Unit
+	if unit == "" {
+		var cerr domain.CError
+		if unit, cerr = distanceUnit(r); cerr != nil {
+			handleError(w, cerr)
+			return
+		}
+	}
+
+	results, cerr := ch.svc.FindLocationsAlongRoute(r.Context(), &req)
+	if cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+	localize := localizer(w, r)
+	for i := range results {
+		results[i].Unit = unit
+		localize(&results[i].Location)
+	}
+
+	handleSuccess(w, http.StatusOK, results)
+}
@@ -0,0 +1,74 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"slices"
+	"testing"
+	"time"
+
+	"leeta/internal/adapter/storage/postgres/postgrestest"
+	"leeta/internal/adapter/storage/postgres/repository"
+	"leeta/internal/core/service"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(postgrestest.Run(m))
+}
+
+// BenchmarkLocationHandler_GetNearestLocation measures nearest lookups over a million locations
+// spread across the globe and reports their p99 latency
+func BenchmarkLocationHandler_GetNearestLocation(b *testing.B) {
+	ctx := context.Background()
+	const rows = 1_000_000
+
+	db := postgrestest.DB(b)
+	handler := NewLocationHandler(service.NewLocationService(repository.NewLocationRepository(db), 0, 0, nil, nil, "", nil, nil, nil, ""), validator.New())
+
+	_, err := db.Exec(ctx, "DELETE FROM locations")
+	require.NoError(b, err)
+	_, err = db.Exec(ctx, `
+		INSERT INTO locations (name, slug, latitude, longitude, geo)
+		SELECT 'Location ' || i, 'location-' || i, lat, lng, ST_MakePoint(lng, lat)::geography
+		FROM (
+			SELECT i, random() * 170 - 85 AS lat, random() * 360 - 180 AS lng
+			FROM generate_series(1, $1) AS i
+		) AS points
+	`, rows)
+	require.NoError(b, err)
+	_, err = db.Exec(ctx, "ANALYZE locations")
+	require.NoError(b, err)
+	b.Cleanup(func() {
+		db.Exec(context.Background(), "DELETE FROM locations")
+	})
+
+	durations := make([]time.Duration, 0, b.N)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		lat := rand.Float64()*170 - 85
+		lng := rand.Float64()*360 - 180
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/location/nearest?lat=%f&lng=%f", lat, lng), nil)
+		w := httptest.NewRecorder()
+
+		start := time.Now()
+		handler.GetNearestLocation(w, req)
+		durations = append(durations, time.Since(start))
+
+		if w.Code != http.StatusOK {
+			b.Fatalf("unexpected status %d: %s", w.Code, w.Body.String())
+		}
+	}
+
+	b.StopTimer()
+	slices.Sort(durations)
+	p99 := durations[(len(durations)*99)/100]
+	b.ReportMetric(float64(p99.Microseconds())/1000, "p99-ms")
+}
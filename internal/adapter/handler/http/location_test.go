@@ -4,88 +4,60 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
-	"os"
+	"strings"
 	"testing"
+	"time"
 
-	"leeta/internal/adapter/config"
-	"leeta/internal/adapter/storage/postgres"
-	"leeta/internal/adapter/storage/postgres/repository"
+	"leeta/internal/adapter/validation"
 	"leeta/internal/core/domain"
-	"leeta/internal/core/port"
-	"leeta/internal/core/service"
+	"leeta/internal/core/errcode"
+	"leeta/internal/core/port/mock"
 
 	"github.com/go-chi/chi/v5"
-	"github.com/go-playground/validator/v10"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
-var testDB *postgres.DB
-var testHandler *LocationHandler
-var testService port.LocationService
-
-// setupTestDB initializes the test database and service
-func setupTestDB(t *testing.T) {
-	// Create test database configuration
-	dbConfig := &config.DatabaseConfiguration{
-		Protocol: "postgres",
-		Host:     "localhost",
-		Port:     "5433",
-		User:     "postgres",
-		Password: "postgres",
-		Name:     "postgres",
-	}
-
-	// Connect to test database
-	ctx := context.Background()
-	var err error
-	testDB, err = postgres.New(ctx, dbConfig)
-	assert.NoError(t, err, "Failed to connect to test database")
-
-	// Run migrations
-	err = testDB.Migrate()
-	assert.NoError(t, err, "Failed to run database migrations")
-
-	// Create repository and service
-	repo := repository.NewLocationRepository(testDB)
-	testService = service.NewLocationService(repo)
-
-	// Create handler
-	validate := validator.New()
-	testHandler = NewLocationHandler(testService, validate)
+// newTestLocationHandler creates a handler backed by the mocked service
+func newTestLocationHandler(svc *mock.LocationServiceMock) *LocationHandler {
+	return NewLocationHandler(svc, validation.New(nil))
 }
 
-// teardownTestDB cleans up the test database
-func teardownTestDB(t *testing.T) {
-	if testDB != nil {
-		testDB.Close()
+// testLocation builds the location the mocked service returns
+func testLocation(name string, lat, lng float64) *domain.Location {
+	return &domain.Location{
+		ID:        "d1hkp3rcm3nc73b5v2og",
+		Name:      name,
+		Slug:      strings.ToLower(strings.ReplaceAll(name, " ", "-")),
+		Latitude:  lat,
+		Longitude: lng,
+		CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Version:   1,
+		UpdatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
 	}
 }
 
-// cleanupTestData removes test data from the database
-func cleanupTestData(t *testing.T) {
-	ctx := context.Background()
-	_, err := testDB.Exec(ctx, "DELETE FROM locations")
-	require.NoError(t, err, "Failed to cleanup test data")
-}
-
-func TestMain(m *testing.M) {
-	// Setup test database
-	setupTestDB(&testing.T{})
-
-	// Run tests
-	code := m.Run()
-
-	// Cleanup
-	teardownTestDB(&testing.T{})
-
-	os.Exit(code)
+// withURLParam sets a chi URL parameter on the request, as the router would
+func withURLParam(req *http.Request, key, value string) *http.Request {
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add(key, value)
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
 }
 
 func TestLocationHandler_RegisterLocation(t *testing.T) {
-	cleanupTestData(t)
+	svc := &mock.LocationServiceMock{
+		RegisterLocationFunc: func(ctx context.Context, req *domain.RegisterLocationRequest) (*domain.Location, domain.CError) {
+			if req.Name == "Duplicate Test" {
+				return nil, domain.ErrConflictingData
+			}
+			return testLocation(req.Name, req.Latitude, req.Longitude), nil
+		},
+	}
+	handler := newTestLocationHandler(svc)
 
 	t.Run("Success - Register new location", func(t *testing.T) {
 		requestBody := domain.RegisterLocationRequest{
@@ -101,11 +73,12 @@ func TestLocationHandler_RegisterLocation(t *testing.T) {
 		req.Header.Set("Content-Type", "application/json")
 		w := httptest.NewRecorder()
 
-		testHandler.RegisterLocation(w, req)
+		handler.RegisterLocation(w, req)
 
 		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, `"1"`, w.Header().Get("ETag"))
 
-		var res response
+		var res dataResponse[any]
 		err = json.Unmarshal(w.Body.Bytes(), &res)
 		require.NoError(t, err)
 
@@ -121,6 +94,11 @@ func TestLocationHandler_RegisterLocation(t *testing.T) {
 		assert.Equal(t, -74.0060, data["longitude"])
 		assert.NotEmpty(t, data["id"])
 		assert.NotEmpty(t, data["created_at"])
+
+		// Verify the request reached the service as sent
+		calls := svc.RegisterLocationCalls()
+		require.NotEmpty(t, calls)
+		assert.Equal(t, &requestBody, calls[len(calls)-1].Location)
 	})
 
 	t.Run("Error - Missing required fields", func(t *testing.T) {
@@ -136,7 +114,7 @@ func TestLocationHandler_RegisterLocation(t *testing.T) {
 		req.Header.Set("Content-Type", "application/json")
 		w := httptest.NewRecorder()
 
-		testHandler.RegisterLocation(w, req)
+		handler.RegisterLocation(w, req)
 
 		assert.Equal(t, http.StatusBadRequest, w.Code)
 
@@ -145,9 +123,223 @@ func TestLocationHandler_RegisterLocation(t *testing.T) {
 		require.NoError(t, err)
 
 		assert.False(t, res.Success)
+		assert.Equal(t, errcode.ValidationFailed, res.Code)
 		assert.Contains(t, res.Message, "Name")
 	})
 
+	t.Run("Success - Register a location by plus code", func(t *testing.T) {
+		body := []byte(`{"name": "Plus Code Location", "plus_code": "7FG49QCJ+2V"}`)
+
+		req := httptest.NewRequest(http.MethodPost, "/locations", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		handler.RegisterLocation(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		// The center of the area reached the service
+		calls := svc.RegisterLocationCalls()
+		require.NotEmpty(t, calls)
+		assert.InDelta(t, 20.3700625, calls[len(calls)-1].Location.Latitude, 1e-9)
+		assert.InDelta(t, 2.7821875, calls[len(calls)-1].Location.Longitude, 1e-9)
+	})
+
+	t.Run("Error - Invalid plus codes", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			body    string
+			message string
+		}{
+			{"code and coordinates", `{"name": "x", "plus_code": "7FG49QCJ+2V", "latitude": 20.37}`, "not both"},
+			{"short code", `{"name": "x", "plus_code": "9QCJ+2V"}`, "short"},
+			{"not a code", `{"name": "x", "plus_code": "7FG49QCJ2V"}`, "Invalid plus code"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				req := httptest.NewRequest(http.MethodPost, "/locations", bytes.NewBufferString(tt.body))
+				req.Header.Set("Content-Type", "application/json")
+				w := httptest.NewRecorder()
+
+				handler.RegisterLocation(w, req)
+
+				assert.Equal(t, http.StatusBadRequest, w.Code)
+
+				var res errorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+				assert.Contains(t, res.Message, tt.message)
+			})
+		}
+	})
+
+	t.Run("Altitude is optional and bounded", func(t *testing.T) {
+		tests := []struct {
+			body string
+			code int
+		}{
+			{`{"name": "Ground Level", "latitude": 40.7128, "longitude": -74.0060, "altitude": 0}`, http.StatusCreated},
+			{`{"name": "Drone Pad", "latitude": 40.7128, "longitude": -74.0060, "altitude": 320.5}`, http.StatusCreated},
+			{`{"name": "In Orbit", "latitude": 40.7128, "longitude": -74.0060, "altitude": 400000}`, http.StatusBadRequest},
+			{`{"name": "Deep Mine", "latitude": 40.7128, "longitude": -74.0060, "altitude": -4000}`, http.StatusBadRequest},
+		}
+
+		for _, tt := range tests {
+			req := httptest.NewRequest(http.MethodPost, "/locations", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			handler.RegisterLocation(w, req)
+
+			assert.Equal(t, tt.code, w.Code, tt.body)
+		}
+
+		calls := svc.RegisterLocationCalls()
+		require.NotNil(t, calls[len(calls)-1].Location.Altitude)
+		assert.Equal(t, 320.5, *calls[len(calls)-1].Location.Altitude)
+	})
+
+	t.Run("Entrances are validated", func(t *testing.T) {
+		body := []byte(`{"name": "Mall", "latitude": 6.6138, "longitude": 3.3579, "entrances": [
+			{"name": "North gate", "latitude": 6.6150, "longitude": 3.3579},
+			{"latitude": 96.6126, "longitude": 3.3579}
+		]}`)
+
+		req := httptest.NewRequest(http.MethodPost, "/locations", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		handler.RegisterLocation(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		var res errorResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+		assert.Contains(t, res.Message, "Latitude")
+	})
+
+	t.Run("Footprints are GeoJSON polygons", func(t *testing.T) {
+		tests := []struct {
+			name      string
+			footprint string
+			code      int
+			message   string
+		}{
+			{"closed ring", `{"type": "Polygon", "coordinates": [[[3.35, 6.61], [3.36, 6.61], [3.36, 6.62], [3.35, 6.61]]]}`, http.StatusCreated, ""},
+			{"open ring", `{"type": "Polygon", "coordinates": [[[3.35, 6.61], [3.36, 6.61], [3.36, 6.62], [3.35, 6.62]]]}`, http.StatusBadRequest, "not closed"},
+			{"out of range", `{"type": "Polygon", "coordinates": [[[183.35, 6.61], [3.36, 6.61], [3.36, 6.62], [183.35, 6.61]]]}`, http.StatusBadRequest, "out of range"},
+			{"not a polygon", `{"type": "Point", "coordinates": [[[3.35, 6.61], [3.36, 6.61], [3.36, 6.62], [3.35, 6.61]]]}`, http.StatusBadRequest, "Type"},
+			{"too few positions", `{"type": "Polygon", "coordinates": [[[3.35, 6.61], [3.36, 6.61], [3.35, 6.61]]]}`, http.StatusBadRequest, "Coordinates"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				body := `{"name": "Campus", "latitude": 6.615, "longitude": 3.355, "footprint": ` + tt.footprint + `}`
+				req := httptest.NewRequest(http.MethodPost, "/locations", bytes.NewBufferString(body))
+				req.Header.Set("Content-Type", "application/json")
+				w := httptest.NewRecorder()
+
+				handler.RegisterLocation(w, req)
+
+				assert.Equal(t, tt.code, w.Code)
+				if tt.message != "" {
+					var res errorResponse
+					require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+					assert.Contains(t, res.Message, tt.message)
+				}
+			})
+		}
+
+		calls := svc.RegisterLocationCalls()
+		footprint := calls[len(calls)-1].Location.Footprint
+		require.NotNil(t, footprint)
+		assert.Len(t, footprint.Coordinates[0], 4)
+	})
+
+	t.Run("Error - Invalid locale in names", func(t *testing.T) {
+		body := []byte(`{"name": "Named Location", "latitude": 40.7128, "longitude": -74.0060, "names": {"not a locale": "x"}}`)
+
+		req := httptest.NewRequest(http.MethodPost, "/locations", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		handler.RegisterLocation(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Names are normalized", func(t *testing.T) {
+		body := []byte(`{"name": "  Cafe\u0301 \t Lagos\n", "latitude": 6.45, "longitude": 3.39}`)
+
+		req := httptest.NewRequest(http.MethodPost, "/locations", bytes.NewBuffer(body))
+		w := httptest.NewRecorder()
+
+		handler.RegisterLocation(w, req)
+
+		require.Equal(t, http.StatusCreated, w.Code)
+		calls := svc.RegisterLocationCalls()
+		assert.Equal(t, "Café Lagos", calls[len(calls)-1].Location.Name)
+	})
+
+	t.Run("Error - Invalid names", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			body    string
+			message string
+		}{
+			{"Blank", `{"name": "   ", "latitude": 6.45, "longitude": 3.39}`, "Name must not be blank"},
+			{"Control character", `{"name": "Lagos\u0000Depot", "latitude": 6.45, "longitude": 3.39}`, "Name must not contain control characters"},
+			{"Too long", `{"name": "` + strings.Repeat("é", 256) + `", "latitude": 6.45, "longitude": 3.39}`, "Name must be at most 255 characters long"},
+			{"Translated name", `{"name": "Lagos", "latitude": 6.45, "longitude": 3.39, "names": {"fr": "Lagos\u001b"}}`, "Names[fr] must not contain control characters"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				req := httptest.NewRequest(http.MethodPost, "/locations", strings.NewReader(tt.body))
+				w := httptest.NewRecorder()
+
+				handler.RegisterLocation(w, req)
+
+				assert.Equal(t, http.StatusBadRequest, w.Code)
+				var res errorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+				assert.Equal(t, errcode.ValidationFailed, res.Code)
+				assert.Equal(t, tt.message, res.Message)
+			})
+		}
+	})
+
+	t.Run("Error - Unknown field", func(t *testing.T) {
+		body := []byte(`{"name": "Typo Location", "latitude": 40.7128, "longitud": -74.0060}`)
+
+		req := httptest.NewRequest(http.MethodPost, "/locations", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		handler.RegisterLocation(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		var res errorResponse
+		err := json.Unmarshal(w.Body.Bytes(), &res)
+		require.NoError(t, err)
+
+		assert.False(t, res.Success)
+		assert.Contains(t, res.Message, `"longitud"`)
+	})
+
+	t.Run("Error - Body too large", func(t *testing.T) {
+		body := []byte(`{"name": "` + strings.Repeat("a", 64) + `", "latitude": 40.7128, "longitude": -74.0060}`)
+
+		req := httptest.NewRequest(http.MethodPost, "/locations", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		bodyLimit(32)(http.HandlerFunc(handler.RegisterLocation)).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	})
+
 	t.Run("Error - Invalid latitude", func(t *testing.T) {
 		requestBody := domain.RegisterLocationRequest{
 			Name:      "Invalid Location",
@@ -162,7 +354,7 @@ func TestLocationHandler_RegisterLocation(t *testing.T) {
 		req.Header.Set("Content-Type", "application/json")
 		w := httptest.NewRecorder()
 
-		testHandler.RegisterLocation(w, req)
+		handler.RegisterLocation(w, req)
 
 		assert.Equal(t, http.StatusBadRequest, w.Code)
 
@@ -175,7 +367,6 @@ func TestLocationHandler_RegisterLocation(t *testing.T) {
 	})
 
 	t.Run("Error - Duplicate location", func(t *testing.T) {
-		// First, create a location
 		requestBody := domain.RegisterLocationRequest{
 			Name:      "Duplicate Test",
 			Latitude:  40.7128,
@@ -189,47 +380,47 @@ func TestLocationHandler_RegisterLocation(t *testing.T) {
 		req.Header.Set("Content-Type", "application/json")
 		w := httptest.NewRecorder()
 
-		testHandler.RegisterLocation(w, req)
-		assert.Equal(t, http.StatusCreated, w.Code)
-
-		// Try to create the same location again
-		req2 := httptest.NewRequest(http.MethodPost, "/location", bytes.NewBuffer(body))
-		req2.Header.Set("Content-Type", "application/json")
-		w2 := httptest.NewRecorder()
+		handler.RegisterLocation(w, req)
 
-		testHandler.RegisterLocation(w2, req2)
-
-		assert.Equal(t, http.StatusConflict, w2.Code)
+		assert.Equal(t, http.StatusConflict, w.Code)
 
 		var res errorResponse
-		err = json.Unmarshal(w2.Body.Bytes(), &res)
+		err = json.Unmarshal(w.Body.Bytes(), &res)
 		require.NoError(t, err)
 
 		assert.False(t, res.Success)
 	})
+
+	t.Run("Invalid requests do not reach the service", func(t *testing.T) {
+		for _, call := range svc.RegisterLocationCalls() {
+			assert.NotEqual(t, "Invalid Location", call.Location.Name)
+			assert.NotEqual(t, "Typo Location", call.Location.Name)
+		}
+	})
 }
 
 func TestLocationHandler_GetLocation(t *testing.T) {
-	cleanupTestData(t)
-
-	res := createTestLocationViaHTTP(t, "Get-Test-Location", 40.7128, -74.0060)
-	assert.True(t, res.Success)
-	locationID := res.Data.(map[string]any)["id"]
+	location := testLocation("Get-Test-Location", 40.7128, -74.0060)
+	handler := newTestLocationHandler(&mock.LocationServiceMock{
+		GetLocationFunc: func(ctx context.Context, name string) (*domain.Location, domain.CError) {
+			if name == "Get-Test-Location" || name == "get-test-location" {
+				return location, nil
+			}
+			return nil, domain.ErrDataNotFound
+		},
+	})
 
 	t.Run("Success - Get location by name", func(t *testing.T) {
 		req := httptest.NewRequest(http.MethodGet, "/v1/locations/Get-Test-Location", nil)
+		req = withURLParam(req, "name", "Get-Test-Location")
 		w := httptest.NewRecorder()
 
-		// Set up chi context with URL parameters
-		rctx := chi.NewRouteContext()
-		rctx.URLParams.Add("name", "Get-Test-Location")
-		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
-
-		testHandler.GetLocation(w, req)
+		handler.GetLocation(w, req)
 
 		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, location.UpdatedAt.Format(http.TimeFormat), w.Header().Get("Last-Modified"))
 
-		var res response
+		var res dataResponse[any]
 		err := json.Unmarshal(w.Body.Bytes(), &res)
 		require.NoError(t, err)
 
@@ -237,25 +428,21 @@ func TestLocationHandler_GetLocation(t *testing.T) {
 		assert.NotNil(t, res.Data)
 
 		data := res.Data.(map[string]any)
-		assert.Equal(t, locationID, data["id"])
+		assert.Equal(t, location.ID, data["id"])
 		assert.Equal(t, "Get-Test-Location", data["name"])
 		assert.Equal(t, "get-test-location", data["slug"])
 	})
 
 	t.Run("Success - Get location by slug", func(t *testing.T) {
 		req := httptest.NewRequest(http.MethodGet, "/v1/locations/get-test-location", nil)
+		req = withURLParam(req, "name", "get-test-location")
 		w := httptest.NewRecorder()
 
-		// Set up chi context with URL parameters
-		rctx := chi.NewRouteContext()
-		rctx.URLParams.Add("name", "get-test-location")
-		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
-
-		testHandler.GetLocation(w, req)
+		handler.GetLocation(w, req)
 
 		assert.Equal(t, http.StatusOK, w.Code)
 
-		var res response
+		var res dataResponse[any]
 		err := json.Unmarshal(w.Body.Bytes(), &res)
 		require.NoError(t, err)
 
@@ -263,20 +450,16 @@ func TestLocationHandler_GetLocation(t *testing.T) {
 		assert.NotNil(t, res.Data)
 
 		data := res.Data.(map[string]any)
-		assert.Equal(t, locationID, data["id"])
+		assert.Equal(t, location.ID, data["id"])
 		assert.Equal(t, "Get-Test-Location", data["name"])
 	})
 
 	t.Run("Error - Empty location name", func(t *testing.T) {
 		req := httptest.NewRequest(http.MethodGet, "/location/", nil)
+		req = withURLParam(req, "name", "")
 		w := httptest.NewRecorder()
 
-		// Set up chi context with empty URL parameter
-		rctx := chi.NewRouteContext()
-		rctx.URLParams.Add("name", "")
-		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
-
-		testHandler.GetLocation(w, req)
+		handler.GetLocation(w, req)
 
 		assert.Equal(t, http.StatusBadRequest, w.Code)
 
@@ -289,14 +472,10 @@ func TestLocationHandler_GetLocation(t *testing.T) {
 
 	t.Run("Error - Location not found", func(t *testing.T) {
 		req := httptest.NewRequest(http.MethodGet, "/location/non-existent", nil)
+		req = withURLParam(req, "name", "non-existent")
 		w := httptest.NewRecorder()
 
-		// Set up chi context with URL parameters
-		rctx := chi.NewRouteContext()
-		rctx.URLParams.Add("name", "non-existent")
-		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
-
-		testHandler.GetLocation(w, req)
+		handler.GetLocation(w, req)
 
 		assert.Equal(t, http.StatusNotFound, w.Code)
 
@@ -305,44 +484,120 @@ func TestLocationHandler_GetLocation(t *testing.T) {
 		require.NoError(t, err)
 
 		assert.False(t, res.Success)
+		assert.Equal(t, errcode.NotFound, res.Code)
 	})
-}
 
-func TestLocationHandler_ListLocations(t *testing.T) {
-	cleanupTestData(t)
+	t.Run("Error - Service unavailable", func(t *testing.T) {
+		handler := newTestLocationHandler(&mock.LocationServiceMock{
+			GetLocationFunc: func(ctx context.Context, name string) (*domain.Location, domain.CError) {
+				return nil, domain.ErrServiceUnavailable
+			},
+		})
 
-	// Create multiple test locations
-	locations := []domain.RegisterLocationRequest{
-		{
-			Name:      "Location 1",
-			Latitude:  40.7128,
-			Longitude: -74.0060,
-		},
-		{
-			Name:      "Location 2",
-			Latitude:  34.0522,
-			Longitude: -118.2437,
-		},
-		{
-			Name:      "Location 3",
-			Latitude:  51.5074,
-			Longitude: -0.1278,
+		req := httptest.NewRequest(http.MethodGet, "/location/Get-Test-Location", nil)
+		req = withURLParam(req, "name", "Get-Test-Location")
+		w := httptest.NewRecorder()
+
+		handler.GetLocation(w, req)
+
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	})
+
+	t.Run("Error - Cause is not sent", func(t *testing.T) {
+		handler := newTestLocationHandler(&mock.LocationServiceMock{
+			GetLocationFunc: func(ctx context.Context, name string) (*domain.Location, domain.CError) {
+				return nil, domain.WrapCError(domain.ErrInternal, errors.New(`relation "locations" does not exist`),
+					domain.ErrorContext{Entity: "location", Key: name})
+			},
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/location/Get-Test-Location", nil)
+		req = withURLParam(req, "name", "Get-Test-Location")
+		w := httptest.NewRecorder()
+
+		handler.GetLocation(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.NotContains(t, w.Body.String(), "relation")
+
+		var res errorResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+		assert.Equal(t, "internal server error", res.Message)
+		assert.Equal(t, errcode.Internal, res.Code)
+	})
+}
+
+func TestLocationHandler_GetLocation_Localized(t *testing.T) {
+	handler := newTestLocationHandler(&mock.LocationServiceMock{
+		GetLocationFunc: func(ctx context.Context, name string) (*domain.Location, domain.CError) {
+			location := testLocation("Lagos Park", 6.5244, 3.3792)
+			location.Names = map[string]string{"en": "Lagos Park", "fr": "Parc de Lagos", "pt-BR": "Parque de Lagos"}
+			return location, nil
 		},
+	})
+
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		expectedName   string
+	}{
+		{name: "Exact locale", acceptLanguage: "fr", expectedName: "Parc de Lagos"},
+		{name: "Regional variant falls back to its language", acceptLanguage: "fr-CA", expectedName: "Parc de Lagos"},
+		{name: "Quality values are honoured", acceptLanguage: "de, pt-BR;q=0.9, fr;q=0.5", expectedName: "Parque de Lagos"},
+		{name: "No matching locale keeps the canonical name", acceptLanguage: "ja", expectedName: "Lagos Park"},
+		{name: "No header keeps the canonical name", expectedName: "Lagos Park"},
+		{name: "Malformed header keeps the canonical name", acceptLanguage: "!!", expectedName: "Lagos Park"},
 	}
 
-	for _, loc := range locations {
-		createTestLocationViaHTTP(t, loc.Name, loc.Latitude, loc.Longitude)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/v1/locations/lagos-park", nil)
+			req = withURLParam(req, "name", "lagos-park")
+			if tt.acceptLanguage != "" {
+				req.Header.Set("Accept-Language", tt.acceptLanguage)
+			}
+			w := httptest.NewRecorder()
+
+			handler.GetLocation(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Contains(t, w.Header().Values("Vary"), "Accept-Language")
+
+			var res dataResponse[any]
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+
+			data := res.Data.(map[string]any)
+			assert.Equal(t, tt.expectedName, data["name"])
+			assert.Equal(t, "lagos-park", data["slug"])
+			assert.Len(t, data["names"], 3)
+		})
+	}
+}
+
+func TestLocationHandler_ListLocations(t *testing.T) {
+	locations := []domain.Location{
+		*testLocation("Location 1", 40.7128, -74.0060),
+		*testLocation("Location 2", 34.0522, -118.2437),
+		*testLocation("Location 3", 51.5074, -0.1278),
 	}
+	locations[1].UpdatedAt = locations[1].UpdatedAt.Add(time.Hour)
 
 	t.Run("Success - List all locations", func(t *testing.T) {
-		req := httptest.NewRequest(http.MethodGet, "/locations", nil)
+		svc := &mock.LocationServiceMock{
+			ListLocationsFunc: func(ctx context.Context, req *domain.ListLocationsRequest) ([]domain.Location, domain.CError) {
+				return locations, nil
+			},
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/locations?q=category:park", nil)
 		w := httptest.NewRecorder()
 
-		testHandler.ListLocations(w, req)
+		newTestLocationHandler(svc).ListLocations(w, req)
 
 		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, locations[1].UpdatedAt.Format(http.TimeFormat), w.Header().Get("Last-Modified"))
 
-		var res response
+		var res dataResponse[any]
 		err := json.Unmarshal(w.Body.Bytes(), &res)
 		require.NoError(t, err)
 
@@ -361,19 +616,27 @@ func TestLocationHandler_ListLocations(t *testing.T) {
 		assert.True(t, names["Location 1"])
 		assert.True(t, names["Location 2"])
 		assert.True(t, names["Location 3"])
+
+		// Verify the query reached the service
+		require.Len(t, svc.ListLocationsCalls(), 1)
+		assert.Equal(t, "category:park", svc.ListLocationsCalls()[0].Req.Query)
 	})
 
 	t.Run("Success - Empty list", func(t *testing.T) {
-		cleanupTestData(t)
+		svc := &mock.LocationServiceMock{
+			ListLocationsFunc: func(ctx context.Context, req *domain.ListLocationsRequest) ([]domain.Location, domain.CError) {
+				return nil, nil
+			},
+		}
 
 		req := httptest.NewRequest(http.MethodGet, "/locations", nil)
 		w := httptest.NewRecorder()
 
-		testHandler.ListLocations(w, req)
+		newTestLocationHandler(svc).ListLocations(w, req)
 
 		assert.Equal(t, http.StatusOK, w.Code)
 
-		var res response
+		var res dataResponse[any]
 		err := json.Unmarshal(w.Body.Bytes(), &res)
 		require.NoError(t, err)
 
@@ -381,62 +644,286 @@ func TestLocationHandler_ListLocations(t *testing.T) {
 
 		assert.Nil(t, res.Data)
 	})
+
+	t.Run("Error - Invalid query", func(t *testing.T) {
+		svc := &mock.LocationServiceMock{
+			ListLocationsFunc: func(ctx context.Context, req *domain.ListLocationsRequest) ([]domain.Location, domain.CError) {
+				return nil, domain.NewBadRequestCError("unknown field")
+			},
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/locations?q=colour:red", nil)
+		w := httptest.NewRecorder()
+
+		newTestLocationHandler(svc).ListLocations(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Success - Include expired", func(t *testing.T) {
+		svc := &mock.LocationServiceMock{
+			ListLocationsFunc: func(ctx context.Context, req *domain.ListLocationsRequest) ([]domain.Location, domain.CError) {
+				return locations, nil
+			},
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/locations?include_expired=true", nil)
+		w := httptest.NewRecorder()
+
+		newTestLocationHandler(svc).ListLocations(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		require.Len(t, svc.ListLocationsCalls(), 1)
+		assert.True(t, svc.ListLocationsCalls()[0].Req.IncludeExpired)
+	})
+
+	t.Run("Error - Invalid include expired", func(t *testing.T) {
+		svc := &mock.LocationServiceMock{}
+
+		req := httptest.NewRequest(http.MethodGet, "/locations?include_expired=maybe", nil)
+		w := httptest.NewRecorder()
+
+		newTestLocationHandler(svc).ListLocations(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Empty(t, svc.ListLocationsCalls())
+	})
+
+	t.Run("Success - Recently changed first", func(t *testing.T) {
+		svc := &mock.LocationServiceMock{
+			ListLocationsFunc: func(ctx context.Context, req *domain.ListLocationsRequest) ([]domain.Location, domain.CError) {
+				return locations, nil
+			},
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/locations?sort=updated_at", nil)
+		w := httptest.NewRecorder()
+
+		newTestLocationHandler(svc).ListLocations(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		require.Len(t, svc.ListLocationsCalls(), 1)
+		assert.Equal(t, domain.SortUpdated, svc.ListLocationsCalls()[0].Req.Sort)
+	})
+
+	t.Run("Error - Invalid sort", func(t *testing.T) {
+		svc := &mock.LocationServiceMock{}
+
+		req := httptest.NewRequest(http.MethodGet, "/locations?sort=name", nil)
+		w := httptest.NewRecorder()
+
+		newTestLocationHandler(svc).ListLocations(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Empty(t, svc.ListLocationsCalls())
+	})
 }
 
-func TestLocationHandler_DeleteLocation(t *testing.T) {
-	cleanupTestData(t)
+func TestLocationHandler_ExportLocations(t *testing.T) {
+	locations := []*domain.Location{
+		testLocation("New York", 40.7128, -74.0060),
+		testLocation("Los Angeles", 34.0522, -118.2437),
+	}
+	svc := &mock.LocationServiceMock{
+		ExportLocationsFunc: func(ctx context.Context, req *domain.ListLocationsRequest, fn func(location *domain.Location) error) domain.CError {
+			switch req.Query {
+			case "none":
+				return nil
+			case "expensive":
+				return domain.NewCodedCError(errcode.QueryTooExpensive, "an export of more than 10 needs a filter")
+			}
+
+			for _, location := range locations {
+				if err := fn(location); err != nil {
+					return domain.WrapCError(domain.ErrInternal, err, domain.ErrorContext{})
+				}
+			}
+			if req.Query == "broken" {
+				return domain.ErrInternal
+			}
+			return nil
+		},
+	}
+	handler := newTestLocationHandler(svc)
 
-	res := createTestLocationViaHTTP(t, "Delete-Test-Location", 40.7128, -74.0060)
-	assert.True(t, res.Success)
-	locationName := res.Data.(map[string]any)["name"].(string)
+	t.Run("Success - One location per line", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		handler.ExportLocations(w, httptest.NewRequest(http.MethodGet, "/locations/export?format=ndjson", nil))
 
-	t.Run("Success - Delete location by name", func(t *testing.T) {
-		req := httptest.NewRequest(http.MethodDelete, "/location/"+locationName, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+
+		lines := strings.Split(strings.TrimSuffix(w.Body.String(), "\n"), "\n")
+		require.Len(t, lines, 2)
+		for i, line := range lines {
+			var location domain.Location
+			require.NoError(t, json.Unmarshal([]byte(line), &location))
+			assert.Equal(t, locations[i].Name, location.Name)
+		}
+	})
+
+	t.Run("Success - Nothing to export", func(t *testing.T) {
 		w := httptest.NewRecorder()
+		handler.ExportLocations(w, httptest.NewRequest(http.MethodGet, "/locations/export?q=none", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+		assert.Empty(t, w.Body.String())
+	})
+
+	t.Run("Error - Before the first line", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		handler.ExportLocations(w, httptest.NewRequest(http.MethodGet, "/locations/export?q=expensive", nil))
+
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		var rsp errorResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &rsp))
+		assert.Equal(t, errcode.QueryTooExpensive, rsp.Code)
+	})
+
+	t.Run("Error - Invalid query", func(t *testing.T) {
+		for _, query := range []string{"format=csv", "include_expired=maybe", "sort=name"} {
+			w := httptest.NewRecorder()
+			handler.ExportLocations(w, httptest.NewRequest(http.MethodGet, "/locations/export?"+query, nil))
+
+			assert.Equal(t, http.StatusBadRequest, w.Code, query)
+		}
+	})
+
+	t.Run("Error - In the middle of the stream", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(handler.ExportLocations))
+		defer server.Close()
+
+		rsp, err := http.Get(server.URL + "/locations/export?q=broken")
+		require.NoError(t, err)
+		defer rsp.Body.Close()
+		assert.Equal(t, http.StatusOK, rsp.StatusCode)
+
+		body, err := io.ReadAll(rsp.Body)
+		assert.ErrorIs(t, err, io.ErrUnexpectedEOF, "the aborted stream does not end like a complete one")
+
+		// the lines written before the error are kept, and the error is the last one
+		lines := strings.Split(strings.TrimSuffix(string(body), "\n"), "\n")
+		require.Len(t, lines, 3)
+
+		var location domain.Location
+		require.NoError(t, json.Unmarshal([]byte(lines[1]), &location))
+		assert.Equal(t, "Los Angeles", location.Name)
+
+		var errRsp errorResponse
+		require.NoError(t, json.Unmarshal([]byte(lines[2]), &errRsp))
+		assert.False(t, errRsp.Success)
+		assert.Equal(t, errcode.Internal, errRsp.Code)
+	})
+}
+
+func TestLocationHandler_UpdateLocation(t *testing.T) {
+	svc := &mock.LocationServiceMock{
+		UpdateLocationFunc: func(ctx context.Context, name string, req *domain.UpdateLocationRequest) (*domain.Location, domain.CError) {
+			if req.Version != 2 {
+				return nil, domain.ErrVersionConflict
+			}
+			location := testLocation(req.Name, req.Latitude, req.Longitude)
+			location.Version = req.Version + 1
+			return location, nil
+		},
+	}
+	handler := newTestLocationHandler(svc)
+
+	tests := []struct {
+		name      string
+		body      string
+		status    int
+		latitude  float64
+		longitude float64
+	}{
+		{"Success - On the equator", `{"name": "Kampala Pier", "latitude": 0, "longitude": 32.5825, "version": 2}`, http.StatusOK, 0, 32.5825},
+		{"Success - On the prime meridian", `{"name": "Greenwich", "latitude": 51.4779, "longitude": 0, "version": 2}`, http.StatusOK, 51.4779, 0},
+		{"Error - Stale version", `{"name": "Greenwich", "latitude": 51.4779, "longitude": 0, "version": 1}`, http.StatusConflict, 0, 0},
+		{"Error - Missing latitude", `{"name": "Greenwich", "longitude": 0, "version": 2}`, http.StatusBadRequest, 0, 0},
+		{"Error - Missing longitude", `{"name": "Greenwich", "latitude": 0, "version": 2}`, http.StatusBadRequest, 0, 0},
+		{"Error - Latitude out of range", `{"name": "Greenwich", "latitude": 91, "longitude": 0, "version": 2}`, http.StatusBadRequest, 0, 0},
+		{"Error - Missing version", `{"name": "Greenwich", "latitude": 51.4779, "longitude": 0}`, http.StatusBadRequest, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := withURLParam(httptest.NewRequest(http.MethodPut, "/locations/greenwich", strings.NewReader(tt.body)), "name", "greenwich")
+			w := httptest.NewRecorder()
+
+			handler.UpdateLocation(w, req)
+
+			assert.Equal(t, tt.status, w.Code, w.Body.String())
+			if tt.status != http.StatusOK {
+				return
+			}
+
+			var res dataResponse[domain.Location]
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+			assert.Equal(t, tt.latitude, res.Data.Latitude)
+			assert.Equal(t, tt.longitude, res.Data.Longitude)
+			assert.Equal(t, int64(3), res.Data.Version)
+		})
+	}
 
-		// Set up chi context with URL parameters
-		rctx := chi.NewRouteContext()
-		rctx.URLParams.Add("name", "Delete Test Location")
-		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	// the invalid updates never reach the service
+	assert.Len(t, svc.UpdateLocationCalls(), 3)
+}
+
+func TestLocationHandler_DeleteLocation(t *testing.T) {
+	svc := &mock.LocationServiceMock{
+		DeleteLocationFunc: func(ctx context.Context, name string, precondition domain.Precondition) domain.CError {
+			if name == "Delete-Test-Location" {
+				return nil
+			}
+			return domain.ErrDataNotFound
+		},
+	}
+	handler := newTestLocationHandler(svc)
+
+	t.Run("Success - Delete location by name", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodDelete, "/location/Delete-Test-Location", nil)
+		req = withURLParam(req, "name", "Delete-Test-Location")
+		w := httptest.NewRecorder()
 
-		testHandler.DeleteLocation(w, req)
+		handler.DeleteLocation(w, req)
 
 		assert.Equal(t, http.StatusOK, w.Code)
 
-		var res response
+		var res dataResponse[any]
 		err := json.Unmarshal(w.Body.Bytes(), &res)
 		require.NoError(t, err)
 
 		assert.True(t, res.Success)
 		assert.Equal(t, "Deleted location successfully", res.Message)
 
-		// Verify location is actually deleted
-		getReq := httptest.NewRequest(http.MethodGet, "/location/"+locationName, nil)
-		getW := httptest.NewRecorder()
+		calls := svc.DeleteLocationCalls()
+		require.Len(t, calls, 1)
+		assert.Equal(t, "Delete-Test-Location", calls[0].Name)
+	})
 
-		rctx2 := chi.NewRouteContext()
-		rctx2.URLParams.Add("name", locationName)
-		getReq = getReq.WithContext(context.WithValue(getReq.Context(), chi.RouteCtxKey, rctx2))
+	t.Run("Error - Location not found", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodDelete, "/location/non-existent", nil)
+		req = withURLParam(req, "name", "non-existent")
+		w := httptest.NewRecorder()
 
-		testHandler.GetLocation(getW, getReq)
-		assert.Equal(t, http.StatusNotFound, getW.Code)
+		handler.DeleteLocation(w, req)
 
-		var res2 errorResponse
-		err = json.Unmarshal(getW.Body.Bytes(), &res2)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		var res errorResponse
+		err := json.Unmarshal(w.Body.Bytes(), &res)
 		require.NoError(t, err)
 
-		assert.False(t, res2.Success)
+		assert.False(t, res.Success)
 	})
 
 	t.Run("Error - Empty location name", func(t *testing.T) {
 		req := httptest.NewRequest(http.MethodDelete, "/location/", nil)
+		req = withURLParam(req, "name", "")
 		w := httptest.NewRecorder()
 
-		rctx := chi.NewRouteContext()
-		rctx.URLParams.Add("name", "")
-		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
-
-		testHandler.DeleteLocation(w, req)
+		handler.DeleteLocation(w, req)
 
 		assert.Equal(t, http.StatusBadRequest, w.Code)
 
@@ -448,42 +935,253 @@ func TestLocationHandler_DeleteLocation(t *testing.T) {
 	})
 }
 
-func TestLocationHandler_GetNearestLocation(t *testing.T) {
-	cleanupTestData(t)
+func TestLocationHandler_LocationByID(t *testing.T) {
+	const id = "d1hkp3rcm3nc73b5v2og"
+	svc := &mock.LocationServiceMock{
+		GetLocationByIDFunc: func(ctx context.Context, locationID string) (*domain.Location, domain.CError) {
+			if locationID == id {
+				return testLocation("Renamed Park", 6.52, 3.37), nil
+			}
+			return nil, domain.NewCError(http.StatusNotFound, "location not found")
+		},
+		UpdateLocationByIDFunc: func(ctx context.Context, locationID string, req *domain.UpdateLocationRequest) (*domain.Location, domain.CError) {
+			location := testLocation(req.Name, req.Latitude, req.Longitude)
+			location.Version = req.Version + 1
+			return location, nil
+		},
+		DeleteLocationByIDFunc: func(ctx context.Context, locationID string, precondition domain.Precondition) domain.CError {
+			return nil
+		},
+	}
+	handler := newTestLocationHandler(svc)
 
-	// Create multiple test locations
-	locations := []domain.RegisterLocationRequest{
-		{
-			Name:      "New York",
-			Latitude:  40.7128,
-			Longitude: -74.0060,
+	t.Run("Success - Get location by id", func(t *testing.T) {
+		req := withURLParam(httptest.NewRequest(http.MethodGet, "/locations/id/"+id, nil), "id", id)
+		w := httptest.NewRecorder()
+
+		handler.GetLocationByID(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, `"1"`, w.Header().Get("ETag"))
+
+		var res dataResponse[domain.Location]
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+		assert.Equal(t, "Renamed Park", res.Data.Name)
+	})
+
+	t.Run("Error - Location not found", func(t *testing.T) {
+		req := withURLParam(httptest.NewRequest(http.MethodGet, "/locations/id/unknown", nil), "id", "unknown")
+		w := httptest.NewRecorder()
+
+		handler.GetLocationByID(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Success - Update location by id", func(t *testing.T) {
+		body := `{"name": "Lekki Park", "latitude": 6.45, "longitude": 3.47}`
+		req := withURLParam(httptest.NewRequest(http.MethodPut, "/locations/id/"+id, strings.NewReader(body)), "id", id)
+		req.Header.Set("If-Match", `"2"`)
+		w := httptest.NewRecorder()
+
+		handler.UpdateLocationByID(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, `"3"`, w.Header().Get("ETag"))
+
+		calls := svc.UpdateLocationByIDCalls()
+		require.Len(t, calls, 1)
+		assert.Equal(t, id, calls[0].ID)
+		assert.Equal(t, int64(2), calls[0].Req.Version)
+	})
+
+	t.Run("Error - Invalid update", func(t *testing.T) {
+		req := withURLParam(httptest.NewRequest(http.MethodPut, "/locations/id/"+id, strings.NewReader(`{"latitude": 91}`)), "id", id)
+		w := httptest.NewRecorder()
+
+		handler.UpdateLocationByID(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Len(t, svc.UpdateLocationByIDCalls(), 1)
+	})
+
+	t.Run("Success - Delete location by id", func(t *testing.T) {
+		req := withURLParam(httptest.NewRequest(http.MethodDelete, "/locations/id/"+id, nil), "id", id)
+		w := httptest.NewRecorder()
+
+		handler.DeleteLocationByID(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		calls := svc.DeleteLocationByIDCalls()
+		require.Len(t, calls, 1)
+		assert.Equal(t, id, calls[0].ID)
+	})
+}
+
+func TestLocationHandler_Precondition(t *testing.T) {
+	current := testLocation("Central Park", 40.7829, -73.9654)
+	current.Version = 4
+	svc := &mock.LocationServiceMock{
+		DeleteLocationFunc: func(ctx context.Context, name string, precondition domain.Precondition) domain.CError {
+			if !precondition.Holds(current) {
+				return domain.NewPreconditionFailedError(current)
+			}
+			return nil
 		},
+	}
+	handler := newTestLocationHandler(svc)
+
+	t.Run("Failed with the current location", func(t *testing.T) {
+		req := withURLParam(httptest.NewRequest(http.MethodDelete, "/locations/central-park", nil), "name", "central-park")
+		req.Header.Set("If-Match", `"3"`)
+		w := httptest.NewRecorder()
+
+		handler.DeleteLocation(w, req)
+
+		assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+		assert.Equal(t, `"4"`, w.Header().Get("ETag"))
+		assert.Equal(t, "Mon, 01 Jan 2024 00:00:00 GMT", w.Header().Get("Last-Modified"))
+
+		var res preconditionFailedResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+		assert.False(t, res.Success)
+		assert.Equal(t, errcode.PreconditionFailed, res.Code)
+		assert.Equal(t, "Central Park", res.Data.Name)
+		assert.Equal(t, int64(4), res.Data.Version)
+	})
+
+	t.Run("Unmodified since", func(t *testing.T) {
+		req := withURLParam(httptest.NewRequest(http.MethodDelete, "/locations/central-park", nil), "name", "central-park")
+		req.Header.Set("If-Unmodified-Since", "Mon, 01 Jan 2024 00:00:00 GMT")
+		w := httptest.NewRecorder()
+
+		handler.DeleteLocation(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		calls := svc.DeleteLocationCalls()
+		assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), calls[len(calls)-1].Precondition.UnmodifiedSince)
+	})
+}
+
+func TestReadPrecondition(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers map[string]string
+		want    domain.Precondition
+		wantErr bool
+	}{
+		{"None", nil, domain.Precondition{}, false},
+		{"If-Match", map[string]string{"If-Match": `W/"3"`}, domain.Precondition{Version: 3}, false},
+		{"Invalid If-Match", map[string]string{"If-Match": "*"}, domain.Precondition{}, true},
 		{
-			Name:      "Los Angeles",
-			Latitude:  34.0522,
-			Longitude: -118.2437,
+			"If-Unmodified-Since",
+			map[string]string{"If-Unmodified-Since": "Mon, 01 Jan 2024 00:00:00 GMT"},
+			domain.Precondition{UnmodifiedSince: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+			false,
 		},
+		{"Invalid If-Unmodified-Since is ignored", map[string]string{"If-Unmodified-Since": "yesterday"}, domain.Precondition{}, false},
 		{
-			Name:      "London",
-			Latitude:  51.5074,
-			Longitude: -0.1278,
+			"If-Unmodified-Since is ignored along with If-Match",
+			map[string]string{"If-Match": `"3"`, "If-Unmodified-Since": "Mon, 01 Jan 2024 00:00:00 GMT"},
+			domain.Precondition{Version: 3},
+			false,
 		},
 	}
 
-	for _, loc := range locations {
-		response := createTestLocationViaHTTP(t, loc.Name, loc.Latitude, loc.Longitude)
-		assert.True(t, response.Success)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodDelete, "/locations/central-park", nil)
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+
+			precondition, cerr := readPrecondition(req)
+			if tt.wantErr {
+				assert.NotNil(t, cerr)
+				return
+			}
+			require.Nil(t, cerr)
+			assert.Equal(t, tt.want, precondition)
+		})
+	}
+}
+
+func TestLocationHandler_LookupLocations(t *testing.T) {
+	svc := &mock.LocationServiceMock{
+		LookupLocationsFunc: func(ctx context.Context, req *domain.LookupLocationsRequest) (*domain.LocationLookup, domain.CError) {
+			return &domain.LocationLookup{
+				Found:   []domain.Location{*testLocation("Central Park", 40.7829, -73.9654)},
+				Missing: domain.MissingLookups{IDs: []string{}, Slugs: []string{"not-a-location"}},
+			}, nil
+		},
 	}
+	handler := newTestLocationHandler(svc)
+
+	t.Run("Success - Found and missing", func(t *testing.T) {
+		body := `{"slugs": ["central-park", "not-a-location"]}`
+		req := httptest.NewRequest(http.MethodPost, "/locations/lookup", strings.NewReader(body))
+		w := httptest.NewRecorder()
+
+		handler.LookupLocations(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var res dataResponse[domain.LocationLookup]
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+		require.Len(t, res.Data.Found, 1)
+		assert.Equal(t, "Central Park", res.Data.Found[0].Name)
+		assert.Equal(t, []string{"not-a-location"}, res.Data.Missing.Slugs)
+	})
+
+	t.Run("Error - Neither ids nor slugs", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/locations/lookup", strings.NewReader(`{}`))
+		w := httptest.NewRecorder()
+
+		handler.LookupLocations(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Len(t, svc.LookupLocationsCalls(), 1)
+	})
+}
+
+func TestLocationHandler_GetNearestLocation(t *testing.T) {
+	svc := &mock.LocationServiceMock{
+		GetNearestLocationFunc: func(ctx context.Context, latitude, longitude float64, formula domain.DistanceFormula) (*domain.NearestLocation, domain.CError) {
+			if latitude > 0 {
+				return &domain.NearestLocation{
+					Location:  *testLocation("New York", 40.7128, -74.0060),
+					Distance:  520.5,
+					Bearing:   212.96,
+					Direction: "SW",
+				}, nil
+			}
+			return nil, domain.ErrDataNotFound
+		},
+		FindNearestLocationsFunc: func(ctx context.Context, req *domain.NearestLocationsRequest) ([]domain.NearestLocation, domain.CError) {
+			if req.Latitude < 0 {
+				return nil, nil
+			}
+			travelTime := 412.0
+			locations := []domain.NearestLocation{
+				{Location: *testLocation("Times Square", 40.7580, -73.9855), Distance: 1100, TravelTime: &travelTime},
+				{Location: *testLocation("Bryant Park", 40.7536, -73.9832), Distance: 1450},
+				{Location: *testLocation("Herald Square", 40.7497, -73.9877), Distance: 2050},
+				{Location: *testLocation("Madison Square", 40.7420, -73.9874), Distance: 2900},
+			}
+			return locations[:min(req.Limit, len(locations))], nil
+		},
+	}
+	handler := newTestLocationHandler(svc)
 
 	t.Run("Success - Find nearest location to New York", func(t *testing.T) {
 		req := httptest.NewRequest(http.MethodGet, "/location/nearest?lat=40.7589&lng=-73.9851", nil)
 		w := httptest.NewRecorder()
 
-		testHandler.GetNearestLocation(w, req)
+		handler.GetNearestLocation(w, req)
 
 		assert.Equal(t, http.StatusOK, w.Code)
 
-		var res response
+		var res dataResponse[any]
 		err := json.Unmarshal(w.Body.Bytes(), &res)
 		require.NoError(t, err)
 
@@ -493,37 +1191,205 @@ func TestLocationHandler_GetNearestLocation(t *testing.T) {
 
 		data := res.Data.(map[string]any)
 		assert.Equal(t, "New York", data["name"])
-		assert.NotEmpty(t, data["distance"])
-		assert.Contains(t, data["distance"], "meters")
+		assert.Equal(t, "520.50 meters", data["distance_text"])
+		assert.Equal(t, 520.5, data["distance_meters"])
+		assert.Equal(t, 213.0, data["bearing"])
+		assert.Equal(t, "SW", data["direction"])
+
+		// Verify the coordinates reached the service
+		calls := svc.GetNearestLocationCalls()
+		require.NotEmpty(t, calls)
+		assert.Equal(t, 40.7589, calls[len(calls)-1].Latitude)
+		assert.Equal(t, -73.9851, calls[len(calls)-1].Longitude)
 	})
 
-	t.Run("Success - Find nearest location to Los Angeles", func(t *testing.T) {
-		req := httptest.NewRequest(http.MethodGet, "/location/nearest?lat=34.0522&lng=-118.2437", nil)
+	t.Run("Success - Find nearest location to a plus code", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/location/nearest?plus_code=87G8Q2PQ%2B2V", nil)
 		w := httptest.NewRecorder()
 
-		testHandler.GetNearestLocation(w, req)
+		handler.GetNearestLocation(w, req)
 
 		assert.Equal(t, http.StatusOK, w.Code)
 
-		var res response
-		err := json.Unmarshal(w.Body.Bytes(), &res)
-		require.NoError(t, err)
+		calls := svc.GetNearestLocationCalls()
+		require.NotEmpty(t, calls)
+		assert.InDelta(t, 40.7850625, calls[len(calls)-1].Latitude, 1e-9)
+		assert.InDelta(t, -73.9603125, calls[len(calls)-1].Longitude, 1e-9)
+	})
 
-		assert.True(t, res.Success)
-		assert.Equal(t, "Success", res.Message)
-		assert.NotNil(t, res.Data)
+	t.Run("Error - Plus code with coordinates", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/location/nearest?plus_code=87G8Q2PQ%2B2V&lat=40.7589", nil)
+		w := httptest.NewRecorder()
+
+		handler.GetNearestLocation(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Success - Nearest location in 3D", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/location/nearest?lat=40.7589&lng=-73.9851&alt=120.5", nil)
+		w := httptest.NewRecorder()
+
+		handler.GetNearestLocation(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		// the altitude takes the nearest location through a search
+		calls := svc.FindNearestLocationsCalls()
+		require.NotEmpty(t, calls)
+		query := calls[len(calls)-1].Req
+		require.NotNil(t, query.Altitude)
+		assert.Equal(t, 120.5, *query.Altitude)
+		assert.Equal(t, 1, query.Limit)
+	})
+
+	t.Run("Error - Invalid altitude", func(t *testing.T) {
+		for _, alt := range []string{"high", "20001", "NaN"} {
+			req := httptest.NewRequest(http.MethodGet, "/location/nearest?lat=40.7589&lng=-73.9851&alt="+alt, nil)
+			w := httptest.NewRecorder()
+
+			handler.GetNearestLocation(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code, alt)
+		}
+	})
+
+	t.Run("Success - Distance in the requested unit", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			query    string
+			language string
+			distance string
+		}{
+			{"unit parameter", "&unit=km", "", "0.52 kilometers"},
+			{"unit parameter over the language", "&unit=m", "en-US", "520.50 meters"},
+			{"miles from the language region", "", "en-US,en;q=0.9", "0.32 miles"},
+			{"kilometers from the language region", "", "fr-FR", "0.52 kilometers"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				req := httptest.NewRequest(http.MethodGet, "/location/nearest?lat=40.7589&lng=-73.9851"+tt.query, nil)
+				if tt.language != "" {
+					req.Header.Set("Accept-Language", tt.language)
+				}
+				w := httptest.NewRecorder()
+
+				handler.GetNearestLocation(w, req)
+
+				assert.Equal(t, http.StatusOK, w.Code)
+
+				var res dataResponse[any]
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+
+				data := res.Data.(map[string]any)
+				assert.Equal(t, tt.distance, data["distance_text"])
+				assert.Equal(t, 520.5, data["distance_meters"])
+			})
+		}
+	})
+
+	t.Run("Success - Formula reaches the service", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/location/nearest?lat=40.7589&lng=-73.9851&formula=haversine", nil)
+		w := httptest.NewRecorder()
+
+		handler.GetNearestLocation(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		calls := svc.GetNearestLocationCalls()
+		require.NotEmpty(t, calls)
+		assert.Equal(t, domain.DistanceHaversine, calls[len(calls)-1].Formula)
+	})
+
+	t.Run("Success - Nearest location within a travel time", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/location/nearest?lat=40.7589&lng=-73.9851&max_travel_time=900&mode=walking", nil)
+		w := httptest.NewRecorder()
+
+		handler.GetNearestLocation(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var res dataResponse[any]
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
 
 		data := res.Data.(map[string]any)
-		assert.Equal(t, "Los Angeles", data["name"])
-		assert.NotEmpty(t, data["distance"])
-		assert.Contains(t, data["distance"], "meters")
+		assert.Equal(t, "Times Square", data["name"])
+		assert.Equal(t, 412.0, data["travel_time_seconds"])
+
+		calls := svc.FindNearestLocationsCalls()
+		require.NotEmpty(t, calls)
+		assert.Equal(t, 900, calls[len(calls)-1].Req.MaxTravelTime)
+		assert.Equal(t, domain.TravelWalking, calls[len(calls)-1].Req.Mode)
+		assert.Equal(t, 1, calls[len(calls)-1].Req.Limit)
+	})
+
+	t.Run("Success - Nearest location of a collection", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/location/nearest?lat=40.7589&lng=-73.9851&collection=hubs", nil)
+		w := httptest.NewRecorder()
+
+		handler.GetNearestLocation(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		calls := svc.FindNearestLocationsCalls()
+		require.NotEmpty(t, calls)
+		assert.Equal(t, "hubs", calls[len(calls)-1].Req.Collection)
+		assert.Equal(t, 1, calls[len(calls)-1].Req.Limit)
+		assert.Zero(t, calls[len(calls)-1].Req.MaxTravelTime)
+	})
+
+	t.Run("Error - No location within the travel time", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/location/nearest?lat=-40.7589&lng=-73.9851&max_travel_time=900", nil)
+		w := httptest.NewRecorder()
+
+		handler.GetNearestLocation(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Error - Invalid travel time", func(t *testing.T) {
+		for _, query := range []string{"max_travel_time=soon", "max_travel_time=0", "max_travel_time=900&mode=flying"} {
+			req := httptest.NewRequest(http.MethodGet, "/location/nearest?lat=40.7589&lng=-73.9851&"+query, nil)
+			w := httptest.NewRecorder()
+
+			handler.GetNearestLocation(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code, query)
+		}
+	})
+
+	t.Run("Error - Invalid formula", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/location/nearest?lat=40.7589&lng=-73.9851&formula=flat", nil)
+		w := httptest.NewRecorder()
+
+		handler.GetNearestLocation(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		var res errorResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+		assert.Equal(t, "Invalid formula, expected spheroid, haversine or vincenty", res.Message)
+	})
+
+	t.Run("Error - Invalid unit", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/location/nearest?lat=40.7589&lng=-73.9851&unit=ft", nil)
+		w := httptest.NewRecorder()
+
+		handler.GetNearestLocation(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		var res errorResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+		assert.Equal(t, "Invalid unit, expected m, km or mi", res.Message)
 	})
 
 	t.Run("Error - Invalid latitude", func(t *testing.T) {
 		req := httptest.NewRequest(http.MethodGet, "/location/nearest?lat=invalid&lng=-74.0060", nil)
 		w := httptest.NewRecorder()
 
-		testHandler.GetNearestLocation(w, req)
+		handler.GetNearestLocation(w, req)
 
 		assert.Equal(t, http.StatusBadRequest, w.Code)
 
@@ -539,7 +1405,7 @@ func TestLocationHandler_GetNearestLocation(t *testing.T) {
 		req := httptest.NewRequest(http.MethodGet, "/location/nearest?lat=40.7128&lng=invalid", nil)
 		w := httptest.NewRecorder()
 
-		testHandler.GetNearestLocation(w, req)
+		handler.GetNearestLocation(w, req)
 
 		assert.Equal(t, http.StatusBadRequest, w.Code)
 
@@ -551,13 +1417,65 @@ func TestLocationHandler_GetNearestLocation(t *testing.T) {
 		assert.Equal(t, "Invalid longitude", res.Message)
 	})
 
-	t.Run("Error - No locations found", func(t *testing.T) {
-		cleanupTestData(t)
+	t.Run("Error - Coordinates out of range", func(t *testing.T) {
+		calls := len(svc.GetNearestLocationCalls())
+
+		for _, query := range []string{"lat=999&lng=-74.0060", "lat=40.7128&lng=-181", "lat=Inf&lng=-74.0060", "lng=-74.0060"} {
+			req := httptest.NewRequest(http.MethodGet, "/location/nearest?"+query, nil)
+			w := httptest.NewRecorder()
+
+			handler.GetNearestLocation(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code, query)
+		}
+
+		// the service is never reached
+		assert.Len(t, svc.GetNearestLocationCalls(), calls)
+	})
+
+	t.Run("Success - Nearest location with its alternatives", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/location/nearest?lat=40.7589&lng=-73.9851&alternatives=true&unit=km", nil)
+		w := httptest.NewRecorder()
+
+		handler.GetNearestLocation(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var res dataResponse[map[string]any]
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+
+		assert.Equal(t, "Times Square", res.Data["name"])
+		alternatives := res.Data["alternatives"].([]any)
+		require.Len(t, alternatives, 2)
+		assert.Equal(t, "Bryant Park", alternatives[0].(map[string]any)["name"])
+		assert.Equal(t, 1450.0, alternatives[0].(map[string]any)["distance_meters"])
+		assert.Equal(t, "1.45 kilometers", alternatives[0].(map[string]any)["distance_text"])
+		assert.Equal(t, "Herald Square", alternatives[1].(map[string]any)["name"])
+
+		calls := svc.FindNearestLocationsCalls()
+		assert.Equal(t, 3, calls[len(calls)-1].Req.Limit)
+	})
 
-		req := httptest.NewRequest(http.MethodGet, "/location/nearest?lat=40.7128&lng=-74.0060", nil)
+	t.Run("Success - No alternatives unless asked", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/location/nearest?lat=40.7589&lng=-73.9851&include_expired=true", nil)
 		w := httptest.NewRecorder()
 
-		testHandler.GetNearestLocation(w, req)
+		handler.GetNearestLocation(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var res dataResponse[map[string]any]
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+
+		assert.Equal(t, "Times Square", res.Data["name"])
+		assert.NotContains(t, res.Data, "alternatives")
+	})
+
+	t.Run("Error - No locations found", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/location/nearest?lat=-40.7128&lng=-74.0060", nil)
+		w := httptest.NewRecorder()
+
+		handler.GetNearestLocation(w, req)
 
 		assert.Equal(t, http.StatusNotFound, w.Code)
 
@@ -569,27 +1487,212 @@ func TestLocationHandler_GetNearestLocation(t *testing.T) {
 	})
 }
 
-// Helper function to create a test location via HTTP
-func createTestLocationViaHTTP(t *testing.T, name string, lat, lng float64) response {
-	requestBody := domain.RegisterLocationRequest{
-		Name:      name,
-		Latitude:  lat,
-		Longitude: lng,
+func TestLocationHandler_SearchNearestLocations(t *testing.T) {
+	svc := &mock.LocationServiceMock{
+		FindNearestLocationsFunc: func(ctx context.Context, req *domain.NearestLocationsRequest) ([]domain.NearestLocation, domain.CError) {
+			return []domain.NearestLocation{{Location: *testLocation("Times Square", 40.7580, -73.9855), Distance: 120}}, nil
+		},
+	}
+	handler := newTestLocationHandler(svc)
+
+	tests := []struct {
+		name    string
+		body    string
+		status  int
+		message string
+	}{
+		{"Success - Coordinates", `{"latitude": 40.7589, "longitude": -73.9851}`, http.StatusOK, ""},
+		{"Success - Limit, distance and categories", `{"latitude": 40.7589, "longitude": -73.9851, "limit": 100, "max_distance": 500, "categories": ["park"]}`, http.StatusOK, ""},
+		{"Success - Plus code", `{"plus_code": "87G8Q2PQ+2V"}`, http.StatusOK, ""},
+		{"Success - On the equator", `{"latitude": 0, "longitude": 32.5825}`, http.StatusOK, ""},
+		{"Success - On the prime meridian", `{"latitude": 51.4779, "longitude": 0}`, http.StatusOK, ""},
+		{"Success - Null Island", `{"latitude": 0, "longitude": 0}`, http.StatusOK, ""},
+		{"Error - Empty body", ``, http.StatusBadRequest, "Request body is empty"},
+		{"Error - Empty object", `{}`, http.StatusBadRequest, ""},
+		{"Error - Malformed JSON", `{"latitude": 40.7589,`, http.StatusBadRequest, "Malformed JSON, the body ended unexpectedly"},
+		{"Error - Coordinates as strings", `{"latitude": "40.7589", "longitude": "-73.9851"}`, http.StatusBadRequest, `Invalid value for field "latitude", expected float64`},
+		{"Error - Unknown field", `{"lat": 40.7589, "lng": -73.9851}`, http.StatusBadRequest, `Unknown field "lat"`},
+		{"Error - Missing longitude", `{"latitude": 40.7589}`, http.StatusBadRequest, ""},
+		{"Error - Latitude out of range", `{"latitude": 91, "longitude": -73.9851}`, http.StatusBadRequest, ""},
+		{"Error - Longitude out of range", `{"latitude": 40.7589, "longitude": -180.5}`, http.StatusBadRequest, ""},
+		{"Error - Plus code along with coordinates", `{"plus_code": "87G8Q2PQ+2V", "latitude": 40.7589}`, http.StatusBadRequest, "Send either a plus code or coordinates, not both"},
+		{"Error - Plus code along with a zero coordinate", `{"plus_code": "87G8Q2PQ+2V", "longitude": 0}`, http.StatusBadRequest, "Send either a plus code or coordinates, not both"},
+		{"Error - Zero latitude without longitude", `{"latitude": 0}`, http.StatusBadRequest, ""},
+		{"Error - Limit too low", `{"latitude": 40.7589, "longitude": -73.9851, "limit": -1}`, http.StatusBadRequest, ""},
+		{"Error - Limit too high", `{"latitude": 40.7589, "longitude": -73.9851, "limit": 101}`, http.StatusBadRequest, ""},
+		{"Error - Negative max distance", `{"latitude": 40.7589, "longitude": -73.9851, "max_distance": -5}`, http.StatusBadRequest, ""},
+		{"Error - Empty category", `{"latitude": 40.7589, "longitude": -73.9851, "categories": [""]}`, http.StatusBadRequest, ""},
+		{"Error - Invalid unit", `{"latitude": 40.7589, "longitude": -73.9851, "unit": "ft"}`, http.StatusBadRequest, ""},
+		{"Error - Two JSON values", `{"latitude": 40.7589, "longitude": -73.9851} {}`, http.StatusBadRequest, "Request body must contain a single JSON value"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/locations/nearest", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			handler.SearchNearestLocations(w, req)
+
+			assert.Equal(t, tt.status, w.Code, w.Body.String())
+			if tt.message != "" {
+				var res errorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+				assert.Equal(t, tt.message, res.Message)
+			}
+		})
 	}
 
-	body, err := json.Marshal(requestBody)
-	require.NoError(t, err)
+	// only the valid searches reach the service, with the body as sent
+	calls := svc.FindNearestLocationsCalls()
+	require.Len(t, calls, 6)
+	assert.Equal(t, 100, calls[1].Req.Limit)
+	assert.Equal(t, 500.0, calls[1].Req.MaxDistance)
+	assert.Equal(t, []string{"park"}, calls[1].Req.Categories)
+	assert.NotZero(t, calls[2].Req.Latitude, "the plus code is resolved to coordinates")
+	assert.Equal(t, 0.0, calls[3].Req.Latitude)
+	assert.Equal(t, 32.5825, calls[3].Req.Longitude)
+	assert.Equal(t, 51.4779, calls[4].Req.Latitude)
+	assert.Equal(t, 0.0, calls[4].Req.Longitude)
+}
 
-	req := httptest.NewRequest(http.MethodPost, "/locations", bytes.NewBuffer(body))
-	req.Header.Set("Content-Type", "application/json")
-	w := httptest.NewRecorder()
+func TestLocationHandler_FindLocationsAlongRoute(t *testing.T) {
+	svc := &mock.LocationServiceMock{
+		FindLocationsAlongRouteFunc: func(ctx context.Context, req *domain.AlongRouteRequest) ([]domain.RouteLocation, domain.CError) {
+			if req.Polyline == "bad" {
+				return nil, domain.NewBadRequestCError("invalid encoded polyline")
+			}
+			return []domain.RouteLocation{
+				{Location: *testLocation("Shell Lekki", 6.4474, 3.4723), Distance: 1250, Fraction: 0.123456},
+			}, nil
+		},
+	}
+	handler := newTestLocationHandler(svc)
+
+	tests := []struct {
+		name    string
+		body    string
+		status  int
+		message string
+	}{
+		{
+			name:   "waypoints",
+			body:   `{"waypoints":[{"latitude":6.43,"longitude":3.42},{"latitude":6.46,"longitude":3.55}],"buffer":2000}`,
+			status: http.StatusOK,
+		},
+		{
+			name:   "polyline",
+			body:   `{"polyline":"_p~iF~ps|U_ulLnnqC","buffer":2000}`,
+			status: http.StatusOK,
+		},
+		{
+			name:   "no route",
+			body:   `{"buffer":2000}`,
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "both polyline and waypoints",
+			body:   `{"polyline":"_p~iF~ps|U","waypoints":[{"latitude":6.43,"longitude":3.42},{"latitude":6.46,"longitude":3.55}],"buffer":2000}`,
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "single waypoint",
+			body:   `{"waypoints":[{"latitude":6.43,"longitude":3.42}],"buffer":2000}`,
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "missing buffer",
+			body:   `{"polyline":"_p~iF~ps|U_ulLnnqC"}`,
+			status: http.StatusBadRequest,
+		},
+		{
+			name:    "invalid polyline",
+			body:    `{"polyline":"bad","buffer":2000}`,
+			status:  http.StatusBadRequest,
+			message: "invalid encoded polyline",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/locations/along-route?unit=km", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+
+			handler.FindLocationsAlongRoute(w, req)
+
+			assert.Equal(t, tt.status, w.Code)
+			if tt.status != http.StatusOK {
+				var res errorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+				if tt.message != "" {
+					assert.Equal(t, tt.message, res.Message)
+				}
+				return
+			}
+
+			var res dataResponse[any]
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+
+			data := res.Data.([]any)
+			require.Len(t, data, 1)
+			location := data[0].(map[string]any)
+			assert.Equal(t, "Shell Lekki", location["name"])
+			assert.Equal(t, 1250.0, location["distance_meters"])
+			assert.Equal(t, "1.25 kilometers", location["distance_text"])
+			assert.Equal(t, 0.1235, location["route_fraction"])
+		})
+	}
+}
 
-	testHandler.RegisterLocation(w, req)
-	assert.Equal(t, http.StatusCreated, w.Code)
+func TestLocationHandler_GetLocationGeometry(t *testing.T) {
+	svc := &mock.LocationServiceMock{
+		LocationGeometryFunc: func(ctx context.Context, req *domain.LocationGeometryRequest) (*domain.LocationGeometry, domain.CError) {
+			if req.Query == "bad:" {
+				return nil, domain.NewBadRequestCError("invalid filter")
+			}
+			return &domain.LocationGeometry{
+				Count:       2,
+				Centroid:    json.RawMessage(`{"type":"Point","coordinates":[3.42,6.49]}`),
+				BoundingBox: []float64{3.3792, 6.4474, 3.4723, 6.5244},
+				ConvexHull:  json.RawMessage(`{"type":"LineString","coordinates":[[3.3792,6.5244],[3.4723,6.4474]]}`),
+			}, nil
+		},
+	}
+	handler := newTestLocationHandler(svc)
+
+	tests := []struct {
+		name   string
+		body   string
+		status int
+	}{
+		{"filter", `{"query":"category:mall","collection":"favorites"}`, http.StatusOK},
+		{"slugs", `{"slugs":["lagos-mall","shell-lekki"]}`, http.StatusOK},
+		{"everything", `{}`, http.StatusOK},
+		{"both filter and slugs", `{"query":"category:mall","slugs":["lagos-mall"]}`, http.StatusBadRequest},
+		{"empty slug", `{"slugs":[""]}`, http.StatusBadRequest},
+		{"invalid filter", `{"query":"bad:"}`, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/locations/geometry", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
 
-	var res response
-	err = json.Unmarshal(w.Body.Bytes(), &res)
-	require.NoError(t, err)
+			handler.GetLocationGeometry(w, req)
 
-	return res
+			require.Equal(t, tt.status, w.Code, w.Body.String())
+			if tt.status != http.StatusOK {
+				return
+			}
+
+			var res dataResponse[any]
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+
+			geometry := res.Data.(map[string]any)
+			assert.Equal(t, 2.0, geometry["count"])
+			assert.Equal(t, []any{3.3792, 6.4474, 3.4723, 6.5244}, geometry["bbox"])
+			assert.Equal(t, "Point", geometry["centroid"].(map[string]any)["type"])
+			assert.Equal(t, "LineString", geometry["convex_hull"].(map[string]any)["type"])
+		})
+	}
 }
@@ -2,11 +2,16 @@ package http
 
 import (
 	"context"
+	"crypto/subtle"
 	"fmt"
 	"leeta/internal/adapter/logger"
+	"leeta/internal/core/auth"
+	"leeta/internal/core/domain"
 	"net/http"
+	"strings"
 	"time"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/rs/xid"
 	"go.uber.org/zap"
 )
@@ -14,61 +19,171 @@ import (
 type contextKey string
 
 const (
-	// correlationIDCtxKey is the key for the correlation id
-	correlationIDCtxKey contextKey = "correlation_id"
+	// requestIDCtxKey is the key for the request id
+	requestIDCtxKey contextKey = "request_id"
+	// reviewCtxKey is the key marking the requests whose submissions wait for review
+	reviewCtxKey contextKey = "review"
+
+	// requestIDHeader carries the request id in requests and responses
+	requestIDHeader = "X-Request-ID"
+	// maxRequestIDLength bounds the request ids accepted from clients
+	maxRequestIDLength = 128
 )
 
-func requestLogger(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		l := logger.Get()
-
-		correlationID := xid.New().String()
+// readRequestID returns the request id sent by the client, or a new one if it sent none or an invalid one
+func readRequestID(r *http.Request) string {
+	id := r.Header.Get(requestIDHeader)
+	if id == "" || len(id) > maxRequestIDLength {
+		return xid.New().String()
+	}
+
+	for _, c := range id {
+		// only printable ASCII, so the id is safe to echo in headers and logs
+		if c < 0x21 || c > 0x7e {
+			return xid.New().String()
+		}
+	}
+
+	return id
+}
 
-		ctx := context.WithValue(
-			r.Context(),
-			correlationIDCtxKey,
-			correlationID,
-		)
+// requestLogger attaches a logger carrying the request id to the request context and writes
+// the access log line of the request once it is served, following the rules of al
+func requestLogger(al *accessLog) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			l := logger.Get()
 
-		r = r.WithContext(ctx)
+			requestID := readRequestID(r)
+			record := &accessRecord{}
 
-		l = l.With(zap.String(string(correlationIDCtxKey), correlationID))
-		w.Header().Add("X-Correlation-ID", correlationID)
+			ctx := context.WithValue(
+				r.Context(),
+				requestIDCtxKey,
+				requestID,
+			)
+			ctx = context.WithValue(ctx, accessRecordCtxKey, record)
+
+			l = l.With(zap.String(string(requestIDCtxKey), requestID))
+			w.Header().Set(requestIDHeader, requestID)
+
+			lrw := newLoggingResponseWriter(w)
+
+			r = r.WithContext(logger.WithCtx(ctx, l))
+
+			var body *bodyRecorder
+			if al.body && r.Body != nil && r.Body != http.NoBody {
+				body = newBodyRecorder(r.Body, al.maxBodySize)
+				r.Body = body
+			}
+
+			defer func(start time.Time) {
+				var pattern string
+				if rctx := chi.RouteContext(r.Context()); rctx != nil {
+					pattern = rctx.RoutePattern()
+				}
+
+				if !al.sampled(r.Method, pattern, lrw.statusCode) {
+					return
+				}
+
+				fields := []zap.Field{
+					zap.String("method", r.Method),
+					zap.String("route", pattern),
+					zap.String("url", r.RequestURI),
+					zap.Int("status_code", lrw.statusCode),
+					zap.Int64("bytes", lrw.bytes),
+					zap.Duration("elapsed_ms", time.Since(start)),
+				}
+
+				if record.user != "" {
+					fields = append(fields, zap.String("user", record.user))
+				}
+				if headers := al.headerFields(r.Header); len(headers) > 0 {
+					fields = append(fields, zap.Any("headers", headers))
+				}
+				if body != nil && body.buf.Len() > 0 {
+					fields = append(fields, al.bodyField(body))
+				}
+
+				l.Info(
+					fmt.Sprintf(
+						"%s request to %s completed",
+						r.Method,
+						r.RequestURI,
+					),
+					fields...,
+				)
+			}(time.Now())
+
+			lrw.Header().Add("Content-Type", "application/json")
+			next.ServeHTTP(lrw, r)
+		})
+	}
+}
 
-		lrw := newLoggingResponseWriter(w)
+// adminToken lets through the requests authorized with the bearer token and rejects the others
+func adminToken(token string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !authorized(r, token) {
+				handleError(w, domain.ErrUnauthorized)
+				return
+			}
+
+			setAccessUser(r.Context(), "admin")
+			next.ServeHTTP(w, r)
+		})
+	}
+}
 
-		r = r.WithContext(logger.WithCtx(ctx, l))
+// authorized reports whether r is authorized with the bearer token
+func authorized(r *http.Request, token string) bool {
+	sent, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return ok && subtle.ConstantTimeCompare([]byte(sent), []byte(token)) == 1
+}
 
-		defer func(start time.Time) {
-			l.Info(
-				fmt.Sprintf(
-					"%s request to %s completed",
-					r.Method,
-					r.RequestURI,
-				),
-				zap.String("method", r.Method),
-				zap.String("url", r.RequestURI),
-				// zap.String("user_agent", r.UserAgent()),
-				zap.Int("status_code", lrw.statusCode),
-				zap.Duration("elapsed_ms", time.Since(start)),
-			)
-		}(time.Now())
+// reviewSubmissions marks the requests of callers without the admin role, so that the
+// locations they submit wait for review
+func reviewSubmissions(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if p, ok := auth.FromCtx(r.Context()); !ok || !p.HasRole(auth.RoleAdmin) {
+			r = r.WithContext(context.WithValue(r.Context(), reviewCtxKey, true))
+		}
 
-		lrw.Header().Add("Content-Type", "application/json")
-		next.ServeHTTP(lrw, r)
+		next.ServeHTTP(w, r)
 	})
 }
 
+// needsReview reports whether the locations submitted with ctx wait for review
+func needsReview(ctx context.Context) bool {
+	review, _ := ctx.Value(reviewCtxKey).(bool)
+	return review
+}
+
 type loggingResponseWriter struct {
 	http.ResponseWriter
 	statusCode int
+	bytes      int64
 }
 
 func newLoggingResponseWriter(w http.ResponseWriter) *loggingResponseWriter {
-	return &loggingResponseWriter{w, http.StatusOK}
+	return &loggingResponseWriter{w, http.StatusOK, 0}
+}
+
+func (lrw *loggingResponseWriter) Write(b []byte) (int, error) {
+	n, err := lrw.ResponseWriter.Write(b)
+	lrw.bytes += int64(n)
+	return n, err
 }
 
 func (lrw *loggingResponseWriter) WriteHeader(code int) {
 	lrw.statusCode = code
 	lrw.ResponseWriter.WriteHeader(code)
 }
+
+// Unwrap returns the original http.ResponseWriter, so http.ResponseController
+// can reach its Flush and deadline methods
+func (lrw *loggingResponseWriter) Unwrap() http.ResponseWriter {
+	return lrw.ResponseWriter
+}
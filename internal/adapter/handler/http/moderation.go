@@ -0,0 +1,137 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"leeta/internal/core/domain"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// ListPendingLocations godoc
+//
+//	@Summary		List the locations waiting for review
+//	@Description	list a page of the submitted locations waiting for review, oldest submission first
+//	@Tags			Admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			limit	query		int						false	"Maximum number of locations, 20 by default, at most 100"
+//	@Param			offset	query		int						false	"Number of locations to skip"
+//	@Success		200		{object}	locationListResponse	"Success"
+//	@Failure		400		{object}	errorResponse			"Validation error"
+//	@Failure		401		{object}	errorResponse			"Unauthorized error"
+//	@Failure		500		{object}	errorResponse			"Internal server error"
+//	@Router			/admin/locations/pending [get]
+//	@Security		BearerAuth
+func (ch *LocationHandler) ListPendingLocations(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	var req domain.ListPendingLocationsRequest
+
+	if value := query.Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil {
+			handleError(w, domain.NewBadRequestCError("limit must be a number"))
+			return
+		}
+		req.Limit = limit
+	}
+
+	if value := query.Get("offset"); value != "" {
+		offset, err := strconv.Atoi(value)
+		if err != nil {
+			handleError(w, domain.NewBadRequestCError("offset must be a number"))
+			return
+		}
+		req.Offset = offset
+	}
+
+	if err := ch.validate.Struct(&req); err != nil {
+		validationError(w, err)
+		return
+	}
+
+	locations, cerr := ch.svc.ListPendingLocations(r.Context(), &req)
+	if cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+
+	handleSuccess(w, http.StatusOK, locations)
+}
+
+// ApproveLocation godoc
+//
+//	@Summary		Approve a location
+//	@Description	approve a location waiting for review, it then appears in the public queries
+//	@Tags			Admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			name	path		string				true	"Location name"
+//	@Success		200		{object}	locationResponse	"Location approved"
+//	@Failure		401		{object}	errorResponse		"Unauthorized error"
+//	@Failure		404		{object}	errorResponse		"Not found error"
+//	@Failure		409		{object}	errorResponse		"Location is not pending review"
+//	@Failure		500		{object}	errorResponse		"Internal server error"
+//	@Router			/admin/locations/{name}/approve [post]
+//	@Security		BearerAuth
+func (ch *LocationHandler) ApproveLocation(w http.ResponseWriter, r *http.Request) {
+	name := chi.URLParam(r, "name")
+	if name == "" {
+		handleError(w, domain.NewBadRequestCError("Invalid location name"))
+		return
+	}
+
+	location, cerr := ch.svc.ApproveLocation(r.Context(), name)
+	if cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+
+	handleSuccessWithMessage(w, http.StatusOK, location, "Location approved")
+}
+
+// RejectLocation godoc
+//
+//	@Summary		Reject a location
+//	@Description	reject a location waiting for review with a reason, it stays out of the public queries
+//	@Tags			Admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			name							path		string							true	"Location name"
+//	@Param			domain.RejectLocationRequest	body		domain.RejectLocationRequest	true	"Rejection"
+//	@Success		200								{object}	locationResponse				"Location rejected"
+//	@Failure		400								{object}	errorResponse					"Validation error"
+//	@Failure		401								{object}	errorResponse					"Unauthorized error"
+//	@Failure		404								{object}	errorResponse					"Not found error"
+//	@Failure		409								{object}	errorResponse					"Location is not pending review"
+//	@Failure		413								{object}	errorResponse					"Request body too large"
+//	@Failure		500								{object}	errorResponse					"Internal server error"
+//	@Router			/admin/locations/{name}/reject [post]
+//	@Security		BearerAuth
+func (ch *LocationHandler) RejectLocation(w http.ResponseWriter, r *http.Request) {
+	name := chi.URLParam(r, "name")
+	if name == "" {
+		handleError(w, domain.NewBadRequestCError("Invalid location name"))
+		return
+	}
+
+	var req domain.RejectLocationRequest
+	if cerr := decodeJSON(r, &req); cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+
+	if err := ch.validate.Struct(&req); err != nil {
+		validationError(w, err)
+		return
+	}
+
+	location, cerr := ch.svc.RejectLocation(r.Context(), name, &req)
+	if cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+
+	handleSuccessWithMessage(w, http.StatusOK, location, "Location rejected")
+}
@@ -0,0 +1,167 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"leeta/internal/core/domain"
+	"leeta/internal/core/port/mock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocationHandler_RegisterLocationForReview(t *testing.T) {
+	svc := &mock.LocationServiceMock{
+		RegisterLocationFunc: func(ctx context.Context, req *domain.RegisterLocationRequest) (*domain.Location, domain.CError) {
+			location := testLocation(req.Name, req.Latitude, req.Longitude)
+			location.Status = domain.LocationApproved
+			if req.Review {
+				location.Status = domain.LocationPending
+			}
+			return location, nil
+		},
+	}
+	authn := &authenticator{adminToken: "secret"}
+	handler := authn.identify(reviewSubmissions(http.HandlerFunc(newTestLocationHandler(svc).RegisterLocation)))
+
+	tests := []struct {
+		name          string
+		authorization string
+		status        int
+		message       string
+	}{
+		{"Submission waits for review", "", http.StatusAccepted, "Location submitted for review"},
+		{"Wrong token waits for review", "Bearer guess", http.StatusAccepted, "Location submitted for review"},
+		{"Admin submission is approved", "Bearer secret", http.StatusCreated, "Location created successfully"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := `{"name": "Lagos Park", "latitude": 6.5244, "longitude": 3.3792}`
+			req := httptest.NewRequest(http.MethodPost, "/locations", strings.NewReader(body))
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.status, w.Code)
+
+			var res dataResponse[any]
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+			assert.Equal(t, tt.message, res.Message)
+		})
+	}
+}
+
+func TestLocationHandler_ListPendingLocations(t *testing.T) {
+	svc := &mock.LocationServiceMock{
+		ListPendingLocationsFunc: func(ctx context.Context, req *domain.ListPendingLocationsRequest) ([]domain.Location, domain.CError) {
+			location := testLocation("Lagos Park", 6.5244, 3.3792)
+			location.Status = domain.LocationPending
+			return []domain.Location{*location}, nil
+		},
+	}
+	handler := newTestLocationHandler(svc)
+
+	tests := []struct {
+		name   string
+		query  string
+		status int
+	}{
+		{"Success", "", http.StatusOK},
+		{"Success - Page", "?limit=10&offset=10", http.StatusOK},
+		{"Error - Limit not a number", "?limit=ten", http.StatusBadRequest},
+		{"Error - Limit too large", "?limit=1000", http.StatusBadRequest},
+		{"Error - Negative offset", "?offset=-1", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/admin/locations/pending"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			handler.ListPendingLocations(w, req)
+
+			assert.Equal(t, tt.status, w.Code, w.Body.String())
+		})
+	}
+
+	calls := svc.ListPendingLocationsCalls()
+	require.Len(t, calls, 2)
+	assert.Equal(t, domain.ListPendingLocationsRequest{Limit: 10, Offset: 10}, *calls[1].Req)
+}
+
+func TestLocationHandler_ReviewLocation(t *testing.T) {
+	review := func(name string, status domain.LocationStatus, reason string) (*domain.Location, domain.CError) {
+		switch name {
+		case "lagos-park":
+			location := testLocation("Lagos Park", 6.5244, 3.3792)
+			location.Status = status
+			location.ReviewReason = reason
+			return location, nil
+		case "ikeja":
+			return nil, domain.NewCError(http.StatusConflict, "location is not pending review")
+		}
+		return nil, domain.NewCError(http.StatusNotFound, "location not found")
+	}
+	svc := &mock.LocationServiceMock{
+		ApproveLocationFunc: func(ctx context.Context, name string) (*domain.Location, domain.CError) {
+			return review(name, domain.LocationApproved, "")
+		},
+		RejectLocationFunc: func(ctx context.Context, name string, req *domain.RejectLocationRequest) (*domain.Location, domain.CError) {
+			return review(name, domain.LocationRejected, req.Reason)
+		},
+	}
+	handler := newTestLocationHandler(svc)
+
+	t.Run("Approve", func(t *testing.T) {
+		for name, status := range map[string]int{"lagos-park": http.StatusOK, "ikeja": http.StatusConflict, "unknown": http.StatusNotFound} {
+			req := httptest.NewRequest(http.MethodPost, "/admin/locations/"+name+"/approve", nil)
+			req = withURLParam(req, "name", name)
+			w := httptest.NewRecorder()
+
+			handler.ApproveLocation(w, req)
+
+			assert.Equal(t, status, w.Code, name)
+		}
+	})
+
+	tests := []struct {
+		name     string
+		location string
+		body     string
+		status   int
+	}{
+		{"Reject", "lagos-park", `{"reason": "Duplicate of Lagos Park Reserve"}`, http.StatusOK},
+		{"Reject - Missing reason", "lagos-park", `{}`, http.StatusBadRequest},
+		{"Reject - Not pending", "ikeja", `{"reason": "Duplicate"}`, http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/admin/locations/"+tt.location+"/reject", strings.NewReader(tt.body))
+			req = withURLParam(req, "name", tt.location)
+			w := httptest.NewRecorder()
+
+			handler.RejectLocation(w, req)
+
+			assert.Equal(t, tt.status, w.Code, w.Body.String())
+			if tt.status != http.StatusOK {
+				return
+			}
+
+			var res dataResponse[any]
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+			data := res.Data.(map[string]any)
+			assert.Equal(t, "rejected", data["status"])
+			assert.Equal(t, "Duplicate of Lagos Park Reserve", data["review_reason"])
+		})
+	}
+}
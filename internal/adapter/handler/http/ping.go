@@ -1,6 +1,7 @@
 package http
 
 import (
+	"net"
 	"net/http"
 
 	"leeta/internal/adapter/logger"
@@ -13,46 +14,104 @@ import (
 // PingHandler represents the HTTP handler for ping-related requests
 type PingHandler struct {
 	svc      port.PingService
+	health   port.HealthService
 	validate *validator.Validate
 }
 
-// NewCategoryHandler creates a new CategoryHandler instance
-func NewPingHandler(svc port.PingService, vld *validator.Validate) *PingHandler {
+// NewPingHandler creates a new PingHandler instance
+func NewPingHandler(svc port.PingService, health port.HealthService, vld *validator.Validate) *PingHandler {
 	return &PingHandler{
 		svc,
+		health,
 		vld,
 	}
 }
 
 // PingPost godoc
 //
-//	@Summary		Create a new ping object
-//	@Description	create a new ping object with name
+//	@Summary		Send a ping
+//	@Description	store a heartbeat, optionally named and carrying the latency the client measured for its previous ping
 //	@Tags			Ping
 //	@Accept			json
 //	@Produce		json
-//	@Param			domain.Ping	body		domain.Ping		true	"Create ping request"
-//	@Success		201			{object}	response		"Ping created"
-//	@Failure		400			{object}	errorResponse	"Validation error"
-//	@Failure		500			{object}	errorResponse	"Internal server error"
+//	@Param			domain.PingRequest	body		domain.PingRequest	true	"Ping"
+//	@Success		201					{object}	pingResponse		"Ping created"
+//	@Failure		400					{object}	errorResponse		"Validation error"
+//	@Failure		500					{object}	errorResponse		"Internal server error"
 //	@Router			/health [post]
 func (ch *PingHandler) PingPost(w http.ResponseWriter, r *http.Request) {
-	var req domain.Ping
+	var req domain.PingRequest
+	if r.ContentLength != 0 {
+		if cerr := decodeJSON(r, &req); cerr != nil {
+			handleError(w, cerr)
+			return
+		}
+	}
 
 	if err := ch.validate.Struct(&req); err != nil {
 		validationError(w, err)
 		return
 	}
 
-	ping, err := ch.svc.Ping(r.Context(), &req)
+	source, _, err := net.SplitHostPort(r.RemoteAddr)
 	if err != nil {
-		handleError(w, err)
+		source = r.RemoteAddr
+	}
+
+	ping, cerr := ch.svc.Ping(r.Context(), &domain.Ping{
+		Name:      req.Name,
+		Source:    source,
+		LatencyMs: req.LatencyMs,
+	})
+	if cerr != nil {
+		handleError(w, cerr)
 		return
 	}
 
 	handleSuccess(w, http.StatusCreated, ping)
 }
 
+// PingStats godoc
+//
+//	@Summary		Get uptime statistics
+//	@Description	get the uptime of the server, the recent ping counts and the availability over the last day
+//	@Tags			Ping
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	pingStatsResponse	"Success"
+//	@Failure		500	{object}	errorResponse		"Internal server error"
+//	@Router			/health/stats [get]
+func (ch *PingHandler) PingStats(w http.ResponseWriter, r *http.Request) {
+	stats, cerr := ch.svc.GetStats(r.Context())
+	if cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+
+	handleSuccess(w, http.StatusOK, stats)
+}
+
+// PingDependencies godoc
+//
+//	@Summary		Check the dependencies
+//	@Description	check every dependency of the server concurrently and report their status, latency and last success. It answers 503 when a critical dependency is down
+//	@Tags			Ping
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	healthReportResponse	"Dependencies checked"
+//	@Failure		503	{object}	healthReportResponse	"Critical dependencies are down"
+//	@Router			/health/dependencies [get]
+func (ch *PingHandler) PingDependencies(w http.ResponseWriter, r *http.Request) {
+	report := ch.health.Report(r.Context())
+
+	if report.Status == domain.HealthDown {
+		handleSuccessWithMessage(w, http.StatusServiceUnavailable, report, "Critical dependencies are down")
+		return
+	}
+
+	handleSuccessWithMessage(w, http.StatusOK, report, "Dependencies checked")
+}
+
 // PingGet godoc
 //
 //	@Summary		Check server status
@@ -60,9 +119,14 @@ func (ch *PingHandler) PingPost(w http.ResponseWriter, r *http.Request) {
 //	@Tags			Ping
 //	@Accept			json
 //	@Produce		json
-//	@Success		200	{object}	response	"Ping created"
+//	@Success		200	{object}	logLevelResponse	"Server OK"
 //	@Router			/health [get]
 func (ch *PingHandler) PingGet(w http.ResponseWriter, r *http.Request) {
 	logger.FromCtx(r.Context()).Info("Alive!")
-	handleSuccessWithMessage(w, 200, nil, "Server OK")
+	handleSuccessWithMessage(w, 200, healthResponse{logger.Level()}, "Server OK")
+}
+
+// healthResponse represents the status of the server
+type healthResponse struct {
+	LogLevel string `json:"log_level" example:"info"`
 }
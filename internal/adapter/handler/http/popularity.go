@@ -0,0 +1,117 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"leeta/internal/core/domain"
+	"leeta/internal/core/port"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// PopularityHandler represents the HTTP handler for popularity-related requests
+type PopularityHandler struct {
+	svc      port.PopularityService
+	validate *validator.Validate
+}
+
+// NewPopularityHandler creates a new PopularityHandler instance
+func NewPopularityHandler(svc port.PopularityService, vld *validator.Validate) *PopularityHandler {
+	return &PopularityHandler{
+		svc,
+		vld,
+	}
+}
+
+// ListTrendingLocations godoc
+//
+//	@Summary		List the trending locations
+//	@Description	list the locations fetched or returned by nearest searches the most within a window, optionally near a point
+//	@Tags			Location
+//	@Accept			json
+//	@Produce		json
+//	@Param			window			query		string							false	"Window up to now, such as 24h or 7d, defaults to 7d"
+//	@Param			limit			query		int								false	"Number of locations, defaults to 10"
+//	@Param			lat				query		float64							false	"Latitude of the point the locations are near, requires lng"
+//	@Param			lng				query		float64							false	"Longitude of the point the locations are near, requires lat"
+//	@Param			radius			query		number							false	"Radius in meters around the point, defaults to 5000"
+//	@Param			category		query		string							false	"Only list the locations of this category"
+//	@Param			Accept-Language	header		string							false	"Preferred locales of the location names, the canonical name is kept when none has a translation"
+//	@Success		200				{object}	trendingLocationListResponse	"Success"
+//	@Failure		400				{object}	errorResponse					"Validation error"
+//	@Failure		500				{object}	errorResponse					"Internal server error"
+//	@Router			/locations/trending [get]
+//	@Security		BearerAuth
+func (ph *PopularityHandler) ListTrendingLocations(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	req := domain.TrendingLocationsRequest{
+		Category: query.Get("category"),
+	}
+
+	if value := query.Get("window"); value != "" {
+		window, ok := domain.ParseWindow(value)
+		if !ok {
+			handleError(w, domain.NewBadRequestCError("Invalid window, expected a duration such as 24h or 7d"))
+			return
+		}
+		req.Window = window
+	}
+
+	if value := query.Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil {
+			handleError(w, domain.NewBadRequestCError("limit must be a number"))
+			return
+		}
+		req.Limit = limit
+	}
+
+	lat, lng := query.Get("lat"), query.Get("lng")
+	if lat != "" || lng != "" {
+		latitude, err := strconv.ParseFloat(lat, 64)
+		if err != nil {
+			handleError(w, domain.NewBadRequestCError("Invalid latitude"))
+			return
+		}
+
+		longitude, err := strconv.ParseFloat(lng, 64)
+		if err != nil {
+			handleError(w, domain.NewBadRequestCError("Invalid longitude"))
+			return
+		}
+		req.Near = &domain.Point{Latitude: latitude, Longitude: longitude}
+	}
+
+	if value := query.Get("radius"); value != "" {
+		if req.Near == nil {
+			handleError(w, domain.NewBadRequestCError("radius requires lat and lng"))
+			return
+		}
+
+		radius, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			handleError(w, domain.NewBadRequestCError("radius must be a number"))
+			return
+		}
+		req.Radius = radius
+	}
+
+	if err := ph.validate.Struct(&req); err != nil {
+		validationError(w, err)
+		return
+	}
+
+	locations, cerr := ph.svc.FindTrendingLocations(r.Context(), &req)
+	if cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+
+	localize := localizer(w, r)
+	for i := range locations {
+		localize(&locations[i].Location)
+	}
+
+	handleSuccess(w, http.StatusOK, locations)
+}
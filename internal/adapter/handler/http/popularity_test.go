@@ -0,0 +1,77 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"leeta/internal/core/domain"
+	"leeta/internal/core/port/mock"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPopularityHandler_ListTrendingLocations(t *testing.T) {
+	svc := &mock.PopularityServiceMock{
+		FindTrendingLocationsFunc: func(ctx context.Context, req *domain.TrendingLocationsRequest) ([]domain.TrendingLocation, domain.CError) {
+			return []domain.TrendingLocation{{Location: *testLocation("Central Park", 40.7829, -73.9654), Hits: 42}}, nil
+		},
+	}
+	handler := NewPopularityHandler(svc, validator.New())
+
+	tests := []struct {
+		name   string
+		query  string
+		status int
+	}{
+		{"Success", "", http.StatusOK},
+		{"Success - Window in days near a point", "?window=7d&lat=40.78&lng=-73.96&radius=2000&limit=5", http.StatusOK},
+		{"Success - Window in hours", "?window=24h&category=park", http.StatusOK},
+		{"Error - Invalid window", "?window=week", http.StatusBadRequest},
+		{"Error - Negative window", "?window=-2d", http.StatusBadRequest},
+		{"Error - Latitude without longitude", "?lat=40.78", http.StatusBadRequest},
+		{"Error - Radius without a point", "?radius=2000", http.StatusBadRequest},
+		{"Error - Radius too large", "?lat=40.78&lng=-73.96&radius=200000", http.StatusBadRequest},
+		{"Error - Limit too large", "?limit=1000", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/locations/trending"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			handler.ListTrendingLocations(w, req)
+
+			assert.Equal(t, tt.status, w.Code, w.Body.String())
+		})
+	}
+
+	calls := svc.FindTrendingLocationsCalls()
+	require.Len(t, calls, 3)
+	assert.Zero(t, calls[0].Req.Window)
+	assert.Equal(t, 7*24*time.Hour, calls[1].Req.Window)
+	assert.Equal(t, &domain.Point{Latitude: 40.78, Longitude: -73.96}, calls[1].Req.Near)
+	assert.Equal(t, 2000.0, calls[1].Req.Radius)
+	assert.Equal(t, 5, calls[1].Req.Limit)
+	assert.Equal(t, 24*time.Hour, calls[2].Req.Window)
+	assert.Equal(t, "park", calls[2].Req.Category)
+
+	t.Run("Hits in the response", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/locations/trending", nil)
+		w := httptest.NewRecorder()
+
+		handler.ListTrendingLocations(w, req)
+
+		var res dataResponse[any]
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+		data := res.Data.([]any)
+		require.Len(t, data, 1)
+		assert.Equal(t, "Central Park", data[0].(map[string]any)["name"])
+		assert.Equal(t, 42.0, data[0].(map[string]any)["hits"])
+	})
+}
@@ -0,0 +1,112 @@
+package http
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"leeta/internal/core/domain"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// bindQuery sets the fields of the struct v points to from the query parameters named by their query tag, then
+// validates it with their validate tag. Fields of the parameters left out keep their value, pointers stay nil so
+// the validation can tell them apart from zero values. Embedded structs are bound like the fields of v
+func bindQuery(r *http.Request, v any, validate *validator.Validate) domain.CError {
+	if cerr := bindValues(r.URL.Query(), reflect.ValueOf(v).Elem()); cerr != nil {
+		return cerr
+	}
+
+	if err := validate.Struct(v); err != nil {
+		return domain.NewBadRequestCError(strings.Join(parseError(err), " - "))
+	}
+	return nil
+}
+
+// bindValues sets the tagged fields of the struct s from values
+func bindValues(values map[string][]string, s reflect.Value) domain.CError {
+	for i := 0; i < s.NumField(); i++ {
+		field, value := s.Type().Field(i), s.Field(i)
+
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			if cerr := bindValues(values, value); cerr != nil {
+				return cerr
+			}
+			continue
+		}
+
+		name := field.Tag.Get("query")
+		if name == "" || len(values[name]) == 0 {
+			continue
+		}
+
+		if value.Kind() == reflect.Pointer {
+			value.Set(reflect.New(field.Type.Elem()))
+			value = value.Elem()
+		}
+
+		if err := setQueryValue(value, values[name][0]); err != nil {
+			return domain.NewBadRequestCError("Invalid " + fieldLabel(field.Name))
+		}
+	}
+
+	return nil
+}
+
+// errNotFinite is returned for the floats that are infinite or not a number, which no range check rejects
+var errNotFinite = errors.New("not a finite number")
+
+// setQueryValue parses s into value according to its kind
+func setQueryValue(value reflect.Value, s string) error {
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(s)
+
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		value.SetBool(b)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetInt(n)
+
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return errNotFinite
+		}
+		value.SetFloat(f)
+
+	default:
+		// a programming error, the tagged field has a kind no query parameter is parsed into
+		panic("bindQuery: unsupported field kind " + value.Kind().String())
+	}
+
+	return nil
+}
+
+// fieldLabel turns the name of a field into the words it is made of, e.g. max travel time for MaxTravelTime
+func fieldLabel(name string) string {
+	var label strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) && i > 0 {
+			label.WriteByte(' ')
+		}
+		label.WriteRune(unicode.ToLower(r))
+	}
+	return label.String()
+}
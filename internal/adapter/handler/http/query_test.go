@@ -0,0 +1,65 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBindQuery(t *testing.T) {
+	radius := 2.5
+	type pageQuery struct {
+		Limit int `query:"limit" validate:"omitempty,min=1,max=100"`
+	}
+	type testQuery struct {
+		pageQuery
+		Name     string   `query:"name" validate:"omitempty,max=10"`
+		Radius   *float64 `query:"radius" validate:"omitempty,gt=0"`
+		Verbose  bool     `query:"verbose"`
+		Untagged string
+	}
+
+	tests := []struct {
+		name    string
+		query   string
+		want    testQuery
+		message string
+	}{
+		{"Empty", "", testQuery{}, ""},
+		{"Every field", "name=lagos&radius=2.5&verbose=true&limit=5&Untagged=x", testQuery{
+			pageQuery: pageQuery{Limit: 5}, Name: "lagos", Radius: &radius, Verbose: true,
+		}, ""},
+		{"Not a number", "limit=ten", testQuery{}, "Invalid limit"},
+		{"Not a boolean", "verbose=maybe", testQuery{}, "Invalid verbose"},
+		{"Not finite", "radius=NaN", testQuery{}, "Invalid radius"},
+		{"Out of range", "limit=1000", testQuery{}, "Key: 'testQuery.pageQuery.Limit' Error:Field validation for 'Limit' failed on the 'max' tag"},
+		{"Zero pointer is validated", "radius=0", testQuery{}, "Key: 'testQuery.Radius' Error:Field validation for 'Radius' failed on the 'gt' tag"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/?"+tt.query, nil)
+
+			var got testQuery
+			cerr := bindQuery(req, &got, validator.New())
+			if tt.message != "" {
+				require.NotNil(t, cerr)
+				assert.Equal(t, http.StatusBadRequest, cerr.Code())
+				assert.Equal(t, tt.message, cerr.Error())
+				return
+			}
+
+			require.Nil(t, cerr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFieldLabel(t *testing.T) {
+	assert.Equal(t, "latitude", fieldLabel("Latitude"))
+	assert.Equal(t, "max travel time", fieldLabel("MaxTravelTime"))
+}
@@ -0,0 +1,97 @@
+package http
+
+import (
+	"net/http"
+
+	"leeta/internal/core/domain"
+	"leeta/internal/core/port"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+)
+
+// QuotaHandler represents the HTTP handler for the quotas of the tenants
+type QuotaHandler struct {
+	svc      port.QuotaService
+	validate *validator.Validate
+}
+
+// NewQuotaHandler creates a new QuotaHandler instance
+func NewQuotaHandler(svc port.QuotaService, vld *validator.Validate) *QuotaHandler {
+	return &QuotaHandler{
+		svc,
+		vld,
+	}
+}
+
+// GetQuota godoc
+//
+//	@Summary		Get the quota of a tenant
+//	@Description	get the number of locations and webhooks a tenant may have and how many it has
+//	@Tags			Admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			tenant	path		string			true	"Tenant"
+//	@Success		200		{object}	quotaResponse	"Success"
+//	@Failure		401		{object}	errorResponse	"Unauthorized error"
+//	@Failure		500		{object}	errorResponse	"Internal server error"
+//	@Router			/admin/quotas/{tenant} [get]
+//	@Security		BearerAuth
+func (qh *QuotaHandler) GetQuota(w http.ResponseWriter, r *http.Request) {
+	tenant := chi.URLParam(r, "tenant")
+	if tenant == "" {
+		handleError(w, domain.NewBadRequestCError("Invalid tenant"))
+		return
+	}
+
+	quota, cerr := qh.svc.GetQuota(r.Context(), tenant)
+	if cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+
+	handleSuccess(w, http.StatusOK, quota)
+}
+
+// SetQuota godoc
+//
+//	@Summary		Set the quota of a tenant
+//	@Description	set the number of locations and webhooks a tenant may have, a limit left out or null is the default one of the configuration and 0 lifts it. The resources a tenant already has are kept when it is lowered
+//	@Tags			Admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			tenant					path		string					true	"Tenant"
+//	@Param			domain.SetQuotaRequest	body		domain.SetQuotaRequest	true	"Quota"
+//	@Success		200						{object}	quotaResponse			"Quota set"
+//	@Failure		400						{object}	errorResponse			"Validation error"
+//	@Failure		401						{object}	errorResponse			"Unauthorized error"
+//	@Failure		413						{object}	errorResponse			"Request body too large"
+//	@Failure		500						{object}	errorResponse			"Internal server error"
+//	@Router			/admin/quotas/{tenant} [put]
+//	@Security		BearerAuth
+func (qh *QuotaHandler) SetQuota(w http.ResponseWriter, r *http.Request) {
+	tenant := chi.URLParam(r, "tenant")
+	if tenant == "" {
+		handleError(w, domain.NewBadRequestCError("Invalid tenant"))
+		return
+	}
+
+	var req domain.SetQuotaRequest
+	if cerr := decodeJSON(r, &req); cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+
+	if err := qh.validate.Struct(&req); err != nil {
+		validationError(w, err)
+		return
+	}
+
+	quota, cerr := qh.svc.SetQuota(r.Context(), tenant, &req)
+	if cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+
+	handleSuccessWithMessage(w, http.StatusOK, quota, "Quota set")
+}
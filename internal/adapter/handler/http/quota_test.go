@@ -0,0 +1,58 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"leeta/internal/core/domain"
+	"leeta/internal/core/port/mock"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuotaHandler_SetQuota(t *testing.T) {
+	svc := &mock.QuotaServiceMock{
+		SetQuotaFunc: func(ctx context.Context, tenant string, req *domain.SetQuotaRequest) (*domain.Quota, domain.CError) {
+			return &domain.Quota{Tenant: tenant}, nil
+		},
+	}
+	handler := NewQuotaHandler(svc, validator.New())
+
+	r := chi.NewRouter()
+	r.Put("/admin/quotas/{tenant}", handler.SetQuota)
+
+	tests := []struct {
+		name   string
+		body   string
+		status int
+	}{
+		{"Success", `{"max_locations": 100, "max_webhooks": 0}`, http.StatusOK},
+		{"Success - Defaults", `{}`, http.StatusOK},
+		{"Error - Negative limit", `{"max_locations": -1}`, http.StatusBadRequest},
+		{"Error - Invalid JSON", `{"max_locations": "many"}`, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPut, "/admin/quotas/acme", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.status, w.Code, w.Body.String())
+		})
+	}
+
+	calls := svc.SetQuotaCalls()
+	require.Len(t, calls, 2)
+	assert.Equal(t, "acme", calls[0].Tenant)
+	assert.Equal(t, 100, *calls[0].Req.MaxLocations)
+	assert.Equal(t, 0, *calls[0].Req.MaxWebhooks)
+	assert.Nil(t, calls[1].Req.MaxLocations)
+}
@@ -0,0 +1,120 @@
+package http
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+
+	"leeta/internal/adapter/config"
+	"leeta/internal/adapter/logger"
+	"leeta/internal/core/domain"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+)
+
+var throttledRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "leeta",
+	Subsystem: "http",
+	Name:      "throttled_requests_total",
+	Help:      "Requests rejected with 429 Too Many Requests, by rate limit.",
+}, []string{"limit"})
+
+/**
+ * rateLimiter is a token bucket shared by every request going
+ * through it. Its rate and burst can be changed while the server runs
+ */
+type rateLimiter struct {
+	name    string
+	limiter *rate.Limiter
+}
+
+// newRateLimiter creates a new rate limiter named after the routes it guards
+func newRateLimiter(name string, limit config.RateLimit) *rateLimiter {
+	rl := &rateLimiter{
+		name,
+		rate.NewLimiter(rate.Inf, 0),
+	}
+	rl.set(limit)
+
+	return rl
+}
+
+// set changes the rate and burst of the limiter, a rate of 0 lifts the limit
+func (rl *rateLimiter) set(limit config.RateLimit) {
+	if limit.Rate <= 0 {
+		rl.limiter.SetLimit(rate.Inf)
+		return
+	}
+
+	burst := limit.Burst
+	if burst <= 0 {
+		burst = max(1, int(math.Ceil(limit.Rate)))
+	}
+
+	rl.limiter.SetBurst(burst)
+	rl.limiter.SetLimit(rate.Limit(limit.Rate))
+}
+
+// limit rejects the requests exceeding the rate with 429 Too Many Requests and a Retry-After header
+func (rl *rateLimiter) limit(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reservation := rl.limiter.Reserve()
+		if delay := reservation.Delay(); !reservation.OK() || delay > 0 {
+			reservation.Cancel()
+
+			retryAfter := 1
+			if reservation.OK() {
+				retryAfter = max(retryAfter, int(math.Ceil(delay.Seconds())))
+			}
+
+			throttledRequests.WithLabelValues(rl.name).Inc()
+			logger.FromCtx(r.Context()).Warn("Request throttled",
+				zap.String("limit", rl.name), zap.Int("retry_after", retryAfter))
+
+			w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+			handleError(w, domain.ErrTooManyRequests)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+/**
+ * rateLimits holds the global rate limit and the limits of
+ * the route groups, cheap routes get generous limits while
+ * expensive ones such as imports get tight ones
+ */
+type rateLimits struct {
+	global    *rateLimiter
+	locations *rateLimiter
+	nearest   *rateLimiter
+	export    *rateLimiter
+	imports   *rateLimiter
+	geocode   *rateLimiter
+}
+
+// newRateLimits creates the rate limiters of config
+func newRateLimits(config *config.RateLimitConfiguration) *rateLimits {
+	return &rateLimits{
+		newRateLimiter("global", config.Global),
+		newRateLimiter("locations", config.Locations),
+		newRateLimiter("nearest", config.Nearest),
+		newRateLimiter("export", config.Export),
+		newRateLimiter("import", config.Import),
+		newRateLimiter("geocode", config.Geocode),
+	}
+}
+
+// set changes every rate limiter to its limit in config
+func (rl *rateLimits) set(config *config.RateLimitConfiguration) {
+	rl.global.set(config.Global)
+	rl.locations.set(config.Locations)
+	rl.nearest.set(config.Nearest)
+	rl.export.set(config.Export)
+	rl.imports.set(config.Import)
+	rl.geocode.set(config.Geocode)
+}
@@ -0,0 +1,117 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"leeta/internal/adapter/config"
+	"leeta/internal/core/errcode"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// serveLimited sends a request from addr through handler and returns the recorded response
+func serveLimited(handler http.Handler, addr string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/v1/locations/", nil)
+	req.RemoteAddr = addr
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	return w
+}
+
+func TestRateLimiter_Limit(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) })
+
+	tests := []struct {
+		name       string
+		limit      config.RateLimit
+		requests   int
+		allowed    int
+		retryAfter string
+	}{
+		{"No limit", config.RateLimit{}, 50, 50, ""},
+		{"Burst allowed at once", config.RateLimit{Rate: 1, Burst: 3}, 5, 3, "1"},
+		{"Burst defaults to the rate rounded up", config.RateLimit{Rate: 2.5}, 5, 3, "1"},
+		{"Retry-After rounded up to the next token", config.RateLimit{Rate: 0.4, Burst: 1}, 2, 1, "3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := newRateLimiter("test", tt.limit).limit(ok)
+
+			allowed := 0
+			var last *httptest.ResponseRecorder
+			for range tt.requests {
+				last = serveLimited(handler, "192.0.2.1:1234")
+				if last.Code == http.StatusOK {
+					allowed++
+				}
+			}
+
+			assert.Equal(t, tt.allowed, allowed)
+			assert.Equal(t, tt.retryAfter, last.Header().Get("Retry-After"))
+		})
+	}
+}
+
+func TestRateLimiter_Throttled(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) })
+	handler := newRateLimiter("throttled-test", config.RateLimit{Rate: 1, Burst: 1}).limit(ok)
+	throttled := throttledRequests.WithLabelValues("throttled-test")
+	before := testutil.ToFloat64(throttled)
+
+	assert.Equal(t, http.StatusOK, serveLimited(handler, "192.0.2.1:1234").Code)
+
+	w := serveLimited(handler, "192.0.2.1:1234")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "1", w.Header().Get("Retry-After"))
+
+	var res errorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.False(t, res.Success)
+	assert.Equal(t, errcode.RateLimited, res.Code)
+	assert.Equal(t, before+1, testutil.ToFloat64(throttled), "throttled requests are counted by limit")
+
+	// the limit is one of the instance, shared by every client rather than kept per client
+	w = serveLimited(handler, "198.51.100.7:4321")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, before+2, testutil.ToFloat64(throttled))
+}
+
+func TestRateLimits_Set(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) })
+	limits := newRateLimits(&config.RateLimitConfiguration{})
+
+	// a route goes through the global limit and the limit of its group
+	handler := limits.global.limit(limits.nearest.limit(ok))
+	for range 20 {
+		require.Equal(t, http.StatusOK, serveLimited(handler, "192.0.2.1:1234").Code, "no limit is set")
+	}
+
+	t.Run("A new limit applies to the live handlers", func(t *testing.T) {
+		limits.set(&config.RateLimitConfiguration{Nearest: config.RateLimit{Rate: 1, Burst: 2}})
+
+		assert.Equal(t, http.StatusOK, serveLimited(handler, "192.0.2.1:1234").Code)
+		assert.Equal(t, http.StatusOK, serveLimited(handler, "192.0.2.1:1234").Code)
+		assert.Equal(t, http.StatusTooManyRequests, serveLimited(handler, "192.0.2.1:1234").Code)
+	})
+
+	t.Run("The global limit applies on top of the group", func(t *testing.T) {
+		limits.set(&config.RateLimitConfiguration{Global: config.RateLimit{Rate: 1, Burst: 1}})
+
+		assert.Equal(t, http.StatusOK, serveLimited(handler, "192.0.2.1:1234").Code)
+		assert.Equal(t, http.StatusTooManyRequests, serveLimited(handler, "192.0.2.1:1234").Code)
+	})
+
+	t.Run("A rate of 0 lifts the limit", func(t *testing.T) {
+		limits.set(&config.RateLimitConfiguration{})
+
+		for range 20 {
+			assert.Equal(t, http.StatusOK, serveLimited(handler, "192.0.2.1:1234").Code)
+		}
+	})
+}
@@ -0,0 +1,63 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"runtime/debug"
+
+	"leeta/internal/adapter/logger"
+	"leeta/internal/core/domain"
+	"leeta/internal/core/port"
+
+	"go.uber.org/zap"
+)
+
+// recoverer answers the requests whose handler panics with a JSON 500 Internal Server Error carrying the
+// request id, so users can quote it to support. The panic is logged with its stack and the request id, and
+// reported to the operators with notifier unless it is nil
+func recoverer(notifier port.Notifier) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer func() {
+				rvr := recover()
+				if rvr == nil {
+					return
+				}
+				// the handler aborts the response on purpose, the server handles it
+				if rvr == http.ErrAbortHandler {
+					panic(rvr)
+				}
+
+				ctx := r.Context()
+				requestID, _ := ctx.Value(requestIDCtxKey).(string)
+
+				logger.FromCtx(ctx).Error("Recovered from panic",
+					zap.Any("panic", rvr),
+					zap.String("method", r.Method),
+					zap.String("url", r.RequestURI),
+					zap.ByteString("stack", debug.Stack()),
+				)
+
+				if notifier != nil {
+					notifier.Notify(ctx, &domain.Notification{
+						Type:  domain.NotificationPanic,
+						Title: "Request panicked",
+						Text:  fmt.Sprint(rvr),
+						Fields: []domain.NotificationField{
+							{Name: "Request", Value: r.Method + " " + r.URL.Path},
+							{Name: "Request ID", Value: requestID},
+						},
+					})
+				}
+
+				// an upgraded connection, such as a websocket, has no response left to write
+				if r.Header.Get("Connection") == "Upgrade" {
+					return
+				}
+				handleError(w, domain.ErrInternal)
+			}()
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"leeta/internal/core/domain"
+	"leeta/internal/core/errcode"
+	"leeta/internal/core/port/mock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecoverer(t *testing.T) {
+	notifier := &mock.NotifierMock{
+		NotifyFunc: func(ctx context.Context, notification *domain.Notification) {},
+	}
+
+	handler := recoverer(notifier)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("nil map")
+	}))
+
+	// as set by requestLogger
+	req := httptest.NewRequest(http.MethodGet, "/v1/locations", nil)
+	req = req.WithContext(context.WithValue(req.Context(), requestIDCtxKey, "support-42"))
+	w := httptest.NewRecorder()
+	w.Header().Set(requestIDHeader, "support-42")
+
+	handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+	var res errorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.False(t, res.Success)
+	assert.Equal(t, "support-42", res.RequestID)
+	assert.Equal(t, errcode.Internal, res.Code)
+
+	require.Len(t, notifier.NotifyCalls(), 1)
+	notification := notifier.NotifyCalls()[0].Notification
+	assert.Equal(t, domain.NotificationPanic, notification.Type)
+	assert.Equal(t, "nil map", notification.Text)
+	assert.Contains(t, notification.Fields, domain.NotificationField{Name: "Request ID", Value: "support-42"})
+}
@@ -0,0 +1,297 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"leeta/internal/core/domain"
+	"leeta/internal/core/olc"
+
+	"golang.org/x/text/language"
+)
+
+const (
+	// defaultMaxBodySize bounds request bodies when no limit is configured
+	defaultMaxBodySize int64 = 1 << 20
+	// defaultMaxImportBodySize bounds import bodies when no limit is configured
+	defaultMaxImportBodySize int64 = 32 << 20
+)
+
+// bodyLimit rejects the requests whose body is larger than limit with 413 Request Entity Too Large.
+// The body is cut off while it is read, so handlers see the error from their decoder
+func bodyLimit(limit int64) func(http.Handler) http.Handler {
+	if limit <= 0 {
+		limit = defaultMaxBodySize
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.ContentLength > limit {
+				handleError(w, domain.ErrPayloadTooLarge)
+				return
+			}
+
+			r.Body = http.MaxBytesReader(w, r.Body, limit)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// decodeJSON decodes the request body into v. Unknown fields and trailing data are rejected,
+// so typos in field names are reported instead of silently ignored
+func decodeJSON(r *http.Request, v any) domain.CError {
+	return decodeJSONBody(r.Body, v)
+}
+
+// decodeJSONBody decodes body into v like decodeJSON, for bodies read through another reader
+func decodeJSONBody(body io.Reader, v any) domain.CError {
+	decoder := json.NewDecoder(body)
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(v); err != nil {
+		return decodeError(err)
+	}
+
+	if decoder.More() {
+		return domain.NewBadRequestCError("Request body must contain a single JSON value")
+	}
+
+	return nil
+}
+
+// decodeError turns a JSON decoding error into a client error describing what is wrong with the body
+func decodeError(err error) domain.CError {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	var maxBytesErr *http.MaxBytesError
+
+	switch {
+	case errors.As(err, &maxBytesErr):
+		return domain.ErrPayloadTooLarge
+	case errors.As(err, &syntaxErr):
+		return domain.NewBadRequestCError(fmt.Sprintf("Malformed JSON at position %d", syntaxErr.Offset))
+	case errors.As(err, &typeErr):
+		if typeErr.Field == "" {
+			return domain.NewBadRequestCError(fmt.Sprintf("Invalid request body, expected %s", typeErr.Type))
+		}
+		return domain.NewBadRequestCError(fmt.Sprintf("Invalid value for field %q, expected %s", typeErr.Field, typeErr.Type))
+	case errors.Is(err, io.EOF):
+		return domain.NewBadRequestCError("Request body is empty")
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return domain.NewBadRequestCError("Malformed JSON, the body ended unexpectedly")
+	}
+
+	// encoding/json has no error type for unknown fields
+	if field, ok := strings.CutPrefix(err.Error(), "json: unknown field "); ok {
+		return domain.NewBadRequestCError("Unknown field " + field)
+	}
+
+	return domain.NewBadRequestCError("Invalid request body")
+}
+
+// resolvePlusCode sets the coordinates to the center of the area of a full plus code, they are left as
+// is without a code. Coordinates sent along with a code are rejected since they could disagree
+func resolvePlusCode(code string, latitude, longitude *float64) domain.CError {
+	if code == "" {
+		return nil
+	}
+	if *latitude != 0 || *longitude != 0 {
+		return domain.NewBadRequestCError("Send either a plus code or coordinates, not both")
+	}
+
+	area, err := olc.Decode(code)
+	if errors.Is(err, olc.ErrShortCode) {
+		return domain.NewBadRequestCError("Plus code is short, send the full code such as 6FR5G9JC+XH")
+	}
+	if err != nil {
+		return domain.NewBadRequestCError("Invalid plus code")
+	}
+
+	*latitude, *longitude = area.Center()
+	return nil
+}
+
+// checkFootprint rejects a footprint whose rings are not closed or out of range, nil footprints are left out
+func checkFootprint(footprint *domain.Polygon) domain.CError {
+	if footprint == nil {
+		return nil
+	}
+	if err := footprint.CheckRings(); err != nil {
+		return domain.NewBadRequestCError(err.Error())
+	}
+	return nil
+}
+
+// checkValidity rejects a validity that ends before it starts
+func checkValidity(from, until *time.Time) domain.CError {
+	if err := domain.CheckValidity(from, until); err != nil {
+		return domain.NewBadRequestCError(err.Error())
+	}
+	return nil
+}
+
+// queryBool reads a boolean query parameter, false when it is left out
+func queryBool(r *http.Request, name string) (bool, domain.CError) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return false, nil
+	}
+
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, domain.NewBadRequestCError(name + " must be true or false")
+	}
+	return b, nil
+}
+
+// queryPoint reads a point from the plus_code query parameter, or from the lat and lng ones without it
+func queryPoint(r *http.Request) (float64, float64, domain.CError) {
+	query := r.URL.Query()
+
+	if code := query.Get("plus_code"); code != "" {
+		if query.Has("lat") || query.Has("lng") {
+			return 0, 0, domain.NewBadRequestCError("Send either a plus code or coordinates, not both")
+		}
+
+		var latitude, longitude float64
+		if cerr := resolvePlusCode(code, &latitude, &longitude); cerr != nil {
+			return 0, 0, cerr
+		}
+		return latitude, longitude, nil
+	}
+
+	latitude, err := strconv.ParseFloat(query.Get("lat"), 64)
+	if err != nil {
+		return 0, 0, domain.NewBadRequestCError("Invalid latitude")
+	}
+
+	longitude, err := strconv.ParseFloat(query.Get("lng"), 64)
+	if err != nil {
+		return 0, 0, domain.NewBadRequestCError("Invalid longitude")
+	}
+
+	return latitude, longitude, nil
+}
+
+// pointQuery is a point sent in the query string, as coordinates or as a full plus code
+type pointQuery struct {
+	Latitude  *float64 `query:"lat" validate:"required_without=PlusCode,omitempty,min=-90,max=90"`
+	Longitude *float64 `query:"lng" validate:"required_without=PlusCode,omitempty,min=-180,max=180"`
+	PlusCode  string   `query:"plus_code"`
+}
+
+// point returns the coordinates of the point, the center of the area of the plus code when it has one
+func (q *pointQuery) point() (float64, float64, domain.CError) {
+	if q.PlusCode == "" {
+		return *q.Latitude, *q.Longitude, nil
+	}
+	if q.Latitude != nil || q.Longitude != nil {
+		return 0, 0, domain.NewBadRequestCError("Send either a plus code or coordinates, not both")
+	}
+
+	var latitude, longitude float64
+	if cerr := resolvePlusCode(q.PlusCode, &latitude, &longitude); cerr != nil {
+		return 0, 0, cerr
+	}
+	return latitude, longitude, nil
+}
+
+// mileRegions are the regions of the Accept-Language tags whose speakers prefer miles
+var mileRegions = map[string]bool{"US": true, "GB": true, "LR": true, "MM": true}
+
+// distanceUnit reads the unit of the formatted distances from the unit query parameter. Without one
+// the preference of the client is guessed from the region of its preferred language, e.g. miles for
+// en-US, and an empty unit is returned when there is no preference
+func distanceUnit(r *http.Request) (domain.DistanceUnit, domain.CError) {
+	if value := r.URL.Query().Get("unit"); value != "" {
+		unit, ok := domain.ParseDistanceUnit(value)
+		if !ok {
+			return "", domain.NewBadRequestCError("Invalid unit, expected m, km or mi")
+		}
+		return unit, nil
+	}
+
+	language, _, _ := strings.Cut(r.Header.Get("Accept-Language"), ",")
+	language, _, _ = strings.Cut(language, ";")
+	if _, region, ok := strings.Cut(strings.TrimSpace(language), "-"); ok {
+		if mileRegions[strings.ToUpper(region)] {
+			return domain.DistanceUnitMiles, nil
+		}
+		return domain.DistanceUnitKilometers, nil
+	}
+
+	return "", nil
+}
+
+// distanceFormula reads the formula distances are computed with from the formula query parameter,
+// it is empty when the parameter is missing
+func distanceFormula(r *http.Request) (domain.DistanceFormula, domain.CError) {
+	value := r.URL.Query().Get("formula")
+	if value == "" {
+		return "", nil
+	}
+
+	formula, ok := domain.ParseDistanceFormula(value)
+	if !ok {
+		return "", domain.NewBadRequestCError("Invalid formula, expected spheroid, haversine or vincenty")
+	}
+	return formula, nil
+}
+
+// locationSort reads the sort query parameter of r, empty if there is none
+func locationSort(r *http.Request) (domain.LocationSort, domain.CError) {
+	value := r.URL.Query().Get("sort")
+	if value == "" {
+		return "", nil
+	}
+
+	sort, ok := domain.ParseLocationSort(value)
+	if !ok {
+		return "", domain.NewBadRequestCError("Invalid sort, expected created_at or updated_at")
+	}
+	return sort, nil
+}
+
+// localizer returns a function replacing the name of a location with its translation best matching
+// the Accept-Language header of r. The canonical name is kept when no translation matches, and the
+// slug is never translated. Responses are marked as varying with Accept-Language
+func localizer(w http.ResponseWriter, r *http.Request) func(location *domain.Location) {
+	w.Header().Add("Vary", "Accept-Language")
+
+	// a malformed header is ignored like a missing one
+	accepted, _, err := language.ParseAcceptLanguage(r.Header.Get("Accept-Language"))
+	if err != nil || len(accepted) == 0 {
+		return func(*domain.Location) {}
+	}
+
+	return func(location *domain.Location) {
+		if len(location.Names) == 0 {
+			return
+		}
+
+		// sorted so the first locale, the fallback of the matcher, is the same for every request
+		locales := make([]string, 0, len(location.Names))
+		for locale := range location.Names {
+			locales = append(locales, locale)
+		}
+		slices.Sort(locales)
+
+		tags := make([]language.Tag, 0, len(locales))
+		for _, locale := range locales {
+			tags = append(tags, language.Make(locale))
+		}
+
+		_, index, confidence := language.NewMatcher(tags).Match(accepted...)
+		if confidence == language.No {
+			return
+		}
+		location.Name = location.Names[locales[index]]
+	}
+}
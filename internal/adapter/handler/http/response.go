@@ -5,31 +5,33 @@ import (
 	"errors"
 	"net/http"
 
+	"leeta/internal/adapter/validation"
 	"leeta/internal/core/domain"
+	"leeta/internal/core/errcode"
 
 	"github.com/go-playground/validator/v10"
 )
 
-// response represents a response body format
+// response is the body of the responses carrying a message only, such as those of deletions
 type response struct {
 	Success bool   `json:"success" example:"true"`
 	Message string `json:"message" example:"Success"`
-	Data    any    `json:"data,omitempty"`
 }
 
-// newResponse is a helper function to create a response body
-func newResponse(success bool, message string, data any) response {
-	return response{
-		Success: success,
-		Message: message,
-		Data:    data,
-	}
+// dataResponse is the body of the responses carrying data of type T. Swag cannot read generic types, so
+// endpoints document the named response of their data instead, see responses.go, which has the same fields
+type dataResponse[T any] struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+	Data    T      `json:"data,omitempty"`
 }
 
 // validationError sends an error response for some specific request validation error
 func validationError(w http.ResponseWriter, err error) {
 	errMsgs := parseError(err)
 	errRsp := newErrorResponse(errMsgs)
+	errRsp.Code = errcode.ValidationFailed
+	errRsp.RequestID = w.Header().Get(requestIDHeader)
 	w.WriteHeader(http.StatusBadRequest)
 	json.NewEncoder(w).Encode(errRsp)
 }
@@ -40,7 +42,7 @@ func parseError(err error) []string {
 
 	if errors.As(err, &validator.ValidationErrors{}) {
 		for _, err := range err.(validator.ValidationErrors) {
-			errMsgs = append(errMsgs, err.Error())
+			errMsgs = append(errMsgs, validation.Message(err))
 		}
 	} else {
 		errMsgs = append(errMsgs, err.Error())
@@ -53,6 +55,10 @@ func parseError(err error) []string {
 type errorResponse struct {
 	Success bool   `json:"success" example:"false"`
 	Message string `json:"message" example:"Error message 1 - Error message 2"`
+	// Code is the machine-readable code of the error, clients branch on it rather than on the message
+	Code errcode.Code `json:"code" example:"LOCATION_NOT_FOUND"`
+	// RequestID identifies the request in the server logs, it is worth quoting in bug reports
+	RequestID string `json:"request_id,omitempty" example:"cq3v1bb2ne1s73a0m2f0"`
 }
 
 // newErrorResponse is a helper function to create an error response body
@@ -72,26 +78,31 @@ func newErrorResponse(errMsgs []string) errorResponse {
 	}
 }
 
-// handleSuccess sends a success response with the specified status code and optional data
-func handleSuccess(w http.ResponseWriter, code int, data any) {
-	rsp := newResponse(true, "Success", data)
+// handleSuccess sends a success response with the specified status code and data
+func handleSuccess[T any](w http.ResponseWriter, code int, data T) {
+	handleSuccessWithMessage(w, code, data, "Success")
+}
+
+// handleSuccessWithMessage sends a success response with the specified status code, data and message
+func handleSuccessWithMessage[T any](w http.ResponseWriter, code int, data T, message string) {
 	w.WriteHeader(code)
-	json.NewEncoder(w).Encode(rsp)
+	json.NewEncoder(w).Encode(dataResponse[T]{true, message, data})
 }
 
-// handleSuccessWithMessage sends a success response with the specified status code, optional data and message
-func handleSuccessWithMessage(w http.ResponseWriter, code int, data any, message string) {
-	rsp := newResponse(true, message, data)
+// handleMessage sends a success response without data with the specified status code and message
+func handleMessage(w http.ResponseWriter, code int, message string) {
 	w.WriteHeader(code)
-	json.NewEncoder(w).Encode(rsp)
+	json.NewEncoder(w).Encode(response{true, message})
 }
 
 // handleError determines the status code of an error and returns a JSON response with the error message and status code
 func handleError(w http.ResponseWriter, err domain.CError) {
 	// TODO: Change the type of error received and the mech to get the code
 	statusCode := err.Code()
-	errMsg := parseError(err)
-	errRsp := newErrorResponse(errMsg)
+	// the message only, the cause of the error is for the logs
+	errRsp := newErrorResponse([]string{err.Message()})
+	errRsp.Code = err.ErrorCode()
+	errRsp.RequestID = w.Header().Get(requestIDHeader)
 	w.WriteHeader(statusCode)
 	json.NewEncoder(w).Encode(errRsp)
 }
@@ -0,0 +1,267 @@
+package http
+
+import (
+	"leeta/internal/core/domain"
+	"leeta/internal/core/errcode"
+)
+
+/**
+ * The named responses below document the body of the
+ * endpoints by their data in Swagger. Handlers send a
+ * dataResponse of the same data, which swag cannot read
+ */
+
+// locationResponse is the body of the responses carrying a location
+type locationResponse struct {
+	Success bool            `json:"success" example:"true"`
+	Message string          `json:"message" example:"Success"`
+	Data    domain.Location `json:"data"`
+}
+
+// locationListResponse is the body of the responses carrying locations
+type locationListResponse struct {
+	Success bool              `json:"success" example:"true"`
+	Message string            `json:"message" example:"Success"`
+	Data    []domain.Location `json:"data"`
+}
+
+// nearestLocationResponse is the body of the responses carrying the nearest location
+type nearestLocationResponse struct {
+	Success bool                   `json:"success" example:"true"`
+	Message string                 `json:"message" example:"Success"`
+	Data    domain.NearestLocation `json:"data"`
+}
+
+// nearestLocationListResponse is the body of the responses carrying the nearest locations
+type nearestLocationListResponse struct {
+	Success bool                     `json:"success" example:"true"`
+	Message string                   `json:"message" example:"Success"`
+	Data    []domain.NearestLocation `json:"data"`
+}
+
+// routeLocationListResponse is the body of the responses carrying the locations along a route
+type routeLocationListResponse struct {
+	Success bool                   `json:"success" example:"true"`
+	Message string                 `json:"message" example:"Success"`
+	Data    []domain.RouteLocation `json:"data"`
+}
+
+// locationGeometryResponse is the body of the responses carrying the geometry of locations
+type locationGeometryResponse struct {
+	Success bool                    `json:"success" example:"true"`
+	Message string                  `json:"message" example:"Success"`
+	Data    domain.LocationGeometry `json:"data"`
+}
+
+// locationLookupResponse is the body of the responses carrying the locations of a lookup
+type locationLookupResponse struct {
+	Success bool                  `json:"success" example:"true"`
+	Message string                `json:"message" example:"Success"`
+	Data    domain.LocationLookup `json:"data"`
+}
+
+// aliasResponse is the body of the responses carrying a location alias
+type aliasResponse struct {
+	Success bool                 `json:"success" example:"true"`
+	Message string               `json:"message" example:"Success"`
+	Data    domain.LocationAlias `json:"data"`
+}
+
+// aliasListResponse is the body of the responses carrying the aliases of a location
+type aliasListResponse struct {
+	Success bool                   `json:"success" example:"true"`
+	Message string                 `json:"message" example:"Success"`
+	Data    []domain.LocationAlias `json:"data"`
+}
+
+// importJobResponse is the body of the responses carrying an import job
+type importJobResponse struct {
+	Success bool             `json:"success" example:"true"`
+	Message string           `json:"message" example:"Success"`
+	Data    domain.ImportJob `json:"data"`
+}
+
+// importPreviewResponse is the body of the responses carrying the preview of an import
+type importPreviewResponse struct {
+	Success bool                 `json:"success" example:"true"`
+	Message string               `json:"message" example:"Success"`
+	Data    domain.ImportPreview `json:"data"`
+}
+
+// collectionResponse is the body of the responses carrying a collection
+type collectionResponse struct {
+	Success bool              `json:"success" example:"true"`
+	Message string            `json:"message" example:"Success"`
+	Data    domain.Collection `json:"data"`
+}
+
+// collectionListResponse is the body of the responses carrying collections
+type collectionListResponse struct {
+	Success bool                `json:"success" example:"true"`
+	Message string              `json:"message" example:"Success"`
+	Data    []domain.Collection `json:"data"`
+}
+
+// geofenceResponse is the body of the responses carrying a geofence
+type geofenceResponse struct {
+	Success bool            `json:"success" example:"true"`
+	Message string          `json:"message" example:"Success"`
+	Data    domain.Geofence `json:"data"`
+}
+
+// deviceResponse is the body of the responses carrying a device
+type deviceResponse struct {
+	Success bool          `json:"success" example:"true"`
+	Message string        `json:"message" example:"Success"`
+	Data    domain.Device `json:"data"`
+}
+
+// deviceListResponse is the body of the responses carrying devices
+type deviceListResponse struct {
+	Success bool            `json:"success" example:"true"`
+	Message string          `json:"message" example:"Success"`
+	Data    []domain.Device `json:"data"`
+}
+
+// entityPositionResponse is the body of the responses carrying the position of an entity
+type entityPositionResponse struct {
+	Success bool                  `json:"success" example:"true"`
+	Message string                `json:"message" example:"Success"`
+	Data    domain.EntityPosition `json:"data"`
+}
+
+// nearestEntityListResponse is the body of the responses carrying the nearest entities
+type nearestEntityListResponse struct {
+	Success bool                   `json:"success" example:"true"`
+	Message string                 `json:"message" example:"Success"`
+	Data    []domain.NearestEntity `json:"data"`
+}
+
+// subscriptionResponse is the body of the responses carrying a subscription
+type subscriptionResponse struct {
+	Success bool                `json:"success" example:"true"`
+	Message string              `json:"message" example:"Success"`
+	Data    domain.Subscription `json:"data"`
+}
+
+// jobResponse is the body of the responses carrying a job
+type jobResponse struct {
+	Success bool       `json:"success" example:"true"`
+	Message string     `json:"message" example:"Success"`
+	Data    domain.Job `json:"data"`
+}
+
+// jobListResponse is the body of the responses carrying jobs
+type jobListResponse struct {
+	Success bool         `json:"success" example:"true"`
+	Message string       `json:"message" example:"Success"`
+	Data    []domain.Job `json:"data"`
+}
+
+// syncReportResponse is the body of the responses carrying a sync report
+type syncReportResponse struct {
+	Success bool              `json:"success" example:"true"`
+	Message string            `json:"message" example:"Success"`
+	Data    domain.SyncReport `json:"data"`
+}
+
+// syncReportListResponse is the body of the responses carrying sync reports
+type syncReportListResponse struct {
+	Success bool                `json:"success" example:"true"`
+	Message string              `json:"message" example:"Success"`
+	Data    []domain.SyncReport `json:"data"`
+}
+
+// snapshotListResponse is the body of the responses carrying the snapshots of the scheduled exports
+type snapshotListResponse struct {
+	Success bool              `json:"success" example:"true"`
+	Message string            `json:"message" example:"Success"`
+	Data    []domain.Snapshot `json:"data"`
+}
+
+// restoreReportResponse is the body of the responses carrying the report of a restore
+type restoreReportResponse struct {
+	Success bool                 `json:"success" example:"true"`
+	Message string               `json:"message" example:"Success"`
+	Data    domain.RestoreReport `json:"data"`
+}
+
+// trendingLocationListResponse is the body of the responses carrying the trending locations
+type trendingLocationListResponse struct {
+	Success bool                      `json:"success" example:"true"`
+	Message string                    `json:"message" example:"Success"`
+	Data    []domain.TrendingLocation `json:"data"`
+}
+
+// locationSearchResponse is the body of the responses carrying the locations matching a search
+type locationSearchResponse struct {
+	Success bool                        `json:"success" example:"true"`
+	Message string                      `json:"message" example:"Success"`
+	Data    domain.LocationSearchResult `json:"data"`
+}
+
+// shareResponse is the body of the responses carrying a link sharing a location
+type shareResponse struct {
+	Success bool                 `json:"success" example:"true"`
+	Message string               `json:"message" example:"Success"`
+	Data    domain.LocationShare `json:"data"`
+}
+
+// shareListResponse is the body of the responses carrying the links sharing a location
+type shareListResponse struct {
+	Success bool                   `json:"success" example:"true"`
+	Message string                 `json:"message" example:"Success"`
+	Data    []domain.LocationShare `json:"data"`
+}
+
+// quotaResponse is the body of the responses carrying the quota of a tenant
+type quotaResponse struct {
+	Success bool         `json:"success" example:"true"`
+	Message string       `json:"message" example:"Success"`
+	Data    domain.Quota `json:"data"`
+}
+
+// addressSuggestionListResponse is the body of the responses carrying address suggestions
+type addressSuggestionListResponse struct {
+	Success bool                       `json:"success" example:"true"`
+	Message string                     `json:"message" example:"Success"`
+	Data    []domain.AddressSuggestion `json:"data"`
+}
+
+// pingResponse is the body of the responses carrying a ping
+type pingResponse struct {
+	Success bool        `json:"success" example:"true"`
+	Message string      `json:"message" example:"Success"`
+	Data    domain.Ping `json:"data"`
+}
+
+// pingStatsResponse is the body of the responses carrying the ping statistics
+type pingStatsResponse struct {
+	Success bool             `json:"success" example:"true"`
+	Message string           `json:"message" example:"Success"`
+	Data    domain.PingStats `json:"data"`
+}
+
+// healthReportResponse is the body of the responses carrying the health of the dependencies
+type healthReportResponse struct {
+	Success bool                `json:"success" example:"true"`
+	Message string              `json:"message" example:"Success"`
+	Data    domain.HealthReport `json:"data"`
+}
+
+// logLevelResponse is the body of the responses carrying the log level of the server
+type logLevelResponse struct {
+	Success bool           `json:"success" example:"true"`
+	Message string         `json:"message" example:"Success"`
+	Data    healthResponse `json:"data"`
+}
+
+// preconditionFailedResponse is the body of the responses to the changes whose precondition failed,
+// an error response carrying the current location
+type preconditionFailedResponse struct {
+	Success   bool            `json:"success" example:"false"`
+	Message   string          `json:"message" example:"the location changed since the version or date the request is based on"`
+	Code      errcode.Code    `json:"code" example:"PRECONDITION_FAILED"`
+	RequestID string          `json:"request_id,omitempty" example:"cq3v1bb2ne1s73a0m2f0"`
+	Data      domain.Location `json:"data"`
+}
@@ -0,0 +1,41 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"leeta/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The named responses must stay in step with what the handlers send, or the documentation lies
+func TestNamedResponses(t *testing.T) {
+	location := testLocation("Central Park", 40.7829, -73.9654)
+
+	tests := []struct {
+		name  string
+		data  any
+		named any
+	}{
+		{"Location", location, locationResponse{true, "Success", *location}},
+		{"Locations", []domain.Location{*location}, locationListResponse{true, "Success", []domain.Location{*location}}},
+		{"Aliases", []domain.LocationAlias{{Name: "JFK", Slug: "jfk"}}, aliasListResponse{true, "Success", []domain.LocationAlias{{Name: "JFK", Slug: "jfk"}}}},
+		{"Import job", &domain.ImportJob{ID: "42", Status: domain.ImportRunning}, importJobResponse{true, "Success", domain.ImportJob{ID: "42", Status: domain.ImportRunning}}},
+		{"Import preview", &domain.ImportPreview{Format: "csv", Total: 1, Valid: 1}, importPreviewResponse{true, "Success", domain.ImportPreview{Format: "csv", Total: 1, Valid: 1}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			handleSuccess(w, http.StatusOK, tt.data)
+
+			expected, err := json.Marshal(tt.named)
+			require.NoError(t, err)
+			assert.JSONEq(t, string(expected), w.Body.String())
+		})
+	}
+}
@@ -1,80 +1,339 @@
 package http
 
 import (
-	"strings"
+	"errors"
+	"net/http"
 
 	"leeta/internal/adapter/config"
+	"leeta/internal/core/port"
 
 	"go.uber.org/zap"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/cors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	httpSwagger "github.com/swaggo/http-swagger/v2"
 )
 
 // Router is a wrapper for HTTP router
 type Router struct {
 	chi.Router
+	admin   chi.Router
+	origins *allowedOrigins
+	limits  *rateLimits
 }
 
-// NewRouter creates a new HTTP router
+// NewRouter creates a new HTTP router, mounting each route in one of groups
 func NewRouter(
 	config *config.ServerConfiguration,
 	logger *zap.Logger,
+	notifier port.Notifier,
+	groups *RouteGroups,
 	pingHandler PingHandler,
 	locationHandler LocationHandler,
+	importHandler ImportHandler,
+	adminHandler AdminHandler,
+	deviceHandler DeviceHandler,
+	jobHandler JobHandler,
+	geofenceHandler GeofenceHandler,
+	popularityHandler PopularityHandler,
+	collectionHandler CollectionHandler,
+	entityHandler EntityHandler,
+	subscriptionHandler SubscriptionHandler,
+	geocodeHandler GeocodeHandler,
+	syncHandler SyncHandler,
+	exportHandler ExportHandler,
+	searchHandler SearchHandler,
+	quotaHandler QuotaHandler,
+	shareHandler ShareHandler,
+	tileHandler TileHandler,
 ) (*Router, error) {
 
 	// CORS
+	origins := newAllowedOrigins(config.HttpAllowedOrigins)
 	corsConfig := cors.Options{
-		AllowedOrigins:   []string{"https://*", "http://*"},
+		AllowOriginFunc:  origins.allow,
 		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token"},
-		ExposedHeaders:   []string{"Link"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token", "If-Match", "If-Unmodified-Since", "X-Request-ID"},
+		ExposedHeaders:   []string{"Link", "Deprecation", "Sunset", "ETag", "X-Request-ID"},
 		AllowCredentials: false,
 		MaxAge:           300, // Maximum value not ignored by any of major browsers
 	}
 
-	allowedOrigins := config.HttpAllowedOrigins
-	if allowedOrigins != "" {
-		originsList := strings.Split(config.HttpAllowedOrigins, ",")
-		corsConfig.AllowedOrigins = originsList
+	deprecations, err := parseDeprecations(config.Deprecations)
+	if err != nil {
+		return nil, err
+	}
+
+	cachePolicies, err := parseCachePolicies(config.CachePolicies)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.Moderation && config.AdminToken == "" && config.Auth.Secret == "" {
+		return nil, errors.New("moderation is enabled without an admin token or JWTs to review the submissions")
+	}
+
+	adminAccess, err := newIPAccess(&config.AdminAccess)
+	if err != nil {
+		return nil, err
+	}
+
+	accessLog, err := newAccessLog(&config.AccessLog)
+	if err != nil {
+		return nil, err
 	}
 
 	router := chi.NewRouter()
 	router.Use(cors.Handler(corsConfig))
 
 	// Logger
-	router.Use(requestLogger)
-	router.Use(middleware.Recoverer)
+	router.Use(requestLogger(accessLog))
+	router.Use(recoverer(notifier))
+
+	// Deprecated routes
+	router.Use(deprecationHeaders(deprecations))
+
+	// Caching headers
+	router.Use(cacheHeaders(cachePolicies))
+
+	// Admin listener, the admin routes are served by the public one unless they have a port of their own
+	admin := router
+	if config.AdminPort != "" {
+		admin = chi.NewRouter()
+		admin.Use(requestLogger(accessLog))
+		admin.Use(recoverer(notifier))
+	}
+
+	// Metrics, they are served to anyone reaching the admin listener, and only to the admins on the public one
+	if admin != router {
+		admin.Handle("/metrics", promhttp.Handler())
+	} else if config.AdminToken != "" || config.Auth.Secret != "" {
+		router.With(groups.Admin...).Handle("/metrics", promhttp.Handler())
+	}
+
+	// Debug
+	if config.Debug.Enabled {
+		if config.Debug.AdminToken == "" {
+			return nil, errors.New("debug endpoints are enabled without an admin token")
+		}
+
+		admin.Route("/debug", func(r chi.Router) {
+			r.Use(adminAccess.middleware)
+			r.Use(adminToken(config.Debug.AdminToken))
+			r.Mount("/", middleware.Profiler())
+		})
+	}
 
 	// Swagger
 	router.Get("/swagger/*", httpSwagger.Handler(
 		httpSwagger.URL("0.0.0.0:"+config.HttpPort+"/swagger/doc.json"), //The url pointing to API definition
 	))
 
+	// Body size limits, imports carry many locations so they get their own
+	maxImportBodySize := config.MaxImportBodySize
+	if maxImportBodySize <= 0 {
+		maxImportBodySize = defaultMaxImportBodySize
+	}
+	limitBody := bodyLimit(config.MaxBodySize)
+	limitImportBody := bodyLimit(maxImportBodySize)
+
+	// Rate limits
+	limits := newRateLimits(&config.RateLimits)
+
+	// Admin routes, served when there is a way to authenticate the admins
+	var adminRoutes func(r chi.Router)
+	if config.AdminToken != "" || config.Auth.Secret != "" {
+		adminRoutes = func(r chi.Router) {
+			r.Route("/admin", func(r chi.Router) {
+				r.Use(groups.Admin...)
+				r.With(limitBody).Put("/log-level", adminHandler.SetLogLevel)
+
+				r.Route("/jobs", func(r chi.Router) {
+					r.Get("/", jobHandler.ListJobs)
+					r.Get("/{id}", jobHandler.GetJob)
+					r.Post("/{id}/cancel", jobHandler.CancelJob)
+					r.Post("/{id}/retry", jobHandler.RetryJob)
+				})
+
+				r.Route("/syncs", func(r chi.Router) {
+					r.Get("/", syncHandler.ListSyncReports)
+					r.Get("/{id}", syncHandler.GetSyncReport)
+				})
+
+				r.Route("/quotas", func(r chi.Router) {
+					r.Get("/{tenant}", quotaHandler.GetQuota)
+					r.With(limitBody).Put("/{tenant}", quotaHandler.SetQuota)
+				})
+
+				r.Get("/exports", exportHandler.ListSnapshots)
+				r.With(limitBody).Post("/restore", exportHandler.RestoreSnapshot)
+
+				r.Route("/locations", func(r chi.Router) {
+					r.Get("/pending", locationHandler.ListPendingLocations)
+					r.Post("/{name}/approve", locationHandler.ApproveLocation)
+					r.With(limitBody).Post("/{name}/reject", locationHandler.RejectLocation)
+				})
+			})
+		}
+	}
+
+	// Admin UI, it calls the admin routes so it is only served along with them
+	if config.AdminUI {
+		if adminRoutes == nil {
+			return nil, errors.New("the admin UI is enabled without an admin token or JWTs to call the admin routes")
+		}
+
+		ui := admin.With(adminAccess.middleware)
+		ui.Get("/admin", http.RedirectHandler("/admin/", http.StatusMovedPermanently).ServeHTTP)
+		ui.Handle("/admin/*", adminUI())
+	}
+
 	// v1
 	router.Route("/v1", func(r chi.Router) {
+		r.Use(limits.global.limit)
 
 		// Ping
 		r.Route("/health", func(r chi.Router) {
+			r.Use(groups.Public...)
 			r.Get("/", pingHandler.PingGet)
-			r.Post("/", pingHandler.PingPost)
+			r.With(limitBody).Post("/", pingHandler.PingPost)
+			r.Get("/stats", pingHandler.PingStats)
+			r.Get("/dependencies", pingHandler.PingDependencies)
 		})
 
 		// Location
 		r.Route("/locations", func(r chi.Router) {
-			r.Post("/", locationHandler.RegisterLocation)
-			r.Get("/{name}", locationHandler.GetLocation)
-			r.Delete("/{name}", locationHandler.DeleteLocation)
-			r.Get("/", locationHandler.ListLocations)
-			r.Get("/nearest", locationHandler.GetNearestLocation)
+			r.Group(func(r chi.Router) {
+				r.Use(limits.locations.limit)
+				public, authenticated := r.With(groups.Public...), r.With(groups.Authenticated...)
+
+				if config.Moderation {
+					authenticated.With(limitBody, reviewSubmissions).Post("/", locationHandler.RegisterLocation)
+				} else {
+					authenticated.With(limitBody).Post("/", locationHandler.RegisterLocation)
+				}
+				public.Get("/{name}", locationHandler.GetLocation)
+				authenticated.With(limitBody).Put("/{name}", locationHandler.UpdateLocation)
+				authenticated.Delete("/{name}", locationHandler.DeleteLocation)
+				public.Get("/id/{id}", locationHandler.GetLocationByID)
+				authenticated.With(limitBody).Put("/id/{id}", locationHandler.UpdateLocationByID)
+				authenticated.Delete("/id/{id}", locationHandler.DeleteLocationByID)
+				authenticated.With(limitBody).Post("/{name}/aliases", locationHandler.CreateLocationAlias)
+				public.Get("/{name}/aliases", locationHandler.ListLocationAliases)
+				authenticated.Delete("/{name}/aliases/{alias}", locationHandler.DeleteLocationAlias)
+				authenticated.With(limitBody).Post("/{name}/share", shareHandler.ShareLocation)
+				authenticated.Get("/{name}/share", shareHandler.ListShares)
+				authenticated.Delete("/{name}/share/{id}", shareHandler.RevokeShare)
+				public.Get("/", locationHandler.ListLocations)
+				public.With(limitBody).Post("/lookup", locationHandler.LookupLocations)
+				public.Get("/trending", popularityHandler.ListTrendingLocations)
+				public.Get("/search", searchHandler.SearchLocations)
+				authenticated.Get("/import/{id}", importHandler.GetImportJob)
+			})
+			public, authenticated := r.With(groups.Public...), r.With(groups.Authenticated...)
+			public.With(limits.nearest.limit).Get("/nearest", locationHandler.GetNearestLocation)
+			public.With(limits.nearest.limit, limitBody).Post("/nearest", locationHandler.SearchNearestLocations)
+			public.With(limits.nearest.limit, limitBody).Post("/along-route", locationHandler.FindLocationsAlongRoute)
+			public.With(limits.nearest.limit, limitBody).Post("/geometry", locationHandler.GetLocationGeometry)
+			public.With(limits.export.limit).Get("/export", locationHandler.ExportLocations)
+			authenticated.With(limits.imports.limit, limitImportBody).Post("/import", importHandler.ImportLocations)
+		})
+
+		// Collection
+		r.Route("/collections", func(r chi.Router) {
+			r.Use(limits.locations.limit)
+			public, authenticated := r.With(groups.Public...), r.With(groups.Authenticated...)
+			authenticated.With(limitBody).Post("/", collectionHandler.CreateCollection)
+			public.Get("/", collectionHandler.ListCollections)
+			authenticated.Delete("/{name}", collectionHandler.DeleteCollection)
+			authenticated.Put("/{name}/locations/{location}", collectionHandler.AddCollectionLocation)
+			authenticated.Delete("/{name}/locations/{location}", collectionHandler.RemoveCollectionLocation)
+		})
+
+		// Device
+		r.Route("/devices", func(r chi.Router) {
+			public, authenticated := r.With(groups.Public...), r.With(groups.Authenticated...)
+			authenticated.With(limitBody).Post("/", deviceHandler.RegisterDevice)
+			public.Get("/stale", deviceHandler.ListStaleDevices)
+		})
+		r.With(groups.Authenticated...).With(limitBody).Post("/heartbeats", deviceHandler.Heartbeat)
+
+		// Entity
+		r.Route("/entities", func(r chi.Router) {
+			public, authenticated := r.With(groups.Public...), r.With(groups.Authenticated...)
+			authenticated.With(limitBody).Post("/{id}/positions", entityHandler.ReportPosition)
+			public.With(limits.nearest.limit).Get("/nearest", entityHandler.FindNearestEntities)
+		})
+
+		// Subscription
+		r.Route("/subscriptions", func(r chi.Router) {
+			r.Use(limits.locations.limit)
+			public, authenticated := r.With(groups.Public...), r.With(groups.Authenticated...)
+			authenticated.With(limitBody).Post("/", subscriptionHandler.CreateSubscription)
+			public.Get("/{id}", subscriptionHandler.GetSubscription)
+			authenticated.Delete("/{id}", subscriptionHandler.DeleteSubscription)
+			public.Get("/{id}/ws", subscriptionHandler.WatchSubscription)
+		})
+
+		// Geocode
+		r.Route("/geocode", func(r chi.Router) {
+			r.Use(groups.Public...)
+			r.With(limits.geocode.limit).Get("/autocomplete", geocodeHandler.Autocomplete)
 		})
 
+		// Geofence
+		r.Route("/geofences", func(r chi.Router) {
+			r.Use(limits.locations.limit)
+			public, authenticated := r.With(groups.Public...), r.With(groups.Authenticated...)
+			authenticated.With(limitBody).Post("/", geofenceHandler.CreateGeofence)
+			public.Get("/{name}/locations", geofenceHandler.ListGeofenceLocations)
+		})
+
+		// Tile
+		r.With(groups.Public...).With(limits.locations.limit).Get("/tiles/{z}/{x}/{y}.mvt", tileHandler.GetLocationTile)
+
+		// Shared locations, the token of the link grants access to its location
+		r.With(groups.Public...).With(limits.locations.limit).Get("/shared/{token}", shareHandler.GetSharedLocation)
+
+		// Admin
+		if adminRoutes != nil && admin == router {
+			adminRoutes(r)
+		}
 	})
 
+	// the admin router is only handed to the server when it has a listener of its own
+	var adminRouter chi.Router
+	if admin != router {
+		if adminRoutes != nil {
+			admin.Route("/v1", adminRoutes)
+		}
+		adminRouter = admin
+	}
+
 	return &Router{
 		router,
+		adminRouter,
+		origins,
+		limits,
 	}, nil
 }
+
+// Admin is the handler of the admin listener, nil when the admin routes are served with the public ones
+func (r *Router) Admin() http.Handler {
+	if r.admin == nil {
+		return nil
+	}
+	return r.admin
+}
+
+// SetRateLimits changes the rate limits to the ones of config
+func (r *Router) SetRateLimits(config *config.RateLimitConfiguration) {
+	r.limits.set(config)
+}
+
+// SetAllowedOrigins replaces the CORS allowed origins with the comma separated list
+func (r *Router) SetAllowedOrigins(list string) {
+	r.origins.set(list)
+}
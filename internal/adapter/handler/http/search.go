@@ -0,0 +1,83 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"leeta/internal/core/domain"
+	"leeta/internal/core/port"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// SearchHandler represents the HTTP handler for the location search
+type SearchHandler struct {
+	svc      port.SearchService
+	validate *validator.Validate
+}
+
+// NewSearchHandler creates a new SearchHandler instance
+func NewSearchHandler(svc port.SearchService, vld *validator.Validate) *SearchHandler {
+	return &SearchHandler{
+		svc,
+		vld,
+	}
+}
+
+// SearchLocations godoc
+//
+//	@Summary		Search locations
+//	@Description	search the locations by name, translated name or category, tolerating typos, and count the matches per category. The search engine is fed with the location events, so a change shows up shortly after it is made
+//	@Tags			Location
+//	@Accept			json
+//	@Produce		json
+//	@Param			q			query		string						true	"Text searched"
+//	@Param			category	query		[]string					false	"Only search the locations of these categories, repeated for several"	collectionFormat(multi)
+//	@Param			limit		query		int							false	"Number of locations, defaults to 20"
+//	@Param			offset		query		int							false	"Number of matching locations skipped"
+//	@Success		200			{object}	locationSearchResponse		"Success"
+//	@Failure		400			{object}	errorResponse				"Validation error"
+//	@Failure		429			{object}	errorResponse				"Too many requests"
+//	@Failure		500			{object}	errorResponse				"Internal server error"
+//	@Failure		501			{object}	errorResponse				"No search engine is configured"
+//	@Failure		503			{object}	errorResponse				"Search engine unavailable"
+//	@Router			/locations/search [get]
+//	@Security		BearerAuth
+func (sh *SearchHandler) SearchLocations(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	req := domain.SearchLocationsRequest{
+		Query:      query.Get("q"),
+		Categories: query["category"],
+	}
+
+	if value := query.Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil {
+			handleError(w, domain.NewBadRequestCError("limit must be a number"))
+			return
+		}
+		req.Limit = limit
+	}
+
+	if value := query.Get("offset"); value != "" {
+		offset, err := strconv.Atoi(value)
+		if err != nil {
+			handleError(w, domain.NewBadRequestCError("offset must be a number"))
+			return
+		}
+		req.Offset = offset
+	}
+
+	if err := sh.validate.Struct(&req); err != nil {
+		validationError(w, err)
+		return
+	}
+
+	result, cerr := sh.svc.SearchLocations(r.Context(), &req)
+	if cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+
+	handleSuccess(w, http.StatusOK, result)
+}
@@ -0,0 +1,57 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"leeta/internal/core/domain"
+	"leeta/internal/core/port/mock"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchHandler_SearchLocations(t *testing.T) {
+	svc := &mock.SearchServiceMock{
+		SearchLocationsFunc: func(ctx context.Context, req *domain.SearchLocationsRequest) (*domain.LocationSearchResult, domain.CError) {
+			if req.Query == "nowhere" {
+				return nil, domain.ErrSearchDisabled
+			}
+			return &domain.LocationSearchResult{Locations: []domain.Location{{ID: "1", Name: "Lekki Depot"}}, Total: 1}, nil
+		},
+	}
+	handler := NewSearchHandler(svc, validator.New())
+
+	tests := []struct {
+		name   string
+		query  string
+		status int
+	}{
+		{"Success", "?q=leki", http.StatusOK},
+		{"Success - Categories", "?q=leki&category=depot&category=park&limit=5&offset=10", http.StatusOK},
+		{"Error - Missing query", "", http.StatusBadRequest},
+		{"Error - Limit too large", "?q=leki&limit=500", http.StatusBadRequest},
+		{"Error - Invalid offset", "?q=leki&offset=ten", http.StatusBadRequest},
+		{"Error - Search disabled", "?q=nowhere", http.StatusNotImplemented},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/locations/search"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			handler.SearchLocations(w, req)
+
+			assert.Equal(t, tt.status, w.Code, w.Body.String())
+		})
+	}
+
+	calls := svc.SearchLocationsCalls()
+	require.Len(t, calls, 3)
+	assert.Equal(t, []string{"depot", "park"}, calls[1].Req.Categories)
+	assert.Equal(t, 5, calls[1].Req.Limit)
+	assert.Equal(t, 10, calls[1].Req.Offset)
+}
@@ -0,0 +1,204 @@
+package http
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"leeta/internal/adapter/config"
+
+	"golang.org/x/crypto/acme/autocert"
+)
+
+const (
+	defaultReadHeaderTimeout = 5 * time.Second
+	defaultReadTimeout       = 15 * time.Second
+	defaultWriteTimeout      = 30 * time.Second
+	defaultIdleTimeout       = 60 * time.Second
+
+	defaultAutocertCacheDir = "certs"
+)
+
+/**
+ * Server is a wrapper for the HTTP server that serves HTTPS
+ * when TLS is configured, along with the optional admin server
+ * and HTTP server redirecting to it
+ */
+type Server struct {
+	*http.Server
+	admin    *http.Server
+	redirect *http.Server
+	tls      bool
+	certFile string
+	keyFile  string
+}
+
+// NewServer creates the server serving handler with the configured address, timeouts and TLS.
+// adminHandler, if not nil, is served on the admin port with the same timeouts and TLS
+func NewServer(config *config.ServerConfiguration, handler, adminHandler http.Handler) (*Server, error) {
+	server := &Server{
+		Server: newHTTPServer(config, config.HttpUrl, config.HttpPort, handler),
+	}
+
+	if adminHandler != nil {
+		if config.AdminPort == "" {
+			return nil, errors.New("the admin routes are served on a listener of their own without an admin port")
+		}
+		adminUrl := config.AdminUrl
+		if adminUrl == "" {
+			adminUrl = config.HttpUrl
+		}
+		if adminUrl == config.HttpUrl && config.AdminPort == config.HttpPort {
+			return nil, errors.New("the admin port must differ from the HTTP port")
+		}
+		server.admin = newHTTPServer(config, adminUrl, config.AdminPort, adminHandler)
+	}
+
+	tlsConfig := &config.TLS
+	if tlsConfig.Autocert && tlsConfig.CertFile != "" {
+		return nil, errors.New("tls: set either a certificate file or autocert, not both")
+	}
+	if !tlsConfig.Autocert && tlsConfig.CertFile == "" {
+		return server, nil
+	}
+	server.tls = true
+	server.certFile = tlsConfig.CertFile
+	server.keyFile = tlsConfig.KeyFile
+
+	// challenge answers the ACME HTTP-01 challenges on the redirect server, if any
+	var challenge func(http.Handler) http.Handler
+
+	if tlsConfig.Autocert {
+		if len(tlsConfig.Domains) == 0 {
+			return nil, errors.New("tls: autocert needs at least one domain")
+		}
+
+		cacheDir := tlsConfig.CacheDir
+		if cacheDir == "" {
+			cacheDir = defaultAutocertCacheDir
+		}
+
+		manager := &autocert.Manager{
+			Prompt:     autocert.AcceptTOS,
+			HostPolicy: autocert.HostWhitelist(tlsConfig.Domains...),
+			Cache:      autocert.DirCache(cacheDir),
+			Email:      tlsConfig.Email,
+		}
+		server.TLSConfig = manager.TLSConfig()
+		challenge = manager.HTTPHandler
+	} else {
+		server.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	if server.admin != nil {
+		server.admin.TLSConfig = server.TLSConfig
+	}
+
+	if tlsConfig.RedirectPort != "" {
+		var redirect http.Handler = redirectToHTTPS(config.HttpPort)
+		if challenge != nil {
+			redirect = challenge(redirect)
+		}
+
+		server.redirect = &http.Server{
+			Addr:              fmt.Sprintf("%s:%s", config.HttpUrl, tlsConfig.RedirectPort),
+			Handler:           redirect,
+			ReadHeaderTimeout: server.ReadHeaderTimeout,
+			IdleTimeout:       server.IdleTimeout,
+		}
+	}
+
+	return server, nil
+}
+
+// AdminAddr is the address of the admin server, empty when the admin routes are served with the public ones
+func (s *Server) AdminAddr() string {
+	if s.admin == nil {
+		return ""
+	}
+	return s.admin.Addr
+}
+
+// ListenAndServe serves HTTPS if TLS is configured and HTTP otherwise, along with
+// the admin and redirect servers. It returns the error of the first server that fails
+func (s *Server) ListenAndServe() error {
+	errs := make(chan error, 3)
+
+	if s.redirect != nil {
+		go func() {
+			errs <- s.redirect.ListenAndServe()
+		}()
+	}
+
+	if s.admin != nil {
+		go func() {
+			errs <- s.listenAndServe(s.admin)
+		}()
+	}
+
+	go func() {
+		errs <- s.listenAndServe(s.Server)
+	}()
+
+	return <-errs
+}
+
+// listenAndServe serves server over HTTPS if TLS is configured and HTTP otherwise
+func (s *Server) listenAndServe(server *http.Server) error {
+	if s.tls {
+		// with autocert both files are empty and certificates come from the TLS config
+		return server.ListenAndServeTLS(s.certFile, s.keyFile)
+	}
+	return server.ListenAndServe()
+}
+
+// Shutdown drains every server, see http.Server.Shutdown
+func (s *Server) Shutdown(ctx context.Context) error {
+	var adminErr, redirectErr error
+	if s.admin != nil {
+		adminErr = s.admin.Shutdown(ctx)
+	}
+	if s.redirect != nil {
+		redirectErr = s.redirect.Shutdown(ctx)
+	}
+
+	return errors.Join(s.Server.Shutdown(ctx), adminErr, redirectErr)
+}
+
+// newHTTPServer creates the server serving handler on host and port with the configured timeouts
+func newHTTPServer(config *config.ServerConfiguration, host, port string, handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:              fmt.Sprintf("%s:%s", host, port),
+		Handler:           handler,
+		ReadHeaderTimeout: orDefault(config.ReadHeaderTimeout, defaultReadHeaderTimeout),
+		ReadTimeout:       orDefault(config.ReadTimeout, defaultReadTimeout),
+		WriteTimeout:      orDefault(config.WriteTimeout, defaultWriteTimeout),
+		IdleTimeout:       orDefault(config.IdleTimeout, defaultIdleTimeout),
+	}
+}
+
+// redirectToHTTPS redirects requests to the same URL over HTTPS on httpsPort
+func redirectToHTTPS(httpsPort string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host, _, err := net.SplitHostPort(r.Host)
+		if err != nil {
+			host = r.Host
+		}
+		if httpsPort != "" && httpsPort != "443" {
+			host = net.JoinHostPort(host, httpsPort)
+		}
+
+		http.Redirect(w, r, "https://"+host+r.URL.RequestURI(), http.StatusPermanentRedirect)
+	})
+}
+
+// orDefault returns timeout, or fallback if it is unset
+func orDefault(timeout, fallback time.Duration) time.Duration {
+	if timeout <= 0 {
+		return fallback
+	}
+	return timeout
+}
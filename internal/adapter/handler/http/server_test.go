@@ -0,0 +1,36 @@
+package http
+
+import (
+	"net/http"
+	"testing"
+
+	"leeta/internal/adapter/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewServer_Admin(t *testing.T) {
+	handler := http.NotFoundHandler()
+
+	t.Run("Served with the public routes", func(t *testing.T) {
+		server, err := NewServer(&config.ServerConfiguration{HttpUrl: "0.0.0.0", HttpPort: "8080"}, handler, nil)
+		require.NoError(t, err)
+		assert.Empty(t, server.AdminAddr())
+	})
+
+	t.Run("Listener of its own", func(t *testing.T) {
+		server, err := NewServer(&config.ServerConfiguration{HttpUrl: "0.0.0.0", HttpPort: "8080", AdminPort: "9090"}, handler, handler)
+		require.NoError(t, err)
+		assert.Equal(t, "0.0.0.0:9090", server.AdminAddr())
+
+		server, err = NewServer(&config.ServerConfiguration{HttpUrl: "0.0.0.0", HttpPort: "8080", AdminUrl: "127.0.0.1", AdminPort: "8080"}, handler, handler)
+		require.NoError(t, err)
+		assert.Equal(t, "127.0.0.1:8080", server.AdminAddr())
+	})
+
+	t.Run("Same port as the public routes", func(t *testing.T) {
+		_, err := NewServer(&config.ServerConfiguration{HttpUrl: "0.0.0.0", HttpPort: "8080", AdminPort: "8080"}, handler, handler)
+		assert.Error(t, err)
+	})
+}
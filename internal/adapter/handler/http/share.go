@@ -0,0 +1,162 @@
+package http
+
+import (
+	"net/http"
+
+	"leeta/internal/core/domain"
+	"leeta/internal/core/port"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+)
+
+// ShareHandler represents the HTTP handler for the links sharing a location
+type ShareHandler struct {
+	svc      port.ShareService
+	validate *validator.Validate
+}
+
+// NewShareHandler creates a new ShareHandler instance
+func NewShareHandler(svc port.ShareService, vld *validator.Validate) *ShareHandler {
+	return &ShareHandler{
+		svc,
+		vld,
+	}
+}
+
+// ShareLocation godoc
+//
+//	@Summary		Share a location
+//	@Description	create a signed link granting read access to the location without authentication until it expires, 24 hours after by default, or is revoked. The body may be left out
+//	@Tags			Location
+//	@Accept			json
+//	@Produce		json
+//	@Param			name							path		string							true	"Location name"
+//	@Param			domain.ShareLocationRequest		body		domain.ShareLocationRequest		false	"Lifetime of the link"
+//	@Success		201								{object}	shareResponse					"Location shared"
+//	@Failure		400								{object}	errorResponse					"Validation error"
+//	@Failure		401								{object}	errorResponse					"Unauthorized error"
+//	@Failure		404								{object}	errorResponse					"Not found error"
+//	@Failure		413								{object}	errorResponse					"Request body too large"
+//	@Failure		500								{object}	errorResponse					"Internal server error"
+//	@Failure		501								{object}	errorResponse					"No signing secret is configured"
+//	@Router			/locations/{name}/share [post]
+//	@Security		BearerAuth
+func (sh *ShareHandler) ShareLocation(w http.ResponseWriter, r *http.Request) {
+	name := chi.URLParam(r, "name")
+	if name == "" {
+		handleError(w, domain.NewBadRequestCError("Invalid location name"))
+		return
+	}
+
+	var req domain.ShareLocationRequest
+	if r.ContentLength != 0 {
+		if cerr := decodeJSON(r, &req); cerr != nil {
+			handleError(w, cerr)
+			return
+		}
+	}
+
+	if err := sh.validate.Struct(&req); err != nil {
+		validationError(w, err)
+		return
+	}
+
+	share, cerr := sh.svc.ShareLocation(r.Context(), name, &req)
+	if cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+
+	handleSuccessWithMessage(w, http.StatusCreated, share, "Location shared")
+}
+
+// ListShares godoc
+//
+//	@Summary		List the links to a location
+//	@Description	list the links sharing the location that are neither revoked nor expired, the latest first
+//	@Tags			Location
+//	@Accept			json
+//	@Produce		json
+//	@Param			name	path		string				true	"Location name"
+//	@Success		200		{object}	shareListResponse	"Success"
+//	@Failure		401		{object}	errorResponse		"Unauthorized error"
+//	@Failure		404		{object}	errorResponse		"Not found error"
+//	@Failure		500		{object}	errorResponse		"Internal server error"
+//	@Router			/locations/{name}/share [get]
+//	@Security		BearerAuth
+func (sh *ShareHandler) ListShares(w http.ResponseWriter, r *http.Request) {
+	name := chi.URLParam(r, "name")
+	if name == "" {
+		handleError(w, domain.NewBadRequestCError("Invalid location name"))
+		return
+	}
+
+	shares, cerr := sh.svc.ListShares(r.Context(), name)
+	if cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+
+	handleSuccess(w, http.StatusOK, shares)
+}
+
+// RevokeShare godoc
+//
+//	@Summary		Revoke a link to a location
+//	@Description	revoke a link sharing the location, it is refused from then on
+//	@Tags			Location
+//	@Accept			json
+//	@Produce		json
+//	@Param			name	path		string			true	"Location name"
+//	@Param			id		path		string			true	"Share ID"
+//	@Success		200		{object}	response		"Success"
+//	@Failure		401		{object}	errorResponse	"Unauthorized error"
+//	@Failure		404		{object}	errorResponse	"Not found error"
+//	@Failure		500		{object}	errorResponse	"Internal server error"
+//	@Router			/locations/{name}/share/{id} [delete]
+//	@Security		BearerAuth
+func (sh *ShareHandler) RevokeShare(w http.ResponseWriter, r *http.Request) {
+	name, id := chi.URLParam(r, "name"), chi.URLParam(r, "id")
+	if name == "" || id == "" {
+		handleError(w, domain.NewBadRequestCError("Invalid location name or share id"))
+		return
+	}
+
+	if cerr := sh.svc.RevokeShare(r.Context(), name, id); cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+
+	handleMessage(w, http.StatusOK, "Revoked share successfully")
+}
+
+// GetSharedLocation godoc
+//
+//	@Summary		Get a shared location
+//	@Description	get the location a share link points to, without authentication, whatever its review status or validity. The response is not to be cached, as the link may be revoked
+//	@Tags			Location
+//	@Accept			json
+//	@Produce		json
+//	@Param			token	path		string				true	"Token of the link"
+//	@Success		200		{object}	locationResponse	"Success"
+//	@Failure		404		{object}	errorResponse		"Unknown, expired or revoked link"
+//	@Failure		500		{object}	errorResponse		"Internal server error"
+//	@Failure		501		{object}	errorResponse		"No signing secret is configured"
+//	@Router			/shared/{token} [get]
+func (sh *ShareHandler) GetSharedLocation(w http.ResponseWriter, r *http.Request) {
+	token := chi.URLParam(r, "token")
+	if token == "" {
+		handleError(w, domain.NewBadRequestCError("Invalid token"))
+		return
+	}
+
+	location, cerr := sh.svc.GetSharedLocation(r.Context(), token)
+	if cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	handleSuccess(w, http.StatusOK, location)
+}
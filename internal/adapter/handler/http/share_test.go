@@ -0,0 +1,77 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"leeta/internal/core/domain"
+	"leeta/internal/core/port/mock"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShareHandler_ShareLocation(t *testing.T) {
+	svc := &mock.ShareServiceMock{
+		ShareLocationFunc: func(ctx context.Context, name string, req *domain.ShareLocationRequest) (*domain.LocationShare, domain.CError) {
+			return &domain.LocationShare{ID: "share-1"}, nil
+		},
+	}
+	handler := NewShareHandler(svc, validator.New())
+
+	r := chi.NewRouter()
+	r.Post("/locations/{name}/share", handler.ShareLocation)
+
+	tests := []struct {
+		name   string
+		body   string
+		status int
+	}{
+		{"Success", `{"expires_in": 3600}`, http.StatusCreated},
+		{"Success - No body", ``, http.StatusCreated},
+		{"Error - Lifetime too short", `{"expires_in": 5}`, http.StatusBadRequest},
+		{"Error - Unknown field", `{"ttl": 3600}`, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/locations/lekki-depot/share", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.status, w.Code, w.Body.String())
+		})
+	}
+
+	calls := svc.ShareLocationCalls()
+	require.Len(t, calls, 2)
+	assert.Equal(t, "lekki-depot", calls[0].Name)
+	assert.Equal(t, 3600, calls[0].Req.ExpiresIn)
+}
+
+func TestShareHandler_GetSharedLocation(t *testing.T) {
+	svc := &mock.ShareServiceMock{
+		GetSharedLocationFunc: func(ctx context.Context, token string) (*domain.Location, domain.CError) {
+			return &domain.Location{ID: "location-1"}, nil
+		},
+	}
+	handler := NewShareHandler(svc, validator.New())
+
+	r := chi.NewRouter()
+	r.Get("/shared/{token}", handler.GetSharedLocation)
+
+	req := httptest.NewRequest(http.MethodGet, "/shared/share-1.1767225600.c2ln", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	// the link may be revoked, so the location must not be served from a cache
+	assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+	assert.Equal(t, "share-1.1767225600.c2ln", svc.GetSharedLocationCalls()[0].Token)
+}
@@ -0,0 +1,288 @@
+package http
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"leeta/internal/adapter/logger"
+	"leeta/internal/core/domain"
+	"leeta/internal/core/port"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+)
+
+const (
+	// matchBufferSize is the number of matches waiting to be written to a WebSocket client before new ones are dropped
+	matchBufferSize = 16
+
+	wsPingInterval = 30 * time.Second
+	wsWriteTimeout = 10 * time.Second
+)
+
+// SubscriptionHandler represents the HTTP handler for the proximity subscriptions
+type SubscriptionHandler struct {
+	svc      port.SubscriptionService
+	validate *validator.Validate
+	upgrader *websocket.Upgrader
+	hub      *matchHub
+}
+
+// NewSubscriptionHandler creates a new SubscriptionHandler instance
+func NewSubscriptionHandler(svc port.SubscriptionService, vld *validator.Validate) *SubscriptionHandler {
+	return &SubscriptionHandler{
+		svc,
+		vld,
+		&websocket.Upgrader{
+			Error: func(w http.ResponseWriter, r *http.Request, status int, reason error) {
+				handleError(w, domain.NewCError(status, reason.Error()))
+			},
+		},
+		newMatchHub(),
+	}
+}
+
+// OnMatch sends match to the WebSocket clients of its subscription connected to this instance
+func (sh *SubscriptionHandler) OnMatch(ctx context.Context, match *domain.ProximityMatch) {
+	if dropped := sh.hub.broadcast(match); dropped > 0 {
+		logger.FromCtx(ctx).Warn("Dropping proximity match, the WebSocket clients are backed up",
+			zap.String("subscription_id", match.SubscriptionID), zap.Int("clients", dropped))
+	}
+}
+
+// CreateSubscription godoc
+//
+//	@Summary		Create a proximity subscription
+//	@Description	register a standing query for the locations or entities that appear within a radius of a point. Matches are posted to the webhook and sent to the WebSocket clients of the subscription
+//	@Tags			Subscription
+//	@Accept			json
+//	@Produce		json
+//	@Param			domain.CreateSubscriptionRequest	body		domain.CreateSubscriptionRequest	true	"Subscription"
+//	@Success		201									{object}	subscriptionResponse				"Subscription created"
+//	@Failure		400									{object}	errorResponse						"Validation error"
+//	@Failure		401									{object}	errorResponse						"Unauthorized error"
+//	@Failure		403									{object}	errorResponse						"Quota of the tenant exceeded"
+//	@Failure		413									{object}	errorResponse						"Request body too large"
+//	@Failure		500									{object}	errorResponse						"Internal server error"
+//	@Router			/subscriptions [post]
+//	@Security		BearerAuth
+func (sh *SubscriptionHandler) CreateSubscription(w http.ResponseWriter, r *http.Request) {
+	var req domain.CreateSubscriptionRequest
+	if cerr := decodeJSON(r, &req); cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+
+	if err := sh.validate.Struct(&req); err != nil {
+		validationError(w, err)
+		return
+	}
+
+	subscription, cerr := sh.svc.CreateSubscription(r.Context(), &req)
+	if cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+
+	handleSuccess(w, http.StatusCreated, subscription)
+}
+
+// GetSubscription godoc
+//
+//	@Summary		Get a proximity subscription
+//	@Description	get a proximity subscription by id
+//	@Tags			Subscription
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string					true	"Subscription id"
+//	@Success		200	{object}	subscriptionResponse	"Success"
+//	@Failure		404	{object}	errorResponse			"Not found error"
+//	@Failure		500	{object}	errorResponse			"Internal server error"
+//	@Router			/subscriptions/{id} [get]
+//	@Security		BearerAuth
+func (sh *SubscriptionHandler) GetSubscription(w http.ResponseWriter, r *http.Request) {
+	subscription, cerr := sh.svc.GetSubscription(r.Context(), chi.URLParam(r, "id"))
+	if cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+
+	handleSuccess(w, http.StatusOK, subscription)
+}
+
+// DeleteSubscription godoc
+//
+//	@Summary		Delete a proximity subscription
+//	@Description	delete a proximity subscription by id, its WebSocket clients are disconnected
+//	@Tags			Subscription
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string			true	"Subscription id"
+//	@Success		200	{object}	response		"Success"
+//	@Failure		401	{object}	errorResponse	"Unauthorized error"
+//	@Failure		404	{object}	errorResponse	"Not found error"
+//	@Failure		500	{object}	errorResponse	"Internal server error"
+//	@Router			/subscriptions/{id} [delete]
+//	@Security		BearerAuth
+func (sh *SubscriptionHandler) DeleteSubscription(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if cerr := sh.svc.DeleteSubscription(r.Context(), id); cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+
+	sh.hub.closeAll(id)
+
+	handleMessage(w, http.StatusOK, "Deleted subscription successfully")
+}
+
+// WatchSubscription godoc
+//
+//	@Summary		Watch a proximity subscription
+//	@Description	upgrade to a WebSocket that receives every match of the subscription as a JSON text message
+//	@Tags			Subscription
+//	@Param			id	path		string					true	"Subscription id"
+//	@Success		101	{object}	domain.ProximityMatch	"Switching protocols, one match per message"
+//	@Failure		400	{object}	errorResponse			"Not a WebSocket handshake"
+//	@Failure		404	{object}	errorResponse			"Not found error"
+//	@Failure		500	{object}	errorResponse			"Internal server error"
+//	@Router			/subscriptions/{id}/ws [get]
+//	@Security		BearerAuth
+func (sh *SubscriptionHandler) WatchSubscription(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if _, cerr := sh.svc.GetSubscription(r.Context(), id); cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+
+	conn, err := sh.upgrader.Upgrade(hijacker{w}, r, nil)
+	if err != nil {
+		// the upgrader already answered
+		return
+	}
+	defer conn.Close()
+
+	matches := sh.hub.add(id)
+	defer sh.hub.remove(id, matches)
+
+	// clients only send control frames, reading handles them and tells when the client goes away
+	gone := make(chan struct{})
+	go func() {
+		defer close(gone)
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	ping := time.NewTicker(wsPingInterval)
+	defer ping.Stop()
+
+	for {
+		select {
+		case <-gone:
+			return
+		case match, ok := <-matches:
+			conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+			if !ok {
+				conn.WriteMessage(websocket.CloseMessage,
+					websocket.FormatCloseMessage(websocket.CloseNormalClosure, "subscription deleted"))
+				return
+			}
+			if err := conn.WriteJSON(match); err != nil {
+				return
+			}
+		case <-ping.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteTimeout)); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// hijacker lets the WebSocket upgrader take over the connection through the middleware response
+// writers, which only expose it to http.ResponseController
+type hijacker struct {
+	http.ResponseWriter
+}
+
+func (h hijacker) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return http.NewResponseController(h.ResponseWriter).Hijack()
+}
+
+// matchHub holds the channels of the WebSocket clients connected to this instance, per subscription
+type matchHub struct {
+	mu      sync.RWMutex
+	clients map[string]map[chan *domain.ProximityMatch]struct{}
+}
+
+func newMatchHub() *matchHub {
+	return &matchHub{
+		clients: make(map[string]map[chan *domain.ProximityMatch]struct{}),
+	}
+}
+
+// add registers a client of the subscription with id, returning the channel of its matches
+func (h *matchHub) add(id string) chan *domain.ProximityMatch {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	matches := make(chan *domain.ProximityMatch, matchBufferSize)
+	if h.clients[id] == nil {
+		h.clients[id] = make(map[chan *domain.ProximityMatch]struct{})
+	}
+	h.clients[id][matches] = struct{}{}
+
+	return matches
+}
+
+// remove unregisters a client of the subscription with id and closes its channel, unless closeAll already did
+func (h *matchHub) remove(id string, matches chan *domain.ProximityMatch) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.clients[id][matches]; !ok {
+		return
+	}
+
+	delete(h.clients[id], matches)
+	if len(h.clients[id]) == 0 {
+		delete(h.clients, id)
+	}
+	close(matches)
+}
+
+// closeAll unregisters the clients of the subscription with id and closes their channels
+func (h *matchHub) closeAll(id string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for matches := range h.clients[id] {
+		close(matches)
+	}
+	delete(h.clients, id)
+}
+
+// broadcast sends match to the clients of its subscription, returning how many dropped it being backed up
+func (h *matchHub) broadcast(match *domain.ProximityMatch) int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	dropped := 0
+	for matches := range h.clients[match.SubscriptionID] {
+		select {
+		case matches <- match:
+		default:
+			dropped++
+		}
+	}
+
+	return dropped
+}
@@ -0,0 +1,135 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"leeta/internal/core/domain"
+	"leeta/internal/core/port/mock"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubscriptionHandler_CreateSubscription(t *testing.T) {
+	svc := &mock.SubscriptionServiceMock{
+		CreateSubscriptionFunc: func(ctx context.Context, req *domain.CreateSubscriptionRequest) (*domain.Subscription, domain.CError) {
+			return &domain.Subscription{ID: "id", Target: req.Target, Radius: req.Radius}, nil
+		},
+	}
+	handler := NewSubscriptionHandler(svc, validator.New())
+
+	tests := []struct {
+		name   string
+		body   string
+		status int
+	}{
+		{"Success", `{"target": "entities", "latitude": 6.52, "longitude": 3.37, "radius_meters": 500, "kind": "courier"}`, http.StatusCreated},
+		{"Success - Webhook", `{"target": "all", "latitude": 6.52, "longitude": 3.37, "radius_meters": 500, "webhook_url": "https://example.com/hooks/near"}`, http.StatusCreated},
+		{"Error - Invalid target", `{"target": "devices", "latitude": 6.52, "longitude": 3.37, "radius_meters": 500}`, http.StatusBadRequest},
+		{"Error - Missing radius", `{"target": "locations", "latitude": 6.52, "longitude": 3.37}`, http.StatusBadRequest},
+		{"Error - Invalid webhook", `{"target": "locations", "latitude": 6.52, "longitude": 3.37, "radius_meters": 500, "webhook_url": "ftp://example.com"}`, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/subscriptions", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			handler.CreateSubscription(w, req)
+
+			assert.Equal(t, tt.status, w.Code, w.Body.String())
+		})
+	}
+
+	require.Len(t, svc.CreateSubscriptionCalls(), 2)
+}
+
+func TestSubscriptionHandler_WatchSubscription(t *testing.T) {
+	svc := &mock.SubscriptionServiceMock{
+		GetSubscriptionFunc: func(ctx context.Context, id string) (*domain.Subscription, domain.CError) {
+			if id != "sub-1" {
+				return nil, domain.NewCError(http.StatusNotFound, "subscription not found")
+			}
+			return &domain.Subscription{ID: id}, nil
+		},
+		DeleteSubscriptionFunc: func(ctx context.Context, id string) domain.CError {
+			return nil
+		},
+	}
+	handler := NewSubscriptionHandler(svc, validator.New())
+
+	// the middlewares wrap the response writer, the upgrade must reach through them
+	r := chi.NewRouter()
+	r.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(newLoggingResponseWriter(w), r)
+		})
+	})
+	r.Get("/subscriptions/{id}/ws", handler.WatchSubscription)
+	r.Delete("/subscriptions/{id}", handler.DeleteSubscription)
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "/subscriptions/"
+
+	t.Run("Error - Unknown subscription", func(t *testing.T) {
+		_, resp, err := websocket.DefaultDialer.Dial(url+"sub-2/ws", nil)
+		require.Error(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("Error - Not a WebSocket handshake", func(t *testing.T) {
+		resp, err := http.Get(server.URL + "/subscriptions/sub-1/ws")
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	conn, _, err := websocket.DefaultDialer.Dial(url+"sub-1/ws", nil)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	// the client is registered once the handler runs past the upgrade
+	require.Eventually(t, func() bool {
+		handler.hub.mu.RLock()
+		defer handler.hub.mu.RUnlock()
+		return len(handler.hub.clients["sub-1"]) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	t.Run("Matches are sent to the clients of their subscription", func(t *testing.T) {
+		handler.OnMatch(context.Background(), &domain.ProximityMatch{SubscriptionID: "sub-2", Distance: 10})
+		handler.OnMatch(context.Background(), &domain.ProximityMatch{
+			SubscriptionID: "sub-1",
+			Entity:         &domain.EntityPosition{EntityID: "courier-1"},
+			Distance:       120,
+		})
+
+		var match domain.ProximityMatch
+		conn.SetReadDeadline(time.Now().Add(time.Second))
+		require.NoError(t, conn.ReadJSON(&match))
+		assert.Equal(t, "courier-1", match.Entity.EntityID)
+		assert.Equal(t, 120.0, match.Distance)
+	})
+
+	t.Run("Deleting the subscription disconnects its clients", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodDelete, server.URL+"/subscriptions/sub-1", nil)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		conn.SetReadDeadline(time.Now().Add(time.Second))
+		_, _, err = conn.ReadMessage()
+		assert.True(t, websocket.IsCloseError(err, websocket.CloseNormalClosure), err)
+	})
+}
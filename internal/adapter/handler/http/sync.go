@@ -0,0 +1,94 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"leeta/internal/core/domain"
+	"leeta/internal/core/port"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+)
+
+// SyncHandler represents the HTTP handler for the sync report requests
+type SyncHandler struct {
+	svc      port.SyncService
+	validate *validator.Validate
+}
+
+// NewSyncHandler creates a new SyncHandler instance
+func NewSyncHandler(svc port.SyncService, vld *validator.Validate) *SyncHandler {
+	return &SyncHandler{
+		svc,
+		vld,
+	}
+}
+
+// ListSyncReports godoc
+//
+//	@Summary		List sync reports
+//	@Description	list the reports of the syncs from the external sources, most recent first
+//	@Tags			Admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			source	query		string					false	"Name of the source"
+//	@Param			limit	query		int						false	"Maximum number of reports, 20 by default, at most 100"
+//	@Success		200		{object}	syncReportListResponse	"Success"
+//	@Failure		400		{object}	errorResponse			"Validation error"
+//	@Failure		401		{object}	errorResponse			"Unauthorized error"
+//	@Failure		500		{object}	errorResponse			"Internal server error"
+//	@Router			/admin/syncs [get]
+//	@Security		BearerAuth
+func (sh *SyncHandler) ListSyncReports(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	req := domain.ListSyncReportsRequest{
+		Source: query.Get("source"),
+	}
+
+	if value := query.Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil {
+			handleError(w, domain.NewBadRequestCError("limit must be a number"))
+			return
+		}
+		req.Limit = limit
+	}
+
+	if err := sh.validate.Struct(&req); err != nil {
+		validationError(w, err)
+		return
+	}
+
+	reports, cerr := sh.svc.ListSyncReports(r.Context(), &req)
+	if cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+
+	handleSuccess(w, http.StatusOK, reports)
+}
+
+// GetSyncReport godoc
+//
+//	@Summary		Get a sync report
+//	@Description	get the report of a sync from an external source by its id, with the changes it made
+//	@Tags			Admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string				true	"Sync report id"
+//	@Success		200	{object}	syncReportResponse	"Success"
+//	@Failure		401	{object}	errorResponse		"Unauthorized error"
+//	@Failure		404	{object}	errorResponse		"Sync report not found"
+//	@Failure		500	{object}	errorResponse		"Internal server error"
+//	@Router			/admin/syncs/{id} [get]
+//	@Security		BearerAuth
+func (sh *SyncHandler) GetSyncReport(w http.ResponseWriter, r *http.Request) {
+	report, cerr := sh.svc.GetSyncReport(r.Context(), chi.URLParam(r, "id"))
+	if cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+
+	handleSuccess(w, http.StatusOK, report)
+}
@@ -0,0 +1,81 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"leeta/internal/core/domain"
+	"leeta/internal/core/port/mock"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyncHandler_ListSyncReports(t *testing.T) {
+	svc := &mock.SyncServiceMock{
+		ListSyncReportsFunc: func(ctx context.Context, req *domain.ListSyncReportsRequest) ([]domain.SyncReport, domain.CError) {
+			return []domain.SyncReport{}, nil
+		},
+	}
+	handler := NewSyncHandler(svc, validator.New())
+
+	tests := []struct {
+		name   string
+		query  string
+		status int
+	}{
+		{"Success - No filter", "", http.StatusOK},
+		{"Success - Filtered", "?source=erp&limit=5", http.StatusOK},
+		{"Error - Limit not a number", "?limit=ten", http.StatusBadRequest},
+		{"Error - Limit too large", "?limit=1000", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/admin/syncs/"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			handler.ListSyncReports(w, req)
+
+			assert.Equal(t, tt.status, w.Code, w.Body.String())
+		})
+	}
+
+	calls := svc.ListSyncReportsCalls()
+	assert.Len(t, calls, 2)
+	assert.Equal(t, &domain.ListSyncReportsRequest{Source: "erp", Limit: 5}, calls[1].Req)
+}
+
+func TestSyncHandler_GetSyncReport(t *testing.T) {
+	svc := &mock.SyncServiceMock{
+		GetSyncReportFunc: func(ctx context.Context, id string) (*domain.SyncReport, domain.CError) {
+			if id != "sync-1" {
+				return nil, domain.NewCError(http.StatusNotFound, "sync report not found")
+			}
+			return &domain.SyncReport{ID: id, Source: "erp", Status: domain.SyncSucceeded}, nil
+		},
+	}
+	handler := NewSyncHandler(svc, validator.New())
+
+	tests := []struct {
+		name   string
+		id     string
+		status int
+	}{
+		{"Success", "sync-1", http.StatusOK},
+		{"Error - Not found", "sync-2", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := withURLParam(httptest.NewRequest(http.MethodGet, "/admin/syncs/"+tt.id, nil), "id", tt.id)
+			w := httptest.NewRecorder()
+
+			handler.GetSyncReport(w, req)
+
+			assert.Equal(t, tt.status, w.Code, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"leeta/internal/core/domain"
+	"leeta/internal/core/port"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+)
+
+// mvtContentType is the media type of the Mapbox Vector Tiles
+const mvtContentType = "application/vnd.mapbox-vector-tile"
+
+// TileHandler represents the HTTP handler for the vector tiles of the locations
+type TileHandler struct {
+	svc      port.TileService
+	validate *validator.Validate
+}
+
+// NewTileHandler creates a new TileHandler instance
+func NewTileHandler(svc port.TileService, vld *validator.Validate) *TileHandler {
+	return &TileHandler{
+		svc,
+		vld,
+	}
+}
+
+// GetLocationTile godoc
+//
+//	@Summary		Get a vector tile of the locations
+//	@Description	get the approved locations within a tile of the Web Mercator grid as a Mapbox Vector Tile, for map frontends to draw them at any zoom level. The tile has a single locations layer of points with the id, name, slug and category of their location. A tile without locations is answered with no content
+//	@Tags			Location
+//	@Produce		application/vnd.mapbox-vector-tile
+//	@Param			z				path		int				true	"Zoom level, up to 22"
+//	@Param			x				path		int				true	"Column of the tile, from the west"
+//	@Param			y				path		int				true	"Row of the tile, from the north"
+//	@Param			q				query		string			false	"Filter"
+//	@Param			include_expired	query		bool			false	"Also draw the locations outside their validity"
+//	@Success		200				{file}		binary			"Tile"
+//	@Success		204				"Tile without locations"
+//	@Failure		400				{object}	errorResponse	"Validation error"
+//	@Failure		500				{object}	errorResponse	"Internal server error"
+//	@Router			/tiles/{z}/{x}/{y}.mvt [get]
+//	@Security		BearerAuth
+func (th *TileHandler) GetLocationTile(w http.ResponseWriter, r *http.Request) {
+	var req domain.TileRequest
+	for _, param := range []struct {
+		name  string
+		value *int
+	}{{"z", &req.Z}, {"x", &req.X}, {"y", &req.Y}} {
+		n, err := strconv.Atoi(chi.URLParam(r, param.name))
+		if err != nil {
+			handleError(w, domain.NewBadRequestCError("Invalid tile, "+param.name+" must be a number"))
+			return
+		}
+		*param.value = n
+	}
+
+	includeExpired, cerr := queryBool(r, "include_expired")
+	if cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+	req.Query = r.URL.Query().Get("q")
+	req.IncludeExpired = includeExpired
+
+	tile, cerr := th.svc.GetLocationTile(r.Context(), &req)
+	if cerr != nil {
+		handleError(w, cerr)
+		return
+	}
+
+	if len(tile) == 0 {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	w.Header().Set("Content-Type", mvtContentType)
+	w.Header().Set("Content-Length", strconv.Itoa(len(tile)))
+	w.WriteHeader(http.StatusOK)
+	w.Write(tile)
+}
@@ -0,0 +1,61 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"leeta/internal/core/domain"
+	"leeta/internal/core/port/mock"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTileHandler_GetLocationTile(t *testing.T) {
+	svc := &mock.TileServiceMock{
+		GetLocationTileFunc: func(ctx context.Context, req *domain.TileRequest) ([]byte, domain.CError) {
+			if req.Z == 0 {
+				return []byte{}, nil
+			}
+			return []byte{0x1a, 0x02, 0x78, 0x02}, nil
+		},
+	}
+	handler := NewTileHandler(svc, validator.New())
+
+	r := chi.NewRouter()
+	r.Get("/tiles/{z}/{x}/{y}.mvt", handler.GetLocationTile)
+
+	tests := []struct {
+		name   string
+		path   string
+		status int
+	}{
+		{"Success", "/tiles/12/2087/1974.mvt?q=category:park&include_expired=true", http.StatusOK},
+		{"Success - Empty tile", "/tiles/0/0/0.mvt", http.StatusNoContent},
+		{"Error - Invalid row", "/tiles/12/2087/north.mvt", http.StatusBadRequest},
+		{"Error - Invalid flag", "/tiles/12/2087/1974.mvt?include_expired=maybe", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.status, w.Code, w.Body.String())
+			if tt.status == http.StatusOK {
+				assert.Equal(t, mvtContentType, w.Header().Get("Content-Type"))
+				assert.Equal(t, []byte{0x1a, 0x02, 0x78, 0x02}, w.Body.Bytes())
+			}
+		})
+	}
+
+	calls := svc.GetLocationTileCalls()
+	require.Len(t, calls, 2)
+	assert.Equal(t, domain.TileRequest{Z: 12, X: 2087, Y: 1974, Query: "category:park", IncludeExpired: true}, *calls[0].Req)
+}
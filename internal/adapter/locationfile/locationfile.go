@@ -0,0 +1,273 @@
+// Package locationfile reads the files locations are imported from
+package locationfile
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"leeta/internal/core/domain"
+)
+
+// Format is the format of a file of locations
+type Format string
+
+const (
+	// FormatJSON is a JSON array of locations, as registered one by one
+	FormatJSON Format = "json"
+	// FormatCSV is CSV with a header, read by ReadCSV
+	FormatCSV Format = "csv"
+	// FormatGeoJSON is a GeoJSON FeatureCollection of points, read by ReadGeoJSON
+	FormatGeoJSON Format = "geojson"
+	// FormatGPX is a GPX file of waypoints, read by ReadGPX
+	FormatGPX Format = "gpx"
+)
+
+// sniffSize is the number of bytes looked at to detect the format of a file
+const sniffSize = 512
+
+// Detect detects the format of body from its media type, or from its first bytes when the media type does not tell,
+// such as when it is empty or application/octet-stream. JSON is told apart from GeoJSON by its opening bracket, as a
+// GeoJSON file is an object. It returns a reader reading body from the start and reports false for media types of
+// other formats
+func Detect(mediaType string, body io.Reader) (Format, io.Reader, bool) {
+	switch mediaType {
+	case "text/csv":
+		return FormatCSV, body, true
+	case "application/geo+json":
+		return FormatGeoJSON, body, true
+	case "application/gpx+xml":
+		return FormatGPX, body, true
+	case "application/json", "", "application/octet-stream", "text/plain":
+	default:
+		return "", body, false
+	}
+
+	reader := bufio.NewReaderSize(body, sniffSize)
+	head, _ := reader.Peek(sniffSize)
+	head = bytes.TrimLeft(head, "\ufeff \t\r\n")
+
+	switch {
+	case len(head) > 0 && head[0] == '[':
+		return FormatJSON, reader, true
+	case len(head) > 0 && head[0] == '{':
+		return FormatGeoJSON, reader, true
+	case mediaType == "application/json":
+		// left to the JSON decoder to report
+		return FormatJSON, reader, true
+	case len(head) > 0 && head[0] == '<':
+		return FormatGPX, reader, true
+	}
+	return FormatCSV, reader, true
+}
+
+// Read reads locations from body in format
+func Read(format Format, body io.Reader) ([]domain.RegisterLocationRequest, error) {
+	switch format {
+	case FormatJSON:
+		return ReadJSON(body)
+	case FormatCSV:
+		return ReadCSV(body)
+	case FormatGeoJSON:
+		return ReadGeoJSON(body)
+	case FormatGPX:
+		return ReadGPX(body)
+	}
+	return nil, fmt.Errorf("unsupported format %q", format)
+}
+
+// ReadJSON reads locations from a JSON array
+func ReadJSON(body io.Reader) ([]domain.RegisterLocationRequest, error) {
+	var locations []domain.RegisterLocationRequest
+
+	decoder := json.NewDecoder(body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&locations); err != nil {
+		return nil, err
+	}
+
+	return locations, nil
+}
+
+// ReadCSV reads locations from CSV with a name,latitude,longitude[,category][,altitude][,valid_from][,valid_until]
+// header. The validity is in RFC 3339, such as 2025-06-01T09:00:00Z, and an empty altitude or validity leaves the
+// location without one
+func ReadCSV(body io.Reader) ([]domain.RegisterLocationRequest, error) {
+	reader := csv.NewReader(body)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("reading header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	for _, required := range []string{"name", "latitude", "longitude"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("missing %s column", required)
+		}
+	}
+
+	var locations []domain.RegisterLocationRequest
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		latitude, err := strconv.ParseFloat(record[columns["latitude"]], 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid latitude", line)
+		}
+
+		longitude, err := strconv.ParseFloat(record[columns["longitude"]], 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid longitude", line)
+		}
+
+		location := domain.RegisterLocationRequest{
+			Name:      record[columns["name"]],
+			Latitude:  latitude,
+			Longitude: longitude,
+		}
+		if i, ok := columns["category"]; ok {
+			location.Category = record[i]
+		}
+		if i, ok := columns["altitude"]; ok && record[i] != "" {
+			altitude, err := strconv.ParseFloat(record[i], 64)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid altitude", line)
+			}
+			location.Altitude = &altitude
+		}
+		for _, bound := range []struct {
+			column string
+			value  **time.Time
+		}{{"valid_from", &location.ValidFrom}, {"valid_until", &location.ValidUntil}} {
+			if i, ok := columns[bound.column]; ok && record[i] != "" {
+				t, err := time.Parse(time.RFC3339, record[i])
+				if err != nil {
+					return nil, fmt.Errorf("line %d: invalid %s, expected a time such as 2025-06-01T09:00:00Z", line, bound.column)
+				}
+				*bound.value = &t
+			}
+		}
+
+		locations = append(locations, location)
+	}
+
+	return locations, nil
+}
+
+// geojsonProperties are the properties of a feature read as the details of its location, the others are ignored
+type geojsonProperties struct {
+	Name       string            `json:"name"`
+	Category   string            `json:"category"`
+	Altitude   *float64          `json:"altitude"`
+	Names      map[string]string `json:"names"`
+	ValidFrom  *time.Time        `json:"valid_from"`
+	ValidUntil *time.Time        `json:"valid_until"`
+}
+
+// ReadGeoJSON reads locations from a GeoJSON FeatureCollection, or a single Feature, of points. The name, category,
+// altitude, names and validity of a location are read from the properties of its feature, which may carry others,
+// and a third coordinate is its altitude when it has none. The snapshots of the scheduled exports are read so too
+func ReadGeoJSON(body io.Reader) ([]domain.RegisterLocationRequest, error) {
+	type feature struct {
+		Type     string `json:"type"`
+		Geometry *struct {
+			Type        string    `json:"type"`
+			Coordinates []float64 `json:"coordinates"`
+		} `json:"geometry"`
+		Properties geojsonProperties `json:"properties"`
+	}
+
+	var collection struct {
+		feature
+		Features []feature `json:"features"`
+	}
+	if err := json.NewDecoder(body).Decode(&collection); err != nil {
+		return nil, err
+	}
+
+	features := collection.Features
+	switch collection.Type {
+	case "FeatureCollection":
+	case "Feature":
+		features = []feature{collection.feature}
+	default:
+		return nil, errors.New("expected a GeoJSON FeatureCollection or Feature")
+	}
+
+	locations := make([]domain.RegisterLocationRequest, 0, len(features))
+	for i, feature := range features {
+		if feature.Geometry == nil || feature.Geometry.Type != "Point" || len(feature.Geometry.Coordinates) < 2 {
+			return nil, fmt.Errorf("feature %d: expected a Point geometry", i+1)
+		}
+
+		coordinates := feature.Geometry.Coordinates
+		location := domain.RegisterLocationRequest{
+			Name:       feature.Properties.Name,
+			Latitude:   coordinates[1],
+			Longitude:  coordinates[0],
+			Altitude:   feature.Properties.Altitude,
+			Category:   feature.Properties.Category,
+			Names:      feature.Properties.Names,
+			ValidFrom:  feature.Properties.ValidFrom,
+			ValidUntil: feature.Properties.ValidUntil,
+		}
+		if location.Altitude == nil && len(coordinates) > 2 {
+			location.Altitude = &coordinates[2]
+		}
+
+		locations = append(locations, location)
+	}
+
+	return locations, nil
+}
+
+// ReadGPX reads locations from the waypoints of a GPX file, their name, elevation and type being the name, altitude
+// and category of the locations. Tracks and routes are ignored
+func ReadGPX(body io.Reader) ([]domain.RegisterLocationRequest, error) {
+	var gpx struct {
+		XMLName   xml.Name `xml:"gpx"`
+		Waypoints []struct {
+			Latitude  float64  `xml:"lat,attr"`
+			Longitude float64  `xml:"lon,attr"`
+			Elevation *float64 `xml:"ele"`
+			Name      string   `xml:"name"`
+			Type      string   `xml:"type"`
+		} `xml:"wpt"`
+	}
+	if err := xml.NewDecoder(body).Decode(&gpx); err != nil {
+		return nil, err
+	}
+
+	locations := make([]domain.RegisterLocationRequest, 0, len(gpx.Waypoints))
+	for _, waypoint := range gpx.Waypoints {
+		locations = append(locations, domain.RegisterLocationRequest{
+			Name:      strings.TrimSpace(waypoint.Name),
+			Latitude:  waypoint.Latitude,
+			Longitude: waypoint.Longitude,
+			Altitude:  waypoint.Elevation,
+			Category:  strings.TrimSpace(waypoint.Type),
+		})
+	}
+
+	return locations, nil
+}
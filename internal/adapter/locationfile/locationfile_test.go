@@ -0,0 +1,112 @@
+package locationfile
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name      string
+		mediaType string
+		body      string
+		format    Format
+		ok        bool
+	}{
+		{"CSV", "text/csv", "name,latitude,longitude", FormatCSV, true},
+		{"GeoJSON", "application/geo+json", `{"type": "FeatureCollection"}`, FormatGeoJSON, true},
+		{"GPX", "application/gpx+xml", "<gpx/>", FormatGPX, true},
+		{"JSON array", "application/json", ` [{"name": "Lekki"}]`, FormatJSON, true},
+		{"JSON object", "application/json", `{"type": "Feature"}`, FormatGeoJSON, true},
+		{"Sniffed JSON", "", "\ufeff\n[]", FormatJSON, true},
+		{"Sniffed GPX", "application/octet-stream", `<?xml version="1.0"?><gpx/>`, FormatGPX, true},
+		{"Sniffed CSV", "text/plain", "name,latitude,longitude", FormatCSV, true},
+		{"Unsupported", "application/pdf", "%PDF", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			format, body, ok := Detect(tt.mediaType, strings.NewReader(tt.body))
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.format, format)
+
+			// the bytes looked at are read again
+			read, err := io.ReadAll(body)
+			require.NoError(t, err)
+			assert.Equal(t, tt.body, string(read))
+		})
+	}
+}
+
+func TestReadGeoJSON(t *testing.T) {
+	t.Run("Feature collection", func(t *testing.T) {
+		locations, err := ReadGeoJSON(strings.NewReader(`{
+			"type": "FeatureCollection",
+			"features": [
+				{"type": "Feature", "geometry": {"type": "Point", "coordinates": [3.47, 6.45, 12]},
+					"properties": {"name": "Lekki Depot", "category": "depot", "marker-color": "#f00"}},
+				{"type": "Feature", "geometry": {"type": "Point", "coordinates": [3.38, 6.51]},
+					"properties": {"name": "Yaba Depot", "altitude": 40, "valid_until": "2030-01-01T00:00:00Z"}}
+			]
+		}`))
+		require.NoError(t, err)
+		require.Len(t, locations, 2)
+
+		assert.Equal(t, "Lekki Depot", locations[0].Name)
+		assert.Equal(t, 6.45, locations[0].Latitude)
+		assert.Equal(t, 3.47, locations[0].Longitude)
+		assert.Equal(t, "depot", locations[0].Category)
+		require.NotNil(t, locations[0].Altitude)
+		assert.Equal(t, 12.0, *locations[0].Altitude)
+
+		require.NotNil(t, locations[1].Altitude)
+		assert.Equal(t, 40.0, *locations[1].Altitude)
+		require.NotNil(t, locations[1].ValidUntil)
+	})
+
+	t.Run("Single feature", func(t *testing.T) {
+		locations, err := ReadGeoJSON(strings.NewReader(`{"type": "Feature", "geometry": {"type": "Point", "coordinates": [3.47, 6.45]}, "properties": {"name": "Lekki Depot"}}`))
+		require.NoError(t, err)
+		require.Len(t, locations, 1)
+		assert.Equal(t, "Lekki Depot", locations[0].Name)
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		for _, body := range []string{
+			`{"type": "Point", "coordinates": [3.47, 6.45]}`,
+			`{"type": "FeatureCollection", "features": [{"type": "Feature", "geometry": {"type": "LineString", "coordinates": [[3.47, 6.45], [3.38, 6.51]]}}]}`,
+			`{"type": "FeatureCollection", "features": [{"type": "Feature", "geometry": null}]}`,
+			`{"type": "FeatureCollection"`,
+		} {
+			_, err := ReadGeoJSON(strings.NewReader(body))
+			assert.Error(t, err, body)
+		}
+	})
+}
+
+func TestReadGPX(t *testing.T) {
+	locations, err := ReadGPX(strings.NewReader(`<?xml version="1.0" encoding="UTF-8"?>
+<gpx version="1.1" creator="test" xmlns="http://www.topografix.com/GPX/1/1">
+  <wpt lat="6.45" lon="3.47"><ele>12</ele><name> Lekki Depot </name><type>depot</type></wpt>
+  <wpt lat="6.51" lon="3.38"><name>Yaba Depot</name></wpt>
+  <trk><name>Route</name><trkseg><trkpt lat="6.46" lon="3.46"/></trkseg></trk>
+</gpx>`))
+	require.NoError(t, err)
+	require.Len(t, locations, 2)
+
+	assert.Equal(t, "Lekki Depot", locations[0].Name)
+	assert.Equal(t, 6.45, locations[0].Latitude)
+	assert.Equal(t, 3.47, locations[0].Longitude)
+	assert.Equal(t, "depot", locations[0].Category)
+	require.NotNil(t, locations[0].Altitude)
+	assert.Equal(t, 12.0, *locations[0].Altitude)
+
+	assert.Nil(t, locations[1].Altitude)
+
+	_, err = ReadGPX(strings.NewReader(`<kml></kml>`))
+	assert.Error(t, err)
+}
@@ -0,0 +1,137 @@
+// Package locationsource downloads the locations of the external sources locations are synced from
+package locationsource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"leeta/internal/adapter/config"
+	"leeta/internal/adapter/locationfile"
+	"leeta/internal/core/domain"
+	"leeta/internal/core/olc"
+
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	defaultTimeout = 30 * time.Second
+	// maxErrors is the number of invalid records reported before giving up on a file
+	maxErrors = 20
+)
+
+/**
+ * HTTP implements port.LocationSource interface with a CSV
+ * or JSON file downloaded over HTTP, such as the export of
+ * an ERP or a presigned URL of a file on S3
+ */
+type HTTP struct {
+	client   *http.Client
+	url      string
+	format   string
+	headers  map[string]string
+	validate *validator.Validate
+}
+
+// New creates a new HTTP source instance from the configuration of a sync source, its locations are checked with vld
+func New(config *config.SyncSourceConfiguration, vld *validator.Validate) (*HTTP, error) {
+	timeout := config.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	format := strings.ToLower(config.Format)
+	if format == "" {
+		u, err := url.Parse(config.URL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid url of sync source %q: %w", config.Name, err)
+		}
+		format = strings.TrimPrefix(strings.ToLower(path.Ext(u.Path)), ".")
+	}
+	if format != "csv" && format != "json" {
+		return nil, fmt.Errorf("invalid format of sync source %q, expected csv or json", config.Name)
+	}
+
+	return &HTTP{
+		&http.Client{Timeout: timeout},
+		config.URL,
+		format,
+		config.Headers,
+		vld,
+	}, nil
+}
+
+func (h *HTTP) FetchLocations(ctx context.Context) ([]domain.RegisterLocationRequest, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, h.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	for name, value := range h.headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+		return nil, fmt.Errorf("source answered with status %d", resp.StatusCode)
+	}
+
+	var locations []domain.RegisterLocationRequest
+	if h.format == "csv" {
+		locations, err = locationfile.ReadCSV(resp.Body)
+	} else {
+		locations, err = locationfile.ReadJSON(resp.Body)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading source: %w", err)
+	}
+
+	var errs []error
+	for i := range locations {
+		if err := h.check(&locations[i]); err != nil {
+			errs = append(errs, fmt.Errorf("record %d: %w", i+1, err))
+		}
+
+		if len(errs) >= maxErrors {
+			break
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	return locations, nil
+}
+
+// check validates a location, resolving its plus code to coordinates
+func (h *HTTP) check(location *domain.RegisterLocationRequest) error {
+	if location.PlusCode != "" {
+		area, err := olc.Decode(location.PlusCode)
+		if err != nil {
+			return err
+		}
+		location.Latitude, location.Longitude = area.Center()
+		location.PlusCode = ""
+	}
+
+	if err := h.validate.Struct(location); err != nil {
+		return err
+	}
+	if location.Footprint != nil {
+		if err := location.Footprint.CheckRings(); err != nil {
+			return err
+		}
+	}
+	return domain.CheckValidity(location.ValidFrom, location.ValidUntil)
+}
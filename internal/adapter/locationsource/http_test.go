@@ -0,0 +1,85 @@
+package locationsource
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"leeta/internal/adapter/config"
+	"leeta/internal/adapter/validation"
+	"leeta/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTP_FetchLocations(t *testing.T) {
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		switch r.URL.Path {
+		case "/stores.csv":
+			w.Write([]byte("name,latitude,longitude,category\nLekki Depot,6.4474,3.4723,depot\nIkeja Depot,6.6018,3.3515,\n"))
+		case "/stores.json":
+			w.Write([]byte(`[{"name":"Lekki Depot","plus_code":"6FR5G9JC+XH"}]`))
+		case "/invalid.json":
+			w.Write([]byte(`[{"name":"Lekki Depot","latitude":6.4474,"longitude":3.4723},{"name":"","latitude":95,"longitude":3.4}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	t.Run("CSV", func(t *testing.T) {
+		source, err := New(&config.SyncSourceConfiguration{
+			Name:    "erp",
+			URL:     server.URL + "/stores.csv?X-Amz-Signature=abc",
+			Headers: map[string]string{"Authorization": "Bearer secret"},
+		}, validation.New(nil))
+		require.NoError(t, err)
+
+		locations, err := source.FetchLocations(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, "Bearer secret", authorization)
+		assert.Equal(t, []domain.RegisterLocationRequest{
+			{Name: "Lekki Depot", Latitude: 6.4474, Longitude: 3.4723, Category: "depot"},
+			{Name: "Ikeja Depot", Latitude: 6.6018, Longitude: 3.3515},
+		}, locations)
+	})
+
+	t.Run("PlusCode", func(t *testing.T) {
+		source, err := New(&config.SyncSourceConfiguration{Name: "erp", URL: server.URL + "/stores.json"}, validation.New(nil))
+		require.NoError(t, err)
+
+		locations, err := source.FetchLocations(context.Background())
+		require.NoError(t, err)
+		require.Len(t, locations, 1)
+
+		assert.Empty(t, locations[0].PlusCode)
+		assert.InDelta(t, 6.5324, locations[0].Latitude, 0.0001)
+		assert.InDelta(t, 3.3714, locations[0].Longitude, 0.0001)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		source, err := New(&config.SyncSourceConfiguration{Name: "erp", URL: server.URL + "/invalid.json"}, validation.New(nil))
+		require.NoError(t, err)
+
+		_, err = source.FetchLocations(context.Background())
+		assert.ErrorContains(t, err, "record 2")
+	})
+
+	t.Run("Status", func(t *testing.T) {
+		source, err := New(&config.SyncSourceConfiguration{Name: "erp", URL: server.URL + "/missing", Format: "CSV"}, validation.New(nil))
+		require.NoError(t, err)
+
+		_, err = source.FetchLocations(context.Background())
+		assert.ErrorContains(t, err, "status 404")
+	})
+
+	t.Run("Format", func(t *testing.T) {
+		_, err := New(&config.SyncSourceConfiguration{Name: "erp", URL: server.URL + "/stores.xml"}, validation.New(nil))
+		assert.Error(t, err)
+	})
+}
@@ -21,6 +21,9 @@ var once sync.Once
 
 var logger *zap.Logger
 
+// level is shared by every core, so changing it applies to the running logger
+var level = zap.NewAtomicLevel()
+
 // Get initializes a zap.Logger instance if it has not been initialized
 // already and returns the same instance for subsequent calls.
 func Get() *zap.Logger {
@@ -35,21 +38,22 @@ func Get() *zap.Logger {
 			Compress:   true,
 		})
 
-		level := zap.InfoLevel
-		levelEnv := os.Getenv("LOG_LEVEL")
+		if config.GetConfig().App.Env == "development" {
+			level.SetLevel(zap.DebugLevel)
+		}
+
+		levelEnv := config.GetConfig().App.LogLevel
+		if levelEnv == "" {
+			levelEnv = os.Getenv("LOG_LEVEL")
+		}
 		if levelEnv != "" {
-			levelFromEnv, err := zapcore.ParseLevel(levelEnv)
-			if err != nil {
+			if err := SetLevel(levelEnv); err != nil {
 				log.Println(
-					fmt.Errorf("invalid level, defaulting to INFO: %w", err),
+					fmt.Errorf("invalid level, keeping the default level: %w", err),
 				)
 			}
-
-			level = levelFromEnv
 		}
 
-		logLevel := zap.NewAtomicLevelAt(level)
-
 		productionCfg := zap.NewProductionEncoderConfig()
 		productionCfg.TimeKey = "timestamp"
 		productionCfg.EncodeTime = zapcore.ISO8601TimeEncoder
@@ -75,13 +79,15 @@ func Get() *zap.Logger {
 		var core zapcore.Core
 
 		if config.GetConfig().App.Env == "development" {
-			logger = zap.Must(zap.NewDevelopment())
+			developmentLoggerCfg := zap.NewDevelopmentConfig()
+			developmentLoggerCfg.Level = level
+			logger = zap.Must(developmentLoggerCfg.Build())
 		} else {
 			// log to multiple destinations (console and file)
 			// extra fields are added to the JSON output alone
 			core = zapcore.NewTee(
-				zapcore.NewCore(consoleEncoder, stdout, logLevel),
-				zapcore.NewCore(fileEncoder, file, logLevel).
+				zapcore.NewCore(consoleEncoder, stdout, level),
+				zapcore.NewCore(fileEncoder, file, level).
 					With(
 						[]zapcore.Field{
 							zap.String("git_revision", gitRevision),
@@ -98,6 +104,23 @@ func Get() *zap.Logger {
 	return logger
 }
 
+// SetLevel changes the level of the logger, e.g. "debug" or "warn".
+// It applies to the loggers already in use as well
+func SetLevel(text string) error {
+	l, err := zapcore.ParseLevel(text)
+	if err != nil {
+		return err
+	}
+
+	level.SetLevel(l)
+	return nil
+}
+
+// Level returns the current level of the logger
+func Level() string {
+	return level.String()
+}
+
 // FromCtx returns the Logger associated with the ctx. If no logger
 // is associated, the default logger is returned, unless it is nil
 // in which case a disabled logger is returned.
@@ -0,0 +1,176 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"leeta/internal/adapter/config"
+	"leeta/internal/adapter/logger"
+	"leeta/internal/core/domain"
+
+	"go.uber.org/zap"
+)
+
+const (
+	defaultTimeout = 5 * time.Second
+
+	// queueSize is the number of notifications waiting to be delivered before new ones are dropped
+	queueSize = 100
+)
+
+// channel delivers notifications to a chat service
+type channel interface {
+	send(ctx context.Context, notification *domain.Notification) error
+}
+
+// delivery is a notification waiting to be sent to a channel
+type delivery struct {
+	ctx          context.Context
+	channel      string
+	notification *domain.Notification
+}
+
+/**
+ * Router implements port.Notifier interface and sends
+ * every notification to the channels its type is routed
+ * to. Deliveries are queued and sent by Run, so alerting
+ * never slows down the operation that raised it
+ */
+type Router struct {
+	channels map[string]channel
+	routes   map[domain.NotificationType][]string
+	catchAll []string
+	timeout  time.Duration
+	queue    chan delivery
+}
+
+// New creates a new notification router instance from the configured channels and routes
+func New(config *config.NotificationsConfiguration) (*Router, error) {
+	timeout := config.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	client := &http.Client{Timeout: timeout}
+
+	r := &Router{
+		channels: make(map[string]channel, len(config.Channels)),
+		routes:   make(map[domain.NotificationType][]string),
+		timeout:  timeout,
+		queue:    make(chan delivery, queueSize),
+	}
+
+	for _, c := range config.Channels {
+		if c.Name == "" || c.URL == "" {
+			return nil, fmt.Errorf("notification channel %q is missing a name or a url", c.Name)
+		}
+		if _, ok := r.channels[c.Name]; ok {
+			return nil, fmt.Errorf("duplicate notification channel %q", c.Name)
+		}
+
+		switch c.Kind {
+		case "slack":
+			r.channels[c.Name] = &slack{client, c.URL}
+		case "teams":
+			r.channels[c.Name] = &teams{client, c.URL}
+		default:
+			return nil, fmt.Errorf("invalid kind %q of notification channel %q, expected slack or teams", c.Kind, c.Name)
+		}
+	}
+
+	for _, route := range config.Routes {
+		for _, name := range route.Channels {
+			if _, ok := r.channels[name]; !ok {
+				return nil, fmt.Errorf("notifications are routed to unknown channel %q", name)
+			}
+		}
+
+		for _, event := range route.Events {
+			if event == "*" {
+				r.catchAll = appendUnique(r.catchAll, route.Channels...)
+				continue
+			}
+			t := domain.NotificationType(event)
+			r.routes[t] = appendUnique(r.routes[t], route.Channels...)
+		}
+	}
+
+	return r, nil
+}
+
+// Notify queues notification for every channel its type is routed to, dropping it
+// for the channels whose deliveries are backed up
+func (r *Router) Notify(ctx context.Context, notification *domain.Notification) {
+	if notification.CreatedAt.IsZero() {
+		notification.CreatedAt = time.Now()
+	}
+
+	// the delivery outlives the operation that raised it, so it only keeps its logger
+	ctx = logger.WithCtx(context.Background(), logger.FromCtx(ctx))
+
+	for _, name := range appendUnique(slices.Clone(r.routes[notification.Type]), r.catchAll...) {
+		select {
+		case r.queue <- delivery{ctx, name, notification}:
+		default:
+			logger.FromCtx(ctx).Warn("Dropping notification, the deliveries are backed up",
+				zap.String("channel", name), zap.String("type", string(notification.Type)))
+		}
+	}
+}
+
+// Run delivers the queued notifications until ctx is done
+func (r *Router) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case d := <-r.queue:
+			sendCtx, cancel := context.WithTimeout(ctx, r.timeout)
+			if err := r.channels[d.channel].send(sendCtx, d.notification); err != nil {
+				logger.FromCtx(d.ctx).Error("Error sending notification", zap.String("channel", d.channel),
+					zap.String("type", string(d.notification.Type)), zap.Error(err))
+			}
+			cancel()
+		}
+	}
+}
+
+// postJSON posts body as JSON to url, failing unless the answer has a 2xx status
+func postJSON(ctx context.Context, client *http.Client, url string, body any) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook answered with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// appendUnique appends the values missing from s
+func appendUnique(s []string, values ...string) []string {
+	for _, v := range values {
+		if !slices.Contains(s, v) {
+			s = append(s, v)
+		}
+	}
+	return s
+}
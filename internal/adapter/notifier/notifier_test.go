@@ -0,0 +1,62 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"leeta/internal/adapter/config"
+	"leeta/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRouter_RoutesByType(t *testing.T) {
+	received := make(chan map[string]any, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		received <- body
+	}))
+	defer server.Close()
+
+	router, err := New(&config.NotificationsConfiguration{
+		Channels: []config.NotificationChannelConfiguration{
+			{Name: "ops", Kind: "slack", URL: server.URL},
+		},
+		Routes: []config.NotificationRouteConfiguration{
+			{Events: []string{string(domain.NotificationImportFailed)}, Channels: []string{"ops"}},
+		},
+	})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go router.Run(ctx)
+
+	router.Notify(ctx, &domain.Notification{Type: domain.NotificationLocationDeleted, Title: "Location deleted"})
+	router.Notify(ctx, &domain.Notification{Type: domain.NotificationImportFailed, Title: "Location import failed"})
+
+	select {
+	case body := <-received:
+		assert.Equal(t, "Location import failed", body["text"], "only the routed type is sent")
+	case <-time.After(5 * time.Second):
+		t.Fatal("the notification was not delivered")
+	}
+}
+
+func TestNew_InvalidConfiguration(t *testing.T) {
+	_, err := New(&config.NotificationsConfiguration{
+		Channels: []config.NotificationChannelConfiguration{{Name: "ops", Kind: "email", URL: "http://example.com"}},
+	})
+	assert.Error(t, err, "unknown kind")
+
+	_, err = New(&config.NotificationsConfiguration{
+		Routes: []config.NotificationRouteConfiguration{{Events: []string{"*"}, Channels: []string{"ops"}}},
+	})
+	assert.Error(t, err, "unknown channel")
+}
@@ -0,0 +1,47 @@
+package notifier
+
+import (
+	"context"
+	"net/http"
+
+	"leeta/internal/core/domain"
+)
+
+// slackMaxFields is the most fields a Slack section block can hold
+const slackMaxFields = 10
+
+// slack posts notifications to a Slack incoming webhook
+type slack struct {
+	client *http.Client
+	url    string
+}
+
+func (s *slack) send(ctx context.Context, notification *domain.Notification) error {
+	blocks := []map[string]any{
+		{
+			"type": "header",
+			"text": map[string]any{"type": "plain_text", "text": notification.Title},
+		},
+	}
+
+	if notification.Text != "" {
+		blocks = append(blocks, map[string]any{
+			"type": "section",
+			"text": map[string]any{"type": "mrkdwn", "text": notification.Text},
+		})
+	}
+
+	if len(notification.Fields) > 0 {
+		fields := make([]map[string]any, 0, min(len(notification.Fields), slackMaxFields))
+		for _, f := range notification.Fields[:min(len(notification.Fields), slackMaxFields)] {
+			fields = append(fields, map[string]any{"type": "mrkdwn", "text": "*" + f.Name + "*\n" + f.Value})
+		}
+		blocks = append(blocks, map[string]any{"type": "section", "fields": fields})
+	}
+
+	return postJSON(ctx, s.client, s.url, map[string]any{
+		// the text is shown in the notifications of the Slack clients
+		"text":   notification.Title,
+		"blocks": blocks,
+	})
+}
@@ -0,0 +1,47 @@
+package notifier
+
+import (
+	"context"
+	"net/http"
+
+	"leeta/internal/core/domain"
+)
+
+// teams posts notifications as adaptive cards to a Microsoft Teams incoming webhook or workflow
+type teams struct {
+	client *http.Client
+	url    string
+}
+
+func (t *teams) send(ctx context.Context, notification *domain.Notification) error {
+	body := []map[string]any{
+		{"type": "TextBlock", "text": notification.Title, "weight": "Bolder", "size": "Medium", "wrap": true},
+	}
+
+	if notification.Text != "" {
+		body = append(body, map[string]any{"type": "TextBlock", "text": notification.Text, "wrap": true})
+	}
+
+	if len(notification.Fields) > 0 {
+		facts := make([]map[string]any, 0, len(notification.Fields))
+		for _, f := range notification.Fields {
+			facts = append(facts, map[string]any{"title": f.Name, "value": f.Value})
+		}
+		body = append(body, map[string]any{"type": "FactSet", "facts": facts})
+	}
+
+	return postJSON(ctx, t.client, t.url, map[string]any{
+		"type": "message",
+		"attachments": []map[string]any{
+			{
+				"contentType": "application/vnd.microsoft.card.adaptive",
+				"content": map[string]any{
+					"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+					"type":    "AdaptiveCard",
+					"version": "1.4",
+					"body":    body,
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,35 @@
+package publisher
+
+import (
+	"context"
+
+	"leeta/internal/core/domain"
+	"leeta/internal/core/port"
+)
+
+/**
+ * Fanout implements port.EventPublisher interface
+ * and publishes every event with each of its publishers
+ */
+type Fanout struct {
+	publishers []port.EventPublisher
+}
+
+// NewFanout creates a new fanout publisher instance
+func NewFanout(publishers ...port.EventPublisher) *Fanout {
+	return &Fanout{
+		publishers,
+	}
+}
+
+// Publish publishes event with each publisher in order, stopping at the first failure.
+// Publishers that already accepted the event will receive it again when it is retried
+func (f *Fanout) Publish(ctx context.Context, event *domain.Event) error {
+	for _, publisher := range f.publishers {
+		if err := publisher.Publish(ctx, event); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,104 @@
+package publisher
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"strconv"
+	"time"
+
+	"leeta/internal/adapter/config"
+	"leeta/internal/core/domain"
+
+	"github.com/segmentio/kafka-go"
+)
+
+const (
+	defaultKafkaTopic        = "leeta.locations"
+	defaultKafkaClientID     = "leeta"
+	defaultKafkaWriteTimeout = 10 * time.Second
+)
+
+/**
+ * Kafka implements port.EventPublisher interface and
+ * writes every event as JSON to the configured topic.
+ * Events are keyed by the location id, so the events of
+ * a location land on the same partition in order
+ */
+type Kafka struct {
+	writer  *kafka.Writer
+	dialer  *kafka.Dialer
+	brokers []string
+}
+
+// NewKafka creates a new Kafka publisher instance
+func NewKafka(config *config.KafkaConfiguration) *Kafka {
+	topic := config.Topic
+	if topic == "" {
+		topic = defaultKafkaTopic
+	}
+
+	clientID := config.ClientID
+	if clientID == "" {
+		clientID = defaultKafkaClientID
+	}
+
+	writeTimeout := config.WriteTimeout
+	if writeTimeout <= 0 {
+		writeTimeout = defaultKafkaWriteTimeout
+	}
+
+	dialer := &kafka.Dialer{ClientID: clientID, Timeout: writeTimeout}
+
+	return &Kafka{
+		&kafka.Writer{
+			Addr:         kafka.TCP(config.Brokers...),
+			Topic:        topic,
+			Balancer:     &kafka.Hash{},
+			RequiredAcks: kafka.RequireAll,
+			// the relay publishes one event at a time and waits for it to be accepted
+			BatchSize:    1,
+			WriteTimeout: writeTimeout,
+			Transport:    &kafka.Transport{ClientID: clientID, Dial: dialer.DialFunc},
+		},
+		dialer,
+		config.Brokers,
+	}
+}
+
+// Publish writes event to the topic and waits until every in-sync replica acknowledged it
+func (k *Kafka) Publish(ctx context.Context, event *domain.Event) error {
+	value, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	return k.writer.WriteMessages(ctx, kafka.Message{
+		Key:   []byte(event.AggregateID),
+		Value: value,
+		Headers: []kafka.Header{
+			{Key: "event-id", Value: []byte(strconv.FormatInt(event.ID, 10))},
+			{Key: "event-type", Value: []byte(event.Type)},
+		},
+		Time: event.CreatedAt,
+	})
+}
+
+// Ping connects to the first broker that answers, it fails if none of them does
+func (k *Kafka) Ping(ctx context.Context) error {
+	var err error
+	for _, broker := range k.brokers {
+		var conn net.Conn
+		conn, err = k.dialer.DialContext(ctx, "tcp", broker)
+		if err == nil {
+			return conn.Close()
+		}
+	}
+
+	return err
+}
+
+// Close flushes the pending writes and closes the connections to the brokers
+func (k *Kafka) Close() error {
+	return k.writer.Close()
+}
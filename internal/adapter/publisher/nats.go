@@ -0,0 +1,118 @@
+package publisher
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"leeta/internal/adapter/config"
+	"leeta/internal/core/domain"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+const (
+	// DefaultNATSStream is the stream the events are published to when none is configured
+	DefaultNATSStream = "LEETA_LOCATIONS"
+
+	defaultNATSSubject = "leeta"
+	defaultNATSTimeout = 5 * time.Second
+)
+
+/**
+ * NATS implements port.EventPublisher interface and
+ * publishes every event as JSON to a JetStream stream,
+ * on the subject of its type. The event id is the message
+ * id, so JetStream drops the events published twice
+ */
+type NATS struct {
+	conn    *nats.Conn
+	js      jetstream.JetStream
+	subject string
+	timeout time.Duration
+}
+
+// NewNATS connects to NATS and creates the stream, or updates it to the configured subjects
+func NewNATS(ctx context.Context, config *config.NATSConfiguration) (*NATS, error) {
+	stream := config.Stream
+	if stream == "" {
+		stream = DefaultNATSStream
+	}
+
+	subject := config.Subject
+	if subject == "" {
+		subject = defaultNATSSubject
+	}
+
+	timeout := config.Timeout
+	if timeout <= 0 {
+		timeout = defaultNATSTimeout
+	}
+
+	conn, err := nats.Connect(config.URL, nats.Name("leeta"), nats.Timeout(timeout), nats.MaxReconnects(-1))
+	if err != nil {
+		return nil, err
+	}
+
+	js, err := jetstream.New(conn)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	_, err = js.CreateOrUpdateStream(ctx, jetstream.StreamConfig{
+		Name:     stream,
+		Subjects: []string{subject + ".>"},
+	})
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("creating stream %s: %w", stream, err)
+	}
+
+	return &NATS{
+		conn,
+		js,
+		subject,
+		timeout,
+	}, nil
+}
+
+// Publish publishes event and waits until the stream stored it
+func (n *NATS) Publish(ctx context.Context, event *domain.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	msg := nats.NewMsg(n.subject + "." + string(event.Type))
+	msg.Data = data
+	msg.Header.Set("Event-ID", strconv.FormatInt(event.ID, 10))
+	msg.Header.Set("Event-Type", string(event.Type))
+
+	ctx, cancel := context.WithTimeout(ctx, n.timeout)
+	defer cancel()
+
+	_, err = n.js.PublishMsg(ctx, msg, jetstream.WithMsgID(strconv.FormatInt(event.ID, 10)))
+	return err
+}
+
+// Ping fails when the connection to NATS is down
+func (n *NATS) Ping(ctx context.Context) error {
+	if !n.conn.IsConnected() {
+		return errors.New("not connected to NATS")
+	}
+
+	return n.conn.FlushWithContext(ctx)
+}
+
+// Close closes the connection to NATS
+func (n *NATS) Close() {
+	n.conn.Close()
+}
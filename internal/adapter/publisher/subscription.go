@@ -0,0 +1,64 @@
+package publisher
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"leeta/internal/adapter/config"
+	"leeta/internal/core/domain"
+)
+
+/**
+ * SubscriptionWebhook implements port.MatchDelivery interface
+ * and posts every match as JSON to the webhook of its subscription
+ */
+type SubscriptionWebhook struct {
+	client *http.Client
+}
+
+// NewSubscriptionWebhook creates a new subscription webhook instance
+func NewSubscriptionWebhook(config *config.SubscriptionsConfiguration) *SubscriptionWebhook {
+	timeout := config.WebhookTimeout
+	if timeout <= 0 {
+		timeout = defaultWebhookTimeout
+	}
+
+	return &SubscriptionWebhook{
+		&http.Client{Timeout: timeout},
+	}
+}
+
+// Deliver posts match to the webhook of subscription, failing unless it answers with a 2xx status.
+// Subscriptions without a webhook are skipped
+func (sw *SubscriptionWebhook) Deliver(ctx context.Context, subscription *domain.Subscription, match *domain.ProximityMatch) error {
+	if subscription.WebhookURL == "" {
+		return nil
+	}
+
+	body, err := json.Marshal(match)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Subscription-ID", subscription.ID)
+
+	resp, err := sw.client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook %s answered with status %d", subscription.WebhookURL, resp.StatusCode)
+	}
+
+	return nil
+}
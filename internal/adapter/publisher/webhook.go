@@ -0,0 +1,69 @@
+package publisher
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"leeta/internal/adapter/config"
+	"leeta/internal/core/domain"
+)
+
+const defaultWebhookTimeout = 5 * time.Second
+
+/**
+ * Webhook implements port.EventPublisher interface
+ * and posts every event as JSON to the configured URLs
+ */
+type Webhook struct {
+	client *http.Client
+	urls   []string
+}
+
+// NewWebhook creates a new webhook publisher instance
+func NewWebhook(config *config.OutboxConfiguration) *Webhook {
+	timeout := config.WebhookTimeout
+	if timeout <= 0 {
+		timeout = defaultWebhookTimeout
+	}
+
+	return &Webhook{
+		&http.Client{Timeout: timeout},
+		config.Webhooks,
+	}
+}
+
+// Publish posts event to every webhook, failing if any of them does not answer with a 2xx status.
+// Webhooks that already accepted the event will receive it again when it is retried
+func (wh *Webhook) Publish(ctx context.Context, event *domain.Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	for _, url := range wh.urls {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Event-ID", strconv.FormatInt(event.ID, 10))
+		req.Header.Set("X-Event-Type", string(event.Type))
+
+		resp, err := wh.client.Do(req)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			return fmt.Errorf("webhook %s answered with status %d", url, resp.StatusCode)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,118 @@
+// Package routing computes travel times with an OSRM server
+package routing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"leeta/internal/adapter/config"
+	"leeta/internal/core/domain"
+)
+
+const defaultTimeout = 5 * time.Second
+
+// defaultProfiles are the OSRM profiles of the modes of travel, the car, foot and bike profiles shipped with OSRM
+var defaultProfiles = map[domain.TravelMode]string{
+	domain.TravelDriving: "car",
+	domain.TravelWalking: "foot",
+	domain.TravelCycling: "bike",
+}
+
+/**
+ * OSRM implements port.Routing interface with the table
+ * service of an OSRM server, computing the travel times
+ * to every destination in a single request
+ */
+type OSRM struct {
+	client   *http.Client
+	url      string
+	profiles map[domain.TravelMode]string
+}
+
+// New creates a new OSRM client instance from the routing configuration
+func New(config *config.RoutingConfiguration) (*OSRM, error) {
+	timeout := config.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	profiles := make(map[domain.TravelMode]string, len(defaultProfiles))
+	for mode, profile := range defaultProfiles {
+		profiles[mode] = profile
+	}
+	for name, profile := range config.Profiles {
+		mode, ok := domain.ParseTravelMode(name)
+		if !ok {
+			return nil, fmt.Errorf("invalid routing mode %q, expected driving, walking or cycling", name)
+		}
+		profiles[mode] = profile
+	}
+
+	return &OSRM{
+		&http.Client{Timeout: timeout},
+		strings.TrimSuffix(config.URL, "/"),
+		profiles,
+	}, nil
+}
+
+// tableResponse is the answer of the table service, durations are null for unreachable destinations
+type tableResponse struct {
+	Code      string       `json:"code"`
+	Message   string       `json:"message"`
+	Durations [][]*float64 `json:"durations"`
+}
+
+func (o *OSRM) TravelTimes(ctx context.Context, origin domain.Point, destinations []domain.Point, mode domain.TravelMode) ([]float64, error) {
+	if len(destinations) == 0 {
+		return nil, nil
+	}
+
+	// coordinates are longitude first, the origin is the only source
+	coordinates := make([]string, 0, len(destinations)+1)
+	for _, p := range append([]domain.Point{origin}, destinations...) {
+		coordinates = append(coordinates,
+			strconv.FormatFloat(p.Longitude, 'f', -1, 64)+","+strconv.FormatFloat(p.Latitude, 'f', -1, 64))
+	}
+
+	endpoint := fmt.Sprintf("%s/table/v1/%s/%s?sources=0&annotations=duration",
+		o.url, url.PathEscape(o.profiles[mode]), strings.Join(coordinates, ";"))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var table tableResponse
+	if err := json.NewDecoder(resp.Body).Decode(&table); err != nil {
+		return nil, fmt.Errorf("decoding OSRM answer with status %d: %w", resp.StatusCode, err)
+	}
+	if table.Code != "Ok" {
+		return nil, fmt.Errorf("OSRM answered with status %d: %s: %s", resp.StatusCode, table.Code, table.Message)
+	}
+	if len(table.Durations) != 1 || len(table.Durations[0]) != len(destinations)+1 {
+		return nil, fmt.Errorf("OSRM answered with %d rows of durations for 1 source", len(table.Durations))
+	}
+
+	times := make([]float64, len(destinations))
+	for i, duration := range table.Durations[0][1:] {
+		if duration == nil {
+			times[i] = -1
+			continue
+		}
+		times[i] = *duration
+	}
+
+	return times, nil
+}
@@ -0,0 +1,56 @@
+package routing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"leeta/internal/adapter/config"
+	"leeta/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOSRM_TravelTimes(t *testing.T) {
+	var path, query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path, query = r.URL.Path, r.URL.RawQuery
+		w.Write([]byte(`{"code":"Ok","durations":[[0,312.5,null]]}`))
+	}))
+	defer server.Close()
+
+	osrm, err := New(&config.RoutingConfiguration{URL: server.URL + "/", Profiles: map[string]string{"cycling": "bicycle"}})
+	require.NoError(t, err)
+
+	times, err := osrm.TravelTimes(context.Background(),
+		domain.Point{Latitude: 6.4474, Longitude: 3.4723},
+		[]domain.Point{{Latitude: 6.4281, Longitude: 3.4219}, {Latitude: 6.5244, Longitude: 3.3792}},
+		domain.TravelCycling,
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "/table/v1/bicycle/3.4723,6.4474;3.4219,6.4281;3.3792,6.5244", path)
+	assert.Equal(t, "sources=0&annotations=duration", query)
+	assert.Equal(t, []float64{312.5, -1}, times)
+}
+
+func TestOSRM_TravelTimesError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"code":"TooBig","message":"Too many table coordinates"}`))
+	}))
+	defer server.Close()
+
+	osrm, err := New(&config.RoutingConfiguration{URL: server.URL})
+	require.NoError(t, err)
+
+	_, err = osrm.TravelTimes(context.Background(), domain.Point{}, []domain.Point{{Latitude: 1, Longitude: 1}}, domain.TravelDriving)
+	assert.ErrorContains(t, err, "TooBig")
+}
+
+func TestNew_InvalidMode(t *testing.T) {
+	_, err := New(&config.RoutingConfiguration{URL: "http://localhost:5000", Profiles: map[string]string{"flying": "plane"}})
+	assert.Error(t, err)
+}
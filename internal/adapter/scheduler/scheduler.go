@@ -0,0 +1,172 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"leeta/internal/adapter/config"
+	"leeta/internal/adapter/logger"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+)
+
+var (
+	jobRuns = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "leeta",
+		Subsystem: "scheduler",
+		Name:      "job_runs_total",
+		Help:      "Scheduled job runs by outcome: success, error or skipped while the previous run was still going.",
+	}, []string{"job", "outcome"})
+
+	jobDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "leeta",
+		Subsystem: "scheduler",
+		Name:      "job_duration_seconds",
+		Help:      "Duration of the scheduled job runs.",
+		Buckets:   []float64{.1, .5, 1, 5, 10, 30, 60, 300, 900},
+	}, []string{"job"})
+
+	jobLastSuccess = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "leeta",
+		Subsystem: "scheduler",
+		Name:      "job_last_success_timestamp_seconds",
+		Help:      "Unix time of the last successful run of the scheduled jobs.",
+	}, []string{"job"})
+)
+
+// Job is a recurring task of the scheduler
+type Job struct {
+	Name string
+	// Schedule is the default schedule of the job, the configuration may override it
+	Schedule string
+	// RunOnStart runs the job as soon as the scheduler starts, on top of its schedule
+	RunOnStart bool
+	Run        func(ctx context.Context) error
+}
+
+// scheduledJob is a job with its configured schedule
+type scheduledJob struct {
+	Job
+	schedule cron.Schedule
+	timeout  time.Duration
+	running  atomic.Bool
+}
+
+/**
+ * Scheduler runs the recurring jobs on their cron schedules.
+ * A run is skipped while the previous run of the same job
+ * is still going, so slow jobs never overlap themselves
+ */
+type Scheduler struct {
+	jobs []*scheduledJob
+}
+
+// New creates a new scheduler instance for jobs, applying the schedules, timeouts and
+// disabled jobs of the configuration. It fails on an invalid schedule or an unknown job
+func New(config *config.SchedulerConfiguration, jobs ...Job) (*Scheduler, error) {
+	known := make(map[string]bool, len(jobs))
+	for _, job := range jobs {
+		known[job.Name] = true
+	}
+
+	overrides := make(map[string]int, len(config.Jobs))
+	for i, override := range config.Jobs {
+		if !known[override.Name] {
+			return nil, fmt.Errorf("unknown scheduled job %q", override.Name)
+		}
+		overrides[override.Name] = i
+	}
+
+	s := &Scheduler{}
+	for _, job := range jobs {
+		var timeout time.Duration
+		if i, ok := overrides[job.Name]; ok {
+			override := config.Jobs[i]
+			if override.Disabled {
+				continue
+			}
+			if override.Schedule != "" {
+				job.Schedule = override.Schedule
+			}
+			timeout = override.Timeout
+		}
+
+		schedule, err := cron.ParseStandard(job.Schedule)
+		if err != nil {
+			return nil, fmt.Errorf("invalid schedule %q of job %q: %w", job.Schedule, job.Name, err)
+		}
+
+		s.jobs = append(s.jobs, &scheduledJob{Job: job, schedule: schedule, timeout: timeout})
+	}
+
+	return s, nil
+}
+
+// Run runs the jobs on their schedules until ctx is done, then waits for the running jobs,
+// whose context is done too
+func (s *Scheduler) Run(ctx context.Context) {
+	c := cron.New()
+	var started sync.WaitGroup
+
+	for _, job := range s.jobs {
+		jobCtx := logger.WithCtx(ctx, logger.FromCtx(ctx).With(zap.String("job", job.Name)))
+		run := func() { s.run(jobCtx, job) }
+
+		c.Schedule(job.schedule, cron.FuncJob(run))
+		if job.RunOnStart {
+			started.Add(1)
+			go func() {
+				defer started.Done()
+				run()
+			}()
+		}
+	}
+
+	c.Start()
+	<-ctx.Done()
+
+	<-c.Stop().Done()
+	started.Wait()
+}
+
+// run runs job unless it is still running, recording the outcome
+func (s *Scheduler) run(ctx context.Context, job *scheduledJob) {
+	log := logger.FromCtx(ctx)
+
+	if !job.running.CompareAndSwap(false, true) {
+		jobRuns.WithLabelValues(job.Name, "skipped").Inc()
+		log.Warn("Skipping scheduled job, its previous run is still going")
+		return
+	}
+	defer job.running.Store(false)
+
+	runCtx := ctx
+	if job.timeout > 0 {
+		var cancel context.CancelFunc
+		runCtx, cancel = context.WithTimeout(ctx, job.timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	err := job.Run(runCtx)
+	jobDuration.WithLabelValues(job.Name).Observe(time.Since(start).Seconds())
+
+	if err != nil {
+		jobRuns.WithLabelValues(job.Name, "error").Inc()
+		// runs canceled by the shutdown are not failures worth reporting
+		if ctx.Err() == nil {
+			log.Error("Scheduled job failed", zap.Duration("elapsed", time.Since(start)), zap.Error(err))
+		}
+		return
+	}
+
+	jobRuns.WithLabelValues(job.Name, "success").Inc()
+	jobLastSuccess.WithLabelValues(job.Name).SetToCurrentTime()
+	log.Debug("Scheduled job finished", zap.Duration("elapsed", time.Since(start)))
+}
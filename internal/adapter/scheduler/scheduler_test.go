@@ -0,0 +1,80 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"leeta/internal/adapter/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func noop(ctx context.Context) error { return nil }
+
+func TestNew_AppliesConfiguration(t *testing.T) {
+	s, err := New(&config.SchedulerConfiguration{
+		Jobs: []config.JobConfiguration{
+			{Name: "warm", Schedule: "*/5 * * * *", Timeout: time.Minute},
+			{Name: "purge", Disabled: true},
+		},
+	},
+		Job{Name: "warm", Schedule: "@hourly", Run: noop},
+		Job{Name: "purge", Schedule: "@daily", Run: noop},
+	)
+	require.NoError(t, err)
+
+	require.Len(t, s.jobs, 1, "disabled jobs are not scheduled")
+	assert.Equal(t, "*/5 * * * *", s.jobs[0].Schedule)
+	assert.Equal(t, time.Minute, s.jobs[0].timeout)
+
+	_, err = New(&config.SchedulerConfiguration{Jobs: []config.JobConfiguration{{Name: "osm"}}}, Job{Name: "warm", Schedule: "@hourly", Run: noop})
+	assert.Error(t, err, "unknown job")
+
+	_, err = New(&config.SchedulerConfiguration{}, Job{Name: "warm", Schedule: "every hour", Run: noop})
+	assert.Error(t, err, "invalid schedule")
+}
+
+func TestScheduler_SkipsOverlappingRuns(t *testing.T) {
+	calls := 0
+	s, err := New(&config.SchedulerConfiguration{}, Job{Name: "slow", Schedule: "@hourly", Run: func(ctx context.Context) error {
+		calls++
+		return nil
+	}})
+	require.NoError(t, err)
+
+	job := s.jobs[0]
+	job.running.Store(true)
+	s.run(context.Background(), job)
+	assert.Equal(t, 0, calls, "a run is skipped while the previous one is going")
+
+	job.running.Store(false)
+	s.run(context.Background(), job)
+	assert.Equal(t, 1, calls)
+}
+
+func TestScheduler_RunOnStart(t *testing.T) {
+	ran := make(chan struct{}, 1)
+	s, err := New(&config.SchedulerConfiguration{}, Job{Name: "start", Schedule: "@yearly", RunOnStart: true, Run: func(ctx context.Context) error {
+		ran <- struct{}{}
+		return nil
+	}})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		s.Run(ctx)
+		close(done)
+	}()
+
+	select {
+	case <-ran:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the job did not run on start")
+	}
+
+	cancel()
+	<-done
+}
@@ -0,0 +1,227 @@
+// Package search indexes and searches the locations with a Meilisearch server
+package search
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"leeta/internal/adapter/config"
+	"leeta/internal/core/domain"
+)
+
+const (
+	defaultTimeout = 5 * time.Second
+	defaultIndex   = "locations"
+)
+
+// settings are the settings of the index, the searchable attributes rank the matches in their order
+var settings = map[string]any{
+	"searchableAttributes": []string{"name", "names", "slug", "category"},
+	"filterableAttributes": []string{"category", "valid_from_unix"},
+}
+
+/**
+ * Meilisearch implements port.SearchIndex interface with the API
+ * of a Meilisearch server, which tolerates typos and counts the
+ * matches per category. Writes are queued by Meilisearch as tasks,
+ * so they show up in the searches shortly after they are accepted
+ */
+type Meilisearch struct {
+	client *http.Client
+	url    string
+	apiKey string
+	index  string
+	now    func() time.Time
+
+	mu sync.Mutex
+	// configured is set once the settings of the index are applied, before the first write or search
+	configured bool
+}
+
+// New creates a new Meilisearch client instance from the search configuration
+func New(config *config.SearchConfiguration) *Meilisearch {
+	timeout := config.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	index := config.Index
+	if index == "" {
+		index = defaultIndex
+	}
+
+	return &Meilisearch{
+		client: &http.Client{Timeout: timeout},
+		url:    strings.TrimSuffix(config.URL, "/"),
+		apiKey: config.APIKey,
+		index:  index,
+		now:    time.Now,
+	}
+}
+
+// document is a location as it is indexed
+type document struct {
+	domain.Location
+	// ValidFromUnix is the time the location becomes valid in Unix seconds, 0 for one always valid,
+	// as filters only compare numbers
+	ValidFromUnix int64 `json:"valid_from_unix"`
+}
+
+// searchResponse is the answer of the search API
+type searchResponse struct {
+	Hits               []domain.Location         `json:"hits"`
+	EstimatedTotalHits int                       `json:"estimatedTotalHits"`
+	FacetDistribution  map[string]map[string]int `json:"facetDistribution"`
+}
+
+// errorResponse is the body of the answers of Meilisearch with an error status
+type errorResponse struct {
+	Message string `json:"message"`
+	Code    string `json:"code"`
+}
+
+func (m *Meilisearch) IndexLocations(ctx context.Context, locations []domain.Location) error {
+	if err := m.configure(ctx); err != nil {
+		return err
+	}
+
+	documents := make([]document, len(locations))
+	for i, location := range locations {
+		documents[i].Location = location
+		if location.ValidFrom != nil {
+			documents[i].ValidFromUnix = location.ValidFrom.Unix()
+		}
+	}
+
+	return m.do(ctx, http.MethodPost, m.indexPath()+"/documents?primaryKey=id", documents, nil)
+}
+
+func (m *Meilisearch) DeleteLocation(ctx context.Context, id string) error {
+	return m.do(ctx, http.MethodDelete, m.indexPath()+"/documents/"+url.PathEscape(id), nil, nil)
+}
+
+func (m *Meilisearch) SearchLocations(ctx context.Context, req *domain.SearchLocationsRequest) (*domain.LocationSearchResult, error) {
+	if err := m.configure(ctx); err != nil {
+		return nil, err
+	}
+
+	// the conditions of the filter are all met, the ones of an inner list are alternatives
+	filter := []any{fmt.Sprintf("valid_from_unix <= %d", m.now().Unix())}
+	if len(req.Categories) > 0 {
+		categories := make([]string, len(req.Categories))
+		for i, category := range req.Categories {
+			categories[i] = "category = " + quote(category)
+		}
+		filter = append(filter, categories)
+	}
+
+	var search searchResponse
+	err := m.do(ctx, http.MethodPost, m.indexPath()+"/search", map[string]any{
+		"q":      req.Query,
+		"limit":  req.Limit,
+		"offset": req.Offset,
+		"filter": filter,
+		"facets": []string{"category"},
+	}, &search)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domain.LocationSearchResult{
+		Locations:  search.Hits,
+		Total:      search.EstimatedTotalHits,
+		Categories: search.FacetDistribution["category"],
+	}
+	if result.Locations == nil {
+		result.Locations = []domain.Location{}
+	}
+	if result.Categories == nil {
+		result.Categories = map[string]int{}
+	}
+
+	return result, nil
+}
+
+// Ping checks that the server is up
+func (m *Meilisearch) Ping(ctx context.Context) error {
+	return m.do(ctx, http.MethodGet, "/health", nil, nil)
+}
+
+// configure applies the settings of the index, creating it when it does not exist. It is retried by the
+// next request when it fails
+func (m *Meilisearch) configure(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.configured {
+		return nil
+	}
+	if err := m.do(ctx, http.MethodPatch, m.indexPath()+"/settings", settings, nil); err != nil {
+		return fmt.Errorf("applying the settings of the index: %w", err)
+	}
+	m.configured = true
+
+	return nil
+}
+
+func (m *Meilisearch) indexPath() string {
+	return "/indexes/" + url.PathEscape(m.index)
+}
+
+// do sends body as JSON to path and decodes the answer into out, when it is not nil
+func (m *Meilisearch) do(ctx context.Context, method, path string, body, out any) error {
+	var reader io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(b)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, method, m.url+path, reader)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+	if m.apiKey != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+m.apiKey)
+	}
+
+	resp, err := m.client.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		var answer errorResponse
+		if err := json.NewDecoder(resp.Body).Decode(&answer); err != nil {
+			return fmt.Errorf("Meilisearch answered with status %d", resp.StatusCode)
+		}
+		return fmt.Errorf("Meilisearch answered with status %d: %s: %s", resp.StatusCode, answer.Code, answer.Message)
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decoding Meilisearch answer: %w", err)
+	}
+
+	return nil
+}
+
+// quote quotes a value of a filter, escaping its quotes and backslashes
+func quote(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
+}
@@ -0,0 +1,89 @@
+package search
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"leeta/internal/adapter/config"
+	"leeta/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMeilisearch(t *testing.T) {
+	var requests []string
+	bodies := make(map[string]string)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer key", r.Header.Get("Authorization"))
+		request := r.Method + " " + r.URL.RequestURI()
+		requests = append(requests, request)
+		body, _ := io.ReadAll(r.Body)
+		bodies[request] = string(body)
+
+		if r.URL.Path == "/indexes/places/search" {
+			w.Write([]byte(`{"hits":[{"id":"1","name":"Lekki Depot","slug":"lekki-depot","latitude":6.4474,"longitude":3.4723,
+				"category":"depot","valid_from_unix":0}],"estimatedTotalHits":1,"facetDistribution":{"category":{"depot":1}}}`))
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`{"taskUid":1,"status":"enqueued"}`))
+	}))
+	defer server.Close()
+
+	m := New(&config.SearchConfiguration{URL: server.URL + "/", APIKey: "key", Index: "places"})
+	m.now = func() time.Time { return time.Unix(1792108800, 0) }
+	ctx := context.Background()
+
+	validFrom := time.Unix(1792000000, 0)
+	require.NoError(t, m.IndexLocations(ctx, []domain.Location{
+		{ID: "1", Name: "Lekki Depot", Latitude: 6.4474, Longitude: 3.4723, Category: "depot"},
+		{ID: "2", Name: "Ikeja Pop-up", Latitude: 6.6018, Longitude: 3.3515, ValidFrom: &validFrom},
+	}))
+	require.NoError(t, m.DeleteLocation(ctx, "3"))
+
+	result, err := m.SearchLocations(ctx, &domain.SearchLocationsRequest{Query: "leki", Categories: []string{"depot", `"quoted"`}, Limit: 20})
+	require.NoError(t, err)
+	assert.Equal(t, &domain.LocationSearchResult{
+		Locations:  []domain.Location{{ID: "1", Name: "Lekki Depot", Slug: "lekki-depot", Latitude: 6.4474, Longitude: 3.4723, Category: "depot"}},
+		Total:      1,
+		Categories: map[string]int{"depot": 1},
+	}, result)
+
+	// the settings are applied once, before the first write
+	assert.Equal(t, []string{
+		"PATCH /indexes/places/settings",
+		"POST /indexes/places/documents?primaryKey=id",
+		"DELETE /indexes/places/documents/3",
+		"POST /indexes/places/search",
+	}, requests)
+	assert.JSONEq(t, `[
+		{"id":"1","name":"Lekki Depot","slug":"","latitude":6.4474,"longitude":3.4723,"category":"depot",
+		 "created_at":"0001-01-01T00:00:00Z","version":0,"updated_at":"0001-01-01T00:00:00Z","status":"","valid_from_unix":0},
+		{"id":"2","name":"Ikeja Pop-up","slug":"","latitude":6.6018,"longitude":3.3515,"valid_from":"`+validFrom.Format(time.RFC3339)+`",
+		 "created_at":"0001-01-01T00:00:00Z","version":0,"updated_at":"0001-01-01T00:00:00Z","status":"","valid_from_unix":1792000000}
+	]`, bodies["POST /indexes/places/documents?primaryKey=id"])
+	assert.JSONEq(t, `{"q":"leki","limit":20,"offset":0,"facets":["category"],
+		"filter":["valid_from_unix <= 1792108800",["category = \"depot\"","category = \"\\\"quoted\\\"\""]]}`, bodies["POST /indexes/places/search"])
+}
+
+func TestMeilisearch_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"message":"The provided API key is invalid.","code":"invalid_api_key","type":"auth"}`))
+	}))
+	defer server.Close()
+
+	m := New(&config.SearchConfiguration{URL: server.URL})
+
+	_, err := m.SearchLocations(context.Background(), &domain.SearchLocationsRequest{Query: "lekki"})
+	assert.EqualError(t, err, "applying the settings of the index: Meilisearch answered with status 403: invalid_api_key: The provided API key is invalid.")
+
+	// the settings are applied again by the next request
+	err = m.IndexLocations(context.Background(), []domain.Location{{ID: "1"}})
+	assert.ErrorContains(t, err, "applying the settings of the index")
+}
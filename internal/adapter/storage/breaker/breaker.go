@@ -0,0 +1,124 @@
+package breaker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"leeta/internal/adapter/config"
+	"leeta/internal/adapter/logger"
+	"leeta/internal/core/domain"
+	"leeta/internal/core/port"
+
+	"github.com/sony/gobreaker/v2"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultFailureThreshold = 5
+	defaultTimeout          = 30 * time.Second
+)
+
+/**
+ * Breaker is a circuit breaker for calls returning a domain.CError.
+ * Internal errors count as failures, other errors are the caller's
+ * fault and count as successes. Once open, calls fail fast with
+ * domain.ErrServiceUnavailable until a half-open probe succeeds
+ */
+type Breaker struct {
+	cb *gobreaker.CircuitBreaker[any]
+}
+
+// canceledError marks a call that failed because its context is done, which says nothing about the database
+type canceledError struct {
+	domain.CError
+}
+
+// New creates a new circuit breaker instance. Trips are reported to notifier, or not at all if it is nil
+func New(name string, config *config.BreakerConfiguration, notifier port.Notifier) *Breaker {
+	threshold := config.FailureThreshold
+	if threshold == 0 {
+		threshold = defaultFailureThreshold
+	}
+
+	timeout := config.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	cb := gobreaker.NewCircuitBreaker[any](gobreaker.Settings{
+		Name:        name,
+		MaxRequests: config.MaxRequests,
+		Interval:    config.Interval,
+		Timeout:     timeout,
+		ReadyToTrip: func(counts gobreaker.Counts) bool {
+			return counts.ConsecutiveFailures >= threshold
+		},
+		OnStateChange: func(name string, from, to gobreaker.State) {
+			l := logger.FromCtx(context.Background()).With(zap.String("breaker", name), zap.Stringer("from", from), zap.Stringer("to", to))
+			if to == gobreaker.StateOpen {
+				l.Error("Circuit breaker opened")
+				if notifier != nil {
+					notifier.Notify(context.Background(), &domain.Notification{
+						Type:  domain.NotificationBreakerOpened,
+						Title: "Circuit breaker " + name + " opened",
+						Text:  fmt.Sprintf("Calls fail fast for %s before a probe is let through.", timeout),
+						Fields: []domain.NotificationField{
+							{Name: "Breaker", Value: name},
+							{Name: "From", Value: from.String()},
+						},
+					})
+				}
+			} else {
+				l.Info("Circuit breaker state changed")
+			}
+		},
+		IsSuccessful: func(err error) bool {
+			var cerr domain.CError
+			return err == nil || errors.As(err, &cerr) && cerr.Code() < http.StatusInternalServerError
+		},
+		IsExcluded: func(err error) bool {
+			return errors.As(err, &canceledError{})
+		},
+	})
+
+	return &Breaker{
+		cb,
+	}
+}
+
+// Do calls fn through the breaker, failing with domain.ErrServiceUnavailable without calling fn while the breaker is open
+func (b *Breaker) Do(ctx context.Context, fn func() domain.CError) domain.CError {
+	_, err := b.cb.Execute(func() (any, error) {
+		cerr := fn()
+		if cerr == nil {
+			return nil, nil
+		}
+		if ctx.Err() != nil {
+			return nil, canceledError{cerr}
+		}
+		return nil, cerr
+	})
+
+	if err == nil {
+		return nil
+	}
+
+	if errors.Is(err, gobreaker.ErrOpenState) || errors.Is(err, gobreaker.ErrTooManyRequests) {
+		return domain.ErrServiceUnavailable
+	}
+
+	var canceled canceledError
+	if errors.As(err, &canceled) {
+		return canceled.CError
+	}
+
+	return err.(domain.CError)
+}
+
+// State returns the current state of the breaker
+func (b *Breaker) State() gobreaker.State {
+	return b.cb.State()
+}
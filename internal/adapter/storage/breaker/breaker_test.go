@@ -0,0 +1,83 @@
+package breaker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"leeta/internal/adapter/config"
+	"leeta/internal/core/domain"
+	"leeta/internal/core/port/mock"
+
+	"github.com/sony/gobreaker/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBreaker_OpensAfterFailures(t *testing.T) {
+	b := New("test", &config.BreakerConfiguration{FailureThreshold: 2, Timeout: time.Hour}, nil)
+	ctx := context.Background()
+
+	calls := 0
+	fail := func() domain.CError {
+		calls++
+		return domain.ErrInternal
+	}
+
+	assert.Equal(t, domain.ErrInternal, b.Do(ctx, fail))
+	assert.Equal(t, domain.ErrInternal, b.Do(ctx, fail))
+	assert.Equal(t, gobreaker.StateOpen, b.State())
+
+	assert.Equal(t, domain.ErrServiceUnavailable, b.Do(ctx, fail))
+	assert.Equal(t, 2, calls)
+}
+
+func TestBreaker_ClientErrorsAreSuccesses(t *testing.T) {
+	b := New("test", &config.BreakerConfiguration{FailureThreshold: 1}, nil)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, domain.ErrDataNotFound, b.Do(ctx, func() domain.CError {
+			return domain.ErrDataNotFound
+		}))
+	}
+	assert.Equal(t, gobreaker.StateClosed, b.State())
+}
+
+func TestBreaker_IgnoresCanceledCalls(t *testing.T) {
+	b := New("test", &config.BreakerConfiguration{FailureThreshold: 1}, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	assert.Equal(t, domain.ErrInternal, b.Do(ctx, func() domain.CError {
+		return domain.ErrInternal
+	}))
+	assert.Equal(t, gobreaker.StateClosed, b.State())
+}
+
+func TestBreaker_HalfOpenProbe(t *testing.T) {
+	b := New("test", &config.BreakerConfiguration{FailureThreshold: 1, Timeout: 10 * time.Millisecond}, nil)
+	ctx := context.Background()
+
+	b.Do(ctx, func() domain.CError { return domain.ErrInternal })
+	assert.Equal(t, gobreaker.StateOpen, b.State())
+
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, gobreaker.StateHalfOpen, b.State())
+
+	assert.Nil(t, b.Do(ctx, func() domain.CError { return nil }))
+	assert.Equal(t, gobreaker.StateClosed, b.State())
+}
+
+func TestBreaker_NotifiesWhenOpened(t *testing.T) {
+	notifier := &mock.NotifierMock{
+		NotifyFunc: func(ctx context.Context, notification *domain.Notification) {},
+	}
+	b := New("test", &config.BreakerConfiguration{FailureThreshold: 1, Timeout: time.Hour}, notifier)
+
+	b.Do(context.Background(), func() domain.CError { return domain.ErrInternal })
+
+	calls := notifier.NotifyCalls()
+	if assert.Len(t, calls, 1) {
+		assert.Equal(t, domain.NotificationBreakerOpened, calls[0].Notification.Type)
+	}
+}
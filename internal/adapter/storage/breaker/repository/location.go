@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"context"
+
+	"leeta/internal/adapter/storage/breaker"
+	"leeta/internal/core/domain"
+	"leeta/internal/core/port"
+)
+
+/**
+ * LocationRepository implements port.LocationRepository interface
+ * and guards the calls to another location repository with a circuit breaker
+ */
+type LocationRepository struct {
+	next    port.LocationRepository
+	breaker *breaker.Breaker
+}
+
+// NewLocationRepository creates a new location repository wrapping next with breaker
+func NewLocationRepository(next port.LocationRepository, breaker *breaker.Breaker) *LocationRepository {
+	return &LocationRepository{
+		next,
+		breaker,
+	}
+}
+
+func (lr *LocationRepository) CreateLocation(ctx context.Context, location *domain.Location) (*domain.Location, domain.CError) {
+	return call(ctx, lr, func() (*domain.Location, domain.CError) {
+		return lr.next.CreateLocation(ctx, location)
+	})
+}
+
+func (lr *LocationRepository) CopyLocations(ctx context.Context, locations []domain.Location) (int64, domain.CError) {
+	return call(ctx, lr, func() (int64, domain.CError) {
+		return lr.next.CopyLocations(ctx, locations)
+	})
+}
+
+func (lr *LocationRepository) ExpireLocations(ctx context.Context, limit int) (int64, domain.CError) {
+	return call(ctx, lr, func() (int64, domain.CError) {
+		return lr.next.ExpireLocations(ctx, limit)
+	})
+}
+
+func (lr *LocationRepository) GetLocationByID(ctx context.Context, id string) (*domain.Location, domain.CError) {
+	return call(ctx, lr, func() (*domain.Location, domain.CError) {
+		return lr.next.GetLocationByID(ctx, id)
+	})
+}
+
+func (lr *LocationRepository) GetLocationByName(ctx context.Context, name string) (*domain.Location, domain.CError) {
+	return call(ctx, lr, func() (*domain.Location, domain.CError) {
+		return lr.next.GetLocationByName(ctx, name)
+	})
+}
+
+func (lr *LocationRepository) ListLocations(ctx context.Context, req *domain.ListLocationsRequest) ([]domain.Location, domain.CError) {
+	return call(ctx, lr, func() ([]domain.Location, domain.CError) {
+		return lr.next.ListLocations(ctx, req)
+	})
+}
+
+func (lr *LocationRepository) LookupLocations(ctx context.Context, ids, slugs []string) ([]domain.Location, domain.CError) {
+	return call(ctx, lr, func() ([]domain.Location, domain.CError) {
+		return lr.next.LookupLocations(ctx, ids, slugs)
+	})
+}
+
+func (lr *LocationRepository) StreamLocations(ctx context.Context, req *domain.ListLocationsRequest, fn func(location *domain.Location) error) domain.CError {
+	return lr.breaker.Do(ctx, func() domain.CError {
+		return lr.next.StreamLocations(ctx, req, fn)
+	})
+}
+
+func (lr *LocationRepository) LocationGeometry(ctx context.Context, req *domain.LocationGeometryRequest) (*domain.LocationGeometry, domain.CError) {
+	return call(ctx, lr, func() (*domain.LocationGeometry, domain.CError) {
+		return lr.next.LocationGeometry(ctx, req)
+	})
+}
+
+func (lr *LocationRepository) UpdateLocation(ctx context.Context, name string, location *domain.Location) (*domain.Location, domain.CError) {
+	return call(ctx, lr, func() (*domain.Location, domain.CError) {
+		return lr.next.UpdateLocation(ctx, name, location)
+	})
+}
+
+func (lr *LocationRepository) DeleteLocation(ctx context.Context, name string) domain.CError {
+	return lr.breaker.Do(ctx, func() domain.CError {
+		return lr.next.DeleteLocation(ctx, name)
+	})
+}
+
+func (lr *LocationRepository) FindLocationsAt(ctx context.Context, latitude, longitude float64) ([]domain.Location, domain.CError) {
+	return call(ctx, lr, func() ([]domain.Location, domain.CError) {
+		return lr.next.FindLocationsAt(ctx, latitude, longitude)
+	})
+}
+
+func (lr *LocationRepository) GetNearestLocation(ctx context.Context, latitude, longitude float64, formula domain.DistanceFormula) (*domain.NearestLocation, domain.CError) {
+	return call(ctx, lr, func() (*domain.NearestLocation, domain.CError) {
+		return lr.next.GetNearestLocation(ctx, latitude, longitude, formula)
+	})
+}
+
+func (lr *LocationRepository) FindNearestLocations(ctx context.Context, query *domain.NearestLocationsRequest) ([]domain.NearestLocation, domain.CError) {
+	return call(ctx, lr, func() ([]domain.NearestLocation, domain.CError) {
+		return lr.next.FindNearestLocations(ctx, query)
+	})
+}
+
+func (lr *LocationRepository) FindLocationsAlongRoute(ctx context.Context, route []domain.Point, query *domain.AlongRouteRequest) ([]domain.RouteLocation, domain.CError) {
+	return call(ctx, lr, func() ([]domain.RouteLocation, domain.CError) {
+		return lr.next.FindLocationsAlongRoute(ctx, route, query)
+	})
+}
+
+func (lr *LocationRepository) CreateLocationAlias(ctx context.Context, name string, alias *domain.LocationAlias) (*domain.LocationAlias, domain.CError) {
+	return call(ctx, lr, func() (*domain.LocationAlias, domain.CError) {
+		return lr.next.CreateLocationAlias(ctx, name, alias)
+	})
+}
+
+func (lr *LocationRepository) ListLocationAliases(ctx context.Context, name string) ([]domain.LocationAlias, domain.CError) {
+	return call(ctx, lr, func() ([]domain.LocationAlias, domain.CError) {
+		return lr.next.ListLocationAliases(ctx, name)
+	})
+}
+
+func (lr *LocationRepository) DeleteLocationAlias(ctx context.Context, name, alias string) domain.CError {
+	return lr.breaker.Do(ctx, func() domain.CError {
+		return lr.next.DeleteLocationAlias(ctx, name, alias)
+	})
+}
+
+func (lr *LocationRepository) ListPendingLocations(ctx context.Context, req *domain.ListPendingLocationsRequest) ([]domain.Location, domain.CError) {
+	return call(ctx, lr, func() ([]domain.Location, domain.CError) {
+		return lr.next.ListPendingLocations(ctx, req)
+	})
+}
+
+func (lr *LocationRepository) ReviewLocation(ctx context.Context, name string, status domain.LocationStatus, reason string) (*domain.Location, domain.CError) {
+	return call(ctx, lr, func() (*domain.Location, domain.CError) {
+		return lr.next.ReviewLocation(ctx, name, status, reason)
+	})
+}
+
+// call runs fn through the breaker and returns its value
+func call[T any](ctx context.Context, lr *LocationRepository, fn func() (T, domain.CError)) (T, domain.CError) {
+	var value T
+	cerr := lr.breaker.Do(ctx, func() domain.CError {
+		var cerr domain.CError
+		value, cerr = fn()
+		return cerr
+	})
+	return value, cerr
+}
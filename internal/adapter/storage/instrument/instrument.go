@@ -0,0 +1,91 @@
+package instrument
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"leeta/internal/adapter/logger"
+	"leeta/internal/core/domain"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+)
+
+const defaultSlowThreshold = 500 * time.Millisecond
+
+var (
+	callDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "leeta",
+		Subsystem: "repository",
+		Name:      "call_duration_seconds",
+		Help:      "Duration of repository calls by outcome: success, client_error or error.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"repository", "method", "outcome"})
+
+	callErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "leeta",
+		Subsystem: "repository",
+		Name:      "errors_total",
+		Help:      "Repository calls that failed, by status code.",
+	}, []string{"repository", "method", "code"})
+
+	callRows = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "leeta",
+		Subsystem: "repository",
+		Name:      "rows_total",
+		Help:      "Rows returned or written by repository calls.",
+	}, []string{"repository", "method"})
+)
+
+/**
+ * Recorder records the latency, errors and row counts of
+ * repository calls as Prometheus metrics and logs the calls
+ * slower than its threshold
+ */
+type Recorder struct {
+	repository    string
+	slowThreshold time.Duration
+}
+
+// New creates a new recorder instance for the named repository
+func New(repository string, slowThreshold time.Duration) *Recorder {
+	if slowThreshold <= 0 {
+		slowThreshold = defaultSlowThreshold
+	}
+
+	return &Recorder{
+		repository,
+		slowThreshold,
+	}
+}
+
+// Observe records a call to method that started at start, affected rows and failed with cerr if not nil
+func (r *Recorder) Observe(ctx context.Context, method string, start time.Time, rows int, cerr domain.CError) {
+	elapsed := time.Since(start)
+
+	outcome := "success"
+	if cerr != nil {
+		outcome = "error"
+		if cerr.Code() < http.StatusInternalServerError {
+			outcome = "client_error"
+		}
+		callErrors.WithLabelValues(r.repository, method, strconv.Itoa(cerr.Code())).Inc()
+	}
+
+	callDuration.WithLabelValues(r.repository, method, outcome).Observe(elapsed.Seconds())
+	if rows > 0 {
+		callRows.WithLabelValues(r.repository, method).Add(float64(rows))
+	}
+
+	if elapsed >= r.slowThreshold {
+		logger.FromCtx(ctx).Warn("Slow repository call",
+			zap.String("repository", r.repository),
+			zap.String("method", method),
+			zap.Duration("elapsed", elapsed),
+			zap.Int("rows", rows),
+			zap.String("outcome", outcome))
+	}
+}
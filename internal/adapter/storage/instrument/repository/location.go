@@ -0,0 +1,179 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"leeta/internal/adapter/storage/instrument"
+	"leeta/internal/core/domain"
+	"leeta/internal/core/port"
+)
+
+/**
+ * LocationRepository implements port.LocationRepository interface
+ * and records metrics for the calls to another location repository
+ */
+type LocationRepository struct {
+	next     port.LocationRepository
+	recorder *instrument.Recorder
+}
+
+// NewLocationRepository creates a new instrumented location repository wrapping next
+func NewLocationRepository(next port.LocationRepository, recorder *instrument.Recorder) *LocationRepository {
+	return &LocationRepository{
+		next,
+		recorder,
+	}
+}
+
+func (lr *LocationRepository) CreateLocation(ctx context.Context, location *domain.Location) (*domain.Location, domain.CError) {
+	start := time.Now()
+	created, cerr := lr.next.CreateLocation(ctx, location)
+	lr.recorder.Observe(ctx, "CreateLocation", start, one(created), cerr)
+	return created, cerr
+}
+
+func (lr *LocationRepository) CopyLocations(ctx context.Context, locations []domain.Location) (int64, domain.CError) {
+	start := time.Now()
+	n, cerr := lr.next.CopyLocations(ctx, locations)
+	lr.recorder.Observe(ctx, "CopyLocations", start, int(n), cerr)
+	return n, cerr
+}
+
+func (lr *LocationRepository) ExpireLocations(ctx context.Context, limit int) (int64, domain.CError) {
+	start := time.Now()
+	n, cerr := lr.next.ExpireLocations(ctx, limit)
+	lr.recorder.Observe(ctx, "ExpireLocations", start, int(n), cerr)
+	return n, cerr
+}
+
+func (lr *LocationRepository) GetLocationByID(ctx context.Context, id string) (*domain.Location, domain.CError) {
+	start := time.Now()
+	location, cerr := lr.next.GetLocationByID(ctx, id)
+	lr.recorder.Observe(ctx, "GetLocationByID", start, one(location), cerr)
+	return location, cerr
+}
+
+func (lr *LocationRepository) GetLocationByName(ctx context.Context, name string) (*domain.Location, domain.CError) {
+	start := time.Now()
+	location, cerr := lr.next.GetLocationByName(ctx, name)
+	lr.recorder.Observe(ctx, "GetLocationByName", start, one(location), cerr)
+	return location, cerr
+}
+
+func (lr *LocationRepository) ListLocations(ctx context.Context, req *domain.ListLocationsRequest) ([]domain.Location, domain.CError) {
+	start := time.Now()
+	locations, cerr := lr.next.ListLocations(ctx, req)
+	lr.recorder.Observe(ctx, "ListLocations", start, len(locations), cerr)
+	return locations, cerr
+}
+
+func (lr *LocationRepository) LookupLocations(ctx context.Context, ids, slugs []string) ([]domain.Location, domain.CError) {
+	start := time.Now()
+	locations, cerr := lr.next.LookupLocations(ctx, ids, slugs)
+	lr.recorder.Observe(ctx, "LookupLocations", start, len(locations), cerr)
+	return locations, cerr
+}
+
+func (lr *LocationRepository) StreamLocations(ctx context.Context, req *domain.ListLocationsRequest, fn func(location *domain.Location) error) domain.CError {
+	start := time.Now()
+	rows := 0
+	cerr := lr.next.StreamLocations(ctx, req, func(location *domain.Location) error {
+		rows++
+		return fn(location)
+	})
+	lr.recorder.Observe(ctx, "StreamLocations", start, rows, cerr)
+	return cerr
+}
+
+func (lr *LocationRepository) LocationGeometry(ctx context.Context, req *domain.LocationGeometryRequest) (*domain.LocationGeometry, domain.CError) {
+	start := time.Now()
+	geometry, cerr := lr.next.LocationGeometry(ctx, req)
+	lr.recorder.Observe(ctx, "LocationGeometry", start, one(geometry), cerr)
+	return geometry, cerr
+}
+
+func (lr *LocationRepository) UpdateLocation(ctx context.Context, name string, location *domain.Location) (*domain.Location, domain.CError) {
+	start := time.Now()
+	updated, cerr := lr.next.UpdateLocation(ctx, name, location)
+	lr.recorder.Observe(ctx, "UpdateLocation", start, one(updated), cerr)
+	return updated, cerr
+}
+
+func (lr *LocationRepository) DeleteLocation(ctx context.Context, name string) domain.CError {
+	start := time.Now()
+	cerr := lr.next.DeleteLocation(ctx, name)
+	lr.recorder.Observe(ctx, "DeleteLocation", start, 0, cerr)
+	return cerr
+}
+
+func (lr *LocationRepository) FindLocationsAt(ctx context.Context, latitude, longitude float64) ([]domain.Location, domain.CError) {
+	start := time.Now()
+	locations, cerr := lr.next.FindLocationsAt(ctx, latitude, longitude)
+	lr.recorder.Observe(ctx, "FindLocationsAt", start, len(locations), cerr)
+	return locations, cerr
+}
+
+func (lr *LocationRepository) GetNearestLocation(ctx context.Context, latitude, longitude float64, formula domain.DistanceFormula) (*domain.NearestLocation, domain.CError) {
+	start := time.Now()
+	location, cerr := lr.next.GetNearestLocation(ctx, latitude, longitude, formula)
+	lr.recorder.Observe(ctx, "GetNearestLocation", start, one(location), cerr)
+	return location, cerr
+}
+
+func (lr *LocationRepository) FindNearestLocations(ctx context.Context, query *domain.NearestLocationsRequest) ([]domain.NearestLocation, domain.CError) {
+	start := time.Now()
+	locations, cerr := lr.next.FindNearestLocations(ctx, query)
+	lr.recorder.Observe(ctx, "FindNearestLocations", start, len(locations), cerr)
+	return locations, cerr
+}
+
+func (lr *LocationRepository) FindLocationsAlongRoute(ctx context.Context, route []domain.Point, query *domain.AlongRouteRequest) ([]domain.RouteLocation, domain.CError) {
+	start := time.Now()
+	locations, cerr := lr.next.FindLocationsAlongRoute(ctx, route, query)
+	lr.recorder.Observe(ctx, "FindLocationsAlongRoute", start, len(locations), cerr)
+	return locations, cerr
+}
+
+func (lr *LocationRepository) CreateLocationAlias(ctx context.Context, name string, alias *domain.LocationAlias) (*domain.LocationAlias, domain.CError) {
+	start := time.Now()
+	created, cerr := lr.next.CreateLocationAlias(ctx, name, alias)
+	lr.recorder.Observe(ctx, "CreateLocationAlias", start, one(created), cerr)
+	return created, cerr
+}
+
+func (lr *LocationRepository) ListLocationAliases(ctx context.Context, name string) ([]domain.LocationAlias, domain.CError) {
+	start := time.Now()
+	aliases, cerr := lr.next.ListLocationAliases(ctx, name)
+	lr.recorder.Observe(ctx, "ListLocationAliases", start, len(aliases), cerr)
+	return aliases, cerr
+}
+
+func (lr *LocationRepository) DeleteLocationAlias(ctx context.Context, name, alias string) domain.CError {
+	start := time.Now()
+	cerr := lr.next.DeleteLocationAlias(ctx, name, alias)
+	lr.recorder.Observe(ctx, "DeleteLocationAlias", start, 0, cerr)
+	return cerr
+}
+
+func (lr *LocationRepository) ListPendingLocations(ctx context.Context, req *domain.ListPendingLocationsRequest) ([]domain.Location, domain.CError) {
+	start := time.Now()
+	locations, cerr := lr.next.ListPendingLocations(ctx, req)
+	lr.recorder.Observe(ctx, "ListPendingLocations", start, len(locations), cerr)
+	return locations, cerr
+}
+
+func (lr *LocationRepository) ReviewLocation(ctx context.Context, name string, status domain.LocationStatus, reason string) (*domain.Location, domain.CError) {
+	start := time.Now()
+	location, cerr := lr.next.ReviewLocation(ctx, name, status, reason)
+	lr.recorder.Observe(ctx, "ReviewLocation", start, one(location), cerr)
+	return location, cerr
+}
+
+// one returns the row count of a call returning a single value
+func one[T any](value *T) int {
+	if value == nil {
+		return 0
+	}
+	return 1
+}
@@ -5,12 +5,17 @@ import (
 	"embed"
 	"errors"
 	"fmt"
+	"io/fs"
+	"strconv"
+	"sync/atomic"
 
 	"leeta/internal/adapter/config"
+	"leeta/internal/core/slugger"
 
 	"github.com/Masterminds/squirrel"
 	"github.com/golang-migrate/migrate/v4"
 
+	"github.com/golang-migrate/migrate/v4/source"
 	"github.com/golang-migrate/migrate/v4/source/iofs"
 
 	_ "github.com/golang-migrate/migrate/v4/database/pgx"
@@ -28,12 +33,25 @@ var migrationsFS embed.FS
  * DB is a wrapper for PostgreSQL database connection
  * that uses pgxpool as database driver.
  * It also holds a reference to squirrel.StatementBuilderType
- * which is used to build SQL queries that compatible with PostgreSQL syntax
+ * which is used to build SQL queries that compatible with PostgreSQL syntax.
+ * Read-only queries can be sent to replicas through ReadQuery and ReadQueryRow.
+ * Calls failing with transient errors are retried according to the retry policy
  */
 type DB struct {
 	*pgxpool.Pool
 	QueryBuilder *squirrel.StatementBuilderType
 	url          string
+	replicas     []*replica
+	writes       *recentWrites
+	next         atomic.Uint32
+	stop         context.CancelFunc
+	// tenancy is the isolation of the locations of the tenants, TenancyRLS or empty
+	tenancy string
+	// slugger makes the slugs of the records from their names
+	slugger *slugger.Slugger
+
+	retryPolicy   retryPolicy
+	retryCounters retryCounters
 }
 
 func dsn(config *config.DatabaseConfiguration) string {
@@ -49,26 +67,94 @@ func dsn(config *config.DatabaseConfiguration) string {
 	return url
 }
 
+// poolConfig parses url into a pool configuration with the pool settings of config applied
+func poolConfig(url string, config *config.DatabaseConfiguration) (*pgxpool.Config, error) {
+	poolConfig, err := pgxpool.ParseConfig(url)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.MaxConns > 0 {
+		poolConfig.MaxConns = config.MaxConns
+	}
+	if config.MinConns > 0 {
+		poolConfig.MinConns = config.MinConns
+	}
+	if config.MaxConnLifetime > 0 {
+		poolConfig.MaxConnLifetime = config.MaxConnLifetime
+	}
+	if config.MaxConnIdleTime > 0 {
+		poolConfig.MaxConnIdleTime = config.MaxConnIdleTime
+	}
+	if config.HealthCheckPeriod > 0 {
+		poolConfig.HealthCheckPeriod = config.HealthCheckPeriod
+	}
+	if config.ConnectTimeout > 0 {
+		poolConfig.ConnConfig.ConnectTimeout = config.ConnectTimeout
+	}
+	if config.StatementTimeout > 0 {
+		poolConfig.ConnConfig.RuntimeParams["statement_timeout"] = strconv.FormatInt(config.StatementTimeout.Milliseconds(), 10)
+	}
+
+	// the rows are tagged with the tenant of their creator whatever the tenancy, e.g. to count them
+	// against the quotas of the tenants
+	scope := newTenantScope()
+	poolConfig.BeforeAcquire = scope.beforeAcquire
+	poolConfig.BeforeClose = scope.beforeClose
+
+	if poolConfig.MinConns > poolConfig.MaxConns {
+		return nil, fmt.Errorf("minConns (%d) is greater than maxConns (%d)", poolConfig.MinConns, poolConfig.MaxConns)
+	}
+
+	return poolConfig, nil
+}
+
 // New creates a new PostgreSQL database instance
 func New(ctx context.Context, config *config.DatabaseConfiguration) (*DB, error) {
 	url := dsn(config)
-	db, err := pgxpool.New(ctx, url)
+	cfg, err := poolConfig(url, config)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := pgxpool.NewWithConfig(ctx, cfg)
 	if err != nil {
 		return nil, err
 	}
 
 	err = db.Ping(ctx)
 	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	replicas, err := connectReplicas(ctx, config)
+	if err != nil {
+		db.Close()
 		return nil, err
 	}
 
 	psql := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
 
-	return &DB{
-		db,
-		&psql,
-		url,
-	}, nil
+	checkCtx, stop := context.WithCancel(context.Background())
+
+	d := &DB{
+		Pool:         db,
+		QueryBuilder: &psql,
+		url:          url,
+		replicas:     replicas,
+		writes:       newRecentWrites(config.ReadYourWrites),
+		stop:         stop,
+		tenancy:      config.Tenancy,
+		slugger:      newSlugger(&config.Slugs),
+		retryPolicy:  newRetryPolicy(&config.Retry),
+	}
+
+	if len(replicas) > 0 {
+		go d.checkReplicas(checkCtx)
+	}
+
+	return d, nil
 }
 
 // Migrate runs the database migration
@@ -92,6 +178,60 @@ func (db *DB) Migrate() error {
 	return nil
 }
 
+// MigrationStatus is the version of the database schema against the latest migration embedded in the binary
+type MigrationStatus struct {
+	Version uint
+	Latest  uint
+	// Dirty is set when a migration failed halfway, the schema must then be fixed by hand
+	Dirty bool
+}
+
+// MigrationStatus reads the version of the database schema, 0 when no migration ran yet
+func (db *DB) MigrationStatus() (*MigrationStatus, error) {
+	driver, err := iofs.New(migrationsFS, "migrations")
+	if err != nil {
+		return nil, fmt.Errorf("reading the migrations: %w", err)
+	}
+	defer driver.Close()
+
+	latest, err := latestMigration(driver)
+	if err != nil {
+		return nil, fmt.Errorf("reading the migrations: %w", err)
+	}
+
+	migrations, err := migrate.NewWithSourceInstance("iofs", driver, db.url)
+	if err != nil {
+		return nil, err
+	}
+	defer migrations.Close()
+
+	version, dirty, err := migrations.Version()
+	if err != nil && !errors.Is(err, migrate.ErrNilVersion) {
+		return nil, fmt.Errorf("reading the schema version: %w", err)
+	}
+
+	return &MigrationStatus{Version: version, Latest: latest, Dirty: dirty}, nil
+}
+
+// latestMigration returns the version of the last migration of driver
+func latestMigration(driver source.Driver) (uint, error) {
+	version, err := driver.First()
+	if err != nil {
+		return 0, err
+	}
+
+	for {
+		next, err := driver.Next(version)
+		if errors.Is(err, fs.ErrNotExist) {
+			return version, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+		version = next
+	}
+}
+
 // ErrorCode returns the error code of the given error
 func (db *DB) ErrorCode(err error) string {
 	var pgErr *pgconn.PgError
@@ -101,7 +241,17 @@ func (db *DB) ErrorCode(err error) string {
 	return "0000"
 }
 
-// Close closes the database connection
+// IsTimeout reports whether err was caused by the statement timeout or by the deadline of ctx
+func (db *DB) IsTimeout(err error) bool {
+	// 57014 is the error code for a statement cancelled by the statement timeout
+	return errors.Is(err, context.DeadlineExceeded) || db.ErrorCode(err) == "57014"
+}
+
+// Close closes the database connections
 func (db *DB) Close() {
+	db.stop()
+	for _, r := range db.replicas {
+		r.Close()
+	}
 	db.Pool.Close()
 }
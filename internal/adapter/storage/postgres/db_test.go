@@ -0,0 +1,23 @@
+package postgres
+
+import (
+	"testing"
+
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLatestMigration(t *testing.T) {
+	driver, err := iofs.New(migrationsFS, "migrations")
+	require.NoError(t, err)
+	defer driver.Close()
+
+	entries, err := migrationsFS.ReadDir("migrations")
+	require.NoError(t, err)
+
+	// every migration has an up and a down file
+	latest, err := latestMigration(driver)
+	require.NoError(t, err)
+	assert.Equal(t, uint(len(entries)/2), latest)
+}
@@ -0,0 +1,365 @@
+// Package filter parses the compact filter language accepted by list endpoints
+// into squirrel predicates, e.g.
+//
+//	category:park AND (created_at>2024-01-01 OR name~"central")
+//
+// A term is a field, an operator and a value. Terms are combined with AND / OR
+// (AND binds tighter) and can be grouped with parentheses. Only fields present
+// in the Fields map are accepted, so a filter can never reach arbitrary columns.
+package filter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// Type is the type a field value is converted to before it is sent to the database
+type Type int
+
+const (
+	String Type = iota
+	Number
+	Time
+)
+
+// Field describes a filterable column
+type Field struct {
+	Column string
+	Type   Type
+	// Related, when set, is a correlated query over the related table holding Column, e.g.
+	// SELECT 1 FROM aliases WHERE aliases.location_id = locations.id. A term on the field
+	// then matches the rows with at least one related row matching it
+	Related string
+}
+
+// Fields maps the field names clients use in a filter to their columns
+type Fields map[string]Field
+
+// supported operators, longest first so that ">=" is matched before ">"
+var operators = []string{">=", "<=", "!=", ":", "=", ">", "<", "~"}
+
+// timeLayouts are the accepted formats for time values
+var timeLayouts = []string{time.RFC3339, "2006-01-02"}
+
+// Error is returned when a filter cannot be parsed
+type Error struct {
+	Pos     int
+	Message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("invalid filter at position %d: %s", e.Pos, e.Message)
+}
+
+// Parse parses the filter q into a predicate over the given fields.
+// An empty filter returns a nil predicate.
+func Parse(q string, fields Fields) (sq.Sqlizer, error) {
+	tokens, err := tokenize(q)
+	if err != nil {
+		return nil, err
+	}
+
+	if tokens[0].kind == tokenEOF {
+		return nil, nil
+	}
+
+	p := &parser{tokens: tokens, fields: fields}
+	pred, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, &Error{tok.pos, fmt.Sprintf("unexpected %q", tok.text)}
+	}
+
+	return pred, nil
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenWord
+	tokenString
+	tokenOperator
+	tokenAnd
+	tokenOr
+	tokenLParen
+	tokenRParen
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+// tokenize splits the filter into tokens
+func tokenize(q string) ([]token, error) {
+	var tokens []token
+
+	for i := 0; i < len(q); {
+		c := rune(q[i])
+
+		switch {
+		case unicode.IsSpace(c):
+			i++
+
+		case c == '(':
+			tokens = append(tokens, token{tokenLParen, "(", i})
+			i++
+
+		case c == ')':
+			tokens = append(tokens, token{tokenRParen, ")", i})
+			i++
+
+		case c == '"':
+			var sb strings.Builder
+			start := i
+			i++
+			for ; i < len(q) && q[i] != '"'; i++ {
+				if q[i] == '\\' && i+1 < len(q) {
+					i++
+				}
+				sb.WriteByte(q[i])
+			}
+			if i >= len(q) {
+				return nil, &Error{start, "unterminated string"}
+			}
+			tokens = append(tokens, token{tokenString, sb.String(), start})
+			i++
+
+		default:
+			// the value of a term runs up to the next space, parenthesis or quote, so that the operator characters
+			// it holds, such as the colons of an RFC3339 time, are part of it
+			value := len(tokens) > 0 && tokens[len(tokens)-1].kind == tokenOperator
+			if op := operatorAt(q, i); op != "" && !value {
+				tokens = append(tokens, token{tokenOperator, op, i})
+				i += len(op)
+				continue
+			}
+
+			start := i
+			for i < len(q) && !isDelimiter(q, i, value) {
+				i++
+			}
+
+			word := q[start:i]
+			switch strings.ToUpper(word) {
+			case "AND":
+				tokens = append(tokens, token{tokenAnd, word, start})
+			case "OR":
+				tokens = append(tokens, token{tokenOr, word, start})
+			default:
+				tokens = append(tokens, token{tokenWord, word, start})
+			}
+		}
+	}
+
+	return append(tokens, token{tokenEOF, "end of filter", len(q)}), nil
+}
+
+// operatorAt returns the operator starting at position i of q, if any
+func operatorAt(q string, i int) string {
+	for _, op := range operators {
+		if strings.HasPrefix(q[i:], op) {
+			return op
+		}
+	}
+	return ""
+}
+
+// isDelimiter reports whether position i of q ends a bare word, operators only end the words that are not values
+func isDelimiter(q string, i int, value bool) bool {
+	c := rune(q[i])
+	return unicode.IsSpace(c) || c == '(' || c == ')' || c == '"' || (!value && operatorAt(q, i) != "")
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+	fields Fields
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+// parseOr parses: and { OR and }
+func (p *parser) parseOr() (sq.Sqlizer, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	preds := sq.Or{left}
+	for p.peek().kind == tokenOr {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		preds = append(preds, right)
+	}
+
+	if len(preds) == 1 {
+		return left, nil
+	}
+	return preds, nil
+}
+
+// parseAnd parses: primary { AND primary }
+func (p *parser) parseAnd() (sq.Sqlizer, error) {
+	left, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+
+	preds := sq.And{left}
+	for p.peek().kind == tokenAnd {
+		p.next()
+		right, err := p.parsePrimary()
+		if err != nil {
+			return nil, err
+		}
+		preds = append(preds, right)
+	}
+
+	if len(preds) == 1 {
+		return left, nil
+	}
+	return preds, nil
+}
+
+// parsePrimary parses: "(" or ")" | field operator value
+func (p *parser) parsePrimary() (sq.Sqlizer, error) {
+	tok := p.next()
+
+	switch tok.kind {
+	case tokenLParen:
+		pred, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokenRParen {
+			return nil, &Error{closing.pos, "expected )"}
+		}
+		return pred, nil
+
+	case tokenWord:
+		return p.parseTerm(tok)
+
+	default:
+		return nil, &Error{tok.pos, fmt.Sprintf("expected a field, got %q", tok.text)}
+	}
+}
+
+// parseTerm parses the operator and value following the field token
+func (p *parser) parseTerm(fieldTok token) (sq.Sqlizer, error) {
+	field, ok := p.fields[strings.ToLower(fieldTok.text)]
+	if !ok {
+		return nil, &Error{fieldTok.pos, fmt.Sprintf("unknown field %q", fieldTok.text)}
+	}
+
+	pred, err := p.parseCondition(fieldTok, field)
+	if err != nil || field.Related == "" {
+		return pred, err
+	}
+	return exists{field.Related, pred}, nil
+}
+
+// parseCondition parses the operator and value of a term into a condition on the column of field
+func (p *parser) parseCondition(fieldTok token, field Field) (sq.Sqlizer, error) {
+	opTok := p.next()
+	if opTok.kind != tokenOperator {
+		return nil, &Error{opTok.pos, fmt.Sprintf("expected an operator after %q", fieldTok.text)}
+	}
+
+	valueTok := p.next()
+	if valueTok.kind != tokenWord && valueTok.kind != tokenString {
+		return nil, &Error{valueTok.pos, fmt.Sprintf("expected a value after %q", opTok.text)}
+	}
+
+	if opTok.text == "~" {
+		if field.Type != String {
+			return nil, &Error{opTok.pos, fmt.Sprintf("operator ~ is not supported on %q", fieldTok.text)}
+		}
+		return sq.ILike{field.Column: "%" + escapeLike(valueTok.text) + "%"}, nil
+	}
+
+	value, err := convert(valueTok.text, field.Type)
+	if err != nil {
+		return nil, &Error{valueTok.pos, err.Error()}
+	}
+
+	switch opTok.text {
+	case ":", "=":
+		return sq.Eq{field.Column: value}, nil
+	case "!=":
+		return sq.NotEq{field.Column: value}, nil
+	case ">":
+		return sq.Gt{field.Column: value}, nil
+	case ">=":
+		return sq.GtOrEq{field.Column: value}, nil
+	case "<":
+		return sq.Lt{field.Column: value}, nil
+	default:
+		return sq.LtOrEq{field.Column: value}, nil
+	}
+}
+
+// exists matches the rows whose related query has a row matching pred
+type exists struct {
+	related string
+	pred    sq.Sqlizer
+}
+
+func (e exists) ToSql() (string, []any, error) {
+	sql, args, err := e.pred.ToSql()
+	if err != nil {
+		return "", nil, err
+	}
+	return "EXISTS (" + e.related + " AND " + sql + ")", args, nil
+}
+
+// convert converts a raw value to the type of its field
+func convert(raw string, typ Type) (any, error) {
+	switch typ {
+	case Number:
+		v, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a number", raw)
+		}
+		return v, nil
+
+	case Time:
+		for _, layout := range timeLayouts {
+			if v, err := time.Parse(layout, raw); err == nil {
+				return v, nil
+			}
+		}
+		return nil, fmt.Errorf("%q is not a date (expected YYYY-MM-DD or RFC3339)", raw)
+
+	default:
+		return raw, nil
+	}
+}
+
+// escapeLike escapes the LIKE wildcards in s
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
@@ -0,0 +1,118 @@
+package filter
+
+import (
+	"testing"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testFields = Fields{
+	"name":       {Column: "name", Type: String},
+	"category":   {Column: "category", Type: String},
+	"latitude":   {Column: "latitude", Type: Number},
+	"created_at": {Column: "created_at", Type: Time},
+	"alias": {
+		Column:  "aliases.name",
+		Type:    String,
+		Related: "SELECT 1 FROM aliases WHERE aliases.location_id = locations.id",
+	},
+}
+
+func toSql(t *testing.T, q string) (string, []any) {
+	pred, err := Parse(q, testFields)
+	require.NoError(t, err)
+	require.NotNil(t, pred)
+
+	sql, args, err := sq.Select("id").From("locations").Where(pred).ToSql()
+	require.NoError(t, err)
+
+	return sql, args
+}
+
+func TestParse_Empty(t *testing.T) {
+	pred, err := Parse("   ", testFields)
+	assert.NoError(t, err)
+	assert.Nil(t, pred)
+}
+
+func TestParse_SingleTerm(t *testing.T) {
+	sql, args := toSql(t, "category:park")
+
+	assert.Equal(t, "SELECT id FROM locations WHERE category = ?", sql)
+	assert.Equal(t, []any{"park"}, args)
+}
+
+func TestParse_AndWithTime(t *testing.T) {
+	sql, args := toSql(t, "category:park AND created_at>2024-01-01")
+
+	assert.Equal(t, "SELECT id FROM locations WHERE (category = ? AND created_at > ?)", sql)
+	assert.Equal(t, []any{"park", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}, args)
+}
+
+func TestParse_RFC3339Time(t *testing.T) {
+	// the colons of the time are part of the value rather than operators
+	sql, args := toSql(t, "(created_at>=2024-01-01T10:00:00Z AND created_at<2024-01-02T00:00:00+01:00) OR category:park")
+
+	assert.Equal(t, "SELECT id FROM locations WHERE ((created_at >= ? AND created_at < ?) OR category = ?)", sql)
+	require.Len(t, args, 3)
+	assert.True(t, time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC).Equal(args[0].(time.Time)))
+	assert.True(t, time.Date(2024, 1, 1, 23, 0, 0, 0, time.UTC).Equal(args[1].(time.Time)))
+	assert.Equal(t, "park", args[2])
+}
+
+func TestParse_Precedence(t *testing.T) {
+	sql, args := toSql(t, "category:park or category:museum and latitude>=40.5")
+
+	assert.Equal(t, "SELECT id FROM locations WHERE (category = ? OR (category = ? AND latitude >= ?))", sql)
+	assert.Equal(t, []any{"park", "museum", 40.5}, args)
+}
+
+func TestParse_Parentheses(t *testing.T) {
+	sql, _ := toSql(t, "(category:park OR category:museum) AND latitude<0")
+
+	assert.Equal(t, "SELECT id FROM locations WHERE ((category = ? OR category = ?) AND latitude < ?)", sql)
+}
+
+func TestParse_QuotedAndContains(t *testing.T) {
+	sql, args := toSql(t, `name~"central 100%" AND category!="theme park"`)
+
+	assert.Equal(t, "SELECT id FROM locations WHERE (name ILIKE ? AND category <> ?)", sql)
+	assert.Equal(t, []any{`%central 100\%%`, "theme park"}, args)
+}
+
+func TestParse_Related(t *testing.T) {
+	sql, args := toSql(t, `alias~"kennedy" OR name:JFK`)
+
+	assert.Equal(t, "SELECT id FROM locations WHERE (EXISTS (SELECT 1 FROM aliases WHERE aliases.location_id = locations.id AND aliases.name ILIKE ?) OR name = ?)", sql)
+	assert.Equal(t, []any{"%kennedy%", "JFK"}, args)
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := map[string]string{
+		"unknown field":         "password:secret",
+		"missing operator":      "category park",
+		"missing value":         "category:",
+		"bad number":            "latitude>north",
+		"bad date":              "created_at>yesterday",
+		"contains on number":    "latitude~4",
+		"unterminated string":   `name:"central`,
+		"unbalanced paren":      "(category:park",
+		"trailing operator":     "category:park AND",
+		"unexpected token":      "category:park museum",
+		"leading boolean":       "OR category:park",
+		"closing paren only":    ")",
+		"operator without name": ">5",
+	}
+
+	for name, q := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := Parse(q, testFields)
+
+			var ferr *Error
+			assert.ErrorAs(t, err, &ferr)
+		})
+	}
+}
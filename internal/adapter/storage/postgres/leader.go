@@ -0,0 +1,195 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"leeta/internal/adapter/config"
+	"leeta/internal/adapter/logger"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+)
+
+const (
+	// defaultLeaderLockID is the advisory lock of the leader, an arbitrary number no other lock uses
+	defaultLeaderLockID   = 7426231
+	defaultLeaderInterval = 5 * time.Second
+)
+
+var isLeader = promauto.NewGauge(prometheus.GaugeOpts{
+	Namespace: "leeta",
+	Subsystem: "leader_election",
+	Name:      "is_leader",
+	Help:      "1 while this instance is the leader running the singleton workers, 0 otherwise.",
+})
+
+// singletonTask is a background task run by the leader only
+type singletonTask struct {
+	name string
+	run  func(ctx context.Context)
+}
+
+/**
+ * Elector elects one instance as the leader with a PostgreSQL
+ * session advisory lock, held on a connection of its own outside
+ * of the pool. The leader runs the singleton tasks until it loses
+ * the connection, which releases the lock so another instance
+ * takes over on its next attempt
+ */
+type Elector struct {
+	db       *DB
+	lockID   int64
+	interval time.Duration
+	tasks    []singletonTask
+	leader   atomic.Bool
+
+	mu      sync.Mutex
+	lastErr error
+}
+
+// NewElector creates a new leader elector instance
+func NewElector(db *DB, config *config.LeaderElectionConfiguration) *Elector {
+	lockID := config.LockID
+	if lockID == 0 {
+		lockID = defaultLeaderLockID
+	}
+
+	interval := config.Interval
+	if interval <= 0 {
+		interval = defaultLeaderInterval
+	}
+
+	return &Elector{
+		db:       db,
+		lockID:   lockID,
+		interval: interval,
+	}
+}
+
+// Add registers a task to run while this instance is the leader, it must be called before Run.
+// The context of the task is done when the leadership is lost
+func (e *Elector) Add(name string, run func(ctx context.Context)) {
+	e.tasks = append(e.tasks, singletonTask{name, run})
+}
+
+// IsLeader reports whether this instance is the leader
+func (e *Elector) IsLeader() bool {
+	return e.leader.Load()
+}
+
+// Check fails when the last attempt to take part in the election failed
+func (e *Elector) Check(ctx context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.lastErr
+}
+
+// Run takes part in the election until ctx is done, running the tasks whenever it is the leader
+func (e *Elector) Run(ctx context.Context) {
+	for {
+		err := e.campaign(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+
+		e.setErr(err)
+		logger.FromCtx(ctx).Warn("Left the leader election, rejoining",
+			zap.Duration("backoff", e.interval), zap.Error(err))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(e.interval):
+		}
+	}
+}
+
+// campaign opens a connection and tries to take the lock every interval. Once it holds
+// the lock it leads until the connection fails or ctx is done
+func (e *Elector) campaign(ctx context.Context) error {
+	conn, err := pgx.Connect(ctx, e.db.url)
+	if err != nil {
+		return err
+	}
+	// closing the session releases the lock
+	defer conn.Close(context.Background())
+
+	for {
+		var acquired bool
+		if err := conn.QueryRow(ctx, "SELECT pg_try_advisory_lock($1)", e.lockID).Scan(&acquired); err != nil {
+			return err
+		}
+		e.setErr(nil)
+
+		if acquired {
+			return e.lead(ctx, conn)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(e.interval):
+		}
+	}
+}
+
+// lead runs the tasks while the connection holding the lock is alive, and stops them
+// before returning
+func (e *Elector) lead(ctx context.Context, conn *pgx.Conn) error {
+	log := logger.FromCtx(ctx)
+	log.Info("Became the leader, starting the singleton workers")
+
+	e.leader.Store(true)
+	isLeader.Set(1)
+
+	taskCtx, cancel := context.WithCancel(ctx)
+	var tasks sync.WaitGroup
+	for _, task := range e.tasks {
+		tasks.Add(1)
+		go func() {
+			defer tasks.Done()
+			task.run(logger.WithCtx(taskCtx, log.With(zap.String("task", task.name))))
+		}()
+	}
+
+	defer func() {
+		cancel()
+		tasks.Wait()
+
+		e.leader.Store(false)
+		isLeader.Set(0)
+		log.Info("Stepped down as the leader, stopped the singleton workers")
+	}()
+
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		pingCtx, cancelPing := context.WithTimeout(ctx, e.interval)
+		err := conn.Ping(pingCtx)
+		cancelPing()
+		if err != nil && ctx.Err() == nil {
+			return fmt.Errorf("lost the connection holding the leader lock: %w", err)
+		}
+	}
+}
+
+func (e *Elector) setErr(err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.lastErr = err
+}
@@ -0,0 +1,43 @@
+package postgres_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"leeta/internal/adapter/config"
+	"leeta/internal/adapter/storage/postgres"
+	"leeta/internal/adapter/storage/postgres/postgrestest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(postgrestest.Run(m))
+}
+
+func TestElector_Failover(t *testing.T) {
+	db := postgrestest.DB(t)
+	cfg := &config.LeaderElectionConfiguration{LockID: 42, Interval: 50 * time.Millisecond}
+
+	first, second := postgres.NewElector(db, cfg), postgres.NewElector(db, cfg)
+	firstCtx, stopFirst := context.WithCancel(context.Background())
+	secondCtx, stopSecond := context.WithCancel(context.Background())
+	defer stopSecond()
+
+	firstDone := make(chan struct{})
+	go func() {
+		first.Run(firstCtx)
+		close(firstDone)
+	}()
+	assert.Eventually(t, first.IsLeader, 5*time.Second, 10*time.Millisecond)
+
+	go second.Run(secondCtx)
+	assert.Never(t, second.IsLeader, 300*time.Millisecond, 10*time.Millisecond, "one leader at a time")
+
+	stopFirst()
+	<-firstDone
+	assert.False(t, first.IsLeader())
+	assert.Eventually(t, second.IsLeader, 5*time.Second, 10*time.Millisecond, "the follower takes over")
+}
@@ -0,0 +1,130 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"leeta/internal/adapter/logger"
+	"leeta/internal/core/domain"
+
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+const (
+	// changesChannel is the channel the locations trigger notifies
+	changesChannel = "locations_changed"
+
+	listenMinBackoff = time.Second
+	listenMaxBackoff = 30 * time.Second
+)
+
+/**
+ * ChangeListener implements port.LocationChangeFeed interface
+ * using PostgreSQL LISTEN/NOTIFY. It holds its own connection
+ * to the primary, outside of the pool, and reconnects when it is lost
+ */
+type ChangeListener struct {
+	db *DB
+
+	mu   sync.RWMutex
+	subs []func(ctx context.Context, change *domain.LocationChange)
+}
+
+// NewChangeListener creates a new change listener instance
+func NewChangeListener(db *DB) *ChangeListener {
+	return &ChangeListener{
+		db: db,
+	}
+}
+
+func (cl *ChangeListener) Subscribe(fn func(ctx context.Context, change *domain.LocationChange)) {
+	cl.mu.Lock()
+	defer cl.mu.Unlock()
+
+	cl.subs = append(cl.subs, fn)
+}
+
+// Run listens for changes until ctx is done. Notifications sent while the connection is
+// down are lost, so subscribers get a resync change every time it is re-established
+func (cl *ChangeListener) Run(ctx context.Context) {
+	listenChannel(ctx, cl.db, changesChannel, "location change feed", func() {
+		cl.publish(ctx, &domain.LocationChange{Op: domain.ChangeResync})
+	}, func(payload string) {
+		var change domain.LocationChange
+		if err := json.Unmarshal([]byte(payload), &change); err != nil {
+			logger.FromCtx(ctx).Error("Error decoding location change", zap.String("payload", payload), zap.Error(err))
+			return
+		}
+
+		cl.publish(ctx, &change)
+	})
+}
+
+// listenChannel listens on channel until ctx is done, calling handle with the payload of every
+// notification. It reconnects when the connection is lost, calling onReconnect once it listens again
+func listenChannel(ctx context.Context, db *DB, channel, feed string, onReconnect func(), handle func(payload string)) {
+	backoff := listenMinBackoff
+	connected := false
+
+	for ctx.Err() == nil {
+		err := listen(ctx, db, channel, func() {
+			if connected {
+				logger.FromCtx(ctx).Info("Reconnected to the " + feed)
+				onReconnect()
+			}
+			connected = true
+			backoff = listenMinBackoff
+		}, handle)
+		if ctx.Err() != nil {
+			return
+		}
+
+		logger.FromCtx(ctx).Warn("Lost the "+feed+", reconnecting",
+			zap.Duration("backoff", backoff), zap.Error(err))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+
+		backoff = min(backoff*2, listenMaxBackoff)
+	}
+}
+
+// listen opens a connection, calls onListen once it listens on channel and hands the payloads of
+// the notifications it receives to handle until the connection fails or ctx is done
+func listen(ctx context.Context, db *DB, channel string, onListen func(), handle func(payload string)) error {
+	conn, err := pgx.Connect(ctx, db.url)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+channel); err != nil {
+		return err
+	}
+	onListen()
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		handle(notification.Payload)
+	}
+}
+
+// publish calls every subscriber with change
+func (cl *ChangeListener) publish(ctx context.Context, change *domain.LocationChange) {
+	cl.mu.RLock()
+	defer cl.mu.RUnlock()
+
+	for _, fn := range cl.subs {
+		fn(ctx, change)
+	}
+}
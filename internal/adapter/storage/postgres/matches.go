@@ -0,0 +1,72 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"leeta/internal/adapter/logger"
+	"leeta/internal/core/domain"
+
+	"go.uber.org/zap"
+)
+
+// matchesChannel is the channel the proximity matches are sent on
+const matchesChannel = "proximity_matches"
+
+/**
+ * MatchFeed implements port.MatchDelivery interface using
+ * PostgreSQL LISTEN/NOTIFY. A match is made on a single
+ * instance, the feed hands it to the subscribers of every
+ * instance, e.g. to reach the WebSocket clients they serve
+ */
+type MatchFeed struct {
+	db *DB
+
+	mu   sync.RWMutex
+	subs []func(ctx context.Context, match *domain.ProximityMatch)
+}
+
+// NewMatchFeed creates a new match feed instance
+func NewMatchFeed(db *DB) *MatchFeed {
+	return &MatchFeed{
+		db: db,
+	}
+}
+
+// Deliver sends match to the subscribers of every instance. A notification payload is limited
+// to 8000 bytes, a larger match fails to be sent
+func (mf *MatchFeed) Deliver(ctx context.Context, subscription *domain.Subscription, match *domain.ProximityMatch) error {
+	payload, err := json.Marshal(match)
+	if err != nil {
+		return err
+	}
+
+	_, err = mf.db.Conn(ctx).Exec(ctx, "SELECT pg_notify($1, $2)", matchesChannel, string(payload))
+	return err
+}
+
+func (mf *MatchFeed) Subscribe(fn func(ctx context.Context, match *domain.ProximityMatch)) {
+	mf.mu.Lock()
+	defer mf.mu.Unlock()
+
+	mf.subs = append(mf.subs, fn)
+}
+
+// Run listens for matches until ctx is done, the matches sent while the connection is down are lost
+func (mf *MatchFeed) Run(ctx context.Context) {
+	listenChannel(ctx, mf.db, matchesChannel, "proximity match feed", func() {}, func(payload string) {
+		var match domain.ProximityMatch
+		if err := json.Unmarshal([]byte(payload), &match); err != nil {
+			logger.FromCtx(ctx).Error("Error decoding proximity match", zap.String("payload", payload), zap.Error(err))
+			return
+		}
+
+		mf.mu.RLock()
+		defer mf.mu.RUnlock()
+
+		for _, fn := range mf.subs {
+			fn(ctx, &match)
+		}
+	})
+}
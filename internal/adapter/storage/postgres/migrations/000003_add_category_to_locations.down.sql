@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_locations_category;
+
+ALTER TABLE locations DROP COLUMN IF EXISTS category;
\ No newline at end of file
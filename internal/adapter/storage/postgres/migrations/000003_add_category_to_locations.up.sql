@@ -0,0 +1,3 @@
+ALTER TABLE locations ADD COLUMN IF NOT EXISTS category VARCHAR(100);
+
+CREATE INDEX IF NOT EXISTS idx_locations_category ON locations (category);
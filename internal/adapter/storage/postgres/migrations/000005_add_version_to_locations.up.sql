@@ -0,0 +1 @@
+ALTER TABLE locations ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
\ No newline at end of file
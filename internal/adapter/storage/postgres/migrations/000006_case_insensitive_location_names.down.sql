@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_locations_slug;
+DROP INDEX IF EXISTS idx_locations_lower_name;
+
+ALTER TABLE locations ADD CONSTRAINT locations_name_key UNIQUE (name);
\ No newline at end of file
@@ -0,0 +1,17 @@
+-- Existing names that only differ by case, or that share a slug, keep the oldest
+-- location as is and get the start of their id appended to tell them apart
+WITH duplicates AS (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY LOWER(name) ORDER BY created_at, id) AS name_rank,
+        ROW_NUMBER() OVER (PARTITION BY slug ORDER BY created_at, id) AS slug_rank
+    FROM locations
+)
+UPDATE locations l
+SET name = l.name || ' (' || LEFT(l.id::text, 8) || ')',
+    slug = l.slug || '-' || LEFT(l.id::text, 8)
+FROM duplicates d
+WHERE l.id = d.id AND (d.name_rank > 1 OR d.slug_rank > 1);
+
+ALTER TABLE locations DROP CONSTRAINT IF EXISTS locations_name_key;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_locations_lower_name ON locations (LOWER(name));
+CREATE UNIQUE INDEX IF NOT EXISTS idx_locations_slug ON locations (slug);
\ No newline at end of file
@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS locations_notify_change ON locations;
+
+DROP FUNCTION IF EXISTS notify_location_change();
\ No newline at end of file
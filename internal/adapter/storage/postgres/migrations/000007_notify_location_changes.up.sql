@@ -0,0 +1,21 @@
+CREATE OR REPLACE FUNCTION notify_location_change() RETURNS trigger AS $$
+DECLARE
+    changed locations%ROWTYPE;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        changed := OLD;
+    ELSE
+        changed := NEW;
+    END IF;
+
+    PERFORM pg_notify('locations_changed', json_build_object(
+        'op', TG_OP, 'id', changed.id, 'name', changed.name, 'slug', changed.slug
+    )::text);
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER locations_notify_change
+AFTER INSERT OR UPDATE OR DELETE ON locations
+FOR EACH ROW EXECUTE FUNCTION notify_location_change();
\ No newline at end of file
@@ -0,0 +1,5 @@
+DROP TRIGGER IF EXISTS locations_audit ON locations;
+
+DROP FUNCTION IF EXISTS audit_location_change();
+
+DROP TABLE IF EXISTS audit_events;
\ No newline at end of file
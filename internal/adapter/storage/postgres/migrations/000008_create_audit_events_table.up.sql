@@ -0,0 +1,50 @@
+-- Audit events are partitioned by month, future partitions are created and expired
+-- ones dropped by the partition maintenance job. The default partition only catches
+-- rows outside of the partitions that exist, e.g. if the job did not run for a while
+CREATE TABLE IF NOT EXISTS audit_events (
+    id BIGSERIAL,
+    table_name VARCHAR(63) NOT NULL,
+    record_id UUID NOT NULL,
+    action VARCHAR(10) NOT NULL,
+    old_data JSONB,
+    new_data JSONB,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id, created_at)
+) PARTITION BY RANGE (created_at);
+
+CREATE INDEX IF NOT EXISTS idx_audit_events_record ON audit_events (record_id, created_at);
+
+CREATE TABLE IF NOT EXISTS audit_events_default PARTITION OF audit_events DEFAULT;
+
+DO $$
+DECLARE
+    first_month DATE := date_trunc('month', CURRENT_TIMESTAMP AT TIME ZONE 'UTC');
+BEGIN
+    FOR i IN 0..2 LOOP
+        EXECUTE format(
+            'CREATE TABLE IF NOT EXISTS %I PARTITION OF audit_events FOR VALUES FROM (%L) TO (%L)',
+            'audit_events_' || to_char(first_month + (i || ' month')::interval, 'YYYY_MM'),
+            (first_month + (i || ' month')::interval) AT TIME ZONE 'UTC',
+            (first_month + ((i + 1) || ' month')::interval) AT TIME ZONE 'UTC'
+        );
+    END LOOP;
+END $$;
+
+CREATE OR REPLACE FUNCTION audit_location_change() RETURNS trigger AS $$
+BEGIN
+    INSERT INTO audit_events (table_name, record_id, action, old_data, new_data)
+    VALUES (
+        TG_TABLE_NAME,
+        CASE WHEN TG_OP = 'DELETE' THEN OLD.id ELSE NEW.id END,
+        TG_OP,
+        CASE WHEN TG_OP = 'INSERT' THEN NULL ELSE to_jsonb(OLD) - 'geo' END,
+        CASE WHEN TG_OP = 'DELETE' THEN NULL ELSE to_jsonb(NEW) - 'geo' END
+    );
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER locations_audit
+AFTER INSERT OR UPDATE OR DELETE ON locations
+FOR EACH ROW EXECUTE FUNCTION audit_location_change();
\ No newline at end of file
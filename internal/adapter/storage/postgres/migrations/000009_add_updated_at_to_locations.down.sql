@@ -0,0 +1 @@
+ALTER TABLE locations DROP COLUMN IF EXISTS updated_at;
\ No newline at end of file
@@ -0,0 +1,6 @@
+ALTER TABLE locations ADD COLUMN IF NOT EXISTS updated_at TIMESTAMP WITH TIME ZONE;
+
+UPDATE locations SET updated_at = created_at WHERE updated_at IS NULL;
+
+ALTER TABLE locations ALTER COLUMN updated_at SET DEFAULT CURRENT_TIMESTAMP;
+ALTER TABLE locations ALTER COLUMN updated_at SET NOT NULL;
\ No newline at end of file
@@ -0,0 +1,28 @@
+-- Pings are partitioned by month like audit_events, so the partition maintenance job
+-- drops the expired ones
+CREATE TABLE IF NOT EXISTS pings (
+    id BIGSERIAL,
+    name VARCHAR(100) NOT NULL DEFAULT '',
+    source VARCHAR(64) NOT NULL DEFAULT '',
+    latency_ms DOUBLE PRECISION,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id, created_at)
+) PARTITION BY RANGE (created_at);
+
+CREATE INDEX IF NOT EXISTS idx_pings_created_at ON pings (created_at);
+
+CREATE TABLE IF NOT EXISTS pings_default PARTITION OF pings DEFAULT;
+
+DO $$
+DECLARE
+    first_month DATE := date_trunc('month', CURRENT_TIMESTAMP AT TIME ZONE 'UTC');
+BEGIN
+    FOR i IN 0..2 LOOP
+        EXECUTE format(
+            'CREATE TABLE IF NOT EXISTS %I PARTITION OF pings FOR VALUES FROM (%L) TO (%L)',
+            'pings_' || to_char(first_month + (i || ' month')::interval, 'YYYY_MM'),
+            (first_month + (i || ' month')::interval) AT TIME ZONE 'UTC',
+            (first_month + ((i + 1) || ' month')::interval) AT TIME ZONE 'UTC'
+        );
+    END LOOP;
+END $$;
\ No newline at end of file
@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS jobs (
+    id BIGSERIAL PRIMARY KEY,
+    kind VARCHAR(100) NOT NULL,
+    args JSONB NOT NULL DEFAULT '{}',
+    state VARCHAR(20) NOT NULL DEFAULT 'available'
+        CHECK (state IN ('available', 'running', 'completed', 'failed', 'cancelled')),
+    attempt INTEGER NOT NULL DEFAULT 0,
+    max_attempts INTEGER NOT NULL DEFAULT 5,
+    progress JSONB,
+    last_error TEXT,
+    scheduled_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    attempted_at TIMESTAMP WITH TIME ZONE,
+    attempted_by VARCHAR(255),
+    heartbeat_at TIMESTAMP WITH TIME ZONE,
+    finalized_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_jobs_available ON jobs (scheduled_at, id) WHERE state = 'available';
+CREATE INDEX IF NOT EXISTS idx_jobs_running ON jobs (heartbeat_at) WHERE state = 'running';
+CREATE INDEX IF NOT EXISTS idx_jobs_kind_state ON jobs (kind, state, id);
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS geofences;
\ No newline at end of file
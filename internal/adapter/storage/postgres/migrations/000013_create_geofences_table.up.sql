@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS geofences (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(255) NOT NULL,
+    slug VARCHAR(255) NOT NULL,
+    area GEOGRAPHY(Polygon, 4326) NOT NULL CHECK (ST_IsValid(area::geometry)),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_geofences_lower_name ON geofences (LOWER(name));
+CREATE UNIQUE INDEX IF NOT EXISTS idx_geofences_slug ON geofences (slug);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS location_aliases;
+
+DROP FUNCTION IF EXISTS notify_location_alias_change();
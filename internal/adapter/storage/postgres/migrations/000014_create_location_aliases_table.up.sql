@@ -0,0 +1,34 @@
+CREATE TABLE IF NOT EXISTS location_aliases (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    location_id UUID NOT NULL REFERENCES locations (id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    slug VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_location_aliases_lower_name ON location_aliases (LOWER(name));
+CREATE UNIQUE INDEX IF NOT EXISTS idx_location_aliases_slug ON location_aliases (slug);
+CREATE INDEX IF NOT EXISTS idx_location_aliases_location_id ON location_aliases (location_id);
+
+-- locations are looked up by their aliases, so a changed alias is announced as a change of its location
+CREATE OR REPLACE FUNCTION notify_location_alias_change() RETURNS trigger AS $$
+DECLARE
+    changed location_aliases%ROWTYPE;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        changed := OLD;
+    ELSE
+        changed := NEW;
+    END IF;
+
+    PERFORM pg_notify('locations_changed', json_build_object(
+        'op', 'UPDATE', 'id', changed.location_id, 'name', changed.name, 'slug', changed.slug
+    )::text);
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER location_aliases_notify_change
+AFTER INSERT OR UPDATE OR DELETE ON location_aliases
+FOR EACH ROW EXECUTE FUNCTION notify_location_alias_change();
@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS location_names (
+    location_id UUID NOT NULL REFERENCES locations (id) ON DELETE CASCADE,
+    locale VARCHAR(35) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    PRIMARY KEY (location_id, locale)
+);
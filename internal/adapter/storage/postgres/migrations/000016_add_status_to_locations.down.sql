@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_locations_pending;
+
+ALTER TABLE locations
+    DROP COLUMN IF EXISTS review_reason,
+    DROP COLUMN IF EXISTS status;
@@ -0,0 +1,7 @@
+-- locations that existed before the review workflow are public
+ALTER TABLE locations
+    ADD COLUMN IF NOT EXISTS status VARCHAR(16) NOT NULL DEFAULT 'approved'
+        CHECK (status IN ('pending', 'approved', 'rejected')),
+    ADD COLUMN IF NOT EXISTS review_reason TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_locations_pending ON locations (created_at, id) WHERE status = 'pending';
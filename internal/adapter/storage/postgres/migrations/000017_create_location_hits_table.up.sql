@@ -0,0 +1,9 @@
+-- hits are counted per location and hour, so trending windows are aggregated from few rows
+CREATE TABLE IF NOT EXISTS location_hits (
+    location_id UUID NOT NULL REFERENCES locations (id) ON DELETE CASCADE,
+    bucket TIMESTAMP WITH TIME ZONE NOT NULL,
+    hits BIGINT NOT NULL,
+    PRIMARY KEY (location_id, bucket)
+);
+
+CREATE INDEX IF NOT EXISTS idx_location_hits_bucket ON location_hits (bucket);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS collection_locations;
+
+DROP FUNCTION IF EXISTS notify_collection_location_change();
+
+DROP TABLE IF EXISTS collections;
@@ -0,0 +1,41 @@
+CREATE TABLE IF NOT EXISTS collections (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(255) NOT NULL,
+    slug VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_collections_lower_name ON collections (LOWER(name));
+CREATE UNIQUE INDEX IF NOT EXISTS idx_collections_slug ON collections (slug);
+
+CREATE TABLE IF NOT EXISTS collection_locations (
+    collection_id UUID NOT NULL REFERENCES collections (id) ON DELETE CASCADE,
+    location_id UUID NOT NULL REFERENCES locations (id) ON DELETE CASCADE,
+    added_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (collection_id, location_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_collection_locations_location_id ON collection_locations (location_id);
+
+-- lists are cached per collection, so a changed membership is announced as a change of its location
+CREATE OR REPLACE FUNCTION notify_collection_location_change() RETURNS trigger AS $$
+DECLARE
+    changed collection_locations%ROWTYPE;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        changed := OLD;
+    ELSE
+        changed := NEW;
+    END IF;
+
+    PERFORM pg_notify('locations_changed', json_build_object(
+        'op', 'UPDATE', 'id', changed.location_id
+    )::text);
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER collection_locations_notify_change
+AFTER INSERT OR UPDATE OR DELETE ON collection_locations
+FOR EACH ROW EXECUTE FUNCTION notify_collection_location_change();
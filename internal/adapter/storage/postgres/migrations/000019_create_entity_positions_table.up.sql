@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS entity_positions (
+    entity_id VARCHAR(64) PRIMARY KEY,
+    kind VARCHAR(32) NOT NULL DEFAULT '',
+    latitude DOUBLE PRECISION NOT NULL,
+    longitude DOUBLE PRECISION NOT NULL,
+    geo GEOGRAPHY(Point, 4326) NOT NULL,
+    reported_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_entity_positions_geo ON entity_positions USING GIST (geo);
+CREATE INDEX IF NOT EXISTS idx_entity_positions_reported_at ON entity_positions (reported_at);
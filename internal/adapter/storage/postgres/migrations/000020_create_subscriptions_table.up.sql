@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS subscriptions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    target VARCHAR(16) NOT NULL,
+    kind VARCHAR(100) NOT NULL DEFAULT '',
+    latitude DOUBLE PRECISION NOT NULL,
+    longitude DOUBLE PRECISION NOT NULL,
+    center GEOGRAPHY(Point, 4326) NOT NULL,
+    radius DOUBLE PRECISION NOT NULL,
+    webhook_url VARCHAR(2048) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_subscriptions_center ON subscriptions USING GIST (center);
@@ -0,0 +1,3 @@
+-- altitude is in meters above sea level, distances to locations without one are measured on the ground
+ALTER TABLE locations
+    ADD COLUMN IF NOT EXISTS altitude DOUBLE PRECISION CHECK (altitude BETWEEN -1000 AND 20000);
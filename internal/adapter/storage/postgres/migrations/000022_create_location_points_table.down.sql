@@ -0,0 +1,13 @@
+DROP TABLE IF EXISTS location_points;
+
+DROP FUNCTION IF EXISTS refresh_location_access();
+
+DROP TRIGGER IF EXISTS locations_set_access ON locations;
+
+DROP FUNCTION IF EXISTS set_location_access();
+
+DROP INDEX IF EXISTS idx_locations_access;
+
+ALTER TABLE locations DROP COLUMN IF EXISTS access;
+
+DROP FUNCTION IF EXISTS location_access(UUID, GEOGRAPHY);
@@ -0,0 +1,57 @@
+CREATE TABLE IF NOT EXISTS location_points (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    location_id UUID NOT NULL REFERENCES locations (id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    name VARCHAR(255) NOT NULL DEFAULT '',
+    latitude DOUBLE PRECISION NOT NULL,
+    longitude DOUBLE PRECISION NOT NULL,
+    geo GEOGRAPHY(Point, 4326) NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_location_points_location_id ON location_points (location_id);
+
+-- access is where a location is reached from, the multipoint of its entrances or its own point without any.
+-- Nearest searches measure to it, so the distance to a location is the distance to its closest entrance
+CREATE OR REPLACE FUNCTION location_access(location UUID, point GEOGRAPHY) RETURNS GEOGRAPHY AS $$
+    SELECT COALESCE(
+        (SELECT ST_Collect(p.geo::geometry)::geography FROM location_points p WHERE p.location_id = $1),
+        $2
+    );
+$$ LANGUAGE sql STABLE;
+
+ALTER TABLE locations ADD COLUMN IF NOT EXISTS access GEOGRAPHY(Geometry, 4326);
+UPDATE locations SET access = geo;
+ALTER TABLE locations ALTER COLUMN access SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_locations_access ON locations USING GIST (access);
+
+CREATE OR REPLACE FUNCTION set_location_access() RETURNS trigger AS $$
+BEGIN
+    NEW.access := location_access(NEW.id, NEW.geo);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER locations_set_access
+BEFORE INSERT OR UPDATE OF geo ON locations
+FOR EACH ROW EXECUTE FUNCTION set_location_access();
+
+-- once per statement, so replacing the entrances of a location updates it, and announces its change, once
+CREATE OR REPLACE FUNCTION refresh_location_access() RETURNS trigger AS $$
+BEGIN
+    UPDATE locations SET access = location_access(id, geo)
+    WHERE id IN (SELECT location_id FROM changed_points);
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER location_points_inserted
+AFTER INSERT ON location_points
+REFERENCING NEW TABLE AS changed_points
+FOR EACH STATEMENT EXECUTE FUNCTION refresh_location_access();
+
+CREATE TRIGGER location_points_deleted
+AFTER DELETE ON location_points
+REFERENCING OLD TABLE AS changed_points
+FOR EACH STATEMENT EXECUTE FUNCTION refresh_location_access();
@@ -0,0 +1,34 @@
+CREATE OR REPLACE FUNCTION location_access(location UUID, point GEOGRAPHY) RETURNS GEOGRAPHY AS $$
+    SELECT COALESCE(
+        (SELECT ST_Collect(p.geo::geometry)::geography FROM location_points p WHERE p.location_id = $1),
+        $2
+    );
+$$ LANGUAGE sql STABLE;
+
+CREATE OR REPLACE FUNCTION set_location_access() RETURNS trigger AS $$
+BEGIN
+    NEW.access := location_access(NEW.id, NEW.geo);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION refresh_location_access() RETURNS trigger AS $$
+BEGIN
+    UPDATE locations SET access = location_access(id, geo)
+    WHERE id IN (SELECT location_id FROM changed_points);
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS locations_set_access ON locations;
+
+CREATE TRIGGER locations_set_access
+BEFORE INSERT OR UPDATE OF geo ON locations
+FOR EACH ROW EXECUTE FUNCTION set_location_access();
+
+DROP FUNCTION IF EXISTS location_access(UUID, GEOGRAPHY, GEOGRAPHY);
+
+ALTER TABLE locations DROP COLUMN IF EXISTS footprint;
+
+UPDATE locations SET access = location_access(id, geo);
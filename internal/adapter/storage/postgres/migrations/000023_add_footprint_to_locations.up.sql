@@ -0,0 +1,36 @@
+-- footprint is the boundary of a location spreading over an area, such as a campus or a park
+ALTER TABLE locations
+    ADD COLUMN IF NOT EXISTS footprint GEOGRAPHY(Polygon, 4326) CHECK (ST_IsValid(footprint::geometry));
+
+-- a location is reached from its entrances, or else across the boundary of its footprint, or else at its point
+CREATE OR REPLACE FUNCTION location_access(location UUID, point GEOGRAPHY, footprint GEOGRAPHY) RETURNS GEOGRAPHY AS $$
+    SELECT COALESCE(
+        (SELECT ST_Collect(p.geo::geometry)::geography FROM location_points p WHERE p.location_id = $1),
+        $3,
+        $2
+    );
+$$ LANGUAGE sql STABLE;
+
+CREATE OR REPLACE FUNCTION set_location_access() RETURNS trigger AS $$
+BEGIN
+    NEW.access := location_access(NEW.id, NEW.geo, NEW.footprint);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION refresh_location_access() RETURNS trigger AS $$
+BEGIN
+    UPDATE locations SET access = location_access(id, geo, footprint)
+    WHERE id IN (SELECT location_id FROM changed_points);
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS locations_set_access ON locations;
+
+CREATE TRIGGER locations_set_access
+BEFORE INSERT OR UPDATE OF geo, footprint ON locations
+FOR EACH ROW EXECUTE FUNCTION set_location_access();
+
+DROP FUNCTION IF EXISTS location_access(UUID, GEOGRAPHY);
@@ -0,0 +1,10 @@
+DROP TABLE IF EXISTS location_syncs;
+
+DROP INDEX IF EXISTS idx_locations_source;
+
+DELETE FROM locations WHERE status = 'removed';
+
+ALTER TABLE locations
+    DROP CONSTRAINT IF EXISTS locations_status_check,
+    ADD CONSTRAINT locations_status_check CHECK (status IN ('pending', 'approved', 'rejected')),
+    DROP COLUMN IF EXISTS source;
@@ -0,0 +1,28 @@
+-- source names the external source a synced location comes from, removed locations were dropped by their source
+-- and are kept hidden so they are restored if it lists them again
+ALTER TABLE locations
+    ADD COLUMN IF NOT EXISTS source VARCHAR(100),
+    DROP CONSTRAINT IF EXISTS locations_status_check,
+    ADD CONSTRAINT locations_status_check CHECK (status IN ('pending', 'approved', 'rejected', 'removed'));
+
+CREATE INDEX IF NOT EXISTS idx_locations_source ON locations (source) WHERE source IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS location_syncs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    source VARCHAR(100) NOT NULL,
+    dry_run BOOLEAN NOT NULL DEFAULT FALSE,
+    status VARCHAR(16) NOT NULL DEFAULT 'running' CHECK (status IN ('running', 'succeeded', 'failed')),
+    total INTEGER NOT NULL DEFAULT 0,
+    created INTEGER NOT NULL DEFAULT 0,
+    updated INTEGER NOT NULL DEFAULT 0,
+    removed INTEGER NOT NULL DEFAULT 0,
+    restored INTEGER NOT NULL DEFAULT 0,
+    unchanged INTEGER NOT NULL DEFAULT 0,
+    failed INTEGER NOT NULL DEFAULT 0,
+    changes JSONB NOT NULL DEFAULT '[]',
+    error TEXT,
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    finished_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_location_syncs_started_at ON location_syncs (started_at DESC, id);
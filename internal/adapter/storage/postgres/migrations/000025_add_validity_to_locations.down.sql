@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_locations_valid_until;
+
+ALTER TABLE locations
+    DROP COLUMN IF EXISTS expired,
+    DROP COLUMN IF EXISTS valid_until,
+    DROP COLUMN IF EXISTS valid_from;
@@ -0,0 +1,9 @@
+-- valid_from and valid_until bound the time a location is listed and found by nearest searches, such as a pop-up
+-- store or an event. expired is set by a recurring job once valid_until has passed, which writes a
+-- location.expired event and lets the caches holding the location drop it
+ALTER TABLE locations
+    ADD COLUMN IF NOT EXISTS valid_from TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN IF NOT EXISTS valid_until TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN IF NOT EXISTS expired BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX IF NOT EXISTS idx_locations_valid_until ON locations (valid_until) WHERE valid_until IS NOT NULL AND NOT expired;
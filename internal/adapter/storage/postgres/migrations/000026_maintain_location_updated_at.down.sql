@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_locations_updated_at;
+
+DROP TRIGGER IF EXISTS locations_set_updated_at ON locations;
+DROP FUNCTION IF EXISTS set_location_updated_at();
@@ -0,0 +1,14 @@
+-- updated_at is when a location last changed. It is set on every update of its row rather than by each
+-- statement, so changes such as its expiry or new entrances move it too. "Recently changed" lists are ordered by it
+CREATE OR REPLACE FUNCTION set_location_updated_at() RETURNS trigger AS $$
+BEGIN
+    NEW.updated_at := CURRENT_TIMESTAMP;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER locations_set_updated_at
+BEFORE UPDATE ON locations
+FOR EACH ROW EXECUTE FUNCTION set_location_updated_at();
+
+CREATE INDEX IF NOT EXISTS idx_locations_updated_at ON locations (updated_at DESC);
@@ -0,0 +1,21 @@
+-- locations_shadow holds the locations of a snapshot restored for comparison, leaving the locations as they are.
+-- Each restore into it replaces the previous one
+CREATE TABLE IF NOT EXISTS locations_shadow (
+    id UUID PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    slug VARCHAR(255) NOT NULL,
+    latitude DOUBLE PRECISION NOT NULL,
+    longitude DOUBLE PRECISION NOT NULL,
+    category VARCHAR(100),
+    altitude DOUBLE PRECISION,
+    names JSONB,
+    entrances JSONB,
+    footprint JSONB,
+    source VARCHAR(100),
+    valid_from TIMESTAMP WITH TIME ZONE,
+    valid_until TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE,
+    snapshot TEXT NOT NULL,
+    loaded_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,7 @@
+ALTER TABLE locations DISABLE ROW LEVEL SECURITY, NO FORCE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS locations_tenant ON locations;
+
+DROP INDEX IF EXISTS idx_locations_tenant;
+
+ALTER TABLE locations DROP COLUMN IF EXISTS tenant;
@@ -0,0 +1,10 @@
+-- tenant is the tenant of the caller that created the location, read from the setting the connection
+-- of the caller is given with the rls tenancy, and empty for the callers without a tenant
+ALTER TABLE locations ADD COLUMN IF NOT EXISTS tenant TEXT NOT NULL DEFAULT COALESCE(current_setting('app.tenant', true), '');
+
+CREATE INDEX IF NOT EXISTS idx_locations_tenant ON locations (tenant);
+
+-- callers without a tenant see every location, the others only the ones of their tenant. The policy
+-- only applies once row level security is enabled on the table, which the rls tenancy does on start
+CREATE POLICY locations_tenant ON locations
+    USING (COALESCE(current_setting('app.tenant', true), '') IN ('', tenant));
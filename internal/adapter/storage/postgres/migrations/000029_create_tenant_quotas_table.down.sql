@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_subscriptions_tenant;
+
+ALTER TABLE subscriptions DROP COLUMN IF EXISTS tenant;
+
+DROP TABLE IF EXISTS tenant_quotas;
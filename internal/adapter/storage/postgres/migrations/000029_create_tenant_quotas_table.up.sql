@@ -0,0 +1,13 @@
+-- tenant_quotas holds the quotas set for the tenants by the admins, a limit left NULL is the default one
+-- of the configuration and 0 lifts it
+CREATE TABLE IF NOT EXISTS tenant_quotas (
+    tenant TEXT PRIMARY KEY,
+    max_locations INTEGER CHECK (max_locations >= 0),
+    max_webhooks INTEGER CHECK (max_webhooks >= 0),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- tenant is the tenant of the caller that created the subscription, its webhooks count against its quota
+ALTER TABLE subscriptions ADD COLUMN IF NOT EXISTS tenant TEXT NOT NULL DEFAULT COALESCE(current_setting('app.tenant', true), '');
+
+CREATE INDEX IF NOT EXISTS idx_subscriptions_tenant ON subscriptions (tenant) WHERE webhook_url <> '';
@@ -0,0 +1,14 @@
+-- location_shares are the links granting read access to a single location without authentication. The links
+-- are signed, a row is kept per link so that it can be revoked before it expires
+CREATE TABLE IF NOT EXISTS location_shares (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    location_id UUID NOT NULL REFERENCES locations (id) ON DELETE CASCADE,
+    -- tenant is the tenant of the caller that shared the location, the link reads the location for it
+    tenant TEXT NOT NULL DEFAULT COALESCE(current_setting('app.tenant', true), ''),
+    created_by TEXT NOT NULL DEFAULT '',
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_location_shares_location_id ON location_shares (location_id);
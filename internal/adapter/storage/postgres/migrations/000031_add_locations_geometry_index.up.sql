@@ -0,0 +1,3 @@
+-- the vector tiles pick their locations by comparing them with the bounds of the tile as geometries,
+-- which the index on the geography column does not serve
+CREATE INDEX IF NOT EXISTS idx_locations_geo_geometry ON locations USING GIST ((geo::geometry));
@@ -0,0 +1,40 @@
+ALTER TABLE outbox DROP COLUMN IF EXISTS tenant;
+
+ALTER TABLE location_aliases DISABLE ROW LEVEL SECURITY, NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE location_names DISABLE ROW LEVEL SECURITY, NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE collections DISABLE ROW LEVEL SECURITY, NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE collection_locations DISABLE ROW LEVEL SECURITY, NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE location_shares DISABLE ROW LEVEL SECURITY, NO FORCE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS collection_locations_tenant ON collection_locations;
+DROP POLICY IF EXISTS location_names_tenant ON location_names;
+DROP POLICY IF EXISTS location_shares_tenant ON location_shares;
+DROP POLICY IF EXISTS collections_tenant ON collections;
+DROP POLICY IF EXISTS location_aliases_tenant ON location_aliases;
+
+DROP INDEX IF EXISTS idx_collections_lower_name;
+DROP INDEX IF EXISTS idx_collections_slug;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_collections_lower_name ON collections (LOWER(name));
+CREATE UNIQUE INDEX IF NOT EXISTS idx_collections_slug ON collections (slug);
+
+ALTER TABLE collections DROP COLUMN IF EXISTS tenant;
+
+DROP INDEX IF EXISTS idx_location_aliases_lower_name;
+DROP INDEX IF EXISTS idx_location_aliases_slug;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_location_aliases_lower_name ON location_aliases (LOWER(name));
+CREATE UNIQUE INDEX IF NOT EXISTS idx_location_aliases_slug ON location_aliases (slug);
+
+ALTER TABLE location_aliases DROP COLUMN IF EXISTS tenant;
+
+DROP INDEX IF EXISTS idx_locations_lower_name;
+DROP INDEX IF EXISTS idx_locations_slug;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_locations_lower_name ON locations (LOWER(name));
+CREATE UNIQUE INDEX IF NOT EXISTS idx_locations_slug ON locations (slug);
+
+DROP POLICY IF EXISTS locations_tenant ON locations;
+
+CREATE POLICY locations_tenant ON locations
+    USING (COALESCE(current_setting('app.tenant', true), '') IN ('', tenant));
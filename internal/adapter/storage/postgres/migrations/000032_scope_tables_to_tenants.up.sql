@@ -0,0 +1,56 @@
+-- the callers without a tenant only see the locations without one, the background jobs maintaining the locations
+-- of every tenant set app.all_tenants to see them all
+DROP POLICY IF EXISTS locations_tenant ON locations;
+
+CREATE POLICY locations_tenant ON locations
+    USING (tenant = COALESCE(current_setting('app.tenant', true), '') OR current_setting('app.all_tenants', true) = 'on');
+
+-- names and slugs are only unique within a tenant
+DROP INDEX IF EXISTS idx_locations_lower_name;
+DROP INDEX IF EXISTS idx_locations_slug;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_locations_lower_name ON locations (tenant, LOWER(name));
+CREATE UNIQUE INDEX IF NOT EXISTS idx_locations_slug ON locations (tenant, slug);
+
+-- aliases and collections have names of their own, so they are tagged with a tenant like the locations,
+-- the aliases with the one of their location
+ALTER TABLE location_aliases ADD COLUMN IF NOT EXISTS tenant TEXT NOT NULL DEFAULT COALESCE(current_setting('app.tenant', true), '');
+
+UPDATE location_aliases a SET tenant = l.tenant FROM locations l WHERE l.id = a.location_id AND a.tenant <> l.tenant;
+
+DROP INDEX IF EXISTS idx_location_aliases_lower_name;
+DROP INDEX IF EXISTS idx_location_aliases_slug;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_location_aliases_lower_name ON location_aliases (tenant, LOWER(name));
+CREATE UNIQUE INDEX IF NOT EXISTS idx_location_aliases_slug ON location_aliases (tenant, slug);
+
+CREATE POLICY location_aliases_tenant ON location_aliases
+    USING (tenant = COALESCE(current_setting('app.tenant', true), '') OR current_setting('app.all_tenants', true) = 'on');
+
+ALTER TABLE collections ADD COLUMN IF NOT EXISTS tenant TEXT NOT NULL DEFAULT COALESCE(current_setting('app.tenant', true), '');
+
+DROP INDEX IF EXISTS idx_collections_lower_name;
+DROP INDEX IF EXISTS idx_collections_slug;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_collections_lower_name ON collections (tenant, LOWER(name));
+CREATE UNIQUE INDEX IF NOT EXISTS idx_collections_slug ON collections (tenant, slug);
+
+CREATE POLICY collections_tenant ON collections
+    USING (tenant = COALESCE(current_setting('app.tenant', true), '') OR current_setting('app.all_tenants', true) = 'on');
+
+CREATE POLICY location_shares_tenant ON location_shares
+    USING (tenant = COALESCE(current_setting('app.tenant', true), '') OR current_setting('app.all_tenants', true) = 'on');
+
+-- the localized names and the memberships of the collections are seen, and written, with their locations, which
+-- the policy of the locations scopes within the subqueries
+CREATE POLICY location_names_tenant ON location_names
+    USING (EXISTS (SELECT 1 FROM locations l WHERE l.id = location_id));
+
+CREATE POLICY collection_locations_tenant ON collection_locations
+    USING (
+        EXISTS (SELECT 1 FROM locations l WHERE l.id = location_id)
+        AND EXISTS (SELECT 1 FROM collections c WHERE c.id = collection_id)
+    );
+
+-- the created locations are only matched against the subscriptions of their tenant
+ALTER TABLE outbox ADD COLUMN IF NOT EXISTS tenant TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,171 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"leeta/internal/adapter/config"
+	"leeta/internal/adapter/logger"
+	"leeta/internal/core/domain"
+	"leeta/internal/core/port"
+
+	"go.uber.org/zap"
+)
+
+const (
+	defaultRelayInterval  = time.Second
+	defaultRelayBatchSize = 100
+	defaultRelayMaxLag    = time.Minute
+)
+
+/**
+ * Relay publishes the events written to the outbox table.
+ * Events are locked while they are published, so several
+ * relays can run against the same database without
+ * publishing an event twice, and they are only marked as
+ * published once the publisher accepted them
+ */
+type Relay struct {
+	db        *DB
+	publisher port.EventPublisher
+	interval  time.Duration
+	batchSize int
+	maxLag    time.Duration
+}
+
+// NewRelay creates a new outbox relay instance
+func NewRelay(db *DB, publisher port.EventPublisher, config *config.OutboxConfiguration) *Relay {
+	interval := config.Interval
+	if interval <= 0 {
+		interval = defaultRelayInterval
+	}
+
+	batchSize := config.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultRelayBatchSize
+	}
+
+	maxLag := config.MaxLag
+	if maxLag <= 0 {
+		maxLag = defaultRelayMaxLag
+	}
+
+	return &Relay{
+		db,
+		publisher,
+		interval,
+		batchSize,
+		maxLag,
+	}
+}
+
+// Check fails when the oldest pending event has waited longer than the max lag,
+// which means the relay or the webhooks are falling behind
+func (r *Relay) Check(ctx context.Context) error {
+	var pending int64
+	var oldest *time.Time
+
+	err := r.db.Conn(ctx).QueryRow(ctx, `
+		SELECT COUNT(*), MIN(created_at) FROM outbox WHERE published_at IS NULL
+	`).Scan(&pending, &oldest)
+	if err != nil {
+		return err
+	}
+
+	if oldest != nil && time.Since(*oldest) > r.maxLag {
+		return fmt.Errorf("%d events pending, the oldest for %s", pending, time.Since(*oldest).Round(time.Second))
+	}
+
+	return nil
+}
+
+// Run publishes pending events every interval until ctx is done
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		// keep going while full batches are published, the outbox may have a backlog
+		for {
+			n, err := r.relay(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					logger.FromCtx(ctx).Error("Error relaying outbox events", zap.Error(err))
+				}
+				break
+			}
+			if n < r.batchSize {
+				break
+			}
+		}
+	}
+}
+
+// relay publishes the next batch of pending events in order and returns how many were published.
+// Publishing stops at the first failure so the failed event and the ones after it are retried
+func (r *Relay) relay(ctx context.Context) (int, error) {
+	published := 0
+
+	err := r.db.WithinTx(ctx, func(ctx context.Context) error {
+		published = 0
+		conn := r.db.Conn(ctx)
+
+		rows, err := conn.Query(ctx, `
+			SELECT id, event_type, aggregate_id, payload, tenant, created_at
+			FROM outbox
+			WHERE published_at IS NULL
+			ORDER BY id
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		`, r.batchSize)
+		if err != nil {
+			return err
+		}
+
+		var events []domain.Event
+		for rows.Next() {
+			var event domain.Event
+			if err := rows.Scan(&event.ID, &event.Type, &event.AggregateID, &event.Payload, &event.Tenant, &event.CreatedAt); err != nil {
+				rows.Close()
+				return err
+			}
+			events = append(events, event)
+		}
+
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		ids := make([]int64, 0, len(events))
+		for i := range events {
+			if err := r.publisher.Publish(ctx, &events[i]); err != nil {
+				logger.FromCtx(ctx).Warn("Error publishing event, it will be retried",
+					zap.Int64("event_id", events[i].ID), zap.Error(err))
+				break
+			}
+			ids = append(ids, events[i].ID)
+		}
+
+		if len(ids) == 0 {
+			return nil
+		}
+
+		_, err = conn.Exec(ctx, "UPDATE outbox SET published_at = CURRENT_TIMESTAMP WHERE id = ANY($1)", ids)
+		if err != nil {
+			return err
+		}
+
+		published = len(ids)
+		return nil
+	})
+
+	return published, err
+}
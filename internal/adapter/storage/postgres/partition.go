@@ -0,0 +1,130 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"leeta/internal/adapter/config"
+	"leeta/internal/adapter/logger"
+
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultPartitionPremake = 3
+
+	// partitionSuffix is the layout of the month at the end of a partition name, e.g. audit_events_2025_01
+	partitionSuffix = "2006_01"
+)
+
+// partitionedTables are the tables partitioned by month on created_at
+var partitionedTables = []string{"audit_events", "pings"}
+
+/**
+ * Partitioner maintains the monthly partitions of the partitioned
+ * tables. It creates the partitions of the upcoming months ahead
+ * of time and drops the ones older than the retention
+ */
+type Partitioner struct {
+	db        *DB
+	premake   int
+	retention int
+}
+
+// NewPartitioner creates a new partition maintenance instance
+func NewPartitioner(db *DB, config *config.PartitionConfiguration) *Partitioner {
+	premake := config.Premake
+	if premake <= 0 {
+		premake = defaultPartitionPremake
+	}
+
+	return &Partitioner{
+		db,
+		premake,
+		config.RetentionMonths,
+	}
+}
+
+// MaintainAll maintains the partitions of every partitioned table as of now
+func (p *Partitioner) MaintainAll(ctx context.Context) error {
+	var errs []error
+	for _, table := range partitionedTables {
+		if err := p.Maintain(ctx, table, time.Now()); err != nil {
+			errs = append(errs, fmt.Errorf("maintaining partitions of %s: %w", table, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Maintain creates the partitions of table from the month of now up to premake months ahead,
+// and drops the partitions that ended more than the retention ago
+func (p *Partitioner) Maintain(ctx context.Context, table string, now time.Time) error {
+	month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	for i := 0; i <= p.premake; i++ {
+		from := month.AddDate(0, i, 0)
+		name := table + "_" + from.Format(partitionSuffix)
+
+		_, err := p.db.Conn(ctx).Exec(ctx, fmt.Sprintf(
+			"CREATE TABLE IF NOT EXISTS %s PARTITION OF %s FOR VALUES FROM ('%s') TO ('%s')",
+			pgx.Identifier{name}.Sanitize(), pgx.Identifier{table}.Sanitize(),
+			from.Format(time.RFC3339), from.AddDate(0, 1, 0).Format(time.RFC3339),
+		))
+		if err != nil {
+			return fmt.Errorf("creating partition %s: %w", name, err)
+		}
+	}
+
+	if p.retention <= 0 {
+		return nil
+	}
+
+	partitions, err := p.partitions(ctx, table)
+	if err != nil {
+		return err
+	}
+
+	// a partition expires once its last row is older than the retention
+	expired := month.AddDate(0, -p.retention, 0)
+	for _, name := range partitions {
+		start, err := time.Parse(partitionSuffix, strings.TrimPrefix(name, table+"_"))
+		if err != nil {
+			// not a monthly partition, such as the default partition
+			continue
+		}
+
+		if start.AddDate(0, 1, 0).After(expired) {
+			continue
+		}
+
+		_, err = p.db.Conn(ctx).Exec(ctx, "DROP TABLE IF EXISTS "+pgx.Identifier{name}.Sanitize())
+		if err != nil {
+			return fmt.Errorf("dropping partition %s: %w", name, err)
+		}
+
+		logger.FromCtx(ctx).Info("Dropped expired partition", zap.String("table", table), zap.String("partition", name))
+	}
+
+	return nil
+}
+
+// partitions returns the names of the partitions of table
+func (p *Partitioner) partitions(ctx context.Context, table string) ([]string, error) {
+	rows, err := p.db.Conn(ctx).Query(ctx, `
+		SELECT child.relname
+		FROM pg_inherits
+		JOIN pg_class parent ON parent.oid = pg_inherits.inhparent
+		JOIN pg_class child ON child.oid = pg_inherits.inhrelid
+		WHERE parent.relname = $1
+	`, table)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgx.CollectRows(rows, pgx.RowTo[string])
+}
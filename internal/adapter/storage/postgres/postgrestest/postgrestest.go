@@ -0,0 +1,150 @@
+// Package postgrestest runs a disposable PostGIS database in a container for the tests,
+// so they only need Docker instead of a database set up by hand
+package postgrestest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"leeta/internal/adapter/config"
+	"leeta/internal/adapter/storage/postgres"
+
+	"github.com/testcontainers/testcontainers-go"
+	tcpostgres "github.com/testcontainers/testcontainers-go/modules/postgres"
+)
+
+// image matches the database of docker-compose.yml
+const image = "postgis/postgis:16-3.5-alpine"
+
+var (
+	once      sync.Once
+	container *tcpostgres.PostgresContainer
+	dbConfig  *config.DatabaseConfiguration
+	// skip is why the tests needing the database are skipped, startErr why they fail
+	skip     string
+	startErr error
+)
+
+// Config returns the configuration of the test database. The database is started and
+// migrated on first use and shared by the tests of the package.
+// The test is skipped when Docker is not available
+func Config(tb testing.TB) *config.DatabaseConfiguration {
+	tb.Helper()
+
+	once.Do(start)
+
+	if skip != "" {
+		tb.Skip(skip)
+	}
+	if startErr != nil {
+		tb.Fatalf("Failed to start the test database: %v", startErr)
+	}
+
+	copied := *dbConfig
+	return &copied
+}
+
+// DB connects to the test database, the connection is closed when the test ends
+func DB(tb testing.TB) *postgres.DB {
+	tb.Helper()
+
+	db, err := postgres.New(context.Background(), Config(tb))
+	if err != nil {
+		tb.Fatalf("Failed to connect to the test database: %v", err)
+	}
+	tb.Cleanup(db.Close)
+
+	return db
+}
+
+// Run runs the tests and then removes the database if one was started, call it from TestMain:
+//
+//	func TestMain(m *testing.M) {
+//		os.Exit(postgrestest.Run(m))
+//	}
+func Run(m *testing.M) int {
+	code := m.Run()
+
+	if container != nil {
+		if err := testcontainers.TerminateContainer(container); err != nil {
+			fmt.Printf("Failed to remove the test database: %v\n", err)
+		}
+	}
+
+	return code
+}
+
+// start starts the container and migrates the database
+func start() {
+	ctx := context.Background()
+
+	if reason := dockerUnavailable(ctx); reason != "" {
+		skip = reason
+		return
+	}
+
+	const name, user, password = "leeta", "postgres", "postgres"
+
+	container, startErr = tcpostgres.Run(ctx, image,
+		tcpostgres.WithDatabase(name),
+		tcpostgres.WithUsername(user),
+		tcpostgres.WithPassword(password),
+		tcpostgres.BasicWaitStrategies(),
+	)
+	if startErr != nil {
+		return
+	}
+
+	host, err := container.Host(ctx)
+	if err != nil {
+		startErr = err
+		return
+	}
+
+	port, err := container.MappedPort(ctx, "5432/tcp")
+	if err != nil {
+		startErr = err
+		return
+	}
+
+	dbConfig = &config.DatabaseConfiguration{
+		Protocol: "postgres",
+		Host:     host,
+		Port:     port.Port(),
+		User:     user,
+		Password: password,
+		Name:     name,
+	}
+
+	db, err := postgres.New(ctx, dbConfig)
+	if err != nil {
+		startErr = err
+		return
+	}
+	defer db.Close()
+
+	startErr = db.Migrate()
+}
+
+// dockerUnavailable tells why containers cannot be started, if they cannot
+func dockerUnavailable(ctx context.Context) (reason string) {
+	defer func() {
+		if r := recover(); r != nil {
+			reason = fmt.Sprintf("Docker is not available: %v", r)
+		}
+	}()
+
+	provider, err := testcontainers.ProviderDocker.GetProvider()
+	if err != nil {
+		return fmt.Sprintf("Docker is not available: %v", err)
+	}
+	defer provider.Close()
+
+	if err := provider.Health(ctx); err != nil {
+		return fmt.Sprintf("Docker is not available: %v", err)
+	}
+
+	return ""
+}
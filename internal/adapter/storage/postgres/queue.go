@@ -0,0 +1,339 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"leeta/internal/adapter/config"
+	"leeta/internal/adapter/logger"
+	"leeta/internal/core/domain"
+
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultQueueConcurrency = 4
+	defaultQueueInterval    = time.Second
+	defaultQueueRescueAfter = 5 * time.Minute
+
+	// maxJobBackoff caps the wait before a failed attempt is retried
+	maxJobBackoff = time.Hour
+)
+
+// queueJobColumns are the columns of the jobs claimed by a worker
+const queueJobColumns = "id, kind, args, attempt, max_attempts, progress, created_at"
+
+// JobWorker runs a job of the kind it is registered for. A returned error fails the attempt,
+// which is retried with a backoff until the job runs out of attempts
+type JobWorker func(ctx context.Context, job *domain.Job) error
+
+/**
+ * Queue runs the jobs of the "jobs" table. Workers claim the
+ * available jobs with FOR UPDATE SKIP LOCKED, so any number of
+ * instances can share the queue, and keep a heartbeat on the
+ * jobs they run. Jobs whose heartbeat stopped, because their
+ * instance died, are made available again
+ */
+type Queue struct {
+	db          *DB
+	concurrency int
+	interval    time.Duration
+	rescueAfter time.Duration
+	name        string
+
+	workers  map[string]JobWorker
+	slots    chan struct{}
+	running  sync.WaitGroup
+	stopping atomic.Bool
+
+	mu     sync.Mutex
+	active map[int64]bool
+}
+
+// NewQueue creates a new job queue instance
+func NewQueue(db *DB, config *config.JobQueueConfiguration) *Queue {
+	concurrency := config.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultQueueConcurrency
+	}
+
+	interval := config.Interval
+	if interval <= 0 {
+		interval = defaultQueueInterval
+	}
+
+	rescueAfter := config.RescueAfter
+	if rescueAfter <= 0 {
+		rescueAfter = defaultQueueRescueAfter
+	}
+
+	hostname, _ := os.Hostname()
+
+	return &Queue{
+		db:          db,
+		concurrency: concurrency,
+		interval:    interval,
+		rescueAfter: rescueAfter,
+		name:        fmt.Sprintf("%s-%d", hostname, os.Getpid()),
+		workers:     make(map[string]JobWorker),
+		slots:       make(chan struct{}, concurrency),
+		active:      make(map[int64]bool),
+	}
+}
+
+// Register sets the worker of the jobs of kind, it must be called before Run
+func (q *Queue) Register(kind string, worker JobWorker) {
+	q.workers[kind] = worker
+}
+
+// Run claims and runs the available jobs every interval until ctx is done. The jobs still running
+// then are canceled and made available again, Shutdown lets them finish first
+func (q *Queue) Run(ctx context.Context) {
+	ticker := time.NewTicker(q.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			q.running.Wait()
+			return
+		case <-ticker.C:
+		}
+
+		if err := q.heartbeat(ctx); err != nil && ctx.Err() == nil {
+			logger.FromCtx(ctx).Error("Error recording the heartbeat of the running jobs", zap.Error(err))
+		}
+
+		if err := q.rescue(ctx); err != nil && ctx.Err() == nil {
+			logger.FromCtx(ctx).Error("Error rescuing the jobs of lost workers", zap.Error(err))
+		}
+
+		if q.stopping.Load() {
+			continue
+		}
+
+		jobs, err := q.claim(ctx, cap(q.slots)-len(q.slots), 0)
+		if err != nil {
+			if ctx.Err() == nil {
+				logger.FromCtx(ctx).Error("Error claiming jobs", zap.Error(err))
+			}
+			continue
+		}
+
+		for i := range jobs {
+			q.slots <- struct{}{}
+			q.running.Add(1)
+			go func() {
+				defer func() {
+					<-q.slots
+					q.running.Done()
+				}()
+				q.work(ctx, &jobs[i])
+			}()
+		}
+	}
+}
+
+// Shutdown stops claiming jobs and waits for the running ones to finish, or for ctx to be done
+func (q *Queue) Shutdown(ctx context.Context) error {
+	q.stopping.Store(true)
+
+	done := make(chan struct{})
+	go func() {
+		q.running.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// RunJob claims the job id if it is available, even if its retry is not due yet, and runs it
+// in the calling goroutine. It reports whether the job was claimed
+func (q *Queue) RunJob(ctx context.Context, id int64) (bool, error) {
+	jobs, err := q.claim(ctx, 1, id)
+	if err != nil || len(jobs) == 0 {
+		return false, err
+	}
+
+	q.running.Add(1)
+	defer q.running.Done()
+
+	q.work(ctx, &jobs[0])
+	return true, nil
+}
+
+// claim marks up to limit available jobs of the registered kinds as running and returns them.
+// If id is set only that job is claimed
+func (q *Queue) claim(ctx context.Context, limit int, id int64) ([]domain.Job, error) {
+	if limit <= 0 || len(q.workers) == 0 {
+		return nil, nil
+	}
+
+	kinds := make([]string, 0, len(q.workers))
+	for kind := range q.workers {
+		kinds = append(kinds, kind)
+	}
+	slices.Sort(kinds)
+
+	rows, err := q.db.Conn(ctx).Query(ctx, `
+		UPDATE jobs
+		SET state = 'running', attempt = attempt + 1, attempted_at = CURRENT_TIMESTAMP,
+			attempted_by = $1, heartbeat_at = CURRENT_TIMESTAMP
+		WHERE id IN (
+			SELECT id FROM jobs
+			WHERE state = 'available' AND kind = ANY($2)
+				AND (id = $4 OR $4 = 0 AND scheduled_at <= CURRENT_TIMESTAMP)
+			ORDER BY scheduled_at, id
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING `+queueJobColumns, q.name, kinds, limit, id)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.Job, error) {
+		job := domain.Job{State: domain.JobRunning}
+		err := row.Scan(&job.ID, &job.Kind, &job.Args, &job.Attempt, &job.MaxAttempts, &job.Progress, &job.CreatedAt)
+		return job, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	q.mu.Lock()
+	for _, job := range jobs {
+		q.active[job.ID] = true
+	}
+	q.mu.Unlock()
+
+	return jobs, nil
+}
+
+// work runs job and records its outcome
+func (q *Queue) work(ctx context.Context, job *domain.Job) {
+	log := logger.FromCtx(ctx).With(zap.Int64("job_id", job.ID), zap.String("kind", job.Kind), zap.Int("attempt", job.Attempt))
+	ctx = logger.WithCtx(ctx, log)
+
+	defer func() {
+		q.mu.Lock()
+		delete(q.active, job.ID)
+		q.mu.Unlock()
+	}()
+
+	err := q.runWorker(ctx, job)
+
+	// the outcome is recorded even when the queue is stopping
+	recordCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
+	defer cancel()
+
+	var recordErr error
+	switch {
+	case err == nil:
+		_, recordErr = q.db.Conn(recordCtx).Exec(recordCtx, `
+			UPDATE jobs SET state = 'completed', finalized_at = CURRENT_TIMESTAMP, heartbeat_at = NULL
+			WHERE id = $1
+		`, job.ID)
+
+	case ctx.Err() != nil:
+		// stopped by a shutdown, which does not count as an attempt
+		log.Info("Job interrupted, it will run again")
+		_, recordErr = q.db.Conn(recordCtx).Exec(recordCtx, `
+			UPDATE jobs SET state = 'available', attempt = attempt - 1, scheduled_at = CURRENT_TIMESTAMP, heartbeat_at = NULL
+			WHERE id = $1
+		`, job.ID)
+
+	case job.Attempt >= job.MaxAttempts:
+		log.Error("Job failed its last attempt", zap.Error(err))
+		_, recordErr = q.db.Conn(recordCtx).Exec(recordCtx, `
+			UPDATE jobs SET state = 'failed', last_error = $2, finalized_at = CURRENT_TIMESTAMP, heartbeat_at = NULL
+			WHERE id = $1
+		`, job.ID, err.Error())
+
+	default:
+		backoff := jobBackoff(job.Attempt)
+		log.Warn("Job failed, it will be retried", zap.Duration("backoff", backoff), zap.Error(err))
+		_, recordErr = q.db.Conn(recordCtx).Exec(recordCtx, `
+			UPDATE jobs SET state = 'available', last_error = $2, scheduled_at = CURRENT_TIMESTAMP + make_interval(secs => $3), heartbeat_at = NULL
+			WHERE id = $1
+		`, job.ID, err.Error(), backoff.Seconds())
+	}
+
+	if recordErr != nil {
+		log.Error("Error recording the outcome of the job, it will be rescued", zap.Error(recordErr))
+	}
+}
+
+// runWorker calls the worker of job, turning a panic into an error
+func (q *Queue) runWorker(ctx context.Context, job *domain.Job) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panicked: %v", r)
+		}
+	}()
+
+	worker, ok := q.workers[job.Kind]
+	if !ok {
+		return errors.New("no worker is registered for the job kind")
+	}
+
+	return worker(ctx, job)
+}
+
+// heartbeat marks the jobs this instance runs as alive
+func (q *Queue) heartbeat(ctx context.Context) error {
+	q.mu.Lock()
+	ids := make([]int64, 0, len(q.active))
+	for id := range q.active {
+		ids = append(ids, id)
+	}
+	q.mu.Unlock()
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	_, err := q.db.Conn(ctx).Exec(ctx, `
+		UPDATE jobs SET heartbeat_at = CURRENT_TIMESTAMP WHERE id = ANY($1) AND state = 'running'
+	`, ids)
+	return err
+}
+
+// rescue makes the running jobs whose heartbeat stopped available again, or fails them
+// when they ran out of attempts
+func (q *Queue) rescue(ctx context.Context) error {
+	tag, err := q.db.Conn(ctx).Exec(ctx, `
+		UPDATE jobs
+		SET state = CASE WHEN attempt >= max_attempts THEN 'failed' ELSE 'available' END,
+			finalized_at = CASE WHEN attempt >= max_attempts THEN CURRENT_TIMESTAMP END,
+			scheduled_at = CURRENT_TIMESTAMP,
+			last_error = 'the worker running the job was lost',
+			heartbeat_at = NULL
+		WHERE state = 'running' AND heartbeat_at < CURRENT_TIMESTAMP - make_interval(secs => $1)
+	`, q.rescueAfter.Seconds())
+	if err != nil {
+		return err
+	}
+
+	if n := tag.RowsAffected(); n > 0 {
+		logger.FromCtx(ctx).Warn("Rescued the jobs of lost workers", zap.Int64("jobs", n))
+	}
+	return nil
+}
+
+// jobBackoff is the wait before retrying a job that failed attempt, growing with the attempts
+func jobBackoff(attempt int) time.Duration {
+	return min(time.Duration(attempt*attempt)*10*time.Second, maxJobBackoff)
+}
@@ -0,0 +1,179 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"leeta/internal/adapter/config"
+	"leeta/internal/adapter/logger"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+const (
+	// replicaCheckInterval is how often replicas are pinged to update their health
+	replicaCheckInterval = 10 * time.Second
+	// replicaCheckTimeout bounds a single replica health check
+	replicaCheckTimeout = 2 * time.Second
+)
+
+// replica is a read replica connection pool and its last known health
+type replica struct {
+	*pgxpool.Pool
+	healthy atomic.Bool
+}
+
+// connectReplicas creates a pool per replica connection string. Replicas that
+// cannot be reached yet are kept as unhealthy and picked up by the health checks.
+func connectReplicas(ctx context.Context, config *config.DatabaseConfiguration) ([]*replica, error) {
+	replicas := make([]*replica, 0, len(config.Replicas))
+
+	closeAll := func() {
+		for _, r := range replicas {
+			r.Close()
+		}
+	}
+
+	for _, url := range config.Replicas {
+		cfg, err := poolConfig(url, config)
+		if err != nil {
+			closeAll()
+			return nil, err
+		}
+
+		pool, err := pgxpool.NewWithConfig(ctx, cfg)
+		if err != nil {
+			closeAll()
+			return nil, err
+		}
+
+		r := &replica{Pool: pool}
+		r.healthy.Store(pool.Ping(ctx) == nil)
+		replicas = append(replicas, r)
+	}
+
+	return replicas, nil
+}
+
+// checkReplicas pings the replicas until ctx is done, logging health changes
+func (db *DB) checkReplicas(ctx context.Context) {
+	ticker := time.NewTicker(replicaCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		for i, r := range db.replicas {
+			pingCtx, cancel := context.WithTimeout(ctx, replicaCheckTimeout)
+			err := r.Ping(pingCtx)
+			cancel()
+
+			healthy := err == nil
+			if r.healthy.Swap(healthy) != healthy {
+				if healthy {
+					logger.FromCtx(ctx).Info("Database replica is back online", zap.Int("replica", i))
+				} else {
+					logger.FromCtx(ctx).Warn("Database replica is unreachable", zap.Int("replica", i), zap.Error(err))
+				}
+			}
+		}
+	}
+}
+
+// reader returns the next healthy replica in round robin order, or nil if there is none
+func (db *DB) reader() *replica {
+	n := len(db.replicas)
+	if n == 0 {
+		return nil
+	}
+
+	start := int(db.next.Add(1))
+	for i := 0; i < n; i++ {
+		r := db.replicas[(start+i)%n]
+		if r.healthy.Load() {
+			return r
+		}
+	}
+
+	return nil
+}
+
+// ReadQuery runs a read-only query on a replica, falling back to the primary
+// when there is no healthy replica or the replica cannot be reached, and retrying
+// on transient errors. Queries within a transaction always run on the transaction
+func (db *DB) ReadQuery(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	if tx, ok := ctx.Value(txCtxKey{}).(pgx.Tx); ok {
+		return tx.Query(ctx, sql, args...)
+	}
+
+	var rows pgx.Rows
+	err := db.retry(ctx, func() error {
+		var err error
+		rows, err = db.readQuery(ctx, sql, args...)
+		return err
+	})
+	return rows, err
+}
+
+// readQuery runs a query on a healthy replica, or on the primary if there is none or the caller wrote recently
+func (db *DB) readQuery(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	if r := db.reader(); r != nil && !db.writes.recent(ctx) {
+		rows, err := r.Query(ctx, sql, args...)
+		if err == nil || !isConnectionError(ctx, err) {
+			return rows, err
+		}
+
+		r.healthy.Store(false)
+		logger.FromCtx(ctx).Warn("Falling back to primary database", zap.Error(err))
+	}
+
+	return db.Pool.Query(ctx, sql, args...)
+}
+
+// ReadQueryRow is like ReadQuery but returns a single row, as pgx.QueryRow does
+func (db *DB) ReadQueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	rows, err := db.ReadQuery(ctx, sql, args...)
+	return &readRow{rows, err}
+}
+
+// isConnectionError reports whether err was caused by the connection rather than the query
+func isConnectionError(ctx context.Context, err error) bool {
+	var pgErr *pgconn.PgError
+	return ctx.Err() == nil && !errors.As(err, &pgErr)
+}
+
+// readRow implements pgx.Row over the rows returned by ReadQuery
+type readRow struct {
+	rows pgx.Rows
+	err  error
+}
+
+func (r *readRow) Scan(dest ...any) error {
+	if r.err != nil {
+		return r.err
+	}
+	defer r.rows.Close()
+
+	if !r.rows.Next() {
+		if err := r.rows.Err(); err != nil {
+			return err
+		}
+		return pgx.ErrNoRows
+	}
+
+	if err := r.rows.Scan(dest...); err != nil {
+		return err
+	}
+
+	r.rows.Close()
+	return r.rows.Err()
+}
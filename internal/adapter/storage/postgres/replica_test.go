@@ -0,0 +1,108 @@
+package postgres
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"leeta/internal/adapter/config"
+	"leeta/internal/core/auth"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// unreachablePool returns a pool to a port nothing listens on, it only fails once a query needs a connection
+func unreachablePool(t *testing.T) *pgxpool.Pool {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := listener.Addr().(*net.TCPAddr)
+	require.NoError(t, listener.Close())
+
+	cfg, err := pgxpool.ParseConfig("postgresql://postgres@127.0.0.1:1/leeta?sslmode=disable&connect_timeout=1")
+	require.NoError(t, err)
+	cfg.ConnConfig.Port = uint16(addr.Port)
+
+	pool, err := pgxpool.NewWithConfig(context.Background(), cfg)
+	require.NoError(t, err)
+	t.Cleanup(pool.Close)
+	return pool
+}
+
+// newReplica returns a replica on pool with its health set
+func newReplica(pool *pgxpool.Pool, healthy bool) *replica {
+	r := &replica{Pool: pool}
+	r.healthy.Store(healthy)
+	return r
+}
+
+func TestDB_Reader(t *testing.T) {
+	t.Run("Without replicas", func(t *testing.T) {
+		db := &DB{}
+		assert.Nil(t, db.reader())
+	})
+
+	t.Run("Skips the unhealthy replicas", func(t *testing.T) {
+		first, down, second := newReplica(nil, true), newReplica(nil, false), newReplica(nil, true)
+		db := &DB{replicas: []*replica{first, down, second}}
+
+		picked := map[*replica]int{}
+		for range 6 {
+			picked[db.reader()]++
+		}
+		assert.Zero(t, picked[down])
+		assert.Positive(t, picked[first])
+		assert.Positive(t, picked[second])
+	})
+
+	t.Run("None healthy", func(t *testing.T) {
+		db := &DB{replicas: []*replica{newReplica(nil, false), newReplica(nil, false)}}
+		assert.Nil(t, db.reader())
+	})
+}
+
+func TestDB_ReadQuery(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Unreachable replicas fall back to the primary", func(t *testing.T) {
+		r := newReplica(unreachablePool(t), true)
+		db := &DB{Pool: unreachablePool(t), replicas: []*replica{r}, retryPolicy: retryPolicy{attempts: 1}}
+
+		// the primary is unreachable too, so the query fails once both were tried
+		_, err := db.ReadQuery(ctx, "SELECT 1")
+		assert.Error(t, err)
+		assert.False(t, r.healthy.Load(), "the replica that failed is marked unhealthy")
+		assert.Nil(t, db.reader())
+	})
+
+	t.Run("The reads of a caller that wrote go to the primary", func(t *testing.T) {
+		r := newReplica(unreachablePool(t), true)
+		db := &DB{
+			Pool:        unreachablePool(t),
+			replicas:    []*replica{r},
+			writes:      newRecentWrites(time.Minute),
+			retryPolicy: retryPolicy{attempts: 1},
+		}
+		ada := auth.WithPrincipal(ctx, &auth.Principal{User: "ada", Tenant: "acme"})
+
+		db.writes.record(ada)
+		_, err := db.ReadQuery(ada, "SELECT 1")
+		assert.Error(t, err)
+		assert.True(t, r.healthy.Load(), "the replica is not tried")
+
+		// the other callers still read the replica
+		_, err = db.ReadQuery(ctx, "SELECT 1")
+		assert.Error(t, err)
+		assert.False(t, r.healthy.Load())
+	})
+}
+
+func TestConnectReplicas(t *testing.T) {
+	replicas, err := connectReplicas(context.Background(), &config.DatabaseConfiguration{
+		Replicas: []string{"postgresql://postgres@127.0.0.1:1/leeta?sslmode=disable&connect_timeout=1", "not a url"},
+	})
+	assert.Error(t, err)
+	assert.Nil(t, replicas)
+}
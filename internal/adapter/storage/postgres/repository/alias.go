@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"context"
+
+	"leeta/internal/adapter/storage/postgres"
+	"leeta/internal/core/domain"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+// aliasColumns are the columns selected whenever a full location alias row is read
+var aliasColumns = []string{"id", "location_id", "name", "slug", "created_at"}
+
+// aliasMatches matches a location alias by its name, ignoring case, or by its slug
+func aliasMatches(db *postgres.DB, alias string) sq.Sqlizer {
+	return sq.Or{sq.Expr("LOWER(name) = LOWER(?)", alias), sq.Eq{"slug": db.Slug(alias)}}
+}
+
+// locationMatches matches a location by its name, ignoring case, its slug or one of its aliases
+func locationMatches(db *postgres.DB, name string) sq.Sqlizer {
+	return sq.Or{
+		nameMatches(db, name),
+		sq.Expr("id IN (SELECT location_id FROM location_aliases WHERE LOWER(name) = LOWER(?) OR slug = ?)", name, db.Slug(name)),
+	}
+}
+
+// byNameFirst orders the locations matched by locationMatches so that a location named name comes
+// before a location with its slug, which comes before a location with such an alias. The slug of a
+// name may have been given to another location when the collisions of the slugs are resolved
+func byNameFirst(db *postgres.DB, name string) sq.Sqlizer {
+	return sq.Expr("LOWER(name) = LOWER(?) DESC, slug = ? DESC", name, db.Slug(name))
+}
+
+// locationID gets the id of the location specified by its name, slug or one of its aliases
+func (ur *LocationRepository) locationID(ctx context.Context, name string) (string, domain.CError) {
+	sql, args, err := ur.db.QueryBuilder.Select("id").
+		From("locations").
+		Where(locationMatches(ur.db, name)).
+		OrderByClause(byNameFirst(ur.db, name)).
+		Limit(1).
+		ToSql()
+	if err != nil {
+		return "", ur.internalError(err)
+	}
+
+	var id string
+	if err := ur.db.ReadQueryRow(ctx, sql, args...).Scan(&id); err != nil {
+		if err == pgx.ErrNoRows {
+			return "", domain.ErrDataNotFound
+		}
+		return "", ur.internalError(err)
+	}
+
+	return id, nil
+}
+
+// CreateLocationAlias adds an alias to a location, it conflicts with the names and slugs of the
+// locations as well as with the other aliases
+func (ur *LocationRepository) CreateLocationAlias(ctx context.Context, name string, alias *domain.LocationAlias) (*domain.LocationAlias, domain.CError) {
+	id, cerr := ur.locationID(ctx, name)
+	if cerr != nil {
+		return nil, cerr
+	}
+
+	// an alias naming another location would be shadowed by it, lookups by name win
+	var taken bool
+	err := ur.db.Conn(ctx).QueryRow(ctx,
+		"SELECT EXISTS (SELECT 1 FROM locations WHERE LOWER(name) = LOWER($1) OR slug = $2)",
+		alias.Name, ur.db.Slug(alias.Name),
+	).Scan(&taken)
+	if err != nil {
+		return nil, ur.internalError(err)
+	}
+	if taken {
+		return nil, domain.ErrConflictingData
+	}
+
+	sql, args, err := ur.db.QueryBuilder.Insert("location_aliases").
+		Columns("location_id", "name", "slug").
+		Values(id, alias.Name, ur.db.Slug(alias.Name)).
+		Suffix("RETURNING id, location_id, name, slug, created_at").
+		ToSql()
+	if err != nil {
+		return nil, ur.internalError(err)
+	}
+
+	var created domain.LocationAlias
+	err = ur.db.Conn(ctx).QueryRow(ctx, sql, args...).
+		Scan(&created.ID, &created.LocationID, &created.Name, &created.Slug, &created.CreatedAt)
+	if err != nil {
+		// 23505 is the error code for a unique conflict error
+		if ur.db.ErrorCode(err) == "23505" {
+			return nil, domain.ErrConflictingData
+		}
+		return nil, ur.internalError(err)
+	}
+
+	return &created, nil
+}
+
+// ListLocationAliases lists the aliases of a location by name
+func (ur *LocationRepository) ListLocationAliases(ctx context.Context, name string) ([]domain.LocationAlias, domain.CError) {
+	id, cerr := ur.locationID(ctx, name)
+	if cerr != nil {
+		return nil, cerr
+	}
+
+	sql, args, err := ur.db.QueryBuilder.Select(aliasColumns...).
+		From("location_aliases").
+		Where(sq.Eq{"location_id": id}).
+		OrderBy("name").
+		ToSql()
+	if err != nil {
+		return nil, ur.internalError(err)
+	}
+
+	rows, err := ur.db.ReadQuery(ctx, sql, args...)
+	if err != nil {
+		return nil, ur.internalError(err)
+	}
+	defer rows.Close()
+
+	aliases := []domain.LocationAlias{}
+	for rows.Next() {
+		var alias domain.LocationAlias
+		if err := rows.Scan(&alias.ID, &alias.LocationID, &alias.Name, &alias.Slug, &alias.CreatedAt); err != nil {
+			return nil, ur.internalError(err)
+		}
+		aliases = append(aliases, alias)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, ur.internalError(err)
+	}
+
+	return aliases, nil
+}
+
+// DeleteLocationAlias removes an alias from a location by name
+func (ur *LocationRepository) DeleteLocationAlias(ctx context.Context, name, alias string) domain.CError {
+	id, cerr := ur.locationID(ctx, name)
+	if cerr != nil {
+		return cerr
+	}
+
+	sql, args, err := ur.db.QueryBuilder.Delete("location_aliases").
+		Where(sq.Eq{"location_id": id}).
+		Where(aliasMatches(ur.db, alias)).
+		ToSql()
+	if err != nil {
+		return ur.internalError(err)
+	}
+
+	tag, err := ur.db.Conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		return ur.internalError(err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrDataNotFound
+	}
+
+	return nil
+}
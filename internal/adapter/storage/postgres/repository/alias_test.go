@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"leeta/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocationRepository_LocationAliases(t *testing.T) {
+	repo, _ := newTestLocationRepository(t)
+	ctx := context.Background()
+
+	for _, l := range []domain.Location{
+		{Name: "John F. Kennedy International Airport", Latitude: 40.6413, Longitude: -73.7781},
+		{Name: "LaGuardia Airport", Latitude: 40.7769, Longitude: -73.8740},
+	} {
+		_, cerr := repo.CreateLocation(ctx, &l)
+		require.Nil(t, cerr)
+	}
+
+	alias, cerr := repo.CreateLocationAlias(ctx, "john-f-kennedy-international-airport", &domain.LocationAlias{Name: "JFK"})
+	require.Nil(t, cerr)
+	assert.Equal(t, "jfk", alias.Slug)
+
+	_, cerr = repo.CreateLocationAlias(ctx, "jfk", &domain.LocationAlias{Name: "Idlewild"})
+	require.Nil(t, cerr, "an alias resolves the location too")
+
+	t.Run("Resolved by alias with the canonical name", func(t *testing.T) {
+		location, cerr := repo.GetLocationByName(ctx, "jfk")
+		require.Nil(t, cerr)
+		assert.Equal(t, "John F. Kennedy International Airport", location.Name)
+	})
+
+	t.Run("Searchable by alias", func(t *testing.T) {
+		locations, cerr := repo.ListLocations(ctx, &domain.ListLocationsRequest{Query: `alias~"idle"`})
+		require.Nil(t, cerr)
+		require.Len(t, locations, 1)
+		assert.Equal(t, "John F. Kennedy International Airport", locations[0].Name)
+	})
+
+	t.Run("Conflicts", func(t *testing.T) {
+		_, cerr := repo.CreateLocationAlias(ctx, "LaGuardia Airport", &domain.LocationAlias{Name: "jfk"})
+		require.NotNil(t, cerr)
+		assert.Equal(t, domain.ErrConflictingData.Code(), cerr.Code(), "taken by another alias")
+
+		_, cerr = repo.CreateLocationAlias(ctx, "jfk", &domain.LocationAlias{Name: "laguardia airport"})
+		require.NotNil(t, cerr)
+		assert.Equal(t, domain.ErrConflictingData.Code(), cerr.Code(), "taken by a location name")
+	})
+
+	t.Run("Listed and deleted", func(t *testing.T) {
+		aliases, cerr := repo.ListLocationAliases(ctx, "John F. Kennedy International Airport")
+		require.Nil(t, cerr)
+		require.Len(t, aliases, 2)
+		assert.Equal(t, "Idlewild", aliases[0].Name)
+		assert.Equal(t, "JFK", aliases[1].Name)
+
+		require.Nil(t, repo.DeleteLocationAlias(ctx, "jfk", "idlewild"))
+
+		cerr = repo.DeleteLocationAlias(ctx, "jfk", "idlewild")
+		require.NotNil(t, cerr)
+		assert.Equal(t, domain.ErrDataNotFound.Code(), cerr.Code())
+
+		_, cerr = repo.GetLocationByName(ctx, "idlewild")
+		require.NotNil(t, cerr)
+		assert.Equal(t, domain.ErrDataNotFound.Code(), cerr.Code())
+	})
+}
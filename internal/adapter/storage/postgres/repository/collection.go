@@ -0,0 +1,236 @@
+package repository
+
+import (
+	"context"
+
+	"leeta/internal/adapter/storage/postgres"
+	"leeta/internal/core/domain"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+// collectionMatches matches a collection by its name, ignoring case, or by its slug
+func collectionMatches(db *postgres.DB, name string) sq.Sqlizer {
+	return sq.Or{sq.Expr("LOWER(name) = LOWER(?)", name), sq.Eq{"slug": db.Slug(name)}}
+}
+
+// inCollection matches the locations of the collection with the id
+func inCollection(id string) sq.Sqlizer {
+	return sq.Expr("locations.id IN (SELECT location_id FROM collection_locations WHERE collection_id = ?)", id)
+}
+
+// collectionID gets the id of the collection specified by its name or slug
+func collectionID(ctx context.Context, db *postgres.DB, name string) (string, error) {
+	sql, args, err := db.QueryBuilder.Select("id").
+		From("collections").
+		Where(collectionMatches(db, name)).
+		ToSql()
+	if err != nil {
+		return "", err
+	}
+
+	var id string
+	if err := db.ReadQueryRow(ctx, sql, args...).Scan(&id); err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+// scopeToCollection restricts the query to the locations of the collection specified by its name or
+// slug, it is left as is without a name
+func (ur *LocationRepository) scopeToCollection(ctx context.Context, query sq.SelectBuilder, name string) (sq.SelectBuilder, domain.CError) {
+	if name == "" {
+		return query, nil
+	}
+
+	id, err := collectionID(ctx, ur.db, name)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return query, domain.ErrDataNotFound
+		}
+		return query, ur.internalError(err)
+	}
+
+	return query.Where(inCollection(id)), nil
+}
+
+/**
+ * CollectionRepository implements port.CollectionRepository interface
+ * and provides an access to the postgres database
+ */
+type CollectionRepository struct {
+	db *postgres.DB
+}
+
+// NewCollectionRepository creates a new collection repository instance
+func NewCollectionRepository(db *postgres.DB) *CollectionRepository {
+	return &CollectionRepository{
+		db,
+	}
+}
+
+func (cr *CollectionRepository) CreateCollection(ctx context.Context, collection *domain.Collection) (*domain.Collection, domain.CError) {
+	sql, args, err := cr.db.QueryBuilder.Insert("collections").
+		Columns("name", "slug").
+		Values(collection.Name, cr.db.Slug(collection.Name)).
+		Suffix("RETURNING id, name, slug, created_at").
+		ToSql()
+	if err != nil {
+		return nil, cr.internalError(err)
+	}
+
+	var created domain.Collection
+	err = cr.db.Conn(ctx).QueryRow(ctx, sql, args...).
+		Scan(&created.ID, &created.Name, &created.Slug, &created.CreatedAt)
+	if err != nil {
+		// 23505 is the error code for a unique conflict error
+		if cr.db.ErrorCode(err) == "23505" {
+			return nil, domain.ErrConflictingData
+		}
+		return nil, cr.internalError(err)
+	}
+
+	return &created, nil
+}
+
+func (cr *CollectionRepository) ListCollections(ctx context.Context) ([]domain.Collection, domain.CError) {
+	sql, args, err := cr.db.QueryBuilder.Select("id", "name", "slug", "created_at").
+		From("collections").
+		OrderBy("name").
+		ToSql()
+	if err != nil {
+		return nil, cr.internalError(err)
+	}
+
+	rows, err := cr.db.ReadQuery(ctx, sql, args...)
+	if err != nil {
+		return nil, cr.internalError(err)
+	}
+	defer rows.Close()
+
+	collections := []domain.Collection{}
+	for rows.Next() {
+		var collection domain.Collection
+		if err := rows.Scan(&collection.ID, &collection.Name, &collection.Slug, &collection.CreatedAt); err != nil {
+			return nil, cr.internalError(err)
+		}
+		collections = append(collections, collection)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, cr.internalError(err)
+	}
+
+	return collections, nil
+}
+
+func (cr *CollectionRepository) DeleteCollection(ctx context.Context, name string) domain.CError {
+	sql, args, err := cr.db.QueryBuilder.Delete("collections").
+		Where(collectionMatches(cr.db, name)).
+		ToSql()
+	if err != nil {
+		return cr.internalError(err)
+	}
+
+	tag, err := cr.db.Conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		return cr.internalError(err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrDataNotFound
+	}
+
+	return nil
+}
+
+func (cr *CollectionRepository) AddCollectionLocation(ctx context.Context, name, location string) domain.CError {
+	id, locationID, cerr := cr.membership(ctx, name, location)
+	if cerr != nil {
+		return cerr
+	}
+
+	sql, args, err := cr.db.QueryBuilder.Insert("collection_locations").
+		Columns("collection_id", "location_id").
+		Values(id, locationID).
+		Suffix("ON CONFLICT DO NOTHING").
+		ToSql()
+	if err != nil {
+		return cr.internalError(err)
+	}
+
+	if _, err := cr.db.Conn(ctx).Exec(ctx, sql, args...); err != nil {
+		return cr.internalError(err)
+	}
+
+	return nil
+}
+
+func (cr *CollectionRepository) RemoveCollectionLocation(ctx context.Context, name, location string) domain.CError {
+	id, locationID, cerr := cr.membership(ctx, name, location)
+	if cerr != nil {
+		return cerr
+	}
+
+	sql, args, err := cr.db.QueryBuilder.Delete("collection_locations").
+		Where(sq.Eq{"collection_id": id, "location_id": locationID}).
+		ToSql()
+	if err != nil {
+		return cr.internalError(err)
+	}
+
+	tag, err := cr.db.Conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		return cr.internalError(err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrDataNotFound
+	}
+
+	return nil
+}
+
+// membership gets the ids of the collection and of the public location specified by their names
+func (cr *CollectionRepository) membership(ctx context.Context, name, location string) (string, string, domain.CError) {
+	id, err := collectionID(ctx, cr.db, name)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return "", "", domain.ErrDataNotFound
+		}
+		return "", "", cr.internalError(err)
+	}
+
+	sql, args, err := cr.db.QueryBuilder.Select("id").
+		From("locations").
+		Where(locationMatches(cr.db, location)).
+		Where(approved).
+		OrderByClause(byNameFirst(cr.db, location)).
+		Limit(1).
+		ToSql()
+	if err != nil {
+		return "", "", cr.internalError(err)
+	}
+
+	var locationID string
+	if err := cr.db.ReadQueryRow(ctx, sql, args...).Scan(&locationID); err != nil {
+		if err == pgx.ErrNoRows {
+			return "", "", domain.ErrDataNotFound
+		}
+		return "", "", cr.internalError(err)
+	}
+
+	return id, locationID, nil
+}
+
+// internalError converts a database error into a CError, telling timeouts and constraint violations apart.
+// The other errors are internal, with the database error as their cause
+func (cr *CollectionRepository) internalError(err error) domain.CError {
+	if cr.db.IsTimeout(err) {
+		return domain.ErrTimeout
+	}
+	if cerr := cr.db.ViolationError(err); cerr != nil {
+		return cerr
+	}
+	return domain.WrapCError(domain.ErrInternal, err, domain.ErrorContext{Entity: "collection"})
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"leeta/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollectionRepository_CollectionLocations(t *testing.T) {
+	locations, db := newTestLocationRepository(t)
+	repo := NewCollectionRepository(db)
+	ctx := context.Background()
+
+	_, err := db.Exec(ctx, "DELETE FROM collections")
+	require.NoError(t, err, "Failed to cleanup test data")
+
+	for _, l := range []domain.Location{
+		{Name: "Lagos Hub", Latitude: 6.5244, Longitude: 3.3792},
+		{Name: "Ikeja Hub", Latitude: 6.6018, Longitude: 3.3515},
+		{Name: "Lekki Beach", Latitude: 6.4281, Longitude: 3.4219},
+	} {
+		_, cerr := locations.CreateLocation(ctx, &l)
+		require.Nil(t, cerr)
+	}
+
+	created, cerr := repo.CreateCollection(ctx, &domain.Collection{Name: "My delivery hubs"})
+	require.Nil(t, cerr)
+	assert.Equal(t, "my-delivery-hubs", created.Slug)
+
+	_, cerr = repo.CreateCollection(ctx, &domain.Collection{Name: "my delivery HUBS"})
+	require.NotNil(t, cerr)
+	assert.Equal(t, domain.ErrConflictingData.Code(), cerr.Code())
+
+	require.Nil(t, repo.AddCollectionLocation(ctx, "My delivery hubs", "Lagos Hub"))
+	require.Nil(t, repo.AddCollectionLocation(ctx, "my-delivery-hubs", "ikeja-hub"))
+	// adding a location twice is not an error
+	require.Nil(t, repo.AddCollectionLocation(ctx, "my-delivery-hubs", "ikeja-hub"))
+
+	t.Run("Unknown collection or location", func(t *testing.T) {
+		cerr := repo.AddCollectionLocation(ctx, "depots", "Lagos Hub")
+		require.NotNil(t, cerr)
+		assert.Equal(t, domain.ErrDataNotFound.Code(), cerr.Code())
+
+		cerr = repo.AddCollectionLocation(ctx, "my-delivery-hubs", "Abuja Hub")
+		require.NotNil(t, cerr)
+		assert.Equal(t, domain.ErrDataNotFound.Code(), cerr.Code())
+	})
+
+	t.Run("List restricted to the collection", func(t *testing.T) {
+		listed, cerr := locations.ListLocations(ctx, &domain.ListLocationsRequest{Collection: "My Delivery Hubs"})
+		require.Nil(t, cerr)
+		require.Len(t, listed, 2)
+
+		_, cerr = locations.ListLocations(ctx, &domain.ListLocationsRequest{Collection: "depots"})
+		require.NotNil(t, cerr)
+		assert.Equal(t, domain.ErrDataNotFound.Code(), cerr.Code())
+	})
+
+	t.Run("Nearest restricted to the collection", func(t *testing.T) {
+		nearest, cerr := locations.FindNearestLocations(ctx, &domain.NearestLocationsRequest{
+			Latitude: 6.4281, Longitude: 3.4219, Limit: 10, Collection: "my-delivery-hubs",
+		})
+		require.Nil(t, cerr)
+		require.Len(t, nearest, 2)
+		assert.Equal(t, "Lagos Hub", nearest[0].Name)
+	})
+
+	t.Run("Removed locations leave the collection", func(t *testing.T) {
+		require.Nil(t, repo.RemoveCollectionLocation(ctx, "my-delivery-hubs", "Lagos Hub"))
+
+		cerr := repo.RemoveCollectionLocation(ctx, "my-delivery-hubs", "Lagos Hub")
+		require.NotNil(t, cerr)
+		assert.Equal(t, domain.ErrDataNotFound.Code(), cerr.Code())
+
+		listed, cerr := locations.ListLocations(ctx, &domain.ListLocationsRequest{Collection: "my-delivery-hubs"})
+		require.Nil(t, cerr)
+		require.Len(t, listed, 1)
+		assert.Equal(t, "Ikeja Hub", listed[0].Name)
+	})
+
+	t.Run("Deleted collections keep their locations", func(t *testing.T) {
+		require.Nil(t, repo.DeleteCollection(ctx, "My delivery hubs"))
+
+		collections, cerr := repo.ListCollections(ctx)
+		require.Nil(t, cerr)
+		assert.Empty(t, collections)
+
+		listed, cerr := locations.ListLocations(ctx, &domain.ListLocationsRequest{})
+		require.Nil(t, cerr)
+		assert.Len(t, listed, 3)
+	})
+}
@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"leeta/internal/adapter/storage/postgres"
+	"leeta/internal/core/domain"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// deviceColumns are the columns selected whenever a full device row is read
+const deviceColumns = "id, name, latitude, longitude, registered_at, last_seen_at"
+
+// scanDevice scans a row selected with deviceColumns into a device
+func scanDevice(row pgx.Row, device *domain.Device) error {
+	return row.Scan(
+		&device.ID,
+		&device.Name,
+		&device.Latitude,
+		&device.Longitude,
+		&device.RegisteredAt,
+		&device.LastSeenAt,
+	)
+}
+
+/**
+ * DeviceRepository implements port.DeviceRepository interface
+ * and provides an access to the postgres database
+ */
+type DeviceRepository struct {
+	db *postgres.DB
+}
+
+// NewDeviceRepository creates a new device repository instance
+func NewDeviceRepository(db *postgres.DB) *DeviceRepository {
+	return &DeviceRepository{
+		db,
+	}
+}
+
+func (dr *DeviceRepository) RegisterDevice(ctx context.Context, device *domain.Device) (*domain.Device, domain.CError) {
+	query := `
+		INSERT INTO devices (id, name)
+		VALUES ($1, $2)
+		ON CONFLICT (id) DO UPDATE SET name = EXCLUDED.name
+		RETURNING ` + deviceColumns
+
+	var registered domain.Device
+	if err := scanDevice(dr.db.Conn(ctx).QueryRow(ctx, query, device.ID, device.Name), &registered); err != nil {
+		return nil, dr.internalError(err)
+	}
+
+	return &registered, nil
+}
+
+func (dr *DeviceRepository) RecordHeartbeat(ctx context.Context, id string, latitude, longitude *float64) (*domain.Device, domain.CError) {
+	query := `
+		UPDATE devices
+		SET last_seen_at = CURRENT_TIMESTAMP,
+			latitude = COALESCE($2, latitude),
+			longitude = COALESCE($3, longitude)
+		WHERE id = $1
+		RETURNING ` + deviceColumns
+
+	var device domain.Device
+	if err := scanDevice(dr.db.Conn(ctx).QueryRow(ctx, query, id, latitude, longitude), &device); err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrDataNotFound
+		}
+		return nil, dr.internalError(err)
+	}
+
+	return &device, nil
+}
+
+func (dr *DeviceRepository) ListStaleDevices(ctx context.Context, since time.Time) ([]domain.Device, domain.CError) {
+	query := `
+		SELECT ` + deviceColumns + `
+		FROM devices
+		WHERE last_seen_at < $1
+		ORDER BY last_seen_at
+	`
+
+	rows, err := dr.db.ReadQuery(ctx, query, since)
+	if err != nil {
+		return nil, dr.internalError(err)
+	}
+	defer rows.Close()
+
+	devices := []domain.Device{}
+	for rows.Next() {
+		var device domain.Device
+		if err := scanDevice(rows, &device); err != nil {
+			return nil, dr.internalError(err)
+		}
+		devices = append(devices, device)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, dr.internalError(err)
+	}
+
+	return devices, nil
+}
+
+// internalError converts a database error into a CError, telling timeouts and constraint violations apart.
+// The other errors are internal, with the database error as their cause
+func (dr *DeviceRepository) internalError(err error) domain.CError {
+	if dr.db.IsTimeout(err) {
+		return domain.ErrTimeout
+	}
+	if cerr := dr.db.ViolationError(err); cerr != nil {
+		return cerr
+	}
+	return domain.WrapCError(domain.ErrInternal, err, domain.ErrorContext{Entity: "device"})
+}
@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"leeta/internal/adapter/storage/postgres"
+	"leeta/internal/core/domain"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+// defaultNearestEntitiesLimit is the number of entities returned when a search sets no limit
+const defaultNearestEntitiesLimit = 10
+
+// entityPositionColumns are the columns selected whenever a full entity position row is read
+var entityPositionColumns = []string{"entity_id", "kind", "latitude", "longitude", "reported_at"}
+
+// scanEntityPosition scans a row selected with entityPositionColumns into a position, extra
+// receives the columns selected after them
+func scanEntityPosition(row pgx.Row, position *domain.EntityPosition, extra ...any) error {
+	return row.Scan(append([]any{
+		&position.EntityID,
+		&position.Kind,
+		&position.Latitude,
+		&position.Longitude,
+		&position.ReportedAt,
+	}, extra...)...)
+}
+
+/**
+ * EntityRepository implements port.EntityRepository interface
+ * and provides an access to the postgres database
+ */
+type EntityRepository struct {
+	db *postgres.DB
+}
+
+// NewEntityRepository creates a new entity repository instance
+func NewEntityRepository(db *postgres.DB) *EntityRepository {
+	return &EntityRepository{
+		db,
+	}
+}
+
+func (er *EntityRepository) ReportPosition(ctx context.Context, position *domain.EntityPosition) (*domain.EntityPosition, *domain.EntityPosition, domain.CError) {
+	// only the most recent position of an entity is kept, so it is found with a single index scan.
+	// The statement sees the table as it was before the upsert, so previous is the replaced position
+	query := `
+		WITH previous AS (
+			SELECT latitude, longitude, reported_at FROM entity_positions WHERE entity_id = $1
+		)
+		INSERT INTO entity_positions (entity_id, kind, latitude, longitude, geo, reported_at)
+		VALUES ($1, $2, $3, $4, ST_MakePoint($4, $3)::geography, CURRENT_TIMESTAMP)
+		ON CONFLICT (entity_id) DO UPDATE SET
+			kind = EXCLUDED.kind,
+			latitude = EXCLUDED.latitude,
+			longitude = EXCLUDED.longitude,
+			geo = EXCLUDED.geo,
+			reported_at = EXCLUDED.reported_at
+		RETURNING entity_id, kind, latitude, longitude, reported_at,
+			(SELECT latitude FROM previous), (SELECT longitude FROM previous), (SELECT reported_at FROM previous)
+	`
+
+	var (
+		reported                            domain.EntityPosition
+		previousLatitude, previousLongitude *float64
+		previousReportedAt                  *time.Time
+	)
+	err := scanEntityPosition(
+		er.db.Conn(ctx).QueryRow(ctx, query, position.EntityID, position.Kind, position.Latitude, position.Longitude),
+		&reported, &previousLatitude, &previousLongitude, &previousReportedAt,
+	)
+	if err != nil {
+		return nil, nil, er.internalError(err)
+	}
+
+	if previousReportedAt == nil {
+		return &reported, nil, nil
+	}
+
+	return &reported, &domain.EntityPosition{
+		EntityID:   reported.EntityID,
+		Kind:       reported.Kind,
+		Latitude:   *previousLatitude,
+		Longitude:  *previousLongitude,
+		ReportedAt: *previousReportedAt,
+	}, nil
+}
+
+func (er *EntityRepository) FindNearestEntities(ctx context.Context, since time.Time, req *domain.NearestEntitiesRequest) ([]domain.NearestEntity, domain.CError) {
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultNearestEntitiesLimit
+	}
+
+	builder := er.db.QueryBuilder.Select(entityPositionColumns...).
+		Column(sq.Expr("ST_Distance(geo, ST_MakePoint(?, ?)::geography) AS distance_meters", req.Longitude, req.Latitude)).
+		From("entity_positions").
+		Where(sq.GtOrEq{"reported_at": since}).
+		OrderByClause("geo <-> ST_MakePoint(?, ?)::geography", req.Longitude, req.Latitude).
+		Limit(uint64(limit))
+
+	if req.MaxDistance > 0 {
+		builder = builder.Where(
+			sq.Expr("ST_DWithin(geo, ST_MakePoint(?, ?)::geography, ?)", req.Longitude, req.Latitude, req.MaxDistance),
+		)
+	}
+
+	if req.Kind != "" {
+		builder = builder.Where(sq.Eq{"kind": req.Kind})
+	}
+
+	sql, args, err := builder.ToSql()
+	if err != nil {
+		return nil, er.internalError(err)
+	}
+
+	// positions change every few seconds, a lagging replica would return where the entities were
+	rows, err := er.db.Conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, er.internalError(err)
+	}
+	defer rows.Close()
+
+	entities := []domain.NearestEntity{}
+	for rows.Next() {
+		var entity domain.NearestEntity
+		if err := scanEntityPosition(rows, &entity.EntityPosition, &entity.Distance); err != nil {
+			return nil, er.internalError(err)
+		}
+		entities = append(entities, entity)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, er.internalError(err)
+	}
+
+	return entities, nil
+}
+
+func (er *EntityRepository) DeletePositions(ctx context.Context, before time.Time) (int64, domain.CError) {
+	sql, args, err := er.db.QueryBuilder.Delete("entity_positions").
+		Where(sq.Lt{"reported_at": before}).
+		ToSql()
+	if err != nil {
+		return 0, er.internalError(err)
+	}
+
+	tag, err := er.db.Conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		return 0, er.internalError(err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+// internalError converts a database error into a CError, telling timeouts and constraint violations apart.
+// The other errors are internal, with the database error as their cause
+func (er *EntityRepository) internalError(err error) domain.CError {
+	if er.db.IsTimeout(err) {
+		return domain.ErrTimeout
+	}
+	if cerr := er.db.ViolationError(err); cerr != nil {
+		return cerr
+	}
+	return domain.WrapCError(domain.ErrInternal, err, domain.ErrorContext{Entity: "entity"})
+}
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"leeta/internal/adapter/storage/postgres/postgrestest"
+	"leeta/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEntityRepository_FindNearestEntities(t *testing.T) {
+	db := postgrestest.DB(t)
+	repo := NewEntityRepository(db)
+	ctx := context.Background()
+
+	_, err := db.Exec(ctx, "DELETE FROM entity_positions")
+	require.NoError(t, err, "Failed to cleanup test data")
+
+	for _, p := range []domain.EntityPosition{
+		{EntityID: "courier-1", Kind: "courier", Latitude: 6.5300, Longitude: 3.3800},
+		{EntityID: "courier-2", Kind: "courier", Latitude: 6.6018, Longitude: 3.3515},
+		{EntityID: "van-1", Kind: "vehicle", Latitude: 6.5250, Longitude: 3.3790},
+	} {
+		_, previous, cerr := repo.ReportPosition(ctx, &p)
+		require.Nil(t, cerr)
+		assert.Nil(t, previous)
+	}
+
+	// only the most recent position of an entity is kept
+	moved, previous, cerr := repo.ReportPosition(ctx, &domain.EntityPosition{EntityID: "courier-2", Kind: "courier", Latitude: 6.5260, Longitude: 3.3795})
+	require.Nil(t, cerr)
+	assert.Equal(t, 6.5260, moved.Latitude)
+	require.NotNil(t, previous)
+	assert.Equal(t, 6.6018, previous.Latitude)
+
+	ids := func(entities []domain.NearestEntity) []string {
+		var ids []string
+		for _, e := range entities {
+			ids = append(ids, e.EntityID)
+		}
+		return ids
+	}
+	since := time.Now().Add(-time.Minute)
+
+	t.Run("Closest first", func(t *testing.T) {
+		entities, cerr := repo.FindNearestEntities(ctx, since, &domain.NearestEntitiesRequest{Latitude: 6.5244, Longitude: 3.3792})
+		require.Nil(t, cerr)
+		assert.Equal(t, []string{"van-1", "courier-2", "courier-1"}, ids(entities))
+		assert.Less(t, entities[0].Distance, 100.0)
+	})
+
+	t.Run("Of a kind within a radius", func(t *testing.T) {
+		entities, cerr := repo.FindNearestEntities(ctx, since, &domain.NearestEntitiesRequest{
+			Latitude: 6.5244, Longitude: 3.3792, Kind: "courier", MaxDistance: 500,
+		})
+		require.Nil(t, cerr)
+		assert.Equal(t, []string{"courier-2"}, ids(entities))
+	})
+
+	t.Run("Stale positions are left out and expire", func(t *testing.T) {
+		_, err := db.Exec(ctx, "UPDATE entity_positions SET reported_at = reported_at - INTERVAL '1 hour' WHERE entity_id = 'van-1'")
+		require.NoError(t, err)
+
+		entities, cerr := repo.FindNearestEntities(ctx, since, &domain.NearestEntitiesRequest{Latitude: 6.5244, Longitude: 3.3792})
+		require.Nil(t, cerr)
+		assert.NotContains(t, ids(entities), "van-1")
+
+		deleted, cerr := repo.DeletePositions(ctx, since)
+		require.Nil(t, cerr)
+		assert.Equal(t, int64(1), deleted)
+	})
+}
@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"leeta/internal/adapter/storage/postgres"
+	"leeta/internal/core/domain"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+// defaultGeofenceLocationsLimit is the page size of the locations inside a geofence when none is set
+const defaultGeofenceLocationsLimit = 20
+
+// polygonWKT writes the points as a closed WKT polygon, longitude first
+func polygonWKT(points []domain.Point) string {
+	var b strings.Builder
+	b.WriteString("POLYGON((")
+	for _, p := range points {
+		fmt.Fprintf(&b, "%g %g, ", p.Longitude, p.Latitude)
+	}
+	fmt.Fprintf(&b, "%g %g))", points[0].Longitude, points[0].Latitude)
+
+	return b.String()
+}
+
+/**
+ * GeofenceRepository implements port.GeofenceRepository interface
+ * and provides an access to the postgres database
+ */
+type GeofenceRepository struct {
+	db *postgres.DB
+}
+
+// NewGeofenceRepository creates a new geofence repository instance
+func NewGeofenceRepository(db *postgres.DB) *GeofenceRepository {
+	return &GeofenceRepository{
+		db,
+	}
+}
+
+func (gr *GeofenceRepository) CreateGeofence(ctx context.Context, geofence *domain.Geofence) (*domain.Geofence, domain.CError) {
+	query := `
+		INSERT INTO geofences (name, slug, area)
+		VALUES ($1, $2, ST_GeogFromText($3))
+		RETURNING id, name, slug, created_at
+	`
+
+	created := domain.Geofence{Points: geofence.Points}
+	err := gr.db.Conn(ctx).QueryRow(ctx, query, geofence.Name, gr.db.Slug(geofence.Name), polygonWKT(geofence.Points)).
+		Scan(&created.ID, &created.Name, &created.Slug, &created.CreatedAt)
+	if err != nil {
+		switch gr.db.ErrorCode(err) {
+		// 23505 is the error code for a unique conflict error
+		case "23505":
+			return nil, domain.ErrConflictingData
+		// 23514 is the error code of a check violation, the polygon is not valid
+		case "23514":
+			return nil, domain.NewBadRequestCError("the edges of the geofence must not cross")
+		}
+
+		return nil, gr.internalError(err)
+	}
+
+	return &created, nil
+}
+
+func (gr *GeofenceRepository) ListGeofenceLocations(ctx context.Context, name string, req *domain.ListGeofenceLocationsRequest) ([]domain.Location, domain.CError) {
+	var id string
+	err := gr.db.ReadQueryRow(ctx, "SELECT id FROM geofences WHERE LOWER(name) = LOWER($1) OR slug = $2", name, gr.db.Slug(name)).
+		Scan(&id)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrDataNotFound
+		}
+		return nil, gr.internalError(err)
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultGeofenceLocationsLimit
+	}
+
+	// ST_Covers on geography walks the GiST index on geo, ST_Contains has no geography variant
+	sql, args, err := gr.db.QueryBuilder.Select(locationColumns...).
+		From("locations").
+		Where(sq.Expr("ST_Covers((SELECT area FROM geofences WHERE id = ?), geo)", id)).
+		Where(approved).
+		OrderBy("name", "id").
+		Limit(uint64(limit)).
+		Offset(uint64(req.Offset)).
+		ToSql()
+	if err != nil {
+		return nil, gr.internalError(err)
+	}
+
+	rows, err := gr.db.ReadQuery(ctx, sql, args...)
+	if err != nil {
+		return nil, gr.internalError(err)
+	}
+	defer rows.Close()
+
+	locations := []domain.Location{}
+	for rows.Next() {
+		var location domain.Location
+		if err := scanLocation(rows, &location); err != nil {
+			return nil, gr.internalError(err)
+		}
+		locations = append(locations, location)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, gr.internalError(err)
+	}
+
+	return locations, nil
+}
+
+// internalError converts a database error into a CError, telling timeouts and constraint violations apart.
+// The other errors are internal, with the database error as their cause
+func (gr *GeofenceRepository) internalError(err error) domain.CError {
+	if gr.db.IsTimeout(err) {
+		return domain.ErrTimeout
+	}
+	if cerr := gr.db.ViolationError(err); cerr != nil {
+		return cerr
+	}
+	return domain.WrapCError(domain.ErrInternal, err, domain.ErrorContext{Entity: "geofence"})
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"leeta/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// manhattan is a rough polygon around Manhattan
+var manhattan = []domain.Point{
+	{Latitude: 40.700, Longitude: -74.020},
+	{Latitude: 40.880, Longitude: -73.935},
+	{Latitude: 40.800, Longitude: -73.920},
+	{Latitude: 40.705, Longitude: -73.970},
+}
+
+func TestGeofenceRepository_ListGeofenceLocations(t *testing.T) {
+	locations, db := newTestLocationRepository(t)
+	_, err := db.Exec(context.Background(), "DELETE FROM geofences")
+	require.NoError(t, err, "Failed to cleanup test data")
+
+	repo := NewGeofenceRepository(db)
+	ctx := context.Background()
+
+	for _, l := range []domain.Location{
+		{Name: "Central Park", Latitude: 40.7829, Longitude: -73.9654},
+		{Name: "Bryant Park", Latitude: 40.7536, Longitude: -73.9832},
+		{Name: "Prospect Park", Latitude: 40.6602, Longitude: -73.9690},
+	} {
+		_, cerr := locations.CreateLocation(ctx, &l)
+		require.Nil(t, cerr)
+	}
+
+	created, cerr := repo.CreateGeofence(ctx, &domain.Geofence{Name: "Manhattan", Points: manhattan})
+	require.Nil(t, cerr)
+	assert.Equal(t, "manhattan", created.Slug)
+
+	t.Run("Locations inside the geofence, by name", func(t *testing.T) {
+		inside, cerr := repo.ListGeofenceLocations(ctx, "MANHATTAN", &domain.ListGeofenceLocationsRequest{})
+		require.Nil(t, cerr)
+		require.Len(t, inside, 2)
+		assert.Equal(t, "Bryant Park", inside[0].Name)
+		assert.Equal(t, "Central Park", inside[1].Name)
+	})
+
+	t.Run("Pages", func(t *testing.T) {
+		page, cerr := repo.ListGeofenceLocations(ctx, "manhattan", &domain.ListGeofenceLocationsRequest{Limit: 1, Offset: 1})
+		require.Nil(t, cerr)
+		require.Len(t, page, 1)
+		assert.Equal(t, "Central Park", page[0].Name)
+	})
+
+	t.Run("Unknown geofence", func(t *testing.T) {
+		_, cerr := repo.ListGeofenceLocations(ctx, "brooklyn", &domain.ListGeofenceLocationsRequest{})
+		require.NotNil(t, cerr)
+		assert.Equal(t, domain.ErrDataNotFound.Code(), cerr.Code())
+	})
+
+	t.Run("Conflicting name", func(t *testing.T) {
+		_, cerr := repo.CreateGeofence(ctx, &domain.Geofence{Name: "manhattan", Points: manhattan})
+		require.NotNil(t, cerr)
+		assert.Equal(t, domain.ErrConflictingData.Code(), cerr.Code())
+	})
+
+	t.Run("Crossing edges", func(t *testing.T) {
+		bowtie := []domain.Point{{Latitude: 0, Longitude: 0}, {Latitude: 1, Longitude: 1}, {Latitude: 0, Longitude: 1}, {Latitude: 1, Longitude: 0}}
+		_, cerr := repo.CreateGeofence(ctx, &domain.Geofence{Name: "Bowtie", Points: bowtie})
+		require.NotNil(t, cerr)
+		assert.Equal(t, 400, cerr.Code())
+	})
+}
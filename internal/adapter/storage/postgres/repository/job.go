@@ -0,0 +1,187 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+
+	"leeta/internal/adapter/storage/postgres"
+	"leeta/internal/core/domain"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	// jobColumns are the columns selected whenever a full job row is read, the args are
+	// only read by the queue workers
+	jobColumns = `id, kind, state, attempt, max_attempts, progress, COALESCE(last_error, ''),
+		scheduled_at, attempted_at, COALESCE(attempted_by, ''), finalized_at, created_at`
+
+	defaultJobsLimit = 20
+)
+
+// scanJob scans a row selected with jobColumns into a job
+func scanJob(row pgx.Row, job *domain.Job) error {
+	return row.Scan(
+		&job.ID,
+		&job.Kind,
+		&job.State,
+		&job.Attempt,
+		&job.MaxAttempts,
+		&job.Progress,
+		&job.LastError,
+		&job.ScheduledAt,
+		&job.AttemptedAt,
+		&job.AttemptedBy,
+		&job.FinalizedAt,
+		&job.CreatedAt,
+	)
+}
+
+/**
+ * JobRepository implements port.JobRepository interface
+ * and provides an access to the postgres database
+ */
+type JobRepository struct {
+	db *postgres.DB
+}
+
+// NewJobRepository creates a new job repository instance
+func NewJobRepository(db *postgres.DB) *JobRepository {
+	return &JobRepository{
+		db,
+	}
+}
+
+func (jr *JobRepository) EnqueueJob(ctx context.Context, job *domain.Job) (*domain.Job, domain.CError) {
+	query := `
+		INSERT INTO jobs (kind, args, max_attempts, progress)
+		VALUES ($1, $2, $3, $4)
+		RETURNING ` + jobColumns
+
+	var enqueued domain.Job
+	err := scanJob(jr.db.Conn(ctx).QueryRow(ctx, query, job.Kind, job.Args, job.MaxAttempts, job.Progress), &enqueued)
+	if err != nil {
+		return nil, jr.internalError(err)
+	}
+
+	return &enqueued, nil
+}
+
+func (jr *JobRepository) GetJob(ctx context.Context, id int64) (*domain.Job, domain.CError) {
+	query := `SELECT ` + jobColumns + ` FROM jobs WHERE id = $1`
+
+	var job domain.Job
+	if err := scanJob(jr.db.Conn(ctx).QueryRow(ctx, query, id), &job); err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrDataNotFound
+		}
+		return nil, jr.internalError(err)
+	}
+
+	return &job, nil
+}
+
+func (jr *JobRepository) ListJobs(ctx context.Context, req *domain.ListJobsRequest) ([]domain.Job, domain.CError) {
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultJobsLimit
+	}
+
+	builder := sq.Select(jobColumns).
+		From("jobs").
+		OrderBy("id DESC").
+		Limit(uint64(limit)).
+		PlaceholderFormat(sq.Dollar)
+	if req.Kind != "" {
+		builder = builder.Where(sq.Eq{"kind": req.Kind})
+	}
+	if req.State != "" {
+		builder = builder.Where(sq.Eq{"state": req.State})
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, jr.internalError(err)
+	}
+
+	rows, err := jr.db.ReadQuery(ctx, query, args...)
+	if err != nil {
+		return nil, jr.internalError(err)
+	}
+	defer rows.Close()
+
+	jobs := []domain.Job{}
+	for rows.Next() {
+		var job domain.Job
+		if err := scanJob(rows, &job); err != nil {
+			return nil, jr.internalError(err)
+		}
+		jobs = append(jobs, job)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, jr.internalError(err)
+	}
+
+	return jobs, nil
+}
+
+func (jr *JobRepository) CancelJob(ctx context.Context, id int64) (*domain.Job, domain.CError) {
+	query := `
+		UPDATE jobs
+		SET state = 'cancelled', finalized_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND state = 'available'
+		RETURNING ` + jobColumns
+
+	return jr.transition(ctx, query, id)
+}
+
+func (jr *JobRepository) RetryJob(ctx context.Context, id int64) (*domain.Job, domain.CError) {
+	query := `
+		UPDATE jobs
+		SET state = 'available', attempt = 0, scheduled_at = CURRENT_TIMESTAMP, finalized_at = NULL
+		WHERE id = $1 AND state IN ('failed', 'cancelled')
+		RETURNING ` + jobColumns
+
+	return jr.transition(ctx, query, id)
+}
+
+func (jr *JobRepository) UpdateJobProgress(ctx context.Context, id int64, progress json.RawMessage) domain.CError {
+	_, err := jr.db.Conn(ctx).Exec(ctx, "UPDATE jobs SET progress = $2 WHERE id = $1", id, progress)
+	if err != nil {
+		return jr.internalError(err)
+	}
+
+	return nil
+}
+
+// transition runs an update of the state of job id that only applies to some states. It fails with
+// domain.ErrConflictingData when the job is in another state
+func (jr *JobRepository) transition(ctx context.Context, query string, id int64) (*domain.Job, domain.CError) {
+	var job domain.Job
+	err := scanJob(jr.db.Conn(ctx).QueryRow(ctx, query, id), &job)
+	if err == nil {
+		return &job, nil
+	}
+	if err != pgx.ErrNoRows {
+		return nil, jr.internalError(err)
+	}
+
+	if _, cerr := jr.GetJob(ctx, id); cerr != nil {
+		return nil, cerr
+	}
+	return nil, domain.ErrConflictingData
+}
+
+// internalError converts a database error into a CError, telling timeouts and constraint violations apart.
+// The other errors are internal, with the database error as their cause
+func (jr *JobRepository) internalError(err error) domain.CError {
+	if jr.db.IsTimeout(err) {
+		return domain.ErrTimeout
+	}
+	if cerr := jr.db.ViolationError(err); cerr != nil {
+		return cerr
+	}
+	return domain.WrapCError(domain.ErrInternal, err, domain.ErrorContext{Entity: "job"})
+}
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"leeta/internal/adapter/config"
+	"leeta/internal/adapter/storage/postgres"
+	"leeta/internal/adapter/storage/postgres/postgrestest"
+	"leeta/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestJobRepository connects to the test database and empties the jobs table
+func newTestJobRepository(t *testing.T) (*JobRepository, *postgres.DB) {
+	db := postgrestest.DB(t)
+
+	_, err := db.Exec(context.Background(), "DELETE FROM jobs")
+	require.NoError(t, err, "Failed to cleanup test data")
+
+	return NewJobRepository(db), db
+}
+
+func TestQueue_RetriesFailedAttempts(t *testing.T) {
+	repo, db := newTestJobRepository(t)
+	ctx := context.Background()
+
+	job, cerr := repo.EnqueueJob(ctx, &domain.Job{Kind: "test", Args: []byte(`{}`), MaxAttempts: 2})
+	require.Nil(t, cerr)
+	assert.Equal(t, domain.JobAvailable, job.State)
+
+	attempts := 0
+	queue := postgres.NewQueue(db, &config.JobQueueConfiguration{})
+	queue.Register("test", func(ctx context.Context, job *domain.Job) error {
+		attempts++
+		if attempts == 1 {
+			return errors.New("flaky")
+		}
+		return nil
+	})
+
+	ran, err := queue.RunJob(ctx, job.ID)
+	require.NoError(t, err)
+	require.True(t, ran)
+
+	job, cerr = repo.GetJob(ctx, job.ID)
+	require.Nil(t, cerr)
+	assert.Equal(t, domain.JobAvailable, job.State, "a failed attempt is retried")
+	assert.Equal(t, "flaky", job.LastError)
+
+	ran, err = queue.RunJob(ctx, job.ID)
+	require.NoError(t, err)
+	require.True(t, ran)
+
+	job, cerr = repo.GetJob(ctx, job.ID)
+	require.Nil(t, cerr)
+	assert.Equal(t, domain.JobCompleted, job.State)
+	assert.Equal(t, 2, job.Attempt)
+	assert.NotNil(t, job.FinalizedAt)
+
+	ran, err = queue.RunJob(ctx, job.ID)
+	require.NoError(t, err)
+	assert.False(t, ran, "finished jobs are not claimed")
+}
+
+func TestJobRepository_CancelAndRetry(t *testing.T) {
+	repo, _ := newTestJobRepository(t)
+	ctx := context.Background()
+
+	job, cerr := repo.EnqueueJob(ctx, &domain.Job{Kind: "test", Args: []byte(`{}`), MaxAttempts: 1})
+	require.Nil(t, cerr)
+
+	_, cerr = repo.RetryJob(ctx, job.ID)
+	assert.Equal(t, domain.ErrConflictingData, cerr, "only failed or cancelled jobs are retried")
+
+	cancelled, cerr := repo.CancelJob(ctx, job.ID)
+	require.Nil(t, cerr)
+	assert.Equal(t, domain.JobCancelled, cancelled.State)
+
+	_, cerr = repo.CancelJob(ctx, job.ID)
+	assert.Equal(t, domain.ErrConflictingData, cerr, "finished jobs cannot be cancelled")
+
+	retried, cerr := repo.RetryJob(ctx, job.ID)
+	require.Nil(t, cerr)
+	assert.Equal(t, domain.JobAvailable, retried.State)
+	assert.Nil(t, retried.FinalizedAt)
+
+	jobs, cerr := repo.ListJobs(ctx, &domain.ListJobsRequest{State: domain.JobAvailable})
+	require.Nil(t, cerr)
+	assert.Len(t, jobs, 1)
+
+	_, cerr = repo.GetJob(ctx, job.ID+1000)
+	assert.Equal(t, domain.ErrDataNotFound, cerr)
+}
@@ -2,15 +2,232 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
 
 	"leeta/internal/adapter/storage/postgres"
+	"leeta/internal/adapter/storage/postgres/filter"
+	"leeta/internal/core/auth"
 	"leeta/internal/core/domain"
+	"leeta/internal/core/olc"
 
 	sq "github.com/Masterminds/squirrel"
-	"github.com/gosimple/slug"
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 )
 
+// namesColumn selects the translated names of a location as a JSON object keyed by locale, NULL without any
+const namesColumn = "(SELECT jsonb_object_agg(locale, name) FROM location_names WHERE location_id = locations.id)"
+
+// entrancesColumn selects the entrances of a location as a JSON array, NULL without any
+const entrancesColumn = `(SELECT jsonb_agg(jsonb_build_object('name', name, 'latitude', latitude, 'longitude', longitude) ORDER BY position)
+	FROM location_points WHERE location_id = locations.id)`
+
+// locationColumns are the columns selected whenever a full location row is read
+var locationColumns = []string{
+	"id", "name", "slug", "latitude", "longitude", "COALESCE(category, '')", "created_at", "version", "updated_at",
+	namesColumn, "status", "COALESCE(review_reason, '')", "altitude", entrancesColumn, "ST_AsGeoJSON(footprint)::jsonb",
+	"COALESCE(source, '')", "valid_from", "valid_until", "expired OR COALESCE(valid_until <= CURRENT_TIMESTAMP, FALSE)",
+}
+
+// approved matches the locations that passed review, the only ones public queries return
+var approved = sq.Eq{"locations.status": domain.LocationApproved}
+
+// current matches the locations within their validity. The validity is checked along with the expired flag, which
+// the expiry job sets a little after the validity ends
+var current = sq.Expr(`NOT locations.expired
+	AND (locations.valid_from IS NULL OR locations.valid_from <= CURRENT_TIMESTAMP)
+	AND (locations.valid_until IS NULL OR locations.valid_until > CURRENT_TIMESTAMP)`)
+
+// scanLocation scans a row selected with locationColumns into a location and derives its plus code
+func scanLocation(row pgx.Row, location *domain.Location, extra ...any) error {
+	// the names, entrances and footprint would be decoded into those of the previous row otherwise
+	location.Names = nil
+	location.Entrances = nil
+	location.Footprint = nil
+
+	dest := []any{
+		&location.ID,
+		&location.Name,
+		&location.Slug,
+		&location.Latitude,
+		&location.Longitude,
+		&location.Category,
+		&location.CreatedAt,
+		&location.Version,
+		&location.UpdatedAt,
+		&location.Names,
+		&location.Status,
+		&location.ReviewReason,
+		&location.Altitude,
+		&location.Entrances,
+		&location.Footprint,
+		&location.Source,
+		&location.ValidFrom,
+		&location.ValidUntil,
+		&location.Expired,
+	}
+
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return err
+	}
+
+	location.PlusCode = olc.Encode(location.Latitude, location.Longitude, olc.DefaultCodeLength)
+	return nil
+}
+
+// locationFilterFields are the fields that can be used in a list filter
+var locationFilterFields = filter.Fields{
+	"name":       {Column: "name", Type: filter.String},
+	"slug":       {Column: "slug", Type: filter.String},
+	"category":   {Column: "category", Type: filter.String},
+	"latitude":   {Column: "latitude", Type: filter.Number},
+	"longitude":  {Column: "longitude", Type: filter.Number},
+	"altitude":   {Column: "altitude", Type: filter.Number},
+	"created_at": {Column: "created_at", Type: filter.Time},
+	"updated_at": {Column: "updated_at", Type: filter.Time},
+	"alias": {
+		Column:  "location_aliases.name",
+		Type:    filter.String,
+		Related: "SELECT 1 FROM location_aliases WHERE location_aliases.location_id = locations.id",
+	},
+}
+
+// listOrder is the ORDER BY clause of a list sorted by sort, the most recent locations first
+func listOrder(sort domain.LocationSort) string {
+	if sort == domain.SortUpdated {
+		return "updated_at DESC"
+	}
+	return "created_at DESC"
+}
+
+// nameMatches matches a location by its name, ignoring case, or by its slug
+func nameMatches(db *postgres.DB, name string) sq.Sqlizer {
+	return sq.Or{sq.Expr("LOWER(name) = LOWER(?)", name), sq.Eq{"slug": db.Slug(name)}}
+}
+
+// oneNameMatches matches the one location matched by nameMatches, the location named name before
+// the location with its slug, which may be another one when the collisions of the slugs are resolved
+func oneNameMatches(db *postgres.DB, name string) sq.Sqlizer {
+	slug := db.Slug(name)
+	return sq.Expr(
+		"id = (SELECT id FROM locations WHERE LOWER(name) = LOWER(?) OR slug = ? ORDER BY LOWER(name) = LOWER(?) DESC LIMIT 1)",
+		name, slug, name,
+	)
+}
+
+// maxSlugAttempts is the number of times a location is written again when its slug was taken meanwhile,
+// or by a location hidden from the caller, when the collisions of the slugs are resolved
+const maxSlugAttempts = 5
+
+// slugCandidates is the number of alternatives to a slug taken looked up at once
+const slugCandidates = 20
+
+// locationSlug returns the slug of a location named name. When the collisions of the slugs are resolved,
+// it is the first of its slug and its alternatives that neither another location nor taken has. The
+// locations named one of owners are the location itself, whose slug is not taken from it
+func (ur *LocationRepository) locationSlug(ctx context.Context, name string, taken map[string]bool, owners ...string) (string, error) {
+	slugger := ur.db.Slugger()
+	base := slugger.Make(name)
+	if !slugger.Resolves() {
+		return base, nil
+	}
+
+	candidates := []string{base}
+	for attempt := 1; attempt <= slugCandidates; attempt++ {
+		candidates = append(candidates, slugger.Alternative(base, name, attempt))
+	}
+	lowered := make([]string, len(owners))
+	for i, owner := range owners {
+		lowered[i] = strings.ToLower(owner)
+	}
+
+	rows, err := ur.db.Conn(ctx).Query(ctx,
+		"SELECT slug FROM locations WHERE slug = ANY($1) AND LOWER(name) <> ALL($2)", candidates, lowered,
+	)
+	if err != nil {
+		return "", err
+	}
+	used, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return "", err
+	}
+
+	for _, candidate := range candidates {
+		if !taken[candidate] && !slices.Contains(used, candidate) {
+			return candidate, nil
+		}
+	}
+	// every candidate is taken, writing the location fails with a conflict
+	return base, nil
+}
+
+// importSlugs returns the slugs of locations. When the collisions of the slugs are resolved, the first of the
+// locations sharing a name that is not taken gets the first of its slug and its alternatives that no other
+// location has, the locations not inserted keep theirs
+func (ur *LocationRepository) importSlugs(ctx context.Context, locations []domain.Location) ([]string, error) {
+	slugger := ur.db.Slugger()
+	slugs := make([]string, len(locations))
+	for i := range locations {
+		slugs[i] = slugger.Make(locations[i].Name)
+	}
+	if !slugger.Resolves() {
+		return slugs, nil
+	}
+
+	names := make([]string, len(locations))
+	candidates := make([]string, 0, len(locations)*(slugCandidates+1))
+	for i, location := range locations {
+		names[i] = strings.ToLower(location.Name)
+		candidates = append(candidates, slugs[i])
+		for attempt := 1; attempt <= slugCandidates; attempt++ {
+			candidates = append(candidates, slugger.Alternative(slugs[i], location.Name, attempt))
+		}
+	}
+
+	rows, err := ur.db.Conn(ctx).Query(ctx,
+		"SELECT slug, LOWER(name) FROM locations WHERE slug = ANY($1) OR LOWER(name) = ANY($2)", candidates, names,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	taken, named := map[string]bool{}, map[string]bool{}
+	var slug, name string
+	_, err = pgx.ForEachRow(rows, []any{&slug, &name}, func() error {
+		taken[slug], named[name] = true, true
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i, location := range locations {
+		// the names already taken are skipped, as are the locations sharing the name of a former one
+		if named[names[i]] {
+			continue
+		}
+		named[names[i]] = true
+
+		base := slugs[i]
+		for attempt := 1; taken[slugs[i]] && attempt <= slugCandidates; attempt++ {
+			slugs[i] = slugger.Alternative(base, location.Name, attempt)
+		}
+		taken[slugs[i]] = true
+	}
+
+	return slugs, nil
+}
+
+// slugTaken reports whether err is the violation of the unique slugs of the locations, which is written
+// again with another slug when the collisions of the slugs are resolved
+func (ur *LocationRepository) slugTaken(err error) bool {
+	// 23505 is the error code for a unique conflict error
+	return ur.db.Slugger().Resolves() && ur.db.ErrorCode(err) == "23505" && ur.db.ConstraintName(err) == "idx_locations_slug"
+}
+
 /**
  * UserRepository implements port.UserRepository interface
  * and provides an access to the postgres database
@@ -29,127 +246,324 @@ func NewLocationRepository(db *postgres.DB) *LocationRepository {
 func (ur *LocationRepository) CreateLocation(ctx context.Context, location *domain.Location) (*domain.Location, domain.CError) {
 
 	query := `
-		INSERT INTO locations (name, slug, latitude, longitude, geo) 
-		VALUES ($1, $2, $3, $4, ST_MakePoint($4, $3)::geography) 
-		RETURNING id, name, slug, latitude, longitude, created_at
-	`
+		INSERT INTO locations (name, slug, latitude, longitude, geo, category, status, altitude, footprint, source, valid_from, valid_until) 
+		VALUES ($1, $2, $3, $4, ST_MakePoint($4, $3)::geography, NULLIF($5, ''), $6, $7, ST_Force2D(ST_GeomFromGeoJSON($8::text))::geography, NULLIF($9, ''), $10, $11) 
+		RETURNING ` + strings.Join(locationColumns, ", ")
 
-	err := ur.db.QueryRow(
-		ctx, query, location.Name, slug.Make(location.Name), location.Latitude, location.Longitude,
-	).Scan(
-		&location.ID, &location.Name, &location.Slug, &location.Latitude, &location.Longitude,
-		&location.CreatedAt,
-	)
+	footprint, err := footprintJSON(location.Footprint)
+	if err != nil {
+		return nil, ur.internalError(err)
+	}
+
+	status := location.Status
+	if status == "" {
+		status = domain.LocationApproved
+	}
+
+	names, entrances := location.Names, location.Entrances
+	taken := map[string]bool{}
+	var slug string
+	for attempt := 1; ; attempt++ {
+		err = ur.db.WithinSavepoint(ctx, func(ctx context.Context) error {
+			var err error
+			if slug, err = ur.locationSlug(ctx, location.Name, taken, location.Name); err != nil {
+				return err
+			}
+
+			err = scanLocation(ur.db.Conn(ctx).QueryRow(
+				ctx, query, location.Name, slug, location.Latitude, location.Longitude,
+				location.Category, status, location.Altitude, footprint, location.Source, location.ValidFrom, location.ValidUntil,
+			), location)
+			if err != nil {
+				return err
+			}
+
+			if err := ur.replaceNames(ctx, location, names); err != nil {
+				return err
+			}
+
+			if err := ur.replaceEntrances(ctx, location, entrances); err != nil {
+				return err
+			}
+
+			// locations waiting for review are announced once approved
+			if location.Status != domain.LocationApproved {
+				return nil
+			}
+
+			return writeEvent(ctx, ur.db, domain.EventLocationCreated, location)
+		})
+		if attempt == maxSlugAttempts || !ur.slugTaken(err) {
+			break
+		}
+		taken[slug] = true
+	}
 
 	if err != nil {
+		switch ur.db.ErrorCode(err) {
 		// 23505 is the error code for a unique conflict error
-		if errCode := ur.db.ErrorCode(err); errCode == "23505" {
+		case "23505":
 			return nil, domain.ErrConflictingData
+		// 23514 is the error code of a check violation, the footprint is not a valid polygon
+		case "23514":
+			return nil, errInvalidFootprint
 		}
 
-		return nil, domain.NewInternalCError(err.Error())
+		return nil, ur.internalError(err)
 	}
 
 	return location, nil
 }
 
-// GetUserByID gets a user by ID from the database
+// CopyLocations bulk inserts locations with the COPY protocol. Rows are copied into a
+// temporary table first, since COPY cannot build the geo column, skip conflicting names or
+// insert the entrances. A created event is written to the outbox for every inserted location
+func (ur *LocationRepository) CopyLocations(ctx context.Context, locations []domain.Location) (int64, domain.CError) {
+	var inserted int64
+
+	err := ur.db.WithinTx(ctx, func(ctx context.Context) error {
+		conn := ur.db.Conn(ctx)
+
+		slugs, err := ur.importSlugs(ctx, locations)
+		if err != nil {
+			return err
+		}
+
+		_, err = conn.Exec(ctx, `
+			CREATE TEMP TABLE locations_import (
+				name VARCHAR(255),
+				slug VARCHAR(255),
+				latitude DOUBLE PRECISION,
+				longitude DOUBLE PRECISION,
+				category VARCHAR(100),
+				altitude DOUBLE PRECISION,
+				position INTEGER,
+				entrances JSONB,
+				footprint TEXT,
+				valid_from TIMESTAMP WITH TIME ZONE,
+				valid_until TIMESTAMP WITH TIME ZONE
+			) ON COMMIT DROP
+		`)
+		if err != nil {
+			return err
+		}
+
+		_, err = conn.CopyFrom(
+			ctx,
+			pgx.Identifier{"locations_import"},
+			[]string{"name", "slug", "latitude", "longitude", "category", "altitude", "position", "entrances", "footprint", "valid_from", "valid_until"},
+			pgx.CopyFromSlice(len(locations), func(i int) ([]any, error) {
+				l := locations[i]
+				var category any
+				if l.Category != "" {
+					category = l.Category
+				}
+				var entrances any
+				if len(l.Entrances) > 0 {
+					entrances = l.Entrances
+				}
+				footprint, err := footprintJSON(l.Footprint)
+				if err != nil {
+					return nil, err
+				}
+				return []any{
+					l.Name, slugs[i], l.Latitude, l.Longitude, category, l.Altitude, i, entrances, footprint,
+					l.ValidFrom, l.ValidUntil,
+				}, nil
+			}),
+		)
+		if err != nil {
+			return err
+		}
+
+		// the first of the locations sharing a name is inserted, along with its entrances
+		tag, err := conn.Exec(ctx, `
+			WITH inserted AS (
+				INSERT INTO locations (name, slug, latitude, longitude, geo, category, altitude, footprint, valid_from, valid_until)
+				SELECT DISTINCT ON (LOWER(name)) name, slug, latitude, longitude,
+					ST_MakePoint(longitude, latitude)::geography, category, altitude,
+					ST_Force2D(ST_GeomFromGeoJSON(footprint))::geography, valid_from, valid_until
+				FROM locations_import
+				ORDER BY LOWER(name), position
+				ON CONFLICT DO NOTHING
+				RETURNING id, name, slug, latitude, longitude, category, created_at, version, updated_at, altitude,
+					valid_from, valid_until, tenant
+			), entrances AS (
+				INSERT INTO location_points (location_id, position, name, latitude, longitude, geo)
+				SELECT inserted.id, e.position, COALESCE(e.entrance->>'name', ''),
+					(e.entrance->>'latitude')::float8, (e.entrance->>'longitude')::float8,
+					ST_MakePoint((e.entrance->>'longitude')::float8, (e.entrance->>'latitude')::float8)::geography
+				FROM inserted
+				CROSS JOIN LATERAL (
+					SELECT entrances FROM locations_import
+					WHERE LOWER(locations_import.name) = LOWER(inserted.name)
+					ORDER BY position
+					LIMIT 1
+				) imported
+				CROSS JOIN LATERAL jsonb_array_elements(imported.entrances) WITH ORDINALITY AS e(entrance, position)
+			)
+			INSERT INTO outbox (event_type, aggregate_id, payload, tenant)
+			SELECT $1, id, jsonb_strip_nulls(jsonb_build_object(
+				'id', id, 'name', name, 'slug', slug, 'latitude', latitude,
+				'longitude', longitude, 'category', category, 'created_at', created_at,
+				'version', version, 'updated_at', updated_at, 'altitude', altitude,
+				'valid_from', valid_from, 'valid_until', valid_until
+			)), COALESCE(NULLIF($2, ''), tenant)
+			FROM inserted
+		`, domain.EventLocationCreated, auth.Tenant(ctx))
+		if err != nil {
+			return err
+		}
+
+		inserted = tag.RowsAffected()
+		return nil
+	})
+
+	if err != nil {
+		return 0, ur.internalError(err)
+	}
+
+	return inserted, nil
+}
+
+// GetLocationByID gets a location by ID from the database
 func (ur *LocationRepository) GetLocationByID(ctx context.Context, id string) (*domain.Location, domain.CError) {
 	var location domain.Location
 
-	query := ur.db.QueryBuilder.Select("id", "name", "slug", "latitude", "longitude", "created_at").
+	query := ur.db.QueryBuilder.Select(locationColumns...).
 		From("locations").
 		Where(sq.Eq{"id": id}).
 		Limit(1)
 
 	sql, args, err := query.ToSql()
 	if err != nil {
-		return nil, domain.NewInternalCError(err.Error())
+		return nil, ur.internalError(err)
 	}
 
-	err = ur.db.QueryRow(ctx, sql, args...).Scan(
-		&location.ID,
-		&location.Name,
-		&location.Slug,
-		&location.Latitude,
-		&location.Longitude,
-		&location.CreatedAt,
-	)
+	err = scanLocation(ur.db.ReadQueryRow(ctx, sql, args...), &location)
 
 	if err != nil {
-		if err == pgx.ErrNoRows {
+		// 22P02 is the error code for an invalid text representation, no location has an id that is not a UUID
+		if err == pgx.ErrNoRows || ur.db.ErrorCode(err) == "22P02" {
 			return nil, domain.ErrDataNotFound
 		}
-		return nil, domain.NewInternalCError(err.Error())
+		return nil, ur.internalError(err)
 	}
 
 	return &location, nil
 }
 
-// GetLocationBySlug gets a location by slug from the database
+// LookupLocations gets the locations by id or slug from the database
+func (ur *LocationRepository) LookupLocations(ctx context.Context, ids, slugs []string) ([]domain.Location, domain.CError) {
+	// an id that is not a UUID would fail the whole query rather than match no location
+	uuids := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if uuid.Validate(id) == nil {
+			uuids = append(uuids, id)
+		}
+	}
+
+	query := ur.db.QueryBuilder.Select(locationColumns...).
+		From("locations").
+		Where(approved).
+		Where(sq.Or{sq.Eq{"id": uuids}, sq.Eq{"slug": slugs}}).
+		OrderBy("name")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, ur.internalError(err)
+	}
+
+	rows, err := ur.db.ReadQuery(ctx, sql, args...)
+	if err != nil {
+		return nil, ur.internalError(err)
+	}
+	defer rows.Close()
+
+	locations := []domain.Location{}
+	for rows.Next() {
+		var location domain.Location
+		if err := scanLocation(rows, &location); err != nil {
+			return nil, ur.internalError(err)
+		}
+		locations = append(locations, location)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, ur.internalError(err)
+	}
+
+	return locations, nil
+}
+
+// GetLocationByName gets a location by name, ignoring case, by slug or by alias from the database
 func (ur *LocationRepository) GetLocationByName(ctx context.Context, name string) (*domain.Location, domain.CError) {
 	var location domain.Location
 
-	query := ur.db.QueryBuilder.Select("id", "name", "slug", "latitude", "longitude", "created_at").
+	query := ur.db.QueryBuilder.Select(locationColumns...).
 		From("locations").
-		Where(sq.Or{sq.Eq{"name": name}, sq.Eq{"slug": slug.Make(name)}}).
+		Where(locationMatches(ur.db, name)).
+		Where(approved).
+		OrderByClause(byNameFirst(ur.db, name)).
 		Limit(1)
 
 	sql, args, err := query.ToSql()
 	if err != nil {
-		return nil, domain.NewInternalCError(err.Error())
+		return nil, ur.internalError(err)
 	}
 
-	err = ur.db.QueryRow(ctx, sql, args...).Scan(
-		&location.ID,
-		&location.Name,
-		&location.Slug,
-		&location.Latitude,
-		&location.Longitude,
-		&location.CreatedAt,
-	)
+	err = scanLocation(ur.db.ReadQueryRow(ctx, sql, args...), &location)
 
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return nil, domain.ErrDataNotFound
 		}
-		return nil, domain.NewInternalCError(err.Error())
+		return nil, ur.internalError(err)
 	}
 
 	return &location, nil
 }
 
-// ListLocations lists all locations from the database
-func (ur *LocationRepository) ListLocations(ctx context.Context) ([]domain.Location, domain.CError) {
+// ListLocations lists all locations matching the request filter from the database
+func (ur *LocationRepository) ListLocations(ctx context.Context, req *domain.ListLocationsRequest) ([]domain.Location, domain.CError) {
 	var location domain.Location
 	var locations []domain.Location
 
-	query := ur.db.QueryBuilder.Select("id", "name", "slug", "latitude", "longitude", "created_at").
+	query := ur.db.QueryBuilder.Select(locationColumns...).
 		From("locations").
-		OrderBy("created_at DESC")
+		Where(approved).
+		OrderBy(listOrder(req.Sort))
+	if !req.IncludeExpired {
+		query = query.Where(current)
+	}
+
+	pred, err := filter.Parse(req.Query, locationFilterFields)
+	if err != nil {
+		return nil, domain.NewBadRequestCError(err.Error())
+	}
+	if pred != nil {
+		query = query.Where(pred)
+	}
+
+	query, cerr := ur.scopeToCollection(ctx, query, req.Collection)
+	if cerr != nil {
+		return nil, cerr
+	}
 
 	sql, args, err := query.ToSql()
 	if err != nil {
-		return nil, domain.NewInternalCError(err.Error())
+		return nil, ur.internalError(err)
 	}
 
-	rows, err := ur.db.Query(ctx, sql, args...)
+	rows, err := ur.db.ReadQuery(ctx, sql, args...)
 	if err != nil {
-		return nil, domain.NewInternalCError(err.Error())
+		return nil, ur.internalError(err)
 	}
 	defer rows.Close()
 
 	for rows.Next() {
-		err := rows.Scan(
-			&location.ID,
-			&location.Name,
-			&location.Slug,
-			&location.Latitude,
-			&location.Longitude,
-			&location.CreatedAt,
-		)
+		err := scanLocation(rows, &location)
 		if err != nil {
-			return nil, domain.NewInternalCError(err.Error())
+			return nil, ur.internalError(err)
 		}
 
 		locations = append(locations, location)
@@ -158,47 +572,627 @@ func (ur *LocationRepository) ListLocations(ctx context.Context) ([]domain.Locat
 	return locations, nil
 }
 
+// StreamLocations iterates over the locations matching the request filter without loading them all in memory
+func (ur *LocationRepository) StreamLocations(ctx context.Context, req *domain.ListLocationsRequest, fn func(location *domain.Location) error) domain.CError {
+	var location domain.Location
+
+	query := ur.db.QueryBuilder.Select(locationColumns...).
+		From("locations").
+		Where(approved).
+		OrderBy(listOrder(req.Sort))
+	if !req.IncludeExpired {
+		query = query.Where(current)
+	}
+
+	pred, err := filter.Parse(req.Query, locationFilterFields)
+	if err != nil {
+		return domain.NewBadRequestCError(err.Error())
+	}
+	if pred != nil {
+		query = query.Where(pred)
+	}
+
+	query, cerr := ur.scopeToCollection(ctx, query, req.Collection)
+	if cerr != nil {
+		return cerr
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return ur.internalError(err)
+	}
+
+	rows, err := ur.db.ReadQuery(ctx, sql, args...)
+	if err != nil {
+		return ur.internalError(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := scanLocation(rows, &location); err != nil {
+			return ur.internalError(err)
+		}
+
+		if err := fn(&location); err != nil {
+			return ur.internalError(err)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return ur.internalError(err)
+	}
+
+	return nil
+}
+
+// LocationGeometry computes the geometry of the locations matching the request filter or slugs from their points,
+// collected once. The centroid is computed on the spheroid, the bounding box and the hull on the plane
+func (ur *LocationRepository) LocationGeometry(ctx context.Context, req *domain.LocationGeometryRequest) (*domain.LocationGeometry, domain.CError) {
+	var geometry domain.LocationGeometry
+	var minLongitude, minLatitude, maxLongitude, maxLatitude *float64
+
+	points := ur.db.QueryBuilder.Select("COUNT(*) AS count", "ST_Collect(geo::geometry) AS points").
+		From("locations").
+		Where(approved).
+		Where(current)
+
+	if len(req.Slugs) > 0 {
+		points = points.Where(sq.Eq{"slug": req.Slugs})
+	} else {
+		pred, err := filter.Parse(req.Query, locationFilterFields)
+		if err != nil {
+			return nil, domain.NewBadRequestCError(err.Error())
+		}
+		if pred != nil {
+			points = points.Where(pred)
+		}
+
+		var cerr domain.CError
+		points, cerr = ur.scopeToCollection(ctx, points, req.Collection)
+		if cerr != nil {
+			return nil, cerr
+		}
+	}
+
+	query := ur.db.QueryBuilder.Select(
+		"count",
+		"ST_AsGeoJSON(ST_Centroid(points::geography))::jsonb",
+		"ST_XMin(points)", "ST_YMin(points)", "ST_XMax(points)", "ST_YMax(points)",
+		"ST_AsGeoJSON(ST_ConvexHull(points))::jsonb",
+	).FromSelect(points, "collected")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, ur.internalError(err)
+	}
+
+	err = ur.db.ReadQueryRow(ctx, sql, args...).Scan(
+		&geometry.Count,
+		&geometry.Centroid,
+		&minLongitude, &minLatitude, &maxLongitude, &maxLatitude,
+		&geometry.ConvexHull,
+	)
+	if err != nil {
+		return nil, ur.internalError(err)
+	}
+
+	if geometry.Count > 0 {
+		geometry.BoundingBox = []float64{*minLongitude, *minLatitude, *maxLongitude, *maxLatitude}
+	}
+
+	return &geometry, nil
+}
+
+// UpdateLocation updates a location by name or slug if it is still at the expected version,
+// and writes an updated event to the outbox for it
+func (ur *LocationRepository) UpdateLocation(ctx context.Context, name string, location *domain.Location) (*domain.Location, domain.CError) {
+	var updated domain.Location
+
+	query := `
+		UPDATE locations
+		SET name = $1, slug = $2, latitude = $3, longitude = $4, geo = ST_MakePoint($4, $3)::geography,
+			category = NULLIF($5, ''), altitude = $9, footprint = ST_Force2D(ST_GeomFromGeoJSON($10::text))::geography,
+			valid_from = $11, valid_until = $12, expired = FALSE, version = version + 1, updated_at = CURRENT_TIMESTAMP
+		WHERE id = (
+			SELECT id FROM locations
+			WHERE (LOWER(name) = LOWER($6) OR slug = $7) AND status = 'approved'
+			ORDER BY LOWER(name) = LOWER($6) DESC
+			LIMIT 1
+		) AND version = $8
+		RETURNING ` + strings.Join(locationColumns, ", ")
+
+	footprint, err := footprintJSON(location.Footprint)
+	if err != nil {
+		return nil, ur.internalError(err)
+	}
+
+	found := true
+	taken := map[string]bool{}
+	var slug string
+	for attempt := 1; ; attempt++ {
+		err = ur.db.WithinSavepoint(ctx, func(ctx context.Context) error {
+			conn := ur.db.Conn(ctx)
+
+			var err error
+			if slug, err = ur.locationSlug(ctx, location.Name, taken, location.Name, name); err != nil {
+				return err
+			}
+
+			err = scanLocation(conn.QueryRow(
+				ctx, query, location.Name, slug, location.Latitude, location.Longitude,
+				location.Category, name, ur.db.Slug(name), location.Version, location.Altitude, footprint,
+				location.ValidFrom, location.ValidUntil,
+			), &updated)
+
+			if err == pgx.ErrNoRows {
+				// nothing matched, either the location does not exist or its version moved on
+				err = conn.QueryRow(ctx,
+					"SELECT EXISTS (SELECT 1 FROM locations WHERE (LOWER(name) = LOWER($1) OR slug = $2) AND status = 'approved')",
+					name, ur.db.Slug(name),
+				).Scan(&found)
+				if err != nil {
+					return err
+				}
+				return pgx.ErrNoRows
+			}
+			if err != nil {
+				return err
+			}
+
+			// the names and entrances are kept when the update leaves them out
+			if location.Names != nil {
+				if err := ur.replaceNames(ctx, &updated, location.Names); err != nil {
+					return err
+				}
+			}
+			if location.Entrances != nil {
+				if err := ur.replaceEntrances(ctx, &updated, location.Entrances); err != nil {
+					return err
+				}
+			}
+
+			return writeEvent(ctx, ur.db, domain.EventLocationUpdated, &updated)
+		})
+		if attempt == maxSlugAttempts || !ur.slugTaken(err) {
+			break
+		}
+		taken[slug] = true
+	}
+
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			if !found {
+				return nil, domain.ErrDataNotFound
+			}
+			return nil, domain.ErrVersionConflict
+		}
+
+		switch ur.db.ErrorCode(err) {
+		// 23505 is the error code for a unique conflict error
+		case "23505":
+			return nil, domain.ErrConflictingData
+		// 23514 is the error code of a check violation, the footprint is not a valid polygon
+		case "23514":
+			return nil, errInvalidFootprint
+		}
+
+		return nil, ur.internalError(err)
+	}
+
+	return &updated, nil
+}
+
 // DeleteLocation deletes a location by name or slug from the database
+// and writes a deleted event to the outbox for it
 func (ur *LocationRepository) DeleteLocation(ctx context.Context, name string) domain.CError {
 	query := ur.db.QueryBuilder.Delete("locations").
-		Where(sq.Or{sq.Eq{"name": name}, sq.Eq{"slug": slug.Make(name)}})
+		Where(oneNameMatches(ur.db, name)).
+		Suffix("RETURNING " + strings.Join(locationColumns, ", "))
 
 	sql, args, err := query.ToSql()
 	if err != nil {
-		return domain.NewInternalCError(err.Error())
+		return ur.internalError(err)
 	}
 
-	_, err = ur.db.Exec(ctx, sql, args...)
+	err = ur.db.WithinTx(ctx, func(ctx context.Context) error {
+		var location domain.Location
+		var deleted []domain.Location
+
+		rows, err := ur.db.Conn(ctx).Query(ctx, sql, args...)
+		if err != nil {
+			return err
+		}
+
+		for rows.Next() {
+			if err := scanLocation(rows, &location); err != nil {
+				rows.Close()
+				return err
+			}
+			deleted = append(deleted, location)
+		}
+
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		for i := range deleted {
+			if err := writeEvent(ctx, ur.db, domain.EventLocationDeleted, &deleted[i]); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
 	if err != nil {
-		return domain.NewInternalCError(err.Error())
+		return ur.internalError(err)
 	}
 
 	return nil
 }
 
-// GetNearestLocation gets the nearest location from the database
-func (ur *LocationRepository) GetNearestLocation(ctx context.Context, latitude, longitude float64) (*domain.NearestLocation, domain.CError) {
+// ExpireLocations flags up to limit locations whose validity has ended as expired, and writes an expired
+// event to the outbox for each of them. It returns the number of locations flagged
+func (ur *LocationRepository) ExpireLocations(ctx context.Context, limit int) (int64, domain.CError) {
+	query := `
+		UPDATE locations SET expired = TRUE
+		WHERE id IN (
+			SELECT id FROM locations
+			WHERE valid_until IS NOT NULL AND NOT expired AND valid_until <= CURRENT_TIMESTAMP
+			ORDER BY valid_until
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + strings.Join(locationColumns, ", ")
+
+	var expired int64
+	err := ur.db.WithinTx(ctx, func(ctx context.Context) error {
+		rows, err := ur.db.Conn(ctx).Query(ctx, query, limit)
+		if err != nil {
+			return err
+		}
+
+		var locations []domain.Location
+		var location domain.Location
+		for rows.Next() {
+			if err := scanLocation(rows, &location); err != nil {
+				rows.Close()
+				return err
+			}
+			locations = append(locations, location)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		// only the approved locations were public, the others were never announced
+		for i := range locations {
+			if locations[i].Status != domain.LocationApproved {
+				continue
+			}
+			if err := writeEvent(ctx, ur.db, domain.EventLocationExpired, &locations[i]); err != nil {
+				return err
+			}
+		}
+
+		expired = int64(len(locations))
+		return nil
+	})
+
+	if err != nil {
+		return 0, ur.internalError(err)
+	}
+
+	return expired, nil
+}
+
+// FindLocationsAt gets the approved and pending locations at exactly the latitude and longitude from the database.
+// It reads the primary, since a location registered a moment ago may not have reached the replica yet
+func (ur *LocationRepository) FindLocationsAt(ctx context.Context, latitude, longitude float64) ([]domain.Location, domain.CError) {
+	query := ur.db.QueryBuilder.Select(locationColumns...).
+		From("locations").
+		Where(sq.Eq{
+			"latitude":  latitude,
+			"longitude": longitude,
+			"status":    []domain.LocationStatus{domain.LocationApproved, domain.LocationPending},
+		}).
+		OrderBy("name", "id")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, ur.internalError(err)
+	}
+
+	rows, err := ur.db.Conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, ur.internalError(err)
+	}
+	defer rows.Close()
+
+	locations := []domain.Location{}
+	for rows.Next() {
+		var location domain.Location
+		if err := scanLocation(rows, &location); err != nil {
+			return nil, ur.internalError(err)
+		}
+		locations = append(locations, location)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, ur.internalError(err)
+	}
+
+	return locations, nil
+}
+
+// GetNearestLocation gets the nearest location within its validity from the database
+func (ur *LocationRepository) GetNearestLocation(ctx context.Context, latitude, longitude float64, formula domain.DistanceFormula) (*domain.NearestLocation, domain.CError) {
 	var location domain.NearestLocation
 
+	// ordering by the <-> distance operator walks the GiST index on access nearest first,
+	// ordering by ST_Distance would compute the distance of every row. Access holds the
+	// entrances of a location, or its point without any
 	query := `
-		SELECT id, name, slug, latitude, longitude, created_at,
-		ST_Distance(geo, ST_MakePoint($1, $2)::geography) AS distance_meters
+		SELECT ` + strings.Join(locationColumns, ", ") + `,
+		ST_Distance(access, ST_MakePoint($1, $2)::geography, $3) AS distance_meters
 		FROM locations
-		ORDER BY distance_meters
+		WHERE status = 'approved' AND NOT expired
+			AND (valid_from IS NULL OR valid_from <= CURRENT_TIMESTAMP)
+			AND (valid_until IS NULL OR valid_until > CURRENT_TIMESTAMP)
+		ORDER BY access <-> ST_MakePoint($1, $2)::geography
 		LIMIT 1
 	`
 
-	err := ur.db.QueryRow(ctx, query, longitude, latitude).Scan(
-		&location.ID, &location.Name, &location.Slug, &location.Latitude,
-		&location.Longitude, &location.CreatedAt, &location.Distance,
-	)
+	err := scanLocation(ur.db.ReadQueryRow(ctx, query, longitude, latitude, useSpheroid(formula)), &location.Location, &location.Distance)
 
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return nil, domain.ErrDataNotFound
 		}
-		return nil, domain.NewInternalCError(err.Error())
+		return nil, ur.internalError(err)
 	}
 
 	return &location, nil
 }
+
+// FindNearestLocations gets the nearest locations matching the filters in the query, closest first.
+// Locations with entrances are measured to their closest entrance
+func (ur *LocationRepository) FindNearestLocations(ctx context.Context, query *domain.NearestLocationsRequest) ([]domain.NearestLocation, domain.CError) {
+	var location domain.NearestLocation
+	var locations []domain.NearestLocation
+	spheroid := useSpheroid(query.Formula)
+
+	builder := ur.db.QueryBuilder.Select(locationColumns...).
+		Column(sq.Expr("ST_Distance(access, ST_MakePoint(?, ?)::geography, ?) AS distance_meters", query.Longitude, query.Latitude, spheroid)).
+		From("locations").
+		Where(approved).
+		OrderByClause("access <-> ST_MakePoint(?, ?)::geography", query.Longitude, query.Latitude).
+		Limit(uint64(query.Limit))
+
+	if !query.IncludeExpired {
+		builder = builder.Where(current)
+	}
+
+	if query.MaxDistance > 0 {
+		builder = builder.Where(
+			sq.Expr("ST_DWithin(access, ST_MakePoint(?, ?)::geography, ?, ?)", query.Longitude, query.Latitude, query.MaxDistance, spheroid),
+		)
+	}
+
+	if len(query.Categories) > 0 {
+		builder = builder.Where(sq.Eq{"category": query.Categories})
+	}
+
+	if len(query.Exclude) > 0 {
+		slugs := make([]string, len(query.Exclude))
+		for i, name := range query.Exclude {
+			slugs[i] = ur.db.Slug(name)
+		}
+		builder = builder.Where(sq.NotEq{"slug": slugs})
+	}
+
+	builder, cerr := ur.scopeToCollection(ctx, builder, query.Collection)
+	if cerr != nil {
+		return nil, cerr
+	}
+
+	sql, args, err := builder.ToSql()
+	if err != nil {
+		return nil, ur.internalError(err)
+	}
+
+	rows, err := ur.db.ReadQuery(ctx, sql, args...)
+	if err != nil {
+		return nil, ur.internalError(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		err := scanLocation(rows, &location.Location, &location.Distance)
+		if err != nil {
+			return nil, ur.internalError(err)
+		}
+
+		locations = append(locations, location)
+	}
+
+	return locations, nil
+}
+
+// FindLocationsAlongRoute gets the locations within the buffer in the query of the route, in the order the
+// route passes them. Distances are measured on the spheroid
+func (ur *LocationRepository) FindLocationsAlongRoute(ctx context.Context, route []domain.Point, query *domain.AlongRouteRequest) ([]domain.RouteLocation, domain.CError) {
+	var location domain.RouteLocation
+	var locations []domain.RouteLocation
+
+	// the fraction is located on the planar line, close enough to order the locations along the route
+	builder := ur.db.QueryBuilder.Select(locationColumns...).
+		Prefix("WITH route AS (SELECT ST_GeogFromText(?) AS line)", lineWKT(route)).
+		Column("ST_Distance(geo, route.line) AS distance_meters").
+		Column("ST_LineLocatePoint(route.line::geometry, geo::geometry) AS route_fraction").
+		From("locations, route").
+		Where("ST_DWithin(geo, route.line, ?)", query.Buffer).
+		Where(approved).
+		Where(current).
+		OrderBy("route_fraction", "distance_meters", "id").
+		Limit(uint64(query.Limit))
+
+	if len(query.Categories) > 0 {
+		builder = builder.Where(sq.Eq{"category": query.Categories})
+	}
+
+	sql, args, err := builder.ToSql()
+	if err != nil {
+		return nil, ur.internalError(err)
+	}
+
+	rows, err := ur.db.ReadQuery(ctx, sql, args...)
+	if err != nil {
+		return nil, ur.internalError(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		err := scanLocation(rows, &location.Location, &location.Distance, &location.Fraction)
+		if err != nil {
+			return nil, ur.internalError(err)
+		}
+
+		locations = append(locations, location)
+	}
+
+	return locations, nil
+}
+
+// errInvalidFootprint is returned for a footprint the database finds invalid, the rings are checked before
+var errInvalidFootprint = domain.NewBadRequestCError("the edges of the footprint must not cross")
+
+// footprintJSON encodes a footprint as GeoJSON for ST_GeomFromGeoJSON, it is NULL without one
+func footprintJSON(footprint *domain.Polygon) (*string, error) {
+	if footprint == nil {
+		return nil, nil
+	}
+
+	encoded, err := json.Marshal(footprint)
+	if err != nil {
+		return nil, err
+	}
+
+	geoJSON := string(encoded)
+	return &geoJSON, nil
+}
+
+// lineWKT writes the points as a WKT line string, longitude first
+func lineWKT(points []domain.Point) string {
+	var b strings.Builder
+	b.WriteString("LINESTRING(")
+	for i, p := range points {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		fmt.Fprintf(&b, "%g %g", p.Longitude, p.Latitude)
+	}
+	b.WriteString(")")
+
+	return b.String()
+}
+
+// useSpheroid reports whether distances computed with formula are measured on the spheroid
+// rather than on a sphere, the haversine formula being the only one on a sphere
+func useSpheroid(formula domain.DistanceFormula) bool {
+	return formula != domain.DistanceHaversine
+}
+
+// internalError converts a database error into a CError, telling timeouts and constraint violations apart.
+// The other errors are internal, with the database error as their cause
+func (ur *LocationRepository) internalError(err error) domain.CError {
+	if ur.db.IsTimeout(err) {
+		return domain.ErrTimeout
+	}
+	if cerr := ur.db.ViolationError(err); cerr != nil {
+		return cerr
+	}
+	return domain.WrapCError(domain.ErrInternal, err, domain.ErrorContext{Entity: "location"})
+}
+
+// replaceNames replaces the translated names of location with names, in the transaction carried by ctx
+func (ur *LocationRepository) replaceNames(ctx context.Context, location *domain.Location, names map[string]string) error {
+	conn := ur.db.Conn(ctx)
+
+	if _, err := conn.Exec(ctx, "DELETE FROM location_names WHERE location_id = $1", location.ID); err != nil {
+		return err
+	}
+
+	location.Names = nil
+	if len(names) == 0 {
+		return nil
+	}
+
+	locales := make([]string, 0, len(names))
+	translations := make([]string, 0, len(names))
+	for locale, name := range names {
+		locales = append(locales, locale)
+		translations = append(translations, name)
+	}
+
+	_, err := conn.Exec(ctx,
+		"INSERT INTO location_names (location_id, locale, name) SELECT $1, unnest($2::text[]), unnest($3::text[])",
+		location.ID, locales, translations,
+	)
+	if err != nil {
+		return err
+	}
+
+	location.Names = names
+	return nil
+}
+
+// replaceEntrances replaces the entrances of location with entrances, in the transaction carried by ctx.
+// The access of the location nearest searches measure to is refreshed from them by a trigger
+func (ur *LocationRepository) replaceEntrances(ctx context.Context, location *domain.Location, entrances []domain.Entrance) error {
+	conn := ur.db.Conn(ctx)
+
+	if _, err := conn.Exec(ctx, "DELETE FROM location_points WHERE location_id = $1", location.ID); err != nil {
+		return err
+	}
+
+	location.Entrances = nil
+	if len(entrances) == 0 {
+		return nil
+	}
+
+	names := make([]string, len(entrances))
+	latitudes := make([]float64, len(entrances))
+	longitudes := make([]float64, len(entrances))
+	for i, entrance := range entrances {
+		names[i], latitudes[i], longitudes[i] = entrance.Name, entrance.Latitude, entrance.Longitude
+	}
+
+	_, err := conn.Exec(ctx, `
+		INSERT INTO location_points (location_id, position, name, latitude, longitude, geo)
+		SELECT $1, e.position, e.name, e.latitude, e.longitude, ST_MakePoint(e.longitude, e.latitude)::geography
+		FROM unnest($2::text[], $3::float8[], $4::float8[]) WITH ORDINALITY AS e(name, latitude, longitude, position)
+	`, location.ID, names, latitudes, longitudes)
+	if err != nil {
+		return err
+	}
+
+	location.Entrances = entrances
+	return nil
+}
+
+// writeEvent records a change to location in the outbox, in the transaction carried by ctx. The event is
+// for the tenant of the caller, or the one of the location for the callers without a tenant
+func writeEvent(ctx context.Context, db *postgres.DB, eventType domain.EventType, location *domain.Location) error {
+	payload, err := json.Marshal(location)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Conn(ctx).Exec(ctx, `
+		INSERT INTO outbox (event_type, aggregate_id, payload, tenant)
+		VALUES ($1, $2, $3, COALESCE(NULLIF($4, ''), (SELECT tenant FROM locations WHERE id = $2), ''))
+	`, eventType, location.ID, payload, auth.Tenant(ctx))
+	return err
+}
@@ -0,0 +1,507 @@
+package repository
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"leeta/internal/adapter/storage/postgres"
+	"leeta/internal/adapter/storage/postgres/postgrestest"
+	"leeta/internal/core/domain"
+	"leeta/internal/core/geo"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(postgrestest.Run(m))
+}
+
+// newTestLocationRepository connects to the test database and empties the locations table
+func newTestLocationRepository(t *testing.T) (*LocationRepository, *postgres.DB) {
+	db := postgrestest.DB(t)
+
+	_, err := db.Exec(context.Background(), "DELETE FROM locations")
+	require.NoError(t, err, "Failed to cleanup test data")
+
+	return NewLocationRepository(db), db
+}
+
+func TestLocationRepository_CreateLocation(t *testing.T) {
+	repo, _ := newTestLocationRepository(t)
+	ctx := context.Background()
+
+	created, cerr := repo.CreateLocation(ctx, &domain.Location{
+		Name:      "Central Park",
+		Latitude:  40.7829,
+		Longitude: -73.9654,
+		Category:  "park",
+	})
+	require.Nil(t, cerr)
+
+	assert.NotEmpty(t, created.ID)
+	assert.Equal(t, "central-park", created.Slug)
+	assert.Equal(t, int64(1), created.Version)
+
+	t.Run("Found by slug and by name ignoring case", func(t *testing.T) {
+		for _, name := range []string{"central-park", "CENTRAL PARK"} {
+			found, cerr := repo.GetLocationByName(ctx, name)
+			require.Nil(t, cerr, name)
+			assert.Equal(t, created.ID, found.ID)
+		}
+	})
+
+	t.Run("Conflicts with a name differing by case", func(t *testing.T) {
+		_, cerr := repo.CreateLocation(ctx, &domain.Location{Name: "central park", Latitude: 1, Longitude: 1})
+		require.NotNil(t, cerr)
+		assert.Equal(t, domain.ErrConflictingData.Code(), cerr.Code())
+	})
+
+	t.Run("Altitude is kept when given", func(t *testing.T) {
+		assert.Nil(t, created.Altitude)
+
+		altitude := 118.5
+		pad, cerr := repo.CreateLocation(ctx, &domain.Location{Name: "Rooftop Pad", Latitude: 40.7580, Longitude: -73.9855, Altitude: &altitude})
+		require.Nil(t, cerr)
+
+		found, cerr := repo.GetLocationByID(ctx, pad.ID)
+		require.Nil(t, cerr)
+		require.NotNil(t, found.Altitude)
+		assert.Equal(t, altitude, *found.Altitude)
+	})
+}
+
+func TestLocationRepository_UpdateLocation_Version(t *testing.T) {
+	repo, _ := newTestLocationRepository(t)
+	ctx := context.Background()
+
+	created, cerr := repo.CreateLocation(ctx, &domain.Location{Name: "Lagos Park", Latitude: 6.5244, Longitude: 3.3792, Category: "park"})
+	require.Nil(t, cerr)
+
+	updated, cerr := repo.UpdateLocation(ctx, "lagos-park", &domain.Location{
+		Name: "Lagos Park", Latitude: 6.5250, Longitude: 3.3800, Category: "park", Version: created.Version,
+	})
+	require.Nil(t, cerr)
+	assert.Equal(t, created.Version+1, updated.Version)
+
+	t.Run("Stale version conflicts and leaves the row unchanged", func(t *testing.T) {
+		_, cerr := repo.UpdateLocation(ctx, "lagos-park", &domain.Location{
+			Name: "Lagos Gardens", Latitude: 1, Longitude: 1, Category: "garden", Version: created.Version,
+		})
+		assert.Equal(t, domain.ErrVersionConflict, cerr)
+
+		found, cerr := repo.GetLocationByID(ctx, created.ID)
+		require.Nil(t, cerr)
+		assert.Equal(t, "Lagos Park", found.Name)
+		assert.Equal(t, "lagos-park", found.Slug)
+		assert.Equal(t, 6.5250, found.Latitude)
+		assert.Equal(t, 3.3800, found.Longitude)
+		assert.Equal(t, "park", found.Category)
+		assert.Equal(t, updated.Version, found.Version)
+	})
+
+	t.Run("Missing location is not found rather than conflicting", func(t *testing.T) {
+		_, cerr := repo.UpdateLocation(ctx, "lagos-gardens", &domain.Location{Name: "Lagos Gardens", Latitude: 1, Longitude: 1, Version: 1})
+		assert.Equal(t, domain.ErrDataNotFound, cerr)
+	})
+}
+
+func TestLocationRepository_GetNearestLocation(t *testing.T) {
+	repo, _ := newTestLocationRepository(t)
+	ctx := context.Background()
+
+	t.Run("Not found without locations", func(t *testing.T) {
+		_, cerr := repo.GetNearestLocation(ctx, 40.7128, -74.0060, domain.DistanceSpheroid)
+		require.NotNil(t, cerr)
+		assert.Equal(t, domain.ErrDataNotFound.Code(), cerr.Code())
+	})
+
+	for _, l := range []domain.Location{
+		{Name: "New York", Latitude: 40.7128, Longitude: -74.0060},
+		{Name: "Los Angeles", Latitude: 34.0522, Longitude: -118.2437},
+		{Name: "London", Latitude: 51.5074, Longitude: -0.1278},
+	} {
+		_, cerr := repo.CreateLocation(ctx, &l)
+		require.Nil(t, cerr)
+	}
+
+	tests := []struct {
+		name      string
+		latitude  float64
+		longitude float64
+		expected  string
+	}{
+		{"Times Square", 40.7589, -73.9851, "New York"},
+		{"Santa Monica", 34.0195, -118.4912, "Los Angeles"},
+		{"Paris", 48.8566, 2.3522, "London"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nearest, cerr := repo.GetNearestLocation(ctx, tt.latitude, tt.longitude, domain.DistanceSpheroid)
+			require.Nil(t, cerr)
+
+			assert.Equal(t, tt.expected, nearest.Name)
+			assert.Greater(t, nearest.Distance, 0.0)
+		})
+	}
+
+	t.Run("Distance on a sphere for haversine", func(t *testing.T) {
+		spheroid, cerr := repo.GetNearestLocation(ctx, 48.8566, 2.3522, domain.DistanceSpheroid)
+		require.Nil(t, cerr)
+		sphere, cerr := repo.GetNearestLocation(ctx, 48.8566, 2.3522, domain.DistanceHaversine)
+		require.Nil(t, cerr)
+
+		assert.Equal(t, spheroid.Name, sphere.Name)
+		assert.NotEqual(t, spheroid.Distance, sphere.Distance)
+		assert.InDelta(t, geo.Haversine(48.8566, 2.3522, 51.5074, -0.1278), sphere.Distance, 1)
+	})
+}
+
+func TestLocationRepository_FindNearestLocations(t *testing.T) {
+	repo, _ := newTestLocationRepository(t)
+	ctx := context.Background()
+
+	for _, l := range []domain.Location{
+		{Name: "Central Park", Latitude: 40.7829, Longitude: -73.9654, Category: "park"},
+		{Name: "Bryant Park", Latitude: 40.7536, Longitude: -73.9832, Category: "park"},
+		{Name: "MoMA", Latitude: 40.7614, Longitude: -73.9776, Category: "museum"},
+		{Name: "Hyde Park", Latitude: 51.5073, Longitude: -0.1657, Category: "park"},
+	} {
+		_, cerr := repo.CreateLocation(ctx, &l)
+		require.Nil(t, cerr)
+	}
+
+	locations, cerr := repo.FindNearestLocations(ctx, &domain.NearestLocationsRequest{
+		Latitude:    40.7580,
+		Longitude:   -73.9855,
+		Limit:       5,
+		MaxDistance: 20000,
+		Categories:  []string{"park"},
+		Exclude:     []string{"bryant-park"},
+	})
+	require.Nil(t, cerr)
+
+	require.Len(t, locations, 1)
+	assert.Equal(t, "Central Park", locations[0].Name)
+
+	t.Run("Measured to the closest entrance", func(t *testing.T) {
+		// the park stretches north, its south entrance is closer than MoMA
+		_, cerr := repo.UpdateLocation(ctx, "central-park", &domain.Location{
+			Name: "Central Park", Latitude: 40.7829, Longitude: -73.9654, Category: "park", Version: 1,
+			Entrances: []domain.Entrance{
+				{Name: "Grand Army Plaza", Latitude: 40.7644, Longitude: -73.9735},
+				{Name: "Frederick Douglass Circle", Latitude: 40.8003, Longitude: -73.9582},
+			},
+		})
+		require.Nil(t, cerr)
+
+		locations, cerr := repo.FindNearestLocations(ctx, &domain.NearestLocationsRequest{
+			Latitude: 40.7644, Longitude: -73.9740, Limit: 2,
+		})
+		require.Nil(t, cerr)
+
+		require.Len(t, locations, 2)
+		assert.Equal(t, "Central Park", locations[0].Name)
+		assert.Less(t, locations[0].Distance, 50.0)
+		assert.Len(t, locations[0].Entrances, 2)
+		assert.Equal(t, "Grand Army Plaza", locations[0].Entrances[0].Name)
+
+		// without entrances it is measured to its point again
+		_, cerr = repo.UpdateLocation(ctx, "central-park", &domain.Location{
+			Name: "Central Park", Latitude: 40.7829, Longitude: -73.9654, Category: "park", Version: 2,
+			Entrances: []domain.Entrance{},
+		})
+		require.Nil(t, cerr)
+
+		locations, cerr = repo.FindNearestLocations(ctx, &domain.NearestLocationsRequest{
+			Latitude: 40.7644, Longitude: -73.9740, Limit: 1,
+		})
+		require.Nil(t, cerr)
+		assert.Equal(t, "MoMA", locations[0].Name)
+	})
+
+	t.Run("Measured to the edge of the footprint", func(t *testing.T) {
+		// a campus whose point is far from its eastern edge, a few meters west of the query point
+		_, cerr := repo.CreateLocation(ctx, &domain.Location{
+			Name: "Campus", Latitude: 40.7640, Longitude: -73.9900,
+			Footprint: &domain.Polygon{Type: "Polygon", Coordinates: [][][]float64{{
+				{-73.9950, 40.7620}, {-73.9741, 40.7620}, {-73.9741, 40.7660}, {-73.9950, 40.7660}, {-73.9950, 40.7620},
+			}}},
+		})
+		require.Nil(t, cerr)
+
+		locations, cerr := repo.FindNearestLocations(ctx, &domain.NearestLocationsRequest{
+			Latitude: 40.7644, Longitude: -73.9740, Limit: 1,
+		})
+		require.Nil(t, cerr)
+
+		require.Len(t, locations, 1)
+		assert.Equal(t, "Campus", locations[0].Name)
+		assert.Less(t, locations[0].Distance, 20.0)
+		require.NotNil(t, locations[0].Footprint)
+		assert.Len(t, locations[0].Footprint.Coordinates[0], 5)
+	})
+
+	t.Run("Footprints with crossing edges are rejected", func(t *testing.T) {
+		_, cerr := repo.CreateLocation(ctx, &domain.Location{
+			Name: "Bow Tie", Latitude: 40.7580, Longitude: -73.9855,
+			Footprint: &domain.Polygon{Type: "Polygon", Coordinates: [][][]float64{{
+				{-73.99, 40.75}, {-73.98, 40.76}, {-73.98, 40.75}, {-73.99, 40.76}, {-73.99, 40.75},
+			}}},
+		})
+		require.NotNil(t, cerr)
+		assert.Equal(t, http.StatusBadRequest, cerr.Code())
+	})
+}
+
+func TestLocationRepository_FindLocationsAlongRoute(t *testing.T) {
+	repo, _ := newTestLocationRepository(t)
+	ctx := context.Background()
+
+	for _, l := range []domain.Location{
+		{Name: "Central Park", Latitude: 40.7829, Longitude: -73.9654, Category: "park"},
+		{Name: "Bryant Park", Latitude: 40.7536, Longitude: -73.9832, Category: "park"},
+		{Name: "MoMA", Latitude: 40.7614, Longitude: -73.9776, Category: "museum"},
+		{Name: "Hyde Park", Latitude: 51.5073, Longitude: -0.1657, Category: "park"},
+	} {
+		_, cerr := repo.CreateLocation(ctx, &l)
+		require.Nil(t, cerr)
+	}
+
+	// up Sixth Avenue from Bryant Park to Central Park South
+	route := []domain.Point{{Latitude: 40.7536, Longitude: -73.9840}, {Latitude: 40.7648, Longitude: -73.9765}}
+
+	locations, cerr := repo.FindLocationsAlongRoute(ctx, route, &domain.AlongRouteRequest{Buffer: 500, Limit: 10})
+	require.Nil(t, cerr)
+
+	require.Len(t, locations, 2)
+	assert.Equal(t, "Bryant Park", locations[0].Name)
+	assert.Equal(t, "MoMA", locations[1].Name)
+	assert.Less(t, locations[0].Fraction, locations[1].Fraction)
+	assert.Less(t, locations[1].Distance, 500.0)
+
+	locations, cerr = repo.FindLocationsAlongRoute(ctx, route, &domain.AlongRouteRequest{Buffer: 500, Limit: 10, Categories: []string{"museum"}})
+	require.Nil(t, cerr)
+
+	require.Len(t, locations, 1)
+	assert.Equal(t, "MoMA", locations[0].Name)
+}
+
+func TestLocationRepository_LocationGeometry(t *testing.T) {
+	repo, _ := newTestLocationRepository(t)
+	ctx := context.Background()
+
+	for _, l := range []domain.Location{
+		{Name: "Central Park", Latitude: 40.7829, Longitude: -73.9654, Category: "park"},
+		{Name: "Bryant Park", Latitude: 40.7536, Longitude: -73.9832, Category: "park"},
+		{Name: "Battery Park", Latitude: 40.7033, Longitude: -74.0170, Category: "park"},
+		{Name: "MoMA", Latitude: 40.7614, Longitude: -73.9776, Category: "museum"},
+	} {
+		_, cerr := repo.CreateLocation(ctx, &l)
+		require.Nil(t, cerr)
+	}
+
+	geometry, cerr := repo.LocationGeometry(ctx, &domain.LocationGeometryRequest{Query: "category:park"})
+	require.Nil(t, cerr)
+
+	assert.Equal(t, 3, geometry.Count)
+	assert.Equal(t, []float64{-74.0170, 40.7033, -73.9654, 40.7829}, geometry.BoundingBox)
+	assert.Contains(t, string(geometry.ConvexHull), `"Polygon"`)
+	assert.Contains(t, string(geometry.Centroid), `"Point"`)
+
+	geometry, cerr = repo.LocationGeometry(ctx, &domain.LocationGeometryRequest{Slugs: []string{"moma", "bryant-park"}})
+	require.Nil(t, cerr)
+
+	assert.Equal(t, 2, geometry.Count)
+	assert.Contains(t, string(geometry.ConvexHull), `"LineString"`)
+
+	geometry, cerr = repo.LocationGeometry(ctx, &domain.LocationGeometryRequest{Slugs: []string{"not-a-location"}})
+	require.Nil(t, cerr)
+
+	assert.Equal(t, 0, geometry.Count)
+	assert.Nil(t, geometry.BoundingBox)
+	assert.Nil(t, geometry.ConvexHull)
+}
+
+func TestLocationRepository_LookupLocations(t *testing.T) {
+	repo, _ := newTestLocationRepository(t)
+	ctx := context.Background()
+
+	central, cerr := repo.CreateLocation(ctx, &domain.Location{Name: "Central Park", Latitude: 40.7829, Longitude: -73.9654})
+	require.Nil(t, cerr)
+	_, cerr = repo.CreateLocation(ctx, &domain.Location{Name: "Bryant Park", Latitude: 40.7536, Longitude: -73.9832})
+	require.Nil(t, cerr)
+
+	// an id that is not a UUID matches nothing rather than failing the query
+	locations, cerr := repo.LookupLocations(ctx, []string{central.ID, "not-a-uuid"}, []string{"bryant-park", "not-a-location"})
+	require.Nil(t, cerr)
+
+	require.Len(t, locations, 2)
+	assert.Equal(t, "Bryant Park", locations[0].Name)
+	assert.Equal(t, central.ID, locations[1].ID)
+}
+
+func TestLocationRepository_FindLocationsAt(t *testing.T) {
+	repo, _ := newTestLocationRepository(t)
+	ctx := context.Background()
+
+	for _, location := range []domain.Location{
+		{Name: "Lekki Pharmacy", Latitude: 6.45, Longitude: 3.47, Status: domain.LocationPending},
+		{Name: "Lekki Mall", Latitude: 6.45, Longitude: 3.47},
+		{Name: "Lekki Kiosk", Latitude: 6.45, Longitude: 3.47, Status: domain.LocationRejected},
+		{Name: "Lekki Gate", Latitude: 6.4501, Longitude: 3.47},
+	} {
+		_, cerr := repo.CreateLocation(ctx, &location)
+		require.Nil(t, cerr)
+	}
+
+	// the rejected location and the one a few meters away are not duplicates
+	locations, cerr := repo.FindLocationsAt(ctx, 6.45, 3.47)
+	require.Nil(t, cerr)
+	require.Len(t, locations, 2)
+	assert.Equal(t, "Lekki Mall", locations[0].Name)
+	assert.Equal(t, "Lekki Pharmacy", locations[1].Name)
+
+	locations, cerr = repo.FindLocationsAt(ctx, 6.51, 3.38)
+	require.Nil(t, cerr)
+	assert.Empty(t, locations)
+}
+
+func TestLocationRepository_SlugCollisions(t *testing.T) {
+	ctx := context.Background()
+
+	newRepo := func(t *testing.T, collisions string) *LocationRepository {
+		cfg := postgrestest.Config(t)
+		cfg.Slugs.Collisions = collisions
+		db, err := postgres.New(ctx, cfg)
+		require.NoError(t, err)
+		t.Cleanup(db.Close)
+
+		_, err = db.Exec(ctx, "DELETE FROM locations")
+		require.NoError(t, err, "Failed to cleanup test data")
+		return NewLocationRepository(db)
+	}
+
+	t.Run("Refused without a strategy", func(t *testing.T) {
+		repo := newRepo(t, "")
+		_, cerr := repo.CreateLocation(ctx, &domain.Location{Name: "Lodz Centrum", Latitude: 51.77, Longitude: 19.46})
+		require.Nil(t, cerr)
+
+		_, cerr = repo.CreateLocation(ctx, &domain.Location{Name: "Łódź Centrum", Latitude: 51.77, Longitude: 19.46})
+		require.NotNil(t, cerr)
+		assert.Equal(t, domain.ErrConflictingData.Code(), cerr.Code())
+	})
+
+	t.Run("Counter", func(t *testing.T) {
+		repo := newRepo(t, "counter")
+		for _, name := range []string{"Lodz Centrum", "Łódź Centrum", "Lodz-Centrum"} {
+			_, cerr := repo.CreateLocation(ctx, &domain.Location{Name: name, Latitude: 51.77, Longitude: 19.46})
+			require.Nil(t, cerr, name)
+		}
+
+		for name, slug := range map[string]string{"Lodz Centrum": "lodz-centrum", "Łódź Centrum": "lodz-centrum-2", "Lodz-Centrum": "lodz-centrum-3"} {
+			found, cerr := repo.GetLocationByName(ctx, name)
+			require.Nil(t, cerr, name)
+			assert.Equal(t, slug, found.Slug, name)
+		}
+
+		// the location keeps its slug when it is updated
+		found, cerr := repo.GetLocationByName(ctx, "lodz-centrum-2")
+		require.Nil(t, cerr)
+		updated, cerr := repo.UpdateLocation(ctx, "lodz-centrum-2", &domain.Location{
+			Name: found.Name, Latitude: 51.78, Longitude: 19.46, Version: found.Version,
+		})
+		require.Nil(t, cerr)
+		assert.Equal(t, "lodz-centrum-2", updated.Slug)
+	})
+
+	t.Run("Hash within an import", func(t *testing.T) {
+		repo := newRepo(t, "hash")
+		inserted, cerr := repo.CopyLocations(ctx, []domain.Location{
+			{Name: "Lodz Centrum", Latitude: 51.77, Longitude: 19.46},
+			{Name: "Łódź Centrum", Latitude: 51.77, Longitude: 19.46},
+			{Name: "LODZ CENTRUM", Latitude: 51.77, Longitude: 19.46},
+		})
+		require.Nil(t, cerr)
+		assert.Equal(t, int64(2), inserted)
+
+		found, cerr := repo.GetLocationByName(ctx, "Łódź Centrum")
+		require.Nil(t, cerr)
+		assert.Regexp(t, `^lodz-centrum-[0-9a-f]{6}$`, found.Slug)
+	})
+}
+
+func TestLocationRepository_ListLocations_SortUpdated(t *testing.T) {
+	repo, _ := newTestLocationRepository(t)
+	ctx := context.Background()
+
+	past := time.Now().Add(-time.Hour)
+	for _, l := range []domain.Location{
+		{Name: "Pop-up Store", Latitude: 6.5250, Longitude: 3.3800, ValidUntil: &past},
+		{Name: "Lagos Park", Latitude: 6.5244, Longitude: 3.3792},
+	} {
+		_, cerr := repo.CreateLocation(ctx, &l)
+		require.Nil(t, cerr)
+	}
+
+	created, cerr := repo.GetLocationByName(ctx, "pop-up-store")
+	require.Nil(t, cerr)
+
+	// the expiry is a change of the location, though its statement leaves updated_at alone
+	_, cerr = repo.ExpireLocations(ctx, 10)
+	require.Nil(t, cerr)
+
+	locations, cerr := repo.ListLocations(ctx, &domain.ListLocationsRequest{IncludeExpired: true, Sort: domain.SortUpdated})
+	require.Nil(t, cerr)
+	require.Len(t, locations, 2)
+	assert.Equal(t, "Pop-up Store", locations[0].Name)
+	assert.True(t, locations[0].UpdatedAt.After(created.UpdatedAt))
+
+	locations, cerr = repo.ListLocations(ctx, &domain.ListLocationsRequest{IncludeExpired: true})
+	require.Nil(t, cerr)
+	assert.Equal(t, "Lagos Park", locations[0].Name, "created last")
+}
+
+func TestLocationRepository_ExpireLocations(t *testing.T) {
+	repo, _ := newTestLocationRepository(t)
+	ctx := context.Background()
+
+	past, future := time.Now().Add(-time.Hour), time.Now().Add(time.Hour)
+	for _, l := range []domain.Location{
+		{Name: "Lagos Park", Latitude: 6.5244, Longitude: 3.3792},
+		{Name: "Pop-up Store", Latitude: 6.5250, Longitude: 3.3800, ValidUntil: &past},
+		{Name: "Festival", Latitude: 6.5260, Longitude: 3.3810, ValidFrom: &future},
+	} {
+		_, cerr := repo.CreateLocation(ctx, &l)
+		require.Nil(t, cerr)
+	}
+
+	locations, cerr := repo.ListLocations(ctx, &domain.ListLocationsRequest{})
+	require.Nil(t, cerr)
+	require.Len(t, locations, 1)
+	assert.Equal(t, "Lagos Park", locations[0].Name)
+
+	nearest, cerr := repo.GetNearestLocation(ctx, 6.5250, 3.3800, domain.DistanceHaversine)
+	require.Nil(t, cerr)
+	assert.Equal(t, "Lagos Park", nearest.Name)
+
+	locations, cerr = repo.ListLocations(ctx, &domain.ListLocationsRequest{IncludeExpired: true})
+	require.Nil(t, cerr)
+	assert.Len(t, locations, 3)
+
+	expired, cerr := repo.ExpireLocations(ctx, 10)
+	require.Nil(t, cerr)
+	assert.Equal(t, int64(1), expired)
+
+	location, cerr := repo.GetLocationByName(ctx, "pop-up-store")
+	require.Nil(t, cerr)
+	assert.True(t, location.Expired)
+
+	expired, cerr = repo.ExpireLocations(ctx, 10)
+	require.Nil(t, cerr)
+	assert.Zero(t, expired)
+}
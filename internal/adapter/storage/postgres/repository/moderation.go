@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"strings"
+
+	"leeta/internal/core/domain"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+// defaultPendingLocationsLimit is the page size of the locations waiting for review when none is set
+const defaultPendingLocationsLimit = 20
+
+// ListPendingLocations fetches a page of the locations waiting for review, oldest submission first
+func (ur *LocationRepository) ListPendingLocations(ctx context.Context, req *domain.ListPendingLocationsRequest) ([]domain.Location, domain.CError) {
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultPendingLocationsLimit
+	}
+
+	sql, args, err := ur.db.QueryBuilder.Select(locationColumns...).
+		From("locations").
+		Where(sq.Eq{"status": domain.LocationPending}).
+		OrderBy("created_at", "id").
+		Limit(uint64(limit)).
+		Offset(uint64(req.Offset)).
+		ToSql()
+	if err != nil {
+		return nil, ur.internalError(err)
+	}
+
+	rows, err := ur.db.ReadQuery(ctx, sql, args...)
+	if err != nil {
+		return nil, ur.internalError(err)
+	}
+	defer rows.Close()
+
+	locations := []domain.Location{}
+	for rows.Next() {
+		var location domain.Location
+		if err := scanLocation(rows, &location); err != nil {
+			return nil, ur.internalError(err)
+		}
+		locations = append(locations, location)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, ur.internalError(err)
+	}
+
+	return locations, nil
+}
+
+// ReviewLocation moves a location waiting for review, specified by its name or slug, to status and keeps
+// the reason with it. It fails with a conflict when the location is not waiting for review. Approved
+// locations become public, so a created event is written to the outbox for them
+func (ur *LocationRepository) ReviewLocation(ctx context.Context, name string, status domain.LocationStatus, reason string) (*domain.Location, domain.CError) {
+	var location domain.Location
+
+	query := `
+		UPDATE locations
+		SET status = $1, review_reason = NULLIF($2, ''), version = version + 1, updated_at = CURRENT_TIMESTAMP
+		WHERE id = (
+			SELECT id FROM locations
+			WHERE (LOWER(name) = LOWER($3) OR slug = $4) AND status = 'pending'
+			ORDER BY LOWER(name) = LOWER($3) DESC
+			LIMIT 1
+		)
+		RETURNING ` + strings.Join(locationColumns, ", ")
+
+	found := true
+	err := ur.db.WithinTx(ctx, func(ctx context.Context) error {
+		conn := ur.db.Conn(ctx)
+
+		err := scanLocation(conn.QueryRow(ctx, query, status, reason, name, ur.db.Slug(name)), &location)
+		if err == pgx.ErrNoRows {
+			// nothing matched, either the location does not exist or it was already reviewed
+			err = conn.QueryRow(ctx,
+				"SELECT EXISTS (SELECT 1 FROM locations WHERE LOWER(name) = LOWER($1) OR slug = $2)", name, ur.db.Slug(name),
+			).Scan(&found)
+			if err != nil {
+				return err
+			}
+			return pgx.ErrNoRows
+		}
+		if err != nil {
+			return err
+		}
+
+		if location.Status != domain.LocationApproved {
+			return nil
+		}
+
+		return writeEvent(ctx, ur.db, domain.EventLocationCreated, &location)
+	})
+
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			if !found {
+				return nil, domain.ErrDataNotFound
+			}
+			return nil, domain.ErrConflictingData
+		}
+		return nil, ur.internalError(err)
+	}
+
+	return &location, nil
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"leeta/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocationRepository_ReviewLocation(t *testing.T) {
+	repo, _ := newTestLocationRepository(t)
+	ctx := context.Background()
+
+	for _, l := range []domain.Location{
+		{Name: "Lagos Park", Latitude: 6.5244, Longitude: 3.3792, Status: domain.LocationPending},
+		{Name: "Ikeja Mall", Latitude: 6.6018, Longitude: 3.3515, Status: domain.LocationPending},
+		{Name: "Lekki Beach", Latitude: 6.4281, Longitude: 3.4219},
+	} {
+		_, cerr := repo.CreateLocation(ctx, &l)
+		require.Nil(t, cerr)
+	}
+
+	t.Run("Pending locations are not public", func(t *testing.T) {
+		_, cerr := repo.GetLocationByName(ctx, "lagos-park")
+		require.NotNil(t, cerr)
+		assert.Equal(t, domain.ErrDataNotFound.Code(), cerr.Code())
+
+		locations, cerr := repo.ListLocations(ctx, &domain.ListLocationsRequest{})
+		require.Nil(t, cerr)
+		require.Len(t, locations, 1)
+		assert.Equal(t, "Lekki Beach", locations[0].Name)
+
+		nearest, cerr := repo.GetNearestLocation(ctx, 6.5244, 3.3792, domain.DistanceSpheroid)
+		require.Nil(t, cerr)
+		assert.Equal(t, "Lekki Beach", nearest.Name)
+	})
+
+	t.Run("Queue oldest first", func(t *testing.T) {
+		locations, cerr := repo.ListPendingLocations(ctx, &domain.ListPendingLocationsRequest{})
+		require.Nil(t, cerr)
+		require.Len(t, locations, 2)
+		assert.Equal(t, "Lagos Park", locations[0].Name)
+		assert.Equal(t, domain.LocationPending, locations[0].Status)
+	})
+
+	t.Run("Approved locations are public", func(t *testing.T) {
+		approved, cerr := repo.ReviewLocation(ctx, "lagos-park", domain.LocationApproved, "")
+		require.Nil(t, cerr)
+		assert.Equal(t, domain.LocationApproved, approved.Status)
+
+		location, cerr := repo.GetLocationByName(ctx, "lagos-park")
+		require.Nil(t, cerr)
+		assert.Equal(t, approved.Version, location.Version)
+	})
+
+	t.Run("Rejected locations keep the reason", func(t *testing.T) {
+		rejected, cerr := repo.ReviewLocation(ctx, "Ikeja Mall", domain.LocationRejected, "Closed down")
+		require.Nil(t, cerr)
+		assert.Equal(t, domain.LocationRejected, rejected.Status)
+		assert.Equal(t, "Closed down", rejected.ReviewReason)
+
+		locations, cerr := repo.ListPendingLocations(ctx, &domain.ListPendingLocationsRequest{})
+		require.Nil(t, cerr)
+		assert.Empty(t, locations)
+	})
+
+	t.Run("Only pending locations are reviewed", func(t *testing.T) {
+		_, cerr := repo.ReviewLocation(ctx, "lekki-beach", domain.LocationRejected, "Too late")
+		require.NotNil(t, cerr)
+		assert.Equal(t, domain.ErrConflictingData.Code(), cerr.Code())
+
+		_, cerr = repo.ReviewLocation(ctx, "victoria-island", domain.LocationApproved, "")
+		require.NotNil(t, cerr)
+		assert.Equal(t, domain.ErrDataNotFound.Code(), cerr.Code())
+	})
+}
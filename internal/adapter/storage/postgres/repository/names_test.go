@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"leeta/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocationRepository_LocationNames(t *testing.T) {
+	repo, _ := newTestLocationRepository(t)
+	ctx := context.Background()
+
+	created, cerr := repo.CreateLocation(ctx, &domain.Location{
+		Name: "Lagos Park", Latitude: 6.5244, Longitude: 3.3792,
+		Names: map[string]string{"fr": "Parc de Lagos", "pt-BR": "Parque de Lagos"},
+	})
+	require.Nil(t, cerr)
+	assert.Equal(t, map[string]string{"fr": "Parc de Lagos", "pt-BR": "Parque de Lagos"}, created.Names)
+
+	_, cerr = repo.CreateLocation(ctx, &domain.Location{Name: "Ikeja", Latitude: 6.6018, Longitude: 3.3515})
+	require.Nil(t, cerr)
+
+	t.Run("Read with the location", func(t *testing.T) {
+		location, cerr := repo.GetLocationByName(ctx, "lagos-park")
+		require.Nil(t, cerr)
+		assert.Equal(t, created.Names, location.Names)
+
+		locations, cerr := repo.ListLocations(ctx, &domain.ListLocationsRequest{})
+		require.Nil(t, cerr)
+		require.Len(t, locations, 2)
+		for _, location := range locations {
+			if location.Name == "Ikeja" {
+				assert.Nil(t, location.Names, "names are not carried over from another row")
+			}
+		}
+	})
+
+	t.Run("Kept when an update leaves them out", func(t *testing.T) {
+		updated, cerr := repo.UpdateLocation(ctx, "lagos-park", &domain.Location{
+			Name: "Lagos Park", Latitude: 6.5245, Longitude: 3.3792, Version: created.Version,
+		})
+		require.Nil(t, cerr)
+		assert.Equal(t, created.Names, updated.Names)
+		created = updated
+	})
+
+	t.Run("Replaced and cleared by updates", func(t *testing.T) {
+		updated, cerr := repo.UpdateLocation(ctx, "lagos-park", &domain.Location{
+			Name: "Lagos Park", Latitude: 6.5245, Longitude: 3.3792, Version: created.Version,
+			Names: map[string]string{"yo": "Ogba Eko"},
+		})
+		require.Nil(t, cerr)
+		assert.Equal(t, map[string]string{"yo": "Ogba Eko"}, updated.Names)
+
+		updated, cerr = repo.UpdateLocation(ctx, "lagos-park", &domain.Location{
+			Name: "Lagos Park", Latitude: 6.5245, Longitude: 3.3792, Version: updated.Version,
+			Names: map[string]string{},
+		})
+		require.Nil(t, cerr)
+		assert.Nil(t, updated.Names)
+
+		location, cerr := repo.GetLocationByName(ctx, "lagos-park")
+		require.Nil(t, cerr)
+		assert.Nil(t, location.Names)
+	})
+}
@@ -2,26 +2,80 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"leeta/internal/adapter/storage/postgres"
 	"leeta/internal/core/domain"
 )
 
 /**
- * CategoryRepository implements port.CategoryRepository interface
+ * PingRepository implements port.PingRepository interface
  * and provides an access to the postgres database
  */
 type PingRepository struct {
 	db *postgres.DB
 }
 
-// NewCategoryRepository creates a new category repository instance
+// NewPingRepository creates a new ping repository instance
 func NewPingRepository(db *postgres.DB) *PingRepository {
 	return &PingRepository{
 		db,
 	}
 }
 
-func (pr *PingRepository) CreatePing(ctx context.Context, category *domain.Ping) error {
-	return nil
+func (pr *PingRepository) CreatePing(ctx context.Context, ping *domain.Ping) (*domain.Ping, domain.CError) {
+	query := `
+		INSERT INTO pings (name, source, latency_ms)
+		VALUES ($1, $2, NULLIF($3, 0))
+		RETURNING id, created_at
+	`
+
+	created := *ping
+	err := pr.db.Conn(ctx).QueryRow(ctx, query, ping.Name, ping.Source, ping.LatencyMs).Scan(&created.ID, &created.CreatedAt)
+	if err != nil {
+		return nil, pr.internalError(err)
+	}
+
+	return &created, nil
+}
+
+func (pr *PingRepository) GetPingStats(ctx context.Context, dayStart, hourStart time.Time) (*domain.PingStats, domain.CError) {
+	query := `
+		SELECT
+			COUNT(*) FILTER (WHERE created_at > $2),
+			COUNT(*),
+			COUNT(DISTINCT date_trunc('minute', created_at)),
+			COALESCE(AVG(latency_ms) FILTER (WHERE created_at > $2), 0),
+			MIN(created_at),
+			MAX(created_at)
+		FROM pings
+		WHERE created_at > $1
+	`
+
+	var stats domain.PingStats
+	err := pr.db.ReadQueryRow(ctx, query, dayStart, hourStart).Scan(
+		&stats.PingsLastHour,
+		&stats.PingsLastDay,
+		&stats.ActiveMinutes,
+		&stats.AverageLatencyMs,
+		&stats.FirstPingAt,
+		&stats.LastPingAt,
+	)
+	if err != nil {
+		return nil, pr.internalError(err)
+	}
+
+	return &stats, nil
+}
+
+// internalError converts a database error into a CError, telling timeouts and constraint violations apart.
+// The other errors are internal, with the database error as their cause
+func (pr *PingRepository) internalError(err error) domain.CError {
+	if pr.db.IsTimeout(err) {
+		return domain.ErrTimeout
+	}
+	if cerr := pr.db.ViolationError(err); cerr != nil {
+		return cerr
+	}
+	return domain.WrapCError(domain.ErrInternal, err, domain.ErrorContext{Entity: "ping"})
 }
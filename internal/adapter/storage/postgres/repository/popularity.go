@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"leeta/internal/adapter/storage/postgres"
+	"leeta/internal/core/domain"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+const (
+	// defaultTrendingLimit is the number of trending locations returned when none is set
+	defaultTrendingLimit = 10
+	// defaultTrendingRadius is the radius in meters of the trending locations near a point when none is set
+	defaultTrendingRadius = 5000
+)
+
+/**
+ * PopularityRepository implements port.PopularityRepository interface
+ * and provides an access to the postgres database
+ */
+type PopularityRepository struct {
+	db *postgres.DB
+}
+
+// NewPopularityRepository creates a new popularity repository instance
+func NewPopularityRepository(db *postgres.DB) *PopularityRepository {
+	return &PopularityRepository{
+		db,
+	}
+}
+
+func (pr *PopularityRepository) RecordLocationHits(ctx context.Context, bucket time.Time, hits map[string]int64) domain.CError {
+	ids := make([]string, 0, len(hits))
+	counts := make([]int64, 0, len(hits))
+	for id, count := range hits {
+		ids = append(ids, id)
+		counts = append(counts, count)
+	}
+
+	// joining the locations drops the hits of the locations deleted since they were counted
+	query := `
+		INSERT INTO location_hits (location_id, bucket, hits)
+		SELECT locations.id, $2, h.hits
+		FROM unnest($1::uuid[], $3::bigint[]) AS h (id, hits)
+		JOIN locations ON locations.id = h.id
+		ON CONFLICT (location_id, bucket) DO UPDATE SET hits = location_hits.hits + EXCLUDED.hits
+	`
+
+	if _, err := pr.db.Conn(ctx).Exec(ctx, query, ids, bucket, counts); err != nil {
+		return pr.internalError(err)
+	}
+
+	return nil
+}
+
+func (pr *PopularityRepository) FindTrendingLocations(ctx context.Context, since time.Time, req *domain.TrendingLocationsRequest) ([]domain.TrendingLocation, domain.CError) {
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultTrendingLimit
+	}
+
+	builder := pr.db.QueryBuilder.Select(locationColumns...).
+		Column("SUM(location_hits.hits) AS total_hits").
+		From("locations").
+		Join("location_hits ON location_hits.location_id = locations.id").
+		Where(sq.GtOrEq{"location_hits.bucket": since}).
+		Where(approved).
+		GroupBy("locations.id").
+		OrderBy("total_hits DESC", "locations.id").
+		Limit(uint64(limit))
+
+	if req.Near != nil {
+		radius := req.Radius
+		if radius == 0 {
+			radius = defaultTrendingRadius
+		}
+		builder = builder.Where(
+			sq.Expr("ST_DWithin(geo, ST_MakePoint(?, ?)::geography, ?)", req.Near.Longitude, req.Near.Latitude, radius),
+		)
+	}
+
+	if req.Category != "" {
+		builder = builder.Where(sq.Eq{"category": req.Category})
+	}
+
+	sql, args, err := builder.ToSql()
+	if err != nil {
+		return nil, pr.internalError(err)
+	}
+
+	rows, err := pr.db.ReadQuery(ctx, sql, args...)
+	if err != nil {
+		return nil, pr.internalError(err)
+	}
+	defer rows.Close()
+
+	locations := []domain.TrendingLocation{}
+	for rows.Next() {
+		var location domain.TrendingLocation
+		if err := scanLocation(rows, &location.Location, &location.Hits); err != nil {
+			return nil, pr.internalError(err)
+		}
+		locations = append(locations, location)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, pr.internalError(err)
+	}
+
+	return locations, nil
+}
+
+func (pr *PopularityRepository) DeleteLocationHits(ctx context.Context, before time.Time) (int64, domain.CError) {
+	tag, err := pr.db.Conn(ctx).Exec(ctx, "DELETE FROM location_hits WHERE bucket < $1", before)
+	if err != nil {
+		return 0, pr.internalError(err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+// internalError converts a database error into a CError, telling timeouts and constraint violations apart.
+// The other errors are internal, with the database error as their cause
+func (pr *PopularityRepository) internalError(err error) domain.CError {
+	if pr.db.IsTimeout(err) {
+		return domain.ErrTimeout
+	}
+	if cerr := pr.db.ViolationError(err); cerr != nil {
+		return cerr
+	}
+	return domain.WrapCError(domain.ErrInternal, err, domain.ErrorContext{Entity: "popularity"})
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"leeta/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPopularityRepository_FindTrendingLocations(t *testing.T) {
+	locations, db := newTestLocationRepository(t)
+	repo := NewPopularityRepository(db)
+	ctx := context.Background()
+
+	ids := make(map[string]string)
+	for _, l := range []domain.Location{
+		{Name: "Lagos Park", Latitude: 6.5244, Longitude: 3.3792, Category: "park"},
+		{Name: "Ikeja Mall", Latitude: 6.6018, Longitude: 3.3515, Category: "mall"},
+		{Name: "Abuja Park", Latitude: 9.0765, Longitude: 7.3986, Category: "park"},
+	} {
+		created, cerr := locations.CreateLocation(ctx, &l)
+		require.Nil(t, cerr)
+		ids[l.Name] = created.ID
+	}
+
+	now := time.Now().Truncate(time.Hour)
+	require.Nil(t, repo.RecordLocationHits(ctx, now.Add(-48*time.Hour), map[string]int64{ids["Ikeja Mall"]: 50}))
+	require.Nil(t, repo.RecordLocationHits(ctx, now, map[string]int64{ids["Lagos Park"]: 3, ids["Ikeja Mall"]: 2, ids["Abuja Park"]: 1}))
+	// hits add up within a bucket and the hits of deleted locations are skipped
+	require.Nil(t, repo.RecordLocationHits(ctx, now, map[string]int64{ids["Lagos Park"]: 2, "d1hkp3rcm3nc73b5v2og": 7}))
+
+	names := func(trending []domain.TrendingLocation) []string {
+		var names []string
+		for _, l := range trending {
+			names = append(names, l.Name)
+		}
+		return names
+	}
+
+	t.Run("Most hits within the window first", func(t *testing.T) {
+		trending, cerr := repo.FindTrendingLocations(ctx, now.Add(-24*time.Hour), &domain.TrendingLocationsRequest{})
+		require.Nil(t, cerr)
+		assert.Equal(t, []string{"Lagos Park", "Ikeja Mall", "Abuja Park"}, names(trending))
+		assert.Equal(t, int64(5), trending[0].Hits)
+
+		trending, cerr = repo.FindTrendingLocations(ctx, now.Add(-72*time.Hour), &domain.TrendingLocationsRequest{Limit: 1})
+		require.Nil(t, cerr)
+		assert.Equal(t, []string{"Ikeja Mall"}, names(trending))
+		assert.Equal(t, int64(52), trending[0].Hits)
+	})
+
+	t.Run("Near a point", func(t *testing.T) {
+		trending, cerr := repo.FindTrendingLocations(ctx, now.Add(-24*time.Hour), &domain.TrendingLocationsRequest{
+			Near: &domain.Point{Latitude: 6.5244, Longitude: 3.3792}, Radius: 20000,
+		})
+		require.Nil(t, cerr)
+		assert.Equal(t, []string{"Lagos Park", "Ikeja Mall"}, names(trending))
+	})
+
+	t.Run("In a category", func(t *testing.T) {
+		trending, cerr := repo.FindTrendingLocations(ctx, now.Add(-24*time.Hour), &domain.TrendingLocationsRequest{Category: "park"})
+		require.Nil(t, cerr)
+		assert.Equal(t, []string{"Lagos Park", "Abuja Park"}, names(trending))
+	})
+
+	t.Run("Old hits are deleted", func(t *testing.T) {
+		deleted, cerr := repo.DeleteLocationHits(ctx, now.Add(-24*time.Hour))
+		require.Nil(t, cerr)
+		assert.Equal(t, int64(1), deleted)
+	})
+}
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+
+	"leeta/internal/adapter/storage/postgres"
+	"leeta/internal/core/domain"
+
+	"github.com/jackc/pgx/v5"
+)
+
+/**
+ * QuotaRepository implements port.QuotaRepository interface
+ * and provides an access to the postgres database
+ */
+type QuotaRepository struct {
+	db *postgres.DB
+}
+
+// NewQuotaRepository creates a new quota repository instance
+func NewQuotaRepository(db *postgres.DB) *QuotaRepository {
+	return &QuotaRepository{
+		db,
+	}
+}
+
+func (qr *QuotaRepository) GetQuota(ctx context.Context, tenant string) (*domain.TenantQuota, domain.CError) {
+	query := "SELECT tenant, max_locations, max_webhooks, updated_at FROM tenant_quotas WHERE tenant = $1"
+
+	var quota domain.TenantQuota
+	err := qr.db.Conn(ctx).QueryRow(ctx, query, tenant).Scan(&quota.Tenant, &quota.MaxLocations, &quota.MaxWebhooks, &quota.UpdatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrDataNotFound
+		}
+		return nil, qr.internalError(err)
+	}
+
+	return &quota, nil
+}
+
+func (qr *QuotaRepository) SetQuota(ctx context.Context, quota *domain.TenantQuota) (*domain.TenantQuota, domain.CError) {
+	query := `
+		INSERT INTO tenant_quotas (tenant, max_locations, max_webhooks)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (tenant) DO UPDATE
+		SET max_locations = EXCLUDED.max_locations, max_webhooks = EXCLUDED.max_webhooks, updated_at = CURRENT_TIMESTAMP
+		RETURNING tenant, max_locations, max_webhooks, updated_at
+	`
+
+	var set domain.TenantQuota
+	err := qr.db.Conn(ctx).QueryRow(ctx, query, quota.Tenant, quota.MaxLocations, quota.MaxWebhooks).
+		Scan(&set.Tenant, &set.MaxLocations, &set.MaxWebhooks, &set.UpdatedAt)
+	if err != nil {
+		return nil, qr.internalError(err)
+	}
+
+	return &set, nil
+}
+
+func (qr *QuotaRepository) CountResources(ctx context.Context, tenant string, resource domain.QuotaResource) (int, domain.CError) {
+	query := "SELECT COUNT(*) FROM locations WHERE tenant = $1"
+	if resource == domain.QuotaWebhooks {
+		query = "SELECT COUNT(*) FROM subscriptions WHERE tenant = $1 AND webhook_url <> ''"
+	}
+
+	var count int
+	if err := qr.db.Conn(ctx).QueryRow(ctx, query, tenant).Scan(&count); err != nil {
+		return 0, qr.internalError(err)
+	}
+
+	return count, nil
+}
+
+// internalError converts a database error into a CError, telling timeouts and constraint violations apart.
+// The other errors are internal, with the database error as their cause
+func (qr *QuotaRepository) internalError(err error) domain.CError {
+	if qr.db.IsTimeout(err) {
+		return domain.ErrTimeout
+	}
+	if cerr := qr.db.ViolationError(err); cerr != nil {
+		return cerr
+	}
+	return domain.WrapCError(domain.ErrInternal, err, domain.ErrorContext{Entity: "quota"})
+}
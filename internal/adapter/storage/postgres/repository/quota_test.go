@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"leeta/internal/core/auth"
+	"leeta/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuotaRepository(t *testing.T) {
+	locations, db := newTestLocationRepository(t)
+	repo := NewQuotaRepository(db)
+	subscriptions := NewSubscriptionRepository(db)
+	ctx := context.Background()
+
+	for _, table := range []string{"tenant_quotas", "subscriptions"} {
+		_, err := db.Exec(ctx, "DELETE FROM "+table)
+		require.NoError(t, err, "Failed to cleanup test data")
+	}
+
+	acme := auth.WithPrincipal(ctx, &auth.Principal{User: "ada", Tenant: "acme"})
+
+	t.Run("Get a quota not set", func(t *testing.T) {
+		_, cerr := repo.GetQuota(ctx, "acme")
+		assert.Equal(t, domain.ErrDataNotFound, cerr)
+	})
+
+	t.Run("Set and replace a quota", func(t *testing.T) {
+		maxLocations, maxWebhooks := 10, 0
+		set, cerr := repo.SetQuota(ctx, &domain.TenantQuota{Tenant: "acme", MaxLocations: &maxLocations, MaxWebhooks: &maxWebhooks})
+		require.Nil(t, cerr)
+		assert.Equal(t, 10, *set.MaxLocations)
+		assert.Equal(t, 0, *set.MaxWebhooks)
+
+		set, cerr = repo.SetQuota(ctx, &domain.TenantQuota{Tenant: "acme", MaxLocations: &maxLocations})
+		require.Nil(t, cerr)
+		assert.Nil(t, set.MaxWebhooks)
+
+		got, cerr := repo.GetQuota(ctx, "acme")
+		require.Nil(t, cerr)
+		assert.Equal(t, set, got)
+	})
+
+	t.Run("Count the resources of a tenant", func(t *testing.T) {
+		for _, name := range []string{"Lekki Depot", "Ikeja Depot"} {
+			_, cerr := locations.CreateLocation(acme, &domain.Location{Name: name, Latitude: 6.45, Longitude: 3.47})
+			require.Nil(t, cerr)
+		}
+		_, cerr := locations.CreateLocation(ctx, &domain.Location{Name: "Yaba Depot", Latitude: 6.51, Longitude: 3.38})
+		require.Nil(t, cerr)
+
+		for _, webhook := range []string{"https://example.com/hook", ""} {
+			_, cerr := subscriptions.CreateSubscription(acme, &domain.Subscription{
+				Target: domain.SubscriptionTargetAll, Latitude: 6.45, Longitude: 3.47, Radius: 500, WebhookURL: webhook,
+			})
+			require.Nil(t, cerr)
+		}
+
+		count, cerr := repo.CountResources(ctx, "acme", domain.QuotaLocations)
+		require.Nil(t, cerr)
+		assert.Equal(t, 2, count)
+
+		// the subscriptions without a webhook are not counted
+		count, cerr = repo.CountResources(ctx, "acme", domain.QuotaWebhooks)
+		require.Nil(t, cerr)
+		assert.Equal(t, 1, count)
+
+		count, cerr = repo.CountResources(ctx, "globex", domain.QuotaLocations)
+		require.Nil(t, cerr)
+		assert.Zero(t, count)
+	})
+}
@@ -0,0 +1,200 @@
+package repository
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"leeta/internal/adapter/storage/postgres"
+	"leeta/internal/core/domain"
+
+	"github.com/jackc/pgx/v5"
+)
+
+/**
+ * RestoreRepository implements port.RestoreRepository interface
+ * and provides an access to the postgres database
+ */
+type RestoreRepository struct {
+	db        *postgres.DB
+	locations *LocationRepository
+}
+
+// NewRestoreRepository creates a new restore repository instance
+func NewRestoreRepository(db *postgres.DB) *RestoreRepository {
+	return &RestoreRepository{
+		db,
+		NewLocationRepository(db),
+	}
+}
+
+// RestoreLocations deletes before it updates and inserts, so that the names freed by the deleted locations
+// can be taken by the others
+func (rr *RestoreRepository) RestoreLocations(ctx context.Context, plan *domain.RestorePlan) domain.CError {
+	deleteQuery := `
+		DELETE FROM locations
+		WHERE id = $1 AND status = 'approved'
+		RETURNING ` + strings.Join(locationColumns, ", ")
+
+	updateQuery := `
+		UPDATE locations
+		SET name = $2, slug = $3, latitude = $4, longitude = $5, geo = ST_MakePoint($5, $4)::geography,
+			category = NULLIF($6, ''), altitude = $7, footprint = ST_Force2D(ST_GeomFromGeoJSON($8::text))::geography,
+			valid_from = $9, valid_until = $10, expired = FALSE, version = version + 1, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND status = 'approved'
+		RETURNING ` + strings.Join(locationColumns, ", ")
+
+	createQuery := `
+		INSERT INTO locations (id, name, slug, latitude, longitude, geo, category, status, altitude, footprint, valid_from, valid_until, source, created_at)
+		VALUES ($1, $2, $3, $4, $5, ST_MakePoint($5, $4)::geography, NULLIF($6, ''), 'approved', $7,
+			ST_Force2D(ST_GeomFromGeoJSON($8::text))::geography, $9, $10, NULLIF($11, ''), COALESCE($12, CURRENT_TIMESTAMP))
+		RETURNING ` + strings.Join(locationColumns, ", ")
+
+	err := rr.db.WithinTx(ctx, func(ctx context.Context) error {
+		conn := rr.db.Conn(ctx)
+
+		var location domain.Location
+		for _, deleted := range plan.Delete {
+			if err := scanLocation(conn.QueryRow(ctx, deleteQuery, deleted.ID), &location); err != nil {
+				return err
+			}
+			if err := writeEvent(ctx, rr.db, domain.EventLocationDeleted, &location); err != nil {
+				return err
+			}
+		}
+
+		// restore writes the names, entrances and event of the location row returns, restored as r
+		restore := func(r *domain.Location, row pgx.Row, event domain.EventType) error {
+			if err := scanLocation(row, &location); err != nil {
+				return err
+			}
+			if err := rr.locations.replaceNames(ctx, &location, r.Names); err != nil {
+				return err
+			}
+			if err := rr.locations.replaceEntrances(ctx, &location, r.Entrances); err != nil {
+				return err
+			}
+			return writeEvent(ctx, rr.db, event, &location)
+		}
+
+		for i := range plan.Update {
+			r := &plan.Update[i]
+			footprint, err := footprintJSON(r.Footprint)
+			if err != nil {
+				return err
+			}
+
+			row := conn.QueryRow(ctx, updateQuery, r.ID, r.Name, rr.slug(r), r.Latitude, r.Longitude, r.Category,
+				r.Altitude, footprint, r.ValidFrom, r.ValidUntil)
+			if err := restore(r, row, domain.EventLocationUpdated); err != nil {
+				return err
+			}
+		}
+
+		for i := range plan.Create {
+			r := &plan.Create[i]
+			footprint, err := footprintJSON(r.Footprint)
+			if err != nil {
+				return err
+			}
+
+			var createdAt *time.Time
+			if !r.CreatedAt.IsZero() {
+				createdAt = &r.CreatedAt
+			}
+			row := conn.QueryRow(ctx, createQuery, r.ID, r.Name, rr.slug(r), r.Latitude, r.Longitude, r.Category,
+				r.Altitude, footprint, r.ValidFrom, r.ValidUntil, r.Source, createdAt)
+			if err := restore(r, row, domain.EventLocationCreated); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		// a location the plan deletes or updates is gone or no longer approved
+		if err == pgx.ErrNoRows {
+			return domain.ErrConflictingData
+		}
+
+		switch rr.db.ErrorCode(err) {
+		// 23505 is the error code for a unique conflict error
+		case "23505":
+			return domain.ErrConflictingData
+		// 23514 is the error code of a check violation, the footprint is not a valid polygon
+		case "23514":
+			return errInvalidFootprint
+		}
+
+		return rr.internalError(err)
+	}
+
+	return nil
+}
+
+func (rr *RestoreRepository) LoadShadowLocations(ctx context.Context, key string, locations []domain.Location) domain.CError {
+	err := rr.db.WithinTx(ctx, func(ctx context.Context) error {
+		conn := rr.db.Conn(ctx)
+
+		if _, err := conn.Exec(ctx, "TRUNCATE locations_shadow"); err != nil {
+			return err
+		}
+
+		_, err := conn.CopyFrom(
+			ctx,
+			pgx.Identifier{"locations_shadow"},
+			[]string{
+				"id", "name", "slug", "latitude", "longitude", "category", "altitude", "names", "entrances", "footprint",
+				"source", "valid_from", "valid_until", "created_at", "updated_at", "snapshot",
+			},
+			pgx.CopyFromSlice(len(locations), func(i int) ([]any, error) {
+				l := locations[i]
+				nullable := func(value any, set bool) any {
+					if !set {
+						return nil
+					}
+					return value
+				}
+				return []any{
+					l.ID, l.Name, rr.slug(&l), l.Latitude, l.Longitude, nullable(l.Category, l.Category != ""), l.Altitude,
+					nullable(l.Names, len(l.Names) > 0), nullable(l.Entrances, len(l.Entrances) > 0), nullable(l.Footprint, l.Footprint != nil),
+					nullable(l.Source, l.Source != ""), l.ValidFrom, l.ValidUntil,
+					nullable(l.CreatedAt, !l.CreatedAt.IsZero()), nullable(l.UpdatedAt, !l.UpdatedAt.IsZero()), key,
+				}, nil
+			}),
+		)
+		return err
+	})
+
+	if err != nil {
+		// 23505 is the error code for a unique conflict error, the snapshot lists an id twice
+		if rr.db.ErrorCode(err) == "23505" {
+			return domain.ErrConflictingData
+		}
+		return rr.internalError(err)
+	}
+
+	return nil
+}
+
+// slug returns the slug of a restored location, the one it was backed up with if any, since it may be an
+// alternative to the slug of its name
+func (rr *RestoreRepository) slug(location *domain.Location) string {
+	if location.Slug != "" {
+		return location.Slug
+	}
+	return rr.db.Slug(location.Name)
+}
+
+// internalError converts a database error into a CError, telling timeouts and constraint violations apart.
+// The other errors are internal, with the database error as their cause
+func (rr *RestoreRepository) internalError(err error) domain.CError {
+	if rr.db.IsTimeout(err) {
+		return domain.ErrTimeout
+	}
+	if cerr := rr.db.ViolationError(err); cerr != nil {
+		return cerr
+	}
+	return domain.WrapCError(domain.ErrInternal, err, domain.ErrorContext{Entity: "location"})
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"leeta/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRestoreRepository_RestoreLocations(t *testing.T) {
+	locations, db := newTestLocationRepository(t)
+	repo := NewRestoreRepository(db)
+	ctx := context.Background()
+
+	var stored []*domain.Location
+	for _, l := range []domain.Location{
+		{Name: "Lekki Depot", Latitude: 6.4474, Longitude: 3.4723},
+		{Name: "Ikeja Depot", Latitude: 6.6018, Longitude: 3.3515},
+	} {
+		created, cerr := locations.CreateLocation(ctx, &l)
+		require.Nil(t, cerr)
+		stored = append(stored, created)
+	}
+
+	moved := *stored[1]
+	moved.Latitude = 6.6020
+	moved.Names = map[string]string{"fr": "Dépôt d'Ikeja"}
+
+	cerr := repo.RestoreLocations(ctx, &domain.RestorePlan{
+		Delete: []domain.Location{*stored[0]},
+		Update: []domain.Location{moved},
+		Create: []domain.Location{{ID: "5d7d4c43-6e0c-4a7f-9b7a-2f7f6b1d2c3e", Name: "Lekki Depot", Latitude: 6.4475, Longitude: 3.4723}},
+	})
+	require.Nil(t, cerr)
+
+	t.Run("Deleted locations free their name", func(t *testing.T) {
+		restored, cerr := locations.GetLocationByName(ctx, "lekki-depot")
+		require.Nil(t, cerr)
+		assert.Equal(t, "5d7d4c43-6e0c-4a7f-9b7a-2f7f6b1d2c3e", restored.ID)
+	})
+
+	t.Run("Updated locations keep their id", func(t *testing.T) {
+		updated, cerr := locations.GetLocationByID(ctx, stored[1].ID)
+		require.Nil(t, cerr)
+		assert.Equal(t, 6.6020, updated.Latitude)
+		assert.Equal(t, map[string]string{"fr": "Dépôt d'Ikeja"}, updated.Names)
+		assert.Equal(t, stored[1].Version+1, updated.Version)
+	})
+
+	t.Run("A location gone since the plan fails the restore", func(t *testing.T) {
+		cerr := repo.RestoreLocations(ctx, &domain.RestorePlan{Delete: []domain.Location{*stored[0]}})
+		require.NotNil(t, cerr)
+		assert.Equal(t, domain.ErrConflictingData.Code(), cerr.Code())
+	})
+}
+
+func TestRestoreRepository_LoadShadowLocations(t *testing.T) {
+	_, db := newTestLocationRepository(t)
+	repo := NewRestoreRepository(db)
+	ctx := context.Background()
+
+	for _, key := range []string{"exports/locations-20261015T000000Z.csv", "exports/locations-20261016T000000Z.csv"} {
+		cerr := repo.LoadShadowLocations(ctx, key, []domain.Location{
+			{ID: "5d7d4c43-6e0c-4a7f-9b7a-2f7f6b1d2c3e", Name: "Lekki Depot", Latitude: 6.4474, Longitude: 3.4723},
+		})
+		require.Nil(t, cerr)
+	}
+
+	// each load replaces the previous one
+	var count int
+	var snapshot string
+	require.NoError(t, db.QueryRow(ctx, "SELECT COUNT(*), MAX(snapshot) FROM locations_shadow").Scan(&count, &snapshot))
+	assert.Equal(t, 1, count)
+	assert.Equal(t, "exports/locations-20261016T000000Z.csv", snapshot)
+}
@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"context"
+
+	"leeta/internal/adapter/storage/postgres"
+	"leeta/internal/core/domain"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// shareColumns are the columns selected whenever a full share row is read
+const shareColumns = "id, location_id, tenant, created_by, expires_at, revoked_at, created_at"
+
+// scanShare scans a row selected with shareColumns into a share
+func scanShare(row pgx.Row, share *domain.LocationShare) error {
+	return row.Scan(
+		&share.ID,
+		&share.LocationID,
+		&share.Tenant,
+		&share.CreatedBy,
+		&share.ExpiresAt,
+		&share.RevokedAt,
+		&share.CreatedAt,
+	)
+}
+
+/**
+ * ShareRepository implements port.ShareRepository interface
+ * and provides an access to the postgres database
+ */
+type ShareRepository struct {
+	db *postgres.DB
+}
+
+// NewShareRepository creates a new share repository instance
+func NewShareRepository(db *postgres.DB) *ShareRepository {
+	return &ShareRepository{
+		db,
+	}
+}
+
+func (sr *ShareRepository) CreateShare(ctx context.Context, share *domain.LocationShare) (*domain.LocationShare, domain.CError) {
+	query := `
+		INSERT INTO location_shares (location_id, created_by, expires_at)
+		VALUES ($1, $2, $3)
+		RETURNING ` + shareColumns
+
+	var created domain.LocationShare
+	if err := scanShare(sr.db.Conn(ctx).QueryRow(ctx, query, share.LocationID, share.CreatedBy, share.ExpiresAt), &created); err != nil {
+		return nil, sr.internalError(err)
+	}
+
+	return &created, nil
+}
+
+func (sr *ShareRepository) GetShare(ctx context.Context, id string) (*domain.LocationShare, domain.CError) {
+	query := "SELECT " + shareColumns + " FROM location_shares WHERE id = $1"
+
+	var share domain.LocationShare
+	if err := scanShare(sr.db.Conn(ctx).QueryRow(ctx, query, id), &share); err != nil {
+		// 22P02 is the error code for an invalid text representation, no share has an id that is not a UUID
+		if err == pgx.ErrNoRows || sr.db.ErrorCode(err) == "22P02" {
+			return nil, domain.ErrDataNotFound
+		}
+		return nil, sr.internalError(err)
+	}
+
+	return &share, nil
+}
+
+func (sr *ShareRepository) ListShares(ctx context.Context, locationID string) ([]domain.LocationShare, domain.CError) {
+	query := `
+		SELECT ` + shareColumns + `
+		FROM location_shares
+		WHERE location_id = $1 AND revoked_at IS NULL AND expires_at > CURRENT_TIMESTAMP
+		ORDER BY created_at DESC, id
+	`
+
+	rows, err := sr.db.Conn(ctx).Query(ctx, query, locationID)
+	if err != nil {
+		return nil, sr.internalError(err)
+	}
+	defer rows.Close()
+
+	shares := []domain.LocationShare{}
+	for rows.Next() {
+		var share domain.LocationShare
+		if err := scanShare(rows, &share); err != nil {
+			return nil, sr.internalError(err)
+		}
+		shares = append(shares, share)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, sr.internalError(err)
+	}
+
+	return shares, nil
+}
+
+func (sr *ShareRepository) RevokeShare(ctx context.Context, locationID, id string) domain.CError {
+	query := `
+		UPDATE location_shares SET revoked_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND location_id = $2 AND revoked_at IS NULL
+	`
+
+	tag, err := sr.db.Conn(ctx).Exec(ctx, query, id, locationID)
+	if err != nil {
+		if sr.db.ErrorCode(err) == "22P02" {
+			return domain.ErrDataNotFound
+		}
+		return sr.internalError(err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrDataNotFound
+	}
+
+	return nil
+}
+
+// internalError converts a database error into a CError, telling timeouts and constraint violations apart.
+// The other errors are internal, with the database error as their cause
+func (sr *ShareRepository) internalError(err error) domain.CError {
+	if sr.db.IsTimeout(err) {
+		return domain.ErrTimeout
+	}
+	if cerr := sr.db.ViolationError(err); cerr != nil {
+		return cerr
+	}
+	return domain.WrapCError(domain.ErrInternal, err, domain.ErrorContext{Entity: "share"})
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"leeta/internal/core/auth"
+	"leeta/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShareRepository(t *testing.T) {
+	locations, db := newTestLocationRepository(t)
+	repo := NewShareRepository(db)
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{User: "ada", Tenant: "acme"})
+
+	location, cerr := locations.CreateLocation(ctx, &domain.Location{Name: "Lekki Depot", Latitude: 6.45, Longitude: 3.47})
+	require.Nil(t, cerr)
+
+	expiresAt := time.Now().Add(time.Hour).Truncate(time.Second)
+	share, cerr := repo.CreateShare(ctx, &domain.LocationShare{LocationID: location.ID, CreatedBy: "ada", ExpiresAt: expiresAt})
+	require.Nil(t, cerr)
+	assert.Equal(t, "acme", share.Tenant)
+	assert.True(t, expiresAt.Equal(share.ExpiresAt))
+
+	_, cerr = repo.CreateShare(ctx, &domain.LocationShare{LocationID: location.ID, ExpiresAt: time.Now().Add(-time.Minute)})
+	require.Nil(t, cerr)
+
+	t.Run("Get a share", func(t *testing.T) {
+		got, cerr := repo.GetShare(context.Background(), share.ID)
+		require.Nil(t, cerr)
+		assert.Equal(t, location.ID, got.LocationID)
+
+		_, cerr = repo.GetShare(context.Background(), "not-a-uuid")
+		assert.Equal(t, domain.ErrDataNotFound, cerr)
+	})
+
+	t.Run("List the active shares", func(t *testing.T) {
+		shares, cerr := repo.ListShares(ctx, location.ID)
+		require.Nil(t, cerr)
+		require.Len(t, shares, 1)
+		assert.Equal(t, share.ID, shares[0].ID)
+	})
+
+	t.Run("Revoke a share once", func(t *testing.T) {
+		require.Nil(t, repo.RevokeShare(ctx, location.ID, share.ID))
+		assert.Equal(t, domain.ErrDataNotFound, repo.RevokeShare(ctx, location.ID, share.ID))
+
+		got, cerr := repo.GetShare(ctx, share.ID)
+		require.Nil(t, cerr)
+		assert.NotNil(t, got.RevokedAt)
+
+		shares, cerr := repo.ListShares(ctx, location.ID)
+		require.Nil(t, cerr)
+		assert.Empty(t, shares)
+	})
+}
@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"context"
+	"strings"
+
+	"leeta/internal/adapter/storage/postgres"
+	"leeta/internal/core/domain"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+// subscriptionColumns are the columns selected whenever a full subscription row is read
+var subscriptionColumns = []string{"id", "target", "kind", "latitude", "longitude", "radius", "tenant", "webhook_url", "created_at"}
+
+// scanSubscription scans a row selected with subscriptionColumns into a subscription
+func scanSubscription(row pgx.Row, subscription *domain.Subscription) error {
+	return row.Scan(
+		&subscription.ID,
+		&subscription.Target,
+		&subscription.Kind,
+		&subscription.Latitude,
+		&subscription.Longitude,
+		&subscription.Radius,
+		&subscription.Tenant,
+		&subscription.WebhookURL,
+		&subscription.CreatedAt,
+	)
+}
+
+/**
+ * SubscriptionRepository implements port.SubscriptionRepository interface
+ * and provides an access to the postgres database
+ */
+type SubscriptionRepository struct {
+	db *postgres.DB
+}
+
+// NewSubscriptionRepository creates a new subscription repository instance
+func NewSubscriptionRepository(db *postgres.DB) *SubscriptionRepository {
+	return &SubscriptionRepository{
+		db,
+	}
+}
+
+func (sr *SubscriptionRepository) CreateSubscription(ctx context.Context, subscription *domain.Subscription) (*domain.Subscription, domain.CError) {
+	sql, args, err := sr.db.QueryBuilder.Insert("subscriptions").
+		Columns("target", "kind", "latitude", "longitude", "center", "radius", "tenant", "webhook_url").
+		Values(
+			subscription.Target,
+			subscription.Kind,
+			subscription.Latitude,
+			subscription.Longitude,
+			sq.Expr("ST_MakePoint(?, ?)::geography", subscription.Longitude, subscription.Latitude),
+			subscription.Radius,
+			subscription.Tenant,
+			subscription.WebhookURL,
+		).
+		Suffix("RETURNING " + strings.Join(subscriptionColumns, ", ")).
+		ToSql()
+	if err != nil {
+		return nil, sr.internalError(err)
+	}
+
+	var created domain.Subscription
+	if err := scanSubscription(sr.db.Conn(ctx).QueryRow(ctx, sql, args...), &created); err != nil {
+		return nil, sr.internalError(err)
+	}
+
+	return &created, nil
+}
+
+func (sr *SubscriptionRepository) GetSubscription(ctx context.Context, id string) (*domain.Subscription, domain.CError) {
+	sql, args, err := sr.db.QueryBuilder.Select(subscriptionColumns...).
+		From("subscriptions").
+		Where(sq.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return nil, sr.internalError(err)
+	}
+
+	var subscription domain.Subscription
+	if err := scanSubscription(sr.db.ReadQueryRow(ctx, sql, args...), &subscription); err != nil {
+		if err == pgx.ErrNoRows || sr.invalidID(err) {
+			return nil, domain.ErrDataNotFound
+		}
+		return nil, sr.internalError(err)
+	}
+
+	return &subscription, nil
+}
+
+func (sr *SubscriptionRepository) DeleteSubscription(ctx context.Context, id string) domain.CError {
+	sql, args, err := sr.db.QueryBuilder.Delete("subscriptions").
+		Where(sq.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return sr.internalError(err)
+	}
+
+	tag, err := sr.db.Conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		if sr.invalidID(err) {
+			return domain.ErrDataNotFound
+		}
+		return sr.internalError(err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrDataNotFound
+	}
+
+	return nil
+}
+
+func (sr *SubscriptionRepository) MatchSubscriptions(ctx context.Context, tenant string, target domain.SubscriptionTarget, kind string, point domain.Point, previous *domain.Point) ([]domain.Subscription, domain.CError) {
+	builder := sr.db.QueryBuilder.Select(subscriptionColumns...).
+		From("subscriptions").
+		Where(sq.Eq{"tenant": tenant}).
+		Where(sq.Eq{"target": []domain.SubscriptionTarget{target, domain.SubscriptionTargetAll}}).
+		Where(sq.Eq{"kind": []string{"", kind}}).
+		Where(sq.Expr("ST_DWithin(center, ST_MakePoint(?, ?)::geography, radius)", point.Longitude, point.Latitude))
+
+	if previous != nil {
+		builder = builder.Where(
+			sq.Expr("NOT ST_DWithin(center, ST_MakePoint(?, ?)::geography, radius)", previous.Longitude, previous.Latitude),
+		)
+	}
+
+	sql, args, err := builder.ToSql()
+	if err != nil {
+		return nil, sr.internalError(err)
+	}
+
+	rows, err := sr.db.ReadQuery(ctx, sql, args...)
+	if err != nil {
+		return nil, sr.internalError(err)
+	}
+	defer rows.Close()
+
+	subscriptions := []domain.Subscription{}
+	for rows.Next() {
+		var subscription domain.Subscription
+		if err := scanSubscription(rows, &subscription); err != nil {
+			return nil, sr.internalError(err)
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, sr.internalError(err)
+	}
+
+	return subscriptions, nil
+}
+
+// invalidID reports whether err is caused by an id that is not a UUID, no subscription has it
+func (sr *SubscriptionRepository) invalidID(err error) bool {
+	// 22P02 is the error code for an invalid text representation
+	return sr.db.ErrorCode(err) == "22P02"
+}
+
+// internalError converts a database error into a CError, telling timeouts and constraint violations apart.
+// The other errors are internal, with the database error as their cause
+func (sr *SubscriptionRepository) internalError(err error) domain.CError {
+	if sr.db.IsTimeout(err) {
+		return domain.ErrTimeout
+	}
+	if cerr := sr.db.ViolationError(err); cerr != nil {
+		return cerr
+	}
+	return domain.WrapCError(domain.ErrInternal, err, domain.ErrorContext{Entity: "subscription"})
+}
@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"leeta/internal/adapter/storage/postgres/postgrestest"
+	"leeta/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubscriptionRepository_MatchSubscriptions(t *testing.T) {
+	db := postgrestest.DB(t)
+	repo := NewSubscriptionRepository(db)
+	ctx := context.Background()
+
+	_, err := db.Exec(ctx, "DELETE FROM subscriptions")
+	require.NoError(t, err, "Failed to cleanup test data")
+
+	ids := map[string]string{}
+	for name, s := range map[string]domain.Subscription{
+		"couriers": {Target: domain.SubscriptionTargetEntities, Kind: "courier", Latitude: 6.5244, Longitude: 3.3792, Radius: 500},
+		"anything": {Target: domain.SubscriptionTargetAll, Latitude: 6.5244, Longitude: 3.3792, Radius: 2000},
+		"malls":    {Target: domain.SubscriptionTargetLocations, Kind: "mall", Latitude: 6.5244, Longitude: 3.3792, Radius: 500},
+		"ikeja":    {Target: domain.SubscriptionTargetEntities, Latitude: 6.6018, Longitude: 3.3515, Radius: 500},
+		"acme":     {Target: domain.SubscriptionTargetAll, Latitude: 6.5244, Longitude: 3.3792, Radius: 2000, Tenant: "acme"},
+	} {
+		created, cerr := repo.CreateSubscription(ctx, &s)
+		require.Nil(t, cerr)
+		ids[name] = created.ID
+	}
+
+	matched := func(subscriptions []domain.Subscription) []string {
+		var matched []string
+		for _, s := range subscriptions {
+			matched = append(matched, s.ID)
+		}
+		return matched
+	}
+	near := domain.Point{Latitude: 6.5250, Longitude: 3.3790}
+
+	t.Run("Of the target and kind covering the point", func(t *testing.T) {
+		subscriptions, cerr := repo.MatchSubscriptions(ctx, "", domain.SubscriptionTargetEntities, "courier", near, nil)
+		require.Nil(t, cerr)
+		assert.ElementsMatch(t, []string{ids["couriers"], ids["anything"]}, matched(subscriptions))
+
+		subscriptions, cerr = repo.MatchSubscriptions(ctx, "", domain.SubscriptionTargetLocations, "mall", near, nil)
+		require.Nil(t, cerr)
+		assert.ElementsMatch(t, []string{ids["malls"], ids["anything"]}, matched(subscriptions))
+	})
+
+	t.Run("Of the tenant", func(t *testing.T) {
+		subscriptions, cerr := repo.MatchSubscriptions(ctx, "acme", domain.SubscriptionTargetEntities, "courier", near, nil)
+		require.Nil(t, cerr)
+		assert.Equal(t, []string{ids["acme"]}, matched(subscriptions))
+		assert.Equal(t, "acme", subscriptions[0].Tenant)
+	})
+
+	t.Run("Not covering the previous point", func(t *testing.T) {
+		// 1km away, within the larger area only
+		previous := domain.Point{Latitude: 6.5334, Longitude: 3.3792}
+
+		subscriptions, cerr := repo.MatchSubscriptions(ctx, "", domain.SubscriptionTargetEntities, "courier", near, &previous)
+		require.Nil(t, cerr)
+		assert.Equal(t, []string{ids["couriers"]}, matched(subscriptions))
+	})
+
+	t.Run("Get and delete", func(t *testing.T) {
+		subscription, cerr := repo.GetSubscription(ctx, ids["malls"])
+		require.Nil(t, cerr)
+		assert.Equal(t, "mall", subscription.Kind)
+
+		require.Nil(t, repo.DeleteSubscription(ctx, ids["malls"]))
+		assert.Equal(t, domain.ErrDataNotFound, repo.DeleteSubscription(ctx, ids["malls"]))
+		_, cerr = repo.GetSubscription(ctx, "not-a-uuid")
+		assert.Equal(t, domain.ErrDataNotFound, cerr)
+	})
+}
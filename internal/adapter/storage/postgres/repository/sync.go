@@ -0,0 +1,249 @@
+package repository
+
+import (
+	"context"
+	"strings"
+
+	"leeta/internal/adapter/storage/postgres"
+	"leeta/internal/core/domain"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+// syncReportColumns are the columns selected whenever a full sync report row is read
+var syncReportColumns = []string{
+	"id", "source", "dry_run", "status", "total", "created", "updated", "removed", "restored", "unchanged", "failed",
+	"changes", "COALESCE(error, '')", "started_at", "finished_at",
+}
+
+// scanSyncReport scans a row selected with syncReportColumns into a sync report
+func scanSyncReport(row pgx.Row, report *domain.SyncReport) error {
+	// the changes would be decoded into those of the previous row otherwise
+	report.Changes = nil
+
+	return row.Scan(
+		&report.ID,
+		&report.Source,
+		&report.DryRun,
+		&report.Status,
+		&report.Total,
+		&report.Created,
+		&report.Updated,
+		&report.Removed,
+		&report.Restored,
+		&report.Unchanged,
+		&report.Failed,
+		&report.Changes,
+		&report.Error,
+		&report.StartedAt,
+		&report.FinishedAt,
+	)
+}
+
+/**
+ * SyncRepository implements port.SyncRepository interface
+ * and provides an access to the postgres database
+ */
+type SyncRepository struct {
+	db *postgres.DB
+}
+
+// NewSyncRepository creates a new sync repository instance
+func NewSyncRepository(db *postgres.DB) *SyncRepository {
+	return &SyncRepository{
+		db,
+	}
+}
+
+// ListSourceLocations reads from the primary, a sync writes right after and must not miss the latest changes
+func (sr *SyncRepository) ListSourceLocations(ctx context.Context, source string) ([]domain.Location, domain.CError) {
+	sql, args, err := sr.db.QueryBuilder.Select(locationColumns...).
+		From("locations").
+		Where(sq.Eq{"source": source}).
+		Where(sq.Eq{"status": []domain.LocationStatus{domain.LocationApproved, domain.LocationRemoved}}).
+		OrderBy("created_at", "id").
+		ToSql()
+	if err != nil {
+		return nil, sr.internalError(err)
+	}
+
+	rows, err := sr.db.Conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, sr.internalError(err)
+	}
+	defer rows.Close()
+
+	var location domain.Location
+	locations := []domain.Location{}
+	for rows.Next() {
+		if err := scanLocation(rows, &location); err != nil {
+			return nil, sr.internalError(err)
+		}
+		locations = append(locations, location)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, sr.internalError(err)
+	}
+
+	return locations, nil
+}
+
+func (sr *SyncRepository) SetLocationStatus(ctx context.Context, id string, from, to domain.LocationStatus) (*domain.Location, domain.CError) {
+	var location domain.Location
+
+	query := `
+		UPDATE locations
+		SET status = $1, version = version + 1, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $2 AND status = $3
+		RETURNING ` + strings.Join(locationColumns, ", ")
+
+	err := sr.db.WithinTx(ctx, func(ctx context.Context) error {
+		if err := scanLocation(sr.db.Conn(ctx).QueryRow(ctx, query, to, id, from), &location); err != nil {
+			return err
+		}
+
+		switch {
+		case to == domain.LocationApproved:
+			return writeEvent(ctx, sr.db, domain.EventLocationCreated, &location)
+		case from == domain.LocationApproved:
+			return writeEvent(ctx, sr.db, domain.EventLocationDeleted, &location)
+		}
+		return nil
+	})
+
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrConflictingData
+		}
+		return nil, sr.internalError(err)
+	}
+
+	return &location, nil
+}
+
+func (sr *SyncRepository) CreateSyncReport(ctx context.Context, report *domain.SyncReport) (*domain.SyncReport, domain.CError) {
+	sql, args, err := sr.db.QueryBuilder.Insert("location_syncs").
+		Columns("source", "dry_run", "status").
+		Values(report.Source, report.DryRun, report.Status).
+		Suffix("RETURNING " + strings.Join(syncReportColumns, ", ")).
+		ToSql()
+	if err != nil {
+		return nil, sr.internalError(err)
+	}
+
+	var created domain.SyncReport
+	if err := scanSyncReport(sr.db.Conn(ctx).QueryRow(ctx, sql, args...), &created); err != nil {
+		return nil, sr.internalError(err)
+	}
+
+	return &created, nil
+}
+
+// FinishSyncReport also sets the time the sync finished on report
+func (sr *SyncRepository) FinishSyncReport(ctx context.Context, report *domain.SyncReport) domain.CError {
+	sql, args, err := sr.db.QueryBuilder.Update("location_syncs").
+		SetMap(map[string]any{
+			"status":      report.Status,
+			"total":       report.Total,
+			"created":     report.Created,
+			"updated":     report.Updated,
+			"removed":     report.Removed,
+			"restored":    report.Restored,
+			"unchanged":   report.Unchanged,
+			"failed":      report.Failed,
+			"changes":     report.Changes,
+			"error":       sq.Expr("NULLIF(?, '')", report.Error),
+			"finished_at": sq.Expr("CURRENT_TIMESTAMP"),
+		}).
+		Where(sq.Eq{"id": report.ID}).
+		Suffix("RETURNING finished_at").
+		ToSql()
+	if err != nil {
+		return sr.internalError(err)
+	}
+
+	if err := sr.db.Conn(ctx).QueryRow(ctx, sql, args...).Scan(&report.FinishedAt); err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.ErrDataNotFound
+		}
+		return sr.internalError(err)
+	}
+
+	return nil
+}
+
+func (sr *SyncRepository) GetSyncReport(ctx context.Context, id string) (*domain.SyncReport, domain.CError) {
+	sql, args, err := sr.db.QueryBuilder.Select(syncReportColumns...).
+		From("location_syncs").
+		Where(sq.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return nil, sr.internalError(err)
+	}
+
+	var report domain.SyncReport
+	if err := scanSyncReport(sr.db.ReadQueryRow(ctx, sql, args...), &report); err != nil {
+		if err == pgx.ErrNoRows || sr.invalidID(err) {
+			return nil, domain.ErrDataNotFound
+		}
+		return nil, sr.internalError(err)
+	}
+
+	return &report, nil
+}
+
+func (sr *SyncRepository) ListSyncReports(ctx context.Context, req *domain.ListSyncReportsRequest) ([]domain.SyncReport, domain.CError) {
+	builder := sr.db.QueryBuilder.Select(syncReportColumns...).
+		From("location_syncs").
+		OrderBy("started_at DESC", "id").
+		Limit(uint64(req.Limit))
+	if req.Source != "" {
+		builder = builder.Where(sq.Eq{"source": req.Source})
+	}
+
+	sql, args, err := builder.ToSql()
+	if err != nil {
+		return nil, sr.internalError(err)
+	}
+
+	rows, err := sr.db.ReadQuery(ctx, sql, args...)
+	if err != nil {
+		return nil, sr.internalError(err)
+	}
+	defer rows.Close()
+
+	var report domain.SyncReport
+	reports := []domain.SyncReport{}
+	for rows.Next() {
+		if err := scanSyncReport(rows, &report); err != nil {
+			return nil, sr.internalError(err)
+		}
+		reports = append(reports, report)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, sr.internalError(err)
+	}
+
+	return reports, nil
+}
+
+// invalidID reports whether err is caused by an id that is not a UUID, no sync report has it
+func (sr *SyncRepository) invalidID(err error) bool {
+	// 22P02 is the error code for an invalid text representation
+	return sr.db.ErrorCode(err) == "22P02"
+}
+
+// internalError converts a database error into a CError, telling timeouts and constraint violations apart.
+// The other errors are internal, with the database error as their cause
+func (sr *SyncRepository) internalError(err error) domain.CError {
+	if sr.db.IsTimeout(err) {
+		return domain.ErrTimeout
+	}
+	if cerr := sr.db.ViolationError(err); cerr != nil {
+		return cerr
+	}
+	return domain.WrapCError(domain.ErrInternal, err, domain.ErrorContext{Entity: "sync source"})
+}
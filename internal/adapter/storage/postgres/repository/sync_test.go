@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"leeta/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncRepository_SetLocationStatus(t *testing.T) {
+	locations, db := newTestLocationRepository(t)
+	repo := NewSyncRepository(db)
+	ctx := context.Background()
+
+	_, err := db.Exec(ctx, "DELETE FROM location_syncs")
+	require.NoError(t, err)
+
+	for _, l := range []domain.Location{
+		{Name: "Lekki Depot", Latitude: 6.4474, Longitude: 3.4723, Source: "erp"},
+		{Name: "Ikeja Depot", Latitude: 6.6018, Longitude: 3.3515, Source: "erp"},
+		{Name: "Lagos Park", Latitude: 6.5244, Longitude: 3.3792},
+	} {
+		_, cerr := locations.CreateLocation(ctx, &l)
+		require.Nil(t, cerr)
+	}
+
+	synced, cerr := repo.ListSourceLocations(ctx, "erp")
+	require.Nil(t, cerr)
+	require.Len(t, synced, 2)
+	assert.Equal(t, "erp", synced[0].Source)
+
+	t.Run("Removed locations are not public", func(t *testing.T) {
+		removed, cerr := repo.SetLocationStatus(ctx, synced[0].ID, domain.LocationApproved, domain.LocationRemoved)
+		require.Nil(t, cerr)
+		assert.Equal(t, domain.LocationRemoved, removed.Status)
+		assert.Equal(t, synced[0].Version+1, removed.Version)
+
+		_, cerr = locations.GetLocationByName(ctx, "lekki-depot")
+		require.NotNil(t, cerr)
+		assert.Equal(t, domain.ErrDataNotFound.Code(), cerr.Code())
+
+		// the removed locations are still listed, so they can be restored
+		listed, cerr := repo.ListSourceLocations(ctx, "erp")
+		require.Nil(t, cerr)
+		assert.Len(t, listed, 2)
+	})
+
+	t.Run("Status must be the expected one", func(t *testing.T) {
+		_, cerr := repo.SetLocationStatus(ctx, synced[0].ID, domain.LocationApproved, domain.LocationRemoved)
+		require.NotNil(t, cerr)
+		assert.Equal(t, domain.ErrConflictingData.Code(), cerr.Code())
+	})
+
+	t.Run("Restored locations are public", func(t *testing.T) {
+		_, cerr := repo.SetLocationStatus(ctx, synced[0].ID, domain.LocationRemoved, domain.LocationApproved)
+		require.Nil(t, cerr)
+
+		_, cerr = locations.GetLocationByName(ctx, "lekki-depot")
+		require.Nil(t, cerr)
+	})
+
+	t.Run("Reports", func(t *testing.T) {
+		report, cerr := repo.CreateSyncReport(ctx, &domain.SyncReport{Source: "erp", Status: domain.SyncRunning})
+		require.Nil(t, cerr)
+		assert.Empty(t, report.Changes)
+		assert.Nil(t, report.FinishedAt)
+
+		report.Status = domain.SyncSucceeded
+		report.Total, report.Removed = 2, 1
+		report.Changes = []domain.SyncChange{{Action: domain.SyncRemove, Name: "Lekki Depot"}}
+		require.Nil(t, repo.FinishSyncReport(ctx, report))
+		assert.NotNil(t, report.FinishedAt)
+
+		got, cerr := repo.GetSyncReport(ctx, report.ID)
+		require.Nil(t, cerr)
+		assert.Equal(t, domain.SyncSucceeded, got.Status)
+		assert.Equal(t, 1, got.Removed)
+		assert.Equal(t, report.Changes, got.Changes)
+
+		reports, cerr := repo.ListSyncReports(ctx, &domain.ListSyncReportsRequest{Source: "erp", Limit: 10})
+		require.Nil(t, cerr)
+		assert.Len(t, reports, 1)
+
+		_, cerr = repo.GetSyncReport(ctx, "not-a-uuid")
+		require.NotNil(t, cerr)
+		assert.Equal(t, domain.ErrDataNotFound.Code(), cerr.Code())
+	})
+}
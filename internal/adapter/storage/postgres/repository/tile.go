@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+
+	"leeta/internal/adapter/storage/postgres"
+	"leeta/internal/adapter/storage/postgres/filter"
+	"leeta/internal/core/domain"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+const (
+	// tileExtent is the size of the tiles in their own coordinates, the default of the Mapbox Vector Tiles
+	tileExtent = 4096
+	// tileBuffer is the margin around the tiles, in the coordinates of the tiles, within which the locations
+	// are drawn too so the markers across the edge of two tiles are not cut off
+	tileBuffer = 64
+)
+
+/**
+ * TileRepository implements port.TileRepository interface
+ * and provides an access to the postgres database
+ */
+type TileRepository struct {
+	db *postgres.DB
+}
+
+// NewTileRepository creates a new tile repository instance
+func NewTileRepository(db *postgres.DB) *TileRepository {
+	return &TileRepository{
+		db,
+	}
+}
+
+// LocationTile encodes the locations within the tile, and its buffer, with ST_AsMVT. The locations are picked by
+// comparing their geometry with the bounds of the tile in WGS 84, which the geometry index serves, and only then
+// projected into Web Mercator
+func (tr *TileRepository) LocationTile(ctx context.Context, req *domain.TileRequest) ([]byte, domain.CError) {
+	features := tr.db.QueryBuilder.Select().
+		Column(sq.Expr("ST_AsMVTGeom(ST_Transform(locations.geo::geometry, 3857), bounds.tile, ?, ?) AS geom", tileExtent, tileBuffer)).
+		Columns("locations.id::text AS id", "locations.name", "locations.slug", "COALESCE(locations.category, '') AS category").
+		From("locations, bounds").
+		Where("locations.geo::geometry && ST_Transform(bounds.area, 4326)").
+		Where(approved)
+	if !req.IncludeExpired {
+		features = features.Where(current)
+	}
+
+	pred, err := filter.Parse(req.Query, locationFilterFields)
+	if err != nil {
+		return nil, domain.NewBadRequestCError(err.Error())
+	}
+	if pred != nil {
+		features = features.Where(pred)
+	}
+
+	query := tr.db.QueryBuilder.Select().
+		Column(sq.Expr("COALESCE(ST_AsMVT(features.*, 'locations', ?, 'geom'), ''::bytea)", tileExtent)).
+		Prefix("WITH bounds AS (SELECT ST_TileEnvelope(?, ?, ?) AS tile, ST_TileEnvelope(?, ?, ?, margin => ?) AS area)",
+			req.Z, req.X, req.Y, req.Z, req.X, req.Y, float64(tileBuffer)/tileExtent).
+		FromSelect(features, "features")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, tr.internalError(err)
+	}
+
+	var tile []byte
+	if err := tr.db.ReadQueryRow(ctx, sql, args...).Scan(&tile); err != nil {
+		return nil, tr.internalError(err)
+	}
+
+	return tile, nil
+}
+
+// internalError converts a database error into a CError, telling timeouts apart.
+// The other errors are internal, with the database error as their cause
+func (tr *TileRepository) internalError(err error) domain.CError {
+	if tr.db.IsTimeout(err) {
+		return domain.ErrTimeout
+	}
+	return domain.WrapCError(domain.ErrInternal, err, domain.ErrorContext{Entity: "tile"})
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"leeta/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTileRepository_LocationTile(t *testing.T) {
+	locations, db := newTestLocationRepository(t)
+	repo := NewTileRepository(db)
+	ctx := context.Background()
+
+	for _, location := range []domain.Location{
+		{Name: "Lekki Depot", Latitude: 6.45, Longitude: 3.47, Category: "depot"},
+		{Name: "Lekki Park", Latitude: 6.451, Longitude: 3.471, Category: "park"},
+		{Name: "Lekki Kiosk", Latitude: 6.452, Longitude: 3.472, Status: domain.LocationPending},
+	} {
+		_, cerr := locations.CreateLocation(ctx, &location)
+		require.Nil(t, cerr)
+	}
+
+	// the strings of the features are kept as is in the tiles
+	lekki := domain.TileRequest{Z: 12, X: 2087, Y: 1974}
+
+	t.Run("Tile with locations", func(t *testing.T) {
+		tile, cerr := repo.LocationTile(ctx, &lekki)
+		require.Nil(t, cerr)
+		assert.Contains(t, string(tile), "Lekki Depot")
+		assert.Contains(t, string(tile), "Lekki Park")
+		assert.NotContains(t, string(tile), "Lekki Kiosk", "the locations waiting for review are not drawn")
+	})
+
+	t.Run("Whole world", func(t *testing.T) {
+		tile, cerr := repo.LocationTile(ctx, &domain.TileRequest{})
+		require.Nil(t, cerr)
+		assert.Contains(t, string(tile), "Lekki Depot")
+	})
+
+	t.Run("Tile without locations", func(t *testing.T) {
+		tile, cerr := repo.LocationTile(ctx, &domain.TileRequest{Z: 12})
+		require.Nil(t, cerr)
+		assert.Empty(t, tile)
+	})
+
+	t.Run("Filtered tile", func(t *testing.T) {
+		req := lekki
+		req.Query = "category:park"
+
+		tile, cerr := repo.LocationTile(ctx, &req)
+		require.Nil(t, cerr)
+		assert.Contains(t, string(tile), "Lekki Park")
+		assert.NotContains(t, string(tile), "Lekki Depot")
+	})
+
+	t.Run("Invalid filter", func(t *testing.T) {
+		req := lekki
+		req.Query = "colour:red"
+
+		_, cerr := repo.LocationTile(ctx, &req)
+		require.NotNil(t, cerr)
+		assert.Equal(t, 400, cerr.Code())
+	})
+}
@@ -0,0 +1,193 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"leeta/internal/adapter/config"
+	"leeta/internal/adapter/logger"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultRetryAttempts       = 3
+	defaultRetryInitialBackoff = 50 * time.Millisecond
+	defaultRetryMaxBackoff     = time.Second
+)
+
+// retryPolicy decides how often and how long to wait before retrying a failed database call
+type retryPolicy struct {
+	attempts       int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+}
+
+// newRetryPolicy creates a retry policy from config, using the defaults for the settings left unset
+func newRetryPolicy(config *config.RetryConfiguration) retryPolicy {
+	policy := retryPolicy{
+		attempts:       defaultRetryAttempts,
+		initialBackoff: defaultRetryInitialBackoff,
+		maxBackoff:     defaultRetryMaxBackoff,
+	}
+
+	if config.Attempts > 0 {
+		policy.attempts = config.Attempts
+	}
+	if config.InitialBackoff > 0 {
+		policy.initialBackoff = config.InitialBackoff
+	}
+	if config.MaxBackoff > 0 {
+		policy.maxBackoff = config.MaxBackoff
+	}
+
+	return policy
+}
+
+// backoff returns the wait before the given retry, doubling from the initial backoff
+// up to the max backoff with full jitter so retrying clients spread out
+func (p retryPolicy) backoff(retry int) time.Duration {
+	wait := p.maxBackoff
+	if retry <= 32 {
+		wait = p.initialBackoff << (retry - 1)
+	}
+	if wait <= 0 || wait > p.maxBackoff {
+		wait = p.maxBackoff
+	}
+	return rand.N(wait) + 1
+}
+
+// RetryStats counts the database calls that were retried
+type RetryStats struct {
+	// Retries is the number of retries made
+	Retries uint64
+	// Exhausted is the number of calls that still failed after the last attempt
+	Exhausted uint64
+}
+
+// retryCounters holds the running RetryStats
+type retryCounters struct {
+	retries   atomic.Uint64
+	exhausted atomic.Uint64
+}
+
+// RetryStats returns the number of retried database calls since the database was opened
+func (db *DB) RetryStats() RetryStats {
+	return RetryStats{
+		Retries:   db.retryCounters.retries.Load(),
+		Exhausted: db.retryCounters.exhausted.Load(),
+	}
+}
+
+// retry calls fn until it succeeds, fails with an error that is not transient,
+// runs out of attempts or ctx is done. The wait between attempts never outlives ctx
+func (db *DB) retry(ctx context.Context, fn func() error) error {
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || !isTransient(ctx, err) {
+			return err
+		}
+
+		if attempt >= db.retryPolicy.attempts {
+			if db.retryPolicy.attempts > 1 {
+				db.retryCounters.exhausted.Add(1)
+				logger.FromCtx(ctx).Error("Database call failed after retries",
+					zap.Int("attempts", attempt), zap.Error(err))
+			}
+			return err
+		}
+
+		wait := db.retryPolicy.backoff(attempt)
+		db.retryCounters.retries.Add(1)
+		logger.FromCtx(ctx).Warn("Retrying database call",
+			zap.Int("attempt", attempt), zap.Duration("backoff", wait), zap.Error(err))
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+// isTransient reports whether err is worth retrying: the statement never reached the server,
+// the transaction lost a serialization conflict, or the server went away mid-call
+func isTransient(ctx context.Context, err error) bool {
+	if ctx.Err() != nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	if pgconn.SafeToRetry(err) {
+		return true
+	}
+
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+
+	switch pgErr.Code {
+	case "40001", // serialization_failure
+		"40P01", // deadlock_detected
+		"57P01", // admin_shutdown
+		"57P02", // crash_shutdown
+		"57P03": // cannot_connect_now
+		return true
+	}
+
+	// class 08 is connection_exception
+	return strings.HasPrefix(pgErr.Code, "08")
+}
+
+// retryQuerier implements Querier on the primary pool, retrying calls that fail with transient errors
+type retryQuerier struct {
+	db *DB
+}
+
+func (q retryQuerier) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	var tag pgconn.CommandTag
+	err := q.db.retry(ctx, func() error {
+		var err error
+		tag, err = q.db.Pool.Exec(ctx, sql, args...)
+		return err
+	})
+	if err == nil {
+		q.db.writes.record(ctx)
+	}
+	return tag, err
+}
+
+func (q retryQuerier) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	var rows pgx.Rows
+	err := q.db.retry(ctx, func() error {
+		var err error
+		rows, err = q.db.Pool.Query(ctx, sql, args...)
+		return err
+	})
+	if err == nil && isWrite(sql) {
+		q.db.writes.record(ctx)
+	}
+	return rows, err
+}
+
+func (q retryQuerier) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	rows, err := q.Query(ctx, sql, args...)
+	return &readRow{rows, err}
+}
+
+// CopyFrom is not retried since the row source cannot be read twice
+func (q retryQuerier) CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error) {
+	n, err := q.db.Pool.CopyFrom(ctx, tableName, columnNames, rowSrc)
+	if err == nil {
+		q.db.writes.record(ctx)
+	}
+	return n, err
+}
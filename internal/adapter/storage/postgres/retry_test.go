@@ -0,0 +1,105 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"leeta/internal/adapter/config"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+func testDB(attempts int) *DB {
+	return &DB{
+		retryPolicy: newRetryPolicy(&config.RetryConfiguration{
+			Attempts:       attempts,
+			InitialBackoff: time.Millisecond,
+			MaxBackoff:     2 * time.Millisecond,
+		}),
+	}
+}
+
+func TestIsTransient(t *testing.T) {
+	ctx := context.Background()
+
+	assert.True(t, isTransient(ctx, &pgconn.PgError{Code: "40001"}))
+	assert.True(t, isTransient(ctx, &pgconn.PgError{Code: "40P01"}))
+	assert.True(t, isTransient(ctx, &pgconn.PgError{Code: "08006"}))
+	assert.True(t, isTransient(ctx, &pgconn.PgError{Code: "57P01"}))
+
+	assert.False(t, isTransient(ctx, &pgconn.PgError{Code: "23505"}))
+	assert.False(t, isTransient(ctx, errors.New("boom")))
+	assert.False(t, isTransient(ctx, context.DeadlineExceeded))
+}
+
+func TestRetry_TransientThenSuccess(t *testing.T) {
+	db := testDB(3)
+
+	calls := 0
+	err := db.retry(context.Background(), func() error {
+		calls++
+		if calls < 3 {
+			return &pgconn.PgError{Code: "40001"}
+		}
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, RetryStats{Retries: 2}, db.RetryStats())
+}
+
+func TestRetry_Exhausted(t *testing.T) {
+	db := testDB(2)
+
+	calls := 0
+	err := db.retry(context.Background(), func() error {
+		calls++
+		return &pgconn.PgError{Code: "40001"}
+	})
+
+	assert.Error(t, err)
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, RetryStats{Retries: 1, Exhausted: 1}, db.RetryStats())
+}
+
+func TestRetry_NotTransient(t *testing.T) {
+	db := testDB(3)
+
+	calls := 0
+	err := db.retry(context.Background(), func() error {
+		calls++
+		return &pgconn.PgError{Code: "23505"}
+	})
+
+	assert.Error(t, err)
+	assert.Equal(t, 1, calls)
+}
+
+func TestRetry_ContextDone(t *testing.T) {
+	db := testDB(5)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	calls := 0
+	err := db.retry(ctx, func() error {
+		calls++
+		cancel()
+		return &pgconn.PgError{Code: "40001"}
+	})
+
+	assert.Error(t, err)
+	assert.Equal(t, 1, calls)
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := retryPolicy{attempts: 5, initialBackoff: 10 * time.Millisecond, maxBackoff: 40 * time.Millisecond}
+
+	for retry := 1; retry <= 10; retry++ {
+		wait := policy.backoff(retry)
+		assert.Greater(t, wait, time.Duration(0))
+		assert.LessOrEqual(t, wait, 40*time.Millisecond)
+	}
+}
@@ -0,0 +1,38 @@
+package postgres
+
+import (
+	"errors"
+
+	"leeta/internal/adapter/config"
+	"leeta/internal/core/slugger"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// newSlugger creates the slugger of the slugs configuration, an unknown collision strategy is none
+func newSlugger(config *config.SlugsConfiguration) *slugger.Slugger {
+	collisions, _ := slugger.ParseCollisions(config.Collisions)
+	return slugger.New(config.Unicode, config.MaxLength, collisions)
+}
+
+// Slugger returns the slugger making the slugs of the records
+func (db *DB) Slugger() *slugger.Slugger {
+	if db.slugger == nil {
+		return slugger.Default
+	}
+	return db.slugger
+}
+
+// Slug returns the slug of name, which records are also looked up by
+func (db *DB) Slug(name string) string {
+	return db.Slugger().Make(name)
+}
+
+// ConstraintName returns the name of the constraint or unique index err violates, empty for other errors
+func (db *DB) ConstraintName(err error) string {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.ConstraintName
+	}
+	return ""
+}
@@ -0,0 +1,107 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"leeta/internal/core/auth"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// TenancyRLS scopes the locations to the tenant of the caller with the row level security policies of the
+// locations and the tables holding their aliases, names, collections and shares, keyed by the app.tenant
+// setting of the connection running the query
+const TenancyRLS = "rls"
+
+const (
+	// tenantSetting is the setting the row level security policies read the tenant of the caller from
+	tenantSetting = "app.tenant"
+	// allTenantsSetting is on for the background jobs acting for every tenant, the policies then let every row through
+	allTenantsSetting = "app.all_tenants"
+)
+
+// tenantTables are the tables with a row level security policy scoping them to the tenant of the caller
+var tenantTables = []string{"locations", "location_aliases", "location_names", "collections", "collection_locations", "location_shares"}
+
+// connTenant is the tenant a connection is set to act for
+type connTenant struct {
+	tenant string
+	all    bool
+}
+
+// tenantScope sets the tenant of the caller of a query on the connection acquired to run it. The tenant
+// of each connection is remembered, so the setting is only sent when a connection changes tenant
+type tenantScope struct {
+	mu      sync.Mutex
+	tenants map[*pgx.Conn]connTenant
+}
+
+func newTenantScope() *tenantScope {
+	return &tenantScope{tenants: make(map[*pgx.Conn]connTenant)}
+}
+
+// beforeAcquire sets the tenant of the caller ctx carries on conn, an empty one for the callers without a
+// tenant, as are new connections, and whether ctx acts for every tenant. A connection the settings fail on
+// is destroyed and another one acquired
+func (ts *tenantScope) beforeAcquire(ctx context.Context, conn *pgx.Conn) bool {
+	tenant := connTenant{auth.Tenant(ctx), auth.AllTenants(ctx)}
+
+	ts.mu.Lock()
+	current := ts.tenants[conn]
+	ts.mu.Unlock()
+	if current == tenant {
+		return true
+	}
+
+	all := "off"
+	if tenant.all {
+		all = "on"
+	}
+	query := "SELECT set_config($1, $2, false), set_config($3, $4, false)"
+	if _, err := conn.Exec(ctx, query, tenantSetting, tenant.tenant, allTenantsSetting, all); err != nil {
+		return false
+	}
+
+	ts.mu.Lock()
+	ts.tenants[conn] = tenant
+	ts.mu.Unlock()
+
+	return true
+}
+
+// beforeClose forgets the tenant of a connection being closed
+func (ts *tenantScope) beforeClose(conn *pgx.Conn) {
+	ts.mu.Lock()
+	delete(ts.tenants, conn)
+	ts.mu.Unlock()
+}
+
+// ApplyTenancy enables the row level security of the tenant tables with the rls tenancy and disables it
+// otherwise. It is forced, as the tables are owned by the user the service connects as
+func (db *DB) ApplyTenancy(ctx context.Context) error {
+	enable := db.tenancy == TenancyRLS
+
+	for _, table := range tenantTables {
+		// a table is only altered when it has to be, as altering it locks it
+		var enabled, forced bool
+		err := db.QueryRow(ctx, "SELECT relrowsecurity, relforcerowsecurity FROM pg_class WHERE oid = $1::regclass", table).Scan(&enabled, &forced)
+		if err != nil {
+			return fmt.Errorf("reading the row level security of %s: %w", table, err)
+		}
+		if enabled == enable && forced == enable {
+			continue
+		}
+
+		query := "ALTER TABLE " + table + " DISABLE ROW LEVEL SECURITY, NO FORCE ROW LEVEL SECURITY"
+		if enable {
+			query = "ALTER TABLE " + table + " ENABLE ROW LEVEL SECURITY, FORCE ROW LEVEL SECURITY"
+		}
+		if _, err := db.Exec(ctx, query); err != nil {
+			return fmt.Errorf("altering the row level security of %s: %w", table, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+
+	"leeta/internal/adapter/storage/postgres"
+	"leeta/internal/adapter/storage/postgres/postgrestest"
+	"leeta/internal/core/auth"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDB_TenancyRLS(t *testing.T) {
+	cfg := postgrestest.Config(t)
+	cfg.Tenancy = postgres.TenancyRLS
+	ctx := context.Background()
+
+	db, err := postgres.New(ctx, cfg)
+	require.NoError(t, err)
+	t.Cleanup(db.Close)
+
+	require.NoError(t, db.ApplyTenancy(ctx))
+	t.Cleanup(func() {
+		for _, table := range []string{"locations", "location_aliases", "location_names", "collections", "collection_locations", "location_shares"} {
+			_, err := db.Exec(ctx, "ALTER TABLE "+table+" DISABLE ROW LEVEL SECURITY, NO FORCE ROW LEVEL SECURITY")
+			require.NoError(t, err)
+		}
+	})
+
+	// the test database user is a superuser, which the policies do not apply to, so the reads switch to a role
+	// they apply to
+	_, err = db.Exec(ctx, `DO $$ BEGIN CREATE ROLE leeta_tenant; EXCEPTION WHEN duplicate_object THEN NULL; END $$`)
+	require.NoError(t, err)
+	_, err = db.Exec(ctx, "GRANT SELECT ON locations TO leeta_tenant")
+	require.NoError(t, err)
+	_, err = db.Exec(ctx, "DELETE FROM locations")
+	require.NoError(t, err)
+
+	withTenant := func(tenant string) context.Context {
+		if tenant == "" {
+			return ctx
+		}
+		return auth.WithPrincipal(ctx, &auth.Principal{User: "ada", Tenant: tenant})
+	}
+
+	// the names are unique within a tenant only
+	for tenant, name := range map[string]string{"acme": "Lekki Depot", "globex": "Ikeja Depot", "": "Yaba Depot", "initech": "Yaba Depot"} {
+		_, err := db.Exec(withTenant(tenant), `
+			INSERT INTO locations (name, slug, latitude, longitude, geo)
+			VALUES ($1, $1, 6.45, 3.47, ST_MakePoint(3.47, 6.45)::geography)`, name)
+		require.NoError(t, err)
+	}
+
+	names := func(ctx context.Context) []string {
+		var names []string
+		err := db.WithinTx(ctx, func(ctx context.Context) error {
+			if _, err := db.Conn(ctx).Exec(ctx, "SET LOCAL ROLE leeta_tenant"); err != nil {
+				return err
+			}
+
+			rows, err := db.Conn(ctx).Query(ctx, "SELECT name FROM locations ORDER BY name")
+			if err != nil {
+				return err
+			}
+			defer rows.Close()
+
+			for rows.Next() {
+				var name string
+				if err := rows.Scan(&name); err != nil {
+					return err
+				}
+				names = append(names, name)
+			}
+			return rows.Err()
+		})
+		require.NoError(t, err)
+		return names
+	}
+
+	assert.Equal(t, []string{"Lekki Depot"}, names(withTenant("acme")))
+	assert.Equal(t, []string{"Ikeja Depot"}, names(withTenant("globex")))
+	// the callers without a tenant only see the locations without one, on connections used by a tenant before too
+	assert.Equal(t, []string{"Yaba Depot"}, names(withTenant("")))
+	// the background jobs see every location
+	assert.Equal(t, []string{"Ikeja Depot", "Lekki Depot", "Yaba Depot", "Yaba Depot"}, names(auth.WithAllTenants(ctx)))
+	assert.Equal(t, []string{"Lekki Depot"}, names(withTenant("acme")))
+}
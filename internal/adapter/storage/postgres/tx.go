@@ -0,0 +1,106 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type txCtxKey struct{}
+
+// Querier is the set of query methods shared by the pool and transactions
+type Querier interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error)
+}
+
+// Conn returns the transaction carried by ctx, or the primary pool if there is none.
+// Repositories run their queries on it so they take part in TxManager transactions.
+// Queries on the pool are retried on transient errors, queries in a transaction are
+// retried with the whole transaction by WithinTx
+func (db *DB) Conn(ctx context.Context) Querier {
+	if tx, ok := ctx.Value(txCtxKey{}).(pgx.Tx); ok {
+		return tx
+	}
+	return retryQuerier{db}
+}
+
+/**
+ * TxManager implements port.TxManager interface
+ * using PostgreSQL transactions
+ */
+type TxManager struct {
+	db *DB
+}
+
+// NewTxManager creates a new transaction manager instance
+func NewTxManager(db *DB) *TxManager {
+	return &TxManager{
+		db,
+	}
+}
+
+// WithinTx runs fn in a transaction, committing it if fn succeeds and rolling it back otherwise
+func (tm *TxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return tm.db.WithinTx(ctx, fn)
+}
+
+// WithinTx runs fn in a transaction carried by ctx, joining the transaction already in ctx if any.
+// A transaction failing with a transient error, such as a serialization failure, is run again
+// from the start, so fn must not have side effects outside the transaction
+func (db *DB) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txCtxKey{}).(pgx.Tx); ok {
+		return fn(ctx)
+	}
+
+	return db.retry(ctx, func() error {
+		return db.runTx(ctx, fn)
+	})
+}
+
+// WithinSavepoint runs fn in a savepoint of the transaction carried by ctx, so that fn failing only rolls
+// back what it did and the transaction goes on, or in a transaction of its own if ctx carries none
+func (db *DB) WithinSavepoint(ctx context.Context, fn func(ctx context.Context) error) error {
+	tx, ok := ctx.Value(txCtxKey{}).(pgx.Tx)
+	if !ok {
+		return db.WithinTx(ctx, fn)
+	}
+
+	savepoint, err := tx.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	// rollback is a no-op once the savepoint has been released
+	defer savepoint.Rollback(ctx)
+
+	if err := fn(context.WithValue(ctx, txCtxKey{}, savepoint)); err != nil {
+		return err
+	}
+
+	return savepoint.Commit(ctx)
+}
+
+// runTx runs fn in a new transaction
+func (db *DB) runTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	// rollback is a no-op once the transaction has been committed
+	defer tx.Rollback(ctx)
+
+	if err := fn(context.WithValue(ctx, txCtxKey{}, tx)); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+	db.writes.record(ctx)
+	return nil
+}
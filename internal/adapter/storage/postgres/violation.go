@@ -0,0 +1,58 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"leeta/internal/core/domain"
+	"leeta/internal/core/errcode"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// detailKey matches the columns of the key in the detail of a violation, such as Key (location_id)=(42)
+var detailKey = regexp.MustCompile(`^Key \(([^)]+)\)=`)
+
+// ViolationError converts an integrity constraint violation into a CError naming the field at fault,
+// it returns nil for the other errors. Repositories telling some violations apart, such as the unique
+// conflict of a name, check them before falling back to this
+func (db *DB) ViolationError(err error) domain.CError {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return nil
+	}
+
+	switch pgErr.Code {
+	// 23502 is the error code of a not null violation, ColumnName is the column left empty
+	case "23502":
+		return domain.NewCodedCError(errcode.RequiredFieldMissing, fmt.Sprintf("%s is required", violationField(pgErr)))
+	// 23503 is the error code of a foreign key violation
+	case "23503":
+		return domain.NewCodedCError(errcode.ReferenceNotFound, fmt.Sprintf("%s refers to a record that does not exist", violationField(pgErr)))
+	// 23505 is the error code for a unique conflict error
+	case "23505":
+		return domain.NewCodedCError(errcode.AlreadyExists, fmt.Sprintf("a record with the same %s already exists", violationField(pgErr)))
+	// 23514 is the error code of a check violation, which usually names the constraint rather than the column
+	case "23514":
+		return domain.NewCodedCError(errcode.ConstraintViolated, fmt.Sprintf("a value breaks the %s check", violationField(pgErr)))
+	}
+
+	return nil
+}
+
+// violationField returns the field of a violation, the column when the error names one,
+// otherwise the columns of the key in its detail, otherwise the constraint violated
+func violationField(pgErr *pgconn.PgError) string {
+	if pgErr.ColumnName != "" {
+		return pgErr.ColumnName
+	}
+	if m := detailKey.FindStringSubmatch(pgErr.Detail); m != nil {
+		return strings.ReplaceAll(m[1], ", ", " and ")
+	}
+	if pgErr.ConstraintName != "" {
+		return pgErr.ConstraintName
+	}
+	return pgErr.TableName
+}
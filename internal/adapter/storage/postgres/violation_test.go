@@ -0,0 +1,66 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"leeta/internal/core/errcode"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestViolationError(t *testing.T) {
+	db := &DB{}
+
+	tests := []struct {
+		name    string
+		err     *pgconn.PgError
+		status  int
+		code    errcode.Code
+		message string
+	}{
+		{
+			"Not null",
+			&pgconn.PgError{Code: "23502", TableName: "devices", ColumnName: "name"},
+			http.StatusBadRequest, errcode.RequiredFieldMissing, "name is required",
+		},
+		{
+			"Foreign key",
+			&pgconn.PgError{Code: "23503", ConstraintName: "collection_locations_location_id_fkey",
+				Detail: `Key (location_id)=(42) is not present in table "locations".`},
+			http.StatusConflict, errcode.ReferenceNotFound, "location_id refers to a record that does not exist",
+		},
+		{
+			"Unique on several columns",
+			&pgconn.PgError{Code: "23505", ConstraintName: "location_names_pkey",
+				Detail: "Key (location_id, locale)=(42, fr) already exists."},
+			http.StatusConflict, errcode.AlreadyExists, "a record with the same location_id and locale already exists",
+		},
+		{
+			"Check",
+			&pgconn.PgError{Code: "23514", ConstraintName: "locations_altitude_check",
+				Detail: "Failing row contains (...)."},
+			http.StatusBadRequest, errcode.ConstraintViolated, "a value breaks the locations_altitude_check check",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cerr := db.ViolationError(fmt.Errorf("inserting: %w", tt.err))
+			require.NotNil(t, cerr)
+
+			assert.Equal(t, tt.status, cerr.Code())
+			assert.Equal(t, tt.code, cerr.ErrorCode())
+			assert.Equal(t, tt.message, cerr.Error())
+		})
+	}
+
+	t.Run("Other errors", func(t *testing.T) {
+		assert.Nil(t, db.ViolationError(&pgconn.PgError{Code: "40001"}))
+		assert.Nil(t, db.ViolationError(errors.New("boom")))
+	})
+}
@@ -0,0 +1,87 @@
+package postgres
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"leeta/internal/core/auth"
+)
+
+const (
+	defaultReadYourWrites = 5 * time.Second
+	// maxRecentWriters is the number of callers remembered before the ones whose window ended are forgotten
+	maxRecentWriters = 10000
+)
+
+/**
+ * recentWrites remembers when each caller last wrote to the
+ * primary, so that its reads go to the primary until the
+ * replicas caught up. Callers are told apart by their user
+ * and tenant, the anonymous ones share a window
+ */
+type recentWrites struct {
+	window time.Duration
+	now    func() time.Time
+
+	mu   sync.Mutex
+	last map[string]time.Time
+}
+
+// newRecentWrites creates a new record of the writes, reads follow writes to the primary for window
+func newRecentWrites(window time.Duration) *recentWrites {
+	if window <= 0 {
+		window = defaultReadYourWrites
+	}
+
+	return &recentWrites{
+		window: window,
+		now:    time.Now,
+		last:   make(map[string]time.Time),
+	}
+}
+
+// writer returns the key of the caller of ctx
+func writer(ctx context.Context) string {
+	return auth.Tenant(ctx) + "\x00" + auth.User(ctx)
+}
+
+// record records a write by the caller of ctx
+func (w *recentWrites) record(ctx context.Context) {
+	if w == nil {
+		return
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	now := w.now()
+	if len(w.last) >= maxRecentWriters {
+		for key, at := range w.last {
+			if now.Sub(at) >= w.window {
+				delete(w.last, key)
+			}
+		}
+	}
+	w.last[writer(ctx)] = now
+}
+
+// recent reports whether the caller of ctx wrote within the window
+func (w *recentWrites) recent(ctx context.Context) bool {
+	if w == nil {
+		return false
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	at, ok := w.last[writer(ctx)]
+	return ok && w.now().Sub(at) < w.window
+}
+
+// isWrite reports whether sql may write, only the plain SELECT statements are known to be reads
+func isWrite(sql string) bool {
+	statement := strings.TrimSpace(sql)
+	return len(statement) < 6 || !strings.EqualFold(statement[:6], "SELECT")
+}
@@ -0,0 +1,43 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"leeta/internal/core/auth"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecentWrites(t *testing.T) {
+	now := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	writes := newRecentWrites(5 * time.Second)
+	writes.now = func() time.Time { return now }
+
+	ada := auth.WithPrincipal(context.Background(), &auth.Principal{User: "ada", Tenant: "acme"})
+	grace := auth.WithPrincipal(context.Background(), &auth.Principal{User: "grace", Tenant: "acme"})
+
+	assert.False(t, writes.recent(ada))
+
+	writes.record(ada)
+	assert.True(t, writes.recent(ada))
+	assert.False(t, writes.recent(grace))
+	assert.False(t, writes.recent(context.Background()))
+
+	now = now.Add(5 * time.Second)
+	assert.False(t, writes.recent(ada), "the window has ended")
+
+	// a database without a record of the writes never routes to the primary
+	var none *recentWrites
+	none.record(ada)
+	assert.False(t, none.recent(ada))
+}
+
+func TestIsWrite(t *testing.T) {
+	assert.False(t, isWrite("SELECT id FROM locations"))
+	assert.False(t, isWrite("\n\t\tselect id FROM locations"))
+	assert.True(t, isWrite("INSERT INTO locations (name) VALUES ($1) RETURNING id"))
+	assert.True(t, isWrite("WITH deleted AS (DELETE FROM locations RETURNING id) SELECT count(*) FROM deleted"))
+	assert.True(t, isWrite("UPDATE locations SET name = $1"))
+}
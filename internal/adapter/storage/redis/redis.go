@@ -0,0 +1,84 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"leeta/internal/adapter/config"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ErrCacheMiss is returned when a key is not in the cache
+var ErrCacheMiss = errors.New("cache miss")
+
+const defaultTTL = 5 * time.Minute
+
+/**
+ * Cache is a wrapper for the Redis client that stores
+ * values with the configured time to live
+ */
+type Cache struct {
+	client *redis.Client
+	ttl    atomic.Int64
+}
+
+// New creates a new Redis cache instance
+func New(ctx context.Context, config *config.RedisConfiguration) (*Cache, error) {
+	client := redis.NewClient(&redis.Options{
+		Addr:     config.Addr,
+		Password: config.Password,
+		DB:       config.DB,
+	})
+
+	if err := client.Ping(ctx).Err(); err != nil {
+		return nil, err
+	}
+
+	cache := &Cache{
+		client: client,
+	}
+	cache.SetTTL(config.TTL)
+
+	return cache, nil
+}
+
+// SetTTL changes the time to live of the values stored from now on, 0 restores the default
+func (c *Cache) SetTTL(ttl time.Duration) {
+	if ttl <= 0 {
+		ttl = defaultTTL
+	}
+
+	c.ttl.Store(int64(ttl))
+}
+
+// Get returns the value stored at key, or ErrCacheMiss if there is none
+func (c *Cache) Get(ctx context.Context, key string) ([]byte, error) {
+	value, err := c.client.Get(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrCacheMiss
+	}
+	return value, err
+}
+
+// Set stores value at key with the cache time to live
+func (c *Cache) Set(ctx context.Context, key string, value []byte) error {
+	return c.client.Set(ctx, key, value, time.Duration(c.ttl.Load())).Err()
+}
+
+// Incr increments the integer stored at key and returns the new value
+func (c *Cache) Incr(ctx context.Context, key string) (int64, error) {
+	return c.client.Incr(ctx, key).Result()
+}
+
+// Ping checks that the Redis server is reachable
+func (c *Cache) Ping(ctx context.Context) error {
+	return c.client.Ping(ctx).Err()
+}
+
+// Close closes the Redis connection
+func (c *Cache) Close() error {
+	return c.client.Close()
+}
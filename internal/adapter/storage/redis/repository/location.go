@@ -0,0 +1,265 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"leeta/internal/adapter/logger"
+	"leeta/internal/adapter/storage/redis"
+	"leeta/internal/core/auth"
+	"leeta/internal/core/domain"
+	"leeta/internal/core/port"
+
+	"github.com/gosimple/slug"
+	"go.uber.org/zap"
+)
+
+// generationKey holds a counter that is part of every cached location key.
+// Writes bump it so all cached reads are invalidated at once, and the
+// orphaned entries expire through their TTL.
+const generationKey = "locations:generation"
+
+/**
+ * LocationRepository implements port.LocationRepository interface
+ * and caches the reads of another location repository in Redis
+ */
+type LocationRepository struct {
+	next  port.LocationRepository
+	cache *redis.Cache
+}
+
+// NewLocationRepository creates a new location cache repository wrapping next
+func NewLocationRepository(next port.LocationRepository, cache *redis.Cache) *LocationRepository {
+	return &LocationRepository{
+		next,
+		cache,
+	}
+}
+
+func (lr *LocationRepository) CreateLocation(ctx context.Context, location *domain.Location) (*domain.Location, domain.CError) {
+	created, cerr := lr.next.CreateLocation(ctx, location)
+	if cerr == nil {
+		lr.invalidate(ctx)
+	}
+	return created, cerr
+}
+
+func (lr *LocationRepository) CopyLocations(ctx context.Context, locations []domain.Location) (int64, domain.CError) {
+	n, cerr := lr.next.CopyLocations(ctx, locations)
+	if cerr == nil && n > 0 {
+		lr.invalidate(ctx)
+	}
+	return n, cerr
+}
+
+// ExpireLocations drops the cached reads since the lists leave out the expired locations
+func (lr *LocationRepository) ExpireLocations(ctx context.Context, limit int) (int64, domain.CError) {
+	n, cerr := lr.next.ExpireLocations(ctx, limit)
+	if cerr == nil && n > 0 {
+		lr.invalidate(ctx)
+	}
+	return n, cerr
+}
+
+// GetLocationByID gets a location by ID from the cache, falling back to the wrapped repository
+func (lr *LocationRepository) GetLocationByID(ctx context.Context, id string) (*domain.Location, domain.CError) {
+	return cached(ctx, lr, "id:"+id, func() (*domain.Location, domain.CError) {
+		return lr.next.GetLocationByID(ctx, id)
+	})
+}
+
+// GetLocationByName gets a location by name or slug from the cache, falling back to the wrapped repository
+func (lr *LocationRepository) GetLocationByName(ctx context.Context, name string) (*domain.Location, domain.CError) {
+	// names are matched ignoring case, so differently cased lookups share an entry
+	return cached(ctx, lr, "name:"+strings.ToLower(name), func() (*domain.Location, domain.CError) {
+		return lr.next.GetLocationByName(ctx, name)
+	})
+}
+
+// ListLocations lists locations from the cache, falling back to the wrapped repository
+func (lr *LocationRepository) ListLocations(ctx context.Context, req *domain.ListLocationsRequest) ([]domain.Location, domain.CError) {
+	key := "list:" + req.Query
+	if req.IncludeExpired {
+		key = "all:" + key
+	}
+	if req.Sort != "" {
+		key = "sort:" + string(req.Sort) + ":" + key
+	}
+	if req.Collection != "" {
+		// collections are matched by name ignoring case or by slug, the slug keeps their lists apart
+		key = "collection:" + slug.Make(req.Collection) + ":" + key
+	}
+	return cached(ctx, lr, key, func() ([]domain.Location, domain.CError) {
+		return lr.next.ListLocations(ctx, req)
+	})
+}
+
+// LookupLocations is not cached, the sets of ids and slugs of clients reconciling their caches rarely repeat
+func (lr *LocationRepository) LookupLocations(ctx context.Context, ids, slugs []string) ([]domain.Location, domain.CError) {
+	return lr.next.LookupLocations(ctx, ids, slugs)
+}
+
+// StreamLocations is not cached, exports always read through to the wrapped repository
+func (lr *LocationRepository) StreamLocations(ctx context.Context, req *domain.ListLocationsRequest, fn func(location *domain.Location) error) domain.CError {
+	return lr.next.StreamLocations(ctx, req, fn)
+}
+
+// LocationGeometry is not cached, it is computed over sets of locations that rarely repeat
+func (lr *LocationRepository) LocationGeometry(ctx context.Context, req *domain.LocationGeometryRequest) (*domain.LocationGeometry, domain.CError) {
+	return lr.next.LocationGeometry(ctx, req)
+}
+
+func (lr *LocationRepository) UpdateLocation(ctx context.Context, name string, location *domain.Location) (*domain.Location, domain.CError) {
+	updated, cerr := lr.next.UpdateLocation(ctx, name, location)
+	if cerr == nil {
+		lr.invalidate(ctx)
+	}
+	return updated, cerr
+}
+
+func (lr *LocationRepository) DeleteLocation(ctx context.Context, name string) domain.CError {
+	cerr := lr.next.DeleteLocation(ctx, name)
+	if cerr == nil {
+		lr.invalidate(ctx)
+	}
+	return cerr
+}
+
+// FindLocationsAt is not cached, a location registered a moment ago must be found
+func (lr *LocationRepository) FindLocationsAt(ctx context.Context, latitude, longitude float64) ([]domain.Location, domain.CError) {
+	return lr.next.FindLocationsAt(ctx, latitude, longitude)
+}
+
+// GetNearestLocation is not cached since query points rarely repeat
+func (lr *LocationRepository) GetNearestLocation(ctx context.Context, latitude, longitude float64, formula domain.DistanceFormula) (*domain.NearestLocation, domain.CError) {
+	return lr.next.GetNearestLocation(ctx, latitude, longitude, formula)
+}
+
+// FindNearestLocations is not cached since query points rarely repeat
+func (lr *LocationRepository) FindNearestLocations(ctx context.Context, query *domain.NearestLocationsRequest) ([]domain.NearestLocation, domain.CError) {
+	return lr.next.FindNearestLocations(ctx, query)
+}
+
+// FindLocationsAlongRoute is not cached since routes rarely repeat
+func (lr *LocationRepository) FindLocationsAlongRoute(ctx context.Context, route []domain.Point, query *domain.AlongRouteRequest) ([]domain.RouteLocation, domain.CError) {
+	return lr.next.FindLocationsAlongRoute(ctx, route, query)
+}
+
+// CreateLocationAlias drops the cached reads since the lists filtered by alias change with the aliases
+func (lr *LocationRepository) CreateLocationAlias(ctx context.Context, name string, alias *domain.LocationAlias) (*domain.LocationAlias, domain.CError) {
+	created, cerr := lr.next.CreateLocationAlias(ctx, name, alias)
+	if cerr == nil {
+		lr.invalidate(ctx)
+	}
+	return created, cerr
+}
+
+// ListLocationAliases is not cached, aliases are only listed to manage them
+func (lr *LocationRepository) ListLocationAliases(ctx context.Context, name string) ([]domain.LocationAlias, domain.CError) {
+	return lr.next.ListLocationAliases(ctx, name)
+}
+
+// DeleteLocationAlias drops the cached reads, including the location cached under the alias
+func (lr *LocationRepository) DeleteLocationAlias(ctx context.Context, name, alias string) domain.CError {
+	cerr := lr.next.DeleteLocationAlias(ctx, name, alias)
+	if cerr == nil {
+		lr.invalidate(ctx)
+	}
+	return cerr
+}
+
+// ListPendingLocations is not cached, the queue is only listed to review it
+func (lr *LocationRepository) ListPendingLocations(ctx context.Context, req *domain.ListPendingLocationsRequest) ([]domain.Location, domain.CError) {
+	return lr.next.ListPendingLocations(ctx, req)
+}
+
+// ReviewLocation drops the cached reads since approved locations join them
+func (lr *LocationRepository) ReviewLocation(ctx context.Context, name string, status domain.LocationStatus, reason string) (*domain.Location, domain.CError) {
+	location, cerr := lr.next.ReviewLocation(ctx, name, status, reason)
+	if cerr == nil {
+		lr.invalidate(ctx)
+	}
+	return location, cerr
+}
+
+// OnLocationChange drops every cached location read when a location changes. Writes through
+// this repository already do so, this also catches changes made outside of it, such as by
+// a peer without the cache or directly in the database
+func (lr *LocationRepository) OnLocationChange(ctx context.Context, change *domain.LocationChange) {
+	lr.invalidate(ctx)
+}
+
+// key returns the cache key prefix of the current generation
+func (lr *LocationRepository) key(ctx context.Context) (string, error) {
+	raw, err := lr.cache.Get(ctx, generationKey)
+	if errors.Is(err, redis.ErrCacheMiss) {
+		return "locations:0:", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	generation, err := strconv.ParseInt(string(raw), 10, 64)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("locations:%d:", generation), nil
+}
+
+// invalidate drops every cached location read
+func (lr *LocationRepository) invalidate(ctx context.Context) {
+	if _, err := lr.cache.Incr(ctx, generationKey); err != nil {
+		logger.FromCtx(ctx).Error("Error invalidating location cache", zap.Error(err))
+	}
+}
+
+// cached returns the value cached at suffix, loading and caching it on a miss.
+// Cache failures are logged and never fail the read.
+func cached[T any](ctx context.Context, lr *LocationRepository, suffix string, load func() (T, domain.CError)) (T, domain.CError) {
+	// the background jobs acting for every tenant read locations no caller may see
+	if auth.AllTenants(ctx) {
+		return load()
+	}
+
+	prefix, err := lr.key(ctx)
+	if err != nil {
+		logger.FromCtx(ctx).Warn("Error reading location cache generation", zap.Error(err))
+		return load()
+	}
+	key := prefix + suffix
+	// the locations a tenant sees may not be the ones the others see, so each caches its own reads
+	if tenant := auth.Tenant(ctx); tenant != "" {
+		key = prefix + "tenant:" + tenant + ":" + suffix
+	}
+
+	var value T
+	raw, err := lr.cache.Get(ctx, key)
+	if err == nil {
+		if err = json.Unmarshal(raw, &value); err == nil {
+			return value, nil
+		}
+	}
+	if !errors.Is(err, redis.ErrCacheMiss) {
+		logger.FromCtx(ctx).Warn("Error reading location cache", zap.String("key", key), zap.Error(err))
+	}
+
+	value, cerr := load()
+	if cerr != nil {
+		return value, cerr
+	}
+
+	raw, err = json.Marshal(value)
+	if err == nil {
+		err = lr.cache.Set(ctx, key, raw)
+	}
+	if err != nil {
+		logger.FromCtx(ctx).Warn("Error writing location cache", zap.String("key", key), zap.Error(err))
+	}
+
+	return value, nil
+}
@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"leeta/internal/adapter/config"
+	"leeta/internal/adapter/storage/redis"
+	"leeta/internal/core/auth"
+	"leeta/internal/core/domain"
+	"leeta/internal/core/port/mock"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCache(t *testing.T) (*redis.Cache, *miniredis.Miniredis) {
+	t.Helper()
+
+	server := miniredis.RunT(t)
+	cache, err := redis.New(context.Background(), &config.RedisConfiguration{Addr: server.Addr()})
+	require.NoError(t, err)
+	t.Cleanup(func() { cache.Close() })
+
+	return cache, server
+}
+
+func TestLocationRepository_GetLocationByID(t *testing.T) {
+	ctx := context.Background()
+	cache, _ := newCache(t)
+
+	next := &mock.LocationRepositoryMock{
+		GetLocationByIDFunc: func(ctx context.Context, id string) (*domain.Location, domain.CError) {
+			return &domain.Location{ID: id, Name: "Lagos"}, nil
+		},
+	}
+	repo := NewLocationRepository(next, cache)
+
+	t.Run("a miss loads the location and caches it", func(t *testing.T) {
+		location, cerr := repo.GetLocationByID(ctx, "1")
+		require.Nil(t, cerr)
+		assert.Equal(t, "Lagos", location.Name)
+		assert.Len(t, next.GetLocationByIDCalls(), 1)
+	})
+
+	t.Run("a hit is read from the cache", func(t *testing.T) {
+		location, cerr := repo.GetLocationByID(ctx, "1")
+		require.Nil(t, cerr)
+		assert.Equal(t, "Lagos", location.Name)
+		assert.Len(t, next.GetLocationByIDCalls(), 1, "the wrapped repository is not read again")
+	})
+
+	t.Run("each tenant caches its own reads", func(t *testing.T) {
+		acme := auth.WithPrincipal(ctx, &auth.Principal{User: "ada", Tenant: "acme"})
+		_, cerr := repo.GetLocationByID(acme, "1")
+		require.Nil(t, cerr)
+		assert.Len(t, next.GetLocationByIDCalls(), 2)
+	})
+
+	t.Run("the jobs acting for every tenant skip the cache", func(t *testing.T) {
+		_, cerr := repo.GetLocationByID(auth.WithAllTenants(ctx), "1")
+		require.Nil(t, cerr)
+		assert.Len(t, next.GetLocationByIDCalls(), 3)
+	})
+}
+
+func TestLocationRepository_GetLocationByNameError(t *testing.T) {
+	ctx := context.Background()
+	cache, _ := newCache(t)
+
+	next := &mock.LocationRepositoryMock{
+		GetLocationByNameFunc: func(ctx context.Context, name string) (*domain.Location, domain.CError) {
+			return nil, domain.ErrDataNotFound
+		},
+	}
+	repo := NewLocationRepository(next, cache)
+
+	for range 2 {
+		_, cerr := repo.GetLocationByName(ctx, "Lagos")
+		assert.Equal(t, domain.ErrDataNotFound, cerr)
+	}
+	assert.Len(t, next.GetLocationByNameCalls(), 2, "errors are not cached")
+}
+
+func TestLocationRepository_Invalidate(t *testing.T) {
+	ctx := context.Background()
+	cache, _ := newCache(t)
+
+	name := "Lagos"
+	next := &mock.LocationRepositoryMock{
+		GetLocationByNameFunc: func(ctx context.Context, _ string) (*domain.Location, domain.CError) {
+			return &domain.Location{ID: "1", Name: name}, nil
+		},
+		UpdateLocationFunc: func(ctx context.Context, _ string, location *domain.Location) (*domain.Location, domain.CError) {
+			name = location.Name
+			return location, nil
+		},
+		DeleteLocationFunc: func(ctx context.Context, name string) domain.CError {
+			return domain.ErrDataNotFound
+		},
+	}
+	repo := NewLocationRepository(next, cache)
+
+	location, cerr := repo.GetLocationByName(ctx, "lagos")
+	require.Nil(t, cerr)
+	assert.Equal(t, "Lagos", location.Name)
+
+	_, cerr = repo.UpdateLocation(ctx, "lagos", &domain.Location{ID: "1", Name: "Lagos Island"})
+	require.Nil(t, cerr)
+
+	location, cerr = repo.GetLocationByName(ctx, "lagos")
+	require.Nil(t, cerr)
+	assert.Equal(t, "Lagos Island", location.Name, "the write dropped the cached read")
+	assert.Len(t, next.GetLocationByNameCalls(), 2)
+
+	// a failed write keeps the cached reads
+	assert.Equal(t, domain.ErrDataNotFound, repo.DeleteLocation(ctx, "lagos"))
+	_, cerr = repo.GetLocationByName(ctx, "lagos")
+	require.Nil(t, cerr)
+	assert.Len(t, next.GetLocationByNameCalls(), 2)
+
+	// a change made outside of the repository drops them too
+	repo.OnLocationChange(ctx, &domain.LocationChange{})
+	_, cerr = repo.GetLocationByName(ctx, "lagos")
+	require.Nil(t, cerr)
+	assert.Len(t, next.GetLocationByNameCalls(), 3)
+}
+
+func TestLocationRepository_CacheDown(t *testing.T) {
+	ctx := context.Background()
+	cache, server := newCache(t)
+
+	next := &mock.LocationRepositoryMock{
+		GetLocationByIDFunc: func(ctx context.Context, id string) (*domain.Location, domain.CError) {
+			return &domain.Location{ID: id, Name: "Lagos"}, nil
+		},
+	}
+	repo := NewLocationRepository(next, cache)
+	server.Close()
+
+	location, cerr := repo.GetLocationByID(ctx, "1")
+	require.Nil(t, cerr, "cache failures never fail the read")
+	assert.Equal(t, "Lagos", location.Name)
+}
@@ -0,0 +1,154 @@
+package subscriber
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"sync/atomic"
+
+	"leeta/internal/adapter/config"
+	"leeta/internal/adapter/logger"
+	"leeta/internal/core/domain"
+	"leeta/internal/core/port"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+	"github.com/go-playground/validator/v10"
+	"go.uber.org/zap"
+)
+
+const defaultMQTTTopic = "devices/+/position"
+
+/**
+ * MQTT ingests the positions devices publish to an MQTT
+ * broker topic, recording each as a heartbeat of the device.
+ * Every message is a JSON heartbeat, its device id may be
+ * left out when the topic holds it instead
+ */
+type MQTT struct {
+	opts      *mqtt.ClientOptions
+	topic     string
+	qos       byte
+	idLevel   int
+	connected atomic.Bool
+	service   port.DeviceService
+	validate  *validator.Validate
+}
+
+// NewMQTT creates a new MQTT subscriber instance, it connects when it runs
+func NewMQTT(config *config.MQTTConfiguration, service port.DeviceService, validate *validator.Validate) (*MQTT, error) {
+	if config.QoS > 2 {
+		return nil, fmt.Errorf("invalid MQTT QoS %d, expected 0, 1 or 2", config.QoS)
+	}
+
+	topic := config.Topic
+	if topic == "" {
+		topic = defaultMQTTTopic
+	}
+
+	clientID := config.ClientID
+	if clientID == "" {
+		// brokers disconnect a client when another one connects with its id
+		hostname, _ := os.Hostname()
+		clientID = fmt.Sprintf("leeta-%s-%d", hostname, os.Getpid())
+	}
+
+	opts := mqtt.NewClientOptions().
+		AddBroker(config.Broker).
+		SetClientID(clientID).
+		SetUsername(config.Username).
+		SetPassword(config.Password).
+		SetAutoReconnect(true).
+		SetConnectRetry(true)
+
+	return &MQTT{
+		opts:     opts,
+		topic:    topic,
+		qos:      config.QoS,
+		idLevel:  wildcardLevel(topic),
+		service:  service,
+		validate: validate,
+	}, nil
+}
+
+// Run subscribes to the topic and records the positions it receives until ctx is done.
+// The subscription is renewed every time the connection to the broker is re-established
+func (m *MQTT) Run(ctx context.Context) {
+	log := logger.FromCtx(ctx)
+
+	opts := *m.opts
+	opts.SetOnConnectHandler(func(client mqtt.Client) {
+		m.connected.Store(true)
+
+		token := client.Subscribe(m.topic, m.qos, func(_ mqtt.Client, msg mqtt.Message) {
+			m.handle(ctx, msg)
+		})
+		go func() {
+			if token.Wait() && token.Error() != nil {
+				log.Error("Error subscribing to the device positions", zap.String("topic", m.topic), zap.Error(token.Error()))
+				return
+			}
+			log.Info("Subscribed to the device positions", zap.String("topic", m.topic), zap.Uint8("qos", m.qos))
+		}()
+	})
+	opts.SetConnectionLostHandler(func(_ mqtt.Client, err error) {
+		m.connected.Store(false)
+		log.Warn("Lost the connection to the MQTT broker, reconnecting", zap.Error(err))
+	})
+
+	client := mqtt.NewClient(&opts)
+	client.Connect()
+
+	<-ctx.Done()
+	client.Disconnect(250)
+	m.connected.Store(false)
+}
+
+// Ping fails when the connection to the broker is down
+func (m *MQTT) Ping(ctx context.Context) error {
+	if !m.connected.Load() {
+		return errors.New("not connected to the MQTT broker")
+	}
+
+	return nil
+}
+
+// handle records the position of msg, messages that are not valid heartbeats are dropped
+func (m *MQTT) handle(ctx context.Context, msg mqtt.Message) {
+	log := logger.FromCtx(ctx).With(zap.String("topic", msg.Topic()))
+
+	var req domain.HeartbeatRequest
+	if err := json.Unmarshal(msg.Payload(), &req); err != nil {
+		log.Warn("Dropping device position that is not JSON", zap.Error(err))
+		return
+	}
+
+	if req.DeviceID == "" && m.idLevel >= 0 {
+		if levels := strings.Split(msg.Topic(), "/"); m.idLevel < len(levels) {
+			req.DeviceID = levels[m.idLevel]
+		}
+	}
+
+	if err := m.validate.Struct(&req); err != nil {
+		log.Warn("Dropping invalid device position", zap.Error(err))
+		return
+	}
+
+	if _, cerr := m.service.Heartbeat(ctx, &req); cerr != nil {
+		log.Warn("Error recording device position", zap.String("device_id", req.DeviceID), zap.Error(cerr))
+	}
+}
+
+// wildcardLevel returns the level of the first + wildcard of topic, or -1 if it has none.
+// The $share/<group>/ prefix of shared subscriptions is not part of the message topics
+func wildcardLevel(topic string) int {
+	levels := strings.Split(topic, "/")
+	if levels[0] == "$share" && len(levels) > 2 {
+		levels = levels[2:]
+	}
+
+	return slices.Index(levels, "+")
+}
@@ -0,0 +1,27 @@
+package subscriber
+
+import (
+	"testing"
+
+	"leeta/internal/adapter/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWildcardLevel(t *testing.T) {
+	assert.Equal(t, 1, wildcardLevel("devices/+/position"))
+	assert.Equal(t, 1, wildcardLevel("$share/leeta/devices/+/position"), "the shared subscription prefix is skipped")
+	assert.Equal(t, -1, wildcardLevel("devices/positions"))
+	assert.Equal(t, -1, wildcardLevel("devices/#"))
+}
+
+func TestNewMQTT_QoS(t *testing.T) {
+	_, err := NewMQTT(&config.MQTTConfiguration{Broker: "tcp://127.0.0.1:1883", QoS: 3}, nil, nil)
+	require.Error(t, err)
+
+	m, err := NewMQTT(&config.MQTTConfiguration{Broker: "tcp://127.0.0.1:1883", QoS: 1}, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, defaultMQTTTopic, m.topic)
+	assert.Error(t, m.Ping(t.Context()), "not connected before it runs")
+}
@@ -0,0 +1,173 @@
+package subscriber
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"leeta/internal/adapter/config"
+	"leeta/internal/adapter/logger"
+	"leeta/internal/adapter/publisher"
+	"leeta/internal/core/domain"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultNATSConsumer = "leeta-consumer"
+	defaultNATSTimeout  = 5 * time.Second
+)
+
+// changeOps maps the event types to the location changes they describe
+var changeOps = map[domain.EventType]domain.ChangeOp{
+	domain.EventLocationCreated: domain.ChangeInsert,
+	domain.EventLocationUpdated: domain.ChangeUpdate,
+	domain.EventLocationDeleted: domain.ChangeDelete,
+	// an expired location is still stored, it only leaves the lists and nearest searches
+	domain.EventLocationExpired: domain.ChangeUpdate,
+}
+
+/**
+ * NATS implements port.LocationChangeFeed interface by
+ * reading the location events of the JetStream stream the
+ * outbox relay publishes to. It lets a process without a
+ * database, such as the consume command, keep the data
+ * derived from locations in sync
+ */
+type NATS struct {
+	conn     *nats.Conn
+	consumer jetstream.Consumer
+
+	mu   sync.RWMutex
+	subs []func(ctx context.Context, change *domain.LocationChange)
+}
+
+// NewNATS connects to NATS and opens the durable consumer of the stream, which resumes after the
+// last event it acknowledged. With replay, the stream is read from its first event instead
+func NewNATS(ctx context.Context, config *config.NATSConfiguration, replay bool) (*NATS, error) {
+	stream := config.Stream
+	if stream == "" {
+		stream = publisher.DefaultNATSStream
+	}
+
+	name := config.Consumer
+	if name == "" {
+		name = defaultNATSConsumer
+	}
+
+	timeout := config.Timeout
+	if timeout <= 0 {
+		timeout = defaultNATSTimeout
+	}
+
+	conn, err := nats.Connect(config.URL, nats.Name("leeta"), nats.Timeout(timeout), nats.MaxReconnects(-1))
+	if err != nil {
+		return nil, err
+	}
+
+	js, err := jetstream.New(conn)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var consumer jetstream.Consumer
+	if replay {
+		consumer, err = js.OrderedConsumer(ctx, stream, jetstream.OrderedConsumerConfig{
+			DeliverPolicy: jetstream.DeliverAllPolicy,
+		})
+	} else {
+		consumer, err = js.CreateOrUpdateConsumer(ctx, stream, jetstream.ConsumerConfig{
+			Durable:       name,
+			DeliverPolicy: jetstream.DeliverAllPolicy,
+			AckPolicy:     jetstream.AckExplicitPolicy,
+		})
+	}
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("opening a consumer of stream %s: %w", stream, err)
+	}
+
+	return &NATS{
+		conn:     conn,
+		consumer: consumer,
+	}, nil
+}
+
+func (n *NATS) Subscribe(fn func(ctx context.Context, change *domain.LocationChange)) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.subs = append(n.subs, fn)
+}
+
+// Run passes the events of the stream to the subscribers until ctx is done
+func (n *NATS) Run(ctx context.Context) error {
+	consumeCtx, err := n.consumer.Consume(func(msg jetstream.Msg) {
+		n.handle(ctx, msg)
+	})
+	if err != nil {
+		return err
+	}
+
+	<-ctx.Done()
+	consumeCtx.Drain()
+	<-consumeCtx.Closed()
+
+	return nil
+}
+
+// handle decodes the event of msg and publishes the change it describes, events that cannot be
+// decoded are terminated so they are not redelivered
+func (n *NATS) handle(ctx context.Context, msg jetstream.Msg) {
+	var event domain.Event
+	if err := json.Unmarshal(msg.Data(), &event); err != nil {
+		logger.FromCtx(ctx).Error("Error decoding event", zap.String("subject", msg.Subject()), zap.Error(err))
+		msg.Term()
+		return
+	}
+
+	op, ok := changeOps[event.Type]
+	if !ok {
+		msg.Ack()
+		return
+	}
+
+	var location domain.Location
+	if err := json.Unmarshal(event.Payload, &location); err != nil {
+		logger.FromCtx(ctx).Error("Error decoding event payload", zap.Int64("event_id", event.ID), zap.Error(err))
+		msg.Term()
+		return
+	}
+
+	n.publish(ctx, &domain.LocationChange{
+		Op:   op,
+		ID:   event.AggregateID,
+		Name: location.Name,
+		Slug: location.Slug,
+	})
+
+	msg.Ack()
+}
+
+// publish calls every subscriber with change
+func (n *NATS) publish(ctx context.Context, change *domain.LocationChange) {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	for _, fn := range n.subs {
+		fn(ctx, change)
+	}
+}
+
+// Close closes the connection to NATS
+func (n *NATS) Close() {
+	n.conn.Close()
+}
@@ -0,0 +1,89 @@
+// Package validation creates the validator of the requests, which also normalizes and checks the names of the
+// locations, aliases, collections and geofences
+package validation
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"leeta/internal/adapter/config"
+
+	"github.com/go-playground/validator/v10"
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	// NameTag normalizes a name with NormalizeName when the field can be set, so structs are validated by pointer,
+	// then refuses control characters and the names out of the length limits
+	NameTag = "name"
+	// MaxNameLength is the longest name the database holds
+	MaxNameLength = 255
+	// defaultMinNameLength is the shortest name when none is configured
+	defaultMinNameLength = 1
+
+	normalizedTag = "name_normalized"
+	charactersTag = "name_characters"
+)
+
+// New creates a validator whose name tag bounds the names to names, counted in characters once normalized.
+// The limits left unset, or a nil configuration, default to 1 and MaxNameLength
+func New(names *config.NamesConfiguration) *validator.Validate {
+	minLength, maxLength := defaultMinNameLength, MaxNameLength
+	if names != nil {
+		if names.MinLength > 0 {
+			minLength = names.MinLength
+		}
+		if names.MaxLength > 0 {
+			maxLength = names.MaxLength
+		}
+	}
+
+	vld := validator.New()
+	// the functions are valid, registering them cannot fail
+	_ = vld.RegisterValidation(normalizedTag, normalized)
+	_ = vld.RegisterValidation(charactersTag, printable)
+	vld.RegisterAlias(NameTag, fmt.Sprintf("%s,%s,min=%d,max=%d", normalizedTag, charactersTag, minLength, maxLength))
+	return vld
+}
+
+// NormalizeName returns name in Unicode normalization form C, so that a letter and its combining accent are
+// the same as the accented letter, without leading and trailing whitespace and with every run of whitespace
+// collapsed to a space
+func NormalizeName(name string) string {
+	return strings.Join(strings.Fields(norm.NFC.String(name)), " ")
+}
+
+// Message returns the message of a validation error, telling what is wrong with the names checked by the name tag
+func Message(err validator.FieldError) string {
+	if err.Tag() != NameTag {
+		return err.Error()
+	}
+
+	switch err.ActualTag() {
+	case charactersTag:
+		return fmt.Sprintf("%s must not contain control characters", err.Field())
+	case "min":
+		if err.Param() == "1" {
+			return fmt.Sprintf("%s must not be blank", err.Field())
+		}
+		return fmt.Sprintf("%s must be at least %s characters long", err.Field(), err.Param())
+	case "max":
+		return fmt.Sprintf("%s must be at most %s characters long", err.Field(), err.Param())
+	}
+	return err.Error()
+}
+
+// normalized normalizes the name of the field when it can be set, it never fails
+func normalized(fl validator.FieldLevel) bool {
+	if field := fl.Field(); field.CanSet() {
+		field.SetString(NormalizeName(field.String()))
+	}
+	return true
+}
+
+// printable reports whether the name of the field has no control characters once normalized, the tabs and
+// line breaks being whitespace
+func printable(fl validator.FieldLevel) bool {
+	return !strings.ContainsFunc(NormalizeName(fl.Field().String()), unicode.IsControl)
+}
@@ -0,0 +1,73 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+
+	"leeta/internal/adapter/config"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeName(t *testing.T) {
+	tests := []struct {
+		name       string
+		normalized string
+	}{
+		{"Lagos \u3000Depot", "Lagos Depot"},
+		{"  Lagos \t Depot\n", "Lagos Depot"},
+		// the e and its combining accent are composed
+		{"Cafe\u0301", "Caf\u00e9"},
+		{"Lagos \u3000Depot", "Lagos Depot"},
+		{"   ", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.normalized, func(t *testing.T) {
+			assert.Equal(t, tt.normalized, NormalizeName(tt.name))
+		})
+	}
+}
+
+func TestNameTag(t *testing.T) {
+	type request struct {
+		Name  string            `validate:"required,name"`
+		Names map[string]string `validate:"omitempty,dive,required,name"`
+	}
+
+	vld := New(&config.NamesConfiguration{MinLength: 3, MaxLength: 10})
+	message := func(err error) string {
+		var errs validator.ValidationErrors
+		require.ErrorAs(t, err, &errs)
+		require.Len(t, errs, 1)
+		return Message(errs[0])
+	}
+
+	t.Run("Normalized before the limits are checked", func(t *testing.T) {
+		req := request{Name: " Yaba   Bu\u0301s "}
+		require.NoError(t, vld.Struct(&req))
+		assert.Equal(t, "Yaba B\u00fas", req.Name)
+	})
+
+	t.Run("Length in characters", func(t *testing.T) {
+		assert.NoError(t, vld.Struct(&request{Name: strings.Repeat("é", 10)}))
+		assert.Equal(t, "Name must be at most 10 characters long", message(vld.Struct(&request{Name: strings.Repeat("é", 11)})))
+		assert.Equal(t, "Name must be at least 3 characters long", message(vld.Struct(&request{Name: " ab "})))
+	})
+
+	t.Run("Control characters", func(t *testing.T) {
+		assert.Equal(t, "Name must not contain control characters", message(vld.Struct(&request{Name: "Ikeja\x7f"})))
+		assert.Equal(t, "Names[fr] must not contain control characters", message(vld.Struct(&request{
+			Name: "Ikeja", Names: map[string]string{"fr": "Ikeja\x00"},
+		})))
+	})
+
+	t.Run("Defaults", func(t *testing.T) {
+		vld := New(nil)
+		assert.NoError(t, vld.Struct(&request{Name: "I"}))
+		assert.Equal(t, "Name must not be blank", message(vld.Struct(&request{Name: "\t"})))
+		assert.Equal(t, "Name must be at most 255 characters long", message(vld.Struct(&request{Name: strings.Repeat("a", 256)})))
+	})
+}
@@ -0,0 +1,68 @@
+// Package auth carries the caller a request is made by through its context, so the services can tell who
+// made a change and for which tenant without knowing how the caller was authenticated
+package auth
+
+import (
+	"context"
+	"slices"
+)
+
+// RoleAdmin is the role of the callers allowed on the admin routes, the admin token has it
+const RoleAdmin = "admin"
+
+type ctxKey struct{}
+
+// Principal is an authenticated caller
+type Principal struct {
+	// User is the subject of the token the caller sent, admin for the admin token
+	User string
+	// Tenant is the tenant the caller acts for, empty when the token names none
+	Tenant string
+	Roles  []string
+}
+
+// HasRole reports whether the principal has role
+func (p *Principal) HasRole(role string) bool {
+	return slices.Contains(p.Roles, role)
+}
+
+// WithPrincipal returns a copy of ctx carrying the principal
+func WithPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, ctxKey{}, p)
+}
+
+// FromCtx returns the principal ctx carries, false for the requests of anonymous callers
+func FromCtx(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(ctxKey{}).(*Principal)
+	return p, ok
+}
+
+// User returns the user ctx is authenticated as, empty for anonymous callers
+func User(ctx context.Context) string {
+	if p, ok := FromCtx(ctx); ok {
+		return p.User
+	}
+	return ""
+}
+
+// Tenant returns the tenant of the caller of ctx, empty for anonymous callers and those without a tenant
+func Tenant(ctx context.Context) string {
+	if p, ok := FromCtx(ctx); ok {
+		return p.Tenant
+	}
+	return ""
+}
+
+type allTenantsCtxKey struct{}
+
+// WithAllTenants returns a copy of ctx acting for every tenant, for the background jobs maintaining the data of
+// all of them. The requests of callers never do
+func WithAllTenants(ctx context.Context) context.Context {
+	return context.WithValue(ctx, allTenantsCtxKey{}, true)
+}
+
+// AllTenants reports whether ctx acts for every tenant
+func AllTenants(ctx context.Context) bool {
+	all, _ := ctx.Value(allTenantsCtxKey{}).(bool)
+	return all
+}
@@ -0,0 +1,18 @@
+package domain
+
+import "time"
+
+// LocationAlias represents a row in the "location_aliases" table, an alternate name a location
+// can be looked up by, such as JFK for John F. Kennedy International Airport
+type LocationAlias struct {
+	ID         string    `json:"id"`
+	LocationID string    `json:"location_id"`
+	Name       string    `json:"name"`
+	Slug       string    `json:"slug"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// CreateLocationAliasRequest adds an alias to a location. It must not be the name of another location
+type CreateLocationAliasRequest struct {
+	Name string `json:"name" validate:"required,name"`
+}
@@ -0,0 +1,20 @@
+package domain
+
+// ChangeOp is the kind of change made to a location
+type ChangeOp string
+
+const (
+	ChangeInsert ChangeOp = "INSERT"
+	ChangeUpdate ChangeOp = "UPDATE"
+	ChangeDelete ChangeOp = "DELETE"
+	// ChangeResync means changes may have been missed, so everything derived from locations must be refreshed
+	ChangeResync ChangeOp = "RESYNC"
+)
+
+// LocationChange describes a change to a location, made by this instance or by one of its peers
+type LocationChange struct {
+	Op   ChangeOp `json:"op"`
+	ID   string   `json:"id,omitempty"`
+	Name string   `json:"name,omitempty"`
+	Slug string   `json:"slug,omitempty"`
+}
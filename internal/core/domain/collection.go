@@ -0,0 +1,17 @@
+package domain
+
+import "time"
+
+// Collection represents a row in the "collections" table, a named set of locations such as
+// "My delivery hubs" that lists and nearest searches can be restricted to
+type Collection struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Slug      string    `json:"slug"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CreateCollectionRequest creates an empty collection
+type CreateCollectionRequest struct {
+	Name string `json:"name" validate:"required,name"`
+}
@@ -0,0 +1,27 @@
+package domain
+
+import "time"
+
+// Device represents an edge device reporting its presence with heartbeats
+type Device struct {
+	ID   string `json:"id"`
+	Name string `json:"name,omitempty"`
+	// Latitude and Longitude are the last coordinates the device reported, if any
+	Latitude     *float64  `json:"latitude,omitempty"`
+	Longitude    *float64  `json:"longitude,omitempty"`
+	RegisteredAt time.Time `json:"registered_at"`
+	LastSeenAt   time.Time `json:"last_seen_at"`
+}
+
+// RegisterDeviceRequest registers a device, registering it again renames it
+type RegisterDeviceRequest struct {
+	ID   string `json:"id" validate:"required,max=64"`
+	Name string `json:"name" validate:"max=255"`
+}
+
+// HeartbeatRequest reports that a device is alive, optionally with its current coordinates
+type HeartbeatRequest struct {
+	DeviceID  string   `json:"device_id" validate:"required,max=64"`
+	Latitude  *float64 `json:"latitude" validate:"required_with=Longitude,omitempty,min=-90,max=90"`
+	Longitude *float64 `json:"longitude" validate:"required_with=Latitude,omitempty,min=-180,max=180"`
+}
@@ -0,0 +1,37 @@
+package domain
+
+import "time"
+
+// EntityPosition represents a row in the "entity_positions" table, the most recent position
+// reported by a moving entity such as a courier or a vehicle
+type EntityPosition struct {
+	EntityID string `json:"entity_id"`
+	// Kind tells the entities apart, e.g. courier or vehicle
+	Kind       string    `json:"kind,omitempty"`
+	Latitude   float64   `json:"latitude"`
+	Longitude  float64   `json:"longitude"`
+	ReportedAt time.Time `json:"reported_at"`
+}
+
+// ReportPositionRequest reports the current position of an entity, it replaces the previous one
+type ReportPositionRequest struct {
+	Kind      string  `json:"kind" validate:"max=32"`
+	Latitude  float64 `json:"latitude" validate:"min=-90,max=90"`
+	Longitude float64 `json:"longitude" validate:"min=-180,max=180"`
+}
+
+// NearestEntitiesRequest searches the entities whose current position is nearest to a point
+type NearestEntitiesRequest struct {
+	Latitude  float64 `validate:"min=-90,max=90"`
+	Longitude float64 `validate:"min=-180,max=180"`
+	Limit     int     `validate:"omitempty,min=1,max=100"`
+	// MaxDistance is in meters, the search is not bounded without it
+	MaxDistance float64 `validate:"omitempty,gt=0"`
+	Kind        string  `validate:"omitempty,max=32"`
+}
+
+// NearestEntity is an entity with the distance of its current position from the point of a search
+type NearestEntity struct {
+	EntityPosition
+	Distance float64 `json:"distance_meters"`
+}
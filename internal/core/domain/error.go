@@ -1,57 +1,203 @@
 package domain
 
 import (
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"runtime"
+	"strings"
+
+	"leeta/internal/core/errcode"
 )
 
-// CError is a custom error interface that also wraps a status code
+// CError is a custom error interface that also wraps a status code and a machine-readable error code.
+// Error returns the message followed by the cause of the error for the logs, Message only the message,
+// which is what clients are sent
 type CError interface {
 	Code() int
+	ErrorCode() errcode.Code
+	Message() string
 	Error() string
 }
 
+// ErrorContext is where an error happened. It is logged with the error but never sent to clients
+type ErrorContext struct {
+	// Op is the operation that failed, such as GetLocationByName
+	Op string
+	// Entity is the kind of the entity operated on, such as location
+	Entity string
+	// Key identifies the entity operated on, such as its name
+	Key string
+}
+
 type err struct {
 	code    int
+	errCode errcode.Code
 	message string
+	cause   error
+	context ErrorContext
+	// stack is a pointer, so errors stay comparable
+	stack *[]uintptr
 }
 
 func (e err) Code() int {
 	return e.code
 }
 
-func (e err) Error() string {
+// ErrorCode returns the code of the error, the generic code of its status when it has none of its own
+func (e err) ErrorCode() errcode.Code {
+	if e.errCode == "" {
+		return errcode.ForStatus(e.code)
+	}
+	return e.errCode
+}
+
+func (e err) Message() string {
 	return e.message
 }
 
+func (e err) Error() string {
+	if e.cause == nil {
+		return e.message
+	}
+	return e.message + ": " + e.cause.Error()
+}
+
+// Unwrap returns the cause of the error, nil if it has none
+func (e err) Unwrap() error {
+	return e.cause
+}
+
+// Context returns where the error happened
+func (e err) Context() ErrorContext {
+	return e.context
+}
+
+// Format prints the error like Error, %+v also prints its context and the stack it was wrapped at.
+// zap logs the latter as errorVerbose
+func (e err) Format(s fmt.State, verb rune) {
+	switch {
+	case verb == 'v' && s.Flag('+'):
+		io.WriteString(s, e.Error())
+		if e.context != (ErrorContext{}) {
+			io.WriteString(s, "\n"+e.context.String())
+		}
+		if e.stack != nil {
+			frames := runtime.CallersFrames(*e.stack)
+			for {
+				frame, more := frames.Next()
+				fmt.Fprintf(s, "\n%s\n\t%s:%d", frame.Function, frame.File, frame.Line)
+				if !more {
+					break
+				}
+			}
+		}
+	case verb == 'q':
+		fmt.Fprintf(s, "%q", e.Error())
+	default:
+		io.WriteString(s, e.Error())
+	}
+}
+
 // NewCError returns a new custom error from code and message
 func NewCError(code int, message string) CError {
-	return err{code, message}
+	return err{code: code, message: message}
+}
+
+// NewCodedCError returns a new custom error from a registered error code and message, its status is the one of code
+func NewCodedCError(code errcode.Code, message string) CError {
+	return err{code: errcode.Status(code), errCode: code, message: message}
 }
 
 // NewUnauthorizedError returns a new custom unauthorized error from message
 func NewUnauthorizedCError(message string) CError {
-	return err{http.StatusUnauthorized, message}
+	return err{code: http.StatusUnauthorized, message: message}
 }
 
 // NewInternalError returns a new custom internal error from message
 func NewInternalCError(message string) CError {
-	return err{http.StatusInternalServerError, message}
+	return err{code: http.StatusInternalServerError, message: message}
 }
 
 // NewBadRequestCError returns a new custom bad request error from message
 func NewBadRequestCError(message string) CError {
-	return err{http.StatusBadRequest, message}
+	return err{code: http.StatusBadRequest, message: message}
+}
+
+// WrapCError returns cerr caused by cause in context, along with the stack of the caller. The status, code
+// and message of cerr are kept, so clients see cerr while the logs show the cause
+func WrapCError(cerr CError, cause error, context ErrorContext) CError {
+	pcs := make([]uintptr, 32)
+	pcs = pcs[:runtime.Callers(2, pcs)]
+
+	return err{
+		code:    cerr.Code(),
+		errCode: cerr.ErrorCode(),
+		message: cerr.Message(),
+		cause:   cause,
+		context: context,
+		stack:   &pcs,
+	}
+}
+
+// ErrorContextOf returns the context of the first error of the chain of err that has one
+func ErrorContextOf(e error) (ErrorContext, bool) {
+	for e != nil {
+		if c, ok := e.(interface{ Context() ErrorContext }); ok && c.Context() != (ErrorContext{}) {
+			return c.Context(), true
+		}
+		e = errors.Unwrap(e)
+	}
+	return ErrorContext{}, false
+}
+
+// String returns the fields of the context that are set, such as op=GetLocationByName entity=location
+func (c ErrorContext) String() string {
+	var fields []string
+	for _, f := range [][2]string{{"op", c.Op}, {"entity", c.Entity}, {"key", c.Key}} {
+		if f[1] != "" {
+			fields = append(fields, f[0]+"="+f[1])
+		}
+	}
+	return strings.Join(fields, " ")
 }
 
 var (
 	// ErrInternal is an error for when an internal service fails to process the request
 	ErrInternal = NewCError(http.StatusInternalServerError, "internal server error")
+	// ErrServiceUnavailable is an error for when a dependency such as the database is down
+	ErrServiceUnavailable = NewCError(http.StatusServiceUnavailable, "service temporarily unavailable")
+	// ErrRoutingDisabled is an error for when travel times are asked for but no routing service is configured
+	ErrRoutingDisabled = NewCodedCError(errcode.RoutingDisabled, "travel times are not available, no routing service is configured")
+	// ErrGeocodingDisabled is an error for when addresses are asked for but no geocoding service is configured
+	ErrGeocodingDisabled = NewCodedCError(errcode.GeocodingDisabled, "address suggestions are not available, no geocoding service is configured")
+	// ErrExportsDisabled is an error for when snapshots are asked for but no object storage bucket is configured
+	ErrExportsDisabled = NewCodedCError(errcode.ExportsDisabled, "scheduled exports are not available, no object storage bucket is configured")
+	// ErrSearchDisabled is an error for when locations are searched but no search engine is configured
+	ErrSearchDisabled = NewCodedCError(errcode.SearchDisabled, "searching the locations is not available, no search engine is configured")
+	// ErrSharesDisabled is an error for when a location is shared but no signing secret is configured
+	ErrSharesDisabled = NewCodedCError(errcode.SharesDisabled, "share links are not available, no signing secret is configured")
+	// ErrBusy is an error for when too many bulk operations are already running or waiting
+	ErrBusy = NewCodedCError(errcode.Busy, "the server is busy with other bulk operations, retry later")
+	// ErrTimeout is an error for when the database did not answer within the statement timeout or the request deadline
+	ErrTimeout = NewCError(http.StatusGatewayTimeout, "the request took too long to process")
+	// ErrPayloadTooLarge is an error for when the request body is larger than allowed
+	ErrPayloadTooLarge = NewCError(http.StatusRequestEntityTooLarge, "request body is too large")
+	// ErrForbidden is an error for when the client is not allowed to reach a route
+	ErrForbidden = NewCError(http.StatusForbidden, "access to this route is not allowed")
+	// ErrTooManyRequests is an error for when a rate limit is exceeded
+	ErrTooManyRequests = NewCError(http.StatusTooManyRequests, "too many requests, retry later")
 	// ErrDataNotFound is an error for when requested data is not found
 	ErrDataNotFound = NewCError(http.StatusNotFound, "data not found")
 	// ErrConflictingData is an error for when data conflicts with existing data
-	ErrConflictingData = NewCError(http.StatusConflict, "data conflicts with existing data in unique column")
+	ErrConflictingData = NewCodedCError(errcode.AlreadyExists, "data conflicts with existing data in unique column")
+	// ErrVersionConflict is an error for when data was modified since the version the update is based on
+	ErrVersionConflict = NewCodedCError(errcode.VersionConflict, "data was modified by another request, fetch it again and retry")
+	// ErrPreconditionFailed is an error for when a resource changed since the precondition of a change
+	ErrPreconditionFailed = NewCodedCError(errcode.PreconditionFailed, "the location changed since the version or date the request is based on")
 	// ErrForeignKeyViolation is an error for when there is a foreign key violation
-	ErrForeignKeyViolation = NewCError(http.StatusConflict, "some of the specified ids were not found")
+	ErrForeignKeyViolation = NewCodedCError(errcode.ReferenceNotFound, "some of the specified ids were not found")
 	// ErrInsufficientPayment is an error for when total paid is less than total price
 	ErrTokenDuration = NewUnauthorizedCError("invalid token duration format")
 	// ErrTokenCreation is an error for when the token creation fails
@@ -71,3 +217,15 @@ var (
 	// ErrInvalidCredentials is an error for when the credentials are invalid
 	ErrInvalidCredentials = NewUnauthorizedCError("invalid email or password")
 )
+
+// PreconditionFailedError is an error for when a location does not satisfy the precondition of a change.
+// It holds the current location, so the client can reapply its change on top of it
+type PreconditionFailedError struct {
+	CError
+	Current *Location
+}
+
+// NewPreconditionFailedError returns ErrPreconditionFailed along with the current location
+func NewPreconditionFailedError(current *Location) *PreconditionFailedError {
+	return &PreconditionFailedError{ErrPreconditionFailed, current}
+}
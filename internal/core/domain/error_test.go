@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"leeta/internal/core/errcode"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWrapCError(t *testing.T) {
+	cause := errors.New("connection reset by peer")
+	cerr := WrapCError(ErrInternal, cause, ErrorContext{Op: "GetLocationByName", Entity: "location", Key: "Lagos Park"})
+
+	assert.Equal(t, http.StatusInternalServerError, cerr.Code())
+	assert.Equal(t, errcode.Internal, cerr.ErrorCode())
+	assert.Equal(t, "internal server error", cerr.Message())
+	assert.Equal(t, "internal server error: connection reset by peer", cerr.Error())
+	assert.ErrorIs(t, cerr, cause)
+
+	context, ok := ErrorContextOf(fmt.Errorf("listing: %w", cerr))
+	assert.True(t, ok)
+	assert.Equal(t, "Lagos Park", context.Key)
+
+	verbose := fmt.Sprintf("%+v", cerr)
+	assert.Contains(t, verbose, "op=GetLocationByName entity=location key=Lagos Park")
+	assert.Contains(t, verbose, "TestWrapCError")
+}
+
+func TestErrorContextOf(t *testing.T) {
+	_, ok := ErrorContextOf(ErrDataNotFound)
+	assert.False(t, ok)
+
+	_, ok = ErrorContextOf(errors.New("boom"))
+	assert.False(t, ok)
+}
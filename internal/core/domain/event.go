@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// EventType is the kind of change an event describes
+type EventType string
+
+const (
+	EventLocationCreated EventType = "location.created"
+	EventLocationUpdated EventType = "location.updated"
+	EventLocationDeleted EventType = "location.deleted"
+	// EventLocationExpired is written once the validity of a location has ended
+	EventLocationExpired EventType = "location.expired"
+)
+
+// Event represents a row in the "outbox" table, a change to a location waiting to be published
+type Event struct {
+	ID          int64           `json:"id"`
+	Type        EventType       `json:"type"`
+	AggregateID string          `json:"aggregate_id"`
+	Payload     json.RawMessage `json:"payload"`
+	// Tenant is the tenant of the location changed, the subscriptions of the other tenants are not matched by it
+	Tenant    string    `json:"-"`
+	CreatedAt time.Time `json:"created_at"`
+}
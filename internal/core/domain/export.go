@@ -0,0 +1,97 @@
+package domain
+
+import "time"
+
+// ExportFormat is a file format of the snapshots of the locations
+type ExportFormat string
+
+const (
+	// ExportGeoJSON is a FeatureCollection with a Point feature per location
+	ExportGeoJSON ExportFormat = "geojson"
+	// ExportCSV has the columns of the CSV imports, so that a snapshot can be imported back
+	ExportCSV ExportFormat = "csv"
+)
+
+// ParseExportFormat returns the export format named s, false if there is none
+func ParseExportFormat(s string) (ExportFormat, bool) {
+	switch format := ExportFormat(s); format {
+	case ExportGeoJSON, ExportCSV:
+		return format, true
+	}
+	return "", false
+}
+
+// StoredObject is an object of an object storage bucket, such as S3
+type StoredObject struct {
+	Key          string
+	Size         int64
+	LastModified time.Time
+}
+
+// Snapshot is an export of every location written to the object storage by the scheduled exports
+type Snapshot struct {
+	Key    string       `json:"key" example:"exports/locations-20261016T020000Z.geojson"`
+	Format ExportFormat `json:"format" example:"geojson"`
+	// Size is in bytes
+	Size      int64     `json:"size" example:"48213"`
+	CreatedAt time.Time `json:"created_at" example:"2026-10-16T02:00:00Z"`
+}
+
+// RestoreTarget is the table a restore reloads a snapshot into
+type RestoreTarget string
+
+const (
+	// RestoreLive makes the approved locations those of the snapshot
+	RestoreLive RestoreTarget = "live"
+	// RestoreShadow loads the snapshot into the locations_shadow table, leaving the locations as they are
+	RestoreShadow RestoreTarget = "shadow"
+)
+
+// RestoreAction is what a restore does to a location to match its snapshot
+type RestoreAction string
+
+const (
+	RestoreCreate RestoreAction = "create"
+	RestoreUpdate RestoreAction = "update"
+	RestoreDelete RestoreAction = "delete"
+)
+
+// RestoreRequest picks the snapshot a restore reloads, and where
+type RestoreRequest struct {
+	// Key is the key of the snapshot, as listed by the admin API
+	Key string `json:"key" validate:"required,max=1024" example:"exports/locations-20261016T020000Z.geojson"`
+	// Target is live by default
+	Target RestoreTarget `json:"target" validate:"omitempty,oneof=live shadow" example:"live"`
+	// DryRun reports the changes the restore would make to the locations without making them
+	DryRun bool `json:"dry_run" example:"true"`
+}
+
+// RestoreChange is a change a restore made, or would make, to a location
+type RestoreChange struct {
+	Action RestoreAction `json:"action"`
+	ID     string        `json:"id"`
+	Name   string        `json:"name"`
+}
+
+// RestorePlan is the changes making the approved locations those of a snapshot
+type RestorePlan struct {
+	Create []Location
+	Update []Location
+	Delete []Location
+}
+
+// RestoreReport is the outcome of a restore, the changes are those between the snapshot and the locations,
+// made for a live restore and left for comparison for a shadow one
+type RestoreReport struct {
+	Key    string        `json:"key"`
+	Target RestoreTarget `json:"target"`
+	DryRun bool          `json:"dry_run"`
+	// Total is the number of locations in the snapshot
+	Total     int `json:"total"`
+	Created   int `json:"created"`
+	Updated   int `json:"updated"`
+	Deleted   int `json:"deleted"`
+	Unchanged int `json:"unchanged"`
+	// Changes are the changes of the restore, only the first ones are kept
+	Changes []RestoreChange `json:"changes"`
+}
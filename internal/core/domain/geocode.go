@@ -0,0 +1,29 @@
+package domain
+
+// AutocompleteRequest is the request for the addresses completing a partly typed one
+type AutocompleteRequest struct {
+	// Query is the address typed so far
+	Query string `validate:"required,min=3,max=200"`
+	// Limit is the number of suggestions, 5 when left out
+	Limit int `validate:"omitempty,min=1,max=10"`
+	// Near ranks the addresses close to a point first, e.g. the center of the map being looked at
+	Near *Point
+	// Language is the language of the suggestions, e.g. en, the local language is used when left out
+	Language string `validate:"omitempty,bcp47_language_tag"`
+}
+
+// AddressSuggestion is an address completing the query of an autocomplete request
+type AddressSuggestion struct {
+	// Label is the whole address, as shown to the user
+	Label       string  `json:"label"`
+	Name        string  `json:"name,omitempty"`
+	HouseNumber string  `json:"house_number,omitempty"`
+	Street      string  `json:"street,omitempty"`
+	Postcode    string  `json:"postcode,omitempty"`
+	City        string  `json:"city,omitempty"`
+	State       string  `json:"state,omitempty"`
+	Country     string  `json:"country,omitempty"`
+	CountryCode string  `json:"country_code,omitempty"`
+	Latitude    float64 `json:"latitude"`
+	Longitude   float64 `json:"longitude"`
+}
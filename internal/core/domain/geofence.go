@@ -0,0 +1,32 @@
+package domain
+
+import "time"
+
+// Point is a pair of coordinates
+type Point struct {
+	Latitude  float64 `json:"latitude" validate:"min=-90,max=90"`
+	Longitude float64 `json:"longitude" validate:"min=-180,max=180"`
+}
+
+// Geofence represents a row in the "geofences" table, a named area
+type Geofence struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+	// Points are the vertices of the polygon bounding the area, in order
+	Points    []Point   `json:"points"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CreateGeofenceRequest creates a geofence. The polygon is closed without repeating
+// the first point at the end, and its edges must not cross
+type CreateGeofenceRequest struct {
+	Name   string  `json:"name" validate:"required,name"`
+	Points []Point `json:"points" validate:"required,min=3,max=1000,dive"`
+}
+
+// ListGeofenceLocationsRequest pages through the locations inside a geofence, by name
+type ListGeofenceLocationsRequest struct {
+	Limit  int `validate:"omitempty,min=1,max=100"`
+	Offset int `validate:"min=0"`
+}
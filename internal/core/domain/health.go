@@ -0,0 +1,33 @@
+package domain
+
+import "time"
+
+// HealthStatus is the status of a dependency, or of the server as a whole
+type HealthStatus string
+
+const (
+	HealthUp HealthStatus = "up"
+	// HealthDegraded means some dependencies the server can do without are down
+	HealthDegraded HealthStatus = "degraded"
+	HealthDown     HealthStatus = "down"
+)
+
+// DependencyHealth is the outcome of checking a dependency of the server
+type DependencyHealth struct {
+	Name   string       `json:"name"`
+	Status HealthStatus `json:"status"`
+	// Critical dependencies take the server down with them, the others only degrade it
+	Critical      bool       `json:"critical"`
+	LatencyMs     float64    `json:"latency_ms"`
+	LastSuccessAt *time.Time `json:"last_success_at,omitempty"`
+	Error         string     `json:"error,omitempty"`
+}
+
+// HealthReport gathers the health of every dependency of the server
+type HealthReport struct {
+	Status       HealthStatus       `json:"status"`
+	Dependencies []DependencyHealth `json:"dependencies"`
+	// Leader tells whether this instance runs the singleton workers, when a leader is elected
+	Leader    *bool     `json:"leader,omitempty"`
+	CheckedAt time.Time `json:"checked_at"`
+}
@@ -0,0 +1,46 @@
+package domain
+
+import "time"
+
+// ImportStatus is the state of an import job
+type ImportStatus string
+
+const (
+	ImportPending   ImportStatus = "pending"
+	ImportRunning   ImportStatus = "running"
+	ImportCompleted ImportStatus = "completed"
+	ImportFailed    ImportStatus = "failed"
+)
+
+// ImportJob tracks the progress of an asynchronous location import
+type ImportJob struct {
+	ID         string       `json:"id"`
+	Status     ImportStatus `json:"status"`
+	Total      int          `json:"total"`
+	Imported   int64        `json:"imported"`
+	Skipped    int64        `json:"skipped"`
+	Error      string       `json:"error,omitempty"`
+	CreatedAt  time.Time    `json:"created_at"`
+	FinishedAt *time.Time   `json:"finished_at,omitempty"`
+}
+
+// ImportPreview reports what importing a file would do, from the records read and checked without writing anything
+type ImportPreview struct {
+	// Format is the format the file was read in, json, csv, geojson or gpx
+	Format  string `json:"format" example:"csv"`
+	Total   int    `json:"total" example:"120"`
+	Valid   int    `json:"valid" example:"118"`
+	Invalid int    `json:"invalid" example:"2"`
+	// Duplicates are the valid records named like an earlier one, ignoring case, which the import skips
+	Duplicates int `json:"duplicates" example:"1"`
+	// Sample holds the first valid records as they would be imported
+	Sample []RegisterLocationRequest `json:"sample"`
+	// Errors holds the problems of the first invalid records
+	Errors []ImportRecordError `json:"errors"`
+}
+
+// ImportRecordError is a problem with a record of an import, numbered from 1 in the order of the file
+type ImportRecordError struct {
+	Record  int    `json:"record" example:"3"`
+	Message string `json:"message" example:"Invalid plus code"`
+}
@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// JobState is the lifecycle state of a queued job
+type JobState string
+
+const (
+	// JobAvailable jobs wait for a worker, including the failed attempts waiting to be retried
+	JobAvailable JobState = "available"
+	JobRunning   JobState = "running"
+	JobCompleted JobState = "completed"
+	// JobFailed jobs failed their last attempt
+	JobFailed    JobState = "failed"
+	JobCancelled JobState = "cancelled"
+)
+
+// JobKindImportLocations is the kind of the jobs importing locations
+const JobKindImportLocations = "import_locations"
+
+// Job represents a row in the "jobs" table, background work that survives restarts
+type Job struct {
+	ID   int64  `json:"id"`
+	Kind string `json:"kind"`
+	// Args are the input of the job, they can be large so they are never sent to clients
+	Args        json.RawMessage `json:"-" swaggerignore:"true"`
+	State       JobState        `json:"state"`
+	Attempt     int             `json:"attempt"`
+	MaxAttempts int             `json:"max_attempts"`
+	// Progress is written by the job as it runs, and kept across its attempts
+	Progress    json.RawMessage `json:"progress,omitempty" swaggertype:"object"`
+	LastError   string          `json:"last_error,omitempty"`
+	ScheduledAt time.Time       `json:"scheduled_at"`
+	AttemptedAt *time.Time      `json:"attempted_at,omitempty"`
+	AttemptedBy string          `json:"attempted_by,omitempty"`
+	FinalizedAt *time.Time      `json:"finalized_at,omitempty"`
+	CreatedAt   time.Time       `json:"created_at"`
+}
+
+// ListJobsRequest filters the jobs listed by the admin API, most recent first
+type ListJobsRequest struct {
+	Kind  string
+	State JobState `validate:"omitempty,oneof=available running completed failed cancelled"`
+	Limit int      `validate:"omitempty,min=1,max=100"`
+}
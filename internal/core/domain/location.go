@@ -2,7 +2,9 @@ package domain
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
 	"time"
 )
 
@@ -13,32 +15,427 @@ type Location struct {
 	Slug      string    `json:"slug"`
 	Latitude  float64   `json:"latitude"`
 	Longitude float64   `json:"longitude"`
+	Category  string    `json:"category,omitempty"`
 	CreatedAt time.Time `json:"created_at"`
+	// Version is incremented by every update, updates must send the version they were based on
+	Version   int64     `json:"version"`
+	UpdatedAt time.Time `json:"updated_at"`
+	// Names are the names of the location translated per locale, Name stays the canonical name
+	Names map[string]string `json:"names,omitempty"`
+	// Status is pending while the location waits for review, only approved locations are public
+	Status       LocationStatus `json:"status"`
+	ReviewReason string         `json:"review_reason,omitempty"`
+	// PlusCode is the Open Location Code of the coordinates, it is derived from them rather than stored
+	PlusCode string `json:"plus_code,omitempty"`
+	// Altitude is in meters above sea level, for locations off the ground such as drone pads or floors of a building
+	Altitude *float64 `json:"altitude,omitempty"`
+	// Entrances are the points the location is reached from, nearest searches measure to the closest one
+	Entrances []Entrance `json:"entrances,omitempty"`
+	// Footprint is the boundary of a location spreading over an area, nearest searches without entrances
+	// measure to its edge
+	Footprint *Polygon `json:"footprint,omitempty"`
+	// Source is the name of the external source the location is synced from, empty for the others
+	Source string `json:"source,omitempty"`
+	// ValidFrom and ValidUntil bound the time a location is listed and found by nearest searches, such as
+	// a pop-up store or an event. A location without them is always valid
+	ValidFrom  *time.Time `json:"valid_from,omitempty"`
+	ValidUntil *time.Time `json:"valid_until,omitempty"`
+	// Expired is set once ValidUntil has passed
+	Expired bool `json:"expired,omitempty"`
+	// NearbyDuplicates are the locations at the coordinates of a location just registered, listed with the
+	// warn duplicate policy
+	NearbyDuplicates []Location `json:"nearby_duplicates,omitempty"`
+}
+
+// Entrance is a point a location is reached from, such as a door of a mall
+type Entrance struct {
+	Name      string  `json:"name,omitempty" validate:"omitempty,max=255"`
+	Latitude  float64 `json:"latitude" validate:"min=-90,max=90"`
+	Longitude float64 `json:"longitude" validate:"min=-180,max=180"`
 }
 
 type RegisterLocationRequest struct {
-	Name      string  `json:"name" validate:"required"`
-	Latitude  float64 `json:"latitude" validate:"required,min=-90,max=90"`
-	Longitude float64 `json:"longitude" validate:"required,min=-180,max=180"`
+	Name      string  `json:"name" validate:"required,name"`
+	Latitude  float64 `json:"latitude" validate:"required_without=PlusCode,min=-90,max=90"`
+	Longitude float64 `json:"longitude" validate:"required_without=PlusCode,min=-180,max=180"`
+	// PlusCode is a full Open Location Code such as 6FR5G9JC+XH, sent instead of the coordinates
+	PlusCode string `json:"plus_code" validate:"omitempty,max=20"`
+	// Altitude is in meters above sea level, distances to a location without one are measured on the ground
+	Altitude *float64 `json:"altitude" validate:"omitempty,min=-1000,max=20000"`
+	Category string   `json:"category" validate:"omitempty,max=100"`
+	// Names are translated names keyed by a BCP 47 locale such as en or fr-CA
+	Names map[string]string `json:"names" validate:"omitempty,max=50,dive,keys,bcp47_language_tag,endkeys,required,name"`
+	// Entrances are the points the location is reached from, up to 50, such as the doors of a mall
+	Entrances []Entrance `json:"entrances" validate:"omitempty,max=50,dive"`
+	// Footprint is the boundary of a location spreading over an area, such as a campus or a park
+	Footprint *Polygon `json:"footprint" validate:"omitempty"`
+	// ValidFrom and ValidUntil bound the time the location is valid, ValidUntil must come after ValidFrom
+	ValidFrom  *time.Time `json:"valid_from"`
+	ValidUntil *time.Time `json:"valid_until"`
+	// Review keeps the location out of public queries until it is approved, it is not read from the body
+	Review bool `json:"-"`
+}
+
+// Precondition is the state a location must still be in for a change to apply, as sent in the If-Match
+// and If-Unmodified-Since headers. The zero value holds for any location
+type Precondition struct {
+	// Version is the version the location must have, 0 for any
+	Version int64
+	// UnmodifiedSince is the time the location must not have been updated after, zero for any
+	UnmodifiedSince time.Time
+}
+
+// IsZero reports whether p holds for any location
+func (p Precondition) IsZero() bool {
+	return p.Version == 0 && p.UnmodifiedSince.IsZero()
+}
+
+// Holds reports whether location satisfies p. HTTP dates have no fraction of a second, so the update
+// time of the location is compared without it
+func (p Precondition) Holds(location *Location) bool {
+	if p.Version != 0 && location.Version != p.Version {
+		return false
+	}
+	if !p.UnmodifiedSince.IsZero() && location.UpdatedAt.Truncate(time.Second).After(p.UnmodifiedSince) {
+		return false
+	}
+	return true
+}
+
+// UpdateLocationRequest replaces the details of a location. Version is the version
+// of the location the update is based on, it can also be sent in an If-Match header.
+// A location on the equator or the prime meridian has a zero coordinate, so whether
+// the coordinates were sent is checked by the handler
+type UpdateLocationRequest struct {
+	Name      string  `json:"name" validate:"required,name"`
+	Latitude  float64 `json:"latitude" validate:"min=-90,max=90"`
+	Longitude float64 `json:"longitude" validate:"min=-180,max=180"`
+	// Altitude is in meters above sea level, it is cleared when left out
+	Altitude *float64 `json:"altitude" validate:"omitempty,min=-1000,max=20000"`
+	Category string   `json:"category" validate:"omitempty,max=100"`
+	Version  int64    `json:"version" validate:"required,min=1"`
+	// Names replace the translated names of the location, they are kept when left out and cleared when empty
+	Names map[string]string `json:"names" validate:"omitempty,max=50,dive,keys,bcp47_language_tag,endkeys,required,name"`
+	// Entrances replace the entrances of the location, they are kept when left out and cleared when empty
+	Entrances []Entrance `json:"entrances" validate:"omitempty,max=50,dive"`
+	// Footprint is the boundary of the location, it is cleared when left out
+	Footprint *Polygon `json:"footprint" validate:"omitempty"`
+	// ValidFrom and ValidUntil bound the time the location is valid, they are cleared when left out
+	ValidFrom  *time.Time `json:"valid_from"`
+	ValidUntil *time.Time `json:"valid_until"`
+	// Precondition is read from the headers of the request, a location failing it is not updated
+	Precondition Precondition `json:"-"`
+}
+
+// CheckValidity reports a validity that ends before it starts, either bound can be left out
+func CheckValidity(from, until *time.Time) error {
+	if from != nil && until != nil && !until.After(*from) {
+		return errors.New("valid_until must come after valid_from")
+	}
+	return nil
+}
+
+// Polygon is a GeoJSON polygon. Its first ring is the boundary and the others are holes in it, each ring
+// being a closed list of [longitude, latitude] positions whose last position repeats the first
+type Polygon struct {
+	Type        string        `json:"type" validate:"eq=Polygon"`
+	Coordinates [][][]float64 `json:"coordinates" validate:"min=1,max=100,dive,min=4,max=1000,dive,min=2,max=3"`
+}
+
+// CheckRings reports a ring of the polygon that is not closed or has a position out of range. Whether
+// its edges cross is left to the database
+func (p *Polygon) CheckRings() error {
+	for i, ring := range p.Coordinates {
+		for _, position := range ring {
+			if len(position) < 2 || math.Abs(position[0]) > 180 || math.Abs(position[1]) > 90 {
+				return fmt.Errorf("ring %d of the footprint has a position out of range", i+1)
+			}
+		}
+		if len(ring) == 0 || ring[0][0] != ring[len(ring)-1][0] || ring[0][1] != ring[len(ring)-1][1] {
+			return fmt.Errorf("ring %d of the footprint is not closed, its last position must repeat the first", i+1)
+		}
+	}
+
+	return nil
+}
+
+// ListLocationsRequest holds the options for listing locations
+type ListLocationsRequest struct {
+	// Query is a filter such as `category:park AND created_at>2024-01-01`
+	Query string
+	// Collection restricts the list to the locations of the collection with this name or slug
+	Collection string
+	// IncludeExpired lists the locations outside their validity too
+	IncludeExpired bool
+	// Sort is the order of the list, the most recently created locations first when empty
+	Sort LocationSort
+}
+
+// LocationSort is the order of a list of locations, most recent first
+type LocationSort string
+
+const (
+	// SortCreated lists the most recently created locations first
+	SortCreated LocationSort = "created_at"
+	// SortUpdated lists the most recently changed locations first
+	SortUpdated LocationSort = "updated_at"
+)
+
+// ParseLocationSort parses created_at or updated_at, it reports false for any other order
+func ParseLocationSort(s string) (LocationSort, bool) {
+	switch sort := LocationSort(s); sort {
+	case SortCreated, SortUpdated:
+		return sort, true
+	}
+	return "", false
+}
+
+// LocationGeometryRequest picks the locations whose geometry is computed, by filter like a list or by slug
+type LocationGeometryRequest struct {
+	// Query is a filter such as `category:park AND created_at>2024-01-01`
+	Query string `json:"query"`
+	// Collection restricts the locations to those of the collection with this name or slug
+	Collection string `json:"collection" validate:"omitempty,max=255"`
+	// Slugs picks the locations by slug instead of a filter
+	Slugs []string `json:"slugs" validate:"excluded_with=Query Collection,omitempty,max=1000,dive,required,max=255"`
+}
+
+// LocationGeometry describes where a set of locations lies, e.g. to fit a map to them
+type LocationGeometry struct {
+	// Count is the number of locations, the geometries are left out when there is none
+	Count int `json:"count"`
+	// Centroid is the GeoJSON point at the center of the locations
+	Centroid json.RawMessage `json:"centroid,omitempty" swaggertype:"object"`
+	// BoundingBox is the GeoJSON bbox of the locations, their least and greatest longitude and latitude
+	BoundingBox []float64 `json:"bbox,omitempty"`
+	// ConvexHull is the smallest convex GeoJSON polygon holding the locations. It is a point or a line
+	// string when the locations are fewer than 3 or in a line
+	ConvexHull json.RawMessage `json:"convex_hull,omitempty" swaggertype:"object"`
+}
+
+// MaxLookupLocations is the number of ids and slugs a lookup accepts at most
+const MaxLookupLocations = 1000
+
+// LookupLocationsRequest picks locations by id or slug, e.g. for a client to reconcile its cache in one request
+type LookupLocationsRequest struct {
+	IDs   []string `json:"ids" validate:"required_without=Slugs,omitempty,max=1000,dive,required,max=255"`
+	Slugs []string `json:"slugs" validate:"required_without=IDs,omitempty,max=1000,dive,required,max=255"`
+}
+
+// LocationLookup holds the locations a lookup found and the ids and slugs it did not
+type LocationLookup struct {
+	Found   []Location     `json:"found"`
+	Missing MissingLookups `json:"missing"`
+}
+
+// MissingLookups are the ids and slugs of a lookup matching no location, as they were requested
+type MissingLookups struct {
+	IDs   []string `json:"ids"`
+	Slugs []string `json:"slugs"`
+}
+
+// NearestLocationsRequest is the body of a nearest search that is too rich for a query string. A point on the
+// equator or the prime meridian has a zero coordinate, so whether the coordinates were sent is checked by the handler
+type NearestLocationsRequest struct {
+	Latitude  float64 `json:"latitude" validate:"min=-90,max=90"`
+	Longitude float64 `json:"longitude" validate:"min=-180,max=180"`
+	// PlusCode is a full Open Location Code such as 6FR5G9JC+XH, sent instead of the coordinates
+	PlusCode string `json:"plus_code" validate:"omitempty,max=20"`
+	// Altitude of the query point in meters above sea level. Distances to the locations with an altitude are
+	// then measured in 3D, and on the ground to the others
+	Altitude    *float64 `json:"altitude" validate:"omitempty,min=-1000,max=20000"`
+	Limit       int      `json:"limit" validate:"omitempty,min=1,max=100"`
+	MaxDistance float64  `json:"max_distance" validate:"omitempty,gt=0"`
+	Categories  []string `json:"categories" validate:"omitempty,max=20,dive,required,max=100"`
+	Exclude     []string `json:"exclude" validate:"omitempty,max=100,dive,required"`
+	// Unit is the unit of the formatted distances, m, km or mi
+	Unit DistanceUnit `json:"unit" validate:"omitempty,oneof=m km mi"`
+	// Formula is how distances are computed, spheroid, haversine or vincenty
+	Formula DistanceFormula `json:"formula" validate:"omitempty,oneof=spheroid haversine vincenty"`
+	// MaxTravelTime keeps the locations reachable within this many seconds with the mode of travel,
+	// they are then ordered by travel time
+	MaxTravelTime int `json:"max_travel_time" validate:"omitempty,min=1,max=7200"`
+	// Mode is the mode of travel of MaxTravelTime, driving, walking or cycling. Defaults to driving
+	Mode TravelMode `json:"mode" validate:"omitempty,oneof=driving walking cycling"`
+	// Collection restricts the search to the locations of the collection with this name or slug
+	Collection string `json:"collection" validate:"omitempty,max=255"`
+	// IncludeExpired searches the locations outside their validity too
+	IncludeExpired bool `json:"include_expired"`
+}
+
+// TravelMode is how the road network is travelled when computing travel times
+type TravelMode string
+
+const (
+	TravelDriving TravelMode = "driving"
+	TravelWalking TravelMode = "walking"
+	TravelCycling TravelMode = "cycling"
+)
+
+// ParseTravelMode parses driving, walking or cycling, it reports false for any other mode
+func ParseTravelMode(s string) (TravelMode, bool) {
+	switch mode := TravelMode(s); mode {
+	case TravelDriving, TravelWalking, TravelCycling:
+		return mode, true
+	}
+	return "", false
+}
+
+// DistanceFormula is how the distance between two points is computed
+type DistanceFormula string
+
+const (
+	// DistanceSpheroid is the geodesic on the WGS 84 spheroid computed by PostGIS, the most accurate
+	DistanceSpheroid DistanceFormula = "spheroid"
+	// DistanceHaversine is the great circle on a sphere, faster but off by up to 0.5%
+	DistanceHaversine DistanceFormula = "haversine"
+	// DistanceVincenty is Vincenty's formula on the WGS 84 spheroid
+	DistanceVincenty DistanceFormula = "vincenty"
+)
+
+// ParseDistanceFormula parses spheroid, haversine or vincenty, it reports false for any other formula
+func ParseDistanceFormula(s string) (DistanceFormula, bool) {
+	switch formula := DistanceFormula(s); formula {
+	case DistanceSpheroid, DistanceHaversine, DistanceVincenty:
+		return formula, true
+	}
+	return "", false
+}
+
+// DuplicatePolicy is what registering a location at the coordinates of an existing location does
+type DuplicatePolicy string
+
+const (
+	// DuplicatesAllow registers the location like any other, such as the stores of franchises sharing a mall
+	DuplicatesAllow DuplicatePolicy = "allow"
+	// DuplicatesWarn registers the location and lists the locations at its coordinates in its NearbyDuplicates
+	DuplicatesWarn DuplicatePolicy = "warn"
+	// DuplicatesReject refuses the location with a conflict, for points of interest that are unique
+	DuplicatesReject DuplicatePolicy = "reject"
+)
+
+// ParseDuplicatePolicy parses allow, warn or reject, it reports false for any other policy
+func ParseDuplicatePolicy(s string) (DuplicatePolicy, bool) {
+	switch policy := DuplicatePolicy(s); policy {
+	case DuplicatesAllow, DuplicatesWarn, DuplicatesReject:
+		return policy, true
+	}
+	return "", false
+}
+
+// DistanceUnit is the unit distances are formatted in
+type DistanceUnit string
+
+const (
+	DistanceUnitMeters     DistanceUnit = "m"
+	DistanceUnitKilometers DistanceUnit = "km"
+	DistanceUnitMiles      DistanceUnit = "mi"
+)
+
+const metersPerMile = 1609.344
+
+// ParseDistanceUnit parses m, km or mi, it reports false for any other unit
+func ParseDistanceUnit(s string) (DistanceUnit, bool) {
+	switch unit := DistanceUnit(s); unit {
+	case DistanceUnitMeters, DistanceUnitKilometers, DistanceUnitMiles:
+		return unit, true
+	}
+	return "", false
+}
+
+// Format formats a distance in meters in the unit. Without a unit distances below
+// a kilometer are in meters and the others in kilometers
+func (u DistanceUnit) Format(meters float64) string {
+	switch {
+	case u == DistanceUnitMiles:
+		return fmt.Sprintf("%.2f miles", meters/metersPerMile)
+	case u == DistanceUnitKilometers, u == "" && meters >= 1000:
+		return fmt.Sprintf("%.2f kilometers", meters/1000)
+	default:
+		return fmt.Sprintf("%.2f meters", meters)
+	}
 }
 
 type NearestLocation struct {
 	Location
-	Distance float64 `json:"distance"`
+	// Distance is in meters
+	Distance float64 `json:"distance_meters"`
+	// Bearing is in degrees clockwise from north, from the query point to the location
+	Bearing float64 `json:"bearing"`
+	// Direction is the compass point of the bearing, e.g. NE
+	Direction string `json:"direction"`
+	// TravelTime is in seconds from the query point, only set when searching by travel time
+	TravelTime *float64 `json:"travel_time_seconds,omitempty"`
+	// Entrance is the entrance closest to the query point of a location with entrances, the distance,
+	// bearing and travel time are to it
+	Entrance *Entrance `json:"entrance,omitempty"`
+	// Alternatives are the next nearest locations, for clients offering a fallback when the nearest is closed or
+	// full. They are only set on request
+	Alternatives []NearestLocation `json:"alternatives,omitempty"`
+	// Unit is the unit the distance is formatted in for clients
+	Unit DistanceUnit `json:"-"`
 }
 
+// MarshalJSON writes the distance in meters, for clients sorting or comparing distances,
+// along with the distance formatted in the unit for display
 func (n *NearestLocation) MarshalJSON() ([]byte, error) {
-	var distance = fmt.Sprintf("%.2f meters", n.Distance)
+	return json.Marshal(struct {
+		Location
+		DistanceMeters float64           `json:"distance_meters"`
+		DistanceText   string            `json:"distance_text"`
+		Bearing        float64           `json:"bearing"`
+		Direction      string            `json:"direction"`
+		TravelTime     *float64          `json:"travel_time_seconds,omitempty"`
+		Entrance       *Entrance         `json:"entrance,omitempty"`
+		Alternatives   []NearestLocation `json:"alternatives,omitempty"`
+	}{
+		Location:       n.Location,
+		DistanceMeters: n.Distance,
+		DistanceText:   n.Unit.Format(n.Distance),
+		Bearing:        math.Round(n.Bearing*10) / 10,
+		Direction:      n.Direction,
+		TravelTime:     n.TravelTime,
+		Entrance:       n.Entrance,
+		Alternatives:   n.Alternatives,
+	})
+}
 
-	if n.Distance >= 1000 {
-		distance = fmt.Sprintf("%.2f kilometers", n.Distance/1000)
-	}
+// AlongRouteRequest searches the locations within a buffer distance of a route, given
+// either as an encoded polyline or as waypoints
+type AlongRouteRequest struct {
+	// Polyline is the route in the encoded polyline algorithm format, with 5 decimal places
+	Polyline  string  `json:"polyline" validate:"required_without=Waypoints,excluded_with=Waypoints,max=100000"`
+	Waypoints []Point `json:"waypoints" validate:"required_without=Polyline,omitempty,min=2,max=1000,dive"`
+	// Buffer is the greatest distance in meters from the route to a location
+	Buffer     float64  `json:"buffer" validate:"required,gt=0,max=50000"`
+	Limit      int      `json:"limit" validate:"omitempty,min=1,max=100"`
+	Categories []string `json:"categories" validate:"omitempty,max=20,dive,required,max=100"`
+	// Unit is the unit of the formatted distances, m, km or mi
+	Unit DistanceUnit `json:"unit" validate:"omitempty,oneof=m km mi"`
+}
+
+// RouteLocation is a location found along a route
+type RouteLocation struct {
+	Location
+	// Distance is in meters, from the location to the closest point of the route
+	Distance float64 `json:"distance_meters"`
+	// Fraction is how far along the route its closest point to the location is, from 0 at the start to 1 at the end
+	Fraction float64 `json:"route_fraction"`
+	// Unit is the unit the distance is formatted in for clients
+	Unit DistanceUnit `json:"-"`
+}
 
+// MarshalJSON writes the distance in meters along with the distance formatted in the unit, like NearestLocation
+func (l *RouteLocation) MarshalJSON() ([]byte, error) {
 	return json.Marshal(struct {
 		Location
-		Distance string `json:"distance"`
+		DistanceMeters float64 `json:"distance_meters"`
+		DistanceText   string  `json:"distance_text"`
+		RouteFraction  float64 `json:"route_fraction"`
 	}{
-		Location: n.Location,
-		Distance: distance,
+		Location:       l.Location,
+		DistanceMeters: l.Distance,
+		DistanceText:   l.Unit.Format(l.Distance),
+		RouteFraction:  math.Round(l.Fraction*10000) / 10000,
 	})
 }
@@ -0,0 +1,24 @@
+package domain
+
+// LocationStatus is where a location stands in the review of submissions, only approved locations are public
+type LocationStatus string
+
+const (
+	LocationPending  LocationStatus = "pending"
+	LocationApproved LocationStatus = "approved"
+	LocationRejected LocationStatus = "rejected"
+	// LocationRemoved locations were dropped by the external source they are synced from, they are
+	// hidden like rejected ones and restored if the source lists them again
+	LocationRemoved LocationStatus = "removed"
+)
+
+// ListPendingLocationsRequest pages through the locations waiting for review, oldest submission first
+type ListPendingLocationsRequest struct {
+	Limit  int `validate:"omitempty,min=1,max=100"`
+	Offset int `validate:"min=0"`
+}
+
+// RejectLocationRequest rejects a location waiting for review, the reason is kept with it
+type RejectLocationRequest struct {
+	Reason string `json:"reason" validate:"required,max=500"`
+}
@@ -0,0 +1,29 @@
+package domain
+
+import "time"
+
+// NotificationType is the kind of occurrence operators are notified about
+type NotificationType string
+
+const (
+	NotificationImportFailed    NotificationType = "import.failed"
+	NotificationBreakerOpened   NotificationType = "breaker.opened"
+	NotificationLocationDeleted NotificationType = "location.deleted"
+	NotificationSyncFailed      NotificationType = "sync.failed"
+	NotificationPanic           NotificationType = "request.panicked"
+)
+
+// NotificationField is a named detail of a notification
+type NotificationField struct {
+	Name  string
+	Value string
+}
+
+// Notification is an alert sent to the operators
+type Notification struct {
+	Type      NotificationType
+	Title     string
+	Text      string
+	Fields    []NotificationField
+	CreatedAt time.Time
+}
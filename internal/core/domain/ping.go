@@ -1,6 +1,37 @@
 package domain
 
-// Ping is an entity that represents a ping object
+import "time"
+
+// Ping is an entity that represents a heartbeat sent to the server
 type Ping struct {
-	Name string
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	// Source is the address the ping came from
+	Source string `json:"source"`
+	// LatencyMs is the round trip time the client measured for its previous ping, 0 if unknown
+	LatencyMs float64   `json:"latency_ms"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// PingRequest is the body of a ping
+type PingRequest struct {
+	Name      string  `json:"name" validate:"max=100"`
+	LatencyMs float64 `json:"latency_ms" validate:"min=0"`
+}
+
+// PingStats summarizes the uptime of the server and the pings it received
+type PingStats struct {
+	StartedAt     time.Time `json:"started_at"`
+	UptimeSeconds int64     `json:"uptime_seconds"`
+	PingsLastHour int64     `json:"pings_last_hour"`
+	PingsLastDay  int64     `json:"pings_last_day"`
+	// Availability is the share of the minutes of the last day, counted from the first ping
+	// of the day, in which at least one ping was received
+	Availability     float64    `json:"availability"`
+	AverageLatencyMs float64    `json:"average_latency_ms"`
+	LastPingAt       *time.Time `json:"last_ping_at,omitempty"`
+
+	// FirstPingAt and ActiveMinutes are read from the pings of the last day to compute the availability
+	FirstPingAt   *time.Time `json:"-"`
+	ActiveMinutes int64      `json:"-"`
 }
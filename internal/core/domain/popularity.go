@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TrendingLocationsRequest searches the locations with the most hits, a get or a nearest search
+// returning them, within a window up to now. With Near set only the locations within Radius
+// meters of it are searched
+type TrendingLocationsRequest struct {
+	Window time.Duration
+	Limit  int    `validate:"omitempty,min=1,max=100"`
+	Near   *Point `validate:"omitempty"`
+	// Radius is in meters, it defaults to 5 km
+	Radius   float64 `validate:"omitempty,gt=0,max=100000"`
+	Category string  `validate:"omitempty,max=100"`
+}
+
+// TrendingLocation is a location with its number of hits within the window of the search
+type TrendingLocation struct {
+	Location
+	Hits int64 `json:"hits"`
+}
+
+// ParseWindow parses a window such as 24h, 90m or 7d, in days or in the units of time.ParseDuration.
+// It reports false for anything else and for windows that are not positive
+func ParseWindow(s string) (time.Duration, bool) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n <= 0 {
+			return 0, false
+		}
+		return time.Duration(n) * 24 * time.Hour, true
+	}
+
+	window, err := time.ParseDuration(s)
+	if err != nil || window <= 0 {
+		return 0, false
+	}
+	return window, true
+}
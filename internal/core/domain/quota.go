@@ -0,0 +1,54 @@
+package domain
+
+import "time"
+
+// QuotaResource is a kind of resource the tenants have a quota of
+type QuotaResource string
+
+const (
+	QuotaLocations QuotaResource = "locations"
+	// QuotaWebhooks are the subscriptions delivering their matches to a webhook
+	QuotaWebhooks QuotaResource = "webhooks"
+)
+
+// TenantQuota is the quota set for a tenant by the admins. A limit left nil is the default one of the
+// configuration, and 0 lifts it
+type TenantQuota struct {
+	Tenant       string
+	MaxLocations *int
+	MaxWebhooks  *int
+	UpdatedAt    time.Time
+}
+
+// Quota is the quota of a tenant with its usage
+type Quota struct {
+	Tenant    string     `json:"tenant" example:"acme"`
+	Locations QuotaUsage `json:"locations"`
+	Webhooks  QuotaUsage `json:"webhooks"`
+	// UpdatedAt is the time the quota was last set by the admins, left out for a tenant with the defaults
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// QuotaUsage is the limit of a resource of a tenant and how much of it is used
+type QuotaUsage struct {
+	// Limit is the number of resources the tenant may have, 0 for no limit
+	Limit int `json:"limit" example:"1000"`
+	Used  int `json:"used" example:"120"`
+	// Default is set when the limit is the default one of the configuration
+	Default bool `json:"default" example:"true"`
+}
+
+// CostLimits bound the requests that cost the database the most, a limit of 0 lifts it
+type CostLimits struct {
+	// MaxRadius is the greatest max_distance in meters of a nearest search without categories
+	MaxRadius float64
+	// MaxExportLocations is the greatest number of locations a tenant may have to export them without a filter
+	MaxExportLocations int
+}
+
+// SetQuotaRequest is the request setting the quota of a tenant. A limit left out or null resets it to
+// the default one, and 0 lifts it
+type SetQuotaRequest struct {
+	MaxLocations *int `json:"max_locations" validate:"omitempty,min=0" example:"1000"`
+	MaxWebhooks  *int `json:"max_webhooks" validate:"omitempty,min=0" example:"10"`
+}
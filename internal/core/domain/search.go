@@ -0,0 +1,22 @@
+package domain
+
+// SearchLocationsRequest is the request for the locations matching a free text query, misspelt words included
+type SearchLocationsRequest struct {
+	// Query is the text searched in the names, translated names and categories of the locations
+	Query string `validate:"required,max=200"`
+	// Categories narrow the search to the locations of one of them
+	Categories []string `validate:"max=20,dive,required,max=100"`
+	// Limit is the number of locations, 20 when left out
+	Limit int `validate:"omitempty,min=1,max=100"`
+	// Offset is the number of matching locations skipped, to page through them
+	Offset int `validate:"min=0,max=1000"`
+}
+
+// LocationSearchResult is a page of the locations matching a search, best match first
+type LocationSearchResult struct {
+	Locations []Location `json:"locations"`
+	// Total is the estimated number of matching locations, across every page
+	Total int `json:"total" example:"42"`
+	// Categories is the number of matching locations of each category, across every page
+	Categories map[string]int `json:"categories"`
+}
@@ -0,0 +1,25 @@
+package domain
+
+import "time"
+
+// LocationShare is a signed link granting read access to a single location without authentication, until
+// it expires or is revoked
+type LocationShare struct {
+	ID         string `json:"id" example:"0b8a3f5e-6f4c-4d2a-9c1e-2f7b5d8e4a10"`
+	LocationID string `json:"location_id" example:"5f1d7c2e-8b3a-4e6f-a9d0-1c2b3a4d5e6f"`
+	// URL is the link, its token is signed with the expiry of the share
+	URL string `json:"url" example:"https://api.example.com/v1/shared/0b8a3f5e-6f4c-4d2a-9c1e-2f7b5d8e4a10.1767225600.c2lnbmF0dXJl"`
+	// Tenant is the tenant of the caller that shared the location, the link reads the location for it
+	Tenant    string    `json:"-"`
+	CreatedBy string    `json:"created_by,omitempty" example:"ada"`
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+	// RevokedAt is set once the share is revoked, its link is refused from then on
+	RevokedAt *time.Time `json:"-"`
+}
+
+// ShareLocationRequest is the request sharing a location
+type ShareLocationRequest struct {
+	// ExpiresIn is the number of seconds the link is valid for, the configured default when left out
+	ExpiresIn int `json:"expires_in" validate:"omitempty,min=60" example:"86400"`
+}
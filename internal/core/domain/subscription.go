@@ -0,0 +1,49 @@
+package domain
+
+import "time"
+
+// SubscriptionTarget is what a proximity subscription watches, locations, entities or all
+type SubscriptionTarget string
+
+const (
+	SubscriptionTargetLocations SubscriptionTarget = "locations"
+	SubscriptionTargetEntities  SubscriptionTarget = "entities"
+	SubscriptionTargetAll       SubscriptionTarget = "all"
+)
+
+// Subscription represents a row in the "subscriptions" table, a standing query matched by every
+// location and entity that appears within Radius meters of a point
+type Subscription struct {
+	ID        string             `json:"id"`
+	Target    SubscriptionTarget `json:"target"`
+	Latitude  float64            `json:"latitude"`
+	Longitude float64            `json:"longitude"`
+	Radius    float64            `json:"radius_meters"`
+	// Tenant is the tenant of the caller that created the subscription, only its locations and entities match it
+	Tenant string `json:"-"`
+	// Kind is the category of the locations or the kind of the entities matched, any when empty
+	Kind string `json:"kind,omitempty"`
+	// WebhookURL receives the matches, they are also sent to the WebSocket clients of the subscription
+	WebhookURL string    `json:"webhook_url,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// CreateSubscriptionRequest registers a proximity subscription
+type CreateSubscriptionRequest struct {
+	Target     SubscriptionTarget `json:"target" validate:"required,oneof=locations entities all"`
+	Latitude   float64            `json:"latitude" validate:"min=-90,max=90"`
+	Longitude  float64            `json:"longitude" validate:"min=-180,max=180"`
+	Radius     float64            `json:"radius_meters" validate:"gt=0,max=50000"`
+	Kind       string             `json:"kind" validate:"max=100"`
+	WebhookURL string             `json:"webhook_url" validate:"omitempty,http_url,max=2048"`
+}
+
+// ProximityMatch is a location or an entity that appeared within the area of a subscription,
+// only one of Location and Entity is set
+type ProximityMatch struct {
+	SubscriptionID string          `json:"subscription_id"`
+	Location       *Location       `json:"location,omitempty"`
+	Entity         *EntityPosition `json:"entity,omitempty"`
+	Distance       float64         `json:"distance_meters"`
+	MatchedAt      time.Time       `json:"matched_at"`
+}
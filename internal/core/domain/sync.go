@@ -0,0 +1,60 @@
+package domain
+
+import "time"
+
+// SyncStatus is where a sync from an external source stands
+type SyncStatus string
+
+const (
+	SyncRunning   SyncStatus = "running"
+	SyncSucceeded SyncStatus = "succeeded"
+	SyncFailed    SyncStatus = "failed"
+)
+
+// SyncAction is what a sync does to a location to match its source
+type SyncAction string
+
+const (
+	SyncCreate SyncAction = "create"
+	SyncUpdate SyncAction = "update"
+	// SyncRemove hides a location its source no longer lists
+	SyncRemove SyncAction = "remove"
+	// SyncRestore makes a removed location public again as its source lists it again
+	SyncRestore SyncAction = "restore"
+)
+
+// SyncChange is a change a sync made, or would make in a dry run, to a location
+type SyncChange struct {
+	Action SyncAction `json:"action"`
+	Name   string     `json:"name"`
+	// Error is why the change failed, the location is left as it was
+	Error string `json:"error,omitempty"`
+}
+
+// SyncReport represents a row in the "location_syncs" table, the outcome of a sync from an external source
+type SyncReport struct {
+	ID     string     `json:"id"`
+	Source string     `json:"source"`
+	DryRun bool       `json:"dry_run"`
+	Status SyncStatus `json:"status"`
+	// Total is the number of locations listed by the source
+	Total     int `json:"total"`
+	Created   int `json:"created"`
+	Updated   int `json:"updated"`
+	Removed   int `json:"removed"`
+	Restored  int `json:"restored"`
+	Unchanged int `json:"unchanged"`
+	// Failed is the number of changes that failed, a failed sync fails before any change is made
+	Failed int `json:"failed"`
+	// Changes are the changes made, or that would be made in a dry run, only the first ones are kept
+	Changes    []SyncChange `json:"changes"`
+	Error      string       `json:"error,omitempty"`
+	StartedAt  time.Time    `json:"started_at"`
+	FinishedAt *time.Time   `json:"finished_at,omitempty"`
+}
+
+// ListSyncReportsRequest filters the sync reports listed by the admin API, most recent first
+type ListSyncReportsRequest struct {
+	Source string
+	Limit  int `validate:"omitempty,min=1,max=100"`
+}
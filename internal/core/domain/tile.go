@@ -0,0 +1,22 @@
+package domain
+
+// MaxTileZoom is the deepest zoom level tiles are served at, the one of a street view in most map frontends
+const MaxTileZoom = 22
+
+// TileRequest fetches the tile at zoom Z, column X and row Y of the Web Mercator tile grid, X and Y
+// counted from the north west corner. Its locations are narrowed like those of a list
+type TileRequest struct {
+	Z int
+	X int
+	Y int
+	// Query is a filter such as `category:park AND created_at>2024-01-01`
+	Query string
+	// IncludeExpired adds the locations outside their validity to the tile
+	IncludeExpired bool
+}
+
+// Exists reports whether the tile is on the grid of its zoom level, 2^Z tiles wide and high
+func (r *TileRequest) Exists() bool {
+	n := 1 << r.Z
+	return r.Z >= 0 && r.Z <= MaxTileZoom && r.X >= 0 && r.X < n && r.Y >= 0 && r.Y < n
+}
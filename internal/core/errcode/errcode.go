@@ -0,0 +1,148 @@
+// Package errcode is the catalog of the machine-readable codes of the errors returned to clients. Codes are
+// stable, unlike messages, so clients branch on them. Each code is registered once with the status its errors
+// are answered with and what it means
+package errcode
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// Code is the machine-readable code of an error, such as LOCATION_NOT_FOUND
+type Code string
+
+// Entry is a registered code with the status of its errors
+type Entry struct {
+	Code        Code   `json:"code"`
+	Status      int    `json:"status"`
+	Description string `json:"description"`
+}
+
+var catalog = make(map[Code]Entry)
+
+// Register adds a code to the catalog and returns it. Registering a code twice is a programming error
+func Register(code Code, status int, description string) Code {
+	if _, ok := catalog[code]; ok {
+		panic("errcode: " + string(code) + " is registered twice")
+	}
+
+	catalog[code] = Entry{code, status, description}
+	return code
+}
+
+// Lookup returns the entry of a registered code
+func Lookup(code Code) (Entry, bool) {
+	entry, ok := catalog[code]
+	return entry, ok
+}
+
+// Status returns the status of the errors of code, 500 for the codes that are not registered
+func Status(code Code) int {
+	if entry, ok := catalog[code]; ok {
+		return entry.Status
+	}
+	return http.StatusInternalServerError
+}
+
+// All returns the registered codes sorted by code
+func All() []Entry {
+	entries := make([]Entry, 0, len(catalog))
+	for _, entry := range catalog {
+		entries = append(entries, entry)
+	}
+	slices.SortFunc(entries, func(a, b Entry) int {
+		return strings.Compare(string(a.Code), string(b.Code))
+	})
+	return entries
+}
+
+// Generic codes of the errors without a code of their own, by status
+var (
+	ValidationFailed   = Register("VALIDATION_FAILED", http.StatusBadRequest, "The request is invalid, the message tells why")
+	Unauthorized       = Register("UNAUTHORIZED", http.StatusUnauthorized, "The request carries no valid token")
+	Forbidden          = Register("FORBIDDEN", http.StatusForbidden, "The caller or its address is not allowed on the route")
+	NotFound           = Register("NOT_FOUND", http.StatusNotFound, "The requested resource does not exist")
+	Conflict           = Register("CONFLICT", http.StatusConflict, "The request conflicts with the current state of the resource")
+	PayloadTooLarge    = Register("PAYLOAD_TOO_LARGE", http.StatusRequestEntityTooLarge, "The request body is larger than allowed")
+	RateLimited        = Register("RATE_LIMITED", http.StatusTooManyRequests, "A rate limit is exceeded, retry after Retry-After")
+	Internal           = Register("INTERNAL_ERROR", http.StatusInternalServerError, "The server failed to process the request")
+	NotImplemented     = Register("NOT_IMPLEMENTED", http.StatusNotImplemented, "The feature is not configured on the server")
+	ServiceUnavailable = Register("SERVICE_UNAVAILABLE", http.StatusServiceUnavailable, "A dependency such as the database is down, retry later")
+	Timeout            = Register("TIMEOUT", http.StatusGatewayTimeout, "The request took too long to process")
+)
+
+// genericCodes are the codes of the errors without one, by status
+var genericCodes = map[int]Code{
+	http.StatusBadRequest:            ValidationFailed,
+	http.StatusUnauthorized:          Unauthorized,
+	http.StatusForbidden:             Forbidden,
+	http.StatusNotFound:              NotFound,
+	http.StatusConflict:              Conflict,
+	http.StatusRequestEntityTooLarge: PayloadTooLarge,
+	http.StatusTooManyRequests:       RateLimited,
+	http.StatusInternalServerError:   Internal,
+	http.StatusNotImplemented:        NotImplemented,
+	http.StatusServiceUnavailable:    ServiceUnavailable,
+	http.StatusGatewayTimeout:        Timeout,
+}
+
+// ForStatus returns the generic code of the errors answered with status, the client and server errors
+// without one of their own are VALIDATION_FAILED and INTERNAL_ERROR
+func ForStatus(status int) Code {
+	if code, ok := genericCodes[status]; ok {
+		return code
+	}
+	if status < http.StatusInternalServerError {
+		return ValidationFailed
+	}
+	return Internal
+}
+
+// Codes of the errors shared by the services
+var (
+	AlreadyExists        = Register("ALREADY_EXISTS", http.StatusConflict, "A resource with the same unique value exists")
+	VersionConflict      = Register("VERSION_CONFLICT", http.StatusConflict, "The resource changed since the version sent, fetch it again")
+	PreconditionFailed   = Register("PRECONDITION_FAILED", http.StatusPreconditionFailed, "The resource changed since the If-Match or If-Unmodified-Since header, the response holds it")
+	ReferenceNotFound    = Register("REFERENCE_NOT_FOUND", http.StatusConflict, "Some of the referenced resources do not exist")
+	ConstraintViolated   = Register("CONSTRAINT_VIOLATED", http.StatusBadRequest, "A value breaks a rule of the database, the message names the field")
+	RequiredFieldMissing = Register("REQUIRED_FIELD_MISSING", http.StatusBadRequest, "A required value is missing, the message names the field")
+	Busy                 = Register("BUSY", http.StatusServiceUnavailable, "Too many bulk operations are running, retry later")
+	RoutingDisabled      = Register("ROUTING_DISABLED", http.StatusNotImplemented, "Travel times need a routing service, none is configured")
+	GeocodingDisabled    = Register("GEOCODING_DISABLED", http.StatusNotImplemented, "Address suggestions need a geocoding service, none is configured")
+	ExportsDisabled      = Register("EXPORTS_DISABLED", http.StatusNotImplemented, "Scheduled exports need an object storage bucket, none is configured")
+	SearchDisabled       = Register("SEARCH_DISABLED", http.StatusNotImplemented, "Searching the locations needs a search engine, none is configured")
+	SharesDisabled       = Register("SHARES_DISABLED", http.StatusNotImplemented, "Share links need a signing secret, none is configured")
+	QuotaExceeded        = Register("QUOTA_EXCEEDED", http.StatusForbidden, "The tenant of the caller reached its quota of the resource, the message names the limit")
+	QueryTooExpensive    = Register("QUERY_TOO_EXPENSIVE", http.StatusUnprocessableEntity, "The request would cost the database too much for the plan of the tenant, the message tells how to narrow it")
+)
+
+// Codes of the location errors
+var (
+	LocationNotFound   = Register("LOCATION_NOT_FOUND", http.StatusNotFound, "No location has the name or slug")
+	DuplicateSlug      = Register("DUPLICATE_SLUG", http.StatusConflict, "Another location has the same name, and so the same slug")
+	DuplicateLocation  = Register("DUPLICATE_COORDINATES", http.StatusConflict, "Another location is at the same coordinates, which the duplicate policy rejects")
+	NoLocationFound    = Register("NO_LOCATION_FOUND", http.StatusNotFound, "No location matches the nearest search")
+	LocationNotPending = Register("LOCATION_NOT_PENDING", http.StatusConflict, "The location is not waiting for review")
+	AliasNotFound      = Register("ALIAS_NOT_FOUND", http.StatusNotFound, "The location has no such alias")
+	AliasTaken         = Register("ALIAS_TAKEN", http.StatusConflict, "The alias is the name or an alias of a location")
+	ImportNotFound     = Register("IMPORT_NOT_FOUND", http.StatusNotFound, "No import job has the id")
+	ShareNotFound      = Register("SHARE_NOT_FOUND", http.StatusNotFound, "The share link is unknown, expired or revoked")
+)
+
+// Codes of the errors of the other resources
+var (
+	CollectionNotFound       = Register("COLLECTION_NOT_FOUND", http.StatusNotFound, "No collection has the name")
+	DuplicateCollection      = Register("DUPLICATE_COLLECTION", http.StatusConflict, "Another collection has the same name")
+	CollectionMemberNotFound = Register("COLLECTION_MEMBER_NOT_FOUND", http.StatusNotFound, "The collection or the location does not exist, or the location is not in it")
+	GeofenceNotFound         = Register("GEOFENCE_NOT_FOUND", http.StatusNotFound, "No geofence has the name")
+	DuplicateGeofence        = Register("DUPLICATE_GEOFENCE", http.StatusConflict, "Another geofence has the same name")
+	DeviceNotRegistered      = Register("DEVICE_NOT_REGISTERED", http.StatusNotFound, "The device sending the heartbeat is not registered")
+	SubscriptionNotFound     = Register("SUBSCRIPTION_NOT_FOUND", http.StatusNotFound, "No subscription has the id")
+	JobNotFound              = Register("JOB_NOT_FOUND", http.StatusNotFound, "No job has the id")
+	JobNotCancellable        = Register("JOB_NOT_CANCELLABLE", http.StatusConflict, "Only the jobs waiting for a worker can be cancelled")
+	JobNotRetryable          = Register("JOB_NOT_RETRYABLE", http.StatusConflict, "Only the failed or cancelled jobs can be retried")
+	SyncSourceNotFound       = Register("SYNC_SOURCE_NOT_FOUND", http.StatusNotFound, "No sync source has the name")
+	SyncReportNotFound       = Register("SYNC_REPORT_NOT_FOUND", http.StatusNotFound, "No sync report has the id")
+	SnapshotNotFound         = Register("SNAPSHOT_NOT_FOUND", http.StatusNotFound, "No snapshot has the key")
+)
@@ -0,0 +1,58 @@
+package errcode
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegister(t *testing.T) {
+	entry, ok := Lookup(LocationNotFound)
+	require.True(t, ok)
+	assert.Equal(t, Entry{"LOCATION_NOT_FOUND", http.StatusNotFound, "No location has the name or slug"}, entry)
+
+	assert.Panics(t, func() { Register("LOCATION_NOT_FOUND", http.StatusNotFound, "Registered twice") })
+
+	_, ok = Lookup("NOT_REGISTERED")
+	assert.False(t, ok)
+}
+
+func TestStatus(t *testing.T) {
+	assert.Equal(t, http.StatusConflict, Status(DuplicateSlug))
+	assert.Equal(t, http.StatusInternalServerError, Status("NOT_REGISTERED"))
+}
+
+func TestForStatus(t *testing.T) {
+	tests := []struct {
+		status int
+		code   Code
+	}{
+		{http.StatusBadRequest, ValidationFailed},
+		{http.StatusNotFound, NotFound},
+		{http.StatusConflict, Conflict},
+		{http.StatusGatewayTimeout, Timeout},
+		{http.StatusMethodNotAllowed, ValidationFailed},
+		{http.StatusBadGateway, Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			assert.Equal(t, tt.code, ForStatus(tt.status))
+		})
+	}
+}
+
+func TestAll(t *testing.T) {
+	entries := All()
+	require.NotEmpty(t, entries)
+
+	for i, entry := range entries {
+		assert.NotEmpty(t, entry.Description, entry.Code)
+		assert.NotZero(t, http.StatusText(entry.Status), entry.Code)
+		if i > 0 {
+			assert.Less(t, entries[i-1].Code, entry.Code)
+		}
+	}
+}
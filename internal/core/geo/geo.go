@@ -0,0 +1,122 @@
+// Package geo holds the geographic calculations shared by the services
+package geo
+
+import "math"
+
+// compassPoints are the eight points of the compass, clockwise from north
+var compassPoints = [...]string{"N", "NE", "E", "SE", "S", "SW", "W", "NW"}
+
+func radians(degrees float64) float64 {
+	return degrees * math.Pi / 180
+}
+
+func degrees(radians float64) float64 {
+	return radians * 180 / math.Pi
+}
+
+// Bearing is the initial bearing in degrees clockwise from north, in [0, 360), of the great circle
+// from the first point to the second
+func Bearing(fromLatitude, fromLongitude, toLatitude, toLongitude float64) float64 {
+	lat1, lat2 := radians(fromLatitude), radians(toLatitude)
+	deltaLng := radians(toLongitude - fromLongitude)
+
+	y := math.Sin(deltaLng) * math.Cos(lat2)
+	x := math.Cos(lat1)*math.Sin(lat2) - math.Sin(lat1)*math.Cos(lat2)*math.Cos(deltaLng)
+
+	return math.Mod(degrees(math.Atan2(y, x))+360, 360)
+}
+
+// Compass is the point of the eight point compass closest to bearing, e.g. NE for 40
+func Compass(bearing float64) string {
+	bearing = math.Mod(math.Mod(bearing, 360)+360, 360)
+	return compassPoints[int(math.Round(bearing/45))%len(compassPoints)]
+}
+
+const (
+	// earthRadius is the mean radius of the earth in meters, the radius of the sphere PostGIS uses
+	earthRadius = 6371008.7714
+
+	// wgs84A and wgs84F are the semi-major axis in meters and the flattening of the WGS 84 spheroid
+	wgs84A = 6378137.0
+	wgs84F = 1 / 298.257223563
+
+	vincentyIterations = 200
+	vincentyTolerance  = 1e-12
+)
+
+// Haversine is the great circle distance in meters between two points on a sphere. It is fast
+// but off by up to 0.5% since the earth is flattened at the poles
+func Haversine(fromLatitude, fromLongitude, toLatitude, toLongitude float64) float64 {
+	lat1, lat2 := radians(fromLatitude), radians(toLatitude)
+	deltaLat := lat2 - lat1
+	deltaLng := radians(toLongitude - fromLongitude)
+
+	a := math.Sin(deltaLat/2)*math.Sin(deltaLat/2) +
+		math.Cos(lat1)*math.Cos(lat2)*math.Sin(deltaLng/2)*math.Sin(deltaLng/2)
+
+	return 2 * earthRadius * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// Vincenty is the distance in meters between two points on the WGS 84 spheroid, accurate to
+// within a millimeter. The iteration does not converge for nearly antipodal points, in which
+// case it reports false
+func Vincenty(fromLatitude, fromLongitude, toLatitude, toLongitude float64) (float64, bool) {
+	const b = wgs84A * (1 - wgs84F)
+
+	// reduced latitudes
+	u1 := math.Atan((1 - wgs84F) * math.Tan(radians(fromLatitude)))
+	u2 := math.Atan((1 - wgs84F) * math.Tan(radians(toLatitude)))
+	sinU1, cosU1 := math.Sincos(u1)
+	sinU2, cosU2 := math.Sincos(u2)
+
+	deltaLng := radians(toLongitude - fromLongitude)
+	lambda := deltaLng
+
+	var sinSigma, cosSigma, sigma, cosSqAlpha, cos2SigmaM float64
+	for i := 0; ; i++ {
+		if i == vincentyIterations {
+			return 0, false
+		}
+
+		sinLambda, cosLambda := math.Sincos(lambda)
+		sinSigma = math.Hypot(cosU2*sinLambda, cosU1*sinU2-sinU1*cosU2*cosLambda)
+		if sinSigma == 0 {
+			// coincident points
+			return 0, true
+		}
+
+		cosSigma = sinU1*sinU2 + cosU1*cosU2*cosLambda
+		sigma = math.Atan2(sinSigma, cosSigma)
+		sinAlpha := cosU1 * cosU2 * sinLambda / sinSigma
+		cosSqAlpha = 1 - sinAlpha*sinAlpha
+		cos2SigmaM = 0
+		if cosSqAlpha != 0 {
+			// points on the equator have no cos2SigmaM
+			cos2SigmaM = cosSigma - 2*sinU1*sinU2/cosSqAlpha
+		}
+
+		c := wgs84F / 16 * cosSqAlpha * (4 + wgs84F*(4-3*cosSqAlpha))
+		previous := lambda
+		lambda = deltaLng + (1-c)*wgs84F*sinAlpha*
+			(sigma+c*sinSigma*(cos2SigmaM+c*cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)))
+
+		if math.Abs(lambda-previous) < vincentyTolerance {
+			break
+		}
+	}
+
+	uSq := cosSqAlpha * (wgs84A*wgs84A - b*b) / (b * b)
+	a := 1 + uSq/16384*(4096+uSq*(-768+uSq*(320-175*uSq)))
+	bb := uSq / 1024 * (256 + uSq*(-128+uSq*(74-47*uSq)))
+	deltaSigma := bb * sinSigma * (cos2SigmaM + bb/4*(cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)-
+		bb/6*cos2SigmaM*(-3+4*sinSigma*sinSigma)*(-3+4*cos2SigmaM*cos2SigmaM)))
+
+	return b * a * (sigma - deltaSigma), true
+}
+
+// Slant is the straight line distance in meters between two points a ground distance apart at the
+// altitudes in meters. It treats the ground between them as flat, which is close enough below tens of
+// kilometers where altitudes matter
+func Slant(groundDistance, fromAltitude, toAltitude float64) float64 {
+	return math.Hypot(groundDistance, toAltitude-fromAltitude)
+}
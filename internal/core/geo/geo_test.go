@@ -0,0 +1,100 @@
+package geo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBearing(t *testing.T) {
+	tests := []struct {
+		name    string
+		from    [2]float64
+		to      [2]float64
+		bearing float64
+	}{
+		{"north", [2]float64{0, 0}, [2]float64{1, 0}, 0},
+		{"east", [2]float64{0, 0}, [2]float64{0, 1}, 90},
+		{"south", [2]float64{1, 0}, [2]float64{0, 0}, 180},
+		{"west", [2]float64{0, 1}, [2]float64{0, 0}, 270},
+		{"Times Square to the Statue of Liberty", [2]float64{40.7580, -73.9855}, [2]float64{40.6892, -74.0445}, 213.0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.bearing, Bearing(tt.from[0], tt.from[1], tt.to[0], tt.to[1]), 0.1)
+		})
+	}
+}
+
+func TestCompass(t *testing.T) {
+	tests := []struct {
+		bearing float64
+		point   string
+	}{
+		{0, "N"},
+		{22.4, "N"},
+		{22.5, "NE"},
+		{90, "E"},
+		{213, "SW"},
+		{337.6, "N"},
+		{359.9, "N"},
+		{-45, "NW"},
+		{405, "NE"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.point, Compass(tt.bearing), "bearing %v", tt.bearing)
+	}
+}
+
+func TestHaversine(t *testing.T) {
+	// one degree of longitude on the equator of the sphere
+	assert.InDelta(t, 111195.08, Haversine(0, 0, 0, 1), 0.01)
+	assert.Zero(t, Haversine(40.7128, -74.0060, 40.7128, -74.0060))
+}
+
+func TestSlant(t *testing.T) {
+	assert.Equal(t, 500.0, Slant(400, 10, 310))
+	assert.Equal(t, 500.0, Slant(400, 310, 10))
+	assert.Equal(t, 400.0, Slant(400, 25, 25))
+	assert.Equal(t, 120.0, Slant(0, 0, 120))
+}
+
+func TestVincenty(t *testing.T) {
+	tests := []struct {
+		name     string
+		from     [2]float64
+		to       [2]float64
+		distance float64
+	}{
+		{"one degree on the equator", [2]float64{0, 0}, [2]float64{0, 1}, 111319.491},
+		{"Flinders Peak to Buninyong", [2]float64{-37.95103342, 144.42486789}, [2]float64{-37.65282114, 143.92649554}, 54972.271},
+		{"coincident points", [2]float64{40.7128, -74.0060}, [2]float64{40.7128, -74.0060}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			distance, ok := Vincenty(tt.from[0], tt.from[1], tt.to[0], tt.to[1])
+			assert.True(t, ok)
+			assert.InDelta(t, tt.distance, distance, 0.001)
+		})
+	}
+
+	t.Run("nearly antipodal points", func(t *testing.T) {
+		_, ok := Vincenty(0, 0, 0.5, 179.7)
+		assert.False(t, ok)
+	})
+}
+
+func BenchmarkHaversine(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		Haversine(40.7580, -73.9855, 51.5074, -0.1278)
+	}
+}
+
+func BenchmarkVincenty(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		Vincenty(40.7580, -73.9855, 51.5074, -0.1278)
+	}
+}
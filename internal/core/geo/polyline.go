@@ -0,0 +1,53 @@
+package geo
+
+import "errors"
+
+// polylinePrecision is the factor the coordinates of an encoded polyline are multiplied by, 5 decimal places
+const polylinePrecision = 1e5
+
+// ErrInvalidPolyline is returned for an encoded polyline that is truncated or has characters outside its alphabet
+var ErrInvalidPolyline = errors.New("invalid encoded polyline")
+
+// DecodePolyline decodes a polyline in the encoded polyline algorithm format into its points,
+// as latitude and longitude pairs
+func DecodePolyline(encoded string) ([][2]float64, error) {
+	var points [][2]float64
+	var latitude, longitude int64
+
+	for i := 0; i < len(encoded); {
+		// each point is the difference in latitude then in longitude from the previous one
+		var deltas [2]int64
+		for j := range deltas {
+			var value int64
+			for shift := uint(0); ; shift += 5 {
+				if i >= len(encoded) || shift > 30 {
+					return nil, ErrInvalidPolyline
+				}
+
+				chunk := int64(encoded[i]) - 63
+				i++
+				if chunk < 0 || chunk > 63 {
+					return nil, ErrInvalidPolyline
+				}
+
+				value |= (chunk & 0x1f) << shift
+				// the sixth bit is set on every chunk but the last of a value
+				if chunk < 0x20 {
+					break
+				}
+			}
+
+			if value&1 != 0 {
+				deltas[j] = ^(value >> 1)
+			} else {
+				deltas[j] = value >> 1
+			}
+		}
+
+		latitude += deltas[0]
+		longitude += deltas[1]
+		points = append(points, [2]float64{float64(latitude) / polylinePrecision, float64(longitude) / polylinePrecision})
+	}
+
+	return points, nil
+}
@@ -0,0 +1,49 @@
+package geo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodePolyline(t *testing.T) {
+	tests := []struct {
+		name    string
+		encoded string
+		points  [][2]float64
+		err     error
+	}{
+		{
+			name:    "reference example",
+			encoded: "_p~iF~ps|U_ulLnnqC_mqNvxq`@",
+			points:  [][2]float64{{38.5, -120.2}, {40.7, -120.95}, {43.252, -126.453}},
+		},
+		{
+			name:    "single point",
+			encoded: "??",
+			points:  [][2]float64{{0, 0}},
+		},
+		{name: "empty", encoded: ""},
+		{name: "truncated value", encoded: "_p~iF~ps|", err: ErrInvalidPolyline},
+		{name: "missing longitude", encoded: "_p~iF", err: ErrInvalidPolyline},
+		{name: "character outside the alphabet", encoded: "_p~iF ps|U", err: ErrInvalidPolyline},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			points, err := DecodePolyline(tt.encoded)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, points, len(tt.points))
+			for i := range tt.points {
+				assert.InDelta(t, tt.points[i][0], points[i][0], 1e-9)
+				assert.InDelta(t, tt.points[i][1], points[i][1], 1e-9)
+			}
+		})
+	}
+}
@@ -0,0 +1,216 @@
+// Package olc encodes and decodes Open Location Codes, also known as Plus Codes, such as 6FR5G9JC+XH.
+// A code names a rectangle of the grid laid over the earth, the longer the code the smaller the rectangle
+package olc
+
+import (
+	"errors"
+	"math"
+	"strings"
+)
+
+const (
+	// DefaultCodeLength gives rectangles of about 14 by 14 meters, close enough to tell buildings apart
+	DefaultCodeLength = 10
+
+	alphabet  = "23456789CFGHJMPQRVWX"
+	separator = '+'
+	padding   = '0'
+
+	// separatorPosition is the number of digits before the separator of a full code
+	separatorPosition = 8
+	encodingBase      = 20
+	maxCodeLength     = 15
+	// pairCodeLength is the number of digits encoding the latitude and longitude in turns,
+	// the digits after them each encode a cell of a 5 row by 4 column grid
+	pairCodeLength = 10
+	gridCodeLength = maxCodeLength - pairCodeLength
+	gridRows       = 5
+	gridColumns    = 4
+
+	// latitudePrecision and longitudePrecision are the number of cells per degree of a code of the maximum length
+	latitudePrecision  = 8000 * 3125 // 8000 * gridRows ^ gridCodeLength
+	longitudePrecision = 8000 * 1024 // 8000 * gridColumns ^ gridCodeLength
+)
+
+var (
+	// ErrInvalidCode is returned for a string that is not a plus code
+	ErrInvalidCode = errors.New("olc: invalid code")
+	// ErrShortCode is returned for a code whose first digits are left out, it only names a place near a reference
+	ErrShortCode = errors.New("olc: short code, the area it is near is unknown")
+)
+
+// CodeArea is the rectangle named by a code, in degrees
+type CodeArea struct {
+	LatitudeLo, LongitudeLo float64
+	LatitudeHi, LongitudeHi float64
+	// Length is the number of digits of the code
+	Length int
+}
+
+// Center is the center of the area, the point a code stands for
+func (area CodeArea) Center() (latitude, longitude float64) {
+	latitude = math.Min(area.LatitudeLo+(area.LatitudeHi-area.LatitudeLo)/2, 90)
+	longitude = math.Min(area.LongitudeLo+(area.LongitudeHi-area.LongitudeLo)/2, 180)
+	return latitude, longitude
+}
+
+// Encode is the code of length digits of the area holding a point. The length is clipped to 2 to 15 and
+// rounded up to an even length below 10. Latitudes are clipped to -90 to 90 and longitudes wrapped around
+func Encode(latitude, longitude float64, length int) string {
+	length = min(max(length, 2), maxCodeLength)
+	if length < pairCodeLength && length%2 == 1 {
+		length++
+	}
+
+	latitude = math.Min(math.Max(latitude, -90), 90)
+
+	// rounding first keeps the floating point error of the multiplication from flooring a point
+	// on a cell border into the cell below
+	latitudeValue := int64(math.Floor(math.Round((latitude+90)*latitudePrecision*1e6) / 1e6))
+	longitudeValue := int64(math.Floor(math.Round((longitude+180)*longitudePrecision*1e6) / 1e6))
+
+	// the north pole is the top border of the top cells
+	latitudeValue = min(latitudeValue, 180*latitudePrecision-1)
+	longitudeValue %= 360 * longitudePrecision
+	if longitudeValue < 0 {
+		longitudeValue += 360 * longitudePrecision
+	}
+
+	var digits [maxCodeLength]byte
+	for i := maxCodeLength - 1; i >= pairCodeLength; i-- {
+		digits[i] = alphabet[latitudeValue%gridRows*gridColumns+longitudeValue%gridColumns]
+		latitudeValue /= gridRows
+		longitudeValue /= gridColumns
+	}
+	for i := pairCodeLength - 2; i >= 0; i -= 2 {
+		digits[i] = alphabet[latitudeValue%encodingBase]
+		digits[i+1] = alphabet[longitudeValue%encodingBase]
+		latitudeValue /= encodingBase
+		longitudeValue /= encodingBase
+	}
+
+	var code strings.Builder
+	code.Grow(maxCodeLength + 1)
+	for i := 0; i < max(length, separatorPosition); i++ {
+		if i == separatorPosition {
+			code.WriteByte(separator)
+		}
+		if i < length {
+			code.WriteByte(digits[i])
+		} else {
+			code.WriteByte(padding)
+		}
+	}
+	if length <= separatorPosition {
+		code.WriteByte(separator)
+	}
+
+	return code.String()
+}
+
+// Decode is the area named by a full code, short codes are rejected with ErrShortCode
+func Decode(code string) (CodeArea, error) {
+	if !IsValid(code) {
+		return CodeArea{}, ErrInvalidCode
+	}
+	if !IsFull(code) {
+		return CodeArea{}, ErrShortCode
+	}
+
+	code = strings.ToUpper(code)
+	code = strings.ReplaceAll(code, string(separator), "")
+	code = strings.TrimRight(code, string(padding))
+	code = code[:min(len(code), maxCodeLength)]
+
+	var latitudeValue, longitudeValue int64
+	pairs := min(len(code), pairCodeLength) / 2
+	for i := 0; i < pairs; i++ {
+		latitudeValue = latitudeValue*encodingBase + int64(strings.IndexByte(alphabet, code[2*i]))
+		longitudeValue = longitudeValue*encodingBase + int64(strings.IndexByte(alphabet, code[2*i+1]))
+	}
+	grid := len(code) - 2*pairs
+	for i := 0; i < grid; i++ {
+		digit := int64(strings.IndexByte(alphabet, code[pairCodeLength+i]))
+		latitudeValue = latitudeValue*gridRows + digit/gridColumns
+		longitudeValue = longitudeValue*gridColumns + digit%gridColumns
+	}
+
+	// the size of the area in cells of a code of the maximum length
+	pairScale := pow(encodingBase, pairCodeLength/2-pairs)
+	latitudeCells := pairScale * pow(gridRows, gridCodeLength-grid)
+	longitudeCells := pairScale * pow(gridColumns, gridCodeLength-grid)
+
+	latitudeLo := latitudeValue * latitudeCells
+	longitudeLo := longitudeValue * longitudeCells
+
+	return CodeArea{
+		LatitudeLo:  float64(latitudeLo)/latitudePrecision - 90,
+		LongitudeLo: float64(longitudeLo)/longitudePrecision - 180,
+		LatitudeHi:  float64(latitudeLo+latitudeCells)/latitudePrecision - 90,
+		LongitudeHi: float64(longitudeLo+longitudeCells)/longitudePrecision - 180,
+		Length:      len(code),
+	}, nil
+}
+
+// IsValid reports whether code is a full or a short plus code, ignoring case
+func IsValid(code string) bool {
+	code = strings.ToUpper(code)
+
+	position := strings.IndexByte(code, separator)
+	if position < 0 || strings.LastIndexByte(code, separator) != position {
+		return false
+	}
+	// the separator follows the pairs, one to four of them
+	if position < 2 || position > separatorPosition || position%2 == 1 {
+		return false
+	}
+	// a single digit after the separator is not a valid length
+	if len(code)-position-1 == 1 {
+		return false
+	}
+
+	if start := strings.IndexByte(code, padding); start >= 0 {
+		// padding fills whole pairs up to the separator of a full code, which ends the code
+		if start == 0 || start%2 == 1 || position != separatorPosition || position != len(code)-1 {
+			return false
+		}
+		if strings.Trim(code[start:position], string(padding)) != "" {
+			return false
+		}
+		code = code[:start] + code[position:]
+	}
+
+	for i := 0; i < len(code); i++ {
+		if code[i] != separator && strings.IndexByte(alphabet, code[i]) < 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// IsFull reports whether code is a full plus code, one naming an area without a reference
+func IsFull(code string) bool {
+	if !IsValid(code) || strings.IndexByte(code, separator) != separatorPosition {
+		return false
+	}
+
+	code = strings.ToUpper(code)
+	// the first latitude digit is within 180 degrees and the first longitude digit within 360
+	if strings.IndexByte(alphabet, code[0])*encodingBase >= 180 {
+		return false
+	}
+	if len(code) > 1 && code[1] != padding && strings.IndexByte(alphabet, code[1])*encodingBase >= 360 {
+		return false
+	}
+
+	return true
+}
+
+func pow(base, exponent int) int64 {
+	result := int64(1)
+	for range exponent {
+		result *= int64(base)
+	}
+	return result
+}
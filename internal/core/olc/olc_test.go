@@ -0,0 +1,119 @@
+package olc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncode(t *testing.T) {
+	tests := []struct {
+		latitude, longitude float64
+		length              int
+		code                string
+	}{
+		{20.375, 2.775, 6, "7FG49Q00+"},
+		{20.3700625, 2.7821875, 10, "7FG49QCJ+2V"},
+		{20.3701125, 2.782234375, 11, "7FG49QCJ+2VX"},
+		{20.3701135, 2.78223535156, 13, "7FG49QCJ+2VXGJ"},
+		{47.0000625, 8.0000625, 10, "8FVC2222+22"},
+		{-41.2730625, 174.7859375, 10, "4VCPPQGP+Q9"},
+		{0.5, -179.5, 4, "62G20000+"},
+		{-89.5, -179.5, 4, "22220000+"},
+		{20.5, 2.5, 4, "7FG40000+"},
+		{-89.9999375, -179.9999375, 10, "22222222+22"},
+		{0.5, 179.5, 4, "6VGX0000+"},
+		{1, 1, 11, "6FH32222+222"},
+		// the north pole is in the top cells, and longitudes wrap around
+		{90, 1, 4, "CFX30000+"},
+		{92, 1, 4, "CFX30000+"},
+		{90, 1, 10, "CFX3X2X2+X2"},
+		{1, 180, 4, "62H20000+"},
+		{1, 181, 4, "62H30000+"},
+		// odd lengths below 10 are rounded up
+		{20.375, 2.775, 5, "7FG49Q00+"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			assert.Equal(t, tt.code, Encode(tt.latitude, tt.longitude, tt.length))
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	area, err := Decode("7FG49QCJ+2V")
+	require.NoError(t, err)
+	assert.InDelta(t, 20.37, area.LatitudeLo, 1e-9)
+	assert.InDelta(t, 2.782125, area.LongitudeLo, 1e-9)
+	assert.InDelta(t, 20.370125, area.LatitudeHi, 1e-9)
+	assert.InDelta(t, 2.78225, area.LongitudeHi, 1e-9)
+	assert.Equal(t, 10, area.Length)
+
+	latitude, longitude := area.Center()
+	assert.InDelta(t, 20.3700625, latitude, 1e-9)
+	assert.InDelta(t, 2.7821875, longitude, 1e-9)
+
+	t.Run("Padded and lower case codes", func(t *testing.T) {
+		area, err := Decode("7fg49q00+")
+		require.NoError(t, err)
+		assert.InDelta(t, 20.35, area.LatitudeLo, 1e-9)
+		assert.InDelta(t, 20.4, area.LatitudeHi, 1e-9)
+		assert.Equal(t, 6, area.Length)
+	})
+
+	t.Run("Round trip", func(t *testing.T) {
+		for _, length := range []int{2, 4, 8, 10, 11, 15} {
+			area, err := Decode(Encode(6.5244, 3.3792, length))
+			require.NoError(t, err)
+			assert.True(t, area.LatitudeLo <= 6.5244 && 6.5244 < area.LatitudeHi, length)
+			assert.True(t, area.LongitudeLo <= 3.3792 && 3.3792 < area.LongitudeHi, length)
+		}
+	})
+
+	t.Run("Short codes are rejected", func(t *testing.T) {
+		_, err := Decode("9QCJ+2V")
+		assert.ErrorIs(t, err, ErrShortCode)
+	})
+
+	t.Run("Invalid codes are rejected", func(t *testing.T) {
+		_, err := Decode("7FG49QCJ2V")
+		assert.ErrorIs(t, err, ErrInvalidCode)
+	})
+}
+
+func TestIsValid(t *testing.T) {
+	tests := []struct {
+		code  string
+		valid bool
+		full  bool
+	}{
+		{"8FWC2345+G6", true, true},
+		{"8FWC2345+G6G", true, true},
+		{"8fwc2345+", true, true},
+		{"8FWCX400+", true, true},
+		{"8FWC0000+", true, true},
+		{"WC2345+G6g", true, false},
+		{"2345+G6", true, false},
+		{"8FWC2345+G", false, false},
+		{"8FWC2_45+G6", false, false},
+		{"8FWC2η45+G6", false, false},
+		{"8FWC2345+G6+", false, false},
+		{"8FWC2300+G6", false, false},
+		{"WC2300+G6g", false, false},
+		{"WC2345+G", false, false},
+		{"+2VX", false, false},
+		{"G+", false, false},
+		// the first digits are out of range
+		{"F2222222+22", true, false},
+		{"2X222222+22", true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			assert.Equal(t, tt.valid, IsValid(tt.code))
+			assert.Equal(t, tt.full, IsFull(tt.code))
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package port
+
+//go:generate moq -rm -out mock/change.go -pkg mock . LocationChangeFeed
+
+import (
+	"context"
+
+	"leeta/internal/core/domain"
+)
+
+// LocationChangeFeed is an interface for learning about location changes made by any instance
+type LocationChangeFeed interface {
+	// Subscribe registers fn to be called with every change. Calls are made one at a
+	// time from the feed goroutine, so fn should return quickly
+	Subscribe(fn func(ctx context.Context, change *domain.LocationChange))
+}
@@ -0,0 +1,39 @@
+package port
+
+//go:generate moq -rm -out mock/collection.go -pkg mock . CollectionRepository CollectionService
+
+import (
+	"context"
+
+	"leeta/internal/core/domain"
+)
+
+// CollectionRepository is an interface for interacting with collection-related data
+type CollectionRepository interface {
+	// CreateCollection inserts a collection, failing with a conflict if its name, ignoring case, or slug exists
+	CreateCollection(ctx context.Context, collection *domain.Collection) (*domain.Collection, domain.CError)
+	// ListCollections fetches all the collections, by name
+	ListCollections(ctx context.Context) ([]domain.Collection, domain.CError)
+	// DeleteCollection removes the collection specified by its name or slug, its locations are kept
+	DeleteCollection(ctx context.Context, name string) domain.CError
+	// AddCollectionLocation adds the location specified by its name, slug or one of its aliases to the
+	// collection specified by its name or slug. Adding a location twice is not an error
+	AddCollectionLocation(ctx context.Context, name, location string) domain.CError
+	// RemoveCollectionLocation removes the location specified by its name, slug or one of its aliases from
+	// the collection specified by its name or slug
+	RemoveCollectionLocation(ctx context.Context, name, location string) domain.CError
+}
+
+// CollectionService is an interface for interacting with collection-related business logic
+type CollectionService interface {
+	// CreateCollection creates an empty collection and returns it
+	CreateCollection(ctx context.Context, req *domain.CreateCollectionRequest) (*domain.Collection, domain.CError)
+	// ListCollections returns all the collections
+	ListCollections(ctx context.Context) ([]domain.Collection, domain.CError)
+	// DeleteCollection deletes a collection specified by name
+	DeleteCollection(ctx context.Context, name string) domain.CError
+	// AddCollectionLocation adds a location to a collection, both specified by name
+	AddCollectionLocation(ctx context.Context, name, location string) domain.CError
+	// RemoveCollectionLocation removes a location from a collection, both specified by name
+	RemoveCollectionLocation(ctx context.Context, name, location string) domain.CError
+}
@@ -0,0 +1,30 @@
+package port
+
+//go:generate moq -rm -out mock/device.go -pkg mock . DeviceRepository DeviceService
+
+import (
+	"context"
+	"time"
+
+	"leeta/internal/core/domain"
+)
+
+// DeviceRepository is an interface for interacting with device-related data
+type DeviceRepository interface {
+	// RegisterDevice inserts a device, or renames it if it is already registered
+	RegisterDevice(ctx context.Context, device *domain.Device) (*domain.Device, domain.CError)
+	// RecordHeartbeat marks a device as seen now, updating its coordinates if they are set
+	RecordHeartbeat(ctx context.Context, id string, latitude, longitude *float64) (*domain.Device, domain.CError)
+	// ListStaleDevices fetches the devices last seen before since, least recently seen first
+	ListStaleDevices(ctx context.Context, since time.Time) ([]domain.Device, domain.CError)
+}
+
+// DeviceService is an interface for interacting with device-related business logic
+type DeviceService interface {
+	// RegisterDevice registers a device and returns it
+	RegisterDevice(ctx context.Context, req *domain.RegisterDeviceRequest) (*domain.Device, domain.CError)
+	// Heartbeat records a heartbeat of a registered device
+	Heartbeat(ctx context.Context, req *domain.HeartbeatRequest) (*domain.Device, domain.CError)
+	// ListStaleDevices returns the devices not seen for the given duration
+	ListStaleDevices(ctx context.Context, notSeenFor time.Duration) ([]domain.Device, domain.CError)
+}
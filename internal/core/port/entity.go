@@ -0,0 +1,31 @@
+package port
+
+//go:generate moq -rm -out mock/entity.go -pkg mock . EntityRepository EntityService
+
+import (
+	"context"
+	"time"
+
+	"leeta/internal/core/domain"
+)
+
+// EntityRepository is an interface for interacting with the positions of the moving entities
+type EntityRepository interface {
+	// ReportPosition replaces the position of an entity with the one given, reported now. It also
+	// returns the position it replaced, nil for an entity reporting for the first time
+	ReportPosition(ctx context.Context, position *domain.EntityPosition) (*domain.EntityPosition, *domain.EntityPosition, domain.CError)
+	// FindNearestEntities fetches the entities whose position reported at or after since is nearest to
+	// the point in the request, closest first
+	FindNearestEntities(ctx context.Context, since time.Time, req *domain.NearestEntitiesRequest) ([]domain.NearestEntity, domain.CError)
+	// DeletePositions removes the positions reported before before, returning how many were removed
+	DeletePositions(ctx context.Context, before time.Time) (int64, domain.CError)
+}
+
+// EntityService is an interface for interacting with moving entity-related business logic
+type EntityService interface {
+	// ReportPosition records the current position of an entity specified by id
+	ReportPosition(ctx context.Context, id string, req *domain.ReportPositionRequest) (*domain.EntityPosition, domain.CError)
+	// FindNearestEntities returns the entities nearest to a point, by the position they last reported
+	// if it is not stale
+	FindNearestEntities(ctx context.Context, req *domain.NearestEntitiesRequest) ([]domain.NearestEntity, domain.CError)
+}
@@ -0,0 +1,16 @@
+package port
+
+//go:generate moq -rm -out mock/event.go -pkg mock . EventPublisher
+
+import (
+	"context"
+
+	"leeta/internal/core/domain"
+)
+
+// EventPublisher is an interface for delivering events to subscribers
+type EventPublisher interface {
+	// Publish delivers event, returning an error if it must be retried. Events may be
+	// delivered more than once, subscribers can use the event id to skip duplicates
+	Publish(ctx context.Context, event *domain.Event) error
+}
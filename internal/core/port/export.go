@@ -0,0 +1,45 @@
+package port
+
+//go:generate moq -rm -out mock/export.go -pkg mock . ObjectStorage RestoreRepository ExportService
+
+import (
+	"context"
+	"io"
+
+	"leeta/internal/core/domain"
+)
+
+// ObjectStorage is an interface for a bucket of an object storage, such as S3 or a compatible store
+type ObjectStorage interface {
+	// PutObject writes the object under key, replacing the object already stored under it
+	PutObject(ctx context.Context, key, contentType string, body io.ReadSeeker) error
+	// GetObject returns the content of the object stored under key, it fails with domain.ErrDataNotFound
+	// if there is none. The caller closes it
+	GetObject(ctx context.Context, key string) (io.ReadCloser, error)
+	// ListObjects returns the objects whose key starts with prefix, in key order
+	ListObjects(ctx context.Context, prefix string) ([]domain.StoredObject, error)
+	// DeleteObject deletes the object stored under key, deleting a missing object is not an error
+	DeleteObject(ctx context.Context, key string) error
+}
+
+// RestoreRepository is an interface for reloading the locations of a snapshot
+type RestoreRepository interface {
+	// RestoreLocations applies the plan of a restore in one transaction, deleting, replacing and inserting the
+	// locations by id and writing an event to the outbox for each. It fails with a conflict if a location changed
+	// status since the plan was made or if another location has the name of a created one
+	RestoreLocations(ctx context.Context, plan *domain.RestorePlan) domain.CError
+	// LoadShadowLocations replaces the content of the shadow table with the locations of the snapshot key
+	LoadShadowLocations(ctx context.Context, key string, locations []domain.Location) domain.CError
+}
+
+// ExportService is an interface for interacting with the snapshots of the locations
+type ExportService interface {
+	// Export writes a snapshot of every location in each format to the object storage, then deletes
+	// the snapshots beyond the ones retained
+	Export(ctx context.Context) error
+	// ListSnapshots returns the snapshots in the object storage, most recent first
+	ListSnapshots(ctx context.Context) ([]domain.Snapshot, domain.CError)
+	// Restore reloads a snapshot into the locations or the shadow table and returns the report of the
+	// changes between the snapshot and the locations
+	Restore(ctx context.Context, req *domain.RestoreRequest) (*domain.RestoreReport, domain.CError)
+}
@@ -0,0 +1,21 @@
+package port
+
+//go:generate moq -rm -out mock/geocode.go -pkg mock . Geocoder GeocodeService
+
+import (
+	"context"
+
+	"leeta/internal/core/domain"
+)
+
+// Geocoder is an interface for looking up addresses
+type Geocoder interface {
+	// Autocomplete returns the addresses completing the query of the request, best match first
+	Autocomplete(ctx context.Context, req *domain.AutocompleteRequest) ([]domain.AddressSuggestion, error)
+}
+
+// GeocodeService is an interface for interacting with address-related business logic
+type GeocodeService interface {
+	// Autocomplete returns the addresses completing a partly typed one
+	Autocomplete(ctx context.Context, req *domain.AutocompleteRequest) ([]domain.AddressSuggestion, domain.CError)
+}
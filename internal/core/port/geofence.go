@@ -0,0 +1,26 @@
+package port
+
+//go:generate moq -rm -out mock/geofence.go -pkg mock . GeofenceRepository GeofenceService
+
+import (
+	"context"
+
+	"leeta/internal/core/domain"
+)
+
+// GeofenceRepository is an interface for interacting with geofence-related data
+type GeofenceRepository interface {
+	// CreateGeofence inserts a geofence, failing with a conflict if its name, ignoring case, or slug exists
+	CreateGeofence(ctx context.Context, geofence *domain.Geofence) (*domain.Geofence, domain.CError)
+	// ListGeofenceLocations fetches a page of the locations inside the geofence specified by its name
+	// or slug, by name
+	ListGeofenceLocations(ctx context.Context, name string, req *domain.ListGeofenceLocationsRequest) ([]domain.Location, domain.CError)
+}
+
+// GeofenceService is an interface for interacting with geofence-related business logic
+type GeofenceService interface {
+	// CreateGeofence creates a geofence and returns it
+	CreateGeofence(ctx context.Context, req *domain.CreateGeofenceRequest) (*domain.Geofence, domain.CError)
+	// ListGeofenceLocations returns a page of the locations inside a geofence specified by its name
+	ListGeofenceLocations(ctx context.Context, name string, req *domain.ListGeofenceLocationsRequest) ([]domain.Location, domain.CError)
+}
@@ -0,0 +1,18 @@
+package port
+
+//go:generate moq -rm -out mock/health.go -pkg mock . HealthService
+
+import (
+	"context"
+
+	"leeta/internal/core/domain"
+)
+
+// HealthCheck checks that a dependency works, returning why it does not
+type HealthCheck func(ctx context.Context) error
+
+// HealthService is an interface for checking the dependencies of the server
+type HealthService interface {
+	// Report checks every dependency concurrently and returns their health
+	Report(ctx context.Context) *domain.HealthReport
+}
@@ -0,0 +1,17 @@
+package port
+
+//go:generate moq -rm -out mock/import.go -pkg mock . ImportService
+
+import (
+	"context"
+
+	"leeta/internal/core/domain"
+)
+
+// ImportService is an interface for bulk importing locations
+type ImportService interface {
+	// ImportLocations starts importing the locations in the background and returns the job tracking it
+	ImportLocations(ctx context.Context, locations []domain.RegisterLocationRequest) (*domain.ImportJob, domain.CError)
+	// GetImportJob returns an import job specified by its id
+	GetImportJob(ctx context.Context, id string) (*domain.ImportJob, domain.CError)
+}
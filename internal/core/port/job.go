@@ -0,0 +1,38 @@
+package port
+
+//go:generate moq -rm -out mock/job.go -pkg mock . JobRepository JobService
+
+import (
+	"context"
+	"encoding/json"
+
+	"leeta/internal/core/domain"
+)
+
+// JobRepository is an interface for interacting with the job queue
+type JobRepository interface {
+	// EnqueueJob inserts a job, it runs once a worker of the queue picks it up
+	EnqueueJob(ctx context.Context, job *domain.Job) (*domain.Job, domain.CError)
+	// GetJob fetches a job by its id
+	GetJob(ctx context.Context, id int64) (*domain.Job, domain.CError)
+	// ListJobs fetches the jobs matching req, most recent first
+	ListJobs(ctx context.Context, req *domain.ListJobsRequest) ([]domain.Job, domain.CError)
+	// CancelJob cancels a job waiting for a worker, running and finished jobs cannot be cancelled
+	CancelJob(ctx context.Context, id int64) (*domain.Job, domain.CError)
+	// RetryJob makes a failed or cancelled job available again, with a new set of attempts
+	RetryJob(ctx context.Context, id int64) (*domain.Job, domain.CError)
+	// UpdateJobProgress records the progress of a running job
+	UpdateJobProgress(ctx context.Context, id int64, progress json.RawMessage) domain.CError
+}
+
+// JobService is an interface for inspecting and managing the job queue
+type JobService interface {
+	// GetJob returns a job specified by its id
+	GetJob(ctx context.Context, id int64) (*domain.Job, domain.CError)
+	// ListJobs returns the jobs matching req, most recent first
+	ListJobs(ctx context.Context, req *domain.ListJobsRequest) ([]domain.Job, domain.CError)
+	// CancelJob cancels a job waiting for a worker
+	CancelJob(ctx context.Context, id int64) (*domain.Job, domain.CError)
+	// RetryJob runs a failed or cancelled job again
+	RetryJob(ctx context.Context, id int64) (*domain.Job, domain.CError)
+}
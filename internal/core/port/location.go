@@ -1,5 +1,7 @@
 package port
 
+//go:generate moq -rm -out mock/location.go -pkg mock . LocationRepository LocationService
+
 import (
 	"context"
 
@@ -10,16 +12,55 @@ import (
 type LocationRepository interface {
 	// CreateLocation inserts a new location into the database
 	CreateLocation(ctx context.Context, location *domain.Location) (*domain.Location, domain.CError)
+	// CopyLocations bulk inserts locations, skipping those whose name, ignoring case, or slug already exists.
+	// It returns the number of locations inserted
+	CopyLocations(ctx context.Context, locations []domain.Location) (int64, domain.CError)
 	// GetLocationByID fetches a new location from the database using it's id
 	GetLocationByID(ctx context.Context, id string) (*domain.Location, domain.CError)
-	// GetLocationByName fetches a new location from the database using it's name
+	// GetLocationByName fetches a new location from the database using it's name, ignoring case, slug or one
+	// of its aliases. A location named so wins over a location with such an alias
 	GetLocationByName(ctx context.Context, name string) (*domain.Location, domain.CError)
-	// ListLocations fetches and returns all locations in the database
-	ListLocations(ctx context.Context) ([]domain.Location, domain.CError)
-	// DeleteLocation performs a soft delete on a location specified by its name or slug
+	// ListLocations fetches and returns all locations in the database matching the request filter
+	ListLocations(ctx context.Context, req *domain.ListLocationsRequest) ([]domain.Location, domain.CError)
+	// StreamLocations iterates over the locations matching the request filter one row at a time,
+	// calling fn for each of them. Iteration stops at the first error returned by fn
+	StreamLocations(ctx context.Context, req *domain.ListLocationsRequest, fn func(location *domain.Location) error) domain.CError
+	// LocationGeometry computes the centroid, bounding box and convex hull of the locations picked by the request
+	LocationGeometry(ctx context.Context, req *domain.LocationGeometryRequest) (*domain.LocationGeometry, domain.CError)
+	// LookupLocations fetches the locations whose id is one of ids or whose slug is one of slugs in one query.
+	// Ids that are not UUIDs match no location
+	LookupLocations(ctx context.Context, ids, slugs []string) ([]domain.Location, domain.CError)
+	// UpdateLocation replaces the details of the location specified by its name or slug, if its
+	// version is still location.Version. The version is incremented and the updated location returned
+	UpdateLocation(ctx context.Context, name string, location *domain.Location) (*domain.Location, domain.CError)
+	// DeleteLocation removes the location specified by its name or slug, the row is deleted rather than flagged
+	// so its name and slug can be registered again
 	DeleteLocation(ctx context.Context, name string) domain.CError
-	// GetNearestLocation fetches the nearest location to the longitude and latitude from the database
-	GetNearestLocation(ctx context.Context, latitude, longitude float64) (*domain.NearestLocation, domain.CError)
+	// ExpireLocations flags up to limit locations whose validity has ended as expired and returns how many
+	ExpireLocations(ctx context.Context, limit int) (int64, domain.CError)
+	// FindLocationsAt fetches the approved and pending locations at exactly the latitude and longitude, by name
+	FindLocationsAt(ctx context.Context, latitude, longitude float64) ([]domain.Location, domain.CError)
+	// GetNearestLocation fetches the nearest location to the longitude and latitude from the database,
+	// its distance is computed on a sphere for the haversine formula and on the spheroid otherwise
+	GetNearestLocation(ctx context.Context, latitude, longitude float64, formula domain.DistanceFormula) (*domain.NearestLocation, domain.CError)
+	// FindNearestLocations fetches the nearest locations matching the filters in the query, closest first.
+	// Distances are computed like in GetNearestLocation
+	FindNearestLocations(ctx context.Context, query *domain.NearestLocationsRequest) ([]domain.NearestLocation, domain.CError)
+	// FindLocationsAlongRoute fetches the locations within the buffer in the query of the route through the points,
+	// in the order the route passes them
+	FindLocationsAlongRoute(ctx context.Context, route []domain.Point, query *domain.AlongRouteRequest) ([]domain.RouteLocation, domain.CError)
+	// CreateLocationAlias adds an alias to the location specified by its name, slug or one of its aliases
+	CreateLocationAlias(ctx context.Context, name string, alias *domain.LocationAlias) (*domain.LocationAlias, domain.CError)
+	// ListLocationAliases fetches the aliases of the location specified by its name, slug or one of its aliases
+	ListLocationAliases(ctx context.Context, name string) ([]domain.LocationAlias, domain.CError)
+	// DeleteLocationAlias removes an alias, by name or slug, from the location specified by its name, slug or
+	// one of its aliases
+	DeleteLocationAlias(ctx context.Context, name, alias string) domain.CError
+	// ListPendingLocations fetches a page of the locations waiting for review, oldest submission first
+	ListPendingLocations(ctx context.Context, req *domain.ListPendingLocationsRequest) ([]domain.Location, domain.CError)
+	// ReviewLocation moves the location waiting for review specified by its name or slug to status, keeping
+	// the reason with it. It fails with a conflict if the location is not waiting for review
+	ReviewLocation(ctx context.Context, name string, status domain.LocationStatus, reason string) (*domain.Location, domain.CError)
 }
 
 // LocationService is an interface for interacting with Location-related business logic
@@ -28,10 +69,44 @@ type LocationService interface {
 	RegisterLocation(ctx context.Context, location *domain.RegisterLocationRequest) (*domain.Location, domain.CError)
 	// GetLocation returns a location specified by its id
 	GetLocation(ctx context.Context, id string) (*domain.Location, domain.CError)
-	// ListLocations returns all locations in the system
-	ListLocations(ctx context.Context) ([]domain.Location, domain.CError)
-	// DeleteLocation deletes a location specified by id
-	DeleteLocation(ctx context.Context, id string) domain.CError
-	// GetNearestLocation returns the nearest location to the longitude and latitude
-	GetNearestLocation(ctx context.Context, latitude, longitude float64) (*domain.NearestLocation, domain.CError)
+	// ListLocations returns all locations in the system, narrowed by the request filter
+	ListLocations(ctx context.Context, req *domain.ListLocationsRequest) ([]domain.Location, domain.CError)
+	// ExportLocations streams the locations matching the request filter to fn without buffering them
+	ExportLocations(ctx context.Context, req *domain.ListLocationsRequest, fn func(location *domain.Location) error) domain.CError
+	// LocationGeometry returns the centroid, bounding box and convex hull of the locations picked by a filter or by slug
+	LocationGeometry(ctx context.Context, req *domain.LocationGeometryRequest) (*domain.LocationGeometry, domain.CError)
+	// LookupLocations returns the locations picked by id or slug, along with the ids and slugs matching none
+	LookupLocations(ctx context.Context, req *domain.LookupLocationsRequest) (*domain.LocationLookup, domain.CError)
+	// UpdateLocation updates a location specified by name, failing with a conflict if it was modified
+	// since the version in the request and with a failed precondition if it does not satisfy the one in the request
+	UpdateLocation(ctx context.Context, name string, req *domain.UpdateLocationRequest) (*domain.Location, domain.CError)
+	// DeleteLocation deletes a location specified by name, failing with a failed precondition if it does not
+	// satisfy precondition
+	DeleteLocation(ctx context.Context, name string, precondition domain.Precondition) domain.CError
+	// GetLocationByID returns a location specified by its id, which unlike its name never changes
+	GetLocationByID(ctx context.Context, id string) (*domain.Location, domain.CError)
+	// UpdateLocationByID updates a location specified by its id, like UpdateLocation
+	UpdateLocationByID(ctx context.Context, id string, req *domain.UpdateLocationRequest) (*domain.Location, domain.CError)
+	// DeleteLocationByID deletes a location specified by its id, like DeleteLocation
+	DeleteLocationByID(ctx context.Context, id string, precondition domain.Precondition) domain.CError
+	// GetNearestLocation returns the nearest location to the longitude and latitude, its distance is
+	// computed with formula, or the default formula if it is empty
+	GetNearestLocation(ctx context.Context, latitude, longitude float64, formula domain.DistanceFormula) (*domain.NearestLocation, domain.CError)
+	// FindNearestLocations returns the nearest locations to a point, narrowed by the filters in the request
+	FindNearestLocations(ctx context.Context, req *domain.NearestLocationsRequest) ([]domain.NearestLocation, domain.CError)
+	// FindLocationsAlongRoute returns the locations within a buffer distance of the route in the request,
+	// in the order the route passes them
+	FindLocationsAlongRoute(ctx context.Context, req *domain.AlongRouteRequest) ([]domain.RouteLocation, domain.CError)
+	// CreateLocationAlias adds an alias to a location specified by name
+	CreateLocationAlias(ctx context.Context, name string, req *domain.CreateLocationAliasRequest) (*domain.LocationAlias, domain.CError)
+	// ListLocationAliases returns the aliases of a location specified by name
+	ListLocationAliases(ctx context.Context, name string) ([]domain.LocationAlias, domain.CError)
+	// DeleteLocationAlias removes an alias from a location specified by name
+	DeleteLocationAlias(ctx context.Context, name, alias string) domain.CError
+	// ListPendingLocations returns a page of the locations waiting for review
+	ListPendingLocations(ctx context.Context, req *domain.ListPendingLocationsRequest) ([]domain.Location, domain.CError)
+	// ApproveLocation makes public a location waiting for review specified by name
+	ApproveLocation(ctx context.Context, name string) (*domain.Location, domain.CError)
+	// RejectLocation rejects a location waiting for review specified by name
+	RejectLocation(ctx context.Context, name string, req *domain.RejectLocationRequest) (*domain.Location, domain.CError)
 }
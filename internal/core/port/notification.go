@@ -0,0 +1,16 @@
+package port
+
+//go:generate moq -rm -out mock/notification.go -pkg mock . Notifier
+
+import (
+	"context"
+
+	"leeta/internal/core/domain"
+)
+
+// Notifier is an interface for alerting the operators
+type Notifier interface {
+	// Notify sends notification to the channels its type is routed to. It does not wait for
+	// the delivery, notifications that cannot be delivered are logged and dropped
+	Notify(ctx context.Context, notification *domain.Notification)
+}
@@ -1,19 +1,26 @@
 package port
 
+//go:generate moq -rm -out mock/ping.go -pkg mock . PingRepository PingService
+
 import (
 	"context"
+	"time"
 
 	"leeta/internal/core/domain"
 )
 
 // PingRepository is an interface for interacting with ping-related data
 type PingRepository interface {
-	// CreatePing inserts a new user into the database
-	CreatePing(ctx context.Context, user *domain.Ping) error
+	// CreatePing stores a ping and returns it with its id and creation time
+	CreatePing(ctx context.Context, ping *domain.Ping) (*domain.Ping, domain.CError)
+	// GetPingStats counts the pings received since dayStart, and separately since hourStart
+	GetPingStats(ctx context.Context, dayStart, hourStart time.Time) (*domain.PingStats, domain.CError)
 }
 
 // PingService is an interface for interacting with ping-related business logic
 type PingService interface {
-	// CreateCategory creates a new category
-	Ping(ctx context.Context, ping *domain.Ping) (domain.Ping, domain.CError)
+	// Ping stores a ping and returns it
+	Ping(ctx context.Context, ping *domain.Ping) (*domain.Ping, domain.CError)
+	// GetStats returns the uptime of the server and statistics about the recent pings
+	GetStats(ctx context.Context) (*domain.PingStats, domain.CError)
 }
@@ -0,0 +1,30 @@
+package port
+
+//go:generate moq -rm -out mock/popularity.go -pkg mock . PopularityRepository PopularityService
+
+import (
+	"context"
+	"time"
+
+	"leeta/internal/core/domain"
+)
+
+// PopularityRepository is an interface for interacting with the hits of locations
+type PopularityRepository interface {
+	// RecordLocationHits adds the hits, keyed by location id, to the hits of bucket. The hits of
+	// locations that no longer exist are dropped
+	RecordLocationHits(ctx context.Context, bucket time.Time, hits map[string]int64) domain.CError
+	// FindTrendingLocations fetches the approved locations with the most hits since since, most hits first
+	FindTrendingLocations(ctx context.Context, since time.Time, req *domain.TrendingLocationsRequest) ([]domain.TrendingLocation, domain.CError)
+	// DeleteLocationHits removes the hits of the buckets before before and returns how many were removed
+	DeleteLocationHits(ctx context.Context, before time.Time) (int64, domain.CError)
+}
+
+// PopularityService is an interface for interacting with popularity-related business logic
+type PopularityService interface {
+	// RecordHits counts a hit for each of the locations specified by id. The hits are written
+	// in the background, so it never blocks
+	RecordHits(ctx context.Context, ids ...string)
+	// FindTrendingLocations returns the locations with the most hits within the window of the request
+	FindTrendingLocations(ctx context.Context, req *domain.TrendingLocationsRequest) ([]domain.TrendingLocation, domain.CError)
+}
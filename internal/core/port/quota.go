@@ -0,0 +1,30 @@
+package port
+
+//go:generate moq -rm -out mock/quota.go -pkg mock . QuotaRepository QuotaService
+
+import (
+	"context"
+
+	"leeta/internal/core/domain"
+)
+
+// QuotaRepository is an interface for interacting with the quotas of the tenants and their usage
+type QuotaRepository interface {
+	// GetQuota returns the quota set for a tenant, ErrDataNotFound when none is
+	GetQuota(ctx context.Context, tenant string) (*domain.TenantQuota, domain.CError)
+	// SetQuota creates or replaces the quota set for a tenant
+	SetQuota(ctx context.Context, quota *domain.TenantQuota) (*domain.TenantQuota, domain.CError)
+	// CountResources returns the number of resources of a tenant
+	CountResources(ctx context.Context, tenant string, resource domain.QuotaResource) (int, domain.CError)
+}
+
+// QuotaService is an interface for interacting with quota-related business logic
+type QuotaService interface {
+	// CheckQuota returns an error when n more resources would exceed the quota of the tenant of the caller.
+	// The callers without a tenant have no quota
+	CheckQuota(ctx context.Context, resource domain.QuotaResource, n int) domain.CError
+	// GetQuota returns the quota of a tenant with its usage
+	GetQuota(ctx context.Context, tenant string) (*domain.Quota, domain.CError)
+	// SetQuota sets the quota of a tenant
+	SetQuota(ctx context.Context, tenant string, req *domain.SetQuotaRequest) (*domain.Quota, domain.CError)
+}
@@ -0,0 +1,16 @@
+package port
+
+//go:generate moq -rm -out mock/routing.go -pkg mock . Routing
+
+import (
+	"context"
+
+	"leeta/internal/core/domain"
+)
+
+// Routing is an interface for computing travel times over the road network
+type Routing interface {
+	// TravelTimes returns the travel time in seconds from origin to each of the destinations, in order,
+	// with the mode of travel. Destinations that cannot be reached get a negative travel time
+	TravelTimes(ctx context.Context, origin domain.Point, destinations []domain.Point, mode domain.TravelMode) ([]float64, error)
+}
@@ -0,0 +1,27 @@
+package port
+
+//go:generate moq -rm -out mock/search.go -pkg mock . SearchIndex SearchService
+
+import (
+	"context"
+
+	"leeta/internal/core/domain"
+)
+
+// SearchIndex is an interface for a search engine indexing the locations
+type SearchIndex interface {
+	// IndexLocations adds the locations to the index, replacing the indexed ones with the same id
+	IndexLocations(ctx context.Context, locations []domain.Location) error
+	// DeleteLocation removes a location from the index, removing one that is not indexed is not an error
+	DeleteLocation(ctx context.Context, id string) error
+	// SearchLocations returns the indexed locations matching the query of the request, best match first
+	SearchLocations(ctx context.Context, req *domain.SearchLocationsRequest) (*domain.LocationSearchResult, error)
+}
+
+// SearchService is an interface for interacting with search-related business logic
+type SearchService interface {
+	// SearchLocations returns the locations matching a free text query, tolerating typos
+	SearchLocations(ctx context.Context, req *domain.SearchLocationsRequest) (*domain.LocationSearchResult, domain.CError)
+	// Reindex indexes every location again, e.g. to fill a new index
+	Reindex(ctx context.Context) error
+}
@@ -0,0 +1,34 @@
+package port
+
+//go:generate moq -rm -out mock/share.go -pkg mock . ShareRepository ShareService
+
+import (
+	"context"
+
+	"leeta/internal/core/domain"
+)
+
+// ShareRepository is an interface for interacting with the share links of the locations
+type ShareRepository interface {
+	// CreateShare inserts a share, its tenant is the one of the caller
+	CreateShare(ctx context.Context, share *domain.LocationShare) (*domain.LocationShare, domain.CError)
+	// GetShare returns a share by id, revoked and expired ones included, ErrDataNotFound when none has it
+	GetShare(ctx context.Context, id string) (*domain.LocationShare, domain.CError)
+	// ListShares returns the shares of a location neither revoked nor expired, the latest first
+	ListShares(ctx context.Context, locationID string) ([]domain.LocationShare, domain.CError)
+	// RevokeShare revokes a share of a location, ErrDataNotFound when the location has no such share
+	// not revoked yet
+	RevokeShare(ctx context.Context, locationID, id string) domain.CError
+}
+
+// ShareService is an interface for interacting with share-related business logic
+type ShareService interface {
+	// ShareLocation creates a link to a location
+	ShareLocation(ctx context.Context, name string, req *domain.ShareLocationRequest) (*domain.LocationShare, domain.CError)
+	// ListShares returns the links to a location neither revoked nor expired
+	ListShares(ctx context.Context, name string) ([]domain.LocationShare, domain.CError)
+	// RevokeShare revokes a link to a location
+	RevokeShare(ctx context.Context, name, id string) domain.CError
+	// GetSharedLocation returns the location a link points to, whatever its status or validity
+	GetSharedLocation(ctx context.Context, token string) (*domain.Location, domain.CError)
+}
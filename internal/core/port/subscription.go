@@ -0,0 +1,41 @@
+package port
+
+//go:generate moq -rm -out mock/subscription.go -pkg mock . SubscriptionRepository SubscriptionService MatchDelivery
+
+import (
+	"context"
+
+	"leeta/internal/core/domain"
+)
+
+// SubscriptionRepository is an interface for interacting with the proximity subscriptions
+type SubscriptionRepository interface {
+	// CreateSubscription inserts a new subscription into the database
+	CreateSubscription(ctx context.Context, subscription *domain.Subscription) (*domain.Subscription, domain.CError)
+	// GetSubscription selects a subscription by id
+	GetSubscription(ctx context.Context, id string) (*domain.Subscription, domain.CError)
+	// DeleteSubscription deletes a subscription by id
+	DeleteSubscription(ctx context.Context, id string) domain.CError
+	// MatchSubscriptions selects the subscriptions of tenant watching target and kind whose area covers point.
+	// With a previous point, the subscriptions whose area already covered it are left out
+	MatchSubscriptions(ctx context.Context, tenant string, target domain.SubscriptionTarget, kind string, point domain.Point, previous *domain.Point) ([]domain.Subscription, domain.CError)
+}
+
+// SubscriptionService is an interface for interacting with proximity subscription-related business logic
+type SubscriptionService interface {
+	// CreateSubscription registers a standing query for what appears near a point
+	CreateSubscription(ctx context.Context, req *domain.CreateSubscriptionRequest) (*domain.Subscription, domain.CError)
+	// GetSubscription returns a subscription by id
+	GetSubscription(ctx context.Context, id string) (*domain.Subscription, domain.CError)
+	// DeleteSubscription removes a subscription by id, no match is delivered for it afterwards
+	DeleteSubscription(ctx context.Context, id string) domain.CError
+	// MatchEntity delivers position to the subscriptions whose area the entity entered since its
+	// previous position, previous is nil for an entity that was not tracked
+	MatchEntity(ctx context.Context, position, previous *domain.EntityPosition)
+}
+
+// MatchDelivery is an interface for delivering the matches of the proximity subscriptions
+type MatchDelivery interface {
+	// Deliver sends match to the receivers of subscription, returning an error if it failed
+	Deliver(ctx context.Context, subscription *domain.Subscription, match *domain.ProximityMatch) error
+}
@@ -0,0 +1,44 @@
+package port
+
+//go:generate moq -rm -out mock/sync.go -pkg mock . LocationSource SyncRepository SyncService
+
+import (
+	"context"
+
+	"leeta/internal/core/domain"
+)
+
+// LocationSource is an interface for an external source of locations, such as the export of an ERP
+type LocationSource interface {
+	// FetchLocations returns every location listed by the source, it fails if any of them is invalid
+	FetchLocations(ctx context.Context) ([]domain.RegisterLocationRequest, error)
+}
+
+// SyncRepository is an interface for interacting with the synced locations and the sync reports
+type SyncRepository interface {
+	// ListSourceLocations fetches the approved and removed locations synced from the source
+	ListSourceLocations(ctx context.Context, source string) ([]domain.Location, domain.CError)
+	// SetLocationStatus moves the location specified by id from the status from to the status to, writing
+	// a deleted event to the outbox when it is no longer approved and a created event when it becomes
+	// approved. It fails with a conflict if the location is not in the status from
+	SetLocationStatus(ctx context.Context, id string, from, to domain.LocationStatus) (*domain.Location, domain.CError)
+	// CreateSyncReport inserts the report of a sync that starts
+	CreateSyncReport(ctx context.Context, report *domain.SyncReport) (*domain.SyncReport, domain.CError)
+	// FinishSyncReport records the outcome of a sync, its status, counts, changes and error
+	FinishSyncReport(ctx context.Context, report *domain.SyncReport) domain.CError
+	// GetSyncReport fetches a sync report by id
+	GetSyncReport(ctx context.Context, id string) (*domain.SyncReport, domain.CError)
+	// ListSyncReports fetches the sync reports matching the request, most recent first
+	ListSyncReports(ctx context.Context, req *domain.ListSyncReportsRequest) ([]domain.SyncReport, domain.CError)
+}
+
+// SyncService is an interface for interacting with sync-related business logic
+type SyncService interface {
+	// Sync brings the locations of a registered source in line with it, creating, updating, removing
+	// and restoring them, and returns the report of the sync
+	Sync(ctx context.Context, source string) (*domain.SyncReport, domain.CError)
+	// GetSyncReport returns a sync report specified by id
+	GetSyncReport(ctx context.Context, id string) (*domain.SyncReport, domain.CError)
+	// ListSyncReports returns the sync reports, most recent first
+	ListSyncReports(ctx context.Context, req *domain.ListSyncReportsRequest) ([]domain.SyncReport, domain.CError)
+}
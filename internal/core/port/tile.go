@@ -0,0 +1,22 @@
+package port
+
+//go:generate moq -rm -out mock/tile.go -pkg mock . TileRepository TileService
+
+import (
+	"context"
+
+	"leeta/internal/core/domain"
+)
+
+// TileRepository is an interface for rendering the locations as vector tiles
+type TileRepository interface {
+	// LocationTile encodes the approved locations within the tile of the request, narrowed by its filter, as a
+	// Mapbox Vector Tile with a single locations layer. A tile with no location is empty
+	LocationTile(ctx context.Context, req *domain.TileRequest) ([]byte, domain.CError)
+}
+
+// TileService is an interface for interacting with tile-related business logic
+type TileService interface {
+	// GetLocationTile returns the Mapbox Vector Tile of the locations within a tile, empty when it has none
+	GetLocationTile(ctx context.Context, req *domain.TileRequest) ([]byte, domain.CError)
+}
@@ -0,0 +1,14 @@
+package port
+
+//go:generate moq -rm -out mock/tx.go -pkg mock . TxManager
+
+import (
+	"context"
+)
+
+// TxManager is an interface for running business logic atomically
+type TxManager interface {
+	// WithinTx runs fn in a transaction carried by the ctx passed to it. The transaction is
+	// committed if fn returns nil and rolled back otherwise. Nested calls join the outer transaction
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
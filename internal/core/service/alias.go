@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+
+	"leeta/internal/adapter/logger"
+	"leeta/internal/core/domain"
+	"leeta/internal/core/errcode"
+
+	"go.uber.org/zap"
+)
+
+func (ls *LocationService) CreateLocationAlias(ctx context.Context, name string, req *domain.CreateLocationAliasRequest) (*domain.LocationAlias, domain.CError) {
+	alias, cerr := ls.repo.CreateLocationAlias(ctx, name, &domain.LocationAlias{Name: req.Name})
+	if cerr != nil {
+		switch {
+		case isUnavailable(cerr) || isViolation(cerr):
+			return nil, cerr
+		case cerr.Code() == 404:
+			return nil, errLocationNotFound
+		case cerr.Code() == 409:
+			return nil, domain.NewCodedCError(errcode.AliasTaken, "the alias is already the name or an alias of a location")
+		}
+
+		logger.FromCtx(ctx).Error("Error creating location alias", zap.Error(cerr))
+		return nil, domain.ErrInternal
+	}
+
+	return alias, nil
+}
+
+func (ls *LocationService) ListLocationAliases(ctx context.Context, name string) ([]domain.LocationAlias, domain.CError) {
+	aliases, cerr := ls.repo.ListLocationAliases(ctx, name)
+	if cerr != nil {
+		switch {
+		case isUnavailable(cerr):
+			return nil, cerr
+		case cerr.Code() == 404:
+			return nil, errLocationNotFound
+		}
+
+		logger.FromCtx(ctx).Error("Error listing location aliases", zap.Error(cerr))
+		return nil, domain.ErrInternal
+	}
+
+	return aliases, nil
+}
+
+func (ls *LocationService) DeleteLocationAlias(ctx context.Context, name, alias string) domain.CError {
+	cerr := ls.repo.DeleteLocationAlias(ctx, name, alias)
+	// the location may be cached under the alias
+	ls.purgeCache()
+	if cerr != nil {
+		switch {
+		case isUnavailable(cerr):
+			return cerr
+		case cerr.Code() == 404:
+			return domain.NewCodedCError(errcode.AliasNotFound, "location or alias not found")
+		}
+
+		logger.FromCtx(ctx).Error("Error deleting location alias", zap.Error(cerr))
+		return domain.ErrInternal
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+
+	"leeta/internal/adapter/logger"
+	"leeta/internal/core/domain"
+	"leeta/internal/core/errcode"
+	"leeta/internal/core/port"
+
+	"go.uber.org/zap"
+)
+
+// errCollectionNotFound is returned when a collection, or the collection a query is restricted to, does not exist
+var errCollectionNotFound = domain.NewCodedCError(errcode.CollectionNotFound, "collection not found")
+
+/**
+ * CollectionService implements port.CollectionService interface
+ */
+type CollectionService struct {
+	repo port.CollectionRepository
+}
+
+// NewCollectionService creates a new collection service instance
+func NewCollectionService(repo port.CollectionRepository) *CollectionService {
+	return &CollectionService{
+		repo,
+	}
+}
+
+func (cs *CollectionService) CreateCollection(ctx context.Context, req *domain.CreateCollectionRequest) (*domain.Collection, domain.CError) {
+	collection, cerr := cs.repo.CreateCollection(ctx, &domain.Collection{Name: req.Name})
+	if cerr != nil {
+		switch {
+		case isUnavailable(cerr) || isViolation(cerr):
+			return nil, cerr
+		case cerr.Code() == 409:
+			return nil, domain.NewCodedCError(errcode.DuplicateCollection, "collection already exists")
+		}
+
+		logger.FromCtx(ctx).Error("Error creating collection", zap.Error(cerr))
+		return nil, domain.ErrInternal
+	}
+
+	return collection, nil
+}
+
+func (cs *CollectionService) ListCollections(ctx context.Context) ([]domain.Collection, domain.CError) {
+	collections, cerr := cs.repo.ListCollections(ctx)
+	if cerr != nil {
+		if isUnavailable(cerr) {
+			return nil, cerr
+		}
+
+		logger.FromCtx(ctx).Error("Error listing collections", zap.Error(cerr))
+		return nil, domain.ErrInternal
+	}
+
+	return collections, nil
+}
+
+func (cs *CollectionService) DeleteCollection(ctx context.Context, name string) domain.CError {
+	cerr := cs.repo.DeleteCollection(ctx, name)
+	if cerr != nil {
+		switch {
+		case isUnavailable(cerr):
+			return cerr
+		case cerr.Code() == 404:
+			return errCollectionNotFound
+		}
+
+		logger.FromCtx(ctx).Error("Error deleting collection", zap.Error(cerr))
+		return domain.ErrInternal
+	}
+
+	return nil
+}
+
+func (cs *CollectionService) AddCollectionLocation(ctx context.Context, name, location string) domain.CError {
+	cerr := cs.repo.AddCollectionLocation(ctx, name, location)
+	if cerr != nil {
+		switch {
+		case isUnavailable(cerr) || isViolation(cerr):
+			return cerr
+		case cerr.Code() == 404:
+			return domain.NewCodedCError(errcode.CollectionMemberNotFound, "collection or location not found")
+		}
+
+		logger.FromCtx(ctx).Error("Error adding location to collection", zap.Error(cerr))
+		return domain.ErrInternal
+	}
+
+	return nil
+}
+
+func (cs *CollectionService) RemoveCollectionLocation(ctx context.Context, name, location string) domain.CError {
+	cerr := cs.repo.RemoveCollectionLocation(ctx, name, location)
+	if cerr != nil {
+		switch {
+		case isUnavailable(cerr):
+			return cerr
+		case cerr.Code() == 404:
+			return domain.NewCodedCError(errcode.CollectionMemberNotFound, "collection not found or location not in it")
+		}
+
+		logger.FromCtx(ctx).Error("Error removing location from collection", zap.Error(cerr))
+		return domain.ErrInternal
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"leeta/internal/adapter/logger"
+	"leeta/internal/core/auth"
+	"leeta/internal/core/domain"
+	"leeta/internal/core/errcode"
+	"leeta/internal/core/port"
+
+	"go.uber.org/zap"
+)
+
+/**
+ * CostGuard implements port.LocationService interface. It refuses
+ * the nearest searches and exports too expensive for the plan of
+ * the tenant of the caller before they reach another location
+ * service, so one client cannot slow the database for the others
+ */
+type CostGuard struct {
+	port.LocationService
+	counter port.QuotaRepository
+	limits  domain.CostLimits
+	tenants map[string]domain.CostLimits
+}
+
+// NewCostGuard creates a new cost guard instance in front of next. The requests are bounded by the limits of the
+// plan of their tenant in tenants, keyed by tenant ignoring case, or by limits for the tenants on no plan. The
+// locations of a tenant are counted with counter
+func NewCostGuard(next port.LocationService, counter port.QuotaRepository, limits domain.CostLimits, tenants map[string]domain.CostLimits) *CostGuard {
+	byTenant := make(map[string]domain.CostLimits, len(tenants))
+	for tenant, plan := range tenants {
+		byTenant[strings.ToLower(tenant)] = plan
+	}
+
+	return &CostGuard{
+		next,
+		counter,
+		limits,
+		byTenant,
+	}
+}
+
+// FindNearestLocations refuses the searches farther than the maximum radius of the plan without categories,
+// which read every location within their distance
+func (cg *CostGuard) FindNearestLocations(ctx context.Context, req *domain.NearestLocationsRequest) ([]domain.NearestLocation, domain.CError) {
+	limits := cg.tenantLimits(ctx)
+	if limits.MaxRadius > 0 && req.MaxDistance > limits.MaxRadius && len(req.Categories) == 0 {
+		return nil, domain.NewCodedCError(errcode.QueryTooExpensive, fmt.Sprintf(
+			"a nearest search farther than %g meters needs categories, narrow max_distance or filter by categories", limits.MaxRadius))
+	}
+
+	return cg.LocationService.FindNearestLocations(ctx, req)
+}
+
+// ExportLocations refuses the exports without a filter of the tenants with more locations than the plan allows,
+// which stream the whole tenant in one response. The callers without a tenant see the locations without a tenant,
+// which are counted in their place
+func (cg *CostGuard) ExportLocations(ctx context.Context, req *domain.ListLocationsRequest, fn func(location *domain.Location) error) domain.CError {
+	limits := cg.tenantLimits(ctx)
+	tenant := auth.Tenant(ctx)
+	if limits.MaxExportLocations > 0 && req.Query == "" && req.Collection == "" {
+		count, cerr := cg.counter.CountResources(ctx, tenant, domain.QuotaLocations)
+		if cerr != nil {
+			if isUnavailable(cerr) {
+				return cerr
+			}
+
+			// the export is let through, it could not be told apart from a cheap one
+			logger.FromCtx(ctx).Error("Error counting locations, skipping the export cost check", zap.Error(cerr))
+		} else if count > limits.MaxExportLocations {
+			owner := "tenant " + tenant
+			if tenant == "" {
+				owner = "the locations without a tenant"
+			}
+			return domain.NewCodedCError(errcode.QueryTooExpensive, fmt.Sprintf(
+				"%s has %d locations, an export of more than %d needs a filter, narrow it with q or collection",
+				owner, count, limits.MaxExportLocations))
+		}
+	}
+
+	return cg.LocationService.ExportLocations(ctx, req, fn)
+}
+
+// tenantLimits returns the limits of the plan of the tenant of the caller
+func (cg *CostGuard) tenantLimits(ctx context.Context) domain.CostLimits {
+	if limits, ok := cg.tenants[strings.ToLower(auth.Tenant(ctx))]; ok {
+		return limits
+	}
+	return cg.limits
+}
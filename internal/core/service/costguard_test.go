@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"leeta/internal/core/auth"
+	"leeta/internal/core/domain"
+	"leeta/internal/core/errcode"
+	"leeta/internal/core/port/mock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCostGuard(t *testing.T) {
+	next := &mock.LocationServiceMock{
+		FindNearestLocationsFunc: func(ctx context.Context, req *domain.NearestLocationsRequest) ([]domain.NearestLocation, domain.CError) {
+			return []domain.NearestLocation{}, nil
+		},
+		ExportLocationsFunc: func(ctx context.Context, req *domain.ListLocationsRequest, fn func(location *domain.Location) error) domain.CError {
+			return nil
+		},
+	}
+	counter := &mock.QuotaRepositoryMock{
+		CountResourcesFunc: func(ctx context.Context, tenant string, resource domain.QuotaResource) (int, domain.CError) {
+			return 250000, nil
+		},
+	}
+	cg := NewCostGuard(next, counter, domain.CostLimits{MaxRadius: 50000, MaxExportLocations: 100000}, map[string]domain.CostLimits{
+		"Globex": {},
+	})
+	acme := auth.WithPrincipal(context.Background(), &auth.Principal{User: "ada", Tenant: "acme"})
+	globex := auth.WithPrincipal(context.Background(), &auth.Principal{User: "hank", Tenant: "globex"})
+
+	t.Run("Wide searches without categories are refused", func(t *testing.T) {
+		_, cerr := cg.FindNearestLocations(acme, &domain.NearestLocationsRequest{Latitude: 6.45, Longitude: 3.47, MaxDistance: 80000})
+		require.NotNil(t, cerr)
+		assert.Equal(t, http.StatusUnprocessableEntity, cerr.Code())
+		assert.Equal(t, errcode.QueryTooExpensive, cerr.ErrorCode())
+		assert.Empty(t, next.FindNearestLocationsCalls())
+	})
+
+	t.Run("Narrow searches and searches with categories pass", func(t *testing.T) {
+		for _, req := range []*domain.NearestLocationsRequest{
+			{Latitude: 6.45, Longitude: 3.47, MaxDistance: 20000},
+			{Latitude: 6.45, Longitude: 3.47},
+			{Latitude: 6.45, Longitude: 3.47, MaxDistance: 80000, Categories: []string{"pharmacy"}},
+		} {
+			_, cerr := cg.FindNearestLocations(acme, req)
+			assert.Nil(t, cerr)
+		}
+		assert.Len(t, next.FindNearestLocationsCalls(), 3)
+	})
+
+	t.Run("Exports of large tenants need a filter", func(t *testing.T) {
+		cerr := cg.ExportLocations(acme, &domain.ListLocationsRequest{}, nil)
+		require.NotNil(t, cerr)
+		assert.Equal(t, errcode.QueryTooExpensive, cerr.ErrorCode())
+		assert.Equal(t, "acme", counter.CountResourcesCalls()[0].Tenant)
+
+		cerr = cg.ExportLocations(acme, &domain.ListLocationsRequest{Query: "category:pharmacy"}, nil)
+		assert.Nil(t, cerr)
+		assert.Len(t, next.ExportLocationsCalls(), 1)
+	})
+
+	t.Run("The plan of the tenant lifts the limits", func(t *testing.T) {
+		_, cerr := cg.FindNearestLocations(globex, &domain.NearestLocationsRequest{Latitude: 6.45, Longitude: 3.47, MaxDistance: 80000})
+		assert.Nil(t, cerr)
+
+		cerr = cg.ExportLocations(globex, &domain.ListLocationsRequest{}, nil)
+		assert.Nil(t, cerr)
+	})
+
+	t.Run("Exports of callers without a tenant count the locations without a tenant", func(t *testing.T) {
+		calls := len(counter.CountResourcesCalls())
+
+		cerr := cg.ExportLocations(context.Background(), &domain.ListLocationsRequest{}, nil)
+		require.NotNil(t, cerr)
+		assert.Equal(t, errcode.QueryTooExpensive, cerr.ErrorCode())
+		require.Len(t, counter.CountResourcesCalls(), calls+1)
+		assert.Equal(t, "", counter.CountResourcesCalls()[calls].Tenant)
+		assert.Equal(t, domain.QuotaLocations, counter.CountResourcesCalls()[calls].Resource)
+
+		exports := len(next.ExportLocationsCalls())
+		cerr = cg.ExportLocations(context.Background(), &domain.ListLocationsRequest{Collection: "lagos-pharmacies"}, nil)
+		assert.Nil(t, cerr)
+		assert.Len(t, next.ExportLocationsCalls(), exports+1)
+	})
+}
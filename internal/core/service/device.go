@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"leeta/internal/adapter/logger"
+	"leeta/internal/core/domain"
+	"leeta/internal/core/errcode"
+	"leeta/internal/core/port"
+
+	"go.uber.org/zap"
+)
+
+/**
+ * DeviceService implements port.DeviceService interface
+ */
+type DeviceService struct {
+	repo port.DeviceRepository
+}
+
+// NewDeviceService creates a new device service instance
+func NewDeviceService(repo port.DeviceRepository) *DeviceService {
+	return &DeviceService{
+		repo,
+	}
+}
+
+func (ds *DeviceService) RegisterDevice(ctx context.Context, req *domain.RegisterDeviceRequest) (*domain.Device, domain.CError) {
+	device, cerr := ds.repo.RegisterDevice(ctx, &domain.Device{
+		ID:   req.ID,
+		Name: req.Name,
+	})
+	if cerr != nil {
+		if isUnavailable(cerr) || isViolation(cerr) {
+			return nil, cerr
+		}
+
+		logger.FromCtx(ctx).Error("Error registering device", zap.Error(cerr))
+		return nil, domain.ErrInternal
+	}
+
+	return device, nil
+}
+
+func (ds *DeviceService) Heartbeat(ctx context.Context, req *domain.HeartbeatRequest) (*domain.Device, domain.CError) {
+	device, cerr := ds.repo.RecordHeartbeat(ctx, req.DeviceID, req.Latitude, req.Longitude)
+	if cerr != nil {
+		if cerr.Code() == 404 {
+			return nil, domain.NewCodedCError(errcode.DeviceNotRegistered, "device is not registered")
+		}
+		if isUnavailable(cerr) {
+			return nil, cerr
+		}
+
+		logger.FromCtx(ctx).Error("Error recording heartbeat", zap.Error(cerr))
+		return nil, domain.ErrInternal
+	}
+
+	return device, nil
+}
+
+func (ds *DeviceService) ListStaleDevices(ctx context.Context, notSeenFor time.Duration) ([]domain.Device, domain.CError) {
+	devices, cerr := ds.repo.ListStaleDevices(ctx, time.Now().Add(-notSeenFor))
+	if cerr != nil {
+		if isUnavailable(cerr) {
+			return nil, cerr
+		}
+
+		logger.FromCtx(ctx).Error("Error listing stale devices", zap.Error(cerr))
+		return nil, domain.ErrInternal
+	}
+
+	return devices, nil
+}
@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"leeta/internal/core/domain"
+	"leeta/internal/core/errcode"
+	"leeta/internal/core/port/mock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeviceService_RegisterDevice(t *testing.T) {
+	repo := &mock.DeviceRepositoryMock{
+		RegisterDeviceFunc: func(ctx context.Context, device *domain.Device) (*domain.Device, domain.CError) {
+			switch device.ID {
+			case "long":
+				return nil, domain.NewCodedCError(errcode.ConstraintViolated, "name is too long")
+			case "down":
+				return nil, domain.ErrServiceUnavailable
+			case "broken":
+				return nil, domain.NewCError(http.StatusInternalServerError, "connection reset")
+			}
+			return &domain.Device{ID: device.ID, Name: device.Name, RegisteredAt: time.Now()}, nil
+		},
+	}
+	ds := NewDeviceService(repo)
+
+	tests := []struct {
+		name string
+		id   string
+		code errcode.Code
+		err  domain.CError
+	}{
+		{"Success", "truck-42", "", nil},
+		{"Constraint violated", "long", errcode.ConstraintViolated, nil},
+		{"Database unavailable", "down", errcode.ServiceUnavailable, nil},
+		{"Database error", "broken", "", domain.ErrInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			device, cerr := ds.RegisterDevice(context.Background(), &domain.RegisterDeviceRequest{ID: tt.id, Name: "Truck"})
+			switch {
+			case tt.err != nil:
+				assert.Equal(t, tt.err, cerr)
+			case tt.code != "":
+				require.NotNil(t, cerr)
+				assert.Equal(t, tt.code, cerr.ErrorCode())
+			default:
+				require.Nil(t, cerr)
+				assert.Equal(t, tt.id, device.ID)
+				assert.Equal(t, "Truck", device.Name)
+			}
+		})
+	}
+}
+
+func TestDeviceService_Heartbeat(t *testing.T) {
+	repo := &mock.DeviceRepositoryMock{
+		RecordHeartbeatFunc: func(ctx context.Context, id string, latitude, longitude *float64) (*domain.Device, domain.CError) {
+			switch id {
+			case "bike-7":
+				return nil, domain.ErrDataNotFound
+			case "down":
+				return nil, domain.ErrTimeout
+			case "broken":
+				return nil, domain.NewCError(http.StatusInternalServerError, "connection reset")
+			}
+			return &domain.Device{ID: id, Latitude: latitude, Longitude: longitude, LastSeenAt: time.Now()}, nil
+		},
+	}
+	ds := NewDeviceService(repo)
+
+	tests := []struct {
+		name string
+		id   string
+		code errcode.Code
+		err  domain.CError
+	}{
+		{"Success", "truck-42", "", nil},
+		{"Unregistered device", "bike-7", errcode.DeviceNotRegistered, nil},
+		{"Database timeout", "down", errcode.Timeout, nil},
+		{"Database error", "broken", "", domain.ErrInternal},
+	}
+
+	latitude, longitude := 40.7589, -73.9851
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			device, cerr := ds.Heartbeat(context.Background(), &domain.HeartbeatRequest{DeviceID: tt.id, Latitude: &latitude, Longitude: &longitude})
+			switch {
+			case tt.err != nil:
+				assert.Equal(t, tt.err, cerr)
+			case tt.code != "":
+				require.NotNil(t, cerr)
+				assert.Equal(t, tt.code, cerr.ErrorCode())
+			default:
+				require.Nil(t, cerr)
+				assert.Equal(t, latitude, *device.Latitude)
+				assert.Equal(t, longitude, *device.Longitude)
+			}
+		})
+	}
+}
+
+func TestDeviceService_ListStaleDevices(t *testing.T) {
+	var fail domain.CError
+	repo := &mock.DeviceRepositoryMock{
+		ListStaleDevicesFunc: func(ctx context.Context, since time.Time) ([]domain.Device, domain.CError) {
+			if fail != nil {
+				return nil, fail
+			}
+			return []domain.Device{{ID: "truck-42"}}, nil
+		},
+	}
+	ds := NewDeviceService(repo)
+
+	t.Run("Success", func(t *testing.T) {
+		devices, cerr := ds.ListStaleDevices(context.Background(), 5*time.Minute)
+		require.Nil(t, cerr)
+		assert.Len(t, devices, 1)
+
+		require.Len(t, repo.ListStaleDevicesCalls(), 1)
+		assert.WithinDuration(t, time.Now().Add(-5*time.Minute), repo.ListStaleDevicesCalls()[0].Since, time.Second)
+	})
+
+	t.Run("Database unavailable", func(t *testing.T) {
+		fail = domain.ErrServiceUnavailable
+		_, cerr := ds.ListStaleDevices(context.Background(), 5*time.Minute)
+		assert.Equal(t, domain.ErrServiceUnavailable, cerr)
+	})
+
+	t.Run("Database error", func(t *testing.T) {
+		fail = domain.NewCError(http.StatusInternalServerError, "connection reset")
+		_, cerr := ds.ListStaleDevices(context.Background(), 5*time.Minute)
+		assert.Equal(t, domain.ErrInternal, cerr)
+	})
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"leeta/internal/adapter/logger"
+	"leeta/internal/core/domain"
+	"leeta/internal/core/port"
+
+	"go.uber.org/zap"
+)
+
+// defaultStaleAfter is how long a position is current when no expiry is configured
+const defaultStaleAfter = 2 * time.Minute
+
+/**
+ * EntityService implements port.EntityService interface
+ */
+type EntityService struct {
+	repo          port.EntityRepository
+	staleAfter    time.Duration
+	subscriptions port.SubscriptionService
+}
+
+// NewEntityService creates a new entity service instance, positions older than staleAfter are left
+// out of the searches. The reported positions are matched against subscriptions, unless it is nil
+func NewEntityService(repo port.EntityRepository, staleAfter time.Duration, subscriptions port.SubscriptionService) *EntityService {
+	if staleAfter <= 0 {
+		staleAfter = defaultStaleAfter
+	}
+
+	return &EntityService{
+		repo,
+		staleAfter,
+		subscriptions,
+	}
+}
+
+func (es *EntityService) ReportPosition(ctx context.Context, id string, req *domain.ReportPositionRequest) (*domain.EntityPosition, domain.CError) {
+	position, previous, cerr := es.repo.ReportPosition(ctx, &domain.EntityPosition{
+		EntityID:  id,
+		Kind:      req.Kind,
+		Latitude:  req.Latitude,
+		Longitude: req.Longitude,
+	})
+	if cerr != nil {
+		if isUnavailable(cerr) || isViolation(cerr) {
+			return nil, cerr
+		}
+
+		logger.FromCtx(ctx).Error("Error reporting entity position", zap.Error(cerr))
+		return nil, domain.ErrInternal
+	}
+
+	if es.subscriptions != nil {
+		// an entity that stopped reporting was out of sight, it appears again wherever it is
+		if previous != nil && previous.ReportedAt.Before(time.Now().Add(-es.staleAfter)) {
+			previous = nil
+		}
+		es.subscriptions.MatchEntity(ctx, position, previous)
+	}
+
+	return position, nil
+}
+
+func (es *EntityService) FindNearestEntities(ctx context.Context, req *domain.NearestEntitiesRequest) ([]domain.NearestEntity, domain.CError) {
+	entities, cerr := es.repo.FindNearestEntities(ctx, time.Now().Add(-es.staleAfter), req)
+	if cerr != nil {
+		if isUnavailable(cerr) {
+			return nil, cerr
+		}
+
+		logger.FromCtx(ctx).Error("Error finding nearest entities", zap.Error(cerr))
+		return nil, domain.ErrInternal
+	}
+
+	return entities, nil
+}
+
+// ExpirePositions removes the stale positions, the entities that stopped reporting
+func (es *EntityService) ExpirePositions(ctx context.Context) error {
+	deleted, cerr := es.repo.DeletePositions(ctx, time.Now().Add(-es.staleAfter))
+	if cerr != nil {
+		return cerr
+	}
+
+	if deleted > 0 {
+		logger.FromCtx(ctx).Info("Expired entity positions", zap.Int64("deleted", deleted))
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"leeta/internal/core/domain"
+	"leeta/internal/core/port/mock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEntityService_StalePositions(t *testing.T) {
+	repo := &mock.EntityRepositoryMock{
+		FindNearestEntitiesFunc: func(ctx context.Context, since time.Time, req *domain.NearestEntitiesRequest) ([]domain.NearestEntity, domain.CError) {
+			return []domain.NearestEntity{}, nil
+		},
+		DeletePositionsFunc: func(ctx context.Context, before time.Time) (int64, domain.CError) {
+			return 3, nil
+		},
+	}
+	es := NewEntityService(repo, 30*time.Second, nil)
+
+	t.Run("Searches leave the stale positions out", func(t *testing.T) {
+		_, cerr := es.FindNearestEntities(context.Background(), &domain.NearestEntitiesRequest{Latitude: 6.52, Longitude: 3.37})
+		require.Nil(t, cerr)
+
+		since := repo.FindNearestEntitiesCalls()[0].Since
+		assert.WithinDuration(t, time.Now().Add(-30*time.Second), since, time.Second)
+	})
+
+	t.Run("Stale positions expire", func(t *testing.T) {
+		require.NoError(t, es.ExpirePositions(context.Background()))
+
+		before := repo.DeletePositionsCalls()[0].Before
+		assert.WithinDuration(t, time.Now().Add(-30*time.Second), before, time.Second)
+	})
+
+	t.Run("Positions are current for 2 minutes by default", func(t *testing.T) {
+		assert.Equal(t, 2*time.Minute, NewEntityService(repo, 0, nil).staleAfter)
+	})
+}
+
+func TestEntityService_ReportPosition(t *testing.T) {
+	var previous *domain.EntityPosition
+	repo := &mock.EntityRepositoryMock{
+		ReportPositionFunc: func(ctx context.Context, position *domain.EntityPosition) (*domain.EntityPosition, *domain.EntityPosition, domain.CError) {
+			return position, previous, nil
+		},
+	}
+	subscriptions := &mock.SubscriptionServiceMock{
+		MatchEntityFunc: func(ctx context.Context, position, previous *domain.EntityPosition) {},
+	}
+	es := NewEntityService(repo, time.Minute, subscriptions)
+	req := &domain.ReportPositionRequest{Kind: "courier", Latitude: 6.52, Longitude: 3.37}
+
+	t.Run("The position is matched from the previous one", func(t *testing.T) {
+		previous = &domain.EntityPosition{Latitude: 6.60, Longitude: 3.35, ReportedAt: time.Now().Add(-10 * time.Second)}
+
+		_, cerr := es.ReportPosition(context.Background(), "courier-1", req)
+		require.Nil(t, cerr)
+
+		call := subscriptions.MatchEntityCalls()[0]
+		assert.Equal(t, "courier-1", call.Position.EntityID)
+		assert.Equal(t, previous, call.Previous)
+	})
+
+	t.Run("A stale previous position is not matched from", func(t *testing.T) {
+		previous = &domain.EntityPosition{Latitude: 6.60, Longitude: 3.35, ReportedAt: time.Now().Add(-time.Hour)}
+
+		_, cerr := es.ReportPosition(context.Background(), "courier-1", req)
+		require.Nil(t, cerr)
+
+		assert.Nil(t, subscriptions.MatchEntityCalls()[1].Previous)
+	})
+}
@@ -0,0 +1,584 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"leeta/internal/adapter/logger"
+	"leeta/internal/core/domain"
+	"leeta/internal/core/errcode"
+	"leeta/internal/core/port"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// restoreMaxChanges is the number of changes kept in a restore report, the counts cover the others
+	restoreMaxChanges = 1000
+
+	// snapshotName starts the file name of the snapshots, followed by the time they were taken and the format
+	snapshotName = "locations-"
+	// snapshotTime is the layout of the time in the file name of the snapshots, it sorts like the times
+	snapshotTime = "20060102T150405Z"
+)
+
+var errSnapshotNotFound = domain.NewCodedCError(errcode.SnapshotNotFound, "snapshot not found")
+
+// snapshotContentTypes are the content types the snapshots are stored with, by format
+var snapshotContentTypes = map[domain.ExportFormat]string{
+	domain.ExportGeoJSON: "application/geo+json",
+	domain.ExportCSV:     "text/csv",
+}
+
+// snapshotColumns are the columns of the CSV snapshots, a superset of the columns of the CSV imports
+var snapshotColumns = []string{
+	"id", "name", "slug", "latitude", "longitude", "category", "altitude", "valid_from", "valid_until", "created_at", "updated_at",
+}
+
+/**
+ * ExportService implements port.ExportService interface.
+ * A snapshot is written to a temporary file before it is
+ * uploaded, so that the locations are not held in memory
+ * and the object storage receives it in a single request
+ */
+type ExportService struct {
+	locations port.LocationService
+	repo      port.RestoreRepository
+	storage   port.ObjectStorage
+	prefix    string
+	formats   []domain.ExportFormat
+	retain    int
+}
+
+// NewExportService creates a new export service instance writing the snapshots in formats under prefix
+// and keeping the last retain of each format. Snapshots are not available when storage is nil
+func NewExportService(locations port.LocationService, repo port.RestoreRepository, storage port.ObjectStorage, prefix string, formats []domain.ExportFormat, retain int) *ExportService {
+	return &ExportService{
+		locations,
+		repo,
+		storage,
+		prefix,
+		formats,
+		retain,
+	}
+}
+
+func (es *ExportService) Export(ctx context.Context) error {
+	if es.storage == nil {
+		return domain.ErrExportsDisabled
+	}
+
+	taken := time.Now().UTC().Format(snapshotTime)
+	for _, format := range es.formats {
+		key := es.prefix + snapshotName + taken + "." + string(format)
+		if err := es.writeSnapshot(ctx, key, format); err != nil {
+			return fmt.Errorf("writing %s snapshot: %w", format, err)
+		}
+		logger.FromCtx(ctx).Info("Wrote snapshot", zap.String("key", key))
+	}
+
+	return es.pruneSnapshots(ctx)
+}
+
+// writeSnapshot writes every location in format to a temporary file, then uploads it under key
+func (es *ExportService) writeSnapshot(ctx context.Context, key string, format domain.ExportFormat) error {
+	file, err := os.CreateTemp("", "leeta-snapshot-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	buffered := bufio.NewWriter(file)
+	encoder := newSnapshotEncoder(format, buffered)
+	if err := encoder.begin(); err != nil {
+		return err
+	}
+
+	// the expired locations are still stored, a snapshot restores them along with the others
+	req := &domain.ListLocationsRequest{IncludeExpired: true}
+	if cerr := es.locations.ExportLocations(ctx, req, encoder.encode); cerr != nil {
+		return cerr
+	}
+
+	if err := encoder.end(); err != nil {
+		return err
+	}
+	if err := buffered.Flush(); err != nil {
+		return err
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	return es.storage.PutObject(ctx, key, snapshotContentTypes[format], file)
+}
+
+// pruneSnapshots deletes the snapshots of each format beyond the most recent ones retained
+func (es *ExportService) pruneSnapshots(ctx context.Context) error {
+	snapshots, err := es.snapshots(ctx)
+	if err != nil {
+		return err
+	}
+
+	kept := make(map[domain.ExportFormat]int)
+	for _, snapshot := range snapshots {
+		kept[snapshot.Format]++
+		if kept[snapshot.Format] <= es.retain {
+			continue
+		}
+
+		if err := es.storage.DeleteObject(ctx, snapshot.Key); err != nil {
+			return fmt.Errorf("deleting snapshot %s: %w", snapshot.Key, err)
+		}
+		logger.FromCtx(ctx).Info("Deleted snapshot", zap.String("key", snapshot.Key))
+	}
+
+	return nil
+}
+
+func (es *ExportService) ListSnapshots(ctx context.Context) ([]domain.Snapshot, domain.CError) {
+	if es.storage == nil {
+		return nil, domain.ErrExportsDisabled
+	}
+
+	snapshots, err := es.snapshots(ctx)
+	if err != nil {
+		logger.FromCtx(ctx).Error("Error listing snapshots", zap.Error(err))
+		return nil, domain.ErrInternal
+	}
+
+	return snapshots, nil
+}
+
+// snapshots returns the snapshots under the prefix, most recent first. The other objects under it are left out
+func (es *ExportService) snapshots(ctx context.Context) ([]domain.Snapshot, error) {
+	objects, err := es.storage.ListObjects(ctx, es.prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots := make([]domain.Snapshot, 0, len(objects))
+	for _, object := range objects {
+		if snapshot, ok := es.parseSnapshot(object); ok {
+			snapshots = append(snapshots, snapshot)
+		}
+	}
+
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		return snapshots[i].CreatedAt.After(snapshots[j].CreatedAt)
+	})
+
+	return snapshots, nil
+}
+
+// parseSnapshot returns the snapshot stored as object, false if object is not a snapshot under the prefix
+func (es *ExportService) parseSnapshot(object domain.StoredObject) (domain.Snapshot, bool) {
+	name, ok := strings.CutPrefix(object.Key, es.prefix)
+	if !ok || strings.Contains(name, "/") || !strings.HasPrefix(name, snapshotName) {
+		return domain.Snapshot{}, false
+	}
+
+	ext := path.Ext(name)
+	format, ok := domain.ParseExportFormat(strings.TrimPrefix(ext, "."))
+	if !ok {
+		return domain.Snapshot{}, false
+	}
+	taken, err := time.Parse(snapshotTime, strings.TrimSuffix(strings.TrimPrefix(name, snapshotName), ext))
+	if err != nil {
+		return domain.Snapshot{}, false
+	}
+
+	return domain.Snapshot{
+		Key:       object.Key,
+		Format:    format,
+		Size:      object.Size,
+		CreatedAt: taken,
+	}, true
+}
+
+func (es *ExportService) Restore(ctx context.Context, req *domain.RestoreRequest) (*domain.RestoreReport, domain.CError) {
+	if es.storage == nil {
+		return nil, domain.ErrExportsDisabled
+	}
+
+	snapshot, ok := es.parseSnapshot(domain.StoredObject{Key: req.Key})
+	if !ok {
+		return nil, domain.NewBadRequestCError("key is not the key of a snapshot")
+	}
+
+	target := req.Target
+	if target == "" {
+		target = domain.RestoreLive
+	}
+
+	restored, cerr := es.readSnapshot(ctx, snapshot)
+	if cerr != nil {
+		return nil, cerr
+	}
+
+	var current []domain.Location
+	cerr = es.locations.ExportLocations(ctx, &domain.ListLocationsRequest{IncludeExpired: true}, func(location *domain.Location) error {
+		current = append(current, *location)
+		return nil
+	})
+	if cerr != nil {
+		return nil, cerr
+	}
+
+	plan := planRestore(restored, current, snapshot.Format == domain.ExportCSV)
+	report := &domain.RestoreReport{
+		Key:       snapshot.Key,
+		Target:    target,
+		DryRun:    req.DryRun,
+		Total:     len(restored),
+		Created:   len(plan.Create),
+		Updated:   len(plan.Update),
+		Deleted:   len(plan.Delete),
+		Unchanged: len(restored) - len(plan.Create) - len(plan.Update),
+		Changes:   []domain.RestoreChange{},
+	}
+	for _, changes := range []struct {
+		action    domain.RestoreAction
+		locations []domain.Location
+	}{{domain.RestoreDelete, plan.Delete}, {domain.RestoreUpdate, plan.Update}, {domain.RestoreCreate, plan.Create}} {
+		for _, location := range changes.locations {
+			if len(report.Changes) == restoreMaxChanges {
+				break
+			}
+			report.Changes = append(report.Changes, domain.RestoreChange{Action: changes.action, ID: location.ID, Name: location.Name})
+		}
+	}
+
+	if req.DryRun {
+		return report, nil
+	}
+
+	if target == domain.RestoreShadow {
+		cerr = es.repo.LoadShadowLocations(ctx, snapshot.Key, restored)
+	} else {
+		cerr = es.repo.RestoreLocations(ctx, plan)
+	}
+	if cerr != nil {
+		if cerr.Code() == 400 || cerr.Code() == 409 || isUnavailable(cerr) {
+			return nil, cerr
+		}
+
+		logger.FromCtx(ctx).Error("Error restoring snapshot", zap.Error(cerr), zap.String("key", snapshot.Key))
+		return nil, domain.ErrInternal
+	}
+
+	logger.FromCtx(ctx).Warn("Restored snapshot", zap.String("key", snapshot.Key), zap.String("target", string(target)),
+		zap.Int("created", report.Created), zap.Int("updated", report.Updated), zap.Int("deleted", report.Deleted))
+
+	return report, nil
+}
+
+// readSnapshot reads the locations of snapshot from the object storage
+func (es *ExportService) readSnapshot(ctx context.Context, snapshot domain.Snapshot) ([]domain.Location, domain.CError) {
+	body, err := es.storage.GetObject(ctx, snapshot.Key)
+	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return nil, errSnapshotNotFound
+		}
+
+		logger.FromCtx(ctx).Error("Error reading snapshot", zap.Error(err), zap.String("key", snapshot.Key))
+		return nil, domain.ErrInternal
+	}
+	defer body.Close()
+
+	var locations []domain.Location
+	if snapshot.Format == domain.ExportCSV {
+		locations, err = decodeCSVSnapshot(body)
+	} else {
+		locations, err = decodeGeoJSONSnapshot(body)
+	}
+	if err != nil {
+		return nil, domain.NewBadRequestCError(fmt.Sprintf("invalid snapshot: %v", err))
+	}
+
+	for i, location := range locations {
+		if location.ID == "" || location.Name == "" {
+			return nil, domain.NewBadRequestCError(fmt.Sprintf("invalid snapshot: location %d has no id or name", i+1))
+		}
+	}
+
+	return locations, nil
+}
+
+// planRestore returns the changes making the current locations those restored, matched by id. CSV snapshots do
+// not carry the translated names, entrances, footprint or source, a location restored from one keeps its own
+func planRestore(restored, current []domain.Location, keepDetails bool) *domain.RestorePlan {
+	byID := make(map[string]*domain.Location, len(current))
+	for i := range current {
+		byID[current[i].ID] = &current[i]
+	}
+
+	plan := &domain.RestorePlan{}
+	seen := make(map[string]bool, len(restored))
+	for _, location := range restored {
+		seen[location.ID] = true
+
+		stored, ok := byID[location.ID]
+		if !ok {
+			plan.Create = append(plan.Create, location)
+			continue
+		}
+
+		if keepDetails {
+			location.Names, location.Entrances, location.Footprint, location.Source =
+				stored.Names, stored.Entrances, stored.Footprint, stored.Source
+		}
+		if restoreChanged(stored, &location) {
+			plan.Update = append(plan.Update, location)
+		}
+	}
+
+	for _, location := range current {
+		if !seen[location.ID] {
+			plan.Delete = append(plan.Delete, location)
+		}
+	}
+
+	return plan
+}
+
+// restoreChanged reports whether a location is restored differently from how it is stored
+func restoreChanged(stored, restored *domain.Location) bool {
+	if stored.Name != restored.Name || stored.Category != restored.Category ||
+		!sameCoordinate(stored.Latitude, restored.Latitude) || !sameCoordinate(stored.Longitude, restored.Longitude) {
+		return true
+	}
+
+	if (stored.Altitude == nil) != (restored.Altitude == nil) ||
+		(stored.Altitude != nil && *stored.Altitude != *restored.Altitude) {
+		return true
+	}
+
+	if len(stored.Names) != len(restored.Names) || (len(restored.Names) > 0 && !reflect.DeepEqual(stored.Names, restored.Names)) {
+		return true
+	}
+	if len(stored.Entrances) != len(restored.Entrances) ||
+		(len(restored.Entrances) > 0 && !reflect.DeepEqual(stored.Entrances, restored.Entrances)) {
+		return true
+	}
+
+	if !sameTime(stored.ValidFrom, restored.ValidFrom) || !sameTime(stored.ValidUntil, restored.ValidUntil) {
+		return true
+	}
+
+	return !samePolygon(stored.Footprint, restored.Footprint)
+}
+
+// decodeGeoJSONSnapshot reads the locations of a GeoJSON snapshot, the properties of its features
+func decodeGeoJSONSnapshot(body io.Reader) ([]domain.Location, error) {
+	var collection struct {
+		Features []struct {
+			Properties domain.Location `json:"properties"`
+		} `json:"features"`
+	}
+	if err := json.NewDecoder(body).Decode(&collection); err != nil {
+		return nil, err
+	}
+
+	locations := make([]domain.Location, len(collection.Features))
+	for i, feature := range collection.Features {
+		locations[i] = feature.Properties
+	}
+	return locations, nil
+}
+
+// decodeCSVSnapshot reads the locations of a CSV snapshot, written with snapshotColumns
+func decodeCSVSnapshot(body io.Reader) ([]domain.Location, error) {
+	reader := csv.NewReader(body)
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("reading header: %w", err)
+	}
+	if !slices.Equal(header, snapshotColumns) {
+		return nil, fmt.Errorf("expected the columns %s", strings.Join(snapshotColumns, ","))
+	}
+
+	var locations []domain.Location
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		location, err := decodeCSVRecord(record)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		locations = append(locations, location)
+	}
+
+	return locations, nil
+}
+
+// decodeCSVRecord reads a location from a record with snapshotColumns
+func decodeCSVRecord(record []string) (domain.Location, error) {
+	location := domain.Location{ID: record[0], Name: record[1], Slug: record[2], Category: record[5]}
+
+	var err error
+	if location.Latitude, err = strconv.ParseFloat(record[3], 64); err != nil {
+		return location, errors.New("invalid latitude")
+	}
+	if location.Longitude, err = strconv.ParseFloat(record[4], 64); err != nil {
+		return location, errors.New("invalid longitude")
+	}
+	if record[6] != "" {
+		altitude, err := strconv.ParseFloat(record[6], 64)
+		if err != nil {
+			return location, errors.New("invalid altitude")
+		}
+		location.Altitude = &altitude
+	}
+
+	for i, t := range []**time.Time{&location.ValidFrom, &location.ValidUntil} {
+		if record[7+i] == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, record[7+i])
+		if err != nil {
+			return location, fmt.Errorf("invalid %s", snapshotColumns[7+i])
+		}
+		*t = &parsed
+	}
+	for i, t := range []*time.Time{&location.CreatedAt, &location.UpdatedAt} {
+		if record[9+i] == "" {
+			continue
+		}
+		if *t, err = time.Parse(time.RFC3339, record[9+i]); err != nil {
+			return location, fmt.Errorf("invalid %s", snapshotColumns[9+i])
+		}
+	}
+
+	return location, nil
+}
+
+// snapshotEncoder writes the locations of a snapshot in a format
+type snapshotEncoder interface {
+	// begin writes what comes before the locations, such as a header
+	begin() error
+	encode(location *domain.Location) error
+	// end writes what comes after the locations
+	end() error
+}
+
+func newSnapshotEncoder(format domain.ExportFormat, w io.Writer) snapshotEncoder {
+	if format == domain.ExportCSV {
+		return &csvEncoder{csv.NewWriter(w)}
+	}
+	return &geojsonEncoder{w: w}
+}
+
+// geojsonEncoder writes a FeatureCollection with a Point feature per location, its properties are the location
+type geojsonEncoder struct {
+	w       io.Writer
+	written int
+}
+
+// geojsonFeature is a GeoJSON feature of a location
+type geojsonFeature struct {
+	Type     string `json:"type"`
+	ID       string `json:"id"`
+	Geometry struct {
+		Type        string    `json:"type"`
+		Coordinates []float64 `json:"coordinates"`
+	} `json:"geometry"`
+	Properties *domain.Location `json:"properties"`
+}
+
+func (e *geojsonEncoder) begin() error {
+	_, err := io.WriteString(e.w, `{"type":"FeatureCollection","features":[`)
+	return err
+}
+
+func (e *geojsonEncoder) encode(location *domain.Location) error {
+	feature := geojsonFeature{Type: "Feature", ID: location.ID, Properties: location}
+	feature.Geometry.Type = "Point"
+	feature.Geometry.Coordinates = []float64{location.Longitude, location.Latitude}
+	if location.Altitude != nil {
+		feature.Geometry.Coordinates = append(feature.Geometry.Coordinates, *location.Altitude)
+	}
+
+	b, err := json.Marshal(feature)
+	if err != nil {
+		return err
+	}
+
+	if e.written > 0 {
+		if _, err := io.WriteString(e.w, ","); err != nil {
+			return err
+		}
+	}
+	e.written++
+
+	_, err = e.w.Write(b)
+	return err
+}
+
+func (e *geojsonEncoder) end() error {
+	_, err := io.WriteString(e.w, "]}\n")
+	return err
+}
+
+// csvEncoder writes a row of snapshotColumns per location
+type csvEncoder struct {
+	w *csv.Writer
+}
+
+func (e *csvEncoder) begin() error {
+	return e.w.Write(snapshotColumns)
+}
+
+func (e *csvEncoder) encode(location *domain.Location) error {
+	formatTime := func(t *time.Time) string {
+		if t == nil {
+			return ""
+		}
+		return t.UTC().Format(time.RFC3339)
+	}
+
+	altitude := ""
+	if location.Altitude != nil {
+		altitude = strconv.FormatFloat(*location.Altitude, 'f', -1, 64)
+	}
+
+	return e.w.Write([]string{
+		location.ID,
+		location.Name,
+		location.Slug,
+		strconv.FormatFloat(location.Latitude, 'f', -1, 64),
+		strconv.FormatFloat(location.Longitude, 'f', -1, 64),
+		location.Category,
+		altitude,
+		formatTime(location.ValidFrom),
+		formatTime(location.ValidUntil),
+		formatTime(&location.CreatedAt),
+		formatTime(&location.UpdatedAt),
+	})
+}
+
+func (e *csvEncoder) end() error {
+	e.w.Flush()
+	return e.w.Error()
+}
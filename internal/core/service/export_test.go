@@ -0,0 +1,231 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"leeta/internal/core/domain"
+	"leeta/internal/core/port/mock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportService_Export(t *testing.T) {
+	altitude := 12.5
+	created := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	locations := &mock.LocationServiceMock{
+		ExportLocationsFunc: func(ctx context.Context, req *domain.ListLocationsRequest, fn func(location *domain.Location) error) domain.CError {
+			assert.True(t, req.IncludeExpired)
+			for _, location := range []domain.Location{
+				{ID: "1", Name: "Lekki Depot", Slug: "lekki-depot", Latitude: 6.4474, Longitude: 3.4723, CreatedAt: created, UpdatedAt: created},
+				{ID: "2", Name: "Ikeja, Tower", Slug: "ikeja-tower", Latitude: 6.6018, Longitude: 3.3515, Altitude: &altitude, CreatedAt: created, UpdatedAt: created},
+			} {
+				if err := fn(&location); err != nil {
+					return domain.NewInternalCError(err.Error())
+				}
+			}
+			return nil
+		},
+	}
+
+	written := make(map[string]string)
+	storage := &mock.ObjectStorageMock{
+		PutObjectFunc: func(ctx context.Context, key, contentType string, body io.ReadSeeker) error {
+			b, err := io.ReadAll(body)
+			require.NoError(t, err)
+			written[contentType] = string(b)
+			return nil
+		},
+		ListObjectsFunc: func(ctx context.Context, prefix string) ([]domain.StoredObject, error) {
+			return []domain.StoredObject{
+				{Key: "exports/locations-20261014T000000Z.csv"},
+				{Key: "exports/locations-20261015T000000Z.csv"},
+				{Key: "exports/locations-20261015T000000Z.geojson"},
+				{Key: "exports/locations-20261016T000000Z.csv"},
+				{Key: "exports/locations-20261016T000000Z.geojson"},
+				{Key: "exports/readme.txt"},
+			}, nil
+		},
+		DeleteObjectFunc: func(ctx context.Context, key string) error {
+			return nil
+		},
+	}
+
+	svc := NewExportService(locations, &mock.RestoreRepositoryMock{}, storage, "exports/", []domain.ExportFormat{domain.ExportGeoJSON, domain.ExportCSV}, 2)
+	require.NoError(t, svc.Export(context.Background()))
+
+	require.Len(t, storage.PutObjectCalls(), 2)
+	assert.Regexp(t, `^exports/locations-\d{8}T\d{6}Z\.geojson$`, storage.PutObjectCalls()[0].Key)
+	assert.JSONEq(t, `{"type":"FeatureCollection","features":[
+		{"type":"Feature","id":"1","geometry":{"type":"Point","coordinates":[3.4723,6.4474]},
+			"properties":{"id":"1","name":"Lekki Depot","slug":"lekki-depot","latitude":6.4474,"longitude":3.4723,
+				"created_at":"2026-10-01T09:00:00Z","version":0,"updated_at":"2026-10-01T09:00:00Z","status":""}},
+		{"type":"Feature","id":"2","geometry":{"type":"Point","coordinates":[3.3515,6.6018,12.5]},
+			"properties":{"id":"2","name":"Ikeja, Tower","slug":"ikeja-tower","latitude":6.6018,"longitude":3.3515,"altitude":12.5,
+				"created_at":"2026-10-01T09:00:00Z","version":0,"updated_at":"2026-10-01T09:00:00Z","status":""}}
+	]}`, written["application/geo+json"])
+	assert.Equal(t, "id,name,slug,latitude,longitude,category,altitude,valid_from,valid_until,created_at,updated_at\n"+
+		"1,Lekki Depot,lekki-depot,6.4474,3.4723,,,,,2026-10-01T09:00:00Z,2026-10-01T09:00:00Z\n"+
+		"2,\"Ikeja, Tower\",ikeja-tower,6.6018,3.3515,,12.5,,,2026-10-01T09:00:00Z,2026-10-01T09:00:00Z\n", written["text/csv"])
+
+	require.Len(t, storage.DeleteObjectCalls(), 1)
+	assert.Equal(t, "exports/locations-20261014T000000Z.csv", storage.DeleteObjectCalls()[0].Key)
+}
+
+func TestExportService_ListSnapshots(t *testing.T) {
+	t.Run("Most recent first", func(t *testing.T) {
+		storage := &mock.ObjectStorageMock{
+			ListObjectsFunc: func(ctx context.Context, prefix string) ([]domain.StoredObject, error) {
+				return []domain.StoredObject{
+					{Key: "locations-20261015T000000Z.geojson", Size: 40},
+					{Key: "locations-20261016T000000Z.csv", Size: 12},
+					{Key: "archive/locations-20261001T000000Z.csv", Size: 10},
+				}, nil
+			},
+		}
+
+		svc := NewExportService(&mock.LocationServiceMock{}, &mock.RestoreRepositoryMock{}, storage, "", []domain.ExportFormat{domain.ExportCSV}, 7)
+		snapshots, cerr := svc.ListSnapshots(context.Background())
+		require.Nil(t, cerr)
+		assert.Equal(t, []domain.Snapshot{
+			{Key: "locations-20261016T000000Z.csv", Format: domain.ExportCSV, Size: 12, CreatedAt: time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)},
+			{Key: "locations-20261015T000000Z.geojson", Format: domain.ExportGeoJSON, Size: 40, CreatedAt: time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)},
+		}, snapshots)
+	})
+
+	t.Run("Object storage failing", func(t *testing.T) {
+		storage := &mock.ObjectStorageMock{
+			ListObjectsFunc: func(ctx context.Context, prefix string) ([]domain.StoredObject, error) {
+				return nil, errors.New("connection refused")
+			},
+		}
+
+		svc := NewExportService(&mock.LocationServiceMock{}, &mock.RestoreRepositoryMock{}, storage, "", nil, 7)
+		_, cerr := svc.ListSnapshots(context.Background())
+		assert.Equal(t, domain.ErrInternal, cerr)
+	})
+
+	t.Run("No object storage", func(t *testing.T) {
+		svc := NewExportService(&mock.LocationServiceMock{}, &mock.RestoreRepositoryMock{}, nil, "", nil, 7)
+		_, cerr := svc.ListSnapshots(context.Background())
+		assert.Equal(t, domain.ErrExportsDisabled, cerr)
+	})
+}
+
+func TestExportService_Restore(t *testing.T) {
+	snapshot := "id,name,slug,latitude,longitude,category,altitude,valid_from,valid_until,created_at,updated_at\n" +
+		"1,Lekki Depot,lekki-depot,6.4474,3.4723,,,,,2026-10-01T09:00:00Z,2026-10-01T09:00:00Z\n" +
+		"2,Ikeja Depot,ikeja-depot,6.602,3.3515,,,,,2026-10-01T09:00:00Z,2026-10-01T09:00:00Z\n" +
+		"3,Yaba Depot,yaba-depot,6.5095,3.3711,depot,,,,2026-10-01T09:00:00Z,2026-10-01T09:00:00Z\n"
+
+	setup := func() (*ExportService, *mock.RestoreRepositoryMock) {
+		locations := &mock.LocationServiceMock{
+			ExportLocationsFunc: func(ctx context.Context, req *domain.ListLocationsRequest, fn func(location *domain.Location) error) domain.CError {
+				for _, location := range []domain.Location{
+					{ID: "1", Name: "Lekki Depot", Latitude: 6.4474, Longitude: 3.4723, Names: map[string]string{"fr": "Dépôt de Lekki"}},
+					{ID: "2", Name: "Ikeja Depot", Latitude: 6.6018, Longitude: 3.3515},
+					{ID: "4", Name: "Ajah Depot", Latitude: 6.4698, Longitude: 3.5852},
+				} {
+					if err := fn(&location); err != nil {
+						return domain.NewInternalCError(err.Error())
+					}
+				}
+				return nil
+			},
+		}
+		storage := &mock.ObjectStorageMock{
+			GetObjectFunc: func(ctx context.Context, key string) (io.ReadCloser, error) {
+				if key != "exports/locations-20261016T000000Z.csv" {
+					return nil, domain.ErrDataNotFound
+				}
+				return io.NopCloser(strings.NewReader(snapshot)), nil
+			},
+		}
+		repo := &mock.RestoreRepositoryMock{
+			RestoreLocationsFunc: func(ctx context.Context, plan *domain.RestorePlan) domain.CError {
+				return nil
+			},
+			LoadShadowLocationsFunc: func(ctx context.Context, key string, locations []domain.Location) domain.CError {
+				return nil
+			},
+		}
+		return NewExportService(locations, repo, storage, "exports/", nil, 7), repo
+	}
+
+	t.Run("Dry run", func(t *testing.T) {
+		svc, repo := setup()
+
+		report, cerr := svc.Restore(context.Background(), &domain.RestoreRequest{Key: "exports/locations-20261016T000000Z.csv", DryRun: true})
+		require.Nil(t, cerr)
+		assert.Equal(t, &domain.RestoreReport{
+			Key:       "exports/locations-20261016T000000Z.csv",
+			Target:    domain.RestoreLive,
+			DryRun:    true,
+			Total:     3,
+			Created:   1,
+			Updated:   1,
+			Deleted:   1,
+			Unchanged: 1,
+			Changes: []domain.RestoreChange{
+				{Action: domain.RestoreDelete, ID: "4", Name: "Ajah Depot"},
+				{Action: domain.RestoreUpdate, ID: "2", Name: "Ikeja Depot"},
+				{Action: domain.RestoreCreate, ID: "3", Name: "Yaba Depot"},
+			},
+		}, report)
+		assert.Empty(t, repo.RestoreLocationsCalls())
+		assert.Empty(t, repo.LoadShadowLocationsCalls())
+	})
+
+	t.Run("Live", func(t *testing.T) {
+		svc, repo := setup()
+
+		_, cerr := svc.Restore(context.Background(), &domain.RestoreRequest{Key: "exports/locations-20261016T000000Z.csv"})
+		require.Nil(t, cerr)
+		require.Len(t, repo.RestoreLocationsCalls(), 1)
+
+		plan := repo.RestoreLocationsCalls()[0].Plan
+		require.Len(t, plan.Update, 1)
+		assert.Equal(t, 6.602, plan.Update[0].Latitude)
+		require.Len(t, plan.Create, 1)
+		assert.Equal(t, "depot", plan.Create[0].Category)
+		require.Len(t, plan.Delete, 1)
+		assert.Equal(t, "4", plan.Delete[0].ID)
+	})
+
+	t.Run("Shadow", func(t *testing.T) {
+		svc, repo := setup()
+
+		_, cerr := svc.Restore(context.Background(), &domain.RestoreRequest{Key: "exports/locations-20261016T000000Z.csv", Target: domain.RestoreShadow})
+		require.Nil(t, cerr)
+		assert.Empty(t, repo.RestoreLocationsCalls())
+		require.Len(t, repo.LoadShadowLocationsCalls(), 1)
+		assert.Len(t, repo.LoadShadowLocationsCalls()[0].Locations, 3)
+	})
+
+	t.Run("Not a snapshot", func(t *testing.T) {
+		svc, _ := setup()
+
+		_, cerr := svc.Restore(context.Background(), &domain.RestoreRequest{Key: "backups/locations.csv"})
+		require.NotNil(t, cerr)
+		assert.Equal(t, 400, cerr.Code())
+
+		_, cerr = svc.Restore(context.Background(), &domain.RestoreRequest{Key: "exports/locations-20261015T000000Z.csv"})
+		assert.Equal(t, errSnapshotNotFound, cerr)
+	})
+}
+
+func TestPlanRestore_KeepDetails(t *testing.T) {
+	current := []domain.Location{{ID: "1", Name: "Lekki Depot", Names: map[string]string{"fr": "Dépôt de Lekki"}}}
+	restored := []domain.Location{{ID: "1", Name: "Lekki Depot"}}
+
+	// a CSV snapshot has no translated names, they are kept
+	assert.Empty(t, planRestore(restored, current, true).Update)
+
+	// a GeoJSON snapshot without them removes them
+	assert.Len(t, planRestore(restored, current, false).Update, 1)
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"leeta/internal/adapter/logger"
+	"leeta/internal/core/domain"
+	"leeta/internal/core/port"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// defaultSuggestions is the number of address suggestions when a request leaves it out
+	defaultSuggestions = 5
+	// nearPrecision is the number of decimal places the point suggestions are ranked near is rounded to,
+	// about a kilometer, so the searches of a map panned a little share their suggestions
+	nearPrecision = 100
+)
+
+/**
+ * GeocodeService implements port.GeocodeService interface.
+ * The suggestions of the geocoder are cached by query, so the
+ * same prefixes typed again, or by other users, are answered
+ * without asking the geocoder
+ */
+type GeocodeService struct {
+	geocoder port.Geocoder
+	cache    *lru[string, []domain.AddressSuggestion]
+}
+
+// NewGeocodeService creates a new geocode service instance. Up to cacheSize queries are cached for
+// cacheTTL, a cacheSize of 0 disables the cache. Suggestions are not available when geocoder is nil
+func NewGeocodeService(geocoder port.Geocoder, cacheSize int, cacheTTL time.Duration) *GeocodeService {
+	var cache *lru[string, []domain.AddressSuggestion]
+	if cacheSize > 0 && cacheTTL > 0 {
+		cache = newLRU[string, []domain.AddressSuggestion](cacheSize, cacheTTL)
+	}
+
+	return &GeocodeService{
+		geocoder,
+		cache,
+	}
+}
+
+func (gs *GeocodeService) Autocomplete(ctx context.Context, req *domain.AutocompleteRequest) ([]domain.AddressSuggestion, domain.CError) {
+	if gs.geocoder == nil {
+		return nil, domain.ErrGeocodingDisabled
+	}
+
+	query := *req
+	query.Query = strings.Join(strings.Fields(req.Query), " ")
+	if query.Limit == 0 {
+		query.Limit = defaultSuggestions
+	}
+	if req.Near != nil {
+		query.Near = &domain.Point{
+			Latitude:  math.Round(req.Near.Latitude*nearPrecision) / nearPrecision,
+			Longitude: math.Round(req.Near.Longitude*nearPrecision) / nearPrecision,
+		}
+	}
+
+	key := autocompleteKey(&query)
+	var generation uint64
+	if gs.cache != nil {
+		if suggestions, ok := gs.cache.get(key); ok {
+			return suggestions, nil
+		}
+		generation = gs.cache.currentGeneration()
+	}
+
+	suggestions, err := gs.geocoder.Autocomplete(ctx, &query)
+	if err != nil {
+		logger.FromCtx(ctx).Error("Error suggesting addresses", zap.Error(err))
+		return nil, domain.ErrServiceUnavailable
+	}
+
+	if gs.cache != nil {
+		gs.cache.set(generation, key, suggestions)
+	}
+
+	return suggestions, nil
+}
+
+// autocompleteKey is the cache key of the suggestions of a request, queries differing by case only share it
+func autocompleteKey(req *domain.AutocompleteRequest) string {
+	near := ""
+	if req.Near != nil {
+		near = fmt.Sprintf("%.2f,%.2f", req.Near.Latitude, req.Near.Longitude)
+	}
+
+	return fmt.Sprintf("%s|%d|%s|%s", strings.ToLower(req.Query), req.Limit, near, req.Language)
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"leeta/internal/core/domain"
+	"leeta/internal/core/port/mock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeocodeService_Autocomplete(t *testing.T) {
+	geocoder := &mock.GeocoderMock{
+		AutocompleteFunc: func(ctx context.Context, req *domain.AutocompleteRequest) ([]domain.AddressSuggestion, error) {
+			if req.Query == "down" {
+				return nil, errors.New("connection refused")
+			}
+			return []domain.AddressSuggestion{{Label: "12 Admiralty Way, Lagos, Nigeria", Latitude: 6.4474, Longitude: 3.4723}}, nil
+		},
+	}
+	gs := NewGeocodeService(geocoder, 10, time.Minute)
+
+	t.Run("Queries are normalized", func(t *testing.T) {
+		suggestions, cerr := gs.Autocomplete(context.Background(), &domain.AutocompleteRequest{
+			Query: "  Admiralty   Way ",
+			Near:  &domain.Point{Latitude: 6.44738, Longitude: 3.47231},
+		})
+		require.Nil(t, cerr)
+		assert.Len(t, suggestions, 1)
+
+		call := geocoder.AutocompleteCalls()[0].Req
+		assert.Equal(t, "Admiralty Way", call.Query)
+		assert.Equal(t, defaultSuggestions, call.Limit)
+		assert.Equal(t, &domain.Point{Latitude: 6.45, Longitude: 3.47}, call.Near)
+	})
+
+	t.Run("Repeated queries are cached", func(t *testing.T) {
+		_, cerr := gs.Autocomplete(context.Background(), &domain.AutocompleteRequest{
+			Query: "admiralty way",
+			Near:  &domain.Point{Latitude: 6.4521, Longitude: 3.4689},
+		})
+		require.Nil(t, cerr)
+		assert.Len(t, geocoder.AutocompleteCalls(), 1)
+
+		// another language is another query
+		_, cerr = gs.Autocomplete(context.Background(), &domain.AutocompleteRequest{Query: "admiralty way", Language: "fr"})
+		require.Nil(t, cerr)
+		assert.Len(t, geocoder.AutocompleteCalls(), 2)
+	})
+
+	t.Run("Geocoder failures are unavailable", func(t *testing.T) {
+		_, cerr := gs.Autocomplete(context.Background(), &domain.AutocompleteRequest{Query: "down"})
+		assert.Equal(t, domain.ErrServiceUnavailable, cerr)
+	})
+
+	t.Run("Without a geocoder", func(t *testing.T) {
+		_, cerr := NewGeocodeService(nil, 0, 0).Autocomplete(context.Background(), &domain.AutocompleteRequest{Query: "lagos"})
+		assert.Equal(t, domain.ErrGeocodingDisabled, cerr)
+	})
+}
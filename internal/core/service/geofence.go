@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+
+	"leeta/internal/adapter/logger"
+	"leeta/internal/core/domain"
+	"leeta/internal/core/errcode"
+	"leeta/internal/core/port"
+
+	"go.uber.org/zap"
+)
+
+/**
+ * GeofenceService implements port.GeofenceService interface
+ */
+type GeofenceService struct {
+	repo port.GeofenceRepository
+}
+
+// NewGeofenceService creates a new geofence service instance
+func NewGeofenceService(repo port.GeofenceRepository) *GeofenceService {
+	return &GeofenceService{
+		repo,
+	}
+}
+
+func (gs *GeofenceService) CreateGeofence(ctx context.Context, req *domain.CreateGeofenceRequest) (*domain.Geofence, domain.CError) {
+	points := req.Points
+	// the polygon is closed by the repository, a repeated first point would make it degenerate
+	if len(points) > 3 && points[0] == points[len(points)-1] {
+		points = points[:len(points)-1]
+	}
+
+	geofence, cerr := gs.repo.CreateGeofence(ctx, &domain.Geofence{
+		Name:   req.Name,
+		Points: points,
+	})
+	if cerr != nil {
+		switch {
+		case cerr.Code() == 400 || isUnavailable(cerr) || isViolation(cerr):
+			return nil, cerr
+		case cerr.Code() == 409:
+			return nil, domain.NewCodedCError(errcode.DuplicateGeofence, "geofence already exists")
+		}
+
+		logger.FromCtx(ctx).Error("Error creating geofence", zap.Error(cerr))
+		return nil, domain.ErrInternal
+	}
+
+	return geofence, nil
+}
+
+func (gs *GeofenceService) ListGeofenceLocations(ctx context.Context, name string, req *domain.ListGeofenceLocationsRequest) ([]domain.Location, domain.CError) {
+	locations, cerr := gs.repo.ListGeofenceLocations(ctx, name, req)
+	if cerr != nil {
+		switch {
+		case cerr.Code() == 404:
+			return nil, domain.NewCodedCError(errcode.GeofenceNotFound, "geofence not found")
+		case isUnavailable(cerr):
+			return nil, cerr
+		}
+
+		logger.FromCtx(ctx).Error("Error listing geofence locations", zap.Error(cerr))
+		return nil, domain.ErrInternal
+	}
+
+	return locations, nil
+}
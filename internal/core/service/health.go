@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"leeta/internal/core/domain"
+	"leeta/internal/core/port"
+)
+
+const defaultHealthCheckTimeout = 2 * time.Second
+
+// dependency is a dependency registered with the health service
+type dependency struct {
+	name     string
+	critical bool
+	check    port.HealthCheck
+}
+
+/**
+ * HealthService implements port.HealthService interface.
+ * Every check is bounded by a timeout, and the time of the last
+ * successful check of each dependency is kept in memory
+ */
+type HealthService struct {
+	timeout time.Duration
+
+	mu           sync.Mutex
+	dependencies []dependency
+	lastSuccess  map[string]time.Time
+	isLeader     func() bool
+}
+
+// NewHealthService creates a new health service instance whose checks time out after timeout
+func NewHealthService(timeout time.Duration) *HealthService {
+	if timeout <= 0 {
+		timeout = defaultHealthCheckTimeout
+	}
+
+	return &HealthService{
+		timeout:     timeout,
+		lastSuccess: make(map[string]time.Time),
+	}
+}
+
+// Register adds a dependency to check. The server is down when a critical dependency is,
+// and only degraded when another one is
+func (hs *HealthService) Register(name string, critical bool, check port.HealthCheck) {
+	hs.mu.Lock()
+	defer hs.mu.Unlock()
+
+	hs.dependencies = append(hs.dependencies, dependency{name, critical, check})
+}
+
+// ReportLeadership adds whether this instance is the leader, as told by isLeader, to the reports
+func (hs *HealthService) ReportLeadership(isLeader func() bool) {
+	hs.mu.Lock()
+	defer hs.mu.Unlock()
+
+	hs.isLeader = isLeader
+}
+
+func (hs *HealthService) Report(ctx context.Context) *domain.HealthReport {
+	hs.mu.Lock()
+	dependencies := append([]dependency{}, hs.dependencies...)
+	isLeader := hs.isLeader
+	hs.mu.Unlock()
+
+	report := &domain.HealthReport{
+		Status:       domain.HealthUp,
+		Dependencies: make([]domain.DependencyHealth, len(dependencies)),
+		CheckedAt:    time.Now(),
+	}
+	if isLeader != nil {
+		leader := isLeader()
+		report.Leader = &leader
+	}
+
+	var wg sync.WaitGroup
+	for i, d := range dependencies {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			report.Dependencies[i] = hs.check(ctx, d)
+		}()
+	}
+	wg.Wait()
+
+	for _, d := range report.Dependencies {
+		if d.Status == domain.HealthUp {
+			continue
+		}
+
+		if d.Critical {
+			report.Status = domain.HealthDown
+			break
+		}
+		report.Status = domain.HealthDegraded
+	}
+
+	return report
+}
+
+// check runs the check of d within the timeout
+func (hs *HealthService) check(ctx context.Context, d dependency) domain.DependencyHealth {
+	ctx, cancel := context.WithTimeout(ctx, hs.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := d.check(ctx)
+
+	health := domain.DependencyHealth{
+		Name:      d.name,
+		Status:    domain.HealthUp,
+		Critical:  d.critical,
+		LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+	}
+
+	hs.mu.Lock()
+	defer hs.mu.Unlock()
+
+	if err != nil {
+		health.Status = domain.HealthDown
+		health.Error = err.Error()
+	} else {
+		hs.lastSuccess[d.name] = start
+	}
+
+	if lastSuccess, ok := hs.lastSuccess[d.name]; ok {
+		health.LastSuccessAt = &lastSuccess
+	}
+
+	return health
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"leeta/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthService_Report(t *testing.T) {
+	up := func(ctx context.Context) error { return nil }
+	down := func(ctx context.Context) error { return errors.New("connection refused") }
+
+	t.Run("Up when every dependency is up", func(t *testing.T) {
+		hs := NewHealthService(time.Second)
+		hs.Register("database", true, up)
+		hs.Register("cache", false, up)
+
+		report := hs.Report(context.Background())
+
+		assert.Equal(t, domain.HealthUp, report.Status)
+		require.Len(t, report.Dependencies, 2)
+		for _, d := range report.Dependencies {
+			assert.Equal(t, domain.HealthUp, d.Status)
+			assert.NotNil(t, d.LastSuccessAt)
+		}
+	})
+
+	t.Run("Degraded when a non critical dependency is down", func(t *testing.T) {
+		hs := NewHealthService(time.Second)
+		hs.Register("database", true, up)
+		hs.Register("cache", false, down)
+
+		report := hs.Report(context.Background())
+
+		assert.Equal(t, domain.HealthDegraded, report.Status)
+		assert.Equal(t, "connection refused", report.Dependencies[1].Error)
+		assert.Nil(t, report.Dependencies[1].LastSuccessAt)
+	})
+
+	t.Run("Down when a critical dependency times out", func(t *testing.T) {
+		hs := NewHealthService(10 * time.Millisecond)
+		hs.Register("database", true, func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+		hs.Register("cache", false, down)
+
+		report := hs.Report(context.Background())
+
+		assert.Equal(t, domain.HealthDown, report.Status)
+		assert.Equal(t, domain.HealthDown, report.Dependencies[0].Status)
+	})
+
+	t.Run("Keeps the last success of a dependency that went down", func(t *testing.T) {
+		hs := NewHealthService(time.Second)
+		healthy := true
+		hs.Register("cache", false, func(ctx context.Context) error {
+			if healthy {
+				return nil
+			}
+			return errors.New("connection refused")
+		})
+
+		first := hs.Report(context.Background())
+		healthy = false
+		second := hs.Report(context.Background())
+
+		assert.Equal(t, domain.HealthDown, second.Dependencies[0].Status)
+		assert.Equal(t, first.Dependencies[0].LastSuccessAt, second.Dependencies[0].LastSuccessAt)
+	})
+}
+
+func TestHealthService_ReportLeadership(t *testing.T) {
+	hs := NewHealthService(time.Second)
+	assert.Nil(t, hs.Report(context.Background()).Leader, "no leadership without an election")
+
+	leader := true
+	hs.ReportLeadership(func() bool { return leader })
+	report := hs.Report(context.Background())
+	require.NotNil(t, report.Leader)
+	assert.True(t, *report.Leader)
+
+	leader = false
+	assert.False(t, *hs.Report(context.Background()).Leader)
+}
@@ -0,0 +1,244 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"leeta/internal/adapter/logger"
+	"leeta/internal/core/auth"
+	"leeta/internal/core/domain"
+	"leeta/internal/core/errcode"
+	"leeta/internal/core/port"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// importBatchSize is the number of locations copied to the database per transaction
+	importBatchSize = 5000
+
+	// importMaxAttempts is how many times an import is run before it fails, an attempt
+	// resumes after the batches imported by the previous ones
+	importMaxAttempts = 3
+)
+
+// importArgs are the args of an import job
+type importArgs struct {
+	Locations []domain.RegisterLocationRequest `json:"locations"`
+	// Tenant is the tenant of the caller of the import, the locations are imported for it
+	Tenant string `json:"tenant,omitempty"`
+}
+
+// importProgress is the progress of an import job. Offset is the number of locations whose
+// batches were committed
+type importProgress struct {
+	Total    int   `json:"total"`
+	Imported int64 `json:"imported"`
+	Skipped  int64 `json:"skipped"`
+	Offset   int   `json:"offset"`
+}
+
+/**
+ * ImportService implements port.ImportService interface.
+ * Imports are jobs of the durable job queue, so they survive
+ * restarts and run on whichever instance picks them up
+ */
+type ImportService struct {
+	repo     port.LocationRepository
+	tx       port.TxManager
+	jobs     port.JobRepository
+	notifier port.Notifier
+	quotas   port.QuotaService
+}
+
+// NewImportService creates a new import service instance. Failed imports are
+// reported to notifier, or not at all if it is nil. The imported locations are
+// checked against the quotas of the tenants with quotas, or not at all if it is nil
+func NewImportService(repo port.LocationRepository, tx port.TxManager, jobs port.JobRepository, notifier port.Notifier, quotas port.QuotaService) *ImportService {
+	return &ImportService{
+		repo,
+		tx,
+		jobs,
+		notifier,
+		quotas,
+	}
+}
+
+func (is *ImportService) ImportLocations(ctx context.Context, locations []domain.RegisterLocationRequest) (*domain.ImportJob, domain.CError) {
+	if len(locations) == 0 {
+		return nil, domain.NewBadRequestCError("no locations to import")
+	}
+
+	// the whole import is checked up front, even though some of the locations may fail or already exist
+	if cerr := checkQuota(ctx, is.quotas, domain.QuotaLocations, len(locations)); cerr != nil {
+		return nil, cerr
+	}
+
+	args, err := json.Marshal(importArgs{locations, auth.Tenant(ctx)})
+	if err != nil {
+		return nil, domain.ErrInternal
+	}
+	progress, err := json.Marshal(importProgress{Total: len(locations)})
+	if err != nil {
+		return nil, domain.ErrInternal
+	}
+
+	job, cerr := is.jobs.EnqueueJob(ctx, &domain.Job{
+		Kind:        domain.JobKindImportLocations,
+		Args:        args,
+		MaxAttempts: importMaxAttempts,
+		Progress:    progress,
+	})
+	if cerr != nil {
+		if isUnavailable(cerr) {
+			return nil, cerr
+		}
+
+		logger.FromCtx(ctx).Error("Error enqueuing import", zap.Error(cerr))
+		return nil, domain.ErrInternal
+	}
+
+	return importJob(job), nil
+}
+
+func (is *ImportService) GetImportJob(ctx context.Context, id string) (*domain.ImportJob, domain.CError) {
+	notFound := domain.NewCodedCError(errcode.ImportNotFound, "import job not found")
+
+	jobID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return nil, notFound
+	}
+
+	job, cerr := is.jobs.GetJob(ctx, jobID)
+	if cerr != nil {
+		if cerr.Code() == 404 {
+			return nil, notFound
+		}
+		if isUnavailable(cerr) {
+			return nil, cerr
+		}
+
+		logger.FromCtx(ctx).Error("Error getting import job", zap.Error(cerr))
+		return nil, domain.ErrInternal
+	}
+
+	if job.Kind != domain.JobKindImportLocations {
+		return nil, notFound
+	}
+
+	return importJob(job), nil
+}
+
+// RunImportJob is the queue worker of the import jobs. It copies the locations in batches, each
+// in its own transaction, and records the progress after every batch. The locations are imported for
+// the tenant of the caller that enqueued the job
+func (is *ImportService) RunImportJob(ctx context.Context, job *domain.Job) error {
+	var args importArgs
+	if err := json.Unmarshal(job.Args, &args); err != nil {
+		return fmt.Errorf("decoding import args: %w", err)
+	}
+	if args.Tenant != "" {
+		ctx = auth.WithPrincipal(ctx, &auth.Principal{Tenant: args.Tenant})
+	}
+
+	progress := importProgress{Total: len(args.Locations)}
+	if len(job.Progress) > 0 {
+		if err := json.Unmarshal(job.Progress, &progress); err != nil {
+			return fmt.Errorf("decoding import progress: %w", err)
+		}
+	}
+
+	for start := progress.Offset; start < len(args.Locations); start += importBatchSize {
+		end := min(start+importBatchSize, len(args.Locations))
+
+		batch := make([]domain.Location, 0, end-start)
+		for _, l := range args.Locations[start:end] {
+			batch = append(batch, domain.Location{
+				Name:      l.Name,
+				Latitude:  l.Latitude,
+				Longitude: l.Longitude,
+				Altitude:  l.Altitude,
+				Category:  l.Category,
+				Entrances: l.Entrances,
+				Footprint: l.Footprint,
+			})
+		}
+
+		var inserted int64
+		err := is.tx.WithinTx(ctx, func(ctx context.Context) error {
+			var cerr domain.CError
+			inserted, cerr = is.repo.CopyLocations(ctx, batch)
+			if cerr != nil {
+				return cerr
+			}
+			return nil
+		})
+
+		if err != nil {
+			// only the last attempt is worth an alert, the queue retries the others
+			if job.Attempt >= job.MaxAttempts && ctx.Err() == nil {
+				notify(ctx, is.notifier, &domain.Notification{
+					Type:  domain.NotificationImportFailed,
+					Title: "Location import failed",
+					Text:  err.Error(),
+					Fields: []domain.NotificationField{
+						{Name: "Import", Value: strconv.FormatInt(job.ID, 10)},
+						{Name: "Imported", Value: fmt.Sprintf("%d of %d", progress.Imported, progress.Total)},
+					},
+				})
+			}
+			return err
+		}
+
+		progress.Imported += inserted
+		progress.Skipped += int64(len(batch)) - inserted
+		progress.Offset = end
+
+		raw, err := json.Marshal(progress)
+		if err != nil {
+			return err
+		}
+		if cerr := is.jobs.UpdateJobProgress(ctx, job.ID, raw); cerr != nil {
+			return cerr
+		}
+	}
+
+	logger.FromCtx(ctx).Info("Finished importing locations", zap.Int("total", progress.Total))
+	return nil
+}
+
+// importJob describes an import job to clients
+func importJob(job *domain.Job) *domain.ImportJob {
+	var progress importProgress
+	if len(job.Progress) > 0 {
+		json.Unmarshal(job.Progress, &progress)
+	}
+
+	imp := &domain.ImportJob{
+		ID:         strconv.FormatInt(job.ID, 10),
+		Total:      progress.Total,
+		Imported:   progress.Imported,
+		Skipped:    progress.Skipped,
+		CreatedAt:  job.CreatedAt,
+		FinishedAt: job.FinalizedAt,
+	}
+
+	switch job.State {
+	case domain.JobRunning:
+		imp.Status = domain.ImportRunning
+	case domain.JobCompleted:
+		imp.Status = domain.ImportCompleted
+	case domain.JobFailed:
+		imp.Status = domain.ImportFailed
+		imp.Error = "import failed after importing some locations"
+	case domain.JobCancelled:
+		imp.Status = domain.ImportFailed
+		imp.Error = "import was cancelled"
+	default:
+		imp.Status = domain.ImportPending
+	}
+
+	return imp
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+
+	"leeta/internal/adapter/logger"
+	"leeta/internal/core/domain"
+	"leeta/internal/core/errcode"
+	"leeta/internal/core/port"
+
+	"go.uber.org/zap"
+)
+
+/**
+ * JobService implements port.JobService interface
+ */
+type JobService struct {
+	repo port.JobRepository
+}
+
+// NewJobService creates a new job service instance
+func NewJobService(repo port.JobRepository) *JobService {
+	return &JobService{
+		repo,
+	}
+}
+
+func (js *JobService) GetJob(ctx context.Context, id int64) (*domain.Job, domain.CError) {
+	job, cerr := js.repo.GetJob(ctx, id)
+	if cerr != nil {
+		return nil, js.jobError(ctx, cerr, "Error getting job")
+	}
+
+	return job, nil
+}
+
+func (js *JobService) ListJobs(ctx context.Context, req *domain.ListJobsRequest) ([]domain.Job, domain.CError) {
+	jobs, cerr := js.repo.ListJobs(ctx, req)
+	if cerr != nil {
+		return nil, js.jobError(ctx, cerr, "Error listing jobs")
+	}
+
+	return jobs, nil
+}
+
+func (js *JobService) CancelJob(ctx context.Context, id int64) (*domain.Job, domain.CError) {
+	job, cerr := js.repo.CancelJob(ctx, id)
+	if cerr != nil {
+		if cerr.Code() == domain.ErrConflictingData.Code() {
+			return nil, domain.NewCodedCError(errcode.JobNotCancellable, "only jobs waiting for a worker can be cancelled")
+		}
+		return nil, js.jobError(ctx, cerr, "Error cancelling job")
+	}
+
+	logger.FromCtx(ctx).Warn("Cancelled job", zap.Int64("job_id", id), zap.String("kind", job.Kind))
+	return job, nil
+}
+
+func (js *JobService) RetryJob(ctx context.Context, id int64) (*domain.Job, domain.CError) {
+	job, cerr := js.repo.RetryJob(ctx, id)
+	if cerr != nil {
+		if cerr.Code() == domain.ErrConflictingData.Code() {
+			return nil, domain.NewCodedCError(errcode.JobNotRetryable, "only failed or cancelled jobs can be retried")
+		}
+		return nil, js.jobError(ctx, cerr, "Error retrying job")
+	}
+
+	logger.FromCtx(ctx).Info("Retrying job", zap.Int64("job_id", id), zap.String("kind", job.Kind))
+	return job, nil
+}
+
+// jobError maps a repository error to the error returned to clients
+func (js *JobService) jobError(ctx context.Context, cerr domain.CError, msg string) domain.CError {
+	if cerr.Code() == 404 {
+		return domain.NewCodedCError(errcode.JobNotFound, "job not found")
+	}
+	if isUnavailable(cerr) {
+		return cerr
+	}
+
+	logger.FromCtx(ctx).Error(msg, zap.Error(cerr))
+	return domain.ErrInternal
+}
@@ -1,53 +1,261 @@
 package service
 
 import (
+	"cmp"
 	"context"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
 
 	"leeta/internal/adapter/logger"
+	"leeta/internal/core/auth"
 	"leeta/internal/core/domain"
+	"leeta/internal/core/errcode"
+	"leeta/internal/core/geo"
 	"leeta/internal/core/port"
 
 	"go.uber.org/zap"
+	"golang.org/x/text/language"
 )
 
+// errLocationNotFound is returned when no location has the name or slug looked up
+var errLocationNotFound = domain.NewCodedCError(errcode.LocationNotFound, "location not found")
+
+// isUnavailable reports whether a repository error means the database is down or timed out.
+// Those errors are returned as is rather than as domain.ErrInternal so clients know to retry
+func isUnavailable(cerr domain.CError) bool {
+	return cerr.Code() == domain.ErrServiceUnavailable.Code() || cerr.Code() == domain.ErrTimeout.Code()
+}
+
+// isViolation reports whether a repository error is a constraint violation naming the field at fault.
+// Those errors are caused by the request, so they are returned as is rather than as domain.ErrInternal
+func isViolation(cerr domain.CError) bool {
+	switch cerr.ErrorCode() {
+	case errcode.ReferenceNotFound, errcode.ConstraintViolated, errcode.RequiredFieldMissing:
+		return true
+	}
+	return false
+}
+
+// recordHits counts a hit for each of the locations with popularity, unless no popularity is set
+func recordHits(ctx context.Context, popularity port.PopularityService, ids ...string) {
+	if popularity != nil {
+		popularity.RecordHits(ctx, ids...)
+	}
+}
+
+// notify sends notification with notifier, unless no notifier is set
+func notify(ctx context.Context, notifier port.Notifier, notification *domain.Notification) {
+	if notifier != nil {
+		notifier.Notify(ctx, notification)
+	}
+}
+
 /**
- * LocationService implements port.LocationService interface
+ * LocationService implements port.LocationService interface.
+ * It keeps the hot location lookups in an in-process cache,
+ * dropped on every write and every change in the change feed
  */
 type LocationService struct {
-	repo port.LocationRepository
+	repo       port.LocationRepository
+	cache      *lru[string, domain.Location]
+	pool       *WorkerPool
+	notifier   port.Notifier
+	formula    domain.DistanceFormula
+	routing    port.Routing
+	popularity port.PopularityService
+	quotas     port.QuotaService
+	duplicates domain.DuplicatePolicy
 }
 
-// NewLocationService creates a new location service instance
-func NewLocationService(repo port.LocationRepository) *LocationService {
+// NewLocationService creates a new location service instance. Up to cacheSize
+// locations looked up by name are cached for cacheTTL, a cacheSize of 0 disables the cache.
+// Exports run on pool, or without bound if it is nil. Deletions are reported to notifier,
+// or not at all if it is nil. Distances are computed with formula unless a search asks for
+// another, an empty formula measures them on the spheroid. Nearest searches by travel time
+// ask routing for the travel times, they are not available if it is nil. The locations fetched and
+// found by nearest searches are counted as hits by popularity, or not at all if it is nil. The registered
+// locations are checked against the quotas of the tenants with quotas, or not at all if it is nil. Registering
+// a location at the coordinates of another follows duplicates, an empty policy allows it
+func NewLocationService(repo port.LocationRepository, cacheSize int, cacheTTL time.Duration, pool *WorkerPool, notifier port.Notifier, formula domain.DistanceFormula, routing port.Routing, popularity port.PopularityService, quotas port.QuotaService, duplicates domain.DuplicatePolicy) *LocationService {
+	var cache *lru[string, domain.Location]
+	if cacheSize > 0 && cacheTTL > 0 {
+		cache = newLRU[string, domain.Location](cacheSize, cacheTTL)
+	}
+
+	if formula == "" {
+		formula = domain.DistanceSpheroid
+	}
+
+	if duplicates == "" {
+		duplicates = domain.DuplicatesAllow
+	}
+
 	return &LocationService{
 		repo,
+		cache,
+		pool,
+		notifier,
+		formula,
+		routing,
+		popularity,
+		quotas,
+		duplicates,
+	}
+}
+
+// OnLocationChange drops the cached locations when a location changes, including
+// changes made by peers or directly in the database
+func (ls *LocationService) OnLocationChange(ctx context.Context, change *domain.LocationChange) {
+	ls.purgeCache()
+}
+
+// cacheKey returns the key of the cached lookup of a location by value. The locations a tenant sees may not be the
+// ones the others see, so the key starts with the tenant, and its parts are separated by a NUL, which no stored
+// name holds
+func cacheKey(ctx context.Context, by, value string) string {
+	return auth.Tenant(ctx) + "\x00" + by + "\x00" + value
+}
+
+// lookupCache returns the cache of the lookups of the caller of ctx, nil without one. The jobs acting for every
+// tenant read locations no caller may see, so their lookups are not cached
+func (ls *LocationService) lookupCache(ctx context.Context) *lru[string, domain.Location] {
+	if auth.AllTenants(ctx) {
+		return nil
+	}
+	return ls.cache
+}
+
+// purgeCache drops the cached locations
+func (ls *LocationService) purgeCache() {
+	if ls.cache != nil {
+		ls.cache.purge()
 	}
 }
 
 func (ls *LocationService) RegisterLocation(ctx context.Context, location *domain.RegisterLocationRequest) (*domain.Location, domain.CError) {
+	names, cerr := canonicalNames(location.Names)
+	if cerr != nil {
+		return nil, cerr
+	}
+
 	locationToCreate := domain.Location{
-		Name:      location.Name,
-		Latitude:  location.Latitude,
-		Longitude: location.Longitude,
+		Name:       location.Name,
+		Latitude:   location.Latitude,
+		Longitude:  location.Longitude,
+		Altitude:   location.Altitude,
+		Category:   location.Category,
+		Names:      names,
+		Entrances:  location.Entrances,
+		Footprint:  location.Footprint,
+		ValidFrom:  location.ValidFrom,
+		ValidUntil: location.ValidUntil,
+		Status:     domain.LocationApproved,
+	}
+	if location.Review {
+		locationToCreate.Status = domain.LocationPending
+	}
+
+	if cerr := checkQuota(ctx, ls.quotas, domain.QuotaLocations, 1); cerr != nil {
+		return nil, cerr
+	}
+
+	duplicates, cerr := ls.findDuplicates(ctx, &locationToCreate)
+	if cerr != nil {
+		return nil, cerr
 	}
 
 	locationResponse, cerr := ls.repo.CreateLocation(ctx, &locationToCreate)
+	ls.purgeCache()
 	if cerr != nil {
+		if cerr.Code() == 400 || isUnavailable(cerr) || isViolation(cerr) {
+			return nil, cerr
+		}
 
 		if cerr.Code() == 409 { // conflict
-			return nil, domain.NewCError(cerr.Code(), "location already exists")
+			return nil, domain.NewCodedCError(errcode.DuplicateSlug, "location already exists")
 		}
 
 		logger.FromCtx(ctx).Error("Error creating location", zap.Error(cerr))
 		return nil, domain.ErrInternal
 	}
 
+	locationResponse.NearbyDuplicates = duplicates
 	return locationResponse, nil
 }
 
+// findDuplicates applies the duplicate policy to a location about to be registered. It refuses the location when
+// the policy rejects duplicates and another is at its coordinates, and returns the locations at its coordinates
+// when the policy warns about them
+func (ls *LocationService) findDuplicates(ctx context.Context, location *domain.Location) ([]domain.Location, domain.CError) {
+	if ls.duplicates == domain.DuplicatesAllow {
+		return nil, nil
+	}
+
+	duplicates, cerr := ls.repo.FindLocationsAt(ctx, location.Latitude, location.Longitude)
+	if cerr != nil {
+		if isUnavailable(cerr) {
+			return nil, cerr
+		}
+
+		logger.FromCtx(ctx).Error("Error finding locations at the same coordinates", zap.Error(cerr))
+		return nil, domain.ErrInternal
+	}
+	if len(duplicates) == 0 {
+		return nil, nil
+	}
+
+	if ls.duplicates == domain.DuplicatesReject {
+		return nil, domain.NewCodedCError(errcode.DuplicateLocation, fmt.Sprintf("location %s is already at these coordinates", duplicates[0].Name))
+	}
+	return duplicates, nil
+}
+
+// canonicalNames keys translated names by their canonical locale, so en-us and en-US are the same locale.
+// A nil map stays nil as it means the names are left as they are
+func canonicalNames(names map[string]string) (map[string]string, domain.CError) {
+	if names == nil {
+		return nil, nil
+	}
+
+	canonical := make(map[string]string, len(names))
+	for locale, name := range names {
+		tag, err := language.Parse(locale)
+		if err != nil {
+			return nil, domain.NewCError(400, fmt.Sprintf("invalid locale %q", locale))
+		}
+
+		key := tag.String()
+		if _, ok := canonical[key]; ok {
+			return nil, domain.NewCError(400, fmt.Sprintf("locale %s is given more than once", key))
+		}
+		canonical[key] = name
+	}
+
+	return canonical, nil
+}
+
 func (ls *LocationService) GetLocation(ctx context.Context, name string) (*domain.Location, domain.CError) {
+	// names are matched ignoring case, so are the cache keys
+	key := cacheKey(ctx, "name", strings.ToLower(name))
+	cache := ls.lookupCache(ctx)
+
+	var generation uint64
+	if cache != nil {
+		if location, ok := cache.get(key); ok {
+			recordHits(ctx, ls.popularity, location.ID)
+			return &location, nil
+		}
+		generation = cache.currentGeneration()
+	}
+
 	location, cerr := ls.repo.GetLocationByName(ctx, name)
 	if cerr != nil {
+		if cerr.Code() == 404 {
+			return nil, errLocationNotFound
+		}
 		if cerr.Code() == 500 {
 
 			logger.FromCtx(ctx).Error("Error getting location", zap.Error(cerr))
@@ -56,12 +264,23 @@ func (ls *LocationService) GetLocation(ctx context.Context, name string) (*domai
 		return nil, cerr
 	}
 
+	if cache != nil {
+		cache.set(generation, key, *location)
+	}
+
+	recordHits(ctx, ls.popularity, location.ID)
 	return location, nil
 }
 
-func (ls *LocationService) ListLocations(ctx context.Context) ([]domain.Location, domain.CError) {
-	locations, cerr := ls.repo.ListLocations(ctx)
+func (ls *LocationService) ListLocations(ctx context.Context, req *domain.ListLocationsRequest) ([]domain.Location, domain.CError) {
+	locations, cerr := ls.repo.ListLocations(ctx, req)
 	if cerr != nil {
+		switch {
+		case cerr.Code() == 400 || isUnavailable(cerr):
+			return nil, cerr
+		case cerr.Code() == 404:
+			return nil, errCollectionNotFound
+		}
 
 		logger.FromCtx(ctx).Error("Error listing location", zap.Error(cerr))
 		return nil, domain.ErrInternal
@@ -70,10 +289,160 @@ func (ls *LocationService) ListLocations(ctx context.Context) ([]domain.Location
 	return locations, nil
 }
 
-func (ls *LocationService) DeleteLocation(ctx context.Context, name string) domain.CError {
+func (ls *LocationService) LocationGeometry(ctx context.Context, req *domain.LocationGeometryRequest) (*domain.LocationGeometry, domain.CError) {
+	geometry, cerr := ls.repo.LocationGeometry(ctx, req)
+	if cerr != nil {
+		switch {
+		case cerr.Code() == 400 || isUnavailable(cerr):
+			return nil, cerr
+		case cerr.Code() == 404:
+			return nil, errCollectionNotFound
+		}
+
+		logger.FromCtx(ctx).Error("Error computing location geometry", zap.Error(cerr))
+		return nil, domain.ErrInternal
+	}
+
+	return geometry, nil
+}
+
+// LookupLocations fetches the locations in one query, ids and slugs are matched ignoring case
+func (ls *LocationService) LookupLocations(ctx context.Context, req *domain.LookupLocationsRequest) (*domain.LocationLookup, domain.CError) {
+	if len(req.IDs)+len(req.Slugs) > domain.MaxLookupLocations {
+		return nil, domain.NewBadRequestCError(fmt.Sprintf("at most %d ids and slugs can be looked up at once", domain.MaxLookupLocations))
+	}
+
+	ids, slugs := lowerAll(req.IDs), lowerAll(req.Slugs)
+	locations, cerr := ls.repo.LookupLocations(ctx, ids, slugs)
+	if cerr != nil {
+		if isUnavailable(cerr) {
+			return nil, cerr
+		}
+
+		logger.FromCtx(ctx).Error("Error looking up locations", zap.Error(cerr))
+		return nil, domain.ErrInternal
+	}
+
+	foundIDs := make(map[string]bool, len(locations))
+	foundSlugs := make(map[string]bool, len(locations))
+	for _, location := range locations {
+		foundIDs[location.ID] = true
+		foundSlugs[location.Slug] = true
+	}
+
+	lookup := &domain.LocationLookup{
+		Found:   locations,
+		Missing: domain.MissingLookups{IDs: []string{}, Slugs: []string{}},
+	}
+	for i, id := range ids {
+		if !foundIDs[id] {
+			lookup.Missing.IDs = append(lookup.Missing.IDs, req.IDs[i])
+		}
+	}
+	for i, slug := range slugs {
+		if !foundSlugs[slug] {
+			lookup.Missing.Slugs = append(lookup.Missing.Slugs, req.Slugs[i])
+		}
+	}
+
+	return lookup, nil
+}
+
+// lowerAll returns the values in lower case
+func lowerAll(values []string) []string {
+	lowered := make([]string, len(values))
+	for i, value := range values {
+		lowered[i] = strings.ToLower(value)
+	}
+	return lowered
+}
+
+func (ls *LocationService) ExportLocations(ctx context.Context, req *domain.ListLocationsRequest, fn func(location *domain.Location) error) domain.CError {
+	stream := func() domain.CError {
+		return ls.repo.StreamLocations(ctx, req, fn)
+	}
+
+	var cerr domain.CError
+	if ls.pool == nil {
+		cerr = stream()
+	} else if poolErr := ls.pool.Do(ctx, func() { cerr = stream() }); poolErr != nil {
+		return poolErr
+	}
+
+	if cerr != nil {
+		switch {
+		case cerr.Code() == 400 || isUnavailable(cerr):
+			return cerr
+		case cerr.Code() == 404:
+			return errCollectionNotFound
+		}
+
+		logger.FromCtx(ctx).Error("Error exporting locations", zap.Error(cerr))
+		return domain.ErrInternal
+	}
+
+	return nil
+}
+
+func (ls *LocationService) UpdateLocation(ctx context.Context, name string, req *domain.UpdateLocationRequest) (*domain.Location, domain.CError) {
+	names, cerr := canonicalNames(req.Names)
+	if cerr != nil {
+		return nil, cerr
+	}
+
+	locationToUpdate := domain.Location{
+		Name:       req.Name,
+		Latitude:   req.Latitude,
+		Longitude:  req.Longitude,
+		Altitude:   req.Altitude,
+		Category:   req.Category,
+		Version:    req.Version,
+		Names:      names,
+		Entrances:  req.Entrances,
+		Footprint:  req.Footprint,
+		ValidFrom:  req.ValidFrom,
+		ValidUntil: req.ValidUntil,
+	}
+
+	if cerr := ls.checkPrecondition(ctx, name, req.Precondition); cerr != nil {
+		return nil, cerr
+	}
+
+	location, cerr := ls.repo.UpdateLocation(ctx, name, &locationToUpdate)
+	ls.purgeCache()
+	if cerr != nil {
+		if cerr.Code() == 404 {
+			return nil, errLocationNotFound
+		}
+		// the location changed since it was checked, the client is sent the new one
+		if cerr.ErrorCode() == errcode.VersionConflict && !req.Precondition.IsZero() {
+			if cerr := ls.checkPrecondition(ctx, name, req.Precondition); cerr != nil {
+				return nil, cerr
+			}
+		}
+		if cerr.Code() == 500 {
+
+			logger.FromCtx(ctx).Error("Error updating location", zap.Error(cerr))
+			return nil, domain.ErrInternal
+		}
+		return nil, cerr
+	}
+
+	return location, nil
+}
+
+func (ls *LocationService) DeleteLocation(ctx context.Context, name string, precondition domain.Precondition) domain.CError {
+	if cerr := ls.checkPrecondition(ctx, name, precondition); cerr != nil {
+		return cerr
+	}
+
 	cerr := ls.repo.DeleteLocation(ctx, name)
+	ls.purgeCache()
 
 	if cerr != nil {
+		if cerr.Code() == 404 {
+			return errLocationNotFound
+		}
 		if cerr.Code() == 500 {
 
 			logger.FromCtx(ctx).Error("Error deleting location", zap.Error(cerr))
@@ -82,18 +451,396 @@ func (ls *LocationService) DeleteLocation(ctx context.Context, name string) doma
 		return cerr
 	}
 
+	notify(ctx, ls.notifier, &domain.Notification{
+		Type:   domain.NotificationLocationDeleted,
+		Title:  "Location deleted",
+		Fields: []domain.NotificationField{{Name: "Location", Value: name}},
+	})
+
 	return nil
 }
 
-func (ls *LocationService) GetNearestLocation(ctx context.Context, latitude, longitude float64) (*domain.NearestLocation, domain.CError) {
-	nearestLocation, cerr := ls.repo.GetNearestLocation(ctx, latitude, longitude)
+func (ls *LocationService) GetLocationByID(ctx context.Context, id string) (*domain.Location, domain.CError) {
+	location, cerr := ls.locationByID(ctx, id)
+	if cerr != nil {
+		return nil, cerr
+	}
+
+	recordHits(ctx, ls.popularity, location.ID)
+	return location, nil
+}
+
+// UpdateLocationByID updates the location by its current name, the version in req guards against the location
+// being renamed in between
+func (ls *LocationService) UpdateLocationByID(ctx context.Context, id string, req *domain.UpdateLocationRequest) (*domain.Location, domain.CError) {
+	location, cerr := ls.locationByID(ctx, id)
+	if cerr != nil {
+		return nil, cerr
+	}
+
+	return ls.UpdateLocation(ctx, location.Name, req)
+}
+
+func (ls *LocationService) DeleteLocationByID(ctx context.Context, id string, precondition domain.Precondition) domain.CError {
+	location, cerr := ls.locationByID(ctx, id)
+	if cerr != nil {
+		return cerr
+	}
+
+	return ls.DeleteLocation(ctx, location.Name, precondition)
+}
+
+// checkPrecondition fails with the current location specified by name if it does not satisfy precondition.
+// The location is read from the repository rather than the cache of the service, which may hold an older version
+func (ls *LocationService) checkPrecondition(ctx context.Context, name string, precondition domain.Precondition) domain.CError {
+	if precondition.IsZero() {
+		return nil
+	}
+
+	location, cerr := ls.repo.GetLocationByName(ctx, name)
+	if cerr != nil {
+		if cerr.Code() == 404 {
+			return errLocationNotFound
+		}
+		if cerr.Code() == 500 {
+
+			logger.FromCtx(ctx).Error("Error getting location", zap.Error(cerr))
+			return domain.ErrInternal
+		}
+		return cerr
+	}
+
+	if !precondition.Holds(location) {
+		return domain.NewPreconditionFailedError(location)
+	}
+	return nil
+}
+
+// locationByID fetches the location with id, the ones waiting for review are not found like by name. It is
+// cached as the lookups by name are, and dropped along with them on every write
+func (ls *LocationService) locationByID(ctx context.Context, id string) (*domain.Location, domain.CError) {
+	key := cacheKey(ctx, "id", id)
+	cache := ls.lookupCache(ctx)
+
+	var generation uint64
+	if cache != nil {
+		if location, ok := cache.get(key); ok {
+			return &location, nil
+		}
+		generation = cache.currentGeneration()
+	}
+
+	location, cerr := ls.repo.GetLocationByID(ctx, id)
+	if cerr != nil {
+		if cerr.Code() == 404 {
+			return nil, errLocationNotFound
+		}
+		if cerr.Code() == 500 {
+
+			logger.FromCtx(ctx).Error("Error getting location", zap.Error(cerr))
+			return nil, domain.ErrInternal
+		}
+		return nil, cerr
+	}
+
+	if location.Status != domain.LocationApproved {
+		return nil, errLocationNotFound
+	}
+
+	if cache != nil {
+		cache.set(generation, key, *location)
+	}
+
+	return location, nil
+}
+
+// expireBatchSize is the number of locations flagged as expired per transaction
+const expireBatchSize = 1000
+
+// ExpireLocations flags the locations whose validity has ended as expired, in batches
+func (ls *LocationService) ExpireLocations(ctx context.Context) error {
+	var total int64
+	for {
+		expired, cerr := ls.repo.ExpireLocations(ctx, expireBatchSize)
+		if cerr != nil {
+			return cerr
+		}
+		total += expired
+
+		if expired < expireBatchSize {
+			break
+		}
+	}
+
+	if total > 0 {
+		ls.purgeCache()
+		logger.FromCtx(ctx).Info("Expired locations", zap.Int64("expired", total))
+	}
+	return nil
+}
+
+func (ls *LocationService) GetNearestLocation(ctx context.Context, latitude, longitude float64, formula domain.DistanceFormula) (*domain.NearestLocation, domain.CError) {
+	if formula == "" {
+		formula = ls.formula
+	}
+
+	nearestLocation, cerr := ls.repo.GetNearestLocation(ctx, latitude, longitude, formula)
 	if cerr != nil {
 		if cerr.Code() == 404 {
-			return nil, domain.NewCError(cerr.Code(), "no location found")
+			return nil, domain.NewCodedCError(errcode.NoLocationFound, "no location found")
+		}
+		if isUnavailable(cerr) {
+			return nil, cerr
 		}
 
 		return nil, domain.ErrInternal
 	}
 
+	setEntrance(nearestLocation, latitude, longitude)
+	setBearing(nearestLocation, latitude, longitude)
+	setVincentyDistance(nearestLocation, latitude, longitude, formula)
+	recordHits(ctx, ls.popularity, nearestLocation.ID)
 	return nearestLocation, nil
 }
+
+// defaultNearestLimit is the number of locations returned when a nearest search sets no limit
+const defaultNearestLimit = 10
+
+// altitudeCandidates is the least number of the nearest locations on the ground a search with an altitude
+// orders by their distance in 3D
+const altitudeCandidates = 50
+
+func (ls *LocationService) FindNearestLocations(ctx context.Context, req *domain.NearestLocationsRequest) ([]domain.NearestLocation, domain.CError) {
+	if req.Limit == 0 {
+		req.Limit = defaultNearestLimit
+	}
+	if req.Formula == "" {
+		req.Formula = ls.formula
+	}
+
+	// the nearest in 3D are looked for among more of the nearest on the ground, the index only knows the ground
+	inSpace := req.Altitude != nil && req.MaxTravelTime == 0
+	query := req
+	if inSpace {
+		candidates := *req
+		candidates.Limit = max(req.Limit, altitudeCandidates)
+		query = &candidates
+	}
+
+	var locations []domain.NearestLocation
+	var cerr domain.CError
+	if req.MaxTravelTime > 0 {
+		locations, cerr = ls.findReachableLocations(ctx, req)
+	} else {
+		locations, cerr = ls.repo.FindNearestLocations(ctx, query)
+	}
+	if cerr != nil {
+		switch {
+		case isUnavailable(cerr) || cerr.Code() == domain.ErrRoutingDisabled.Code():
+			return nil, cerr
+		case cerr.Code() == 404:
+			return nil, errCollectionNotFound
+		}
+
+		logger.FromCtx(ctx).Error("Error finding nearest locations", zap.Error(cerr))
+		return nil, domain.ErrInternal
+	}
+
+	for i := range locations {
+		setEntrance(&locations[i], req.Latitude, req.Longitude)
+		setBearing(&locations[i], req.Latitude, req.Longitude)
+		setVincentyDistance(&locations[i], req.Latitude, req.Longitude, req.Formula)
+		setSlantDistance(&locations[i], req.Altitude)
+	}
+	if inSpace {
+		locations = nearestInSpace(locations, req)
+	}
+
+	ids := make([]string, len(locations))
+	for i := range locations {
+		ids[i] = locations[i].ID
+	}
+
+	recordHits(ctx, ls.popularity, ids...)
+	return locations, nil
+}
+
+func (ls *LocationService) FindLocationsAlongRoute(ctx context.Context, req *domain.AlongRouteRequest) ([]domain.RouteLocation, domain.CError) {
+	route, cerr := routePoints(req)
+	if cerr != nil {
+		return nil, cerr
+	}
+	if req.Limit == 0 {
+		req.Limit = defaultNearestLimit
+	}
+
+	locations, cerr := ls.repo.FindLocationsAlongRoute(ctx, route, req)
+	if cerr != nil {
+		if isUnavailable(cerr) {
+			return nil, cerr
+		}
+
+		logger.FromCtx(ctx).Error("Error finding locations along route", zap.Error(cerr))
+		return nil, domain.ErrInternal
+	}
+
+	return locations, nil
+}
+
+// maxRoutePoints is the greatest number of points of a route, decoded polylines included
+const maxRoutePoints = 1000
+
+// routePoints returns the points of the route in the request, decoding its polyline if it has one
+func routePoints(req *domain.AlongRouteRequest) ([]domain.Point, domain.CError) {
+	if req.Polyline == "" {
+		return req.Waypoints, nil
+	}
+
+	decoded, err := geo.DecodePolyline(req.Polyline)
+	if err != nil {
+		return nil, domain.NewBadRequestCError(err.Error())
+	}
+	if len(decoded) < 2 || len(decoded) > maxRoutePoints {
+		return nil, domain.NewBadRequestCError(fmt.Sprintf("the polyline must have between 2 and %d points", maxRoutePoints))
+	}
+
+	points := make([]domain.Point, len(decoded))
+	for i, p := range decoded {
+		if math.Abs(p[0]) > 90 || math.Abs(p[1]) > 180 {
+			return nil, domain.NewBadRequestCError("the polyline has coordinates out of range")
+		}
+		points[i] = domain.Point{Latitude: p[0], Longitude: p[1]}
+	}
+
+	return points, nil
+}
+
+// travelTimeCandidates is the number of nearest locations whose travel time is computed in a search by travel time
+const travelTimeCandidates = 50
+
+// maxSpeeds are the greatest speeds in meters per second of the modes of travel. No location further than the
+// maximum travel time at that speed can be reached in time, so they bound the candidates of a search by travel time
+var maxSpeeds = map[domain.TravelMode]float64{
+	domain.TravelDriving: 130 / 3.6,
+	domain.TravelCycling: 40 / 3.6,
+	domain.TravelWalking: 7 / 3.6,
+}
+
+// findReachableLocations finds the nearest locations reachable within the maximum travel time of the request,
+// quickest first. The travel times of the nearest candidates within reach at the greatest speed of the mode
+// are asked to routing and those too long or unreachable are dropped
+func (ls *LocationService) findReachableLocations(ctx context.Context, req *domain.NearestLocationsRequest) ([]domain.NearestLocation, domain.CError) {
+	if ls.routing == nil {
+		return nil, domain.ErrRoutingDisabled
+	}
+	if req.Mode == "" {
+		req.Mode = domain.TravelDriving
+	}
+
+	query := *req
+	query.Limit = travelTimeCandidates
+	if reach := float64(req.MaxTravelTime) * maxSpeeds[req.Mode]; query.MaxDistance == 0 || query.MaxDistance > reach {
+		query.MaxDistance = reach
+	}
+
+	locations, cerr := ls.repo.FindNearestLocations(ctx, &query)
+	if cerr != nil || len(locations) == 0 {
+		return nil, cerr
+	}
+
+	destinations := make([]domain.Point, len(locations))
+	for i := range locations {
+		setEntrance(&locations[i], req.Latitude, req.Longitude)
+		destinations[i] = reachedAt(&locations[i])
+	}
+
+	origin := domain.Point{Latitude: req.Latitude, Longitude: req.Longitude}
+	times, err := ls.routing.TravelTimes(ctx, origin, destinations, req.Mode)
+	if err != nil {
+		logger.FromCtx(ctx).Error("Error computing travel times", zap.Error(err))
+		return nil, domain.ErrServiceUnavailable
+	}
+
+	reachable := locations[:0]
+	for i := range locations {
+		if times[i] < 0 || times[i] > float64(req.MaxTravelTime) {
+			continue
+		}
+		locations[i].TravelTime = &times[i]
+		reachable = append(reachable, locations[i])
+	}
+
+	slices.SortStableFunc(reachable, func(a, b domain.NearestLocation) int {
+		return cmp.Compare(*a.TravelTime, *b.TravelTime)
+	})
+
+	return reachable[:min(len(reachable), req.Limit)], nil
+}
+
+// setEntrance sets the entrance of the location closest to the query point, when it has entrances
+func setEntrance(location *domain.NearestLocation, latitude, longitude float64) {
+	location.Entrance = nil
+
+	closest := math.Inf(1)
+	for i, entrance := range location.Entrances {
+		if distance := geo.Haversine(latitude, longitude, entrance.Latitude, entrance.Longitude); distance < closest {
+			closest = distance
+			location.Entrance = &location.Entrances[i]
+		}
+	}
+}
+
+// reachedAt is the point distances to the location are measured to, its closest entrance or the location itself
+func reachedAt(location *domain.NearestLocation) domain.Point {
+	if location.Entrance != nil {
+		return domain.Point{Latitude: location.Entrance.Latitude, Longitude: location.Entrance.Longitude}
+	}
+	return domain.Point{Latitude: location.Latitude, Longitude: location.Longitude}
+}
+
+// setBearing sets the bearing and compass direction from the query point to the location
+func setBearing(location *domain.NearestLocation, latitude, longitude float64) {
+	to := reachedAt(location)
+	location.Bearing = geo.Bearing(latitude, longitude, to.Latitude, to.Longitude)
+	location.Direction = geo.Compass(location.Bearing)
+}
+
+// setVincentyDistance replaces the spheroid distance computed by the database with the distance given
+// by Vincenty's formula, when it is the formula asked for and it converges. Distances to the edge of a
+// footprint are kept, Vincenty's formula is between points
+func setVincentyDistance(location *domain.NearestLocation, latitude, longitude float64, formula domain.DistanceFormula) {
+	if formula != domain.DistanceVincenty || (location.Footprint != nil && location.Entrance == nil) {
+		return
+	}
+
+	to := reachedAt(location)
+	if distance, ok := geo.Vincenty(latitude, longitude, to.Latitude, to.Longitude); ok {
+		location.Distance = distance
+	}
+}
+
+// setSlantDistance replaces the ground distance to the location with the distance in 3D, when both the
+// query point and the location have an altitude
+func setSlantDistance(location *domain.NearestLocation, altitude *float64) {
+	if altitude == nil || location.Altitude == nil {
+		return
+	}
+
+	location.Distance = geo.Slant(location.Distance, *altitude, *location.Altitude)
+}
+
+// nearestInSpace orders the candidates by their distance in 3D, drops those beyond the maximum distance of the
+// request, which only bounded their ground distance, and keeps the limit of the request
+func nearestInSpace(locations []domain.NearestLocation, req *domain.NearestLocationsRequest) []domain.NearestLocation {
+	if req.MaxDistance > 0 {
+		locations = slices.DeleteFunc(locations, func(l domain.NearestLocation) bool {
+			return l.Distance > req.MaxDistance
+		})
+	}
+
+	slices.SortStableFunc(locations, func(a, b domain.NearestLocation) int {
+		return cmp.Compare(a.Distance, b.Distance)
+	})
+
+	return locations[:min(len(locations), req.Limit)]
+}
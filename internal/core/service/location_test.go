@@ -0,0 +1,547 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"leeta/internal/core/auth"
+	"leeta/internal/core/domain"
+	"leeta/internal/core/errcode"
+	"leeta/internal/core/port/mock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocationService_FindNearestLocationsByTravelTime(t *testing.T) {
+	repo := &mock.LocationRepositoryMock{
+		FindNearestLocationsFunc: func(ctx context.Context, query *domain.NearestLocationsRequest) ([]domain.NearestLocation, domain.CError) {
+			return []domain.NearestLocation{
+				{Location: domain.Location{Name: "Across the river", Latitude: 6.45, Longitude: 3.40}, Distance: 800},
+				{Location: domain.Location{Name: "Down the road", Latitude: 6.46, Longitude: 3.41}, Distance: 1500},
+				{Location: domain.Location{Name: "On an island", Latitude: 6.47, Longitude: 3.42}, Distance: 2000},
+				{Location: domain.Location{Name: "Out of town", Latitude: 6.60, Longitude: 3.50}, Distance: 9000},
+			}, nil
+		},
+	}
+	routing := &mock.RoutingMock{
+		TravelTimesFunc: func(ctx context.Context, origin domain.Point, destinations []domain.Point, mode domain.TravelMode) ([]float64, error) {
+			return []float64{840, 300, -1, 1200}, nil
+		},
+	}
+
+	t.Run("Reachable locations quickest first", func(t *testing.T) {
+		ls := NewLocationService(repo, 0, 0, nil, nil, "", routing, nil, nil, "")
+
+		locations, cerr := ls.FindNearestLocations(context.Background(), &domain.NearestLocationsRequest{
+			Latitude: 6.44, Longitude: 3.39, MaxTravelTime: 900,
+		})
+		require.Nil(t, cerr)
+
+		require.Len(t, locations, 2)
+		assert.Equal(t, "Down the road", locations[0].Name)
+		assert.Equal(t, 300.0, *locations[0].TravelTime)
+		assert.Equal(t, "Across the river", locations[1].Name)
+		assert.Equal(t, 840.0, *locations[1].TravelTime)
+
+		// the candidates are bounded by the distance reachable at the greatest driving speed
+		query := repo.FindNearestLocationsCalls()[len(repo.FindNearestLocationsCalls())-1].Query
+		assert.Equal(t, travelTimeCandidates, query.Limit)
+		assert.InDelta(t, 900*130/3.6, query.MaxDistance, 0.001)
+		assert.Equal(t, domain.TravelDriving, routing.TravelTimesCalls()[0].Mode)
+	})
+
+	t.Run("Limit applies to the reachable locations", func(t *testing.T) {
+		ls := NewLocationService(repo, 0, 0, nil, nil, "", routing, nil, nil, "")
+
+		locations, cerr := ls.FindNearestLocations(context.Background(), &domain.NearestLocationsRequest{
+			Latitude: 6.44, Longitude: 3.39, MaxTravelTime: 900, Mode: domain.TravelWalking, Limit: 1,
+		})
+		require.Nil(t, cerr)
+
+		require.Len(t, locations, 1)
+		assert.Equal(t, "Down the road", locations[0].Name)
+	})
+
+	t.Run("Not available without routing", func(t *testing.T) {
+		ls := NewLocationService(repo, 0, 0, nil, nil, "", nil, nil, nil, "")
+
+		_, cerr := ls.FindNearestLocations(context.Background(), &domain.NearestLocationsRequest{
+			Latitude: 6.44, Longitude: 3.39, MaxTravelTime: 900,
+		})
+		require.NotNil(t, cerr)
+		assert.Equal(t, http.StatusNotImplemented, cerr.Code())
+	})
+
+	t.Run("Unavailable when routing fails", func(t *testing.T) {
+		failing := &mock.RoutingMock{
+			TravelTimesFunc: func(ctx context.Context, origin domain.Point, destinations []domain.Point, mode domain.TravelMode) ([]float64, error) {
+				return nil, errors.New("connection refused")
+			},
+		}
+		ls := NewLocationService(repo, 0, 0, nil, nil, "", failing, nil, nil, "")
+
+		_, cerr := ls.FindNearestLocations(context.Background(), &domain.NearestLocationsRequest{
+			Latitude: 6.44, Longitude: 3.39, MaxTravelTime: 900,
+		})
+		assert.Equal(t, domain.ErrServiceUnavailable, cerr)
+	})
+}
+
+func TestLocationService_FindNearestLocationsByAltitude(t *testing.T) {
+	altitude := func(meters float64) *float64 { return &meters }
+	repo := &mock.LocationRepositoryMock{
+		FindNearestLocationsFunc: func(ctx context.Context, query *domain.NearestLocationsRequest) ([]domain.NearestLocation, domain.CError) {
+			return []domain.NearestLocation{
+				{Location: domain.Location{Name: "Rooftop pad", Latitude: 6.45, Longitude: 3.40, Altitude: altitude(330)}, Distance: 400},
+				{Location: domain.Location{Name: "On the ground", Latitude: 6.46, Longitude: 3.41}, Distance: 450},
+				{Location: domain.Location{Name: "Low pad", Latitude: 6.47, Longitude: 3.42, Altitude: altitude(40)}, Distance: 480},
+			}, nil
+		},
+	}
+	ls := NewLocationService(repo, 0, 0, nil, nil, domain.DistanceSpheroid, nil, nil, nil, "")
+
+	t.Run("Nearest in 3D first", func(t *testing.T) {
+		locations, cerr := ls.FindNearestLocations(context.Background(), &domain.NearestLocationsRequest{
+			Latitude: 6.44, Longitude: 3.39, Altitude: altitude(30), Limit: 2,
+		})
+		require.Nil(t, cerr)
+
+		require.Len(t, locations, 2)
+		assert.Equal(t, "On the ground", locations[0].Name)
+		assert.Equal(t, 450.0, locations[0].Distance)
+		assert.Equal(t, "Low pad", locations[1].Name)
+		assert.InDelta(t, 480.1, locations[1].Distance, 0.1)
+
+		// more candidates than the limit are ordered
+		query := repo.FindNearestLocationsCalls()[len(repo.FindNearestLocationsCalls())-1].Query
+		assert.Equal(t, altitudeCandidates, query.Limit)
+	})
+
+	t.Run("Maximum distance bounds the 3D distance", func(t *testing.T) {
+		locations, cerr := ls.FindNearestLocations(context.Background(), &domain.NearestLocationsRequest{
+			Latitude: 6.44, Longitude: 3.39, Altitude: altitude(30), MaxDistance: 470,
+		})
+		require.Nil(t, cerr)
+
+		require.Len(t, locations, 1)
+		assert.Equal(t, "On the ground", locations[0].Name)
+	})
+
+	t.Run("Ground distances without an altitude", func(t *testing.T) {
+		locations, cerr := ls.FindNearestLocations(context.Background(), &domain.NearestLocationsRequest{
+			Latitude: 6.44, Longitude: 3.39,
+		})
+		require.Nil(t, cerr)
+
+		require.Len(t, locations, 3)
+		assert.Equal(t, "Rooftop pad", locations[0].Name)
+		assert.Equal(t, 400.0, locations[0].Distance)
+	})
+}
+
+func TestLocationService_FindNearestLocationsByEntrance(t *testing.T) {
+	repo := &mock.LocationRepositoryMock{
+		FindNearestLocationsFunc: func(ctx context.Context, query *domain.NearestLocationsRequest) ([]domain.NearestLocation, domain.CError) {
+			return []domain.NearestLocation{{
+				Location: domain.Location{
+					Name: "Ikeja City Mall", Latitude: 6.6138, Longitude: 3.3579,
+					Entrances: []domain.Entrance{
+						{Name: "North gate", Latitude: 6.6150, Longitude: 3.3579},
+						{Name: "South gate", Latitude: 6.6126, Longitude: 3.3579},
+					},
+				},
+				Distance: 1000,
+			}}, nil
+		},
+	}
+	ls := NewLocationService(repo, 0, 0, nil, nil, domain.DistanceVincenty, nil, nil, nil, "")
+
+	// due south of the mall
+	locations, cerr := ls.FindNearestLocations(context.Background(), &domain.NearestLocationsRequest{
+		Latitude: 6.6000, Longitude: 3.3579,
+	})
+	require.Nil(t, cerr)
+
+	require.Len(t, locations, 1)
+	require.NotNil(t, locations[0].Entrance)
+	assert.Equal(t, "South gate", locations[0].Entrance.Name)
+	assert.Equal(t, "N", locations[0].Direction)
+	// measured to the south gate, 0.0126 degrees of latitude away
+	assert.InDelta(t, 1393, locations[0].Distance, 1)
+
+	t.Run("Distances to a footprint are kept", func(t *testing.T) {
+		repo.FindNearestLocationsFunc = func(ctx context.Context, query *domain.NearestLocationsRequest) ([]domain.NearestLocation, domain.CError) {
+			return []domain.NearestLocation{{
+				Location: domain.Location{
+					Name: "University of Lagos", Latitude: 6.5158, Longitude: 3.3896,
+					Footprint: &domain.Polygon{Type: "Polygon"},
+				},
+				Distance: 120,
+			}}, nil
+		}
+
+		locations, cerr := ls.FindNearestLocations(context.Background(), &domain.NearestLocationsRequest{
+			Latitude: 6.5000, Longitude: 3.3896,
+		})
+		require.Nil(t, cerr)
+
+		require.Len(t, locations, 1)
+		assert.Nil(t, locations[0].Entrance)
+		assert.Equal(t, 120.0, locations[0].Distance)
+	})
+}
+
+func TestLocationService_RegisterLocation(t *testing.T) {
+	repo := &mock.LocationRepositoryMock{
+		CreateLocationFunc: func(ctx context.Context, location *domain.Location) (*domain.Location, domain.CError) {
+			return location, nil
+		},
+	}
+	ls := NewLocationService(repo, 0, 0, nil, nil, "", nil, nil, nil, "")
+
+	t.Run("Locales are canonicalized", func(t *testing.T) {
+		location, cerr := ls.RegisterLocation(context.Background(), &domain.RegisterLocationRequest{
+			Name: "Lagos Park", Latitude: 6.52, Longitude: 3.37,
+			Names: map[string]string{"FR": "Parc de Lagos", "pt-br": "Parque de Lagos"},
+		})
+		require.Nil(t, cerr)
+
+		assert.Equal(t, map[string]string{"fr": "Parc de Lagos", "pt-BR": "Parque de Lagos"}, location.Names)
+	})
+
+	t.Run("Submissions for review are pending", func(t *testing.T) {
+		location, cerr := ls.RegisterLocation(context.Background(), &domain.RegisterLocationRequest{
+			Name: "Lagos Park", Latitude: 6.52, Longitude: 3.37, Review: true,
+		})
+		require.Nil(t, cerr)
+
+		assert.Equal(t, domain.LocationPending, location.Status)
+	})
+
+	t.Run("Locales given twice", func(t *testing.T) {
+		_, cerr := ls.RegisterLocation(context.Background(), &domain.RegisterLocationRequest{
+			Name: "Lagos Park", Latitude: 6.52, Longitude: 3.37,
+			Names: map[string]string{"en-us": "Lagos Park", "en-US": "Lagos Park"},
+		})
+		require.NotNil(t, cerr)
+
+		assert.Equal(t, http.StatusBadRequest, cerr.Code())
+	})
+
+	t.Run("Constraint violations are returned as is", func(t *testing.T) {
+		violation := domain.NewCodedCError(errcode.ConstraintViolated, "a value breaks the locations_altitude_check check")
+		ls := NewLocationService(&mock.LocationRepositoryMock{
+			CreateLocationFunc: func(ctx context.Context, location *domain.Location) (*domain.Location, domain.CError) {
+				return nil, violation
+			},
+		}, 0, 0, nil, nil, "", nil, nil, nil, "")
+
+		_, cerr := ls.RegisterLocation(context.Background(), &domain.RegisterLocationRequest{
+			Name: "Lagos Park", Latitude: 6.52, Longitude: 3.37,
+		})
+		assert.Equal(t, violation, cerr)
+	})
+
+	t.Run("Over the quota of the tenant", func(t *testing.T) {
+		exceeded := domain.NewCodedCError(errcode.QuotaExceeded, "tenant acme would exceed its quota of 1 locations, 1 are used")
+		repo := &mock.LocationRepositoryMock{}
+		ls := NewLocationService(repo, 0, 0, nil, nil, "", nil, nil, &mock.QuotaServiceMock{
+			CheckQuotaFunc: func(ctx context.Context, resource domain.QuotaResource, n int) domain.CError {
+				return exceeded
+			},
+		}, "")
+
+		_, cerr := ls.RegisterLocation(context.Background(), &domain.RegisterLocationRequest{
+			Name: "Lagos Park", Latitude: 6.52, Longitude: 3.37,
+		})
+		assert.Equal(t, exceeded, cerr)
+		assert.Empty(t, repo.CreateLocationCalls())
+	})
+}
+
+func TestLocationService_RegisterLocationDuplicates(t *testing.T) {
+	existing := domain.Location{ID: "loc-1", Name: "Lekki Mall", Latitude: 6.45, Longitude: 3.47}
+	newRepo := func() *mock.LocationRepositoryMock {
+		return &mock.LocationRepositoryMock{
+			FindLocationsAtFunc: func(ctx context.Context, latitude, longitude float64) ([]domain.Location, domain.CError) {
+				if latitude == existing.Latitude && longitude == existing.Longitude {
+					return []domain.Location{existing}, nil
+				}
+				return []domain.Location{}, nil
+			},
+			CreateLocationFunc: func(ctx context.Context, location *domain.Location) (*domain.Location, domain.CError) {
+				return location, nil
+			},
+		}
+	}
+	req := &domain.RegisterLocationRequest{Name: "Lekki Pharmacy", Latitude: 6.45, Longitude: 3.47}
+
+	t.Run("Allowed without looking them up", func(t *testing.T) {
+		repo := newRepo()
+		ls := NewLocationService(repo, 0, 0, nil, nil, "", nil, nil, nil, domain.DuplicatesAllow)
+
+		location, cerr := ls.RegisterLocation(context.Background(), req)
+		require.Nil(t, cerr)
+		assert.Nil(t, location.NearbyDuplicates)
+		assert.Empty(t, repo.FindLocationsAtCalls())
+	})
+
+	t.Run("Warned about in the response", func(t *testing.T) {
+		repo := newRepo()
+		ls := NewLocationService(repo, 0, 0, nil, nil, "", nil, nil, nil, domain.DuplicatesWarn)
+
+		location, cerr := ls.RegisterLocation(context.Background(), req)
+		require.Nil(t, cerr)
+		assert.Equal(t, []domain.Location{existing}, location.NearbyDuplicates)
+
+		location, cerr = ls.RegisterLocation(context.Background(), &domain.RegisterLocationRequest{Name: "Yaba Market", Latitude: 6.51, Longitude: 3.38})
+		require.Nil(t, cerr)
+		assert.Nil(t, location.NearbyDuplicates)
+	})
+
+	t.Run("Rejected", func(t *testing.T) {
+		repo := newRepo()
+		ls := NewLocationService(repo, 0, 0, nil, nil, "", nil, nil, nil, domain.DuplicatesReject)
+
+		_, cerr := ls.RegisterLocation(context.Background(), req)
+		require.NotNil(t, cerr)
+		assert.Equal(t, http.StatusConflict, cerr.Code())
+		assert.Equal(t, errcode.DuplicateLocation, cerr.ErrorCode())
+		assert.Empty(t, repo.CreateLocationCalls())
+
+		_, cerr = ls.RegisterLocation(context.Background(), &domain.RegisterLocationRequest{Name: "Yaba Market", Latitude: 6.51, Longitude: 3.38})
+		assert.Nil(t, cerr)
+	})
+}
+
+func TestLocationService_GetLocation(t *testing.T) {
+	repo := &mock.LocationRepositoryMock{
+		GetLocationByNameFunc: func(ctx context.Context, name string) (*domain.Location, domain.CError) {
+			return nil, domain.ErrDataNotFound
+		},
+	}
+	ls := NewLocationService(repo, 0, 0, nil, nil, "", nil, nil, nil, "")
+
+	_, cerr := ls.GetLocation(context.Background(), "Nowhere")
+	require.NotNil(t, cerr)
+
+	assert.Equal(t, http.StatusNotFound, cerr.Code())
+	assert.Equal(t, errcode.LocationNotFound, cerr.ErrorCode())
+}
+
+func TestLocationService_GetLocationCachedPerTenant(t *testing.T) {
+	repo := &mock.LocationRepositoryMock{
+		GetLocationByNameFunc: func(ctx context.Context, name string) (*domain.Location, domain.CError) {
+			return &domain.Location{ID: "1", Name: "Lekki Depot"}, nil
+		},
+	}
+	ls := NewLocationService(repo, 10, time.Minute, nil, nil, "", nil, nil, nil, "")
+
+	acme := auth.WithPrincipal(context.Background(), &auth.Principal{User: "ada", Tenant: "acme"})
+	globex := auth.WithPrincipal(context.Background(), &auth.Principal{User: "hank", Tenant: "globex"})
+	for _, ctx := range []context.Context{acme, acme, globex, context.Background()} {
+		_, cerr := ls.GetLocation(ctx, "lekki-depot")
+		require.Nil(t, cerr)
+	}
+
+	// the second read of acme is cached, the reads of the others are not
+	assert.Len(t, repo.GetLocationByNameCalls(), 3)
+}
+
+func TestLocationService_GetLocationByIDCached(t *testing.T) {
+	repo := &mock.LocationRepositoryMock{
+		GetLocationByIDFunc: func(ctx context.Context, id string) (*domain.Location, domain.CError) {
+			return &domain.Location{ID: id, Name: "Lekki Depot", Status: domain.LocationApproved}, nil
+		},
+		DeleteLocationFunc: func(ctx context.Context, name string) domain.CError {
+			return nil
+		},
+	}
+	ls := NewLocationService(repo, 10, time.Minute, nil, nil, "", nil, nil, nil, "")
+
+	acme := auth.WithPrincipal(context.Background(), &auth.Principal{User: "ada", Tenant: "acme"})
+	globex := auth.WithPrincipal(context.Background(), &auth.Principal{User: "hank", Tenant: "globex"})
+	read := func(ctx context.Context) {
+		location, cerr := ls.GetLocationByID(ctx, "1")
+		require.Nil(t, cerr)
+		assert.Equal(t, "Lekki Depot", location.Name)
+	}
+
+	// the second read of acme is cached, the reads of the others are not
+	for _, ctx := range []context.Context{acme, acme, globex, context.Background()} {
+		read(ctx)
+	}
+	assert.Len(t, repo.GetLocationByIDCalls(), 3)
+
+	// the background jobs acting for every tenant are never cached
+	read(auth.WithAllTenants(context.Background()))
+	read(auth.WithAllTenants(context.Background()))
+	assert.Len(t, repo.GetLocationByIDCalls(), 5)
+
+	// a write drops the entry, as does a change made elsewhere
+	require.Nil(t, ls.DeleteLocation(acme, "lekki-depot", domain.Precondition{}))
+	read(acme)
+	read(acme)
+	assert.Len(t, repo.GetLocationByIDCalls(), 6)
+
+	ls.OnLocationChange(context.Background(), &domain.LocationChange{})
+	read(acme)
+	assert.Len(t, repo.GetLocationByIDCalls(), 7)
+}
+
+func TestLocationService_GetLocationByID(t *testing.T) {
+	repo := &mock.LocationRepositoryMock{
+		GetLocationByIDFunc: func(ctx context.Context, id string) (*domain.Location, domain.CError) {
+			switch id {
+			case "approved":
+				return &domain.Location{ID: id, Name: "Lagos Park", Status: domain.LocationApproved}, nil
+			case "pending":
+				return &domain.Location{ID: id, Name: "Lekki Park", Status: domain.LocationPending}, nil
+			}
+			return nil, domain.ErrDataNotFound
+		},
+	}
+	ls := NewLocationService(repo, 0, 0, nil, nil, "", nil, nil, nil, "")
+
+	location, cerr := ls.GetLocationByID(context.Background(), "approved")
+	require.Nil(t, cerr)
+	assert.Equal(t, "Lagos Park", location.Name)
+
+	// locations waiting for review are not found, like by name
+	for _, id := range []string{"pending", "unknown"} {
+		_, cerr = ls.GetLocationByID(context.Background(), id)
+		require.NotNil(t, cerr, id)
+		assert.Equal(t, errcode.LocationNotFound, cerr.ErrorCode(), id)
+	}
+}
+
+func TestLocationService_LookupLocations(t *testing.T) {
+	repo := &mock.LocationRepositoryMock{
+		LookupLocationsFunc: func(ctx context.Context, ids, slugs []string) ([]domain.Location, domain.CError) {
+			return []domain.Location{
+				{ID: "8d6a3b0e-3f2c-4c8e-9a55-1d2f0c3b7e41", Name: "Lagos Park", Slug: "lagos-park"},
+				{ID: "0b7c2f9e-5a1d-4e3b-8c6f-2e9d4a1b3c58", Name: "Lekki Park", Slug: "lekki-park"},
+			}, nil
+		},
+	}
+	ls := NewLocationService(repo, 0, 0, nil, nil, "", nil, nil, nil, "")
+
+	lookup, cerr := ls.LookupLocations(context.Background(), &domain.LookupLocationsRequest{
+		IDs:   []string{"8D6A3B0E-3F2C-4C8E-9A55-1D2F0C3B7E41", "2f1e0d9c-8b7a-4654-9321-0fedcba98765"},
+		Slugs: []string{"Lekki-Park", "ikoyi-park"},
+	})
+	require.Nil(t, cerr)
+
+	assert.Len(t, lookup.Found, 2)
+	// the missing ids and slugs are returned as they were requested
+	assert.Equal(t, []string{"2f1e0d9c-8b7a-4654-9321-0fedcba98765"}, lookup.Missing.IDs)
+	assert.Equal(t, []string{"ikoyi-park"}, lookup.Missing.Slugs)
+
+	calls := repo.LookupLocationsCalls()
+	require.Len(t, calls, 1)
+	assert.Equal(t, []string{"8d6a3b0e-3f2c-4c8e-9a55-1d2f0c3b7e41", "2f1e0d9c-8b7a-4654-9321-0fedcba98765"}, calls[0].Ids)
+
+	_, cerr = ls.LookupLocations(context.Background(), &domain.LookupLocationsRequest{
+		IDs:   make([]string, domain.MaxLookupLocations),
+		Slugs: []string{"lagos-park"},
+	})
+	require.NotNil(t, cerr)
+	assert.Equal(t, http.StatusBadRequest, cerr.Code())
+}
+
+func TestLocationService_Precondition(t *testing.T) {
+	updatedAt := time.Date(2024, 1, 1, 12, 0, 0, 500, time.UTC)
+	version := int64(2)
+	repo := &mock.LocationRepositoryMock{
+		GetLocationByNameFunc: func(ctx context.Context, name string) (*domain.Location, domain.CError) {
+			return &domain.Location{Name: "Lagos Park", Version: version, UpdatedAt: updatedAt}, nil
+		},
+		UpdateLocationFunc: func(ctx context.Context, name string, location *domain.Location) (*domain.Location, domain.CError) {
+			// another request updates the location after the check
+			version++
+			return nil, domain.ErrVersionConflict
+		},
+		DeleteLocationFunc: func(ctx context.Context, name string) domain.CError {
+			return nil
+		},
+	}
+	ls := NewLocationService(repo, 0, 0, nil, nil, "", nil, nil, nil, "")
+
+	t.Run("Failed with the current location", func(t *testing.T) {
+		cerr := ls.DeleteLocation(context.Background(), "lagos-park", domain.Precondition{Version: 1})
+
+		var failed *domain.PreconditionFailedError
+		require.ErrorAs(t, cerr, &failed)
+		assert.Equal(t, http.StatusPreconditionFailed, cerr.Code())
+		assert.Equal(t, int64(2), failed.Current.Version)
+		assert.Empty(t, repo.DeleteLocationCalls())
+	})
+
+	t.Run("Unmodified since the second of the update", func(t *testing.T) {
+		cerr := ls.DeleteLocation(context.Background(), "lagos-park", domain.Precondition{UnmodifiedSince: updatedAt.Add(-time.Second)})
+		require.NotNil(t, cerr)
+		assert.Equal(t, errcode.PreconditionFailed, cerr.ErrorCode())
+
+		cerr = ls.DeleteLocation(context.Background(), "lagos-park", domain.Precondition{UnmodifiedSince: updatedAt.Truncate(time.Second)})
+		require.Nil(t, cerr)
+		assert.Len(t, repo.DeleteLocationCalls(), 1)
+	})
+
+	t.Run("Changed between the check and the update", func(t *testing.T) {
+		_, cerr := ls.UpdateLocation(context.Background(), "lagos-park", &domain.UpdateLocationRequest{
+			Name: "Lagos Park", Version: 2, Precondition: domain.Precondition{Version: 2},
+		})
+
+		var failed *domain.PreconditionFailedError
+		require.ErrorAs(t, cerr, &failed)
+		assert.Equal(t, int64(3), failed.Current.Version)
+	})
+
+	t.Run("Stale version in the body", func(t *testing.T) {
+		_, cerr := ls.UpdateLocation(context.Background(), "lagos-park", &domain.UpdateLocationRequest{Name: "Lagos Park", Version: 1})
+		require.NotNil(t, cerr)
+		assert.Equal(t, errcode.VersionConflict, cerr.ErrorCode())
+	})
+}
+
+func TestLocationService_ListLocationsInCollection(t *testing.T) {
+	repo := &mock.LocationRepositoryMock{
+		ListLocationsFunc: func(ctx context.Context, req *domain.ListLocationsRequest) ([]domain.Location, domain.CError) {
+			if req.Collection != "hubs" {
+				return nil, domain.ErrDataNotFound
+			}
+			return []domain.Location{{Name: "Lagos Hub"}}, nil
+		},
+	}
+	ls := NewLocationService(repo, 0, 0, nil, nil, "", nil, nil, nil, "")
+
+	locations, cerr := ls.ListLocations(context.Background(), &domain.ListLocationsRequest{Collection: "hubs"})
+	require.Nil(t, cerr)
+	assert.Len(t, locations, 1)
+
+	_, cerr = ls.ListLocations(context.Background(), &domain.ListLocationsRequest{Collection: "depots"})
+	require.NotNil(t, cerr)
+	assert.Equal(t, http.StatusNotFound, cerr.Code())
+	assert.Equal(t, "collection not found", cerr.Error())
+	assert.Equal(t, errcode.CollectionNotFound, cerr.ErrorCode())
+}
+
+func TestLocationService_ExpireLocations(t *testing.T) {
+	batches := []int64{expireBatchSize, expireBatchSize, 3}
+	repo := &mock.LocationRepositoryMock{
+		ExpireLocationsFunc: func(ctx context.Context, limit int) (int64, domain.CError) {
+			expired := batches[0]
+			batches = batches[1:]
+			return expired, nil
+		},
+	}
+	ls := NewLocationService(repo, 0, 0, nil, nil, "", nil, nil, nil, "")
+
+	require.NoError(t, ls.ExpireLocations(context.Background()))
+	assert.Len(t, repo.ExpireLocationsCalls(), 3)
+	assert.Equal(t, expireBatchSize, repo.ExpireLocationsCalls()[0].Limit)
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+/**
+ * lru is a size-bounded, least recently used cache whose entries
+ * expire after a time to live. Purging it starts a new generation,
+ * so values read before the purge are not stored after it
+ */
+type lru[K comparable, V any] struct {
+	mu         sync.Mutex
+	size       int
+	ttl        time.Duration
+	entries    map[K]*list.Element
+	order      *list.List
+	generation uint64
+}
+
+type lruEntry[K comparable, V any] struct {
+	key     K
+	value   V
+	expires time.Time
+}
+
+// newLRU creates a new cache holding up to size entries for ttl each
+func newLRU[K comparable, V any](size int, ttl time.Duration) *lru[K, V] {
+	return &lru[K, V]{
+		size:    size,
+		ttl:     ttl,
+		entries: make(map[K]*list.Element, size),
+		order:   list.New(),
+	}
+}
+
+// get returns the value stored at key, unless it is missing or expired
+func (c *lru[K, V]) get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var zero V
+	element, ok := c.entries[key]
+	if !ok {
+		return zero, false
+	}
+
+	entry := element.Value.(*lruEntry[K, V])
+	if time.Now().After(entry.expires) {
+		c.order.Remove(element)
+		delete(c.entries, key)
+		return zero, false
+	}
+
+	c.order.MoveToFront(element)
+	return entry.value, true
+}
+
+// currentGeneration returns the generation to pass to set for a value about to be read
+func (c *lru[K, V]) currentGeneration() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.generation
+}
+
+// set stores value at key, evicting the least recently used entry when the cache is full.
+// The value is dropped if the cache was purged since generation
+func (c *lru[K, V]) set(generation uint64, key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if generation != c.generation {
+		return
+	}
+
+	expires := time.Now().Add(c.ttl)
+	if element, ok := c.entries[key]; ok {
+		element.Value = &lruEntry[K, V]{key, value, expires}
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&lruEntry[K, V]{key, value, expires})
+
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lruEntry[K, V]).key)
+	}
+}
+
+// purge drops every entry
+func (c *lru[K, V]) purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	c.entries = make(map[K]*list.Element, c.size)
+	c.order.Init()
+}
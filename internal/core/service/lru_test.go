@@ -0,0 +1,59 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLRU(t *testing.T) {
+	t.Run("Evicts the least recently used entry", func(t *testing.T) {
+		cache := newLRU[string, int](2, time.Minute)
+
+		cache.set(0, "a", 1)
+		cache.set(0, "b", 2)
+		cache.get("a")
+		cache.set(0, "c", 3)
+
+		_, ok := cache.get("b")
+		assert.False(t, ok)
+
+		value, ok := cache.get("a")
+		assert.True(t, ok)
+		assert.Equal(t, 1, value)
+
+		value, ok = cache.get("c")
+		assert.True(t, ok)
+		assert.Equal(t, 3, value)
+	})
+
+	t.Run("Expires entries after the ttl", func(t *testing.T) {
+		cache := newLRU[string, int](2, time.Millisecond)
+
+		cache.set(0, "a", 1)
+		time.Sleep(5 * time.Millisecond)
+
+		_, ok := cache.get("a")
+		assert.False(t, ok)
+	})
+
+	t.Run("Drops values read before a purge", func(t *testing.T) {
+		cache := newLRU[string, int](2, time.Minute)
+
+		generation := cache.currentGeneration()
+		cache.set(generation, "a", 1)
+		cache.purge()
+
+		_, ok := cache.get("a")
+		assert.False(t, ok)
+
+		cache.set(generation, "a", 1)
+		_, ok = cache.get("a")
+		assert.False(t, ok)
+
+		cache.set(cache.currentGeneration(), "a", 1)
+		_, ok = cache.get("a")
+		assert.True(t, ok)
+	})
+}
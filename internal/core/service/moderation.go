@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+
+	"leeta/internal/adapter/logger"
+	"leeta/internal/core/domain"
+	"leeta/internal/core/errcode"
+
+	"go.uber.org/zap"
+)
+
+func (ls *LocationService) ListPendingLocations(ctx context.Context, req *domain.ListPendingLocationsRequest) ([]domain.Location, domain.CError) {
+	locations, cerr := ls.repo.ListPendingLocations(ctx, req)
+	if cerr != nil {
+		if isUnavailable(cerr) {
+			return nil, cerr
+		}
+
+		logger.FromCtx(ctx).Error("Error listing pending locations", zap.Error(cerr))
+		return nil, domain.ErrInternal
+	}
+
+	return locations, nil
+}
+
+func (ls *LocationService) ApproveLocation(ctx context.Context, name string) (*domain.Location, domain.CError) {
+	return ls.reviewLocation(ctx, name, domain.LocationApproved, "")
+}
+
+func (ls *LocationService) RejectLocation(ctx context.Context, name string, req *domain.RejectLocationRequest) (*domain.Location, domain.CError) {
+	return ls.reviewLocation(ctx, name, domain.LocationRejected, req.Reason)
+}
+
+// reviewLocation moves a location waiting for review to status
+func (ls *LocationService) reviewLocation(ctx context.Context, name string, status domain.LocationStatus, reason string) (*domain.Location, domain.CError) {
+	location, cerr := ls.repo.ReviewLocation(ctx, name, status, reason)
+	ls.purgeCache()
+	if cerr != nil {
+		switch {
+		case isUnavailable(cerr):
+			return nil, cerr
+		case cerr.Code() == 404:
+			return nil, errLocationNotFound
+		case cerr.Code() == 409:
+			return nil, domain.NewCodedCError(errcode.LocationNotPending, "location is not pending review")
+		}
+
+		logger.FromCtx(ctx).Error("Error reviewing location", zap.Error(cerr))
+		return nil, domain.ErrInternal
+	}
+
+	return location, nil
+}
@@ -2,27 +2,71 @@ package service
 
 import (
 	"context"
+	"math"
+	"time"
 
+	"leeta/internal/adapter/logger"
 	"leeta/internal/core/domain"
 	"leeta/internal/core/port"
+
+	"go.uber.org/zap"
 )
 
 /**
  * PingService implements port.PingService interface
+ * and keeps the time the server started at
  */
 type PingService struct {
-	repo port.PingRepository
+	repo      port.PingRepository
+	startedAt time.Time
 }
 
-// NewAuthService creates a new auth service instance
+// NewPingService creates a new ping service instance
 func NewPingService(repo port.PingRepository) *PingService {
 	return &PingService{
 		repo,
+		time.Now(),
 	}
 }
 
-// Login gives a registered user an access token if the credentials are valid
-func (ps *PingService) Ping(ctx context.Context, ping *domain.Ping) (domain.Ping, domain.CError) {
-	_ = ps.repo.CreatePing(ctx, ping)
-	return *ping, nil
+// Ping stores a ping received by the server
+func (ps *PingService) Ping(ctx context.Context, ping *domain.Ping) (*domain.Ping, domain.CError) {
+	created, cerr := ps.repo.CreatePing(ctx, ping)
+	if cerr != nil {
+		if isUnavailable(cerr) {
+			return nil, cerr
+		}
+
+		logger.FromCtx(ctx).Error("Error storing ping", zap.Error(cerr))
+		return nil, domain.ErrInternal
+	}
+
+	return created, nil
+}
+
+// GetStats returns the uptime of the server and the ping counts and availability of the last day
+func (ps *PingService) GetStats(ctx context.Context) (*domain.PingStats, domain.CError) {
+	now := time.Now()
+
+	stats, cerr := ps.repo.GetPingStats(ctx, now.Add(-24*time.Hour), now.Add(-time.Hour))
+	if cerr != nil {
+		if isUnavailable(cerr) {
+			return nil, cerr
+		}
+
+		logger.FromCtx(ctx).Error("Error getting ping stats", zap.Error(cerr))
+		return nil, domain.ErrInternal
+	}
+
+	stats.StartedAt = ps.startedAt
+	stats.UptimeSeconds = int64(now.Sub(ps.startedAt).Seconds())
+
+	// the availability is counted from the first ping, so a new deployment is not penalized
+	// for the part of the day before it was monitored
+	if stats.FirstPingAt != nil {
+		minutes := math.Ceil(now.Sub(stats.FirstPingAt.Truncate(time.Minute)).Minutes())
+		stats.Availability = min(1, float64(stats.ActiveMinutes)/max(1, minutes))
+	}
+
+	return stats, nil
 }
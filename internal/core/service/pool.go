@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+
+	"leeta/internal/core/domain"
+)
+
+const (
+	defaultPoolWorkers   = 4
+	defaultPoolQueueSize = 16
+)
+
+/**
+ * WorkerPool bounds the number of exports running at once. Exports
+ * beyond the workers wait in a bounded queue, and are rejected once
+ * it is full, so a flood of bulk requests cannot exhaust goroutines
+ * or database connections
+ */
+type WorkerPool struct {
+	workers  chan struct{}
+	admitted chan struct{}
+}
+
+// NewWorkerPool creates a new worker pool running up to workers operations at once,
+// with up to queueSize more waiting for a worker
+func NewWorkerPool(workers, queueSize int) *WorkerPool {
+	if workers <= 0 {
+		workers = defaultPoolWorkers
+	}
+
+	if queueSize <= 0 {
+		queueSize = defaultPoolQueueSize
+	}
+
+	return &WorkerPool{
+		workers:  make(chan struct{}, workers),
+		admitted: make(chan struct{}, workers+queueSize),
+	}
+}
+
+// Do runs fn in the calling goroutine once a worker is free. It fails with domain.ErrBusy
+// when the queue is full, and with domain.ErrTimeout if ctx is done while waiting
+func (p *WorkerPool) Do(ctx context.Context, fn func()) domain.CError {
+	if !p.admit() {
+		return domain.ErrBusy
+	}
+	defer p.leave()
+
+	if cerr := p.acquire(ctx); cerr != nil {
+		return cerr
+	}
+	defer p.release()
+
+	fn()
+	return nil
+}
+
+// admit takes a place among the running and queued operations, if there is one left
+func (p *WorkerPool) admit() bool {
+	select {
+	case p.admitted <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+func (p *WorkerPool) leave() {
+	<-p.admitted
+}
+
+// acquire waits for a free worker
+func (p *WorkerPool) acquire(ctx context.Context) domain.CError {
+	select {
+	case p.workers <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return domain.ErrTimeout
+	}
+}
+
+func (p *WorkerPool) release() {
+	<-p.workers
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"leeta/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkerPool(t *testing.T) {
+	t.Run("Runs at most workers operations at once", func(t *testing.T) {
+		pool := NewWorkerPool(2, 10)
+
+		var running, peak atomic.Int32
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				cerr := pool.Do(context.Background(), func() {
+					n := running.Add(1)
+					for {
+						p := peak.Load()
+						if n <= p || peak.CompareAndSwap(p, n) {
+							break
+						}
+					}
+					time.Sleep(5 * time.Millisecond)
+					running.Add(-1)
+				})
+				assert.Nil(t, cerr)
+			}()
+		}
+
+		wg.Wait()
+		assert.Equal(t, int32(2), peak.Load())
+	})
+
+	t.Run("Rejects operations once the queue is full", func(t *testing.T) {
+		pool := NewWorkerPool(1, 1)
+		release := make(chan struct{})
+
+		// one operation runs and another waits for the worker
+		var wg sync.WaitGroup
+		started := make(chan struct{})
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			assert.Nil(t, pool.Do(context.Background(), func() {
+				close(started)
+				<-release
+			}))
+		}()
+		<-started
+		go func() {
+			defer wg.Done()
+			assert.Nil(t, pool.Do(context.Background(), func() {}))
+		}()
+
+		assert.Eventually(t, func() bool { return len(pool.admitted) == 2 }, time.Second, time.Millisecond)
+
+		cerr := pool.Do(context.Background(), func() {})
+		assert.Equal(t, domain.ErrBusy, cerr)
+
+		close(release)
+		wg.Wait()
+
+		assert.Nil(t, pool.Do(context.Background(), func() {}))
+	})
+
+	t.Run("Stops waiting when the context is done", func(t *testing.T) {
+		pool := NewWorkerPool(1, 1)
+		release := make(chan struct{})
+		defer close(release)
+
+		started := make(chan struct{})
+		go pool.Do(context.Background(), func() {
+			close(started)
+			<-release
+		})
+		<-started
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		cerr := pool.Do(ctx, func() {})
+		assert.Equal(t, domain.ErrTimeout, cerr)
+	})
+}
@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"leeta/internal/adapter/logger"
+	"leeta/internal/core/domain"
+	"leeta/internal/core/port"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// defaultHitsFlushInterval is how often the hits are written when no interval is configured
+	defaultHitsFlushInterval = 10 * time.Second
+	// defaultHitsRetention is how long the hits are kept when no retention is configured
+	defaultHitsRetention = 30 * 24 * time.Hour
+	// defaultTrendingWindow is the window of the trending locations when none is requested
+	defaultTrendingWindow = 7 * 24 * time.Hour
+	// maxPendingHits is the number of locations with hits held in memory before they are written early
+	maxPendingHits = 10000
+	// hitsBucket is the period the hits are counted by
+	hitsBucket = time.Hour
+)
+
+/**
+ * PopularityService implements port.PopularityService interface.
+ * Hits are counted in memory and written in batches by Run
+ */
+type PopularityService struct {
+	repo          port.PopularityRepository
+	flushInterval time.Duration
+	retention     time.Duration
+
+	mu      sync.Mutex
+	pending map[string]int64
+	// full asks Run to write the hits before the next interval
+	full chan struct{}
+}
+
+// NewPopularityService creates a new popularity service instance, hits are written every
+// flushInterval and kept for retention
+func NewPopularityService(repo port.PopularityRepository, flushInterval, retention time.Duration) *PopularityService {
+	if flushInterval <= 0 {
+		flushInterval = defaultHitsFlushInterval
+	}
+	if retention <= 0 {
+		retention = defaultHitsRetention
+	}
+
+	return &PopularityService{
+		repo:          repo,
+		flushInterval: flushInterval,
+		retention:     retention,
+		pending:       make(map[string]int64),
+		full:          make(chan struct{}, 1),
+	}
+}
+
+func (ps *PopularityService) RecordHits(ctx context.Context, ids ...string) {
+	if len(ids) == 0 {
+		return
+	}
+
+	ps.mu.Lock()
+	for _, id := range ids {
+		ps.pending[id]++
+	}
+	full := len(ps.pending) >= maxPendingHits
+	ps.mu.Unlock()
+
+	if full {
+		select {
+		case ps.full <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Run writes the hits counted every flush interval, or earlier once many locations have hits,
+// until ctx is done. The hits still pending are written before it returns
+func (ps *PopularityService) Run(ctx context.Context) {
+	ticker := time.NewTicker(ps.flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			flushCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), ps.flushInterval)
+			ps.flush(flushCtx)
+			cancel()
+			return
+		case <-ticker.C:
+			ps.flush(ctx)
+		case <-ps.full:
+			ps.flush(ctx)
+		}
+	}
+}
+
+// flush writes the pending hits to the current bucket. Popularity is approximate, so the hits
+// that cannot be written are dropped rather than piling up while the database is down
+func (ps *PopularityService) flush(ctx context.Context) {
+	ps.mu.Lock()
+	hits := ps.pending
+	ps.pending = make(map[string]int64, len(hits))
+	ps.mu.Unlock()
+
+	if len(hits) == 0 {
+		return
+	}
+
+	if cerr := ps.repo.RecordLocationHits(ctx, time.Now().Truncate(hitsBucket), hits); cerr != nil {
+		logger.FromCtx(ctx).Error("Error recording location hits", zap.Int("locations", len(hits)), zap.Error(cerr))
+	}
+}
+
+// PurgeHits removes the hits older than the retention
+func (ps *PopularityService) PurgeHits(ctx context.Context) error {
+	deleted, cerr := ps.repo.DeleteLocationHits(ctx, time.Now().Add(-ps.retention).Truncate(hitsBucket))
+	if cerr != nil {
+		return cerr
+	}
+
+	logger.FromCtx(ctx).Info("Purged location hits", zap.Int64("deleted", deleted))
+	return nil
+}
+
+func (ps *PopularityService) FindTrendingLocations(ctx context.Context, req *domain.TrendingLocationsRequest) ([]domain.TrendingLocation, domain.CError) {
+	window := req.Window
+	if window == 0 {
+		window = min(defaultTrendingWindow, ps.retention)
+	}
+	if window > ps.retention {
+		return nil, domain.NewBadRequestCError(fmt.Sprintf("window must not be longer than the %s hits are kept", ps.retention))
+	}
+
+	locations, cerr := ps.repo.FindTrendingLocations(ctx, time.Now().Add(-window).Truncate(hitsBucket), req)
+	if cerr != nil {
+		if isUnavailable(cerr) {
+			return nil, cerr
+		}
+
+		logger.FromCtx(ctx).Error("Error finding trending locations", zap.Error(cerr))
+		return nil, domain.ErrInternal
+	}
+
+	return locations, nil
+}
@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"leeta/internal/core/domain"
+	"leeta/internal/core/port/mock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPopularityService_RecordHits(t *testing.T) {
+	repo := &mock.PopularityRepositoryMock{
+		RecordLocationHitsFunc: func(ctx context.Context, bucket time.Time, hits map[string]int64) domain.CError {
+			return nil
+		},
+	}
+	ps := NewPopularityService(repo, time.Hour, 0)
+
+	ps.RecordHits(context.Background(), "a", "b")
+	ps.RecordHits(context.Background(), "a")
+	ps.RecordHits(context.Background())
+
+	// the hits pending on shutdown are written before Run returns
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	ps.Run(ctx)
+
+	calls := repo.RecordLocationHitsCalls()
+	require.Len(t, calls, 1)
+	assert.Equal(t, map[string]int64{"a": 2, "b": 1}, calls[0].Hits)
+	assert.Equal(t, calls[0].Bucket, calls[0].Bucket.Truncate(time.Hour))
+
+	t.Run("Nothing is written without hits", func(t *testing.T) {
+		ps.Run(ctx)
+		assert.Len(t, repo.RecordLocationHitsCalls(), 1)
+	})
+}
+
+func TestPopularityService_FindTrendingLocations(t *testing.T) {
+	repo := &mock.PopularityRepositoryMock{
+		FindTrendingLocationsFunc: func(ctx context.Context, since time.Time, req *domain.TrendingLocationsRequest) ([]domain.TrendingLocation, domain.CError) {
+			return []domain.TrendingLocation{{Location: domain.Location{Name: "Lagos Park"}, Hits: 3}}, nil
+		},
+	}
+	ps := NewPopularityService(repo, 0, 72*time.Hour)
+
+	t.Run("Window defaults to the retention", func(t *testing.T) {
+		locations, cerr := ps.FindTrendingLocations(context.Background(), &domain.TrendingLocationsRequest{})
+		require.Nil(t, cerr)
+		require.Len(t, locations, 1)
+
+		since := repo.FindTrendingLocationsCalls()[0].Since
+		assert.WithinDuration(t, time.Now().Add(-72*time.Hour), since, time.Hour)
+	})
+
+	t.Run("Window longer than the retention", func(t *testing.T) {
+		_, cerr := ps.FindTrendingLocations(context.Background(), &domain.TrendingLocationsRequest{Window: 7 * 24 * time.Hour})
+		require.NotNil(t, cerr)
+		assert.Equal(t, http.StatusBadRequest, cerr.Code())
+		assert.Len(t, repo.FindTrendingLocationsCalls(), 1)
+	})
+}
@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"leeta/internal/adapter/logger"
+	"leeta/internal/core/auth"
+	"leeta/internal/core/domain"
+	"leeta/internal/core/errcode"
+	"leeta/internal/core/port"
+
+	"go.uber.org/zap"
+)
+
+// checkQuota checks n more resources against the quota of the caller with quotas, unless no quotas are set
+func checkQuota(ctx context.Context, quotas port.QuotaService, resource domain.QuotaResource, n int) domain.CError {
+	if quotas == nil {
+		return nil
+	}
+	return quotas.CheckQuota(ctx, resource, n)
+}
+
+/**
+ * QuotaService implements port.QuotaService interface. The quotas
+ * are soft, the resources are counted before they are created, so
+ * concurrent requests of a tenant may take it slightly over
+ */
+type QuotaService struct {
+	repo         port.QuotaRepository
+	maxLocations int
+	maxWebhooks  int
+}
+
+// NewQuotaService creates a new quota service instance, maxLocations and maxWebhooks are the limits of the
+// tenants without a quota of their own, 0 for no limit
+func NewQuotaService(repo port.QuotaRepository, maxLocations, maxWebhooks int) *QuotaService {
+	return &QuotaService{
+		repo,
+		maxLocations,
+		maxWebhooks,
+	}
+}
+
+func (qs *QuotaService) CheckQuota(ctx context.Context, resource domain.QuotaResource, n int) domain.CError {
+	tenant := auth.Tenant(ctx)
+	if tenant == "" {
+		return nil
+	}
+
+	quota, cerr := qs.tenantQuota(ctx, tenant)
+	if cerr != nil {
+		return qs.checkError(ctx, cerr)
+	}
+
+	limit, _ := qs.limit(quota, resource)
+	if limit == 0 {
+		return nil
+	}
+
+	used, cerr := qs.repo.CountResources(ctx, tenant, resource)
+	if cerr != nil {
+		return qs.checkError(ctx, cerr)
+	}
+
+	if used+n > limit {
+		return domain.NewCodedCError(errcode.QuotaExceeded,
+			fmt.Sprintf("tenant %s would exceed its quota of %d %s, %d are used", tenant, limit, resource, used))
+	}
+
+	return nil
+}
+
+func (qs *QuotaService) GetQuota(ctx context.Context, tenant string) (*domain.Quota, domain.CError) {
+	quota, cerr := qs.tenantQuota(ctx, tenant)
+	if cerr != nil {
+		if isUnavailable(cerr) {
+			return nil, cerr
+		}
+
+		logger.FromCtx(ctx).Error("Error getting quota", zap.Error(cerr))
+		return nil, domain.ErrInternal
+	}
+
+	return qs.usage(ctx, quota)
+}
+
+func (qs *QuotaService) SetQuota(ctx context.Context, tenant string, req *domain.SetQuotaRequest) (*domain.Quota, domain.CError) {
+	quota, cerr := qs.repo.SetQuota(ctx, &domain.TenantQuota{
+		Tenant:       tenant,
+		MaxLocations: req.MaxLocations,
+		MaxWebhooks:  req.MaxWebhooks,
+	})
+	if cerr != nil {
+		if isUnavailable(cerr) || isViolation(cerr) {
+			return nil, cerr
+		}
+
+		logger.FromCtx(ctx).Error("Error setting quota", zap.Error(cerr))
+		return nil, domain.ErrInternal
+	}
+
+	return qs.usage(ctx, quota)
+}
+
+// tenantQuota returns the quota set for tenant, one without limits of its own when none is
+func (qs *QuotaService) tenantQuota(ctx context.Context, tenant string) (*domain.TenantQuota, domain.CError) {
+	quota, cerr := qs.repo.GetQuota(ctx, tenant)
+	if cerr != nil {
+		if cerr == domain.ErrDataNotFound {
+			return &domain.TenantQuota{Tenant: tenant}, nil
+		}
+		return nil, cerr
+	}
+
+	return quota, nil
+}
+
+// limit returns the limit of resource of quota and whether it is the default one
+func (qs *QuotaService) limit(quota *domain.TenantQuota, resource domain.QuotaResource) (int, bool) {
+	set, fallback := quota.MaxLocations, qs.maxLocations
+	if resource == domain.QuotaWebhooks {
+		set, fallback = quota.MaxWebhooks, qs.maxWebhooks
+	}
+
+	if set == nil {
+		return fallback, true
+	}
+	return *set, false
+}
+
+// usage counts the resources of the tenant of quota against its limits
+func (qs *QuotaService) usage(ctx context.Context, quota *domain.TenantQuota) (*domain.Quota, domain.CError) {
+	result := &domain.Quota{Tenant: quota.Tenant}
+	if !quota.UpdatedAt.IsZero() {
+		result.UpdatedAt = &quota.UpdatedAt
+	}
+
+	for resource, usage := range map[domain.QuotaResource]*domain.QuotaUsage{
+		domain.QuotaLocations: &result.Locations,
+		domain.QuotaWebhooks:  &result.Webhooks,
+	} {
+		used, cerr := qs.repo.CountResources(ctx, quota.Tenant, resource)
+		if cerr != nil {
+			if isUnavailable(cerr) {
+				return nil, cerr
+			}
+
+			logger.FromCtx(ctx).Error("Error counting resources", zap.String("resource", string(resource)), zap.Error(cerr))
+			return nil, domain.ErrInternal
+		}
+
+		usage.Limit, usage.Default = qs.limit(quota, resource)
+		usage.Used = used
+	}
+
+	return result, nil
+}
+
+// checkError is the error of a quota check failing, the check is skipped unless the database is unavailable,
+// as the resource could not be created either
+func (qs *QuotaService) checkError(ctx context.Context, cerr domain.CError) domain.CError {
+	if isUnavailable(cerr) {
+		return cerr
+	}
+
+	logger.FromCtx(ctx).Error("Error checking quota, skipping it", zap.Error(cerr))
+	return nil
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"leeta/internal/core/auth"
+	"leeta/internal/core/domain"
+	"leeta/internal/core/errcode"
+	"leeta/internal/core/port/mock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuotaService_CheckQuota(t *testing.T) {
+	maxLocations, unlimited := 3, 0
+	repo := &mock.QuotaRepositoryMock{
+		GetQuotaFunc: func(ctx context.Context, tenant string) (*domain.TenantQuota, domain.CError) {
+			switch tenant {
+			case "acme":
+				return &domain.TenantQuota{Tenant: tenant, MaxLocations: &maxLocations}, nil
+			case "globex":
+				return &domain.TenantQuota{Tenant: tenant, MaxLocations: &unlimited}, nil
+			case "down":
+				return nil, domain.ErrServiceUnavailable
+			}
+			return nil, domain.ErrDataNotFound
+		},
+		CountResourcesFunc: func(ctx context.Context, tenant string, resource domain.QuotaResource) (int, domain.CError) {
+			return 2, nil
+		},
+	}
+	qs := NewQuotaService(repo, 10, 2)
+
+	withTenant := func(tenant string) context.Context {
+		return auth.WithPrincipal(context.Background(), &auth.Principal{User: "ada", Tenant: tenant})
+	}
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		resource domain.QuotaResource
+		n        int
+		code     errcode.Code
+		status   int
+	}{
+		{"Within the quota of the tenant", withTenant("acme"), domain.QuotaLocations, 1, "", 0},
+		{"Over the quota of the tenant", withTenant("acme"), domain.QuotaLocations, 2, errcode.QuotaExceeded, 403},
+		{"Over the default quota", withTenant("acme"), domain.QuotaWebhooks, 1, errcode.QuotaExceeded, 403},
+		{"Within the default quota", withTenant("initech"), domain.QuotaLocations, 8, "", 0},
+		{"Quota lifted", withTenant("globex"), domain.QuotaLocations, 100, "", 0},
+		{"No tenant", context.Background(), domain.QuotaWebhooks, 100, "", 0},
+		{"Database unavailable", withTenant("down"), domain.QuotaLocations, 1, errcode.ServiceUnavailable, 503},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cerr := qs.CheckQuota(tt.ctx, tt.resource, tt.n)
+			if tt.status == 0 {
+				assert.Nil(t, cerr)
+				return
+			}
+			require.NotNil(t, cerr)
+			assert.Equal(t, tt.code, cerr.ErrorCode())
+			assert.Equal(t, tt.status, cerr.Code())
+		})
+	}
+
+	t.Run("Message names the limit", func(t *testing.T) {
+		cerr := qs.CheckQuota(withTenant("acme"), domain.QuotaLocations, 2)
+		require.NotNil(t, cerr)
+		assert.Equal(t, "tenant acme would exceed its quota of 3 locations, 2 are used", cerr.Error())
+	})
+}
+
+func TestQuotaService_SetQuota(t *testing.T) {
+	now := time.Now()
+	repo := &mock.QuotaRepositoryMock{
+		SetQuotaFunc: func(ctx context.Context, quota *domain.TenantQuota) (*domain.TenantQuota, domain.CError) {
+			set := *quota
+			set.UpdatedAt = now
+			return &set, nil
+		},
+		CountResourcesFunc: func(ctx context.Context, tenant string, resource domain.QuotaResource) (int, domain.CError) {
+			if resource == domain.QuotaWebhooks {
+				return 1, nil
+			}
+			return 40, nil
+		},
+	}
+
+	maxLocations := 50
+	quota, cerr := NewQuotaService(repo, 10, 2).SetQuota(context.Background(), "acme", &domain.SetQuotaRequest{MaxLocations: &maxLocations})
+	require.Nil(t, cerr)
+	assert.Equal(t, &domain.Quota{
+		Tenant:    "acme",
+		Locations: domain.QuotaUsage{Limit: 50, Used: 40},
+		Webhooks:  domain.QuotaUsage{Limit: 2, Used: 1, Default: true},
+		UpdatedAt: &now,
+	}, quota)
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+
+	"leeta/internal/adapter/logger"
+	"leeta/internal/core/domain"
+	"leeta/internal/core/port"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// defaultSearchLimit is the number of locations a search returns when the request leaves it out
+	defaultSearchLimit = 20
+	// reindexBatchSize is the number of locations sent to the search engine at once by a reindex
+	reindexBatchSize = 500
+)
+
+/**
+ * SearchService implements port.SearchService and port.EventPublisher
+ * interfaces. The index is kept up to date with the location events
+ * the outbox relay publishes, and filled again by Reindex
+ */
+type SearchService struct {
+	index     port.SearchIndex
+	locations port.LocationService
+}
+
+// NewSearchService creates a new search service instance. Searches are not available when index is nil
+func NewSearchService(index port.SearchIndex, locations port.LocationService) *SearchService {
+	return &SearchService{
+		index,
+		locations,
+	}
+}
+
+func (ss *SearchService) SearchLocations(ctx context.Context, req *domain.SearchLocationsRequest) (*domain.LocationSearchResult, domain.CError) {
+	if ss.index == nil {
+		return nil, domain.ErrSearchDisabled
+	}
+
+	query := *req
+	if query.Limit == 0 {
+		query.Limit = defaultSearchLimit
+	}
+
+	result, err := ss.index.SearchLocations(ctx, &query)
+	if err != nil {
+		logger.FromCtx(ctx).Error("Error searching locations", zap.Error(err))
+		return nil, domain.ErrServiceUnavailable
+	}
+
+	return result, nil
+}
+
+func (ss *SearchService) Reindex(ctx context.Context) error {
+	if ss.index == nil {
+		return domain.ErrSearchDisabled
+	}
+
+	// the request lists the expired locations too, as leaving them out there would leave out the ones not
+	// valid yet as well, which the searches filter out instead
+	batch := make([]domain.Location, 0, reindexBatchSize)
+	var indexErr error
+	cerr := ss.locations.ExportLocations(ctx, &domain.ListLocationsRequest{IncludeExpired: true}, func(location *domain.Location) error {
+		if location.Expired {
+			return nil
+		}
+
+		batch = append(batch, *location)
+		if len(batch) < reindexBatchSize {
+			return nil
+		}
+
+		indexErr = ss.index.IndexLocations(ctx, batch)
+		batch = batch[:0]
+		return indexErr
+	})
+	if indexErr != nil {
+		return indexErr
+	}
+	if cerr != nil {
+		return cerr
+	}
+
+	if len(batch) > 0 {
+		return ss.index.IndexLocations(ctx, batch)
+	}
+
+	return nil
+}
+
+func (ss *SearchService) Publish(ctx context.Context, event *domain.Event) error {
+	if ss.index == nil {
+		return nil
+	}
+
+	var location domain.Location
+	switch event.Type {
+	case domain.EventLocationCreated, domain.EventLocationUpdated, domain.EventLocationDeleted, domain.EventLocationExpired:
+		if err := json.Unmarshal(event.Payload, &location); err != nil {
+			// retrying would not help, the payload stays the same
+			logger.FromCtx(ctx).Error("Error decoding location event", zap.Int64("event_id", event.ID), zap.Error(err))
+			return nil
+		}
+	default:
+		return nil
+	}
+
+	// expired locations are no longer listed, so they are no longer found either
+	if event.Type == domain.EventLocationDeleted || event.Type == domain.EventLocationExpired {
+		return ss.index.DeleteLocation(ctx, location.ID)
+	}
+
+	return ss.index.IndexLocations(ctx, []domain.Location{location})
+}
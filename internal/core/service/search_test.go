@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"leeta/internal/core/domain"
+	"leeta/internal/core/port/mock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchService_SearchLocations(t *testing.T) {
+	t.Run("Default limit", func(t *testing.T) {
+		index := &mock.SearchIndexMock{
+			SearchLocationsFunc: func(ctx context.Context, req *domain.SearchLocationsRequest) (*domain.LocationSearchResult, error) {
+				return &domain.LocationSearchResult{Locations: []domain.Location{{ID: "1"}}, Total: 1}, nil
+			},
+		}
+
+		result, cerr := NewSearchService(index, &mock.LocationServiceMock{}).SearchLocations(context.Background(), &domain.SearchLocationsRequest{Query: "lekki"})
+		require.Nil(t, cerr)
+		assert.Equal(t, 1, result.Total)
+		assert.Equal(t, defaultSearchLimit, index.SearchLocationsCalls()[0].Req.Limit)
+	})
+
+	t.Run("Search engine failing", func(t *testing.T) {
+		index := &mock.SearchIndexMock{
+			SearchLocationsFunc: func(ctx context.Context, req *domain.SearchLocationsRequest) (*domain.LocationSearchResult, error) {
+				return nil, errors.New("connection refused")
+			},
+		}
+
+		_, cerr := NewSearchService(index, &mock.LocationServiceMock{}).SearchLocations(context.Background(), &domain.SearchLocationsRequest{Query: "lekki"})
+		assert.Equal(t, domain.ErrServiceUnavailable, cerr)
+	})
+
+	t.Run("No search engine", func(t *testing.T) {
+		_, cerr := NewSearchService(nil, &mock.LocationServiceMock{}).SearchLocations(context.Background(), &domain.SearchLocationsRequest{Query: "lekki"})
+		assert.Equal(t, domain.ErrSearchDisabled, cerr)
+	})
+}
+
+func TestSearchService_Reindex(t *testing.T) {
+	locations := &mock.LocationServiceMock{
+		ExportLocationsFunc: func(ctx context.Context, req *domain.ListLocationsRequest, fn func(location *domain.Location) error) domain.CError {
+			for i := 0; i < reindexBatchSize+2; i++ {
+				location := domain.Location{ID: "id", Expired: i == 0}
+				if err := fn(&location); err != nil {
+					return domain.NewInternalCError(err.Error())
+				}
+			}
+			return nil
+		},
+	}
+
+	var sizes []int
+	index := &mock.SearchIndexMock{
+		IndexLocationsFunc: func(ctx context.Context, locations []domain.Location) error {
+			sizes = append(sizes, len(locations))
+			return nil
+		},
+	}
+
+	require.NoError(t, NewSearchService(index, locations).Reindex(context.Background()))
+	// the expired location is left out
+	assert.Equal(t, []int{reindexBatchSize, 1}, sizes)
+}
+
+func TestSearchService_Publish(t *testing.T) {
+	index := &mock.SearchIndexMock{
+		IndexLocationsFunc: func(ctx context.Context, locations []domain.Location) error {
+			return nil
+		},
+		DeleteLocationFunc: func(ctx context.Context, id string) error {
+			return nil
+		},
+	}
+	svc := NewSearchService(index, &mock.LocationServiceMock{})
+	ctx := context.Background()
+	payload := []byte(`{"id":"1","name":"Lekki Depot"}`)
+
+	require.NoError(t, svc.Publish(ctx, &domain.Event{Type: domain.EventLocationCreated, Payload: payload}))
+	require.NoError(t, svc.Publish(ctx, &domain.Event{Type: domain.EventLocationUpdated, Payload: payload}))
+	require.NoError(t, svc.Publish(ctx, &domain.Event{Type: domain.EventLocationExpired, Payload: payload}))
+	require.NoError(t, svc.Publish(ctx, &domain.Event{Type: domain.EventLocationDeleted, Payload: []byte(`{`)}))
+
+	require.Len(t, index.IndexLocationsCalls(), 2)
+	assert.Equal(t, "Lekki Depot", index.IndexLocationsCalls()[1].Locations[0].Name)
+	require.Len(t, index.DeleteLocationCalls(), 1)
+	assert.Equal(t, "1", index.DeleteLocationCalls()[0].ID)
+}
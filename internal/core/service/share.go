@@ -0,0 +1,236 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"leeta/internal/adapter/logger"
+	"leeta/internal/core/auth"
+	"leeta/internal/core/domain"
+	"leeta/internal/core/errcode"
+	"leeta/internal/core/port"
+
+	"go.uber.org/zap"
+)
+
+// sharedPath is the path of the route serving the shared locations, the tokens are appended to it
+const sharedPath = "/v1/shared/"
+
+var errShareNotFound = domain.NewCodedCError(errcode.ShareNotFound, "share link not found, expired or revoked")
+
+/**
+ * ShareService implements port.ShareService interface. A link
+ * carries the id of its share and its expiry, signed, so forged
+ * and expired links are refused without a query, and the share
+ * is then looked up to refuse the revoked ones
+ */
+type ShareService struct {
+	repo      port.ShareRepository
+	locations port.LocationRepository
+	secret    []byte
+	baseURL   string
+	ttl       time.Duration
+	maxTTL    time.Duration
+}
+
+// NewShareService creates a new share service instance. The links are signed with secret, they are not
+// available when it is empty, and point to baseURL, the public URL of the API. They are valid for ttl
+// unless the request asks for another lifetime, up to maxTTL
+func NewShareService(repo port.ShareRepository, locations port.LocationRepository, secret, baseURL string, ttl, maxTTL time.Duration) *ShareService {
+	return &ShareService{
+		repo,
+		locations,
+		[]byte(secret),
+		strings.TrimSuffix(baseURL, "/"),
+		ttl,
+		maxTTL,
+	}
+}
+
+func (ss *ShareService) ShareLocation(ctx context.Context, name string, req *domain.ShareLocationRequest) (*domain.LocationShare, domain.CError) {
+	if len(ss.secret) == 0 {
+		return nil, domain.ErrSharesDisabled
+	}
+
+	ttl := ss.ttl
+	if req.ExpiresIn > 0 {
+		ttl = time.Duration(req.ExpiresIn) * time.Second
+	}
+	if ttl > ss.maxTTL {
+		return nil, domain.NewBadRequestCError(fmt.Sprintf("expires_in must be at most %d seconds", int(ss.maxTTL.Seconds())))
+	}
+
+	location, cerr := ss.location(ctx, name)
+	if cerr != nil {
+		return nil, cerr
+	}
+
+	// the expiry is signed in seconds, so it is stored in seconds too
+	share, cerr := ss.repo.CreateShare(ctx, &domain.LocationShare{
+		LocationID: location.ID,
+		CreatedBy:  auth.User(ctx),
+		ExpiresAt:  time.Now().Add(ttl).Truncate(time.Second),
+	})
+	if cerr != nil {
+		if isUnavailable(cerr) {
+			return nil, cerr
+		}
+
+		logger.FromCtx(ctx).Error("Error creating share", zap.Error(cerr))
+		return nil, domain.ErrInternal
+	}
+
+	share.URL = ss.url(share)
+	return share, nil
+}
+
+func (ss *ShareService) ListShares(ctx context.Context, name string) ([]domain.LocationShare, domain.CError) {
+	location, cerr := ss.location(ctx, name)
+	if cerr != nil {
+		return nil, cerr
+	}
+
+	shares, cerr := ss.repo.ListShares(ctx, location.ID)
+	if cerr != nil {
+		if isUnavailable(cerr) {
+			return nil, cerr
+		}
+
+		logger.FromCtx(ctx).Error("Error listing shares", zap.Error(cerr))
+		return nil, domain.ErrInternal
+	}
+
+	for i := range shares {
+		shares[i].URL = ss.url(&shares[i])
+	}
+	return shares, nil
+}
+
+func (ss *ShareService) RevokeShare(ctx context.Context, name, id string) domain.CError {
+	location, cerr := ss.location(ctx, name)
+	if cerr != nil {
+		return cerr
+	}
+
+	if cerr := ss.repo.RevokeShare(ctx, location.ID, id); cerr != nil {
+		switch {
+		case isUnavailable(cerr):
+			return cerr
+		case cerr.Code() == 404:
+			return errShareNotFound
+		}
+
+		logger.FromCtx(ctx).Error("Error revoking share", zap.Error(cerr))
+		return domain.ErrInternal
+	}
+
+	return nil
+}
+
+func (ss *ShareService) GetSharedLocation(ctx context.Context, token string) (*domain.Location, domain.CError) {
+	if len(ss.secret) == 0 {
+		return nil, domain.ErrSharesDisabled
+	}
+
+	id, ok := ss.verify(token, time.Now())
+	if !ok {
+		return nil, errShareNotFound
+	}
+
+	// the link is signed, so its share is read whichever tenant it belongs to
+	share, cerr := ss.repo.GetShare(auth.WithAllTenants(ctx), id)
+	if cerr != nil {
+		if cerr.Code() == 404 {
+			return nil, errShareNotFound
+		}
+		if isUnavailable(cerr) {
+			return nil, cerr
+		}
+
+		logger.FromCtx(ctx).Error("Error getting share", zap.Error(cerr))
+		return nil, domain.ErrInternal
+	}
+	if share.RevokedAt != nil || !share.ExpiresAt.After(time.Now()) {
+		return nil, errShareNotFound
+	}
+
+	// the location is read as the caller that shared it, so that it is found within the locations of its tenant
+	ctx = auth.WithPrincipal(ctx, &auth.Principal{User: share.CreatedBy, Tenant: share.Tenant})
+	location, cerr := ss.locations.GetLocationByID(ctx, share.LocationID)
+	if cerr != nil {
+		if cerr.Code() == 404 {
+			return nil, errShareNotFound
+		}
+		if isUnavailable(cerr) {
+			return nil, cerr
+		}
+
+		logger.FromCtx(ctx).Error("Error getting shared location", zap.Error(cerr))
+		return nil, domain.ErrInternal
+	}
+
+	return location, nil
+}
+
+// location returns the location shared by name
+func (ss *ShareService) location(ctx context.Context, name string) (*domain.Location, domain.CError) {
+	location, cerr := ss.locations.GetLocationByName(ctx, name)
+	if cerr != nil {
+		if cerr.Code() == 404 {
+			return nil, errLocationNotFound
+		}
+		if isUnavailable(cerr) {
+			return nil, cerr
+		}
+
+		logger.FromCtx(ctx).Error("Error getting location", zap.Error(cerr))
+		return nil, domain.ErrInternal
+	}
+
+	return location, nil
+}
+
+// url returns the link of share
+func (ss *ShareService) url(share *domain.LocationShare) string {
+	return ss.baseURL + sharedPath + ss.token(share.ID, share.ExpiresAt)
+}
+
+// token returns the token of the share with id expiring at expiresAt, the id and the expiry in unix seconds
+// followed by their signature
+func (ss *ShareService) token(id string, expiresAt time.Time) string {
+	payload := id + "." + strconv.FormatInt(expiresAt.Unix(), 10)
+	return payload + "." + base64.RawURLEncoding.EncodeToString(ss.sign(payload))
+}
+
+// verify checks the signature and the expiry of token at now and returns the id of its share
+func (ss *ShareService) verify(token string, now time.Time) (string, bool) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return "", false
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || !hmac.Equal(signature, ss.sign(parts[0]+"."+parts[1])) {
+		return "", false
+	}
+
+	expiresAt, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil || now.Unix() >= expiresAt {
+		return "", false
+	}
+
+	return parts[0], true
+}
+
+// sign returns the HMAC-SHA256 of payload with the secret of the links
+func (ss *ShareService) sign(payload string) []byte {
+	mac := hmac.New(sha256.New, ss.secret)
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"leeta/internal/core/auth"
+	"leeta/internal/core/domain"
+	"leeta/internal/core/errcode"
+	"leeta/internal/core/port/mock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShareService(t *testing.T) {
+	shares := map[string]*domain.LocationShare{}
+	repo := &mock.ShareRepositoryMock{
+		CreateShareFunc: func(ctx context.Context, share *domain.LocationShare) (*domain.LocationShare, domain.CError) {
+			created := *share
+			created.ID = "share-1"
+			created.Tenant = auth.Tenant(ctx)
+			shares[created.ID] = &created
+			return &created, nil
+		},
+		GetShareFunc: func(ctx context.Context, id string) (*domain.LocationShare, domain.CError) {
+			share, ok := shares[id]
+			if !ok {
+				return nil, domain.ErrDataNotFound
+			}
+			return share, nil
+		},
+		RevokeShareFunc: func(ctx context.Context, locationID, id string) domain.CError {
+			share, ok := shares[id]
+			if !ok || share.LocationID != locationID || share.RevokedAt != nil {
+				return domain.ErrDataNotFound
+			}
+			now := time.Now()
+			share.RevokedAt = &now
+			return nil
+		},
+	}
+	locations := &mock.LocationRepositoryMock{
+		GetLocationByNameFunc: func(ctx context.Context, name string) (*domain.Location, domain.CError) {
+			if name != "lekki-depot" {
+				return nil, domain.ErrDataNotFound
+			}
+			return &domain.Location{ID: "location-1", Name: "Lekki Depot"}, nil
+		},
+		GetLocationByIDFunc: func(ctx context.Context, id string) (*domain.Location, domain.CError) {
+			return &domain.Location{ID: id, Name: "Lekki Depot", Status: domain.LocationPending}, nil
+		},
+	}
+	ss := NewShareService(repo, locations, "secret", "https://api.example.com/", time.Hour, 24*time.Hour)
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{User: "ada", Tenant: "acme"})
+
+	share, cerr := ss.ShareLocation(ctx, "lekki-depot", &domain.ShareLocationRequest{})
+	require.Nil(t, cerr)
+	assert.Equal(t, "location-1", share.LocationID)
+	assert.Equal(t, "ada", share.CreatedBy)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), share.ExpiresAt, time.Minute)
+	require.True(t, strings.HasPrefix(share.URL, "https://api.example.com/v1/shared/"))
+	token := strings.TrimPrefix(share.URL, "https://api.example.com/v1/shared/")
+
+	t.Run("Shared location", func(t *testing.T) {
+		location, cerr := ss.GetSharedLocation(context.Background(), token)
+		require.Nil(t, cerr)
+		assert.Equal(t, domain.LocationPending, location.Status)
+
+		// the share is read whichever tenant it belongs to, the location for the tenant of the caller that shared it
+		shareCalls := repo.GetShareCalls()
+		assert.True(t, auth.AllTenants(shareCalls[len(shareCalls)-1].Ctx))
+		calls := locations.GetLocationByIDCalls()
+		assert.Equal(t, "acme", auth.Tenant(calls[len(calls)-1].Ctx))
+		assert.False(t, auth.AllTenants(calls[len(calls)-1].Ctx))
+	})
+
+	t.Run("Refused links", func(t *testing.T) {
+		for name, token := range map[string]string{
+			"Malformed":     "share-1",
+			"Forged":        ss.token("share-2", time.Now().Add(time.Hour)) + "x",
+			"Other expiry":  strings.Replace(token, ".", ".1", 1),
+			"Expired":       ss.token("share-1", time.Now().Add(-time.Second)),
+			"Unknown share": ss.token("share-2", time.Now().Add(time.Hour)),
+		} {
+			_, cerr := ss.GetSharedLocation(context.Background(), token)
+			require.NotNil(t, cerr, name)
+			assert.Equal(t, errcode.ShareNotFound, cerr.ErrorCode(), name)
+		}
+	})
+
+	t.Run("Lifetime over the bound", func(t *testing.T) {
+		_, cerr := ss.ShareLocation(ctx, "lekki-depot", &domain.ShareLocationRequest{ExpiresIn: 48 * 3600})
+		require.NotNil(t, cerr)
+		assert.Equal(t, 400, cerr.Code())
+	})
+
+	t.Run("Revoked link", func(t *testing.T) {
+		require.Nil(t, ss.RevokeShare(ctx, "lekki-depot", share.ID))
+
+		_, cerr := ss.GetSharedLocation(context.Background(), token)
+		require.NotNil(t, cerr)
+		assert.Equal(t, errcode.ShareNotFound, cerr.ErrorCode())
+
+		cerr = ss.RevokeShare(ctx, "lekki-depot", share.ID)
+		require.NotNil(t, cerr)
+		assert.Equal(t, errcode.ShareNotFound, cerr.ErrorCode())
+	})
+
+	t.Run("No signing secret", func(t *testing.T) {
+		ss := NewShareService(repo, locations, "", "", time.Hour, 24*time.Hour)
+
+		_, cerr := ss.ShareLocation(ctx, "lekki-depot", &domain.ShareLocationRequest{})
+		assert.Equal(t, domain.ErrSharesDisabled, cerr)
+		_, cerr = ss.GetSharedLocation(context.Background(), token)
+		assert.Equal(t, domain.ErrSharesDisabled, cerr)
+	})
+}
@@ -0,0 +1,201 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"leeta/internal/adapter/logger"
+	"leeta/internal/core/auth"
+	"leeta/internal/core/domain"
+	"leeta/internal/core/errcode"
+	"leeta/internal/core/geo"
+	"leeta/internal/core/port"
+
+	"go.uber.org/zap"
+)
+
+// matchQueueSize is the number of matches waiting to be delivered before new ones are dropped
+const matchQueueSize = 1000
+
+var errSubscriptionNotFound = domain.NewCodedCError(errcode.SubscriptionNotFound, "subscription not found")
+
+// matchDelivery is a match waiting to be delivered to the receivers of its subscription
+type matchDelivery struct {
+	ctx          context.Context
+	subscription domain.Subscription
+	match        *domain.ProximityMatch
+}
+
+/**
+ * SubscriptionService implements port.SubscriptionService and
+ * port.EventPublisher interfaces. Reported positions are matched
+ * as they come in and created locations as the outbox relay
+ * publishes them, the matches are queued and delivered by Run
+ */
+type SubscriptionService struct {
+	repo       port.SubscriptionRepository
+	quotas     port.QuotaService
+	deliveries []port.MatchDelivery
+	queue      chan matchDelivery
+}
+
+// NewSubscriptionService creates a new subscription service instance, every match is delivered
+// with each of deliveries. The subscriptions with a webhook are checked against the quotas of the
+// tenants with quotas, or not at all if it is nil
+func NewSubscriptionService(repo port.SubscriptionRepository, quotas port.QuotaService, deliveries ...port.MatchDelivery) *SubscriptionService {
+	return &SubscriptionService{
+		repo,
+		quotas,
+		deliveries,
+		make(chan matchDelivery, matchQueueSize),
+	}
+}
+
+func (ss *SubscriptionService) CreateSubscription(ctx context.Context, req *domain.CreateSubscriptionRequest) (*domain.Subscription, domain.CError) {
+	if req.WebhookURL != "" {
+		if cerr := checkQuota(ctx, ss.quotas, domain.QuotaWebhooks, 1); cerr != nil {
+			return nil, cerr
+		}
+	}
+
+	subscription, cerr := ss.repo.CreateSubscription(ctx, &domain.Subscription{
+		Target:     req.Target,
+		Latitude:   req.Latitude,
+		Longitude:  req.Longitude,
+		Radius:     req.Radius,
+		Tenant:     auth.Tenant(ctx),
+		Kind:       req.Kind,
+		WebhookURL: req.WebhookURL,
+	})
+	if cerr != nil {
+		if isUnavailable(cerr) || isViolation(cerr) {
+			return nil, cerr
+		}
+
+		logger.FromCtx(ctx).Error("Error creating subscription", zap.Error(cerr))
+		return nil, domain.ErrInternal
+	}
+
+	return subscription, nil
+}
+
+func (ss *SubscriptionService) GetSubscription(ctx context.Context, id string) (*domain.Subscription, domain.CError) {
+	subscription, cerr := ss.repo.GetSubscription(ctx, id)
+	if cerr != nil {
+		if isUnavailable(cerr) {
+			return nil, cerr
+		}
+		if cerr.Code() == 404 {
+			return nil, errSubscriptionNotFound
+		}
+
+		logger.FromCtx(ctx).Error("Error getting subscription", zap.Error(cerr))
+		return nil, domain.ErrInternal
+	}
+
+	return subscription, nil
+}
+
+func (ss *SubscriptionService) DeleteSubscription(ctx context.Context, id string) domain.CError {
+	if cerr := ss.repo.DeleteSubscription(ctx, id); cerr != nil {
+		if isUnavailable(cerr) {
+			return cerr
+		}
+		if cerr.Code() == 404 {
+			return errSubscriptionNotFound
+		}
+
+		logger.FromCtx(ctx).Error("Error deleting subscription", zap.Error(cerr))
+		return domain.ErrInternal
+	}
+
+	return nil
+}
+
+func (ss *SubscriptionService) MatchEntity(ctx context.Context, position, previous *domain.EntityPosition) {
+	point := domain.Point{Latitude: position.Latitude, Longitude: position.Longitude}
+	var from *domain.Point
+	if previous != nil {
+		from = &domain.Point{Latitude: previous.Latitude, Longitude: previous.Longitude}
+	}
+
+	// an entity only matches the subscriptions of the tenant of the caller reporting its position
+	subscriptions, cerr := ss.repo.MatchSubscriptions(ctx, auth.Tenant(ctx), domain.SubscriptionTargetEntities, position.Kind, point, from)
+	if cerr != nil {
+		// the position is recorded all the same, only its matches are lost
+		logger.FromCtx(ctx).Error("Error matching entity position", zap.String("entity_id", position.EntityID), zap.Error(cerr))
+		return
+	}
+
+	for _, subscription := range subscriptions {
+		ss.enqueue(ctx, subscription, &domain.ProximityMatch{
+			SubscriptionID: subscription.ID,
+			Entity:         position,
+			Distance:       geo.Haversine(subscription.Latitude, subscription.Longitude, position.Latitude, position.Longitude),
+			MatchedAt:      time.Now(),
+		})
+	}
+}
+
+// Publish matches the location of a location.created event against the subscriptions of its tenant, it
+// appeared where it was registered or approved. The other events are ignored
+func (ss *SubscriptionService) Publish(ctx context.Context, event *domain.Event) error {
+	if event.Type != domain.EventLocationCreated {
+		return nil
+	}
+
+	var location domain.Location
+	if err := json.Unmarshal(event.Payload, &location); err != nil {
+		// retrying would not help, the payload stays the same
+		logger.FromCtx(ctx).Error("Error decoding location event", zap.Int64("event_id", event.ID), zap.Error(err))
+		return nil
+	}
+
+	point := domain.Point{Latitude: location.Latitude, Longitude: location.Longitude}
+	subscriptions, cerr := ss.repo.MatchSubscriptions(ctx, event.Tenant, domain.SubscriptionTargetLocations, location.Category, point, nil)
+	if cerr != nil {
+		return cerr
+	}
+
+	for _, subscription := range subscriptions {
+		ss.enqueue(ctx, subscription, &domain.ProximityMatch{
+			SubscriptionID: subscription.ID,
+			Location:       &location,
+			Distance:       geo.Haversine(subscription.Latitude, subscription.Longitude, location.Latitude, location.Longitude),
+			MatchedAt:      time.Now(),
+		})
+	}
+
+	return nil
+}
+
+// enqueue queues match for delivery, dropping it when the deliveries are backed up
+func (ss *SubscriptionService) enqueue(ctx context.Context, subscription domain.Subscription, match *domain.ProximityMatch) {
+	// the delivery outlives the operation that matched, so it only keeps its logger
+	ctx = logger.WithCtx(context.Background(), logger.FromCtx(ctx))
+
+	select {
+	case ss.queue <- matchDelivery{ctx, subscription, match}:
+	default:
+		logger.FromCtx(ctx).Warn("Dropping proximity match, the deliveries are backed up",
+			zap.String("subscription_id", subscription.ID))
+	}
+}
+
+// Run delivers the queued matches until ctx is done
+func (ss *SubscriptionService) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case d := <-ss.queue:
+			for _, delivery := range ss.deliveries {
+				if err := delivery.Deliver(ctx, &d.subscription, d.match); err != nil {
+					logger.FromCtx(d.ctx).Error("Error delivering proximity match",
+						zap.String("subscription_id", d.subscription.ID), zap.Error(err))
+				}
+			}
+		}
+	}
+}
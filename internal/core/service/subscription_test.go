@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"leeta/internal/core/auth"
+	"leeta/internal/core/domain"
+	"leeta/internal/core/port/mock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubscriptionService_MatchEntity(t *testing.T) {
+	repo := &mock.SubscriptionRepositoryMock{
+		MatchSubscriptionsFunc: func(ctx context.Context, tenant string, target domain.SubscriptionTarget, kind string, point domain.Point, previous *domain.Point) ([]domain.Subscription, domain.CError) {
+			return []domain.Subscription{{ID: "sub-1", Latitude: 6.5244, Longitude: 3.3792, Radius: 500}}, nil
+		},
+	}
+	delivery := &mock.MatchDeliveryMock{
+		DeliverFunc: func(ctx context.Context, subscription *domain.Subscription, match *domain.ProximityMatch) error {
+			return nil
+		},
+	}
+	ss := NewSubscriptionService(repo, nil, delivery)
+
+	position := &domain.EntityPosition{EntityID: "courier-1", Kind: "courier", Latitude: 6.5250, Longitude: 3.3790}
+	acme := auth.WithPrincipal(context.Background(), &auth.Principal{User: "ada", Tenant: "acme"})
+	ss.MatchEntity(acme, position, &domain.EntityPosition{Latitude: 6.6018, Longitude: 3.3515})
+
+	call := repo.MatchSubscriptionsCalls()[0]
+	assert.Equal(t, "acme", call.Tenant)
+	assert.Equal(t, domain.SubscriptionTargetEntities, call.Target)
+	assert.Equal(t, "courier", call.Kind)
+	assert.Equal(t, &domain.Point{Latitude: 6.6018, Longitude: 3.3515}, call.Previous)
+
+	// the queued matches are delivered by Run
+	assert.Empty(t, delivery.DeliverCalls())
+	ctx, cancel := context.WithCancel(context.Background())
+	go ss.Run(ctx)
+	defer cancel()
+
+	require.Eventually(t, func() bool { return len(delivery.DeliverCalls()) == 1 }, time.Second, 10*time.Millisecond)
+	match := delivery.DeliverCalls()[0].Match
+	assert.Equal(t, "sub-1", match.SubscriptionID)
+	assert.Equal(t, position, match.Entity)
+	assert.InDelta(t, 70, match.Distance, 5)
+}
+
+func TestSubscriptionService_Publish(t *testing.T) {
+	repo := &mock.SubscriptionRepositoryMock{
+		MatchSubscriptionsFunc: func(ctx context.Context, tenant string, target domain.SubscriptionTarget, kind string, point domain.Point, previous *domain.Point) ([]domain.Subscription, domain.CError) {
+			if kind == "park" {
+				return nil, domain.ErrTimeout
+			}
+			return []domain.Subscription{{ID: "sub-1"}}, nil
+		},
+	}
+	ss := NewSubscriptionService(repo, nil)
+
+	event := func(eventType domain.EventType, location domain.Location) *domain.Event {
+		payload, err := json.Marshal(location)
+		require.NoError(t, err)
+		return &domain.Event{ID: 1, Type: eventType, Payload: payload, Tenant: "acme"}
+	}
+
+	t.Run("Created locations are matched", func(t *testing.T) {
+		err := ss.Publish(context.Background(), event(domain.EventLocationCreated, domain.Location{
+			Name: "Lagos Mall", Latitude: 6.52, Longitude: 3.37, Category: "mall",
+		}))
+		require.NoError(t, err)
+
+		call := repo.MatchSubscriptionsCalls()[0]
+		assert.Equal(t, "acme", call.Tenant)
+		assert.Equal(t, domain.SubscriptionTargetLocations, call.Target)
+		assert.Equal(t, "mall", call.Kind)
+		assert.Nil(t, call.Previous)
+		assert.Len(t, ss.queue, 1)
+	})
+
+	t.Run("Other events are ignored", func(t *testing.T) {
+		err := ss.Publish(context.Background(), event(domain.EventLocationUpdated, domain.Location{Name: "Lagos Mall"}))
+		require.NoError(t, err)
+		assert.Len(t, repo.MatchSubscriptionsCalls(), 1)
+	})
+
+	t.Run("Failed matches are retried", func(t *testing.T) {
+		err := ss.Publish(context.Background(), event(domain.EventLocationCreated, domain.Location{Name: "Lagos Park", Category: "park"}))
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,408 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"time"
+
+	"leeta/internal/adapter/logger"
+	"leeta/internal/core/domain"
+	"leeta/internal/core/errcode"
+	"leeta/internal/core/port"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// syncMaxChanges is the number of changes kept in a sync report, the counts cover the others
+	syncMaxChanges = 1000
+
+	// defaultSyncReports is the number of sync reports listed when no limit is given
+	defaultSyncReports = 20
+)
+
+var (
+	errSyncSourceNotFound = domain.NewCodedCError(errcode.SyncSourceNotFound, "sync source not found")
+	errSyncReportNotFound = domain.NewCodedCError(errcode.SyncReportNotFound, "sync report not found")
+)
+
+// syncSource is a registered source and how its syncs run
+type syncSource struct {
+	source port.LocationSource
+	dryRun bool
+	// maxRemoved is the share of the approved locations of the source a sync may remove
+	maxRemoved float64
+}
+
+// syncPlan is a change a sync makes to match its source. Location is the stored location, nil for a
+// create, and Request is what the source lists, nil for a remove
+type syncPlan struct {
+	action   domain.SyncAction
+	location *domain.Location
+	request  *domain.RegisterLocationRequest
+	// update is set when a restored location also changed
+	update bool
+}
+
+/**
+ * SyncService implements port.SyncService interface.
+ * Each source owns the locations synced from it, they
+ * are matched to its records by name, and the locations
+ * it no longer lists are removed rather than deleted
+ */
+type SyncService struct {
+	repo      port.SyncRepository
+	locations port.LocationRepository
+	notifier  port.Notifier
+	sources   map[string]syncSource
+}
+
+// NewSyncService creates a new sync service instance. Failed syncs are
+// reported to notifier, or not at all if it is nil
+func NewSyncService(repo port.SyncRepository, locations port.LocationRepository, notifier port.Notifier) *SyncService {
+	return &SyncService{
+		repo,
+		locations,
+		notifier,
+		make(map[string]syncSource),
+	}
+}
+
+// Register adds the source synced by name. A dry run only reports the changes, and a sync removing
+// more than maxRemoved of the approved locations of the source fails, 1 lifts the guard
+func (ss *SyncService) Register(name string, source port.LocationSource, dryRun bool, maxRemoved float64) {
+	ss.sources[name] = syncSource{source, dryRun, maxRemoved}
+}
+
+func (ss *SyncService) Sync(ctx context.Context, name string) (*domain.SyncReport, domain.CError) {
+	source, ok := ss.sources[name]
+	if !ok {
+		return nil, errSyncSourceNotFound
+	}
+
+	report, cerr := ss.repo.CreateSyncReport(ctx, &domain.SyncReport{
+		Source: name,
+		DryRun: source.dryRun,
+		Status: domain.SyncRunning,
+	})
+	if cerr != nil {
+		if isUnavailable(cerr) {
+			return nil, cerr
+		}
+
+		logger.FromCtx(ctx).Error("Error creating sync report", zap.Error(cerr))
+		return nil, domain.ErrInternal
+	}
+
+	report.Changes = []domain.SyncChange{}
+	if err := ss.sync(ctx, name, &source, report); err != nil {
+		report.Status = domain.SyncFailed
+		report.Error = err.Error()
+
+		notify(ctx, ss.notifier, &domain.Notification{
+			Type:  domain.NotificationSyncFailed,
+			Title: "Location sync failed",
+			Text:  err.Error(),
+			Fields: []domain.NotificationField{
+				{Name: "Source", Value: name},
+				{Name: "Sync", Value: report.ID},
+			},
+		})
+	} else {
+		report.Status = domain.SyncSucceeded
+	}
+
+	// the sync may have timed out, its outcome is still recorded
+	if cerr := ss.repo.FinishSyncReport(context.WithoutCancel(ctx), report); cerr != nil {
+		logger.FromCtx(ctx).Error("Error finishing sync report", zap.Error(cerr))
+		return nil, domain.ErrInternal
+	}
+
+	logger.FromCtx(ctx).Info("Finished syncing locations",
+		zap.String("source", name), zap.String("status", string(report.Status)),
+		zap.Int("created", report.Created), zap.Int("updated", report.Updated),
+		zap.Int("removed", report.Removed), zap.Int("restored", report.Restored),
+		zap.Int("failed", report.Failed))
+
+	return report, nil
+}
+
+// sync fetches the locations of the source, plans the changes matching them and makes them unless
+// it is a dry run. It fails without making any change if the source or the stored locations cannot
+// be read or the plan removes too many locations, a change that fails is counted and the others made
+func (ss *SyncService) sync(ctx context.Context, name string, source *syncSource, report *domain.SyncReport) error {
+	requests, err := source.source.FetchLocations(ctx)
+	if err != nil {
+		return fmt.Errorf("fetching locations: %w", err)
+	}
+	report.Total = len(requests)
+
+	stored, cerr := ss.repo.ListSourceLocations(ctx, name)
+	if cerr != nil {
+		return fmt.Errorf("listing locations: %w", cerr)
+	}
+
+	plans, err := planSync(stored, requests)
+	if err != nil {
+		return err
+	}
+	report.Unchanged = len(requests)
+
+	var approved, removed int
+	for i := range stored {
+		if stored[i].Status == domain.LocationApproved {
+			approved++
+		}
+	}
+	for i := range plans {
+		if plans[i].action == domain.SyncRemove {
+			removed++
+		}
+	}
+	if approved > 0 && float64(removed) > source.maxRemoved*float64(approved) {
+		return fmt.Errorf("sync would remove %d of %d locations, more than allowed", removed, approved)
+	}
+
+	for i := range plans {
+		plan := &plans[i]
+		change := domain.SyncChange{Action: plan.action, Name: plan.name()}
+
+		if !source.dryRun {
+			if err := ss.apply(ctx, name, plan); err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				change.Error = err.Error()
+			}
+		}
+
+		if change.Error != "" {
+			report.Failed++
+		} else {
+			switch plan.action {
+			case domain.SyncCreate:
+				report.Created++
+			case domain.SyncUpdate:
+				report.Updated++
+			case domain.SyncRemove:
+				report.Removed++
+			case domain.SyncRestore:
+				report.Restored++
+			}
+		}
+		if plan.action != domain.SyncRemove {
+			report.Unchanged--
+		}
+
+		if len(report.Changes) < syncMaxChanges {
+			report.Changes = append(report.Changes, change)
+		}
+	}
+
+	return nil
+}
+
+// apply makes a planned change
+func (ss *SyncService) apply(ctx context.Context, name string, plan *syncPlan) domain.CError {
+	switch plan.action {
+	case domain.SyncCreate:
+		location := syncedLocation(plan.request)
+		location.Source = name
+		_, cerr := ss.locations.CreateLocation(ctx, location)
+		return cerr
+
+	case domain.SyncRemove:
+		_, cerr := ss.repo.SetLocationStatus(ctx, plan.location.ID, domain.LocationApproved, domain.LocationRemoved)
+		return cerr
+
+	case domain.SyncRestore:
+		location, cerr := ss.repo.SetLocationStatus(ctx, plan.location.ID, domain.LocationRemoved, domain.LocationApproved)
+		if cerr != nil || !plan.update {
+			return cerr
+		}
+		plan.location = location
+	}
+
+	location := syncedLocation(plan.request)
+	location.Version = plan.location.Version
+	_, cerr := ss.locations.UpdateLocation(ctx, plan.location.Slug, location)
+	return cerr
+}
+
+func (p *syncPlan) name() string {
+	if p.request != nil {
+		return p.request.Name
+	}
+	return p.location.Name
+}
+
+// planSync plans the changes bringing the stored locations of a source in line with the locations it
+// lists, matched by name ignoring case. A source listing a name twice is broken and fails the sync
+func planSync(stored []domain.Location, requests []domain.RegisterLocationRequest) ([]syncPlan, error) {
+	byName := make(map[string]*domain.Location, len(stored))
+	for i := range stored {
+		byName[strings.ToLower(stored[i].Name)] = &stored[i]
+	}
+
+	var plans []syncPlan
+	listed := make(map[string]bool, len(requests))
+	for i := range requests {
+		request := &requests[i]
+
+		key := strings.ToLower(request.Name)
+		if listed[key] {
+			return nil, fmt.Errorf("source lists %q more than once", request.Name)
+		}
+		listed[key] = true
+
+		names, cerr := canonicalNames(request.Names)
+		if cerr != nil {
+			return nil, fmt.Errorf("location %q: %s", request.Name, cerr.Error())
+		}
+		request.Names = names
+
+		location, ok := byName[key]
+		switch {
+		case !ok:
+			plans = append(plans, syncPlan{action: domain.SyncCreate, request: request})
+		case location.Status == domain.LocationRemoved:
+			plans = append(plans, syncPlan{domain.SyncRestore, location, request, syncChanged(location, request)})
+		case syncChanged(location, request):
+			plans = append(plans, syncPlan{action: domain.SyncUpdate, location: location, request: request})
+		}
+	}
+
+	for i := range stored {
+		if stored[i].Status == domain.LocationApproved && !listed[strings.ToLower(stored[i].Name)] {
+			plans = append(plans, syncPlan{action: domain.SyncRemove, location: &stored[i]})
+		}
+	}
+
+	return plans, nil
+}
+
+// syncedLocation is the location a source lists. Its names and entrances are always set, so an
+// update clears the ones the source no longer lists
+func syncedLocation(request *domain.RegisterLocationRequest) *domain.Location {
+	location := &domain.Location{
+		Name:       request.Name,
+		Latitude:   request.Latitude,
+		Longitude:  request.Longitude,
+		Altitude:   request.Altitude,
+		Category:   request.Category,
+		Names:      request.Names,
+		Entrances:  request.Entrances,
+		Footprint:  request.Footprint,
+		ValidFrom:  request.ValidFrom,
+		ValidUntil: request.ValidUntil,
+	}
+	if location.Names == nil {
+		location.Names = map[string]string{}
+	}
+	if location.Entrances == nil {
+		location.Entrances = []domain.Entrance{}
+	}
+	return location
+}
+
+// syncChanged reports whether the source lists a location differently from how it is stored
+func syncChanged(location *domain.Location, request *domain.RegisterLocationRequest) bool {
+	if location.Name != request.Name || location.Category != request.Category ||
+		!sameCoordinate(location.Latitude, request.Latitude) || !sameCoordinate(location.Longitude, request.Longitude) {
+		return true
+	}
+
+	if (location.Altitude == nil) != (request.Altitude == nil) ||
+		(location.Altitude != nil && *location.Altitude != *request.Altitude) {
+		return true
+	}
+
+	if len(location.Names) != len(request.Names) || (len(request.Names) > 0 && !reflect.DeepEqual(location.Names, request.Names)) {
+		return true
+	}
+	if len(location.Entrances) != len(request.Entrances) ||
+		(len(request.Entrances) > 0 && !reflect.DeepEqual(location.Entrances, request.Entrances)) {
+		return true
+	}
+
+	if !sameTime(location.ValidFrom, request.ValidFrom) || !sameTime(location.ValidUntil, request.ValidUntil) {
+		return true
+	}
+
+	return !samePolygon(location.Footprint, request.Footprint)
+}
+
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	// timestamps are stored to the microsecond
+	return a.Truncate(time.Microsecond).Equal(b.Truncate(time.Microsecond))
+}
+
+// sameCoordinate tells apart coordinates further than the precision PostGIS writes GeoJSON with
+func sameCoordinate(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func samePolygon(a, b *domain.Polygon) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	if len(a.Coordinates) != len(b.Coordinates) {
+		return false
+	}
+
+	for i := range a.Coordinates {
+		if len(a.Coordinates[i]) != len(b.Coordinates[i]) {
+			return false
+		}
+		for j := range a.Coordinates[i] {
+			// footprints are stored in two dimensions, a third one is dropped
+			p, q := a.Coordinates[i][j], b.Coordinates[i][j]
+			if !sameCoordinate(p[0], q[0]) || !sameCoordinate(p[1], q[1]) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func (ss *SyncService) GetSyncReport(ctx context.Context, id string) (*domain.SyncReport, domain.CError) {
+	report, cerr := ss.repo.GetSyncReport(ctx, id)
+	if cerr != nil {
+		if cerr.Code() == 404 {
+			return nil, errSyncReportNotFound
+		}
+		if isUnavailable(cerr) {
+			return nil, cerr
+		}
+
+		logger.FromCtx(ctx).Error("Error getting sync report", zap.Error(cerr))
+		return nil, domain.ErrInternal
+	}
+
+	return report, nil
+}
+
+func (ss *SyncService) ListSyncReports(ctx context.Context, req *domain.ListSyncReportsRequest) ([]domain.SyncReport, domain.CError) {
+	if req.Limit == 0 {
+		req.Limit = defaultSyncReports
+	}
+
+	reports, cerr := ss.repo.ListSyncReports(ctx, req)
+	if cerr != nil {
+		if isUnavailable(cerr) {
+			return nil, cerr
+		}
+
+		logger.FromCtx(ctx).Error("Error listing sync reports", zap.Error(cerr))
+		return nil, domain.ErrInternal
+	}
+
+	return reports, nil
+}
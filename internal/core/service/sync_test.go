@@ -0,0 +1,189 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"leeta/internal/core/domain"
+	"leeta/internal/core/port/mock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncService_Sync(t *testing.T) {
+	stored := func() []domain.Location {
+		return []domain.Location{
+			{ID: "1", Name: "Lekki Depot", Slug: "lekki-depot", Latitude: 6.4474, Longitude: 3.4723, Version: 2, Status: domain.LocationApproved},
+			{ID: "2", Name: "Ikeja Depot", Slug: "ikeja-depot", Latitude: 6.6018, Longitude: 3.3515, Version: 1, Status: domain.LocationApproved},
+			{ID: "3", Name: "Yaba Depot", Slug: "yaba-depot", Latitude: 6.5095, Longitude: 3.3711, Version: 1, Status: domain.LocationApproved},
+			{ID: "4", Name: "Ajah Depot", Slug: "ajah-depot", Latitude: 6.4698, Longitude: 3.5852, Version: 3, Status: domain.LocationRemoved},
+		}
+	}
+	fetched := func() []domain.RegisterLocationRequest {
+		return []domain.RegisterLocationRequest{
+			// unchanged
+			{Name: "Lekki Depot", Latitude: 6.4474, Longitude: 3.4723},
+			// moved
+			{Name: "ikeja depot", Latitude: 6.6020, Longitude: 3.3515},
+			// listed again
+			{Name: "Ajah Depot", Latitude: 6.4698, Longitude: 3.5852},
+			// new
+			{Name: "Surulere Depot", Latitude: 6.5059, Longitude: 3.3509, Category: "depot"},
+		}
+	}
+
+	setup := func() (*mock.SyncRepositoryMock, *mock.LocationRepositoryMock, *mock.NotifierMock) {
+		repo := &mock.SyncRepositoryMock{
+			CreateSyncReportFunc: func(ctx context.Context, report *domain.SyncReport) (*domain.SyncReport, domain.CError) {
+				created := *report
+				created.ID = "sync-1"
+				return &created, nil
+			},
+			FinishSyncReportFunc: func(ctx context.Context, report *domain.SyncReport) domain.CError {
+				return nil
+			},
+			ListSourceLocationsFunc: func(ctx context.Context, source string) ([]domain.Location, domain.CError) {
+				return stored(), nil
+			},
+			SetLocationStatusFunc: func(ctx context.Context, id string, from, to domain.LocationStatus) (*domain.Location, domain.CError) {
+				return &domain.Location{ID: id, Status: to, Version: 4}, nil
+			},
+		}
+		locations := &mock.LocationRepositoryMock{
+			CreateLocationFunc: func(ctx context.Context, location *domain.Location) (*domain.Location, domain.CError) {
+				return location, nil
+			},
+			UpdateLocationFunc: func(ctx context.Context, name string, location *domain.Location) (*domain.Location, domain.CError) {
+				return location, nil
+			},
+		}
+		notifier := &mock.NotifierMock{
+			NotifyFunc: func(ctx context.Context, notification *domain.Notification) {},
+		}
+		return repo, locations, notifier
+	}
+	source := func(requests []domain.RegisterLocationRequest, err error) *mock.LocationSourceMock {
+		return &mock.LocationSourceMock{
+			FetchLocationsFunc: func(ctx context.Context) ([]domain.RegisterLocationRequest, error) {
+				return requests, err
+			},
+		}
+	}
+
+	t.Run("Changes are made", func(t *testing.T) {
+		repo, locations, notifier := setup()
+		ss := NewSyncService(repo, locations, notifier)
+		ss.Register("erp", source(fetched(), nil), false, 0.5)
+
+		report, cerr := ss.Sync(context.Background(), "erp")
+		require.Nil(t, cerr)
+
+		assert.Equal(t, domain.SyncSucceeded, report.Status)
+		assert.Equal(t, 4, report.Total)
+		assert.Equal(t, 1, report.Created)
+		assert.Equal(t, 1, report.Updated)
+		assert.Equal(t, 1, report.Removed)
+		assert.Equal(t, 1, report.Restored)
+		assert.Equal(t, 1, report.Unchanged)
+		assert.Equal(t, []domain.SyncChange{
+			{Action: domain.SyncUpdate, Name: "ikeja depot"},
+			{Action: domain.SyncRestore, Name: "Ajah Depot"},
+			{Action: domain.SyncCreate, Name: "Surulere Depot"},
+			{Action: domain.SyncRemove, Name: "Yaba Depot"},
+		}, report.Changes)
+
+		created := locations.CreateLocationCalls()[0].Location
+		assert.Equal(t, "erp", created.Source)
+		assert.Equal(t, "depot", created.Category)
+
+		update := locations.UpdateLocationCalls()[0]
+		assert.Equal(t, "ikeja-depot", update.Name)
+		assert.Equal(t, int64(1), update.Location.Version)
+		assert.Equal(t, 6.6020, update.Location.Latitude)
+
+		statuses := repo.SetLocationStatusCalls()
+		require.Len(t, statuses, 2)
+		assert.Equal(t, "4", statuses[0].ID)
+		assert.Equal(t, domain.LocationApproved, statuses[0].To)
+		assert.Equal(t, "3", statuses[1].ID)
+		assert.Equal(t, domain.LocationRemoved, statuses[1].To)
+
+		assert.Len(t, repo.FinishSyncReportCalls(), 1)
+		assert.Empty(t, notifier.NotifyCalls())
+	})
+
+	t.Run("Dry run makes no change", func(t *testing.T) {
+		repo, locations, notifier := setup()
+		ss := NewSyncService(repo, locations, notifier)
+		ss.Register("erp", source(fetched(), nil), true, 0.5)
+
+		report, cerr := ss.Sync(context.Background(), "erp")
+		require.Nil(t, cerr)
+
+		assert.True(t, report.DryRun)
+		assert.Equal(t, 1, report.Created)
+		assert.Len(t, report.Changes, 4)
+		assert.Empty(t, locations.CreateLocationCalls())
+		assert.Empty(t, locations.UpdateLocationCalls())
+		assert.Empty(t, repo.SetLocationStatusCalls())
+	})
+
+	t.Run("Failed change is counted", func(t *testing.T) {
+		repo, locations, notifier := setup()
+		locations.CreateLocationFunc = func(ctx context.Context, location *domain.Location) (*domain.Location, domain.CError) {
+			return nil, domain.ErrConflictingData
+		}
+		ss := NewSyncService(repo, locations, notifier)
+		ss.Register("erp", source(fetched(), nil), false, 0.5)
+
+		report, cerr := ss.Sync(context.Background(), "erp")
+		require.Nil(t, cerr)
+
+		assert.Equal(t, domain.SyncSucceeded, report.Status)
+		assert.Equal(t, 0, report.Created)
+		assert.Equal(t, 1, report.Failed)
+		assert.Equal(t, domain.ErrConflictingData.Error(), report.Changes[2].Error)
+	})
+
+	t.Run("Too many removals fail the sync", func(t *testing.T) {
+		repo, locations, notifier := setup()
+		ss := NewSyncService(repo, locations, notifier)
+		ss.Register("erp", source(fetched()[3:], nil), false, 0.5)
+
+		report, cerr := ss.Sync(context.Background(), "erp")
+		require.Nil(t, cerr)
+
+		assert.Equal(t, domain.SyncFailed, report.Status)
+		assert.Contains(t, report.Error, "remove 3 of 3")
+		assert.Empty(t, repo.SetLocationStatusCalls())
+		assert.Empty(t, locations.CreateLocationCalls())
+
+		require.Len(t, notifier.NotifyCalls(), 1)
+		assert.Equal(t, domain.NotificationSyncFailed, notifier.NotifyCalls()[0].Notification.Type)
+	})
+
+	t.Run("Source error fails the sync", func(t *testing.T) {
+		repo, locations, notifier := setup()
+		ss := NewSyncService(repo, locations, notifier)
+		ss.Register("erp", source(nil, errors.New("source answered with status 503")), false, 0.5)
+
+		report, cerr := ss.Sync(context.Background(), "erp")
+		require.Nil(t, cerr)
+
+		assert.Equal(t, domain.SyncFailed, report.Status)
+		assert.Contains(t, report.Error, "status 503")
+		assert.Len(t, repo.FinishSyncReportCalls(), 1)
+		assert.Len(t, notifier.NotifyCalls(), 1)
+	})
+
+	t.Run("Unknown source", func(t *testing.T) {
+		repo, locations, notifier := setup()
+		ss := NewSyncService(repo, locations, notifier)
+
+		_, cerr := ss.Sync(context.Background(), "erp")
+		require.NotNil(t, cerr)
+		assert.Equal(t, 404, cerr.Code())
+	})
+}
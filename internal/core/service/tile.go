@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"leeta/internal/adapter/logger"
+	"leeta/internal/core/domain"
+	"leeta/internal/core/port"
+
+	"go.uber.org/zap"
+)
+
+/**
+ * TileService implements port.TileService interface.
+ * The tiles are rendered by the database on every request,
+ * caching them is left to the HTTP caches in front of the API
+ */
+type TileService struct {
+	repo port.TileRepository
+}
+
+// NewTileService creates a new tile service instance
+func NewTileService(repo port.TileRepository) *TileService {
+	return &TileService{
+		repo,
+	}
+}
+
+func (ts *TileService) GetLocationTile(ctx context.Context, req *domain.TileRequest) ([]byte, domain.CError) {
+	if !req.Exists() {
+		return nil, domain.NewBadRequestCError(fmt.Sprintf("tile %d/%d/%d does not exist, zoom levels go up to %d and have 2^zoom columns and rows", req.Z, req.X, req.Y, domain.MaxTileZoom))
+	}
+
+	tile, cerr := ts.repo.LocationTile(ctx, req)
+	if cerr != nil {
+		if cerr.Code() == 400 || isUnavailable(cerr) {
+			return nil, cerr
+		}
+
+		logger.FromCtx(ctx).Error("Error rendering location tile", zap.Error(cerr))
+		return nil, domain.ErrInternal
+	}
+
+	return tile, nil
+}
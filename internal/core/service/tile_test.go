@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"leeta/internal/core/domain"
+	"leeta/internal/core/port/mock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTileService_GetLocationTile(t *testing.T) {
+	newRepo := func(tile []byte, cerr domain.CError) *mock.TileRepositoryMock {
+		return &mock.TileRepositoryMock{
+			LocationTileFunc: func(ctx context.Context, req *domain.TileRequest) ([]byte, domain.CError) {
+				return tile, cerr
+			},
+		}
+	}
+
+	t.Run("Tile", func(t *testing.T) {
+		repo := newRepo([]byte{0x1a, 0x02}, nil)
+
+		tile, cerr := NewTileService(repo).GetLocationTile(context.Background(), &domain.TileRequest{Z: 12, X: 2084, Y: 1963})
+		require.Nil(t, cerr)
+		assert.Equal(t, []byte{0x1a, 0x02}, tile)
+		assert.Len(t, repo.LocationTileCalls(), 1)
+	})
+
+	t.Run("Tiles off the grid", func(t *testing.T) {
+		repo := newRepo(nil, nil)
+
+		for _, req := range []domain.TileRequest{
+			{Z: 0, X: 1, Y: 0},
+			{Z: 2, X: 0, Y: 4},
+			{Z: 3, X: -1, Y: 0},
+			{Z: domain.MaxTileZoom + 1},
+		} {
+			_, cerr := NewTileService(repo).GetLocationTile(context.Background(), &req)
+			require.NotNil(t, cerr, "tile %d/%d/%d", req.Z, req.X, req.Y)
+			assert.Equal(t, 400, cerr.Code())
+		}
+		assert.Empty(t, repo.LocationTileCalls())
+	})
+
+	t.Run("Invalid filter", func(t *testing.T) {
+		_, cerr := NewTileService(newRepo(nil, domain.NewBadRequestCError("unknown field"))).GetLocationTile(context.Background(), &domain.TileRequest{Query: "colour:red"})
+		require.NotNil(t, cerr)
+		assert.Equal(t, 400, cerr.Code())
+	})
+
+	t.Run("Database error", func(t *testing.T) {
+		_, cerr := NewTileService(newRepo(nil, domain.WrapCError(domain.ErrInternal, errors.New("boom"), domain.ErrorContext{}))).GetLocationTile(context.Background(), &domain.TileRequest{})
+		assert.Equal(t, domain.ErrInternal, cerr)
+
+		_, cerr = NewTileService(newRepo(nil, domain.ErrTimeout)).GetLocationTile(context.Background(), &domain.TileRequest{})
+		assert.Equal(t, domain.ErrTimeout, cerr)
+	})
+}
@@ -0,0 +1,147 @@
+// Package slugger turns names into the slugs locations, collections, aliases and geofences are addressed by
+package slugger
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/gosimple/slug"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Collisions is how a slug already taken by another record is told apart
+type Collisions string
+
+const (
+	// CollisionsNone leaves the slug as is, the record is refused as conflicting, or skipped by imports
+	CollisionsNone Collisions = "none"
+	// CollisionsCounter appends the first free counter from 2, as in lodz-centrum-2
+	CollisionsCounter Collisions = "counter"
+	// CollisionsHash appends a short hash of the name, as in lodz-centrum-3f2a9c, so the slug does not depend on
+	// the order the records were created in
+	CollisionsHash Collisions = "hash"
+)
+
+const (
+	// MaxLength is the longest slug the slug columns hold
+	MaxLength = 255
+	// MinLength is the shortest maximum length leaving room for the suffixes of the collision strategies
+	MinLength = 16
+	// hashLength is the number of hexadecimal digits of the hash appended by CollisionsHash
+	hashLength = 6
+)
+
+// ParseCollisions parses none, counter or hash, an empty strategy is none. It reports false for anything else
+func ParseCollisions(s string) (Collisions, bool) {
+	switch collisions := Collisions(s); collisions {
+	case "":
+		return CollisionsNone, true
+	case CollisionsNone, CollisionsCounter, CollisionsHash:
+		return collisions, true
+	}
+	return "", false
+}
+
+// Slugger makes the slugs of names. Making the slug of a slug returns it unchanged, so records are looked up by
+// slug by making the slug of the name looked up
+type Slugger struct {
+	unicode    bool
+	maxLength  int
+	collisions Collisions
+}
+
+// New creates a slugger. Names are transliterated to ASCII, so Łódź Centrum becomes lodz-centrum, unless unicode is
+// set, which keeps the letters of every script, as in łódź-centrum. Slugs longer than maxLength characters are cut
+// at a word when possible, 0 does not limit them. A collisions strategy that is not known is none
+func New(unicode bool, maxLength int, collisions Collisions) *Slugger {
+	if _, ok := ParseCollisions(string(collisions)); !ok || collisions == "" {
+		collisions = CollisionsNone
+	}
+
+	return &Slugger{
+		unicode,
+		max(maxLength, 0),
+		collisions,
+	}
+}
+
+// Default is the slugger of the zero configuration, transliterating without limit or collision strategy
+var Default = New(false, 0, CollisionsNone)
+
+// Make returns the slug of name
+func (s *Slugger) Make(name string) string {
+	var made string
+	if s.unicode {
+		made = unicodeSlug(name)
+	} else {
+		made = slug.Make(name)
+	}
+	return truncate(made, s.maxLength)
+}
+
+// Resolves reports whether the slugger tells apart the slugs already taken
+func (s *Slugger) Resolves() bool {
+	return s.collisions != CollisionsNone
+}
+
+// Alternative returns the slug of name to try after base, its slug, and attempt-1 alternatives were taken, from
+// attempt 1. It returns base with CollisionsNone. The alternatives keep within the maximum length by cutting base
+func (s *Slugger) Alternative(base, name string, attempt int) string {
+	var suffix string
+	switch s.collisions {
+	case CollisionsCounter:
+		suffix = "-" + strconv.Itoa(attempt+1)
+	case CollisionsHash:
+		sum := sha256.Sum256([]byte(strings.ToLower(name)))
+		suffix = "-" + hex.EncodeToString(sum[:])[:hashLength]
+		if attempt > 1 {
+			suffix += "-" + strconv.Itoa(attempt)
+		}
+	default:
+		return base
+	}
+
+	if s.maxLength > 0 {
+		base = truncate(base, s.maxLength-len(suffix))
+	}
+	return base + suffix
+}
+
+// unicodeSlug lowercases the letters and digits of name, in any script, and joins the words with dashes
+func unicodeSlug(name string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range norm.NFC.String(name) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r):
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(unicode.ToLower(r))
+			dash = false
+		default:
+			dash = true
+		}
+	}
+	return b.String()
+}
+
+// truncate cuts slug to maxLength characters, at the last dash within them when there is one, 0 does not limit it
+func truncate(slug string, maxLength int) string {
+	runes := []rune(slug)
+	if maxLength <= 0 || len(runes) <= maxLength {
+		return slug
+	}
+
+	cut := string(runes[:maxLength])
+	// a dash right after the cut means the cut fell between two words
+	if runes[maxLength] != '-' {
+		if i := strings.LastIndexByte(cut, '-'); i > 0 {
+			cut = cut[:i]
+		}
+	}
+	return strings.Trim(cut, "-")
+}
@@ -0,0 +1,84 @@
+package slugger
+
+import (
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMake(t *testing.T) {
+	tests := []struct {
+		name      string
+		unicode   bool
+		maxLength int
+		slug      string
+	}{
+		{"Łódź Centrum", false, 0, "lodz-centrum"},
+		{"Київ Центр", false, 0, "kiyiv-tsentr"},
+		{"Café & Bar", false, 0, "cafe-and-bar"},
+		{"Łódź Centrum", true, 0, "łódź-centrum"},
+		{"Київ — Центр!", true, 0, "київ-центр"},
+		{"東京駅 (Tokyo)", true, 0, "東京駅-tokyo"},
+		// the decomposed é is composed first
+		{"Café Bar", true, 0, "café-bar"},
+		{"Murtala Muhammed International Airport", false, 20, "murtala-muhammed"},
+		{"Murtala Muhammed International Airport", false, 16, "murtala-muhammed"},
+		{"Supercalifragilistic Depot", false, 10, "supercalif"},
+		{"Łódź Centrum", true, 8, "łódź"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.slug, func(t *testing.T) {
+			s := New(tt.unicode, tt.maxLength, CollisionsNone)
+			slug := s.Make(tt.name)
+			assert.Equal(t, tt.slug, slug)
+
+			// slugs are looked up by making the slug of the slug
+			assert.Equal(t, slug, s.Make(slug))
+		})
+	}
+}
+
+func TestAlternative(t *testing.T) {
+	t.Run("Counter", func(t *testing.T) {
+		s := New(false, 0, CollisionsCounter)
+		assert.True(t, s.Resolves())
+		assert.Equal(t, "lodz-centrum-2", s.Alternative("lodz-centrum", "Łódź Centrum", 1))
+		assert.Equal(t, "lodz-centrum-3", s.Alternative("lodz-centrum", "Łódź Centrum", 2))
+	})
+
+	t.Run("Hash", func(t *testing.T) {
+		s := New(false, 0, CollisionsHash)
+		first := s.Alternative("lodz-centrum", "Łódź Centrum", 1)
+		assert.Regexp(t, `^lodz-centrum-[0-9a-f]{6}$`, first)
+		assert.Equal(t, first, s.Alternative("lodz-centrum", "łódź centrum", 1), "the hash ignores case, like the names")
+		assert.NotEqual(t, first, s.Alternative("lodz-centrum", "Lodz Centrum", 1))
+		assert.Equal(t, first+"-2", s.Alternative("lodz-centrum", "Łódź Centrum", 2))
+		assert.Equal(t, first, s.Make(first))
+	})
+
+	t.Run("Within the maximum length", func(t *testing.T) {
+		s := New(false, 16, CollisionsCounter)
+		base := s.Make("Murtala Muhammed International Airport")
+		alternative := s.Alternative(base, "Murtala Muhammed International Airport", 1)
+		assert.Equal(t, "murtala-2", alternative)
+		assert.LessOrEqual(t, utf8.RuneCountInString(alternative), 16)
+	})
+
+	t.Run("None", func(t *testing.T) {
+		s := New(false, 0, "")
+		assert.False(t, s.Resolves())
+		assert.Equal(t, "lodz-centrum", s.Alternative("lodz-centrum", "Łódź Centrum", 1))
+	})
+}
+
+func TestParseCollisions(t *testing.T) {
+	for _, s := range []string{"", "none", "counter", "hash"} {
+		_, ok := ParseCollisions(s)
+		assert.True(t, ok, s)
+	}
+
+	_, ok := ParseCollisions("uuid")
+	assert.False(t, ok)
+}